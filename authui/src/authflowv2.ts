@@ -41,6 +41,9 @@ import { InlinePreviewController } from "./inline-preview";
 import { PreviewableResourceController } from "./previewable-resource";
 import { CloudflareTurnstileController } from "./authflowv2/botprotection/cloudflareTurnstile";
 import { RecaptchaV2Controller } from "./authflowv2/botprotection/recaptchav2";
+import { RecaptchaV3Controller } from "./authflowv2/botprotection/recaptchav3";
+import { HCaptchaController } from "./authflowv2/botprotection/hcaptcha";
+import { PoWController } from "./authflowv2/botprotection/pow";
 import { BotProtectionTokenInputController } from "./authflowv2/botprotection/botProtectionTokenInput";
 import { BotProtectionStandalonePageSubmitBtnController } from "./authflowv2/botprotection/botProtectionStandalonePageSubmitBtn";
 import { BotProtectionController } from "./authflowv2/botprotection/botProtection";
@@ -154,6 +157,9 @@ Stimulus.register(
 );
 Stimulus.register("cloudflare-turnstile", CloudflareTurnstileController);
 Stimulus.register("recaptcha-v2", RecaptchaV2Controller);
+Stimulus.register("recaptcha-v3", RecaptchaV3Controller);
+Stimulus.register("hcaptcha", HCaptchaController);
+Stimulus.register("pow", PoWController);
 Stimulus.register("bot-protection", BotProtectionController);
 Stimulus.register("bot-protection-dialog", BotProtectionDialogController);
 Stimulus.register("select-input", SelectInputController);
@@ -0,0 +1,117 @@
+import { Controller } from "@hotwired/stimulus";
+import { getColorScheme } from "../../getColorScheme";
+import {
+  dispatchBotProtectionEventExpired,
+  dispatchBotProtectionEventFailed,
+  dispatchBotProtectionEventVerified,
+} from "./botProtection";
+import { setErrorMessage } from "../alert-message";
+import { dispatchBotProtectionWidgetEventReadyForRender } from "./botProtectionWidget";
+
+function parseTheme(theme: string): "light" | "dark" | undefined {
+  switch (theme) {
+    case "light":
+      return "light";
+    case "dark":
+      return "dark";
+    default:
+      return undefined;
+  }
+}
+
+const HCAPTCHA_ERROR_MSG_ID = "data-bot-protection-hcaptcha";
+
+export class HCaptchaController extends Controller {
+  static values = {
+    siteKey: { type: String },
+  };
+
+  static targets = ["widget"];
+
+  declare siteKeyValue: string;
+  declare widgetTarget: HTMLDivElement;
+  declare widgetContainer: HTMLDivElement | undefined;
+  declare widgetID: string | undefined;
+
+  hasExistingWidget = () => {
+    return this.widgetContainer != null && this.widgetID != null;
+  };
+  resetWidget = () => {
+    if (!this.hasExistingWidget()) {
+      return;
+    }
+    window.hcaptcha.reset(this.widgetID);
+  };
+
+  renderWidget = () => {
+    if (this.hasExistingWidget()) {
+      return;
+    }
+    const colorScheme = getColorScheme();
+
+    // Note how we wrap an extra layer of div here, because on cleanup we can just remove this extra layer of div.
+    // container-container
+    //   container <-- can just remove this on cleanup
+    //     widget
+    const widgetContainer = document.createElement("div");
+    this.widgetContainer = widgetContainer;
+    this.widgetTarget.appendChild(widgetContainer);
+    const widgetID = window.hcaptcha.render(widgetContainer, {
+      sitekey: this.siteKeyValue,
+      theme: parseTheme(colorScheme),
+      callback: (token: string) => {
+        dispatchBotProtectionEventVerified(token);
+      },
+      "error-callback": (error: string) => {
+        console.error("Something went wrong with hCaptcha.", error);
+        setErrorMessage(HCAPTCHA_ERROR_MSG_ID);
+        dispatchBotProtectionEventFailed(error);
+      },
+      "expired-callback": () => {
+        this.resetWidget();
+        dispatchBotProtectionEventExpired();
+      },
+      "chalexpired-callback": () => {
+        this.resetWidget();
+        dispatchBotProtectionEventExpired();
+      },
+    });
+    this.widgetID = widgetID;
+  };
+
+  undoRenderWidget = () => {
+    if (this.widgetID != null) {
+      window.hcaptcha.remove(this.widgetID);
+    }
+    this.widgetID = undefined;
+    if (this.widgetContainer != null) {
+      this.widgetTarget.removeChild(this.widgetContainer);
+    }
+    this.widgetContainer = undefined;
+  };
+
+  connect() {
+    // api.js is loaded synchronously in <head>, so it is ready by now.
+    setTimeout(() => dispatchBotProtectionWidgetEventReadyForRender(), 0);
+    document.addEventListener(
+      "bot-protection-widget:render",
+      this.renderWidget
+    );
+    document.addEventListener(
+      "bot-protection-widget:undo-render",
+      this.undoRenderWidget
+    );
+  }
+
+  disconnect() {
+    document.removeEventListener(
+      "bot-protection-widget:render",
+      this.renderWidget
+    );
+    document.removeEventListener(
+      "bot-protection-widget:undo-render",
+      this.undoRenderWidget
+    );
+    this.undoRenderWidget();
+  }
+}
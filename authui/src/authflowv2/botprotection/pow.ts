@@ -0,0 +1,117 @@
+import { Controller } from "@hotwired/stimulus";
+import axios from "axios";
+import {
+  dispatchBotProtectionEventFailed,
+  dispatchBotProtectionEventVerified,
+} from "./botProtection";
+import { setErrorMessage } from "../alert-message";
+import { dispatchBotProtectionWidgetEventReadyForRender } from "./botProtectionWidget";
+
+const POW_ERROR_MSG_ID = "data-bot-protection-pow";
+
+const POW_CHALLENGE_ENDPOINT = "/api/bot_protection/pow/challenge";
+
+interface PoWChallenge {
+  challenge: string;
+  difficulty: number;
+  expire_at: string;
+}
+
+function leadingZeroBits(bytes: Uint8Array): number {
+  let n = 0;
+  for (const b of bytes) {
+    if (b !== 0) {
+      return n + Math.clz32(b) - 24;
+    }
+    n += 8;
+  }
+  return n;
+}
+
+/**
+ * Find a nonce such that SHA-256 of `${challenge}:${nonce}` has at least
+ * `difficulty` leading zero bits, and return the token to be submitted.
+ */
+async function solve(
+  challenge: string,
+  difficulty: number,
+  isCancelled: () => boolean
+): Promise<string | null> {
+  const encoder = new TextEncoder();
+  for (let nonce = 0; ; nonce++) {
+    if (isCancelled()) {
+      return null;
+    }
+    const token = `${challenge}:${nonce}`;
+    const digest = await crypto.subtle.digest("SHA-256", encoder.encode(token));
+    if (leadingZeroBits(new Uint8Array(digest)) >= difficulty) {
+      return token;
+    }
+  }
+}
+
+/**
+ * Controller for the self-hosted proof-of-work provider.
+ *
+ * There is no visible widget. The challenge is fetched and solved
+ * as soon as the widget is asked to render.
+ */
+export class PoWController extends Controller {
+  declare isSolving: boolean;
+  declare isCancelled: boolean;
+
+  renderWidget = () => {
+    if (this.isSolving) {
+      return;
+    }
+    this.isSolving = true;
+    this.isCancelled = false;
+
+    this.run().finally(() => {
+      this.isSolving = false;
+    });
+  };
+
+  undoRenderWidget = () => {
+    this.isCancelled = true;
+  };
+
+  run = async () => {
+    try {
+      const resp = await axios(POW_CHALLENGE_ENDPOINT, { method: "GET" });
+      const { challenge, difficulty } = resp.data.result as PoWChallenge;
+      const token = await solve(challenge, difficulty, () => this.isCancelled);
+      if (token != null) {
+        dispatchBotProtectionEventVerified(token);
+      }
+    } catch (err: unknown) {
+      console.error("Something went wrong with proof-of-work.", err);
+      setErrorMessage(POW_ERROR_MSG_ID);
+      dispatchBotProtectionEventFailed();
+    }
+  };
+
+  connect() {
+    setTimeout(() => dispatchBotProtectionWidgetEventReadyForRender(), 0);
+    document.addEventListener(
+      "bot-protection-widget:render",
+      this.renderWidget
+    );
+    document.addEventListener(
+      "bot-protection-widget:undo-render",
+      this.undoRenderWidget
+    );
+  }
+
+  disconnect() {
+    document.removeEventListener(
+      "bot-protection-widget:render",
+      this.renderWidget
+    );
+    document.removeEventListener(
+      "bot-protection-widget:undo-render",
+      this.undoRenderWidget
+    );
+    this.undoRenderWidget();
+  }
+}
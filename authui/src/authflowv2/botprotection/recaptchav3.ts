@@ -0,0 +1,81 @@
+import { Controller } from "@hotwired/stimulus";
+import {
+  dispatchBotProtectionEventFailed,
+  dispatchBotProtectionEventVerified,
+} from "./botProtection";
+import { setErrorMessage } from "../alert-message";
+import { dispatchBotProtectionWidgetEventReadyForRender } from "./botProtectionWidget";
+
+const RECAPTCHA_V3_ERROR_MSG_ID = "data-bot-protection-recaptcha-v3";
+
+const RECAPTCHA_V3_ACTION = "authenticate";
+
+/**
+ * Controller for reCAPTCHA v3 and reCAPTCHA Enterprise score-based keys.
+ *
+ * There is no visible widget. A token is requested as soon as
+ * the widget is asked to render.
+ */
+export class RecaptchaV3Controller extends Controller {
+  static values = {
+    siteKey: { type: String },
+    enterprise: { type: Boolean },
+  };
+
+  declare siteKeyValue: string;
+  declare enterpriseValue: boolean;
+  declare isReadyForRendering: boolean;
+  declare isExecuting: boolean;
+
+  client = (): ReCaptchaV2.ReCaptcha => {
+    if (this.enterpriseValue) {
+      return window.grecaptcha.enterprise;
+    }
+    return window.grecaptcha;
+  };
+
+  renderWidget = () => {
+    if (!this.isReadyForRendering) {
+      throw new Error("recaptchav3 target is not ready for rendering");
+    }
+
+    if (this.isExecuting) {
+      return;
+    }
+    this.isExecuting = true;
+
+    this.client()
+      .execute(this.siteKeyValue, { action: RECAPTCHA_V3_ACTION })
+      .then(
+        (token: string) => {
+          this.isExecuting = false;
+          dispatchBotProtectionEventVerified(token);
+        },
+        (err: unknown) => {
+          this.isExecuting = false;
+          console.error("Something went wrong with Google reCAPTCHA.", err);
+          setErrorMessage(RECAPTCHA_V3_ERROR_MSG_ID);
+          dispatchBotProtectionEventFailed();
+        }
+      );
+  };
+
+  connect() {
+    this.client().ready(() => {
+      this.isReadyForRendering = true;
+      // Use setTimeout to prevent isReadyForRendering not changed to True yet
+      setTimeout(() => dispatchBotProtectionWidgetEventReadyForRender(), 0);
+    });
+    document.addEventListener(
+      "bot-protection-widget:render",
+      this.renderWidget
+    );
+  }
+
+  disconnect() {
+    document.removeEventListener(
+      "bot-protection-widget:render",
+      this.renderWidget
+    );
+  }
+}
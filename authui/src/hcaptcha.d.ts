@@ -0,0 +1,22 @@
+declare namespace HCaptcha {
+  interface RenderParameters {
+    sitekey: string;
+    theme?: "light" | "dark";
+    size?: "normal" | "compact" | "invisible";
+    tabindex?: number;
+    callback?: (token: string) => void;
+    "expired-callback"?: () => void;
+    "chalexpired-callback"?: () => void;
+    "error-callback"?: (error: string) => void;
+  }
+
+  interface HCaptcha {
+    render(container: string | HTMLElement, params: RenderParameters): string;
+    reset(widgetID?: string): void;
+    remove(widgetID?: string): void;
+  }
+}
+
+declare interface Window {
+  hcaptcha: HCaptcha.HCaptcha;
+}
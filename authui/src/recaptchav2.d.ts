@@ -1,3 +1,4 @@
 declare interface Window {
-  grecaptcha: ReCaptchaV2.ReCaptcha;
+  // reCAPTCHA v2, v3 and Enterprise share the same global object.
+  grecaptcha: ReCaptchaV2.ReCaptcha & { enterprise: ReCaptchaV2.ReCaptcha };
 }
@@ -16,6 +16,8 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountanonymization"
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountdeletion"
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountstatus"
+	"github.com/authgear/authgear-server/pkg/lib/feature/ldapdirectorysync"
+	"github.com/authgear/authgear-server/pkg/lib/feature/webhookdelivery"
 	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/auditdb"
@@ -23,6 +25,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/infra/redis/analyticredis"
 	"github.com/authgear/authgear-server/pkg/lib/infra/redis/appredis"
 	"github.com/authgear/authgear-server/pkg/lib/infra/redis/globalredis"
+	"github.com/authgear/authgear-server/pkg/lib/ldapsync"
 	"github.com/authgear/authgear-server/pkg/lib/web"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
 	"github.com/authgear/authgear-server/pkg/util/resource"
@@ -81,6 +84,22 @@ func (f *AccountStatusServiceFactory) MakeUserService(appID string, appContext *
 	return newUserService(f.BackgroundProvider, appID, appContext)
 }
 
+type LDAPDirectorySyncServiceFactory struct {
+	BackgroundProvider *deps.BackgroundProvider
+}
+
+func (f *LDAPDirectorySyncServiceFactory) MakeSyncService(appID string, appContext *config.AppContext) ldapdirectorysync.SyncService {
+	return newUserService(f.BackgroundProvider, appID, appContext)
+}
+
+type WebhookDeliveryServiceFactory struct {
+	BackgroundProvider *deps.BackgroundProvider
+}
+
+func (f *WebhookDeliveryServiceFactory) MakeDeliveryService(appID string, appContext *config.AppContext) webhookdelivery.DeliveryService {
+	return newWebhookDeliveryService(f.BackgroundProvider, appID, appContext)
+}
+
 type UserFacade interface {
 	DeleteFromScheduledDeletion(ctx context.Context, userID string) error
 	AnonymizeFromScheduledAnonymization(ctx context.Context, userID string) error
@@ -88,8 +107,9 @@ type UserFacade interface {
 }
 
 type UserService struct {
-	AppDBHandle *appdb.Handle
-	UserFacade  UserFacade
+	AppDBHandle       *appdb.Handle
+	UserFacade        UserFacade
+	LDAPDirectorySync *ldapsync.DirectorySyncService
 }
 
 func (s *UserService) DeleteFromScheduledDeletion(ctx context.Context, userID string) (err error) {
@@ -110,6 +130,12 @@ func (s *UserService) RefreshAccountStatus(ctx context.Context, userID string) (
 	})
 }
 
+// SyncDirectory manages its own transactions
+// because searching the directory can take a long time.
+func (s *UserService) SyncDirectory(ctx context.Context) error {
+	return s.LDAPDirectorySync.SyncDirectory(ctx)
+}
+
 var DependencySet = wire.NewSet(
 	deps.BackgroundDependencySet,
 
@@ -131,11 +157,15 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(AccountDeletionServiceFactory), "*"),
 	wire.Struct(new(AccountAnonymizationServiceFactory), "*"),
 	wire.Struct(new(AccountStatusServiceFactory), "*"),
+	wire.Struct(new(LDAPDirectorySyncServiceFactory), "*"),
+	wire.Struct(new(WebhookDeliveryServiceFactory), "*"),
 	wire.Struct(new(UserService), "*"),
 	wire.Bind(new(UserFacade), new(*facade.UserFacade)),
 	wire.Bind(new(accountdeletion.UserServiceFactory), new(*AccountDeletionServiceFactory)),
 	wire.Bind(new(accountanonymization.UserServiceFactory), new(*AccountAnonymizationServiceFactory)),
 	wire.Bind(new(accountstatus.UserServiceFactory), new(*AccountStatusServiceFactory)),
+	wire.Bind(new(ldapdirectorysync.SyncServiceFactory), new(*LDAPDirectorySyncServiceFactory)),
+	wire.Bind(new(webhookdelivery.DeliveryServiceFactory), new(*WebhookDeliveryServiceFactory)),
 	wire.Bind(new(event.Database), new(*appdb.Handle)),
 	wire.Bind(new(fraudprotection.DatabaseHandle), new(*appdb.Handle)),
 	wire.Bind(new(template.ResourceManager), new(*resource.Manager)),
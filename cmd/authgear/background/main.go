@@ -43,6 +43,8 @@ func (c *Controller) Start(ctx context.Context) {
 		newAccountDeletionRunner(ctx, p, configSrcController),
 		newAccountAnonymizationRunner(ctx, p, configSrcController),
 		newAccountStatusRunner(ctx, p, configSrcController),
+		newWebhookDeliveryRunner(ctx, p, configSrcController),
+		newLDAPDirectorySyncRunner(ctx, p, configSrcController),
 	}
 	backgroundjob.Main(ctx, runners)
 }
@@ -13,6 +13,9 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountanonymization"
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountdeletion"
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountstatus"
+	"github.com/authgear/authgear-server/pkg/lib/feature/ldapdirectorysync"
+	"github.com/authgear/authgear-server/pkg/lib/feature/webhookdelivery"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/util/backgroundjob"
 )
 
@@ -49,9 +52,32 @@ func newAccountStatusRunner(ctx context.Context, p *deps.BackgroundProvider, ctr
 	))
 }
 
+func newWebhookDeliveryRunner(ctx context.Context, p *deps.BackgroundProvider, ctrl *configsource.Controller) *backgroundjob.Runner {
+	panic(wire.Build(
+		DependencySet,
+		webhookdelivery.DependencySet,
+		wire.Bind(new(webhookdelivery.AppContextResolver), new(*configsource.Controller)),
+	))
+}
+
+func newLDAPDirectorySyncRunner(ctx context.Context, p *deps.BackgroundProvider, ctrl *configsource.Controller) *backgroundjob.Runner {
+	panic(wire.Build(
+		DependencySet,
+		ldapdirectorysync.DependencySet,
+		wire.Bind(new(ldapdirectorysync.AppContextResolver), new(*configsource.Controller)),
+	))
+}
+
 func newUserService(p *deps.BackgroundProvider, appID string, appContext *config.AppContext) *UserService {
 	panic(wire.Build(
 		DependencySet,
 		wire.FieldsOf(new(*config.AppContext), "Config"),
 	))
 }
+
+func newWebhookDeliveryService(p *deps.BackgroundProvider, appID string, appContext *config.AppContext) *hook.WebhookDeliveryService {
+	panic(wire.Build(
+		DependencySet,
+		wire.FieldsOf(new(*config.AppContext), "Config"),
+	))
+}
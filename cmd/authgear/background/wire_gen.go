@@ -21,6 +21,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/passkey"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity/saml"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/siwe"
 	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
@@ -39,9 +40,11 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/feature/accountstatus"
 	"github.com/authgear/authgear-server/pkg/lib/feature/customattrs"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
+	"github.com/authgear/authgear-server/pkg/lib/feature/ldapdirectorysync"
 	passkey2 "github.com/authgear/authgear-server/pkg/lib/feature/passkey"
 	stdattrs2 "github.com/authgear/authgear-server/pkg/lib/feature/stdattrs"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/feature/webhookdelivery"
 	"github.com/authgear/authgear-server/pkg/lib/fraudprotection"
 	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
@@ -56,6 +59,8 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms/custom"
 	"github.com/authgear/authgear-server/pkg/lib/infra/whatsapp"
+	ldap2 "github.com/authgear/authgear-server/pkg/lib/ldap"
+	"github.com/authgear/authgear-server/pkg/lib/ldapsync"
 	"github.com/authgear/authgear-server/pkg/lib/lockout"
 	"github.com/authgear/authgear-server/pkg/lib/messaging"
 	"github.com/authgear/authgear-server/pkg/lib/meter"
@@ -63,6 +68,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/oauth/pq"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/redis"
 	"github.com/authgear/authgear-server/pkg/lib/oauthclient"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
 	"github.com/authgear/authgear-server/pkg/lib/search/pgsearch"
@@ -163,6 +169,34 @@ func newAccountStatusRunner(ctx context.Context, p *deps.BackgroundProvider, ctr
 	return runner
 }
 
+func newWebhookDeliveryRunner(ctx context.Context, p *deps.BackgroundProvider, ctrl *configsource.Controller) *backgroundjob.Runner {
+	pool := p.DatabasePool
+	environmentConfig := p.EnvironmentConfig
+	globalDatabaseCredentialsEnvironmentConfig := &environmentConfig.GlobalDatabase
+	databaseEnvironmentConfig := &environmentConfig.DatabaseConfig
+	clockClock := _wireSystemClockValue
+	webhookDeliveryServiceFactory := &WebhookDeliveryServiceFactory{
+		BackgroundProvider: p,
+	}
+	runnableFactory := webhookdelivery.NewRunnableFactory(pool, globalDatabaseCredentialsEnvironmentConfig, databaseEnvironmentConfig, clockClock, ctrl, webhookDeliveryServiceFactory)
+	runner := webhookdelivery.NewRunner(ctx, runnableFactory)
+	return runner
+}
+
+func newLDAPDirectorySyncRunner(ctx context.Context, p *deps.BackgroundProvider, ctrl *configsource.Controller) *backgroundjob.Runner {
+	pool := p.DatabasePool
+	environmentConfig := p.EnvironmentConfig
+	globalDatabaseCredentialsEnvironmentConfig := &environmentConfig.GlobalDatabase
+	databaseEnvironmentConfig := &environmentConfig.DatabaseConfig
+	clockClock := _wireSystemClockValue
+	ldapDirectorySyncServiceFactory := &LDAPDirectorySyncServiceFactory{
+		BackgroundProvider: p,
+	}
+	runnableFactory := ldapdirectorysync.NewRunnableFactory(pool, globalDatabaseCredentialsEnvironmentConfig, databaseEnvironmentConfig, clockClock, ctrl, ldapDirectorySyncServiceFactory)
+	runner := ldapdirectorysync.NewRunner(ctx, runnableFactory)
+	return runner
+}
+
 func newUserService(p *deps.BackgroundProvider, appID string, appContext *config.AppContext) *UserService {
 	pool := p.DatabasePool
 	environmentConfig := p.EnvironmentConfig
@@ -335,6 +369,15 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		Clock:                        clockClock,
 		StandardAttributesNormalizer: normalizer,
 	}
+	samlStore := &saml.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	samlProvider := &saml.Provider{
+		Store:                        samlStore,
+		Clock:                        clockClock,
+		StandardAttributesNormalizer: normalizer,
+	}
 	serviceService := &service.Service{
 		Authentication:          authenticationConfig,
 		Identity:                identityConfig,
@@ -348,6 +391,7 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		Passkey:                 passkeyProvider,
 		SIWE:                    siweProvider,
 		LDAP:                    ldapProvider,
+		SAML:                    samlProvider,
 	}
 	store3 := &service2.Store{
 		SQLBuilder:  sqlBuilderApp,
@@ -365,7 +409,13 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		SQLExecutor: sqlExecutor,
 	}
 	authenticatorFeatureConfig := featureConfig.Authenticator
-	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore)
+	pwnedPasswordsEnvironmentConfig := environmentConfig.PwnedPasswords
+	breachedPasswordHTTPClient := password.NewBreachedPasswordHTTPClient()
+	breachedPasswordService := &password.BreachedPasswordService{
+		EnvConfig:  pwnedPasswordsEnvironmentConfig,
+		HTTPClient: breachedPasswordHTTPClient,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore, breachedPasswordService)
 	expiry := password.ProvideExpiry(authenticatorPasswordConfig, clockClock)
 	housekeeper := &password.Housekeeper{
 		Store:  historyStore,
@@ -495,6 +545,14 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 	commands := &rolesgroups.Commands{
 		Store: rolesgroupsStore,
 	}
+	organizationStore := &organization.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
+	}
+	organizationCommands := &organization.Commands{
+		Store: organizationStore,
+	}
 	sink := &hook.Sink{
 		Config:             hookConfig,
 		Clock:              clockClock,
@@ -600,7 +658,18 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		AnalyticRedis: analyticredisHandle,
 		Posthog:       posthogService,
 	}
-	eventService := event.NewService(configAppID, remoteIP, userAgentString, httpRequestURL, handle, clockClock, localizationConfig, storeImpl, resolverImpl, sink, auditSink, reindexSink, userinfoSink, firstAuthSink)
+	webhookDeliveryStore := &hook.WebhookDeliveryStore{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	webhookDeliveryService := &hook.WebhookDeliveryService{
+		Clock:        clockClock,
+		Config:       hookConfig,
+		Database:     handle,
+		Store:        webhookDeliveryStore,
+		EventWebHook: eventWebHookImpl,
+	}
+	eventService := event.NewService(configAppID, remoteIP, userAgentString, httpRequestURL, handle, clockClock, localizationConfig, storeImpl, resolverImpl, sink, auditSink, reindexSink, userinfoSink, firstAuthSink, webhookDeliveryService)
 	userCommands := &user.Commands{
 		RawCommands:        rawCommands,
 		RawQueries:         rawQueries,
@@ -781,9 +850,19 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		Lockout:         mfaLockout,
 	}
 	smtpServerCredentials := deps.ProvideSMTPServerCredentials(secretConfig)
-	dialer := mail.NewGomailDialer(smtpServerCredentials)
+	sesCredentials := deps.ProvideSESCredentials(secretConfig)
+	sendGridCredentials := deps.ProvideSendGridCredentials(secretConfig)
+	mailgunCredentials := deps.ProvideMailgunCredentials(secretConfig)
+	postmarkCredentials := deps.ProvidePostmarkCredentials(secretConfig)
+	mailClientResolver := &mail.ClientResolver{
+		SMTPServerCredentials: smtpServerCredentials,
+		SESCredentials:        sesCredentials,
+		SendGridCredentials:   sendGridCredentials,
+		MailgunCredentials:    mailgunCredentials,
+		PostmarkCredentials:   postmarkCredentials,
+	}
 	sender := &mail.Sender{
-		GomailDialer: dialer,
+		ClientResolver: mailClientResolver,
 	}
 	devMode := environmentConfig.DevMode
 	usageAlertEmailServiceImpl := &usage.UsageAlertEmailServiceImpl{
@@ -862,6 +941,10 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 	testModeSMSConfig := testModeConfig.SMS
 	featureTestModeWhatsappSuppressed := deps.ProvideTestModeWhatsappSuppressed(testModeFeatureConfig)
 	testModeWhatsappConfig := testModeConfig.Whatsapp
+	messageDeliveryProducer := redisqueue.NewMessageDeliveryProducer(appredisHandle, clockClock)
+	whatsappDeliveryCallback := &otp.WhatsappDeliveryCallback{
+		CodeStore: codeStoreRedis,
+	}
 	messagingSender := &messaging.Sender{
 		Limits:                            limits,
 		Events:                            eventService,
@@ -869,7 +952,11 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		MailSender:                        sender,
 		SMSSender:                         smsSender,
 		WhatsappSender:                    whatsappService,
+		Producer:                          messageDeliveryProducer,
+		WhatsappCallback:                  whatsappDeliveryCallback,
 		Database:                          handle,
+		Clock:                             clockClock,
+		AppID:                             configAppID,
 		DevMode:                           devMode,
 		MessagingFeatureConfig:            messagingFeatureConfig,
 		FeatureTestModeEmailSuppressed:    featureTestModeEmailSuppressed,
@@ -1005,6 +1092,7 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		UserCommands:               userCommands,
 		UserQueries:                userQueries,
 		RolesGroupsCommands:        commands,
+		OrganizationCommands:       organizationCommands,
 		StdAttrsService:            stdattrsService,
 		PasswordHistory:            historyStore,
 		OAuth:                      authorizationStore,
@@ -1022,9 +1110,37 @@ func newUserService(p *deps.BackgroundProvider, appID string, appContext *config
 		Clock:        clockClock,
 		Coordinator:  coordinator,
 	}
+	ldapConfig := identityConfig.LDAP
+	ldapServerUserCredentials := deps.ProvideLDAPServerUserCredentials(secretConfig)
+	clientFactory := &ldap2.ClientFactory{
+		Config:       ldapConfig,
+		SecretConfig: ldapServerUserCredentials,
+	}
+	identityFacade := &facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	groupSyncService := &ldapsync.GroupSyncService{
+		LDAPConfig:          ldapConfig,
+		LDAPClientFactory:   clientFactory,
+		RolesGroupsQueries:  queries,
+		RolesGroupsCommands: commands,
+		Events:              eventService,
+	}
+	directorySyncService := &ldapsync.DirectorySyncService{
+		Database:          handle,
+		Clock:             clockClock,
+		LDAPConfig:        ldapConfig,
+		LDAPClientFactory: clientFactory,
+		LDAPIdentities:    ldapProvider,
+		Identities:        identityFacade,
+		Users:             userFacade,
+		GroupSync:         groupSyncService,
+		Events:            eventService,
+	}
 	userService := &UserService{
-		AppDBHandle: handle,
-		UserFacade:  userFacade,
+		AppDBHandle:       handle,
+		UserFacade:        userFacade,
+		LDAPDirectorySync: directorySyncService,
 	}
 	return userService
 }
@@ -1033,3 +1149,42 @@ var (
 	_wireRandValue      = idpsession.Rand(rand.SecureRand)
 	_wireMaxTrialsValue = password.DefaultMaxTrials
 )
+
+func newWebhookDeliveryService(p *deps.BackgroundProvider, appID string, appContext *config.AppContext) *hook.WebhookDeliveryService {
+	clockClock := _wireSystemClockValue
+	configConfig := appContext.Config
+	appConfig := configConfig.AppConfig
+	hookConfig := appConfig.Hook
+	pool := p.DatabasePool
+	environmentConfig := p.EnvironmentConfig
+	databaseEnvironmentConfig := &environmentConfig.DatabaseConfig
+	secretConfig := configConfig.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	handle := appdb.NewHandle(pool, databaseEnvironmentConfig, databaseCredentials)
+	configAppID := appConfig.ID
+	sqlBuilderApp := appdb.NewSQLBuilderApp(databaseCredentials, configAppID)
+	sqlExecutor := appdb.NewSQLExecutor(handle)
+	webhookDeliveryStore := &hook.WebhookDeliveryStore{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	webHookImpl := hook.WebHookImpl{
+		Secret: webhookKeyMaterials,
+	}
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	eventWebHookImpl := &hook.EventWebHookImpl{
+		WebHookImpl: webHookImpl,
+		SyncHTTP:    syncHTTPClient,
+		AsyncHTTP:   asyncHTTPClient,
+	}
+	webhookDeliveryService := &hook.WebhookDeliveryService{
+		Clock:        clockClock,
+		Config:       hookConfig,
+		Database:     handle,
+		Store:        webhookDeliveryStore,
+		EventWebHook: eventWebHookImpl,
+	}
+	return webhookDeliveryService
+}
@@ -0,0 +1,37 @@
+-- +migrate Up
+
+CREATE TABLE _auth_webhook_delivery (
+  id text PRIMARY KEY,
+  app_id text NOT NULL,
+  created_at timestamp without time zone NOT NULL,
+  updated_at timestamp without time zone NOT NULL,
+  event_id text NOT NULL,
+  event_type text NOT NULL,
+  url text NOT NULL,
+  payload jsonb NOT NULL,
+  status text NOT NULL,
+  attempt_count integer NOT NULL,
+  next_attempt_at timestamp without time zone,
+  last_attempt_at timestamp without time zone
+);
+CREATE INDEX _auth_webhook_delivery_app_id_created_at ON _auth_webhook_delivery USING btree (app_id, created_at);
+CREATE INDEX _auth_webhook_delivery_next_attempt_at ON _auth_webhook_delivery USING btree (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE _auth_webhook_delivery_attempt (
+  id text PRIMARY KEY,
+  app_id text NOT NULL,
+  created_at timestamp without time zone NOT NULL,
+  delivery_id text NOT NULL REFERENCES _auth_webhook_delivery(id) ON DELETE CASCADE,
+  status_code integer,
+  latency_ms bigint NOT NULL,
+  error text
+);
+CREATE INDEX _auth_webhook_delivery_attempt_delivery_id ON _auth_webhook_delivery_attempt USING btree (app_id, delivery_id, created_at);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS _auth_webhook_delivery_attempt_delivery_id;
+DROP TABLE IF EXISTS _auth_webhook_delivery_attempt;
+DROP INDEX IF EXISTS _auth_webhook_delivery_next_attempt_at;
+DROP INDEX IF EXISTS _auth_webhook_delivery_app_id_created_at;
+DROP TABLE IF EXISTS _auth_webhook_delivery;
@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE _auth_identity_saml
+(
+    id                          text  PRIMARY KEY REFERENCES _auth_identity (id),
+    app_id                      text  NOT NULL,
+    identity_provider_name      text  NOT NULL,
+    identity_provider_entity_id text  NOT NULL,
+    name_id                     text  NOT NULL,
+    name_id_format              text  NOT NULL,
+    claims                      jsonb NOT NULL,
+    attributes                  jsonb NOT NULL
+);
+ALTER TABLE _auth_identity_saml
+    ADD CONSTRAINT _auth_identity_saml_unique UNIQUE (app_id, identity_provider_entity_id, name_id);
+
+CREATE INDEX _auth_identity_saml_claim_preferred_username ON _auth_identity_saml (app_id, (claims ->> 'preferred_username'));
+CREATE INDEX _auth_identity_saml_claim_phone_number ON _auth_identity_saml (app_id, (claims ->> 'phone_number'));
+CREATE INDEX _auth_identity_saml_claim_email ON _auth_identity_saml (app_id, (claims ->> 'email'));
+
+-- +migrate Down
+DROP TABLE _auth_identity_saml;
+DELETE FROM _auth_identity WHERE "type" = 'saml';
@@ -0,0 +1,63 @@
+-- +migrate Up
+CREATE TABLE _auth_organization (
+  id text PRIMARY KEY,
+  app_id text NOT NULL,
+  created_at timestamp without time zone NOT NULL,
+  updated_at timestamp without time zone NOT NULL,
+  key text NOT NULL,
+  name text,
+  description text
+);
+-- Each project has its own set of organizations. The organization keys are unique within a project.
+CREATE UNIQUE INDEX _auth_organization_key_unique ON _auth_organization USING btree (app_id, key);
+-- This index supports listing organizations of a project.
+CREATE INDEX _auth_organization_app_id ON _auth_organization USING btree (app_id);
+
+CREATE TABLE _auth_organization_member (
+  id text PRIMARY KEY,
+  app_id text NOT NULL,
+  created_at timestamp without time zone NOT NULL,
+  updated_at timestamp without time zone NOT NULL,
+  organization_id text NOT NULL REFERENCES _auth_organization(id),
+  user_id text NOT NULL REFERENCES _auth_user(id)
+);
+-- A user can only be a member of an organization at most once.
+CREATE UNIQUE INDEX _auth_organization_member_unique ON _auth_organization_member USING btree (app_id, organization_id, user_id);
+-- This index supports joining from User.
+CREATE INDEX _auth_organization_member_user ON _auth_organization_member USING btree (app_id, user_id);
+
+CREATE TABLE _auth_organization_member_role (
+  id text PRIMARY KEY,
+  app_id text NOT NULL,
+  created_at timestamp without time zone NOT NULL,
+  updated_at timestamp without time zone NOT NULL,
+  member_id text NOT NULL REFERENCES _auth_organization_member(id),
+  role_id text NOT NULL REFERENCES _auth_role(id)
+);
+-- A role and a member can only be associated at most once.
+CREATE UNIQUE INDEX _auth_organization_member_role_unique ON _auth_organization_member_role USING btree (app_id, member_id, role_id);
+-- This index supports joining from Role.
+CREATE INDEX _auth_organization_member_role_role ON _auth_organization_member_role USING btree (app_id, role_id);
+
+CREATE TABLE _auth_organization_invitation (
+  id text PRIMARY KEY,
+  app_id text NOT NULL,
+  created_at timestamp without time zone NOT NULL,
+  updated_at timestamp without time zone NOT NULL,
+  organization_id text NOT NULL REFERENCES _auth_organization(id),
+  email text NOT NULL,
+  role_keys jsonb NOT NULL,
+  expire_at timestamp without time zone NOT NULL,
+  accepted_at timestamp without time zone,
+  accepted_by_user_id text
+);
+-- This index supports listing invitations of an organization.
+CREATE INDEX _auth_organization_invitation_organization ON _auth_organization_invitation USING btree (app_id, organization_id);
+-- This index supports looking up pending invitations by email during login.
+CREATE INDEX _auth_organization_invitation_email ON _auth_organization_invitation USING btree (app_id, email) WHERE accepted_at IS NULL;
+
+-- +migrate Down
+DROP TABLE _auth_organization_invitation;
+DROP TABLE _auth_organization_member_role;
+DROP TABLE _auth_organization_member;
+DROP TABLE _auth_organization;
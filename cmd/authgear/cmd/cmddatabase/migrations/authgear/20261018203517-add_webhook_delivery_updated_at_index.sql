@@ -0,0 +1,7 @@
+-- +migrate Up
+
+CREATE INDEX _auth_webhook_delivery_updated_at ON _auth_webhook_delivery USING btree (updated_at) WHERE status <> 'pending';
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS _auth_webhook_delivery_updated_at;
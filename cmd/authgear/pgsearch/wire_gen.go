@@ -18,6 +18,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/passkey"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity/saml"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/siwe"
 	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
@@ -204,6 +205,15 @@ func NewReindexer(pool *db.Pool, databaseCredentials *CmdDBCredential, searchDat
 		Clock:                        clock,
 		StandardAttributesNormalizer: normalizer,
 	}
+	samlStore := &saml.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	samlProvider := &saml.Provider{
+		Store:                        samlStore,
+		Clock:                        clock,
+		StandardAttributesNormalizer: normalizer,
+	}
 	serviceService := &service.Service{
 		Authentication:          authenticationConfig,
 		Identity:                identityConfig,
@@ -217,6 +227,7 @@ func NewReindexer(pool *db.Pool, databaseCredentials *CmdDBCredential, searchDat
 		Passkey:                 passkeyProvider,
 		SIWE:                    siweProvider,
 		LDAP:                    ldapProvider,
+		SAML:                    samlProvider,
 	}
 	store3 := &service2.Store{
 		SQLBuilder:  sqlBuilderApp,
@@ -234,7 +245,13 @@ func NewReindexer(pool *db.Pool, databaseCredentials *CmdDBCredential, searchDat
 		SQLExecutor: sqlExecutor,
 	}
 	authenticatorFeatureConfig := featureConfig.Authenticator
-	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore)
+	pwnedPasswordsEnvironmentConfig := environmentConfig.PwnedPasswords
+	breachedPasswordHTTPClient := password.NewBreachedPasswordHTTPClient()
+	breachedPasswordService := &password.BreachedPasswordService{
+		EnvConfig:  pwnedPasswordsEnvironmentConfig,
+		HTTPClient: breachedPasswordHTTPClient,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore, breachedPasswordService)
 	expiry := password.ProvideExpiry(authenticatorPasswordConfig, clock)
 	housekeeper := &password.Housekeeper{
 		Store:  historyStore,
@@ -35,6 +35,9 @@ type Config struct {
 	// ConfigSource configures the source of app configurations
 	ConfigSource *configsource.Config `envconfig:"CONFIG_SOURCE"`
 
+	// MessageDeliveryWorkers sets the number of messages that are delivered at the same time.
+	MessageDeliveryWorkers int `envconfig:"MESSAGE_DELIVERY_WORKERS" default:"10"`
+
 	// BUILTIN_RESOURCE_DIRECTORY is deprecated. It has no effect anymore.
 
 	// CustomResourceDirectory sets the directory for customized resource files
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/admin"
 	"github.com/authgear/authgear-server/pkg/auth"
+	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
 	infraredisqueue "github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
 	"github.com/authgear/authgear-server/pkg/redisqueue"
@@ -103,11 +104,44 @@ func (c *Controller) Start(ctx context.Context) {
 		specs = append(specs, redisqueue.NewConsumer(
 			ctx,
 			infraredisqueue.QueueUserReindex,
+			1,
 			cfg.RateLimits.TaskUserReindex,
 			p,
 			configSrcController,
 			redisqueue.UserReindex,
 		))
+
+		specs = append(specs, redisqueue.NewConsumer(
+			ctx,
+			infraredisqueue.QueueBackchannelLogout,
+			1,
+			cfg.RateLimits.TaskBackchannelLogout,
+			p,
+			configSrcController,
+			redisqueue.BackchannelLogout,
+		))
+
+		specs = append(specs, redisqueue.NewConsumer(
+			ctx,
+			infraredisqueue.QueueCIBANotification,
+			1,
+			cfg.RateLimits.TaskCIBANotification,
+			p,
+			configSrcController,
+			redisqueue.CIBANotification,
+		))
+
+		specs = append(specs, redisqueue.NewConsumer(
+			ctx,
+			infraredisqueue.QueueMessageDelivery,
+			cfg.MessageDeliveryWorkers,
+			// The messages are limited per app when they are enqueued,
+			// so the delivery is not limited by a global bucket.
+			config.RateLimitsEnvironmentConfigEntry{},
+			p,
+			configSrcController,
+			redisqueue.MessageDelivery,
+		))
 	}
 
 	if c.ServeResolver {
@@ -172,6 +206,7 @@ func (c *Controller) Start(ctx context.Context) {
 		specs = append(specs, redisqueue.NewConsumer(
 			ctx,
 			infraredisqueue.QueueUserImport,
+			1,
 			cfg.RateLimits.TaskUserImport,
 			p,
 			configSrcController,
@@ -181,6 +216,7 @@ func (c *Controller) Start(ctx context.Context) {
 		specs = append(specs, redisqueue.NewConsumer(
 			ctx,
 			infraredisqueue.QueueUserExport,
+			1,
 			cfg.RateLimits.TaskUserExport,
 			p,
 			configSrcController,
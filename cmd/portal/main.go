@@ -25,6 +25,8 @@ import (
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/github"
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/google"
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/linkedin"
+	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/oauth2"
+	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/oidc"
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/wechat"
 	"github.com/authgear/authgear-server/pkg/util/debug"
 	"github.com/authgear/authgear-server/pkg/util/otelutil"
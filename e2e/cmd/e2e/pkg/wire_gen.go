@@ -20,6 +20,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/passkey"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity/saml"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/siwe"
 	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
@@ -56,6 +57,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/oauth/pq"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/redis"
 	"github.com/authgear/authgear-server/pkg/lib/oauthclient"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
 	"github.com/authgear/authgear-server/pkg/lib/search/pgsearch"
@@ -278,6 +280,15 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		Clock:                        clockClock,
 		StandardAttributesNormalizer: normalizer,
 	}
+	samlStore := &saml.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	samlProvider := &saml.Provider{
+		Store:                        samlStore,
+		Clock:                        clockClock,
+		StandardAttributesNormalizer: normalizer,
+	}
 	serviceService := &service.Service{
 		Authentication:          authenticationConfig,
 		Identity:                identityConfig,
@@ -291,6 +302,7 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		Passkey:                 passkeyProvider,
 		SIWE:                    siweProvider,
 		LDAP:                    ldapProvider,
+		SAML:                    samlProvider,
 	}
 	store3 := &service2.Store{
 		SQLBuilder:  sqlBuilderApp,
@@ -308,7 +320,13 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		SQLExecutor: sqlExecutor,
 	}
 	authenticatorFeatureConfig := featureConfig.Authenticator
-	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore)
+	pwnedPasswordsEnvironmentConfig := environmentConfig.PwnedPasswords
+	breachedPasswordHTTPClient := password.NewBreachedPasswordHTTPClient()
+	breachedPasswordService := &password.BreachedPasswordService{
+		EnvConfig:  pwnedPasswordsEnvironmentConfig,
+		HTTPClient: breachedPasswordHTTPClient,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore, breachedPasswordService)
 	expiry := password.ProvideExpiry(authenticatorPasswordConfig, clockClock)
 	housekeeper := &password.Housekeeper{
 		Store:  historyStore,
@@ -438,6 +456,14 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 	commands := &rolesgroups.Commands{
 		Store: rolesgroupsStore,
 	}
+	organizationStore := &organization.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
+	}
+	organizationCommands := &organization.Commands{
+		Store: organizationStore,
+	}
 	sink := &hook.Sink{
 		Config:             hookConfig,
 		Clock:              clockClock,
@@ -542,7 +568,18 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		AnalyticRedis: analyticredisHandle,
 		Posthog:       posthogService,
 	}
-	eventService := event.NewService(appID, remoteIP, userAgentString, httpRequestURL, handle, clockClock, localizationConfig, storeImpl, resolverImpl, sink, auditSink, reindexSink, userinfoSink, firstAuthSink)
+	webhookDeliveryStore := &hook.WebhookDeliveryStore{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	webhookDeliveryService := &hook.WebhookDeliveryService{
+		Clock:        clockClock,
+		Config:       hookConfig,
+		Database:     handle,
+		Store:        webhookDeliveryStore,
+		EventWebHook: eventWebHookImpl,
+	}
+	eventService := event.NewService(appID, remoteIP, userAgentString, httpRequestURL, handle, clockClock, localizationConfig, storeImpl, resolverImpl, sink, auditSink, reindexSink, userinfoSink, firstAuthSink, webhookDeliveryService)
 	serviceReadOnlyService := service2.ReadOnlyService{
 		Store:    store3,
 		Password: passwordProvider,
@@ -711,9 +748,19 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		Lockout:         mfaLockout,
 	}
 	smtpServerCredentials := deps.ProvideSMTPServerCredentials(secretConfig)
-	dialer := mail.NewGomailDialer(smtpServerCredentials)
+	sesCredentials := deps.ProvideSESCredentials(secretConfig)
+	sendGridCredentials := deps.ProvideSendGridCredentials(secretConfig)
+	mailgunCredentials := deps.ProvideMailgunCredentials(secretConfig)
+	postmarkCredentials := deps.ProvidePostmarkCredentials(secretConfig)
+	mailClientResolver := &mail.ClientResolver{
+		SMTPServerCredentials: smtpServerCredentials,
+		SESCredentials:        sesCredentials,
+		SendGridCredentials:   sendGridCredentials,
+		MailgunCredentials:    mailgunCredentials,
+		PostmarkCredentials:   postmarkCredentials,
+	}
 	sender := &mail.Sender{
-		GomailDialer: dialer,
+		ClientResolver: mailClientResolver,
 	}
 	devMode := environmentConfig.DevMode
 	usageAlertEmailServiceImpl := &usage.UsageAlertEmailServiceImpl{
@@ -792,6 +839,10 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 	testModeSMSConfig := testModeConfig.SMS
 	featureTestModeWhatsappSuppressed := deps.ProvideTestModeWhatsappSuppressed(testModeFeatureConfig)
 	testModeWhatsappConfig := testModeConfig.Whatsapp
+	messageDeliveryProducer := redisqueue.NewMessageDeliveryProducer(appredisHandle, clockClock)
+	whatsappDeliveryCallback := &otp.WhatsappDeliveryCallback{
+		CodeStore: codeStoreRedis,
+	}
 	messagingSender := &messaging.Sender{
 		Limits:                            limits,
 		Events:                            eventService,
@@ -799,7 +850,11 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		MailSender:                        sender,
 		SMSSender:                         smsSender,
 		WhatsappSender:                    whatsappService,
+		Producer:                          messageDeliveryProducer,
+		WhatsappCallback:                  whatsappDeliveryCallback,
 		Database:                          handle,
+		Clock:                             clockClock,
+		AppID:                             appID,
 		DevMode:                           devMode,
 		MessagingFeatureConfig:            messagingFeatureConfig,
 		FeatureTestModeEmailSuppressed:    featureTestModeEmailSuppressed,
@@ -949,6 +1004,7 @@ func newUserImport(p *deps.AppProvider) *userimport.UserImportService {
 		UserCommands:               userCommands,
 		UserQueries:                userQueries,
 		RolesGroupsCommands:        commands,
+		OrganizationCommands:       organizationCommands,
 		StdAttrsService:            stdattrsService,
 		PasswordHistory:            historyStore,
 		OAuth:                      authorizationStore,
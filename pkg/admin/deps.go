@@ -20,6 +20,7 @@ import (
 	featurecustomattrs "github.com/authgear/authgear-server/pkg/lib/feature/customattrs"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
 	featurestdattrs "github.com/authgear/authgear-server/pkg/lib/feature/stdattrs"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/middleware"
 	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
@@ -28,6 +29,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
 	"github.com/authgear/authgear-server/pkg/lib/oauthclient"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/presign"
 	"github.com/authgear/authgear-server/pkg/lib/resourcescope"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
@@ -54,6 +56,7 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(loader.AuthenticatorLoaderAuthenticatorService), new(*authenticatorservice.Service)),
 	wire.Bind(new(loader.RoleLoaderRoles), new(*rolesgroups.Queries)),
 	wire.Bind(new(loader.GroupLoaderGroups), new(*rolesgroups.Queries)),
+	wire.Bind(new(loader.OrganizationLoaderOrganizations), new(*organization.Queries)),
 	wire.Bind(new(loader.ResourceLoaderResources), new(*resourcescope.Queries)),
 	wire.Bind(new(loader.ResourceClientLoaderResources), new(*resourcescope.Queries)),
 	wire.Bind(new(loader.ScopeLoaderScopes), new(*resourcescope.Queries)),
@@ -63,11 +66,15 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(facade.UserService), new(*libfacade.UserFacade)),
 	wire.Bind(new(facade.RolesGroupsCommands), new(*rolesgroups.Commands)),
 	wire.Bind(new(facade.RolesGroupsQueries), new(*rolesgroups.Queries)),
+	wire.Bind(new(facade.OrganizationCommands), new(*organization.Commands)),
+	wire.Bind(new(facade.OrganizationQueries), new(*organization.Queries)),
+	wire.Bind(new(facade.OrganizationInvitationMessageSender), new(*organization.InvitationMessageSender)),
 	wire.Bind(new(facade.ResourceScopeCommands), new(*resourcescope.Commands)),
 	wire.Bind(new(facade.ResourceScopeQueries), new(*resourcescope.Queries)),
 	wire.Bind(new(facade.IdentityService), new(*libfacade.IdentityFacade)),
 	wire.Bind(new(facade.UserSearchService), new(*search.Service)),
 	wire.Bind(new(facade.AuthenticatorService), new(*authenticatorservice.Service)),
+	wire.Bind(new(facade.AuthenticatorCoordinator), new(*libfacade.AuthenticatorFacade)),
 	wire.Bind(new(facade.InteractionService), new(*service.InteractionService)),
 	wire.Bind(new(facade.VerificationService), new(*libfacade.AdminVerificationFacade)),
 	wire.Bind(new(facade.StandardAttributesService), new(*featurestdattrs.ServiceNoEvent)),
@@ -80,7 +87,14 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(facade.OAuthTokenService), new(*oauthhandler.TokenService)),
 	wire.Bind(new(facade.OAuthClientResolver), new(*oauthclient.Resolver)),
 	wire.Bind(new(facade.OAuthAccessTokenEncoding), new(*oauth.AccessTokenEncoding)),
+	wire.Bind(new(facade.OAuthInitialAccessTokenService), new(*oauth.InitialAccessTokenService)),
+	wire.Bind(new(facade.OAuthRegistrationAccessTokenService), new(*oauth.RegistrationAccessTokenService)),
 	wire.Bind(new(facade.LockoutProvider), new(*lockoutpkg.Service)),
+	wire.Bind(new(facade.WebhookDeliveryStore), new(*hook.WebhookDeliveryStore)),
+	wire.Bind(new(facade.WebhookDeliveryService), new(*hook.WebhookDeliveryService)),
+	wire.Bind(new(facade.SCIMUserFacade), new(*facade.UserFacade)),
+	wire.Bind(new(facade.SCIMUserProfileFacade), new(*facade.UserProfileFacade)),
+	wire.Bind(new(facade.SCIMUserQueries), new(*user.Queries)),
 
 	graphql.DependencySet,
 	wire.Bind(new(graphql.UserLoader), new(*loader.UserLoader)),
@@ -89,11 +103,13 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(graphql.AuditLogLoader), new(*loader.AuditLogLoader)),
 	wire.Bind(new(graphql.RoleLoader), new(*loader.RoleLoader)),
 	wire.Bind(new(graphql.GroupLoader), new(*loader.GroupLoader)),
+	wire.Bind(new(graphql.OrganizationLoader), new(*loader.OrganizationLoader)),
 	wire.Bind(new(graphql.ResourceLoader), new(*loader.ResourceLoader)),
 	wire.Bind(new(graphql.ResourceClientLoader), new(*loader.ResourceClientLoader)),
 	wire.Bind(new(graphql.ScopeLoader), new(*loader.ScopeLoader)),
 	wire.Bind(new(graphql.UserFacade), new(*facade.UserFacade)),
 	wire.Bind(new(graphql.RolesGroupsFacade), new(*facade.RolesGroupsFacade)),
+	wire.Bind(new(graphql.OrganizationFacade), new(*facade.OrganizationFacade)),
 	wire.Bind(new(graphql.ResourceScopeFacade), new(*facade.ResourceScopeFacade)),
 	wire.Bind(new(graphql.IdentityFacade), new(*facade.IdentityFacade)),
 	wire.Bind(new(graphql.AuthenticatorFacade), new(*facade.AuthenticatorFacade)),
@@ -104,6 +120,7 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(graphql.AuthorizationFacade), new(*facade.AuthorizationFacade)),
 	wire.Bind(new(graphql.OAuthFacade), new(*facade.OAuthFacade)),
 	wire.Bind(new(graphql.AccountLockoutFacade), new(*facade.LockoutFacade)),
+	wire.Bind(new(graphql.WebhookDeliveryFacade), new(*facade.WebhookDeliveryFacade)),
 	wire.Bind(new(graphql.SessionListingService), new(*sessionlisting.SessionListingService)),
 	wire.Bind(new(graphql.OTPCodeService), new(*otp.Service)),
 	wire.Bind(new(graphql.ForgotPasswordService), new(*forgotpassword.Service)),
@@ -122,6 +139,8 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(transport.UserExportCreateHandlerCloudStorage), new(userexport.UserExportCloudStorage)),
 	wire.Bind(new(transport.UserExportGetHandlerCloudStorage), new(userexport.UserExportCloudStorage)),
 	wire.Bind(new(transport.UserExportCreateHandlerUserExportService), new(*userexport.UserExportService)),
+	wire.Bind(new(transport.SCIMUsersHandlerFacade), new(*facade.SCIMFacade)),
+	wire.Bind(new(transport.SCIMGroupsHandlerFacade), new(*facade.SCIMFacade)),
 
 	adminauthz.DependencySet,
 )
@@ -14,15 +14,20 @@ type AuthenticatorService interface {
 	New(ctx context.Context, spec *authenticator.Spec) (*authenticator.Info, error)
 	UpdatePassword(ctx context.Context, ai *authenticator.Info, options *service.UpdatePasswordOptions) (bool, *authenticator.Info, error)
 
-	Create(ctx context.Context, info *authenticator.Info) error
 	Update(ctx context.Context, info *authenticator.Info) error
 	Get(ctx context.Context, id string) (*authenticator.Info, error)
 	Count(ctx context.Context, userID string) (uint64, error)
 	ListRefsByUsers(ctx context.Context, userIDs []string, authenticatorType *apimodel.AuthenticatorType, authenticatorKind *authenticator.Kind) ([]*authenticator.Ref, error)
 }
 
+// AuthenticatorCoordinator creates authenticators with the blocking events dispatched.
+type AuthenticatorCoordinator interface {
+	CreateByAdmin(ctx context.Context, authenticatorInfo *authenticator.Info) error
+}
+
 type AuthenticatorFacade struct {
 	Authenticators AuthenticatorService
+	Coordinator    AuthenticatorCoordinator
 	Interaction    InteractionService
 }
 
@@ -64,8 +69,7 @@ func (f *AuthenticatorFacade) CreateBySpec(ctx context.Context, spec *authentica
 	if err != nil {
 		return nil, err
 	}
-	err = f.Authenticators.Create(ctx, info)
-
+	err = f.Coordinator.CreateByAdmin(ctx, info)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(IdentityFacade), "*"),
 	wire.Struct(new(AuthenticatorFacade), "*"),
 	wire.Struct(new(RolesGroupsFacade), "*"),
+	wire.Struct(new(OrganizationFacade), "*"),
 	wire.Struct(new(ResourceScopeFacade), "*"),
 	wire.Struct(new(VerificationFacade), "*"),
 	wire.Struct(new(SessionFacade), "*"),
@@ -15,4 +16,6 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(AuthorizationFacade), "*"),
 	wire.Struct(new(OAuthFacade), "*"),
 	wire.Struct(new(LockoutFacade), "*"),
+	wire.Struct(new(WebhookDeliveryFacade), "*"),
+	wire.Struct(new(SCIMFacade), "*"),
 )
@@ -2,6 +2,7 @@ package facade
 
 import (
 	"context"
+	"time"
 
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
@@ -46,6 +47,14 @@ type OAuthClientResolver interface {
 	ResolveClient(clientID string) *config.OAuthClientConfig
 }
 
+type OAuthInitialAccessTokenService interface {
+	CreateInitialAccessToken(ctx context.Context, lifetime time.Duration) (string, *oauth.InitialAccessToken, error)
+}
+
+type OAuthRegistrationAccessTokenService interface {
+	ResetRegistrationAccessToken(ctx context.Context, clientID string) (string, error)
+}
+
 type OAuthFacade struct {
 	Config              *config.OAuthConfig
 	Users               UserService
@@ -54,6 +63,8 @@ type OAuthFacade struct {
 	AccessTokenCoding   OAuthAccessTokenEncoding
 	Clock               clock.Clock
 	OAuthClientResolver OAuthClientResolver
+	InitialAccessTokens OAuthInitialAccessTokenService
+	RegistrationTokens  OAuthRegistrationAccessTokenService
 }
 
 func (f *OAuthFacade) CreateSession(ctx context.Context, clientID string, userID string, deviceInfo map[string]any) (session.ListableSession, protocol.TokenResponse, error) {
@@ -135,3 +146,20 @@ func (f *OAuthFacade) CreateSession(ctx context.Context, clientID string, userID
 	result2.WriteTo(resp)
 	return offlineGrant, resp, nil
 }
+
+func (f *OAuthFacade) CreateInitialAccessToken(ctx context.Context, lifetime time.Duration) (string, *oauth.InitialAccessToken, error) {
+	return f.InitialAccessTokens.CreateInitialAccessToken(ctx, lifetime)
+}
+
+func (f *OAuthFacade) ResetRegistrationAccessToken(ctx context.Context, clientID string) (string, error) {
+	client := f.OAuthClientResolver.ResolveClient(clientID)
+	if client == nil {
+		return "", apierrors.NewInvalid("invalid client ID")
+	}
+	// Only third-party apps can be managed at the client configuration endpoint.
+	if !client.IsThirdParty() {
+		return "", apierrors.NewForbidden("cannot reset registration access token for first party client")
+	}
+
+	return f.RegistrationTokens.ResetRegistrationAccessToken(ctx, clientID)
+}
@@ -0,0 +1,196 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type OrganizationCommands interface {
+	CreateOrganization(ctx context.Context, options *organization.NewOrganizationOptions) (*model.Organization, error)
+	UpdateOrganization(ctx context.Context, options *organization.UpdateOrganizationOptions) (*model.Organization, error)
+	DeleteOrganization(ctx context.Context, id string) (memberUserIDs []string, err error)
+
+	AddMember(ctx context.Context, organizationID string, userID string, roleKeys []string) (*model.OrganizationMember, error)
+	RemoveMember(ctx context.Context, organizationID string, userID string) error
+	UpdateMemberRoles(ctx context.Context, organizationID string, userID string, roleKeys []string) (*model.OrganizationMember, error)
+
+	CreateInvitation(ctx context.Context, options *organization.NewInvitationOptions) (*model.OrganizationInvitation, error)
+	DeleteInvitation(ctx context.Context, id string) error
+}
+
+type OrganizationQueries interface {
+	GetOrganization(ctx context.Context, id string) (*model.Organization, error)
+	ListOrganizations(ctx context.Context, options *organization.ListOrganizationsOptions, pageArgs graphqlutil.PageArgs) ([]model.PageItemRef, error)
+	ListOrganizationsByUserID(ctx context.Context, userID string) ([]*model.Organization, error)
+	CountOrganizations(ctx context.Context) (uint64, error)
+
+	GetMember(ctx context.Context, organizationID string, userID string) (*model.OrganizationMember, error)
+	ListMembers(ctx context.Context, organizationID string, pageArgs graphqlutil.PageArgs) ([]*model.OrganizationMember, []model.PageCursor, error)
+	CountMembers(ctx context.Context, organizationID string) (uint64, error)
+
+	GetInvitation(ctx context.Context, id string) (*model.OrganizationInvitation, error)
+	ListInvitations(ctx context.Context, organizationID string) ([]*model.OrganizationInvitation, error)
+}
+
+type OrganizationInvitationMessageSender interface {
+	Send(ctx context.Context, o *model.Organization, i *model.OrganizationInvitation) error
+}
+
+type OrganizationFacade struct {
+	OrganizationCommands OrganizationCommands
+	OrganizationQueries  OrganizationQueries
+	Sessions             SessionManager
+	InvitationMessages   OrganizationInvitationMessageSender
+}
+
+func (f *OrganizationFacade) CreateOrganization(ctx context.Context, options *organization.NewOrganizationOptions) (organizationID string, err error) {
+	o, err := f.OrganizationCommands.CreateOrganization(ctx, options)
+	if err != nil {
+		return
+	}
+
+	organizationID = o.ID
+	return
+}
+
+func (f *OrganizationFacade) UpdateOrganization(ctx context.Context, options *organization.UpdateOrganizationOptions) (err error) {
+	_, err = f.OrganizationCommands.UpdateOrganization(ctx, options)
+	return
+}
+
+// DeleteOrganization revokes the organization sessions of the members
+// collected by OrganizationCommands.DeleteOrganization in the same transaction.
+func (f *OrganizationFacade) DeleteOrganization(ctx context.Context, id string) (memberUserIDs []string, err error) {
+	memberUserIDs, err = f.OrganizationCommands.DeleteOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userID := range memberUserIDs {
+		err = f.revokeOrganizationSessions(ctx, id, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return memberUserIDs, nil
+}
+
+func (f *OrganizationFacade) GetOrganization(ctx context.Context, id string) (*model.Organization, error) {
+	return f.OrganizationQueries.GetOrganization(ctx, id)
+}
+
+func (f *OrganizationFacade) ListOrganizations(ctx context.Context, options *organization.ListOrganizationsOptions, pageArgs graphqlutil.PageArgs) ([]model.PageItemRef, *graphqlutil.PageResult, error) {
+	refs, err := f.OrganizationQueries.ListOrganizations(ctx, options, pageArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	count, err := f.OrganizationQueries.CountOrganizations(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return refs, graphqlutil.NewPageResult(pageArgs, len(refs), graphqlutil.NewLazy(func() (any, error) {
+		return count, nil
+	})), nil
+}
+
+func (f *OrganizationFacade) ListOrganizationsByUserID(ctx context.Context, userID string) ([]*model.Organization, error) {
+	return f.OrganizationQueries.ListOrganizationsByUserID(ctx, userID)
+}
+
+func (f *OrganizationFacade) GetMember(ctx context.Context, organizationID string, userID string) (*model.OrganizationMember, error) {
+	return f.OrganizationQueries.GetMember(ctx, organizationID, userID)
+}
+
+func (f *OrganizationFacade) ListMembers(ctx context.Context, organizationID string, pageArgs graphqlutil.PageArgs) ([]*model.OrganizationMember, []model.PageCursor, *graphqlutil.PageResult, error) {
+	members, cursors, err := f.OrganizationQueries.ListMembers(ctx, organizationID, pageArgs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	count, err := f.OrganizationQueries.CountMembers(ctx, organizationID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return members, cursors, graphqlutil.NewPageResult(pageArgs, len(members), graphqlutil.NewLazy(func() (any, error) {
+		return count, nil
+	})), nil
+}
+
+func (f *OrganizationFacade) AddMember(ctx context.Context, organizationID string, userID string, roleKeys []string) (*model.OrganizationMember, error) {
+	return f.OrganizationCommands.AddMember(ctx, organizationID, userID, roleKeys)
+}
+
+func (f *OrganizationFacade) RemoveMember(ctx context.Context, organizationID string, userID string) error {
+	err := f.OrganizationCommands.RemoveMember(ctx, organizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return f.revokeOrganizationSessions(ctx, organizationID, userID)
+}
+
+func (f *OrganizationFacade) UpdateMemberRoles(ctx context.Context, organizationID string, userID string, roleKeys []string) (*model.OrganizationMember, error) {
+	return f.OrganizationCommands.UpdateMemberRoles(ctx, organizationID, userID, roleKeys)
+}
+
+func (f *OrganizationFacade) CreateInvitation(ctx context.Context, options *organization.NewInvitationOptions) (*model.OrganizationInvitation, error) {
+	i, err := f.OrganizationCommands.CreateInvitation(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := f.OrganizationQueries.GetOrganization(ctx, i.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.InvitationMessages.Send(ctx, o, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func (f *OrganizationFacade) DeleteInvitation(ctx context.Context, id string) error {
+	return f.OrganizationCommands.DeleteInvitation(ctx, id)
+}
+
+func (f *OrganizationFacade) GetInvitation(ctx context.Context, id string) (*model.OrganizationInvitation, error) {
+	return f.OrganizationQueries.GetInvitation(ctx, id)
+}
+
+func (f *OrganizationFacade) ListInvitations(ctx context.Context, organizationID string) ([]*model.OrganizationInvitation, error) {
+	return f.OrganizationQueries.ListInvitations(ctx, organizationID)
+}
+
+// revokeOrganizationSessions revokes the sessions and offline grants of the user
+// that were authenticated into the organization.
+// The organization is recorded in them when they are created,
+// so they would keep issuing tokens with org_id after the user left the organization.
+func (f *OrganizationFacade) revokeOrganizationSessions(ctx context.Context, organizationID string, userID string) error {
+	sessions, err := f.Sessions.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		if s.GetAuthenticationInfo().OrganizationID != organizationID {
+			continue
+		}
+
+		err = f.Sessions.RevokeWithEvent(ctx, s, true, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
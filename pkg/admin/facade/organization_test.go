@@ -0,0 +1,146 @@
+package facade
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+)
+
+type fakeOrganizationCommands struct {
+	OrganizationCommands
+	memberUserIDs          map[string][]string
+	deletedOrganizationIDs []string
+	removedUserIDs         []string
+}
+
+func (f *fakeOrganizationCommands) DeleteOrganization(ctx context.Context, id string) ([]string, error) {
+	f.deletedOrganizationIDs = append(f.deletedOrganizationIDs, id)
+	return f.memberUserIDs[id], nil
+}
+
+func (f *fakeOrganizationCommands) CreateInvitation(ctx context.Context, options *organization.NewInvitationOptions) (*model.OrganizationInvitation, error) {
+	return &model.OrganizationInvitation{
+		Meta:           model.Meta{ID: "invitation-1"},
+		OrganizationID: options.OrganizationID,
+		Email:          options.Email,
+	}, nil
+}
+
+func (f *fakeOrganizationCommands) RemoveMember(ctx context.Context, organizationID string, userID string) error {
+	f.removedUserIDs = append(f.removedUserIDs, userID)
+	return nil
+}
+
+type fakeOrganizationQueries struct {
+	OrganizationQueries
+}
+
+func (f *fakeOrganizationQueries) GetOrganization(ctx context.Context, id string) (*model.Organization, error) {
+	return &model.Organization{Meta: model.Meta{ID: id}, Key: "acme"}, nil
+}
+
+type fakeOrganizationInvitationMessageSender struct {
+	sent []string
+}
+
+func (f *fakeOrganizationInvitationMessageSender) Send(ctx context.Context, o *model.Organization, i *model.OrganizationInvitation) error {
+	f.sent = append(f.sent, o.Key+"/"+i.Email)
+	return nil
+}
+
+type fakeOrganizationSessionManager struct {
+	SessionManager
+	sessions   map[string][]session.ListableSession
+	revokedIDs []string
+}
+
+func (f *fakeOrganizationSessionManager) List(ctx context.Context, userID string) ([]session.ListableSession, error) {
+	return f.sessions[userID], nil
+}
+
+func (f *fakeOrganizationSessionManager) RevokeWithEvent(ctx context.Context, s session.SessionBase, isTermination bool, isAdminAPI bool) error {
+	f.revokedIDs = append(f.revokedIDs, s.SessionID())
+	return nil
+}
+
+func TestOrganizationFacade(t *testing.T) {
+	Convey("OrganizationFacade", t, func() {
+		ctx := context.Background()
+
+		newIDPSession := func(id string, userID string, organizationID string) *idpsession.IDPSession {
+			attrs := session.NewAttrs(userID)
+			attrs.SetOrganizationID(organizationID)
+			return &idpsession.IDPSession{ID: id, Attrs: *attrs}
+		}
+		newOfflineGrant := func(id string, userID string, organizationID string) *oauth.OfflineGrant {
+			attrs := session.NewAttrs(userID)
+			attrs.SetOrganizationID(organizationID)
+			return &oauth.OfflineGrant{ID: id, Attrs: *attrs}
+		}
+
+		commands := &fakeOrganizationCommands{
+			memberUserIDs: map[string][]string{
+				"org-1": {"user-1", "user-2"},
+			},
+		}
+		sessions := &fakeOrganizationSessionManager{
+			sessions: map[string][]session.ListableSession{
+				"user-1": {
+					newIDPSession("session-1", "user-1", "org-1"),
+					newIDPSession("session-2", "user-1", "org-2"),
+					newIDPSession("session-3", "user-1", ""),
+					newOfflineGrant("grant-1", "user-1", "org-1"),
+					newOfflineGrant("grant-2", "user-1", ""),
+				},
+				"user-2": {
+					newOfflineGrant("grant-3", "user-2", "org-1"),
+				},
+			},
+		}
+		invitationMessages := &fakeOrganizationInvitationMessageSender{}
+		f := &OrganizationFacade{
+			OrganizationCommands: commands,
+			OrganizationQueries:  &fakeOrganizationQueries{},
+			Sessions:             sessions,
+			InvitationMessages:   invitationMessages,
+		}
+
+		Convey("should revoke sessions of the organization when a member is removed", func() {
+			err := f.RemoveMember(ctx, "org-1", "user-1")
+			So(err, ShouldBeNil)
+			So(commands.removedUserIDs, ShouldResemble, []string{"user-1"})
+			So(sessions.revokedIDs, ShouldResemble, []string{"session-1", "grant-1"})
+		})
+
+		Convey("should not revoke sessions of other organizations", func() {
+			err := f.RemoveMember(ctx, "org-2", "user-1")
+			So(err, ShouldBeNil)
+			So(sessions.revokedIDs, ShouldResemble, []string{"session-2"})
+		})
+
+		Convey("should revoke sessions of all members when the organization is deleted", func() {
+			userIDs, err := f.DeleteOrganization(ctx, "org-1")
+			So(err, ShouldBeNil)
+			So(userIDs, ShouldResemble, []string{"user-1", "user-2"})
+			So(commands.deletedOrganizationIDs, ShouldResemble, []string{"org-1"})
+			So(sessions.revokedIDs, ShouldResemble, []string{"session-1", "grant-1", "grant-3"})
+		})
+
+		Convey("should send the invitation email", func() {
+			i, err := f.CreateInvitation(ctx, &organization.NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          "user@example.com",
+			})
+			So(err, ShouldBeNil)
+			So(i.ID, ShouldEqual, "invitation-1")
+			So(invitationMessages.sent, ShouldResemble, []string{"acme/user@example.com"})
+		})
+	})
+}
@@ -0,0 +1,933 @@
+package facade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/admin/model"
+	"github.com/authgear/authgear-server/pkg/api"
+	apimodel "github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/facade"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
+	"github.com/authgear/authgear-server/pkg/lib/scim"
+	"github.com/authgear/authgear-server/pkg/util/accesscontrol"
+	"github.com/authgear/authgear-server/pkg/util/base32"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+	corerand "github.com/authgear/authgear-server/pkg/util/rand"
+	"github.com/authgear/authgear-server/pkg/util/setutil"
+)
+
+type SCIMUserFacade interface {
+	ListPage(ctx context.Context, listOption user.ListOptions, pageArgs graphqlutil.PageArgs) ([]apimodel.PageItemRef, *graphqlutil.PageResult, error)
+	Create(ctx context.Context, identityDef model.IdentityDef, opts facade.CreatePasswordOptions) (userID string, err error)
+	SetDisabled(ctx context.Context, options facade.SetDisabledOptions) error
+	Delete(ctx context.Context, id string, reason string) error
+	GetUserByLoginID(ctx context.Context, loginIDKey string, loginIDValue string) (string, error)
+}
+
+type SCIMUserProfileFacade interface {
+	UpdateUserProfile(ctx context.Context, role accesscontrol.Role, userID string, stdAttrs map[string]any, customAttrs map[string]any) error
+}
+
+type SCIMUserQueries interface {
+	Get(ctx context.Context, id string, role accesscontrol.Role) (*apimodel.User, error)
+	GetMany(ctx context.Context, ids []string, role accesscontrol.Role) ([]*apimodel.User, error)
+}
+
+// scimUserAttributes maps the writable SCIM User attributes to standard attributes.
+var scimUserAttributes = map[string]string{
+	"displayname": stdattrs.Name,
+	"nickname":    stdattrs.Nickname,
+	"profileurl":  stdattrs.Profile,
+	"locale":      stdattrs.Locale,
+	"timezone":    stdattrs.Zoneinfo,
+}
+
+// scimNameAttributes maps the sub-attributes of SCIM User name to standard attributes.
+var scimNameAttributes = map[string]string{
+	"formatted":  stdattrs.Name,
+	"familyname": stdattrs.FamilyName,
+	"givenname":  stdattrs.GivenName,
+	"middlename": stdattrs.MiddleName,
+}
+
+type scimAttributeValue struct {
+	Attr  string
+	Value string
+}
+
+// scimIgnoredUserAttributes are accepted but not stored.
+// emails and phoneNumbers are derived from the login ID identified by userName.
+var scimIgnoredUserAttributes = map[string]struct{}{
+	"externalid":   {},
+	"emails":       {},
+	"phonenumbers": {},
+}
+
+type SCIMFacade struct {
+	LoginIDConfig       *config.LoginIDConfig
+	Users               SCIMUserFacade
+	UserProfiles        SCIMUserProfileFacade
+	UserQueries         SCIMUserQueries
+	RolesGroupsCommands RolesGroupsCommands
+	RolesGroupsQueries  RolesGroupsQueries
+}
+
+func (f *SCIMFacade) ListUsers(ctx context.Context, options *scim.ListOptions) (*scim.ListResponse, error) {
+	if options.Filter != nil {
+		userName, err := scimFilterStringValue(options.Filter, "userName")
+		if err != nil {
+			return nil, err
+		}
+
+		userID, err := f.getUserIDByUserName(ctx, userName)
+		if errors.Is(err, api.ErrUserNotFound) {
+			return scim.NewListResponse(nil, 0, options.StartIndex), nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		var resources []any
+		if options.StartIndex == 1 && options.Count > 0 {
+			u, err := f.GetUser(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, u)
+		}
+		return scim.NewListResponse(resources, 1, options.StartIndex), nil
+	}
+
+	pageArgs, err := scimPageArgs(options)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, result, err := f.Users.ListPage(ctx, user.ListOptions{}, pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := result.TotalCount.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		ids[i] = ref.ID
+	}
+
+	users, err := f.UserQueries.GetMany(ctx, ids, accesscontrol.RoleGreatest)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []any
+	for _, u := range users {
+		if u == nil {
+			continue
+		}
+		scimUser, err := f.toSCIMUser(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, scimUser)
+	}
+
+	//nolint:gosec // G115
+	return scim.NewListResponse(resources, int(totalCount.(uint64)), options.StartIndex), nil
+}
+
+func (f *SCIMFacade) GetUser(ctx context.Context, id string) (*scim.User, error) {
+	u, err := f.UserQueries.Get(ctx, id, accesscontrol.RoleGreatest)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.toSCIMUser(ctx, u)
+}
+
+func (f *SCIMFacade) CreateUser(ctx context.Context, input *scim.User) (*scim.User, error) {
+	loginIDKey, err := f.loginIDKeyForUserName(input.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := f.Users.Create(ctx, &model.IdentityDefLoginID{
+		Key:   loginIDKey,
+		Value: input.UserName,
+	}, facade.CreatePasswordOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.replaceUser(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetUser(ctx, userID)
+}
+
+func (f *SCIMFacade) ReplaceUser(ctx context.Context, id string, input *scim.User) (*scim.User, error) {
+	err := f.replaceUser(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetUser(ctx, id)
+}
+
+func (f *SCIMFacade) PatchUser(ctx context.Context, id string, input *scim.PatchRequest) (*scim.User, error) {
+	u, err := f.UserQueries.Get(ctx, id, accesscontrol.RoleGreatest)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := &scimUserPatch{
+		userName: scimUserName(u.StandardAttributes),
+		stdAttrs: maps.Clone(u.StandardAttributes),
+	}
+	if patch.stdAttrs == nil {
+		patch.stdAttrs = make(map[string]any)
+	}
+
+	err = scimForEachOperation(input, patch.apply)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.applyUserPatch(ctx, u, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetUser(ctx, id)
+}
+
+func (f *SCIMFacade) DeleteUser(ctx context.Context, id string) error {
+	return f.Users.Delete(ctx, id, "")
+}
+
+func (f *SCIMFacade) ListGroups(ctx context.Context, options *scim.ListOptions) (*scim.ListResponse, error) {
+	includeMembers := !options.IsExcluded("members")
+
+	if options.Filter != nil {
+		displayName, err := scimFilterStringValue(options.Filter, "displayName")
+		if err != nil {
+			return nil, err
+		}
+
+		refs, err := f.RolesGroupsQueries.ListGroups(ctx, &rolesgroups.ListGroupsOptions{
+			SearchKeyword: displayName,
+		}, graphqlutil.PageArgs{})
+		if err != nil {
+			return nil, err
+		}
+
+		var matched []*apimodel.Group
+		for _, ref := range refs {
+			g, err := f.RolesGroupsQueries.GetGroup(ctx, ref.ID)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(scimGroupDisplayName(g), displayName) {
+				matched = append(matched, g)
+			}
+		}
+
+		var resources []any
+		start := min(options.StartIndex-1, len(matched))
+		end := min(start+options.Count, len(matched))
+		for _, g := range matched[start:end] {
+			scimGroup, err := f.toSCIMGroup(ctx, g, includeMembers)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, scimGroup)
+		}
+		return scim.NewListResponse(resources, len(matched), options.StartIndex), nil
+	}
+
+	pageArgs, err := scimPageArgs(options)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := f.RolesGroupsQueries.ListGroups(ctx, &rolesgroups.ListGroupsOptions{}, pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := f.RolesGroupsQueries.CountGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []any
+	for _, ref := range refs {
+		g, err := f.RolesGroupsQueries.GetGroup(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		scimGroup, err := f.toSCIMGroup(ctx, g, includeMembers)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, scimGroup)
+	}
+
+	//nolint:gosec // G115
+	return scim.NewListResponse(resources, int(totalCount), options.StartIndex), nil
+}
+
+func (f *SCIMFacade) GetGroup(ctx context.Context, id string, includeMembers bool) (*scim.Group, error) {
+	g, err := f.RolesGroupsQueries.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.toSCIMGroup(ctx, g, includeMembers)
+}
+
+func (f *SCIMFacade) CreateGroup(ctx context.Context, input *scim.Group) (*scim.Group, error) {
+	displayName := input.DisplayName
+	g, err := f.RolesGroupsCommands.CreateGroup(ctx, &rolesgroups.NewGroupOptions{
+		Key:  scimGroupKey(displayName, newSCIMGroupKeySuffix()),
+		Name: &displayName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.updateGroupMembers(ctx, g, nil, scimMemberIDs(input.Members))
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetGroup(ctx, g.ID, true)
+}
+
+func (f *SCIMFacade) ReplaceGroup(ctx context.Context, id string, input *scim.Group) (*scim.Group, error) {
+	g, err := f.RolesGroupsQueries.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := input.DisplayName
+	g, err = f.RolesGroupsCommands.UpdateGroup(ctx, &rolesgroups.UpdateGroupOptions{
+		ID:      id,
+		NewName: &displayName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := f.RolesGroupsQueries.ListAllUserIDsByGroupIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.updateGroupMembers(ctx, g, memberIDs, scimMemberIDs(input.Members))
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetGroup(ctx, id, true)
+}
+
+func (f *SCIMFacade) PatchGroup(ctx context.Context, id string, input *scim.PatchRequest) (*scim.Group, error) {
+	g, err := f.RolesGroupsQueries.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := f.RolesGroupsQueries.ListAllUserIDsByGroupIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	patch := &scimGroupPatch{
+		members: setutil.NewSetFromSlice(memberIDs, setutil.Identity[string]),
+	}
+
+	err = scimForEachOperation(input, patch.apply)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.displayName != nil {
+		g, err = f.RolesGroupsCommands.UpdateGroup(ctx, &rolesgroups.UpdateGroupOptions{
+			ID:      id,
+			NewName: patch.displayName,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = f.updateGroupMembers(ctx, g, memberIDs, patch.members.Keys())
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetGroup(ctx, id, true)
+}
+
+func (f *SCIMFacade) DeleteGroup(ctx context.Context, id string) error {
+	return f.RolesGroupsCommands.DeleteGroup(ctx, id)
+}
+
+func (f *SCIMFacade) replaceUser(ctx context.Context, userID string, input *scim.User) error {
+	u, err := f.UserQueries.Get(ctx, userID, accesscontrol.RoleGreatest)
+	if err != nil {
+		return err
+	}
+
+	userName := scimUserName(u.StandardAttributes)
+	if !strings.EqualFold(input.UserName, userName) {
+		return scim.Mutability.New("userName cannot be changed")
+	}
+
+	patch := &scimUserPatch{
+		userName: userName,
+		stdAttrs: maps.Clone(u.StandardAttributes),
+		active:   input.Active,
+	}
+	if patch.stdAttrs == nil {
+		patch.stdAttrs = make(map[string]any)
+	}
+
+	// PUT replaces all writable attributes, so attributes absent in input are removed.
+	for _, key := range scimUserAttributes {
+		delete(patch.stdAttrs, key)
+	}
+	for _, key := range scimNameAttributes {
+		delete(patch.stdAttrs, key)
+	}
+	// displayName and name.formatted are both stored as name.
+	// The values are applied in order, so name.formatted takes precedence over displayName.
+	values := []scimAttributeValue{
+		{"displayname", input.DisplayName},
+		{"nickname", input.NickName},
+		{"profileurl", input.ProfileURL},
+		{"locale", input.Locale},
+		{"timezone", input.Timezone},
+	}
+	if input.Name != nil {
+		values = append(values,
+			scimAttributeValue{"formatted", input.Name.Formatted},
+			scimAttributeValue{"familyname", input.Name.FamilyName},
+			scimAttributeValue{"givenname", input.Name.GivenName},
+			scimAttributeValue{"middlename", input.Name.MiddleName},
+		)
+	}
+	for _, v := range values {
+		key, ok := scimUserAttributes[v.Attr]
+		if !ok {
+			key = scimNameAttributes[v.Attr]
+		}
+		if v.Value != "" {
+			patch.stdAttrs[key] = v.Value
+		}
+	}
+
+	return f.applyUserPatch(ctx, u, patch)
+}
+
+func (f *SCIMFacade) applyUserPatch(ctx context.Context, u *apimodel.User, patch *scimUserPatch) error {
+	if scimStandardAttributesChanged(u.StandardAttributes, patch.stdAttrs) {
+		err := f.UserProfiles.UpdateUserProfile(ctx, accesscontrol.RoleGreatest, u.ID, patch.stdAttrs, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if patch.active != nil && *patch.active == u.IsDisabled {
+		err := f.Users.SetDisabled(ctx, facade.SetDisabledOptions{
+			UserID:     u.ID,
+			IsDisabled: !*patch.active,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *SCIMFacade) updateGroupMembers(ctx context.Context, g *apimodel.Group, oldMemberIDs []string, newMemberIDs []string) error {
+	oldSet := setutil.NewSetFromSlice(oldMemberIDs, setutil.Identity[string])
+	newSet := setutil.NewSetFromSlice(newMemberIDs, setutil.Identity[string])
+
+	added := newSet.Subtract(oldSet).Keys()
+	if len(added) > 0 {
+		_, err := f.RolesGroupsCommands.AddGroupToUsers(ctx, &rolesgroups.AddGroupToUsersOptions{
+			GroupKey: g.Key,
+			UserIDs:  added,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	removed := oldSet.Subtract(newSet).Keys()
+	if len(removed) > 0 {
+		_, err := f.RolesGroupsCommands.RemoveGroupFromUsers(ctx, &rolesgroups.RemoveGroupFromUsersOptions{
+			GroupKey: g.Key,
+			UserIDs:  removed,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *SCIMFacade) getUserIDByUserName(ctx context.Context, userName string) (string, error) {
+	for _, keyConfig := range f.LoginIDConfig.Keys {
+		userID, err := f.Users.GetUserByLoginID(ctx, keyConfig.Key, userName)
+		if errors.Is(err, api.ErrUserNotFound) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		return userID, nil
+	}
+	return "", api.ErrUserNotFound
+}
+
+// loginIDKeyForUserName picks the login ID key whose type matches the shape of userName.
+func (f *SCIMFacade) loginIDKeyForUserName(userName string) (string, error) {
+	typ := apimodel.LoginIDKeyTypeUsername
+	switch {
+	case strings.Contains(userName, "@"):
+		typ = apimodel.LoginIDKeyTypeEmail
+	case strings.HasPrefix(userName, "+"):
+		typ = apimodel.LoginIDKeyTypePhone
+	}
+
+	for _, keyConfig := range f.LoginIDConfig.Keys {
+		if keyConfig.Type == typ {
+			return keyConfig.Key, nil
+		}
+	}
+
+	return "", scim.InvalidValue.New(fmt.Sprintf("no login ID key of type %v is configured for userName", typ))
+}
+
+func (f *SCIMFacade) toSCIMUser(ctx context.Context, u *apimodel.User) (*scim.User, error) {
+	groups, err := f.RolesGroupsQueries.ListGroupsByUserID(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := stdattrs.T(u.StandardAttributes)
+	active := !u.IsDisabled
+	createdAt := u.CreatedAt
+	updatedAt := u.UpdatedAt
+
+	out := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          u.ID,
+		UserName:    scimUserName(u.StandardAttributes),
+		DisplayName: scimString(attrs, stdattrs.Name),
+		NickName:    scimString(attrs, stdattrs.Nickname),
+		ProfileURL:  scimString(attrs, stdattrs.Profile),
+		Locale:      scimString(attrs, stdattrs.Locale),
+		Timezone:    scimString(attrs, stdattrs.Zoneinfo),
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: scim.ResourceTypeUser,
+			Created:      &createdAt,
+			LastModified: &updatedAt,
+		},
+	}
+
+	name := &scim.Name{
+		Formatted:  scimString(attrs, stdattrs.Name),
+		FamilyName: scimString(attrs, stdattrs.FamilyName),
+		GivenName:  scimString(attrs, stdattrs.GivenName),
+		MiddleName: scimString(attrs, stdattrs.MiddleName),
+	}
+	if *name != (scim.Name{}) {
+		out.Name = name
+	}
+
+	if email := scimString(attrs, stdattrs.Email); email != "" {
+		out.Emails = []scim.MultiValuedAttribute{{Value: email, Primary: true}}
+	}
+	if phoneNumber := scimString(attrs, stdattrs.PhoneNumber); phoneNumber != "" {
+		out.PhoneNumbers = []scim.MultiValuedAttribute{{Value: phoneNumber, Primary: true}}
+	}
+
+	for _, g := range groups {
+		out.Groups = append(out.Groups, scim.MultiValuedAttribute{
+			Value:   g.ID,
+			Display: scimGroupDisplayName(g),
+		})
+	}
+
+	return out, nil
+}
+
+func (f *SCIMFacade) toSCIMGroup(ctx context.Context, g *apimodel.Group, includeMembers bool) (*scim.Group, error) {
+	createdAt := g.CreatedAt
+	updatedAt := g.UpdatedAt
+
+	out := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          g.ID,
+		DisplayName: scimGroupDisplayName(g),
+		Meta: &scim.Meta{
+			ResourceType: scim.ResourceTypeGroup,
+			Created:      &createdAt,
+			LastModified: &updatedAt,
+		},
+	}
+
+	if includeMembers {
+		memberIDs, err := f.RolesGroupsQueries.ListAllUserIDsByGroupIDs(ctx, []string{g.ID})
+		if err != nil {
+			return nil, err
+		}
+		for _, memberID := range memberIDs {
+			out.Members = append(out.Members, scim.MultiValuedAttribute{
+				Value: memberID,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+type scimUserPatch struct {
+	userName string
+	stdAttrs map[string]any
+	active   *bool
+}
+
+func (p *scimUserPatch) apply(op string, path *scim.Path, value any) error {
+	attr := strings.ToLower(path.Attr)
+
+	if _, ok := scimIgnoredUserAttributes[attr]; ok {
+		return nil
+	}
+
+	switch attr {
+	case "active":
+		if op == scim.PatchOpRemove {
+			return scim.Mutability.New("active cannot be removed")
+		}
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		p.active = &active
+		return nil
+	case "username":
+		s, ok := value.(string)
+		if op == scim.PatchOpRemove || !ok || !strings.EqualFold(s, p.userName) {
+			return scim.Mutability.New("userName cannot be changed")
+		}
+		return nil
+	case "groups":
+		return scim.Mutability.New("groups is read-only; update members of Groups instead")
+	case "name":
+		if path.SubAttr != "" {
+			key, ok := scimNameAttributes[strings.ToLower(path.SubAttr)]
+			if !ok {
+				return scim.InvalidPath.New(fmt.Sprintf("unsupported attribute: name.%v", path.SubAttr))
+			}
+			return p.set(op, key, value)
+		}
+
+		if op == scim.PatchOpRemove || op == scim.PatchOpReplace {
+			for _, key := range scimNameAttributes {
+				delete(p.stdAttrs, key)
+			}
+		}
+		if op == scim.PatchOpRemove {
+			return nil
+		}
+
+		m, ok := value.(map[string]any)
+		if !ok {
+			return scim.InvalidValue.New("name must be an object")
+		}
+		for subAttr, subValue := range m {
+			key, ok := scimNameAttributes[strings.ToLower(subAttr)]
+			if !ok {
+				return scim.InvalidPath.New(fmt.Sprintf("unsupported attribute: name.%v", subAttr))
+			}
+			err := p.set(op, key, subValue)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		key, ok := scimUserAttributes[attr]
+		if !ok || path.SubAttr != "" || path.ValueFilter != nil {
+			return scim.InvalidPath.New(fmt.Sprintf("unsupported attribute: %v", path.Attr))
+		}
+		return p.set(op, key, value)
+	}
+}
+
+func (p *scimUserPatch) set(op string, key string, value any) error {
+	if op == scim.PatchOpRemove {
+		delete(p.stdAttrs, key)
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return scim.InvalidValue.New(fmt.Sprintf("value of %v must be a string", key))
+	}
+
+	if s == "" {
+		delete(p.stdAttrs, key)
+	} else {
+		p.stdAttrs[key] = s
+	}
+	return nil
+}
+
+type scimGroupPatch struct {
+	displayName *string
+	members     setutil.Set[string]
+}
+
+func (p *scimGroupPatch) apply(op string, path *scim.Path, value any) error {
+	switch strings.ToLower(path.Attr) {
+	case "externalid":
+		return nil
+	case "displayname":
+		s, ok := value.(string)
+		if op == scim.PatchOpRemove || !ok || s == "" {
+			return scim.InvalidValue.New("displayName must be a non-empty string")
+		}
+		p.displayName = &s
+		return nil
+	case "members":
+		// members[value eq "id"]
+		if path.ValueFilter != nil {
+			memberID, err := scimFilterStringValue(path.ValueFilter, "value")
+			if err != nil {
+				return err
+			}
+			if op == scim.PatchOpRemove {
+				p.members.Delete(memberID)
+			} else {
+				p.members.Add(memberID)
+			}
+			return nil
+		}
+
+		var memberIDs []string
+		if value != nil {
+			items, ok := value.([]any)
+			if !ok {
+				return scim.InvalidValue.New("members must be an array")
+			}
+			for _, item := range items {
+				m, ok := item.(map[string]any)
+				if !ok {
+					return scim.InvalidValue.New("member must be an object")
+				}
+				memberID, ok := m["value"].(string)
+				if !ok {
+					return scim.InvalidValue.New("value of member must be a string")
+				}
+				memberIDs = append(memberIDs, memberID)
+			}
+		}
+
+		switch op {
+		case scim.PatchOpAdd:
+			for _, memberID := range memberIDs {
+				p.members.Add(memberID)
+			}
+		case scim.PatchOpReplace:
+			p.members = setutil.NewSetFromSlice(memberIDs, setutil.Identity[string])
+		case scim.PatchOpRemove:
+			// Removing members without value removes all members.
+			if value == nil {
+				p.members = setutil.Set[string]{}
+			}
+			for _, memberID := range memberIDs {
+				p.members.Delete(memberID)
+			}
+		}
+		return nil
+	default:
+		return scim.InvalidPath.New(fmt.Sprintf("unsupported attribute: %v", path.Attr))
+	}
+}
+
+// scimForEachOperation normalizes the operations of a PATCH request and calls fn on each of them.
+func scimForEachOperation(input *scim.PatchRequest, fn func(op string, path *scim.Path, value any) error) error {
+	for _, operation := range input.Operations {
+		path, err := operation.Normalize()
+		if err != nil {
+			return err
+		}
+
+		if path != nil {
+			err = fn(operation.Op, path, operation.Value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		flattened, err := operation.FlattenValue()
+		if err != nil {
+			return err
+		}
+		for _, o := range flattened {
+			path, err := o.Normalize()
+			if err != nil {
+				return err
+			}
+			err = fn(o.Op, path, o.Value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func scimFilterStringValue(filter *scim.Filter, attr string) (string, error) {
+	if !filter.IsAttr(attr) {
+		return "", scim.InvalidFilter.New(fmt.Sprintf("unsupported filter attribute: %v", filter.AttrPath))
+	}
+	s, ok := filter.StringValue()
+	if !ok {
+		return "", scim.InvalidFilter.New(fmt.Sprintf("%v must be compared with a string", attr))
+	}
+	return s, nil
+}
+
+// scimPageArgs converts the 1-based startIndex to page args.
+func scimPageArgs(options *scim.ListOptions) (graphqlutil.PageArgs, error) {
+	//nolint:gosec // G115
+	first := uint64(options.Count)
+	pageArgs := graphqlutil.PageArgs{First: &first}
+
+	// The offset of after is exclusive, so startIndex 2 means after offset 0.
+	if options.StartIndex > 1 {
+		//nolint:gosec // G115
+		pageKey := db.PageKey{Offset: uint64(options.StartIndex - 2)}
+		cursor, err := pageKey.ToPageCursor()
+		if err != nil {
+			return graphqlutil.PageArgs{}, err
+		}
+		pageArgs.After = graphqlutil.Cursor(cursor)
+	}
+
+	return pageArgs, nil
+}
+
+// scimUserName is the login ID that identifies the user, in the order of preference.
+func scimUserName(attrs map[string]any) string {
+	for _, key := range []string{stdattrs.PreferredUsername, stdattrs.Email, stdattrs.PhoneNumber} {
+		if s := scimString(attrs, key); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func scimStandardAttributesChanged(before map[string]any, after map[string]any) bool {
+	if len(before) == 0 && len(after) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(before, after)
+}
+
+func scimString(attrs map[string]any, key string) string {
+	s, _ := attrs[key].(string)
+	return s
+}
+
+// scimBool accepts "True" and "False" because some clients send booleans as strings.
+func scimBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, scim.InvalidValue.New("active must be a boolean")
+}
+
+func scimMemberIDs(members []scim.MultiValuedAttribute) []string {
+	var ids []string
+	for _, m := range members {
+		ids = append(ids, m.Value)
+	}
+	return ids
+}
+
+func scimGroupDisplayName(g *apimodel.Group) string {
+	if g.Name != nil && *g.Name != "" {
+		return *g.Name
+	}
+	return g.Key
+}
+
+const (
+	scimGroupKeyMaxLength    = 40
+	scimGroupKeySuffixLength = 8
+)
+
+// scimGroupKey derives a group key from displayName,
+// since SCIM does not have the concept of key.
+// Different displayNames can be reduced to the same key,
+// so suffix is appended to disambiguate them.
+func scimGroupKey(displayName string, suffix string) string {
+	var b strings.Builder
+	for i, r := range displayName {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		isDigit := r >= '0' && r <= '9'
+		switch {
+		case isLetter:
+			b.WriteRune(r)
+		case i > 0 && (isDigit || r == ':'):
+			b.WriteRune(r)
+		case i == 0 && isDigit:
+			b.WriteString("_")
+			b.WriteRune(r)
+		default:
+			b.WriteString("_")
+		}
+	}
+
+	key := b.String()
+	if maxLength := scimGroupKeyMaxLength - len(suffix) - 1; len(key) > maxLength {
+		key = key[:maxLength]
+	}
+	return key + "_" + suffix
+}
+
+func newSCIMGroupKeySuffix() string {
+	return strings.ToLower(corerand.StringWithAlphabet(scimGroupKeySuffixLength, base32.Alphabet, corerand.SecureRand))
+}
@@ -0,0 +1,381 @@
+package facade
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	apimodel "github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
+	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
+	"github.com/authgear/authgear-server/pkg/lib/scim"
+	"github.com/authgear/authgear-server/pkg/util/accesscontrol"
+)
+
+type fakeSCIMRolesGroupsQueries struct {
+	RolesGroupsQueries
+	groups    map[string]*apimodel.Group
+	memberIDs map[string][]string
+}
+
+func (f *fakeSCIMRolesGroupsQueries) GetGroup(ctx context.Context, id string) (*apimodel.Group, error) {
+	return f.groups[id], nil
+}
+
+func (f *fakeSCIMRolesGroupsQueries) ListAllUserIDsByGroupIDs(ctx context.Context, groupIDs []string) ([]string, error) {
+	var userIDs []string
+	for _, groupID := range groupIDs {
+		userIDs = append(userIDs, f.memberIDs[groupID]...)
+	}
+	return userIDs, nil
+}
+
+type fakeSCIMRolesGroupsCommands struct {
+	RolesGroupsCommands
+	queries      *fakeSCIMRolesGroupsQueries
+	createdKeys  []string
+	addedUserIDs []string
+	removedUsers []string
+}
+
+func (f *fakeSCIMRolesGroupsCommands) CreateGroup(ctx context.Context, options *rolesgroups.NewGroupOptions) (*apimodel.Group, error) {
+	f.createdKeys = append(f.createdKeys, options.Key)
+	g := &apimodel.Group{
+		Meta: apimodel.Meta{ID: "group-" + options.Key},
+		Key:  options.Key,
+		Name: options.Name,
+	}
+	f.queries.groups[g.ID] = g
+	return g, nil
+}
+
+func (f *fakeSCIMRolesGroupsCommands) UpdateGroup(ctx context.Context, options *rolesgroups.UpdateGroupOptions) (*apimodel.Group, error) {
+	g := f.queries.groups[options.ID]
+	if options.NewName != nil {
+		g.Name = options.NewName
+	}
+	return g, nil
+}
+
+func (f *fakeSCIMRolesGroupsCommands) AddGroupToUsers(ctx context.Context, options *rolesgroups.AddGroupToUsersOptions) (*apimodel.Group, error) {
+	f.addedUserIDs = append(f.addedUserIDs, options.UserIDs...)
+	sort.Strings(f.addedUserIDs)
+	return nil, nil
+}
+
+func (f *fakeSCIMRolesGroupsCommands) RemoveGroupFromUsers(ctx context.Context, options *rolesgroups.RemoveGroupFromUsersOptions) (*apimodel.Group, error) {
+	f.removedUsers = append(f.removedUsers, options.UserIDs...)
+	sort.Strings(f.removedUsers)
+	return nil, nil
+}
+
+func TestSCIMGroupKey(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("scimGroupKey", t, func() {
+		Convey("should replace unsupported characters", func() {
+			So(scimGroupKey("Sales Team (EU)", "abcdefgh"), ShouldEqual, "Sales_Team__EU__abcdefgh")
+			So(scimGroupKey("營業部", "abcdefgh"), ShouldEqual, "____abcdefgh")
+			So(scimGroupKey("team:admin_1", "abcdefgh"), ShouldEqual, "team:admin_1_abcdefgh")
+		})
+
+		Convey("should prefix a leading digit", func() {
+			So(scimGroupKey("1st Line", "abcdefgh"), ShouldEqual, "_1st_Line_abcdefgh")
+			So(scimGroupKey(":admin", "abcdefgh"), ShouldEqual, "_admin_abcdefgh")
+		})
+
+		Convey("should truncate to the maximum length of keys", func() {
+			key := scimGroupKey(strings.Repeat("a", 100), "abcdefgh")
+			So(key, ShouldEqual, strings.Repeat("a", 31)+"_abcdefgh")
+			So(len(key), ShouldEqual, 40)
+		})
+
+		Convey("should not collide for displayNames that are reduced to the same key", func() {
+			a := scimGroupKey("Sales Team (EU)", newSCIMGroupKeySuffix())
+			b := scimGroupKey("Sales Team [EU]", newSCIMGroupKeySuffix())
+			So(a, ShouldNotEqual, b)
+		})
+
+		Convey("should be valid keys", func() {
+			for _, displayName := range []string{"Sales Team (EU)", "營業部", "1st Line", "", strings.Repeat("長", 50)} {
+				key := scimGroupKey(displayName, newSCIMGroupKeySuffix())
+				So(rolesgroups.ValidateKey(ctx, key), ShouldBeNil)
+			}
+		})
+	})
+}
+
+func TestSCIMFacadeGroups(t *testing.T) {
+	Convey("SCIMFacade groups", t, func() {
+		ctx := context.Background()
+
+		name := "Sales"
+		queries := &fakeSCIMRolesGroupsQueries{
+			groups: map[string]*apimodel.Group{
+				"group-id": {
+					Meta: apimodel.Meta{ID: "group-id"},
+					Key:  "Sales_abcdefgh",
+					Name: &name,
+				},
+			},
+			memberIDs: map[string][]string{
+				"group-id": {"user-1", "user-2"},
+			},
+		}
+		commands := &fakeSCIMRolesGroupsCommands{
+			queries: queries,
+		}
+		f := &SCIMFacade{
+			RolesGroupsQueries:  queries,
+			RolesGroupsCommands: commands,
+		}
+
+		patch := func(operations ...*scim.PatchOperation) error {
+			_, err := f.PatchGroup(ctx, "group-id", &scim.PatchRequest{
+				Schemas:    []string{scim.SchemaPatchOp},
+				Operations: operations,
+			})
+			return err
+		}
+
+		Convey("should create groups with disambiguated keys", func() {
+			_, err := f.CreateGroup(ctx, &scim.Group{DisplayName: "Sales Team (EU)"})
+			So(err, ShouldBeNil)
+			_, err = f.CreateGroup(ctx, &scim.Group{DisplayName: "Sales Team [EU]"})
+			So(err, ShouldBeNil)
+
+			So(commands.createdKeys, ShouldHaveLength, 2)
+			So(commands.createdKeys[0], ShouldStartWith, "Sales_Team__EU__")
+			So(commands.createdKeys[1], ShouldStartWith, "Sales_Team__EU__")
+			So(commands.createdKeys[0], ShouldNotEqual, commands.createdKeys[1])
+		})
+
+		Convey("should add members", func() {
+			err := patch(&scim.PatchOperation{
+				Op:   "Add",
+				Path: "members",
+				Value: []any{
+					map[string]any{"value": "user-2"},
+					map[string]any{"value": "user-3"},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(commands.addedUserIDs, ShouldResemble, []string{"user-3"})
+			So(commands.removedUsers, ShouldBeEmpty)
+		})
+
+		Convey("should remove a member by value filter", func() {
+			err := patch(&scim.PatchOperation{
+				Op:   "remove",
+				Path: `members[value eq "user-1"]`,
+			})
+			So(err, ShouldBeNil)
+			So(commands.addedUserIDs, ShouldBeEmpty)
+			So(commands.removedUsers, ShouldResemble, []string{"user-1"})
+		})
+
+		Convey("should remove all members without value", func() {
+			err := patch(&scim.PatchOperation{
+				Op:   "remove",
+				Path: "members",
+			})
+			So(err, ShouldBeNil)
+			So(commands.removedUsers, ShouldResemble, []string{"user-1", "user-2"})
+		})
+
+		Convey("should replace members", func() {
+			err := patch(&scim.PatchOperation{
+				Op:   "replace",
+				Path: "members",
+				Value: []any{
+					map[string]any{"value": "user-2"},
+					map[string]any{"value": "user-3"},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(commands.addedUserIDs, ShouldResemble, []string{"user-3"})
+			So(commands.removedUsers, ShouldResemble, []string{"user-1"})
+		})
+
+		Convey("should replace displayName without path and keep the key", func() {
+			err := patch(&scim.PatchOperation{
+				Op:    "replace",
+				Value: map[string]any{"displayName": "Sales EU"},
+			})
+			So(err, ShouldBeNil)
+			So(*queries.groups["group-id"].Name, ShouldEqual, "Sales EU")
+			So(queries.groups["group-id"].Key, ShouldEqual, "Sales_abcdefgh")
+		})
+
+		Convey("should reject removing displayName", func() {
+			err := patch(&scim.PatchOperation{
+				Op:   "remove",
+				Path: "displayName",
+			})
+			So(apierrors.IsKind(err, scim.InvalidValue), ShouldBeTrue)
+		})
+
+		Convey("should reject unsupported attributes", func() {
+			err := patch(&scim.PatchOperation{
+				Op:    "replace",
+				Path:  "description",
+				Value: "foobar",
+			})
+			So(apierrors.IsKind(err, scim.InvalidPath), ShouldBeTrue)
+		})
+	})
+}
+
+func TestSCIMUserPatch(t *testing.T) {
+	Convey("scimUserPatch", t, func() {
+		p := &scimUserPatch{
+			userName: "john@example.com",
+			stdAttrs: map[string]any{
+				stdattrs.Email:     "john@example.com",
+				stdattrs.Nickname:  "Johnny",
+				stdattrs.GivenName: "John",
+			},
+		}
+
+		apply := func(operations ...*scim.PatchOperation) error {
+			return scimForEachOperation(&scim.PatchRequest{
+				Schemas:    []string{scim.SchemaPatchOp},
+				Operations: operations,
+			}, p.apply)
+		}
+
+		Convey("should map attributes to standard attributes", func() {
+			err := apply(
+				&scim.PatchOperation{Op: "replace", Path: "name.familyName", Value: "Doe"},
+				&scim.PatchOperation{Op: "remove", Path: "nickName"},
+				&scim.PatchOperation{Op: "replace", Value: map[string]any{"locale": "en-US"}},
+			)
+			So(err, ShouldBeNil)
+			So(p.stdAttrs, ShouldResemble, map[string]any{
+				stdattrs.Email:      "john@example.com",
+				stdattrs.GivenName:  "John",
+				stdattrs.FamilyName: "Doe",
+				stdattrs.Locale:     "en-US",
+			})
+		})
+
+		Convey("should replace the whole name", func() {
+			err := apply(&scim.PatchOperation{
+				Op:    "replace",
+				Path:  "name",
+				Value: map[string]any{"familyName": "Doe"},
+			})
+			So(err, ShouldBeNil)
+			So(p.stdAttrs, ShouldResemble, map[string]any{
+				stdattrs.Email:      "john@example.com",
+				stdattrs.Nickname:   "Johnny",
+				stdattrs.FamilyName: "Doe",
+			})
+		})
+
+		Convey("should accept active as a string", func() {
+			err := apply(&scim.PatchOperation{Op: "Replace", Value: map[string]any{"active": "False"}})
+			So(err, ShouldBeNil)
+			So(*p.active, ShouldBeFalse)
+		})
+
+		Convey("should ignore emails", func() {
+			err := apply(&scim.PatchOperation{
+				Op:    "replace",
+				Path:  "emails",
+				Value: []any{map[string]any{"value": "jane@example.com"}},
+			})
+			So(err, ShouldBeNil)
+			So(p.stdAttrs[stdattrs.Email], ShouldEqual, "john@example.com")
+		})
+
+		Convey("should reject changing userName", func() {
+			err := apply(&scim.PatchOperation{Op: "replace", Path: "userName", Value: "jane@example.com"})
+			So(apierrors.IsKind(err, scim.Mutability), ShouldBeTrue)
+
+			err = apply(&scim.PatchOperation{Op: "replace", Path: "userName", Value: "JOHN@example.com"})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should reject groups", func() {
+			err := apply(&scim.PatchOperation{Op: "add", Path: "groups", Value: []any{}})
+			So(apierrors.IsKind(err, scim.Mutability), ShouldBeTrue)
+		})
+	})
+}
+
+type fakeSCIMUserQueries struct {
+	SCIMUserQueries
+	user *apimodel.User
+}
+
+func (f *fakeSCIMUserQueries) Get(ctx context.Context, id string, role accesscontrol.Role) (*apimodel.User, error) {
+	return f.user, nil
+}
+
+type fakeSCIMUserProfileFacade struct {
+	stdAttrs map[string]any
+}
+
+func (f *fakeSCIMUserProfileFacade) UpdateUserProfile(ctx context.Context, role accesscontrol.Role, userID string, stdAttrs map[string]any, customAttrs map[string]any) error {
+	f.stdAttrs = stdAttrs
+	return nil
+}
+
+func TestSCIMFacadeReplaceUser(t *testing.T) {
+	Convey("SCIMFacade replaceUser", t, func() {
+		ctx := context.Background()
+
+		userProfiles := &fakeSCIMUserProfileFacade{}
+		f := &SCIMFacade{
+			UserQueries: &fakeSCIMUserQueries{
+				user: &apimodel.User{
+					Meta: apimodel.Meta{ID: "user-id"},
+					StandardAttributes: map[string]any{
+						stdattrs.Email: "user@example.com",
+						stdattrs.Name:  "Old Name",
+					},
+				},
+			},
+			UserProfiles: userProfiles,
+		}
+
+		Convey("should prefer name.formatted to displayName", func() {
+			err := f.replaceUser(ctx, "user-id", &scim.User{
+				UserName:    "user@example.com",
+				DisplayName: "John",
+				Name: &scim.Name{
+					Formatted: "John Doe",
+				},
+			})
+			So(err, ShouldBeNil)
+			So(userProfiles.stdAttrs[stdattrs.Name], ShouldEqual, "John Doe")
+		})
+
+		Convey("should use displayName without name.formatted", func() {
+			err := f.replaceUser(ctx, "user-id", &scim.User{
+				UserName:    "user@example.com",
+				DisplayName: "John",
+				Name: &scim.Name{
+					GivenName: "John",
+				},
+			})
+			So(err, ShouldBeNil)
+			So(userProfiles.stdAttrs[stdattrs.Name], ShouldEqual, "John")
+			So(userProfiles.stdAttrs[stdattrs.GivenName], ShouldEqual, "John")
+		})
+
+		Convey("should remove name if both are absent", func() {
+			err := f.replaceUser(ctx, "user-id", &scim.User{
+				UserName: "user@example.com",
+			})
+			So(err, ShouldBeNil)
+			So(userProfiles.stdAttrs, ShouldNotContainKey, stdattrs.Name)
+			So(userProfiles.stdAttrs[stdattrs.Email], ShouldEqual, "user@example.com")
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package facade
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type WebhookDeliveryStore interface {
+	GetDeliveryByID(ctx context.Context, id string) (*hook.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, options *hook.WebhookDeliveryListOptions, pageArgs graphqlutil.PageArgs) ([]*hook.WebhookDelivery, uint64, error)
+	CountDeliveries(ctx context.Context, options *hook.WebhookDeliveryListOptions) (uint64, error)
+	ListAttemptsByDeliveryID(ctx context.Context, deliveryID string) ([]*hook.WebhookDeliveryAttempt, error)
+}
+
+type WebhookDeliveryService interface {
+	Redeliver(ctx context.Context, deliveryID string) (*hook.WebhookDelivery, error)
+}
+
+type WebhookDeliveryFacade struct {
+	Store      WebhookDeliveryStore
+	Deliveries WebhookDeliveryService
+}
+
+func (f *WebhookDeliveryFacade) Get(ctx context.Context, id string) (*hook.WebhookDelivery, error) {
+	return f.Store.GetDeliveryByID(ctx, id)
+}
+
+func (f *WebhookDeliveryFacade) ListPage(
+	ctx context.Context,
+	options *hook.WebhookDeliveryListOptions,
+	pageArgs graphqlutil.PageArgs,
+) ([]*hook.WebhookDelivery, uint64, *graphqlutil.PageResult, error) {
+	items, offset, err := f.Store.ListDeliveries(ctx, options, pageArgs)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	count, err := f.Store.CountDeliveries(ctx, options)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return items, offset, graphqlutil.NewPageResult(pageArgs, len(items), graphqlutil.NewLazy(func() (any, error) {
+		return count, nil
+	})), nil
+}
+
+func (f *WebhookDeliveryFacade) ListAttempts(ctx context.Context, deliveryID string) ([]*hook.WebhookDeliveryAttempt, error) {
+	return f.Store.ListAttemptsByDeliveryID(ctx, deliveryID)
+}
+
+func (f *WebhookDeliveryFacade) Redeliver(ctx context.Context, deliveryID string) (*hook.WebhookDelivery, error) {
+	return f.Deliveries.Redeliver(ctx, deliveryID)
+}
@@ -155,6 +155,12 @@ var auditLogActivityType = graphql.NewEnum(graphql.EnumConfig{
 		"IDENTITY_BIOMETRIC_DISABLED": &graphql.EnumValueConfig{
 			Value: "identity.biometric.disabled",
 		},
+		"IDENTITY_LDAP_UPDATED": &graphql.EnumValueConfig{
+			Value: "identity.ldap.updated",
+		},
+		"IDENTITY_LDAP_GROUPS_SYNCED": &graphql.EnumValueConfig{
+			Value: "identity.ldap.groups_synced",
+		},
 		"M2M_TOKEN_CREATED": &graphql.EnumValueConfig{
 			Value: "m2m.token.created",
 		},
@@ -206,6 +212,36 @@ var auditLogActivityType = graphql.NewEnum(graphql.EnumConfig{
 		"FRAUD_PROTECTION_DECISION_RECORDED": &graphql.EnumValueConfig{
 			Value: "fraud_protection.decision_recorded",
 		},
+		"RISK_DECISION_RECORDED": &graphql.EnumValueConfig{
+			Value: "risk.decision_recorded",
+		},
+		"ORGANIZATION_CREATED": &graphql.EnumValueConfig{
+			Value: "organization.created",
+		},
+		"ORGANIZATION_UPDATED": &graphql.EnumValueConfig{
+			Value: "organization.updated",
+		},
+		"ORGANIZATION_DELETED": &graphql.EnumValueConfig{
+			Value: "organization.deleted",
+		},
+		"ORGANIZATION_MEMBER_ADDED": &graphql.EnumValueConfig{
+			Value: "organization.member.added",
+		},
+		"ORGANIZATION_MEMBER_UPDATED": &graphql.EnumValueConfig{
+			Value: "organization.member.updated",
+		},
+		"ORGANIZATION_MEMBER_REMOVED": &graphql.EnumValueConfig{
+			Value: "organization.member.removed",
+		},
+		"ORGANIZATION_INVITATION_CREATED": &graphql.EnumValueConfig{
+			Value: "organization.invitation.created",
+		},
+		"ORGANIZATION_INVITATION_DELETED": &graphql.EnumValueConfig{
+			Value: "organization.invitation.deleted",
+		},
+		"ORGANIZATION_INVITATION_ACCEPTED": &graphql.EnumValueConfig{
+			Value: "organization.invitation.accepted",
+		},
 		"ADMIN_API_MUTATION_ANONYMIZE_USER_EXECUTED": &graphql.EnumValueConfig{
 			Value: "admin_api.mutation.anonymize_user.executed",
 		},
@@ -260,6 +296,9 @@ var auditLogActivityType = graphql.NewEnum(graphql.EnumConfig{
 		"ADMIN_API_MUTATION_GENERATE_OOB_OTP_CODE_EXECUTED": &graphql.EnumValueConfig{
 			Value: "admin_api.mutation.generate_oob_otp_code.executed",
 		},
+		"ADMIN_API_MUTATION_REDELIVER_WEBHOOK_DELIVERY_EXECUTED": &graphql.EnumValueConfig{
+			Value: "admin_api.mutation.redeliver_webhook_delivery.executed",
+		},
 		"ADMIN_API_MUTATION_RESET_ACCOUNT_LOCKOUT_EXECUTED": &graphql.EnumValueConfig{
 			Value: "admin_api.mutation.reset_account_lockout.executed",
 		},
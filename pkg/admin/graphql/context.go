@@ -15,8 +15,10 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/facade"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/resourcescope"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
 	"github.com/authgear/authgear-server/pkg/lib/session"
@@ -45,6 +47,10 @@ type GroupLoader interface {
 	graphqlutil.DataLoaderInterface
 }
 
+type OrganizationLoader interface {
+	graphqlutil.DataLoaderInterface
+}
+
 type AuditLogLoader interface {
 	graphqlutil.DataLoaderInterface
 }
@@ -189,6 +195,8 @@ type AuthorizationFacade interface {
 
 type OAuthFacade interface {
 	CreateSession(ctx context.Context, clientID string, userID string, deviceInfo map[string]any) (session.ListableSession, protocol.TokenResponse, error)
+	CreateInitialAccessToken(ctx context.Context, lifetime time.Duration) (string, *oauth.InitialAccessToken, error)
+	ResetRegistrationAccessToken(ctx context.Context, clientID string) (string, error)
 }
 
 type AccountLockoutFacade interface {
@@ -196,6 +204,13 @@ type AccountLockoutFacade interface {
 	ResetAccountLockout(ctx context.Context, userID string) error
 }
 
+type WebhookDeliveryFacade interface {
+	Get(ctx context.Context, id string) (*hook.WebhookDelivery, error)
+	ListPage(ctx context.Context, options *hook.WebhookDeliveryListOptions, pageArgs graphqlutil.PageArgs) ([]*hook.WebhookDelivery, uint64, *graphqlutil.PageResult, error)
+	ListAttempts(ctx context.Context, deliveryID string) ([]*hook.WebhookDeliveryAttempt, error)
+	Redeliver(ctx context.Context, deliveryID string) (*hook.WebhookDelivery, error)
+}
+
 type SessionListingService interface {
 	FilterForDisplay(ctx context.Context, sessions []session.ListableSession, currentSession session.ResolvedSession) ([]*sessionlisting.Session, error)
 }
@@ -230,6 +245,26 @@ type ResourceScopeFacade interface {
 	ReplaceScopesOfClientID(ctx context.Context, resourceURI, clientID string, scopes []string) ([]*apimodel.Scope, error)
 }
 
+type OrganizationFacade interface {
+	CreateOrganization(ctx context.Context, options *organization.NewOrganizationOptions) (string, error)
+	UpdateOrganization(ctx context.Context, options *organization.UpdateOrganizationOptions) error
+	DeleteOrganization(ctx context.Context, id string) (memberUserIDs []string, err error)
+	GetOrganization(ctx context.Context, id string) (*apimodel.Organization, error)
+	ListOrganizations(ctx context.Context, options *organization.ListOrganizationsOptions, pageArgs graphqlutil.PageArgs) ([]apimodel.PageItemRef, *graphqlutil.PageResult, error)
+	ListOrganizationsByUserID(ctx context.Context, userID string) ([]*apimodel.Organization, error)
+
+	GetMember(ctx context.Context, organizationID string, userID string) (*apimodel.OrganizationMember, error)
+	ListMembers(ctx context.Context, organizationID string, pageArgs graphqlutil.PageArgs) ([]*apimodel.OrganizationMember, []apimodel.PageCursor, *graphqlutil.PageResult, error)
+	AddMember(ctx context.Context, organizationID string, userID string, roleKeys []string) (*apimodel.OrganizationMember, error)
+	RemoveMember(ctx context.Context, organizationID string, userID string) error
+	UpdateMemberRoles(ctx context.Context, organizationID string, userID string, roleKeys []string) (*apimodel.OrganizationMember, error)
+
+	CreateInvitation(ctx context.Context, options *organization.NewInvitationOptions) (*apimodel.OrganizationInvitation, error)
+	DeleteInvitation(ctx context.Context, id string) error
+	GetInvitation(ctx context.Context, id string) (*apimodel.OrganizationInvitation, error)
+	ListInvitations(ctx context.Context, organizationID string) ([]*apimodel.OrganizationInvitation, error)
+}
+
 type Context struct {
 	Config                *config.AppConfig
 	OAuthConfig           *config.OAuthConfig
@@ -240,27 +275,30 @@ type Context struct {
 	Authenticators  AuthenticatorLoader
 	Roles           RoleLoader
 	Groups          GroupLoader
+	Organizations   OrganizationLoader
 	AuditLogs       AuditLogLoader
 	Resources       ResourceLoader
 	ResourceClients ResourceClientLoader
 	Scopes          ScopeLoader
 
-	UserFacade           UserFacade
-	RolesGroupsFacade    RolesGroupsFacade
-	AuditLogFacade       AuditLogFacade
-	IdentityFacade       IdentityFacade
-	AuthenticatorFacade  AuthenticatorFacade
-	VerificationFacade   VerificationFacade
-	SessionFacade        SessionFacade
-	UserProfileFacade    UserProfileFacade
-	AuthorizationFacade  AuthorizationFacade
-	OAuthFacade          OAuthFacade
-	SessionListing       SessionListingService
-	OTPCode              OTPCodeService
-	ForgotPassword       ForgotPasswordService
-	Events               EventService
-	ResourceScopeFacade  ResourceScopeFacade
-	AccountLockoutFacade AccountLockoutFacade
+	UserFacade            UserFacade
+	RolesGroupsFacade     RolesGroupsFacade
+	OrganizationFacade    OrganizationFacade
+	AuditLogFacade        AuditLogFacade
+	IdentityFacade        IdentityFacade
+	AuthenticatorFacade   AuthenticatorFacade
+	VerificationFacade    VerificationFacade
+	SessionFacade         SessionFacade
+	UserProfileFacade     UserProfileFacade
+	AuthorizationFacade   AuthorizationFacade
+	OAuthFacade           OAuthFacade
+	SessionListing        SessionListingService
+	OTPCode               OTPCodeService
+	ForgotPassword        ForgotPasswordService
+	Events                EventService
+	ResourceScopeFacade   ResourceScopeFacade
+	AccountLockoutFacade  AccountLockoutFacade
+	WebhookDeliveryFacade WebhookDeliveryFacade
 }
 
 func WithContext(ctx context.Context, gqlContext *Context) context.Context {
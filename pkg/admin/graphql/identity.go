@@ -37,6 +37,9 @@ var identityType = graphql.NewEnum(graphql.EnumConfig{
 		"LDAP": &graphql.EnumValueConfig{
 			Value: string(model.IdentityTypeLDAP),
 		},
+		"SAML": &graphql.EnumValueConfig{
+			Value: string(model.IdentityTypeSAML),
+		},
 	},
 })
 
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+)
+
+var createOAuthInitialAccessTokenInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateOAuthInitialAccessTokenInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"expiresIn": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Int,
+			Description: "Lifetime of the initial access token in seconds. Default to 1 day, and at most 30 days.",
+		},
+	},
+})
+
+var createOAuthInitialAccessTokenPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "CreateOAuthInitialAccessTokenPayload",
+	Fields: graphql.Fields{
+		"initialAccessToken": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"expireAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"createOAuthInitialAccessToken",
+	&graphql.Field{
+		Description: "Create a single-use initial access token for the OAuth client registration endpoint",
+		Type:        graphql.NewNonNull(createOAuthInitialAccessTokenPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(createOAuthInitialAccessTokenInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			input := p.Args["input"].(map[string]any)
+
+			lifetime := oauth.InitialAccessTokenDefaultLifetime
+			if expiresIn, ok := input["expiresIn"].(int); ok {
+				lifetime = time.Duration(expiresIn) * time.Second
+				if lifetime <= 0 || lifetime > oauth.InitialAccessTokenMaxLifetime {
+					return nil, apierrors.NewInvalid("invalid expiresIn")
+				}
+			}
+
+			token, t, err := gqlCtx.OAuthFacade.CreateInitialAccessToken(ctx, lifetime)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{
+				"initialAccessToken": token,
+				"expireAt":           t.ExpireAt,
+			}, nil
+		},
+	},
+)
+
+var resetOAuthClientRegistrationAccessTokenInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "ResetOAuthClientRegistrationAccessTokenInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"clientID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "The client ID of the third-party app.",
+		},
+	},
+})
+
+var resetOAuthClientRegistrationAccessTokenPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ResetOAuthClientRegistrationAccessTokenPayload",
+	Fields: graphql.Fields{
+		"registrationAccessToken": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"resetOAuthClientRegistrationAccessToken",
+	&graphql.Field{
+		Description: "Issue a new registration access token to a third-party app, and revoke the previous one",
+		Type:        graphql.NewNonNull(resetOAuthClientRegistrationAccessTokenPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(resetOAuthClientRegistrationAccessTokenInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			input := p.Args["input"].(map[string]any)
+			clientID := input["clientID"].(string)
+
+			token, err := gqlCtx.OAuthFacade.ResetRegistrationAccessToken(ctx, clientID)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{
+				"registrationAccessToken": token,
+			}, nil
+		},
+	},
+)
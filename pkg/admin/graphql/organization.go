@@ -0,0 +1,210 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+
+	relay "github.com/authgear/authgear-server/pkg/graphqlgo/relay"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+func init() {
+	// Organization and user forms a initialization cycle.
+	// So we break the cycle by using AddFieldConfig.
+	nodeOrganization.AddFieldConfig("members", &graphql.Field{
+		Type:        connOrganizationMember.ConnectionType,
+		Description: "The list of members of the organization.",
+		Args:        relay.NewConnectionArgs(graphql.FieldConfigArgument{}),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			source := p.Source.(*model.Organization)
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+			pageArgs := graphqlutil.NewPageArgs(relay.NewConnectionArguments(p.Args))
+
+			members, cursors, result, err := gqlCtx.OrganizationFacade.ListMembers(ctx, source.ID, pageArgs)
+			if err != nil {
+				return nil, err
+			}
+
+			var lazyItems []graphqlutil.LazyItem
+			for i, m := range members {
+				lazyItems = append(lazyItems, graphqlutil.LazyItem{
+					Lazy:   graphqlutil.NewLazyValue(m),
+					Cursor: graphqlutil.Cursor(cursors[i]),
+				})
+			}
+
+			return graphqlutil.NewConnectionFromResult(lazyItems, result)
+		},
+	})
+
+	nodeOrganization.AddFieldConfig("invitations", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(organizationInvitation))),
+		Description: "The list of invitations of the organization, including accepted and expired ones.",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			source := p.Source.(*model.Organization)
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			return gqlCtx.OrganizationFacade.ListInvitations(ctx, source.ID)
+		},
+	})
+
+	nodeUser.AddFieldConfig("organizations", &graphql.Field{
+		Type:        connOrganization.ConnectionType,
+		Description: "The list of organizations this user is a member of.",
+		Args:        relay.NewConnectionArgs(graphql.FieldConfigArgument{}),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			source := p.Source.(*model.User)
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			organizations, err := gqlCtx.OrganizationFacade.ListOrganizationsByUserID(ctx, source.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			organizationIfaces := make([]any, len(organizations))
+			for i, o := range organizations {
+				organizationIfaces[i] = o
+			}
+
+			args := relay.NewConnectionArguments(p.Args)
+			return graphqlutil.NewConnectionFromArray(organizationIfaces, args), nil
+		},
+	})
+}
+
+const typeOrganization = "Organization"
+
+var nodeOrganization = node(
+	graphql.NewObject(graphql.ObjectConfig{
+		Name:        typeOrganization,
+		Description: "Authgear organization",
+		Interfaces: []*graphql.Interface{
+			nodeDefs.NodeInterface,
+			entityInterface,
+		},
+		Fields: graphql.Fields{
+			"id":        entityIDField(typeOrganization),
+			"createdAt": entityCreatedAtField(nil),
+			"updatedAt": entityUpdatedAtField(nil),
+			"key": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The key of the organization.",
+			},
+			"name": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The optional name of the organization.",
+			},
+			"description": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The optional description of the organization.",
+			},
+		},
+	}),
+	&model.Organization{},
+	func(ctx context.Context, gqlCtx *Context, id string) (any, error) {
+		return gqlCtx.Organizations.Load(ctx, id).Value, nil
+	},
+)
+
+var connOrganization = graphqlutil.NewConnectionDef(nodeOrganization)
+
+var organizationMember = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "OrganizationMember",
+	Description: "A user in an organization, with the roles the user has in the organization.",
+	Fields: graphql.Fields{
+		"createdAt": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.DateTime),
+			Description: "The time the user joined the organization.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationMember).CreatedAt, nil
+			},
+		},
+		"updatedAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationMember).UpdatedAt, nil
+			},
+		},
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source := p.Source.(*model.OrganizationMember)
+				ctx := p.Context
+				gqlCtx := GQLContext(ctx)
+				return gqlCtx.Users.Load(ctx, source.UserID).Value, nil
+			},
+		},
+		"roleKeys": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			Description: "The keys of the roles the user has in the organization.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationMember).RoleKeys, nil
+			},
+		},
+	},
+})
+
+var connOrganizationMember = graphqlutil.NewConnectionDef(organizationMember)
+
+var organizationInvitation = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "OrganizationInvitation",
+	Description: "An invitation to join an organization, addressed to an email.",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the invitation.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationInvitation).ID, nil
+			},
+		},
+		"createdAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationInvitation).CreatedAt, nil
+			},
+		},
+		"email": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "The invited email.",
+		},
+		"roleKeys": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+			Description: "The keys of the roles the user will have in the organization.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationInvitation).RoleKeys, nil
+			},
+		},
+		"expireAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationInvitation).ExpireAt, nil
+			},
+		},
+		"acceptedAt": &graphql.Field{
+			Type:        graphql.DateTime,
+			Description: "The time the invitation was accepted.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				return p.Source.(*model.OrganizationInvitation).AcceptedAt, nil
+			},
+		},
+		"acceptedBy": &graphql.Field{
+			Type:        nodeUser,
+			Description: "The user who accepted the invitation.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source := p.Source.(*model.OrganizationInvitation)
+				if source.AcceptedByUserID == nil {
+					return nil, nil
+				}
+				ctx := p.Context
+				gqlCtx := GQLContext(ctx)
+				return gqlCtx.Users.Load(ctx, *source.AcceptedByUserID).Value, nil
+			},
+		},
+	},
+})
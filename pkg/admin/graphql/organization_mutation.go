@@ -0,0 +1,677 @@
+package graphql
+
+import (
+	"time"
+
+	relay "github.com/authgear/authgear-server/pkg/graphqlgo/relay"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+func resolveOrganizationID(nodeID string) (string, error) {
+	resolvedNodeID := relay.FromGlobalID(nodeID)
+	if resolvedNodeID == nil || resolvedNodeID.Type != typeOrganization {
+		return "", apierrors.NewInvalid("invalid organization ID")
+	}
+	return resolvedNodeID.ID, nil
+}
+
+func resolveOrganizationMemberUserID(nodeID string) (string, error) {
+	resolvedNodeID := relay.FromGlobalID(nodeID)
+	if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+		return "", apierrors.NewInvalid("invalid user ID")
+	}
+	return resolvedNodeID.ID, nil
+}
+
+func parseOrganizationRoleKeys(input map[string]any) []string {
+	roleKeys := []string{}
+	if ifaces, ok := input["roleKeys"].([]any); ok {
+		for _, v := range ifaces {
+			roleKeys = append(roleKeys, v.(string))
+		}
+	}
+	return roleKeys
+}
+
+var createOrganizationInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateOrganizationInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"key": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "The key of the organization.",
+		},
+		"name": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "The optional name of the organization.",
+		},
+		"description": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "The optional description of the organization.",
+		},
+	},
+})
+
+var createOrganizationPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "CreateOrganizationPayload",
+	Fields: graphql.Fields{
+		"organization": &graphql.Field{
+			Type: graphql.NewNonNull(nodeOrganization),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"createOrganization",
+	&graphql.Field{
+		Description: "Create a new organization.",
+		Type:        graphql.NewNonNull(createOrganizationPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(createOrganizationInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			key := input["key"].(string)
+
+			var name *string
+			if str, ok := input["name"].(string); ok && str != "" {
+				name = &str
+			}
+
+			var description *string
+			if str, ok := input["description"].(string); ok && str != "" {
+				description = &str
+			}
+
+			options := &organization.NewOrganizationOptions{
+				Key:         key,
+				Name:        name,
+				Description: description,
+			}
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+			organizationID, err := gqlCtx.OrganizationFacade.CreateOrganization(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationCreatedEventPayload{
+				Organization: *o,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, organizationID),
+			}).Value, nil
+		},
+	},
+)
+
+var updateOrganizationInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "UpdateOrganizationInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"id": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the organization.",
+		},
+		"key": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "The new key of the organization. Pass null if you do not need to update the key.",
+		},
+		"name": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "The new name of the organization. Pass null if you do not need to update the name. Pass an empty string to remove the name.",
+		},
+		"description": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "The new description of the organization. Pass null if you do not need to update the description. Pass an empty string to remove the description.",
+		},
+	},
+})
+
+var updateOrganizationPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UpdateOrganizationPayload",
+	Fields: graphql.Fields{
+		"organization": &graphql.Field{
+			Type: graphql.NewNonNull(nodeOrganization),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"updateOrganization",
+	&graphql.Field{
+		Description: "Update an existing organization.",
+		Type:        graphql.NewNonNull(updateOrganizationPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(updateOrganizationInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			organizationID, err := resolveOrganizationID(input["id"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			var newKey *string
+			if str, ok := input["key"].(string); ok {
+				newKey = &str
+			}
+
+			var newName *string
+			if str, ok := input["name"].(string); ok {
+				newName = &str
+			}
+
+			var newDescription *string
+			if str, ok := input["description"].(string); ok {
+				newDescription = &str
+			}
+
+			options := &organization.UpdateOrganizationOptions{
+				ID:             organizationID,
+				NewKey:         newKey,
+				NewName:        newName,
+				NewDescription: newDescription,
+			}
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			originalOrganization, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.OrganizationFacade.UpdateOrganization(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+
+			newOrganization, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationUpdatedEventPayload{
+				OriginalOrganization: *originalOrganization,
+				NewOrganization:      *newOrganization,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, organizationID),
+			}).Value, nil
+		},
+	},
+)
+
+var deleteOrganizationInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DeleteOrganizationInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"id": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the organization.",
+		},
+	},
+})
+
+var deleteOrganizationPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DeleteOrganizationPayload",
+	Fields: graphql.Fields{
+		"ok": &graphql.Field{
+			Type: graphql.Boolean,
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"deleteOrganization",
+	&graphql.Field{
+		Description: "Delete an existing organization. The memberships and the invitations of the organization will also be deleted.",
+		Type:        graphql.NewNonNull(deleteOrganizationPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(deleteOrganizationInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			organizationID, err := resolveOrganizationID(input["id"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			memberUserIDs, err := gqlCtx.OrganizationFacade.DeleteOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationDeletedEventPayload{
+				Organization:  *o,
+				MemberUserIDs: memberUserIDs,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{
+				"ok": true,
+			}, nil
+		},
+	},
+)
+
+var organizationMemberInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "OrganizationMemberInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"organizationID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the organization.",
+		},
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the user.",
+		},
+		"roleKeys": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+			Description: "The keys of the roles the user has in the organization.",
+		},
+	},
+})
+
+var organizationMemberPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "OrganizationMemberPayload",
+	Fields: graphql.Fields{
+		"organization": &graphql.Field{
+			Type: graphql.NewNonNull(nodeOrganization),
+		},
+		"member": &graphql.Field{
+			Type: graphql.NewNonNull(organizationMember),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"addUserToOrganization",
+	&graphql.Field{
+		Description: "Add an existing user to an organization, with optional organization-scoped roles.",
+		Type:        graphql.NewNonNull(organizationMemberPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(organizationMemberInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			organizationID, err := resolveOrganizationID(input["organizationID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			userID, err := resolveOrganizationMemberUserID(input["userID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			roleKeys := parseOrganizationRoleKeys(input)
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			member, err := gqlCtx.OrganizationFacade.AddMember(ctx, organizationID, userID, roleKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationMemberAddedEventPayload{
+				Organization: *o,
+				Member:       *member,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, organizationID),
+				"member":       member,
+			}).Value, nil
+		},
+	},
+)
+
+var _ = registerMutationField(
+	"updateOrganizationMemberRoles",
+	&graphql.Field{
+		Description: "Replace the organization-scoped roles of a member of an organization.",
+		Type:        graphql.NewNonNull(organizationMemberPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(organizationMemberInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			organizationID, err := resolveOrganizationID(input["organizationID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			userID, err := resolveOrganizationMemberUserID(input["userID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			roleKeys := parseOrganizationRoleKeys(input)
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			originalMember, err := gqlCtx.OrganizationFacade.GetMember(ctx, organizationID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			newMember, err := gqlCtx.OrganizationFacade.UpdateMemberRoles(ctx, organizationID, userID, roleKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationMemberUpdatedEventPayload{
+				Organization:   *o,
+				OriginalMember: *originalMember,
+				NewMember:      *newMember,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, organizationID),
+				"member":       newMember,
+			}).Value, nil
+		},
+	},
+)
+
+var removeUserFromOrganizationInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RemoveUserFromOrganizationInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"organizationID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the organization.",
+		},
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the user.",
+		},
+	},
+})
+
+var removeUserFromOrganizationPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RemoveUserFromOrganizationPayload",
+	Fields: graphql.Fields{
+		"organization": &graphql.Field{
+			Type: graphql.NewNonNull(nodeOrganization),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"removeUserFromOrganization",
+	&graphql.Field{
+		Description: "Remove a user from an organization. The organization-scoped roles of the user will also be removed.",
+		Type:        graphql.NewNonNull(removeUserFromOrganizationPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(removeUserFromOrganizationInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			organizationID, err := resolveOrganizationID(input["organizationID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			userID, err := resolveOrganizationMemberUserID(input["userID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			member, err := gqlCtx.OrganizationFacade.GetMember(ctx, organizationID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.OrganizationFacade.RemoveMember(ctx, organizationID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationMemberRemovedEventPayload{
+				Organization: *o,
+				Member:       *member,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, organizationID),
+			}).Value, nil
+		},
+	},
+)
+
+var createOrganizationInvitationInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateOrganizationInvitationInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"organizationID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the organization.",
+		},
+		"email": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "The email to invite.",
+		},
+		"roleKeys": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+			Description: "The keys of the roles the user will have in the organization.",
+		},
+		"expireAt": &graphql.InputObjectFieldConfig{
+			Type:        graphql.DateTime,
+			Description: "The expiry time of the invitation. Defaults to 7 days later.",
+		},
+	},
+})
+
+var createOrganizationInvitationPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "CreateOrganizationInvitationPayload",
+	Fields: graphql.Fields{
+		"organization": &graphql.Field{
+			Type: graphql.NewNonNull(nodeOrganization),
+		},
+		"invitation": &graphql.Field{
+			Type: graphql.NewNonNull(organizationInvitation),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"createOrganizationInvitation",
+	&graphql.Field{
+		Description: "Invite an email to join an organization. The invitation is accepted when a user with the verified email signs in.",
+		Type:        graphql.NewNonNull(createOrganizationInvitationPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(createOrganizationInvitationInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			organizationID, err := resolveOrganizationID(input["organizationID"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			email := input["email"].(string)
+			roleKeys := parseOrganizationRoleKeys(input)
+
+			var expireAt time.Time
+			if t, ok := input["expireAt"].(time.Time); ok {
+				expireAt = t
+			}
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, organizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			invitation, err := gqlCtx.OrganizationFacade.CreateInvitation(ctx, &organization.NewInvitationOptions{
+				OrganizationID: organizationID,
+				Email:          email,
+				RoleKeys:       roleKeys,
+				ExpireAt:       expireAt,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationInvitationCreatedEventPayload{
+				Organization: *o,
+				Invitation:   *invitation,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, organizationID),
+				"invitation":   invitation,
+			}).Value, nil
+		},
+	},
+)
+
+var deleteOrganizationInvitationInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DeleteOrganizationInvitationInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"id": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "The ID of the invitation.",
+		},
+	},
+})
+
+var deleteOrganizationInvitationPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DeleteOrganizationInvitationPayload",
+	Fields: graphql.Fields{
+		"organization": &graphql.Field{
+			Type: graphql.NewNonNull(nodeOrganization),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"deleteOrganizationInvitation",
+	&graphql.Field{
+		Description: "Delete an invitation of an organization.",
+		Type:        graphql.NewNonNull(deleteOrganizationInvitationPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(deleteOrganizationInvitationInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+
+			invitationID := input["id"].(string)
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			invitation, err := gqlCtx.OrganizationFacade.GetInvitation(ctx, invitationID)
+			if err != nil {
+				return nil, err
+			}
+
+			o, err := gqlCtx.OrganizationFacade.GetOrganization(ctx, invitation.OrganizationID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.OrganizationFacade.DeleteInvitation(ctx, invitationID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationInvitationDeletedEventPayload{
+				Organization: *o,
+				Invitation:   *invitation,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return graphqlutil.NewLazyValue(map[string]any{
+				"organization": gqlCtx.Organizations.Load(ctx, o.ID),
+			}).Value, nil
+		},
+	},
+)
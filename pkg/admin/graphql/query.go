@@ -13,7 +13,9 @@ import (
 	apimodel "github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/audit"
 	libuser "github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/resourcescope"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
 	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
@@ -239,6 +241,42 @@ var query = graphql.NewObject(graphql.ObjectConfig{
 				return graphqlutil.NewConnectionFromResult(lazyItems, result)
 			},
 		},
+		"organizations": &graphql.Field{
+			Description: "All organizations",
+			Type:        connOrganization.ConnectionType,
+			Args: relay.NewConnectionArgs(graphql.FieldConfigArgument{
+				"searchKeyword": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+			}),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				ctx := p.Context
+				gqlCtx := GQLContext(ctx)
+
+				pageArgs := graphqlutil.NewPageArgs(relay.NewConnectionArguments(p.Args))
+
+				searchKeyword, _ := p.Args["searchKeyword"].(string)
+
+				options := &organization.ListOrganizationsOptions{
+					SearchKeyword: searchKeyword,
+				}
+
+				refs, result, err := gqlCtx.OrganizationFacade.ListOrganizations(ctx, options, pageArgs)
+				if err != nil {
+					return nil, err
+				}
+
+				var lazyItems []graphqlutil.LazyItem
+				for _, ref := range refs {
+					lazyItems = append(lazyItems, graphqlutil.LazyItem{
+						Lazy:   gqlCtx.Organizations.Load(ctx, ref.ID),
+						Cursor: graphqlutil.Cursor(ref.Cursor),
+					})
+				}
+
+				return graphqlutil.NewConnectionFromResult(lazyItems, result)
+			},
+		},
 		"auditLogs": &graphql.Field{
 			Description: "Audit logs",
 			Type:        connAuditLog.ConnectionType,
@@ -444,6 +482,48 @@ var query = graphql.NewObject(graphql.ObjectConfig{
 				return graphqlutil.NewConnectionFromResult(lazyItems, result)
 			},
 		},
+		"webhookDeliveries": &graphql.Field{
+			Description: "Deliveries of non-blocking events to webhooks",
+			Type:        connWebhookDelivery.ConnectionType,
+			Args: relay.NewConnectionArgs(graphql.FieldConfigArgument{
+				"status": &graphql.ArgumentConfig{
+					Type: webhookDeliveryStatus,
+				},
+				"eventType": &graphql.ArgumentConfig{
+					Type: graphql.String,
+				},
+			}),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				ctx := p.Context
+				gqlCtx := GQLContext(ctx)
+				pageArgs := graphqlutil.NewPageArgs(relay.NewConnectionArguments(p.Args))
+
+				options := &hook.WebhookDeliveryListOptions{}
+				if status, ok := p.Args["status"].(hook.WebhookDeliveryStatus); ok {
+					options.Status = &status
+				}
+				options.EventType, _ = p.Args["eventType"].(string)
+
+				items, offset, result, err := gqlCtx.WebhookDeliveryFacade.ListPage(ctx, options, pageArgs)
+				if err != nil {
+					return nil, err
+				}
+
+				lazyItems := make([]graphqlutil.LazyItem, 0, len(items))
+				for i, item := range items {
+					cursor, err := (&db.PageKey{Offset: offset + uint64(i)}).ToPageCursor()
+					if err != nil {
+						return nil, err
+					}
+					lazyItems = append(lazyItems, graphqlutil.LazyItem{
+						Lazy:   graphqlutil.NewLazyValue(item),
+						Cursor: graphqlutil.Cursor(cursor),
+					})
+				}
+
+				return graphqlutil.NewConnectionFromResult(lazyItems, result)
+			},
+		},
 		"resources": &graphql.Field{
 			Description: "All resources",
 			Type:        connResource.ConnectionType,
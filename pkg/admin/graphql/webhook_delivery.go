@@ -0,0 +1,148 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/graphql-go/graphql"
+
+	relay "github.com/authgear/authgear-server/pkg/graphqlgo/relay"
+
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+var webhookDeliveryStatus = graphql.NewEnum(graphql.EnumConfig{
+	Name: "WebhookDeliveryStatus",
+	Values: graphql.EnumValueConfigMap{
+		"PENDING": &graphql.EnumValueConfig{
+			Value: hook.WebhookDeliveryStatusPending,
+		},
+		"SUCCEEDED": &graphql.EnumValueConfig{
+			Value: hook.WebhookDeliveryStatusSucceeded,
+		},
+		"FAILED": &graphql.EnumValueConfig{
+			Value: hook.WebhookDeliveryStatusFailed,
+		},
+	},
+})
+
+var webhookDeliveryPayload = graphqlutil.NewJSONObjectScalar(
+	"WebhookDeliveryPayload",
+	"The `WebhookDeliveryPayload` scalar type represents the event delivered to the webhook.",
+)
+
+var webhookDeliveryAttempt = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "WebhookDeliveryAttempt",
+	Description: "An attempt to deliver a webhook",
+	Fields: graphql.Fields{
+		"createdAt": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source := p.Source.(*hook.WebhookDeliveryAttempt)
+				return source.CreatedAt, nil
+			},
+		},
+		"statusCode": &graphql.Field{
+			Type:        graphql.Int,
+			Description: "The HTTP status code of the response, or null if no response was received.",
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source := p.Source.(*hook.WebhookDeliveryAttempt)
+				return source.StatusCode, nil
+			},
+		},
+		"latencyMilliseconds": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source := p.Source.(*hook.WebhookDeliveryAttempt)
+				return source.Latency.Milliseconds(), nil
+			},
+		},
+		"error": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				source := p.Source.(*hook.WebhookDeliveryAttempt)
+				return source.Error, nil
+			},
+		},
+	},
+})
+
+const typeWebhookDelivery = "WebhookDelivery"
+
+var nodeWebhookDelivery = node(
+	graphql.NewObject(graphql.ObjectConfig{
+		Name:        typeWebhookDelivery,
+		Description: "Delivery of a non-blocking event to a webhook",
+		Interfaces: []*graphql.Interface{
+			nodeDefs.NodeInterface,
+		},
+		Fields: graphql.Fields{
+			"id": relay.GlobalIDField(typeWebhookDelivery, nil),
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+			},
+			"updatedAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+			},
+			"eventID": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"eventType": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			"url": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					source := p.Source.(*hook.WebhookDelivery)
+					return source.URL, nil
+				},
+			},
+			"payload": &graphql.Field{
+				Type: graphql.NewNonNull(webhookDeliveryPayload),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					source := p.Source.(*hook.WebhookDelivery)
+					var m map[string]any
+					if err := json.Unmarshal(source.Payload, &m); err != nil {
+						return nil, err
+					}
+					return m, nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(webhookDeliveryStatus),
+			},
+			"attemptCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+			},
+			"nextAttemptAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			"lastAttemptAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			"attempts": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(webhookDeliveryAttempt))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					source := p.Source.(*hook.WebhookDelivery)
+					ctx := p.Context
+					gqlCtx := GQLContext(ctx)
+					attempts, err := gqlCtx.WebhookDeliveryFacade.ListAttempts(ctx, source.ID)
+					if err != nil {
+						return nil, err
+					}
+					if attempts == nil {
+						attempts = []*hook.WebhookDeliveryAttempt{}
+					}
+					return attempts, nil
+				},
+			},
+		},
+	}),
+	&hook.WebhookDelivery{},
+	func(ctx context.Context, gqlCtx *Context, id string) (any, error) {
+		return gqlCtx.WebhookDeliveryFacade.Get(ctx, id)
+	},
+)
+
+var connWebhookDelivery = graphqlutil.NewConnectionDef(nodeWebhookDelivery)
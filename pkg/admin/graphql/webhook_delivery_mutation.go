@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+
+	relay "github.com/authgear/authgear-server/pkg/graphqlgo/relay"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+)
+
+var redeliverWebhookDeliveryInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RedeliverWebhookDeliveryInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"webhookDeliveryID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target webhook delivery ID.",
+		},
+	},
+})
+
+var redeliverWebhookDeliveryPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RedeliverWebhookDeliveryPayload",
+	Fields: graphql.Fields{
+		"webhookDelivery": &graphql.Field{
+			Type: graphql.NewNonNull(nodeWebhookDelivery),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"redeliverWebhookDelivery",
+	&graphql.Field{
+		Description: "Deliver the event of a webhook delivery again",
+		Type:        graphql.NewNonNull(redeliverWebhookDeliveryPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(redeliverWebhookDeliveryInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			input := p.Args["input"].(map[string]any)
+			deliveryNodeID := input["webhookDeliveryID"].(string)
+
+			resolvedNodeID := relay.FromGlobalID(deliveryNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeWebhookDelivery {
+				return nil, apierrors.NewInvalid("invalid webhook delivery ID")
+			}
+			deliveryID := resolvedNodeID.ID
+
+			ctx := p.Context
+			gqlCtx := GQLContext(ctx)
+
+			d, err := gqlCtx.WebhookDeliveryFacade.Redeliver(ctx, deliveryID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = gqlCtx.Events.DispatchEventOnCommit(ctx, &nonblocking.AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload{
+				OriginalWebhookDeliveryID: deliveryID,
+				WebhookDeliveryID:         d.ID,
+				EventID:                   d.EventID,
+				EventType:                 d.EventType,
+				URL:                       d.URL,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]any{
+				"webhookDelivery": d,
+			}, nil
+		},
+	},
+)
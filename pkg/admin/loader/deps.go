@@ -8,6 +8,7 @@ var DependencySet = wire.NewSet(
 	NewAuthenticatorLoader,
 	NewRoleLoader,
 	NewGroupLoader,
+	NewOrganizationLoader,
 	NewAuditLogLoader,
 	NewResourceLoader,
 	NewResourceClientLoader,
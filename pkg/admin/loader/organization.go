@@ -0,0 +1,53 @@
+package loader
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type OrganizationLoaderOrganizations interface {
+	GetManyOrganizations(ctx context.Context, ids []string) ([]*model.Organization, error)
+}
+
+type OrganizationLoader struct {
+	*graphqlutil.DataLoader `wire:"-"`
+
+	Organizations OrganizationLoaderOrganizations
+}
+
+func NewOrganizationLoader(organizations OrganizationLoaderOrganizations) *OrganizationLoader {
+	l := &OrganizationLoader{
+		Organizations: organizations,
+	}
+	l.DataLoader = graphqlutil.NewDataLoader(l.LoadFunc)
+	return l
+}
+
+func (l *OrganizationLoader) LoadFunc(ctx context.Context, keys []any) ([]any, error) {
+	// Prepare IDs.
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.(string)
+	}
+
+	// Get entities.
+	entities, err := l.Organizations.GetManyOrganizations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create map.
+	entityMap := make(map[string]*model.Organization)
+	for _, entity := range entities {
+		entityMap[entity.ID] = entity
+	}
+
+	out := make([]any, len(keys))
+	for i, id := range ids {
+		entity := entityMap[id]
+		out[i] = entity
+	}
+	return out, nil
+}
@@ -17,7 +17,7 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, au
 
 	router.Health(p.RootHandler(newHealthzHandler))
 
-	chain := httproute.Chain(
+	baseChain := httproute.Chain(
 		p.RootMiddleware(newOtelMiddleware),
 		p.RootMiddleware(newPanicMiddleware),
 		p.RootMiddleware(newBodyLimitMiddleware),
@@ -41,7 +41,10 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, au
 			return newAuthorizationMiddleware(p, auth)
 		}),
 		p.Middleware(newUIParamMiddleware),
+	)
 
+	chain := httproute.Chain(
+		baseChain,
 		// The following middlewares may terminate the request,
 		// so they are ordered just before the handler, to make sure
 		// the middlewares above always write their headers.
@@ -52,6 +55,9 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, au
 	)
 
 	route := httproute.Route{Middleware: chain}
+	// SCIM requests may be DELETE without a body, and use application/scim+json,
+	// so the content type is checked by the handlers instead.
+	scimRoute := httproute.Route{Middleware: baseChain}
 
 	router.AddRoutes(p.Handler(newGraphQLHandler), transport.ConfigureGraphQLRoute(route)...)
 	router.Add(transport.ConfigurePresignImagesUploadRoute(route), p.Handler(newPresignImagesUploadHandler))
@@ -59,6 +65,8 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, au
 	router.Add(transport.ConfigureUserImportGetRoute(route), p.Handler(newUserImportGetHandler))
 	router.Add(transport.ConfigureUserExportCreateRoute(route), p.Handler(newUserExportCreateHandler))
 	router.Add(transport.ConfigureUserExportGetRoute(route), p.Handler(newUserExportGetHandler))
+	router.AddRoutes(p.Handler(newSCIMUsersHandler), transport.ConfigureSCIMUsersRoute(scimRoute)...)
+	router.AddRoutes(p.Handler(newSCIMGroupsHandler), transport.ConfigureSCIMGroupsRoute(scimRoute)...)
 
 	return router.HTTPHandler()
 }
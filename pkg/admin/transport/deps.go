@@ -12,4 +12,6 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(UserImportGetHandler), "*"),
 	wire.Struct(new(UserExportCreateHandler), "*"),
 	wire.Struct(new(UserExportGetHandler), "*"),
+	wire.Struct(new(SCIMUsersHandler), "*"),
+	wire.Struct(new(SCIMGroupsHandler), "*"),
 )
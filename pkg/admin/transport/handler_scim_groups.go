@@ -0,0 +1,153 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/lib/scim"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+)
+
+func ConfigureSCIMGroupsRoute(route httproute.Route) []httproute.Route {
+	return []httproute.Route{
+		route.WithMethods("GET", "POST").WithPathPattern("/scim/v2/Groups"),
+		route.WithMethods("GET", "PUT", "PATCH", "DELETE").WithPathPattern("/scim/v2/Groups/:id"),
+	}
+}
+
+type SCIMGroupsHandlerFacade interface {
+	ListGroups(ctx context.Context, options *scim.ListOptions) (*scim.ListResponse, error)
+	GetGroup(ctx context.Context, id string, includeMembers bool) (*scim.Group, error)
+	CreateGroup(ctx context.Context, input *scim.Group) (*scim.Group, error)
+	ReplaceGroup(ctx context.Context, id string, input *scim.Group) (*scim.Group, error)
+	PatchGroup(ctx context.Context, id string, input *scim.PatchRequest) (*scim.Group, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+type SCIMGroupsHandler struct {
+	AppDatabase *appdb.Handle
+	SCIM        SCIMGroupsHandlerFacade
+}
+
+func (h *SCIMGroupsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := h.handle(ctx, w, r)
+	if err != nil {
+		scim.WriteError(ctx, w, err)
+		return
+	}
+}
+
+func (h *SCIMGroupsHandler) handle(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := httproute.GetParam(r, "id")
+
+	switch {
+	case r.Method == "GET" && id == "":
+		options, err := scim.ParseListOptions(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.ListResponse
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.ListGroups(ctx, options)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "GET":
+		options, err := scim.ParseListOptions(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.Group
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.GetGroup(ctx, id, !options.IsExcluded("members"))
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "POST":
+		var input scim.Group
+		err := scim.ParseBody(r, w, scim.GroupSchema.Validator(), &input)
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.Group
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.CreateGroup(ctx, &input)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusCreated, resp)
+		return nil
+
+	case r.Method == "PUT":
+		var input scim.Group
+		err := scim.ParseBody(r, w, scim.GroupSchema.Validator(), &input)
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.Group
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.ReplaceGroup(ctx, id, &input)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "PATCH":
+		var input scim.PatchRequest
+		err := scim.ParseBody(r, w, scim.PatchRequestSchema.Validator(), &input)
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.Group
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.PatchGroup(ctx, id, &input)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "DELETE":
+		err := h.AppDatabase.WithTx(ctx, func(ctx context.Context) error {
+			return h.SCIM.DeleteGroup(ctx, id)
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusNoContent, nil)
+		return nil
+	}
+
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	return nil
+}
@@ -0,0 +1,148 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/lib/scim"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+)
+
+func ConfigureSCIMUsersRoute(route httproute.Route) []httproute.Route {
+	return []httproute.Route{
+		route.WithMethods("GET", "POST").WithPathPattern("/scim/v2/Users"),
+		route.WithMethods("GET", "PUT", "PATCH", "DELETE").WithPathPattern("/scim/v2/Users/:id"),
+	}
+}
+
+type SCIMUsersHandlerFacade interface {
+	ListUsers(ctx context.Context, options *scim.ListOptions) (*scim.ListResponse, error)
+	GetUser(ctx context.Context, id string) (*scim.User, error)
+	CreateUser(ctx context.Context, input *scim.User) (*scim.User, error)
+	ReplaceUser(ctx context.Context, id string, input *scim.User) (*scim.User, error)
+	PatchUser(ctx context.Context, id string, input *scim.PatchRequest) (*scim.User, error)
+	DeleteUser(ctx context.Context, id string) error
+}
+
+type SCIMUsersHandler struct {
+	AppDatabase *appdb.Handle
+	SCIM        SCIMUsersHandlerFacade
+}
+
+func (h *SCIMUsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := h.handle(ctx, w, r)
+	if err != nil {
+		scim.WriteError(ctx, w, err)
+		return
+	}
+}
+
+func (h *SCIMUsersHandler) handle(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := httproute.GetParam(r, "id")
+
+	switch {
+	case r.Method == "GET" && id == "":
+		options, err := scim.ParseListOptions(r.URL.Query())
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.ListResponse
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.ListUsers(ctx, options)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "GET":
+		var resp *scim.User
+		err := h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.GetUser(ctx, id)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "POST":
+		var input scim.User
+		err := scim.ParseBody(r, w, scim.UserSchema.Validator(), &input)
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.User
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.CreateUser(ctx, &input)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusCreated, resp)
+		return nil
+
+	case r.Method == "PUT":
+		var input scim.User
+		err := scim.ParseBody(r, w, scim.UserSchema.Validator(), &input)
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.User
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.ReplaceUser(ctx, id, &input)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "PATCH":
+		var input scim.PatchRequest
+		err := scim.ParseBody(r, w, scim.PatchRequestSchema.Validator(), &input)
+		if err != nil {
+			return err
+		}
+
+		var resp *scim.User
+		err = h.AppDatabase.WithTx(ctx, func(ctx context.Context) (err error) {
+			resp, err = h.SCIM.PatchUser(ctx, id, &input)
+			return
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusOK, resp)
+		return nil
+
+	case r.Method == "DELETE":
+		err := h.AppDatabase.WithTx(ctx, func(ctx context.Context) error {
+			return h.SCIM.DeleteUser(ctx, id)
+		})
+		if err != nil {
+			return err
+		}
+
+		scim.WriteResponse(w, http.StatusNoContent, nil)
+		return nil
+	}
+
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	return nil
+}
@@ -111,3 +111,17 @@ func newUserExportGetHandler(p *deps.RequestProvider) http.Handler {
 		wire.Bind(new(http.Handler), new(*transport.UserExportGetHandler)),
 	))
 }
+
+func newSCIMUsersHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*transport.SCIMUsersHandler)),
+	))
+}
+
+func newSCIMGroupsHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*transport.SCIMGroupsHandler)),
+	))
+}
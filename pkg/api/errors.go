@@ -29,6 +29,7 @@ var OAuthProviderMissingCredentials = apierrors.Invalid.WithReason("OAuthProvide
 
 var ErrInvalidCredentials = InvalidCredentials.New("invalid credentials")
 var ErrOAuthProviderNotFound = apierrors.NotFound.WithReason("OAuthProviderNotFound").New("oauth provider not found")
+var ErrSAMLIdentityProviderNotFound = apierrors.NotFound.WithReason("SAMLIdentityProviderNotFound").New("saml identity provider not found")
 var ErrIdentityModifyDisabled = NewInvariantViolated("IdentityModifyDisabled", "identity modification disabled", nil)
 var ErrMismatchedUser = NewInvariantViolated("MismatchedUser", "mismatched user", nil)
 var ErrNoAuthenticator = NewInvariantViolated("NoAuthenticator", "no authenticator", nil)
@@ -46,6 +47,8 @@ var ErrLDAPEndUserSearchNotFound = LDAPConnectionTestFailed.NewWithCause("end us
 var ErrLDAPEndUserSearchMultipleResult = LDAPConnectionTestFailed.NewWithCause("multiple end users found", apierrors.StringCause("MoreThanOneEntryInSearchResult"))
 var ErrLDAPMissingUniqueAttribute = LDAPConnectionTestFailed.NewWithCause("missing ID attribute", apierrors.StringCause("TestingEndUserMissingUserIDAttribute"))
 
+var InvalidSAMLResponse = apierrors.Unauthorized.WithReason("InvalidSAMLResponse")
+
 var ErrGetUsersInvalidArgument = apierrors.Invalid.WithReason("GetUsersInvalidArgument")
 
 var ErrTaskNotFound = apierrors.NotFound.WithReason("TaskNotFound").New("task not found")
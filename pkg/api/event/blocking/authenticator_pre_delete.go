@@ -0,0 +1,80 @@
+package blocking
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	AuthenticatorPreDelete event.Type = "authenticator.pre_delete"
+)
+
+func init() {
+	s := event.GetBaseHookResponseSchema()
+	s.Add("AuthenticatorPreDeleteHookResponse", `
+{
+	"allOf": [
+		{ "$ref": "#/$defs/BaseHookResponseSchema" },
+		{
+			"if": {
+				"properties": {
+					"is_allowed": { "const": true }
+				}
+			},
+			"then": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"is_allowed": {},
+					"mutations": {}
+				}
+			}
+		}
+	]
+}`)
+
+	s.Instantiate()
+	event.RegisterResponseSchemaValidator(AuthenticatorPreDelete, s.PartValidator("AuthenticatorPreDeleteHookResponse"))
+}
+
+type AuthenticatorPreDeleteBlockingEventPayload struct {
+	UserRef       model.UserRef       `json:"-" resolve:"user"`
+	UserModel     model.User          `json:"user"`
+	Authenticator model.Authenticator `json:"authenticator"`
+	AdminAPI      bool                `json:"-"`
+}
+
+func (e *AuthenticatorPreDeleteBlockingEventPayload) BlockingEventType() event.Type {
+	return AuthenticatorPreDelete
+}
+
+func (e *AuthenticatorPreDeleteBlockingEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *AuthenticatorPreDeleteBlockingEventPayload) GetTriggeredBy() event.TriggeredByType {
+	if e.AdminAPI {
+		return event.TriggeredByTypeAdminAPI
+	}
+	return event.TriggeredByTypeUser
+}
+
+func (e *AuthenticatorPreDeleteBlockingEventPayload) FillContext(ctx *event.Context) {}
+
+func (e *AuthenticatorPreDeleteBlockingEventPayload) ApplyHookResponse(ctx context.Context, response event.HookResponse) event.ApplyHookResponseResult {
+	user, mutated := ApplyUserMutations(e.UserModel, response.Mutations.User)
+	if mutated {
+		e.UserModel = user
+	}
+	return event.ApplyHookResponseResult{MutationsEverApplied: mutated}
+}
+
+func (e *AuthenticatorPreDeleteBlockingEventPayload) PerformEffects(ctx context.Context, effectCtx event.MutationsEffectContext) error {
+	userID := e.UserID()
+	userMutations := MakeUserMutations(e.UserModel)
+	return PerformEffectsOnUser(ctx, effectCtx, userID, userMutations)
+}
+
+var _ event.BlockingPayload = &AuthenticatorPreDeleteBlockingEventPayload{}
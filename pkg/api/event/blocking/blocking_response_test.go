@@ -131,6 +131,195 @@ func TestBlockingHookResponse(t *testing.T) {
 			}`)
 		})
 
+		Convey(string(AuthenticatorPreCreate), func() {
+			pass("is_allowed true", AuthenticatorPreCreate, `{
+				"is_allowed": true
+			}`)
+
+			pass("mutations supported", AuthenticatorPreCreate, `{
+				"is_allowed": true,
+				"mutations": {
+					"user": {
+						"is_anonymous": true
+					}
+				}
+			}`)
+
+			fail("constraints not supported", AuthenticatorPreCreate, `{
+				"is_allowed": true,
+				"constraints": {
+					"amr": ["mfa"]
+				}
+			}`)
+
+			fail("bot_protection not supported", AuthenticatorPreCreate, `{
+				"is_allowed": true,
+				"bot_protection": {
+					"mode": "always"
+				}
+			}`)
+
+			fail("rate_limits not supported", AuthenticatorPreCreate, `{
+				"is_allowed": true,
+				"rate_limits": {
+					"authentication.general": {
+						"weight": 1.5
+					}
+				}
+			}`)
+		})
+
+		Convey(string(AuthenticatorPreDelete), func() {
+			pass("is_allowed true", AuthenticatorPreDelete, `{
+				"is_allowed": true
+			}`)
+
+			pass("mutations supported", AuthenticatorPreDelete, `{
+				"is_allowed": true,
+				"mutations": {
+					"user": {
+						"is_anonymous": true
+					}
+				}
+			}`)
+
+			fail("constraints not supported", AuthenticatorPreDelete, `{
+				"is_allowed": true,
+				"constraints": {
+					"amr": ["mfa"]
+				}
+			}`)
+
+			fail("bot_protection not supported", AuthenticatorPreDelete, `{
+				"is_allowed": true,
+				"bot_protection": {
+					"mode": "always"
+				}
+			}`)
+
+			fail("rate_limits not supported", AuthenticatorPreDelete, `{
+				"is_allowed": true,
+				"rate_limits": {
+					"authentication.general": {
+						"weight": 1.5
+					}
+				}
+			}`)
+		})
+
+		Convey(string(IdentityPreCreate), func() {
+			pass("is_allowed true", IdentityPreCreate, `{
+				"is_allowed": true
+			}`)
+
+			pass("mutations supported", IdentityPreCreate, `{
+				"is_allowed": true,
+				"mutations": {
+					"user": {
+						"is_anonymous": true
+					}
+				}
+			}`)
+
+			fail("constraints not supported", IdentityPreCreate, `{
+				"is_allowed": true,
+				"constraints": {
+					"amr": ["mfa"]
+				}
+			}`)
+
+			fail("bot_protection not supported", IdentityPreCreate, `{
+				"is_allowed": true,
+				"bot_protection": {
+					"mode": "always"
+				}
+			}`)
+
+			fail("rate_limits not supported", IdentityPreCreate, `{
+				"is_allowed": true,
+				"rate_limits": {
+					"authentication.general": {
+						"weight": 1.5
+					}
+				}
+			}`)
+		})
+
+		Convey(string(IdentityPreDelete), func() {
+			pass("is_allowed true", IdentityPreDelete, `{
+				"is_allowed": true
+			}`)
+
+			pass("mutations supported", IdentityPreDelete, `{
+				"is_allowed": true,
+				"mutations": {
+					"user": {
+						"is_anonymous": true
+					}
+				}
+			}`)
+
+			fail("constraints not supported", IdentityPreDelete, `{
+				"is_allowed": true,
+				"constraints": {
+					"amr": ["mfa"]
+				}
+			}`)
+
+			fail("bot_protection not supported", IdentityPreDelete, `{
+				"is_allowed": true,
+				"bot_protection": {
+					"mode": "always"
+				}
+			}`)
+
+			fail("rate_limits not supported", IdentityPreDelete, `{
+				"is_allowed": true,
+				"rate_limits": {
+					"authentication.general": {
+						"weight": 1.5
+					}
+				}
+			}`)
+		})
+
+		Convey(string(OAuthTokenPreRefresh), func() {
+			pass("is_allowed true", OAuthTokenPreRefresh, `{
+				"is_allowed": true
+			}`)
+
+			pass("is_allowed false", OAuthTokenPreRefresh, `{
+				"is_allowed": false,
+				"title": "title",
+				"reason": "reason"
+			}`)
+
+			pass("oauth_token mutations supported", OAuthTokenPreRefresh, `{
+				"is_allowed": true,
+				"mutations": {
+					"oauth_token": {
+						"scopes": ["openid"]
+					}
+				}
+			}`)
+
+			fail("user mutations not supported", OAuthTokenPreRefresh, `{
+				"is_allowed": true,
+				"mutations": {
+					"user": {
+						"standard_attributes": {}
+					}
+				}
+			}`)
+
+			fail("constraints not supported", OAuthTokenPreRefresh, `{
+				"is_allowed": true,
+				"constraints": {
+					"amr": ["mfa"]
+				}
+			}`)
+		})
+
 		Convey(string(OIDCJWTPreCreate), func() {
 			pass("is_allowed true", OIDCJWTPreCreate, `{
 				"is_allowed": true
@@ -171,6 +360,44 @@ func TestBlockingHookResponse(t *testing.T) {
 			}`)
 		})
 
+		Convey(string(SessionPreCreate), func() {
+			pass("is_allowed true", SessionPreCreate, `{
+				"is_allowed": true
+			}`)
+
+			pass("mutations supported", SessionPreCreate, `{
+				"is_allowed": true,
+				"mutations": {
+					"user": {
+						"is_anonymous": true
+					}
+				}
+			}`)
+
+			fail("constraints not supported", SessionPreCreate, `{
+				"is_allowed": true,
+				"constraints": {
+					"amr": ["mfa"]
+				}
+			}`)
+
+			fail("bot_protection not supported", SessionPreCreate, `{
+				"is_allowed": true,
+				"bot_protection": {
+					"mode": "always"
+				}
+			}`)
+
+			fail("rate_limits not supported", SessionPreCreate, `{
+				"is_allowed": true,
+				"rate_limits": {
+					"authentication.general": {
+						"weight": 1.5
+					}
+				}
+			}`)
+		})
+
 		Convey(string(UserPreCreate), func() {
 			pass("is_allowed true", UserPreCreate, `{
 				"is_allowed": true
@@ -0,0 +1,80 @@
+package blocking
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	IdentityPreCreate event.Type = "identity.pre_create"
+)
+
+func init() {
+	s := event.GetBaseHookResponseSchema()
+	s.Add("IdentityPreCreateHookResponse", `
+{
+	"allOf": [
+		{ "$ref": "#/$defs/BaseHookResponseSchema" },
+		{
+			"if": {
+				"properties": {
+					"is_allowed": { "const": true }
+				}
+			},
+			"then": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"is_allowed": {},
+					"mutations": {}
+				}
+			}
+		}
+	]
+}`)
+
+	s.Instantiate()
+	event.RegisterResponseSchemaValidator(IdentityPreCreate, s.PartValidator("IdentityPreCreateHookResponse"))
+}
+
+type IdentityPreCreateBlockingEventPayload struct {
+	UserRef   model.UserRef  `json:"-" resolve:"user"`
+	UserModel model.User     `json:"user"`
+	Identity  model.Identity `json:"identity"`
+	AdminAPI  bool           `json:"-"`
+}
+
+func (e *IdentityPreCreateBlockingEventPayload) BlockingEventType() event.Type {
+	return IdentityPreCreate
+}
+
+func (e *IdentityPreCreateBlockingEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *IdentityPreCreateBlockingEventPayload) GetTriggeredBy() event.TriggeredByType {
+	if e.AdminAPI {
+		return event.TriggeredByTypeAdminAPI
+	}
+	return event.TriggeredByTypeUser
+}
+
+func (e *IdentityPreCreateBlockingEventPayload) FillContext(ctx *event.Context) {}
+
+func (e *IdentityPreCreateBlockingEventPayload) ApplyHookResponse(ctx context.Context, response event.HookResponse) event.ApplyHookResponseResult {
+	user, mutated := ApplyUserMutations(e.UserModel, response.Mutations.User)
+	if mutated {
+		e.UserModel = user
+	}
+	return event.ApplyHookResponseResult{MutationsEverApplied: mutated}
+}
+
+func (e *IdentityPreCreateBlockingEventPayload) PerformEffects(ctx context.Context, effectCtx event.MutationsEffectContext) error {
+	userID := e.UserID()
+	userMutations := MakeUserMutations(e.UserModel)
+	return PerformEffectsOnUser(ctx, effectCtx, userID, userMutations)
+}
+
+var _ event.BlockingPayload = &IdentityPreCreateBlockingEventPayload{}
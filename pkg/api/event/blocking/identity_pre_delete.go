@@ -0,0 +1,80 @@
+package blocking
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	IdentityPreDelete event.Type = "identity.pre_delete"
+)
+
+func init() {
+	s := event.GetBaseHookResponseSchema()
+	s.Add("IdentityPreDeleteHookResponse", `
+{
+	"allOf": [
+		{ "$ref": "#/$defs/BaseHookResponseSchema" },
+		{
+			"if": {
+				"properties": {
+					"is_allowed": { "const": true }
+				}
+			},
+			"then": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"is_allowed": {},
+					"mutations": {}
+				}
+			}
+		}
+	]
+}`)
+
+	s.Instantiate()
+	event.RegisterResponseSchemaValidator(IdentityPreDelete, s.PartValidator("IdentityPreDeleteHookResponse"))
+}
+
+type IdentityPreDeleteBlockingEventPayload struct {
+	UserRef   model.UserRef  `json:"-" resolve:"user"`
+	UserModel model.User     `json:"user"`
+	Identity  model.Identity `json:"identity"`
+	AdminAPI  bool           `json:"-"`
+}
+
+func (e *IdentityPreDeleteBlockingEventPayload) BlockingEventType() event.Type {
+	return IdentityPreDelete
+}
+
+func (e *IdentityPreDeleteBlockingEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *IdentityPreDeleteBlockingEventPayload) GetTriggeredBy() event.TriggeredByType {
+	if e.AdminAPI {
+		return event.TriggeredByTypeAdminAPI
+	}
+	return event.TriggeredByTypeUser
+}
+
+func (e *IdentityPreDeleteBlockingEventPayload) FillContext(ctx *event.Context) {}
+
+func (e *IdentityPreDeleteBlockingEventPayload) ApplyHookResponse(ctx context.Context, response event.HookResponse) event.ApplyHookResponseResult {
+	user, mutated := ApplyUserMutations(e.UserModel, response.Mutations.User)
+	if mutated {
+		e.UserModel = user
+	}
+	return event.ApplyHookResponseResult{MutationsEverApplied: mutated}
+}
+
+func (e *IdentityPreDeleteBlockingEventPayload) PerformEffects(ctx context.Context, effectCtx event.MutationsEffectContext) error {
+	userID := e.UserID()
+	userMutations := MakeUserMutations(e.UserModel)
+	return PerformEffectsOnUser(ctx, effectCtx, userID, userMutations)
+}
+
+var _ event.BlockingPayload = &IdentityPreDeleteBlockingEventPayload{}
@@ -0,0 +1,101 @@
+package blocking
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OAuthTokenPreRefresh event.Type = "oauth.token.pre_refresh"
+)
+
+func init() {
+	s := event.GetBaseHookResponseSchema()
+	s.Add("OAuthTokenPreRefreshHookResponse", `
+{
+	"allOf": [
+		{ "$ref": "#/$defs/BaseHookResponseSchema" },
+		{
+			"if": {
+				"properties": {
+					"is_allowed": { "const": true }
+				}
+			},
+			"then": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"is_allowed": {},
+					"mutations": {
+						"type": "object",
+						"additionalProperties": false,
+						"properties": {
+							"oauth_token": {}
+						}
+					}
+				}
+			}
+		}
+	]
+}`)
+
+	s.Instantiate()
+	event.RegisterResponseSchemaValidator(OAuthTokenPreRefresh, s.PartValidator("OAuthTokenPreRefreshHookResponse"))
+}
+
+type OAuthOfflineGrant struct {
+	ID              string    `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	AuthenticatedAt time.Time `json:"authenticated_at"`
+	Scopes          []string  `json:"scopes"`
+}
+
+type OAuthTokenPreRefreshBlockingEventPayload struct {
+	UserRef      model.UserRef     `json:"-" resolve:"user"`
+	UserModel    model.User        `json:"user"`
+	OfflineGrant OAuthOfflineGrant `json:"offline_grant"`
+	ClientID     string            `json:"client_id"`
+	DeviceInfo   map[string]any    `json:"device_info,omitempty"`
+	IPAddress    string            `json:"ip_address"`
+}
+
+func (e *OAuthTokenPreRefreshBlockingEventPayload) BlockingEventType() event.Type {
+	return OAuthTokenPreRefresh
+}
+
+func (e *OAuthTokenPreRefreshBlockingEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *OAuthTokenPreRefreshBlockingEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeUser
+}
+
+func (e *OAuthTokenPreRefreshBlockingEventPayload) FillContext(ctx *event.Context) {}
+
+// ApplyHookResponse only narrows the scopes.
+// Scopes that were not granted are ignored.
+func (e *OAuthTokenPreRefreshBlockingEventPayload) ApplyHookResponse(ctx context.Context, response event.HookResponse) event.ApplyHookResponseResult {
+	mutationsEverApplied := false
+	if response.Mutations.OAuthToken.Scopes != nil {
+		var scopes []string
+		for _, scope := range e.OfflineGrant.Scopes {
+			if slices.Contains(response.Mutations.OAuthToken.Scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+		e.OfflineGrant.Scopes = scopes
+		mutationsEverApplied = true
+	}
+	return event.ApplyHookResponseResult{MutationsEverApplied: mutationsEverApplied}
+}
+
+func (e *OAuthTokenPreRefreshBlockingEventPayload) PerformEffects(ctx context.Context, effectCtx event.MutationsEffectContext) error {
+	return nil
+}
+
+var _ event.BlockingPayload = &OAuthTokenPreRefreshBlockingEventPayload{}
@@ -0,0 +1,76 @@
+package blocking
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	SessionPreCreate event.Type = "session.pre_create"
+)
+
+func init() {
+	s := event.GetBaseHookResponseSchema()
+	s.Add("SessionPreCreateHookResponse", `
+{
+	"allOf": [
+		{ "$ref": "#/$defs/BaseHookResponseSchema" },
+		{
+			"if": {
+				"properties": {
+					"is_allowed": { "const": true }
+				}
+			},
+			"then": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"is_allowed": {},
+					"mutations": {}
+				}
+			}
+		}
+	]
+}`)
+
+	s.Instantiate()
+	event.RegisterResponseSchemaValidator(SessionPreCreate, s.PartValidator("SessionPreCreateHookResponse"))
+}
+
+type SessionPreCreateBlockingEventPayload struct {
+	UserRef   model.UserRef `json:"-" resolve:"user"`
+	UserModel model.User    `json:"user"`
+	Session   model.Session `json:"session"`
+}
+
+func (e *SessionPreCreateBlockingEventPayload) BlockingEventType() event.Type {
+	return SessionPreCreate
+}
+
+func (e *SessionPreCreateBlockingEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *SessionPreCreateBlockingEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeUser
+}
+
+func (e *SessionPreCreateBlockingEventPayload) FillContext(ctx *event.Context) {}
+
+func (e *SessionPreCreateBlockingEventPayload) ApplyHookResponse(ctx context.Context, response event.HookResponse) event.ApplyHookResponseResult {
+	user, mutated := ApplyUserMutations(e.UserModel, response.Mutations.User)
+	if mutated {
+		e.UserModel = user
+	}
+	return event.ApplyHookResponseResult{MutationsEverApplied: mutated}
+}
+
+func (e *SessionPreCreateBlockingEventPayload) PerformEffects(ctx context.Context, effectCtx event.MutationsEffectContext) error {
+	userID := e.UserID()
+	userMutations := MakeUserMutations(e.UserModel)
+	return PerformEffectsOnUser(ctx, effectCtx, userID, userMutations)
+}
+
+var _ event.BlockingPayload = &SessionPreCreateBlockingEventPayload{}
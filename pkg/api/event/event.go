@@ -67,6 +67,9 @@ type Event struct {
 	Payload       Payload `json:"payload"`
 	Context       Context `json:"context"`
 	IsNonBlocking bool    `json:"-"`
+	// WebhookDeliveryEnqueued is true when the webhooks of the event
+	// are persisted for durable delivery, instead of being delivered on commit.
+	WebhookDeliveryEnqueued bool `json:"-"`
 }
 
 func (e *Event) ApplyHookResponse(ctx context.Context, response HookResponse) ApplyHookResponseResult {
@@ -41,7 +41,7 @@ func GetBaseHookResponseSchema() *validation.MultipartSchema {
 	_ = baseHookResponseSchema.Add("BotProtectionRiskMode", `
 {
 	"type": "string",
-	"enum": ["never", "always"]
+	"enum": ["never", "always", "risk_level_medium", "risk_level_high"]
 }
 `)
 
@@ -107,6 +107,15 @@ func GetBaseHookResponseSchema() *validation.MultipartSchema {
 					"type": "object"
 				}
 			}
+		},
+		"oauth_token": {
+			"type": "object",
+			"properties": {
+				"scopes": {
+					"type": "array",
+					"items": { "type": "string" }
+				}
+			}
 		}
 	}
 }
@@ -208,9 +217,10 @@ type RateLimitRequirements struct {
 }
 
 type Mutations struct {
-	User    UserMutations    `json:"user,omitempty"`
-	JWT     JWTMutations     `json:"jwt,omitempty"`
-	IDToken IDTokenMutations `json:"id_token,omitempty"`
+	User       UserMutations       `json:"user,omitempty"`
+	JWT        JWTMutations        `json:"jwt,omitempty"`
+	IDToken    IDTokenMutations    `json:"id_token,omitempty"`
+	OAuthToken OAuthTokenMutations `json:"oauth_token,omitempty"`
 }
 
 type UserMutations struct {
@@ -228,6 +238,10 @@ type IDTokenMutations struct {
 	Payload map[string]any `json:"payload,omitempty"`
 }
 
+type OAuthTokenMutations struct {
+	Scopes []string `json:"scopes,omitempty"`
+}
+
 func ParseHookResponse(ctx context.Context, eventType Type, r io.Reader) (*HookResponse, error) {
 	var resp HookResponse
 	if v, ok := responseSchemaValidators[eventType]; ok && v != nil {
@@ -0,0 +1,50 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+)
+
+const (
+	AdminAPIMutationRedeliverWebhookDeliveryExecuted event.Type = "admin_api.mutation.redeliver_webhook_delivery.executed"
+)
+
+type AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload struct {
+	OriginalWebhookDeliveryID string `json:"original_webhook_delivery_id"`
+	WebhookDeliveryID         string `json:"webhook_delivery_id"`
+	EventID                   string `json:"event_id"`
+	EventType                 string `json:"event_type"`
+	URL                       string `json:"url"`
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) NonBlockingEventType() event.Type {
+	return AdminAPIMutationRedeliverWebhookDeliveryExecuted
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) UserID() string {
+	return ""
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) ForHook() bool {
+	return false
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload{}
@@ -0,0 +1,59 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	IdentityLDAPGroupsSynced event.Type = "identity.ldap.groups_synced"
+)
+
+// IdentityLDAPGroupsSyncedEventPayload is dispatched when the LDAP group sync
+// adds or removes the groups or roles of a user.
+type IdentityLDAPGroupsSyncedEventPayload struct {
+	UserRef          model.UserRef  `json:"-" resolve:"user"`
+	UserModel        model.User     `json:"user"`
+	Identity         model.Identity `json:"identity"`
+	AddedGroupKeys   []string       `json:"added_group_keys,omitempty"`
+	RemovedGroupKeys []string       `json:"removed_group_keys,omitempty"`
+	AddedRoleKeys    []string       `json:"added_role_keys,omitempty"`
+	RemovedRoleKeys  []string       `json:"removed_role_keys,omitempty"`
+	IsDirectorySync  bool           `json:"-"`
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) NonBlockingEventType() event.Type {
+	return IdentityLDAPGroupsSynced
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	if e.IsDirectorySync {
+		return event.TriggeredBySystem
+	}
+	return event.TriggeredByTypeUser
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) RequireReindexUserIDs() []string {
+	return []string{e.UserID()}
+}
+
+func (e *IdentityLDAPGroupsSyncedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &IdentityLDAPGroupsSyncedEventPayload{}
@@ -0,0 +1,52 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	IdentityLDAPUpdated event.Type = "identity.ldap.updated"
+)
+
+// IdentityLDAPUpdatedEventPayload is dispatched when the LDAP directory sync
+// refreshes the attributes of an LDAP identity.
+type IdentityLDAPUpdatedEventPayload struct {
+	UserRef     model.UserRef  `json:"-" resolve:"user"`
+	UserModel   model.User     `json:"user"`
+	NewIdentity model.Identity `json:"new_identity"`
+	OldIdentity model.Identity `json:"old_identity"`
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) NonBlockingEventType() event.Type {
+	return IdentityLDAPUpdated
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) UserID() string {
+	return e.UserRef.ID
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredBySystem
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) RequireReindexUserIDs() []string {
+	return []string{e.UserID()}
+}
+
+func (e *IdentityLDAPUpdatedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &IdentityLDAPUpdatedEventPayload{}
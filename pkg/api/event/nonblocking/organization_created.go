@@ -0,0 +1,47 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationCreated event.Type = "organization.created"
+)
+
+type OrganizationCreatedEventPayload struct {
+	Organization model.Organization `json:"organization"`
+}
+
+func (e *OrganizationCreatedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationCreated
+}
+
+func (e *OrganizationCreatedEventPayload) UserID() string {
+	return ""
+}
+
+func (e *OrganizationCreatedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationCreatedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationCreatedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationCreatedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationCreatedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationCreatedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationCreatedEventPayload{}
@@ -0,0 +1,48 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationDeleted event.Type = "organization.deleted"
+)
+
+type OrganizationDeletedEventPayload struct {
+	Organization  model.Organization `json:"organization"`
+	MemberUserIDs []string           `json:"member_user_ids"`
+}
+
+func (e *OrganizationDeletedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationDeleted
+}
+
+func (e *OrganizationDeletedEventPayload) UserID() string {
+	return ""
+}
+
+func (e *OrganizationDeletedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationDeletedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationDeletedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationDeletedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationDeletedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationDeletedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationDeletedEventPayload{}
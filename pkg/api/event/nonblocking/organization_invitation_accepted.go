@@ -0,0 +1,49 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationInvitationAccepted event.Type = "organization.invitation.accepted"
+)
+
+type OrganizationInvitationAcceptedEventPayload struct {
+	Organization model.Organization           `json:"organization"`
+	Invitation   model.OrganizationInvitation `json:"invitation"`
+	Member       model.OrganizationMember     `json:"member"`
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationInvitationAccepted
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) UserID() string {
+	return e.Member.UserID
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeUser
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationInvitationAcceptedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationInvitationAcceptedEventPayload{}
@@ -0,0 +1,48 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationInvitationCreated event.Type = "organization.invitation.created"
+)
+
+type OrganizationInvitationCreatedEventPayload struct {
+	Organization model.Organization           `json:"organization"`
+	Invitation   model.OrganizationInvitation `json:"invitation"`
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationInvitationCreated
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) UserID() string {
+	return ""
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationInvitationCreatedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationInvitationCreatedEventPayload{}
@@ -0,0 +1,48 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationInvitationDeleted event.Type = "organization.invitation.deleted"
+)
+
+type OrganizationInvitationDeletedEventPayload struct {
+	Organization model.Organization           `json:"organization"`
+	Invitation   model.OrganizationInvitation `json:"invitation"`
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationInvitationDeleted
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) UserID() string {
+	return ""
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationInvitationDeletedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationInvitationDeletedEventPayload{}
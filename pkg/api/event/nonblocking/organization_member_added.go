@@ -0,0 +1,48 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationMemberAdded event.Type = "organization.member.added"
+)
+
+type OrganizationMemberAddedEventPayload struct {
+	Organization model.Organization       `json:"organization"`
+	Member       model.OrganizationMember `json:"member"`
+}
+
+func (e *OrganizationMemberAddedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationMemberAdded
+}
+
+func (e *OrganizationMemberAddedEventPayload) UserID() string {
+	return e.Member.UserID
+}
+
+func (e *OrganizationMemberAddedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationMemberAddedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationMemberAddedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationMemberAddedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationMemberAddedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationMemberAddedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationMemberAddedEventPayload{}
@@ -0,0 +1,48 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationMemberRemoved event.Type = "organization.member.removed"
+)
+
+type OrganizationMemberRemovedEventPayload struct {
+	Organization model.Organization       `json:"organization"`
+	Member       model.OrganizationMember `json:"member"`
+}
+
+func (e *OrganizationMemberRemovedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationMemberRemoved
+}
+
+func (e *OrganizationMemberRemovedEventPayload) UserID() string {
+	return e.Member.UserID
+}
+
+func (e *OrganizationMemberRemovedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationMemberRemovedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationMemberRemovedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationMemberRemovedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationMemberRemovedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationMemberRemovedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationMemberRemovedEventPayload{}
@@ -0,0 +1,49 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationMemberUpdated event.Type = "organization.member.updated"
+)
+
+type OrganizationMemberUpdatedEventPayload struct {
+	Organization   model.Organization       `json:"organization"`
+	OriginalMember model.OrganizationMember `json:"original_member"`
+	NewMember      model.OrganizationMember `json:"new_member"`
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationMemberUpdated
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) UserID() string {
+	return e.NewMember.UserID
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationMemberUpdatedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationMemberUpdatedEventPayload{}
@@ -0,0 +1,48 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	OrganizationUpdated event.Type = "organization.updated"
+)
+
+type OrganizationUpdatedEventPayload struct {
+	OriginalOrganization model.Organization `json:"original_organization"`
+	NewOrganization      model.Organization `json:"new_organization"`
+}
+
+func (e *OrganizationUpdatedEventPayload) NonBlockingEventType() event.Type {
+	return OrganizationUpdated
+}
+
+func (e *OrganizationUpdatedEventPayload) UserID() string {
+	return ""
+}
+
+func (e *OrganizationUpdatedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeAdminAPI
+}
+
+func (e *OrganizationUpdatedEventPayload) FillContext(ctx *event.Context) {
+}
+
+func (e *OrganizationUpdatedEventPayload) ForHook() bool {
+	return true
+}
+
+func (e *OrganizationUpdatedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *OrganizationUpdatedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *OrganizationUpdatedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &OrganizationUpdatedEventPayload{}
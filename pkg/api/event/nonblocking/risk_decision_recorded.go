@@ -0,0 +1,46 @@
+package nonblocking
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	RiskDecisionRecorded event.Type = "risk.decision_recorded"
+)
+
+type RiskDecisionRecordedEventPayload struct {
+	Record model.RiskDecisionRecord `json:"record"`
+}
+
+func (e *RiskDecisionRecordedEventPayload) NonBlockingEventType() event.Type {
+	return RiskDecisionRecorded
+}
+
+func (e *RiskDecisionRecordedEventPayload) UserID() string {
+	return e.Record.UserID
+}
+
+func (e *RiskDecisionRecordedEventPayload) GetTriggeredBy() event.TriggeredByType {
+	return event.TriggeredByTypeUser
+}
+
+func (e *RiskDecisionRecordedEventPayload) FillContext(ctx *event.Context) {}
+
+func (e *RiskDecisionRecordedEventPayload) ForHook() bool {
+	return false
+}
+
+func (e *RiskDecisionRecordedEventPayload) ForAudit() bool {
+	return true
+}
+
+func (e *RiskDecisionRecordedEventPayload) RequireReindexUserIDs() []string {
+	return nil
+}
+
+func (e *RiskDecisionRecordedEventPayload) DeletedUserIDs() []string {
+	return nil
+}
+
+var _ event.NonBlockingPayload = &RiskDecisionRecordedEventPayload{}
@@ -11,6 +11,8 @@ const (
 type SMSErrorEventPayload struct {
 	Description string `json:"description"`
 	Recipient   string `json:"recipient"`
+	// Provider is the last SMS gateway that failed to deliver the message.
+	Provider string `json:"provider,omitempty"`
 }
 
 func (e *SMSErrorEventPayload) NonBlockingEventType() event.Type {
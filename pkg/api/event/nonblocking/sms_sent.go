@@ -13,6 +13,8 @@ type SMSSentEventPayload struct {
 	Recipient           string `json:"recipient"`
 	Type                string `json:"type"`
 	IsNotCountedInUsage bool   `json:"is_not_counted_in_usage"`
+	// Provider is the SMS gateway that delivered the message.
+	Provider string `json:"provider,omitempty"`
 }
 
 func (e *SMSSentEventPayload) NonBlockingEventType() event.Type {
@@ -16,6 +16,7 @@ type UserDisabledEventPayload struct {
 	UserModel                model.User    `json:"user"`
 	TemporarilyDisabledFrom  *time.Time    `json:"temporarily_disabled_from,omitempty"`
 	TemporarilyDisabledUntil *time.Time    `json:"temporarily_disabled_until,omitempty"`
+	IsSystem                 bool          `json:"-"`
 }
 
 func (e *UserDisabledEventPayload) NonBlockingEventType() event.Type {
@@ -27,6 +28,9 @@ func (e *UserDisabledEventPayload) UserID() string {
 }
 
 func (e *UserDisabledEventPayload) GetTriggeredBy() event.TriggeredByType {
+	if e.IsSystem {
+		return event.TriggeredBySystem
+	}
 	return event.TriggeredByTypeAdminAPI
 }
 
@@ -21,8 +21,14 @@ var payloadRegistry = []any{
 	&blocking.AuthenticationPostIdentifiedBlockingEventPayload{},
 	&blocking.AuthenticationPreAuthenticatedBlockingEventPayload{},
 	&blocking.AuthenticationPreInitializeBlockingEventPayload{},
+	&blocking.AuthenticatorPreCreateBlockingEventPayload{},
+	&blocking.AuthenticatorPreDeleteBlockingEventPayload{},
+	&blocking.IdentityPreCreateBlockingEventPayload{},
+	&blocking.IdentityPreDeleteBlockingEventPayload{},
+	&blocking.OAuthTokenPreRefreshBlockingEventPayload{},
 	&blocking.OIDCIDTokenPreCreateBlockingEventPayload{},
 	&blocking.OIDCJWTPreCreateBlockingEventPayload{},
+	&blocking.SessionPreCreateBlockingEventPayload{},
 	&blocking.UserPreCreateBlockingEventPayload{},
 	&blocking.UserPreScheduleAnonymizationBlockingEventPayload{},
 	&blocking.UserPreScheduleDeletionBlockingEventPayload{},
@@ -54,6 +60,7 @@ var payloadRegistry = []any{
 	&nonblocking.AdminAPIMutationDeleteUserExecutedEventPayload{},
 	&nonblocking.AdminAPIMutationGenerateOOBOTPCodeExecutedEventPayload{},
 	&nonblocking.AdminAPIMutationSetPasswordExpiredExecutedEventPayload{},
+	&nonblocking.AdminAPIMutationRedeliverWebhookDeliveryExecutedEventPayload{},
 	&nonblocking.AdminAPIMutationRemoveGroupFromRolesExecutedEventPayload{},
 	&nonblocking.AdminAPIMutationRemoveGroupFromUsersExecutedEventPayload{},
 	&nonblocking.AdminAPIMutationRemoveResourceFromClientIDExecutedEventPayload{},
@@ -94,6 +101,8 @@ var payloadRegistry = []any{
 	&nonblocking.FraudProtectionDecisionRecordedEventPayload{},
 	&nonblocking.IdentityBiometricDisabledEventPayload{},
 	&nonblocking.IdentityBiometricEnabledEventPayload{},
+	&nonblocking.IdentityLDAPGroupsSyncedEventPayload{},
+	&nonblocking.IdentityLDAPUpdatedEventPayload{},
 	&nonblocking.IdentityLoginIDAddedEventPayload{},
 	&nonblocking.IdentityLoginIDRemovedEventPayload{},
 	&nonblocking.IdentityLoginIDUpdatedEventPayload{},
@@ -102,6 +111,15 @@ var payloadRegistry = []any{
 	&nonblocking.IdentityUnverifiedEventPayload{},
 	&nonblocking.IdentityVerifiedEventPayload{},
 	&nonblocking.M2MTokenCreatedEventPayload{},
+	&nonblocking.OrganizationCreatedEventPayload{},
+	&nonblocking.OrganizationDeletedEventPayload{},
+	&nonblocking.OrganizationInvitationAcceptedEventPayload{},
+	&nonblocking.OrganizationInvitationCreatedEventPayload{},
+	&nonblocking.OrganizationInvitationDeletedEventPayload{},
+	&nonblocking.OrganizationMemberAddedEventPayload{},
+	&nonblocking.OrganizationMemberRemovedEventPayload{},
+	&nonblocking.OrganizationMemberUpdatedEventPayload{},
+	&nonblocking.OrganizationUpdatedEventPayload{},
 	&nonblocking.ProjectAppCreatedEventPayload{},
 	&nonblocking.ProjectAppSecretViewedEventPayload{},
 	&nonblocking.ProjectAppUpdatedEventPayload{},
@@ -117,6 +135,7 @@ var payloadRegistry = []any{
 	&nonblocking.ProjectDomainDeletedEventPayload{},
 	&nonblocking.ProjectDomainVerifiedEventPayload{},
 	&nonblocking.RateLimitBlockedEventPayload{},
+	&nonblocking.RiskDecisionRecordedEventPayload{},
 	&nonblocking.SMSErrorEventPayload{},
 	&nonblocking.SMSSentEventPayload{},
 	&nonblocking.SMSSuppressedEventPayload{},
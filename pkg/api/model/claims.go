@@ -11,6 +11,7 @@ const (
 	ClaimPhoneNumber           ClaimName = "phone_number"
 	ClaimPreferredUsername     ClaimName = "preferred_username"
 	ClaimDeviceSecretHash      ClaimName = "ds_hash"
+	ClaimOrganizationID        ClaimName = "org_id"
 	ClaimAuthgearRoles         ClaimName = "https://authgear.com/claims/user/roles"
 	ClaimUserIsAnonymous       ClaimName = "https://authgear.com/claims/user/is_anonymous"
 	ClaimUserIsVerified        ClaimName = "https://authgear.com/claims/user/is_verified"
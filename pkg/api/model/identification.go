@@ -12,6 +12,7 @@ const (
 	AuthenticationFlowIdentificationPasskey  AuthenticationFlowIdentification = "passkey"
 	AuthenticationFlowIdentificationIDToken  AuthenticationFlowIdentification = "id_token"
 	AuthenticationFlowIdentificationLDAP     AuthenticationFlowIdentification = "ldap"
+	AuthenticationFlowIdentificationSAML     AuthenticationFlowIdentification = "saml"
 
 	AuthenticationFlowIdentificationSelectAccount AuthenticationFlowIdentification = "select_account"
 )
@@ -44,6 +45,9 @@ func (m AuthenticationFlowIdentification) PrimaryAuthentications() []Authenticat
 	case AuthenticationFlowIdentificationLDAP:
 		// LDAP does not require primary authentication.
 		return nil
+	case AuthenticationFlowIdentificationSAML:
+		// SAML does not require primary authentication.
+		return nil
 	case AuthenticationFlowIdentificationSelectAccount:
 		// SelectAccount does not require primary authentication.
 		return nil
@@ -74,6 +78,9 @@ func (m AuthenticationFlowIdentification) SecondaryAuthentications() []Authentic
 		return nil
 	case AuthenticationFlowIdentificationLDAP:
 		return all
+	case AuthenticationFlowIdentificationSAML:
+		// SAML does not require secondary authentication.
+		return nil
 	case AuthenticationFlowIdentificationSelectAccount:
 		// SelectAccount does not require secondary authentication.
 		return nil
@@ -52,6 +52,7 @@ const (
 	IdentityTypePasskey   IdentityType = "passkey"
 	IdentityTypeSIWE      IdentityType = "siwe"
 	IdentityTypeLDAP      IdentityType = "ldap"
+	IdentityTypeSAML      IdentityType = "saml"
 )
 
 // This indicates whether the identity type can be used for password-related usage e.g. reset password, change password by admin.
@@ -99,6 +100,8 @@ func (t IdentityType) PrimaryAuthenticatorTypes(loginIDKeyType LoginIDKeyType) [
 		return nil
 	case IdentityTypeLDAP:
 		return nil
+	case IdentityTypeSAML:
+		return nil
 	default:
 		panic(fmt.Sprintf("identity: unexpected identity type: %s", t))
 	}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+)
+
+type Organization struct {
+	Meta
+	Key         string  `json:"key,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type OrganizationMember struct {
+	Meta
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	RoleKeys       []string `json:"role_keys"`
+}
+
+type OrganizationInvitation struct {
+	Meta
+	OrganizationID   string     `json:"organization_id"`
+	Email            string     `json:"email"`
+	RoleKeys         []string   `json:"role_keys"`
+	ExpireAt         time.Time  `json:"expire_at"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
+	AcceptedByUserID *string    `json:"accepted_by_user_id,omitempty"`
+}
+
+func (i *OrganizationInvitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpireAt)
+}
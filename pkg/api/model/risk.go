@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type RiskDecision string
+
+const (
+	RiskDecisionAllowed RiskDecision = "allowed"
+	RiskDecisionStepUp  RiskDecision = "step_up"
+	RiskDecisionBlocked RiskDecision = "blocked"
+)
+
+type RiskSignalType string
+
+const (
+	RiskSignalTypeNewDevice        RiskSignalType = "new_device"
+	RiskSignalTypeNewCountry       RiskSignalType = "new_country"
+	RiskSignalTypeImpossibleTravel RiskSignalType = "impossible_travel"
+	RiskSignalTypeDenyIP           RiskSignalType = "deny_ip"
+)
+
+type RiskSignal struct {
+	Type  RiskSignalType `json:"type"`
+	Score int            `json:"score"`
+}
+
+type RiskDecisionRecord struct {
+	Timestamp               time.Time    `json:"timestamp"`
+	Decision                RiskDecision `json:"decision"`
+	Score                   int          `json:"score"`
+	TriggeredSignals        []RiskSignal `json:"triggered_signals"`
+	UserAgent               string       `json:"user_agent,omitempty"`
+	IPAddress               string       `json:"ip_address,omitempty"`
+	HTTPUrl                 string       `json:"http_url,omitempty"`
+	HTTPReferer             string       `json:"http_referer,omitempty"`
+	UserID                  string       `json:"user_id,omitempty"`
+	GeoLocationCode         string       `json:"geo_location_code,omitempty"`
+	PreviousGeoLocationCode string       `json:"previous_geo_location_code,omitempty"`
+}
@@ -24,6 +24,7 @@ import (
 	identityservice "github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
 	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/botprotection"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
@@ -73,8 +74,10 @@ var DependencySet = wire.NewSet(
 
 	wire.Bind(new(oauthhandler.TokenHandlerAppDatabase), new(*appdb.Handle)),
 	wire.Bind(new(oauthhandler.AuthorizationHandlerDatabase), new(*appdb.Handle)),
+	wire.Bind(new(oauthhandler.BackchannelAuthenticationHandlerDatabase), new(*appdb.Handle)),
 
 	wire.Bind(new(interaction.NonceService), new(*nonce.Service)),
+	wire.Bind(new(oauthhandler.ClientAuthenticatorNonceStore), new(*nonce.Store)),
 
 	wire.Bind(new(webapp.SessionMiddlewareOAuthSessionService), new(*oauthsession.StoreRedis)),
 	wire.Bind(new(webapp.SessionMiddlewareOAuthUIInfoResolver), new(*oidc.UIInfoResolver)),
@@ -134,6 +137,11 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handleroauth.ProtocolConsentHandler), new(*oauthhandler.AuthorizationHandler)),
 	wire.Bind(new(handleroauth.ProtocolTokenHandler), new(*oauthhandler.TokenHandler)),
 	wire.Bind(new(handleroauth.ProtocolRevokeHandler), new(*oauthhandler.RevokeHandler)),
+	wire.Bind(new(handleroauth.ProtocolIntrospectHandler), new(*oauthhandler.IntrospectHandler)),
+	wire.Bind(new(handleroauth.ProtocolDeviceAuthorizationHandler), new(*oauthhandler.DeviceAuthorizationHandler)),
+	wire.Bind(new(handleroauth.ProtocolBackchannelAuthenticationHandler), new(*oauthhandler.BackchannelAuthenticationHandler)),
+	wire.Bind(new(handleroauth.ProtocolPushedAuthorizationHandler), new(*oauthhandler.PushedAuthorizationHandler)),
+	wire.Bind(new(handleroauth.ProtocolClientRegistrationHandler), new(*oauthhandler.ClientRegistrationHandler)),
 	wire.Bind(new(handleroauth.ProtocolEndSessionHandler), new(*oidchandler.EndSessionHandler)),
 	wire.Bind(new(handleroauth.ProtocolUserInfoProvider), new(*oidc.IDTokenIssuer)),
 	wire.Bind(new(handleroauth.JWSSource), new(*oidc.IDTokenIssuer)),
@@ -155,6 +163,7 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handlerapi.PromotionCodeIssuer), new(*oauthhandler.AnonymousUserHandler)),
 	wire.Bind(new(handlerapi.RateLimiter), new(*ratelimit.Limiter)),
 	wire.Bind(new(handlerapi.PresignProvider), new(*presign.Provider)),
+	wire.Bind(new(handlerapi.PoWChallengeIssuer), new(*botprotection.Provider)),
 	wire.Bind(new(handlerapi.WorkflowNewWorkflowService), new(*workflow.Service)),
 	wire.Bind(new(handlerapi.WorkflowGetWorkflowService), new(*workflow.Service)),
 	wire.Bind(new(handlerapi.WorkflowInputWorkflowService), new(*workflow.Service)),
@@ -166,6 +175,11 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handlerapi.AuthenticationFlowV1WebsocketOriginMatcher), new(*middleware.CORSMatcher)),
 	wire.Bind(new(handlerapi.AccountManagementV1IdentificationHandlerService), new(*accountmanagement.Service)),
 	wire.Bind(new(handlerapi.AccountManagementV1IdentificationOAuthHandlerService), new(*accountmanagement.Service)),
+	wire.Bind(new(handlerapi.AccountManagementV1SessionsHandlerService), new(*accountmanagement.Service)),
+	wire.Bind(new(handlerapi.AccountManagementV1SessionsRenameHandlerService), new(*accountmanagement.Service)),
+	wire.Bind(new(handlerapi.AccountManagementV1SessionsRevokeHandlerService), new(*accountmanagement.Service)),
+	wire.Bind(new(handlerapi.AccountManagementV1SessionsRevokeOthersHandlerService), new(*accountmanagement.Service)),
+	wire.Bind(new(handlerapi.AccountManagementV1MFADeviceTokensRevokeHandlerService), new(*accountmanagement.Service)),
 
 	viewmodelswebapp.DependencySet,
 	wire.Bind(new(viewmodelswebapp.StaticAssetResolver), new(*web.StaticAssetResolver)),
@@ -213,6 +227,9 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handlerwebappauthflowv2.EnterOOBOTPHandlerFlashMessage), new(*httputil.FlashMessage)),
 	wire.Bind(new(handlerwebappauthflowv2.ForgotPasswordOTPHandlerFlashMessage), new(*httputil.FlashMessage)),
 	wire.Bind(new(handlerwebapp.LogoutSessionManager), new(*session.Manager)),
+	wire.Bind(new(handlerwebapp.LogoutFrontchannelLogoutService), new(*oidc.FrontchannelLogoutService)),
+	wire.Bind(new(handlerwebappauthflowv2.DeviceDeviceGrantService), new(*oauthhandler.DeviceGrantService)),
+	wire.Bind(new(handlerwebapp.CIBAGrantService), new(*oauthhandler.CIBAGrantService)),
 	wire.Bind(new(handlerwebapp.PageService), new(*webapp.Service2)),
 	wire.Bind(new(handlerwebapp.ResourceManager), new(*resource.Manager)),
 	wire.Bind(new(handlerwebapp.GlobalEmbeddedResourceManager), new(*web.GlobalEmbeddedResourceManager)),
@@ -230,6 +247,7 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handlerwebapp.AuthflowControllerAuthflowService), new(*authenticationflow.Service)),
 	wire.Bind(new(handlerwebapp.WechatCallbackHandlerOAuthStateStore), new(*webappoauth.Store)),
 	wire.Bind(new(handlerwebapp.SSOCallbackHandlerOAuthStateStore), new(*webappoauth.Store)),
+	wire.Bind(new(handlerwebapp.SSOSAMLACSHandlerOAuthStateStore), new(*webappoauth.Store)),
 	wire.Bind(new(handlerwebappauthflowv2.AuthflowV2WechatHandlerOAuthStateStore), new(*webappoauth.Store)),
 
 	handlerwebappauthflowv2.DependencySet,
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/accountmanagement"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func ConfigureAccountManagementV1MFADeviceTokensRevokeRoute(route httproute.Route) httproute.Route {
+	return route.WithMethods("OPTIONS", "POST").WithPathPattern("/api/v1/account/mfa/device_tokens/revoke")
+}
+
+type AccountManagementV1MFADeviceTokensRevokeHandlerService interface {
+	RevokeMFADeviceTokens(ctx context.Context, resolvedSession session.ResolvedSession) (*accountmanagement.RevokeMFADeviceTokensOutput, error)
+}
+
+type AccountManagementV1MFADeviceTokensRevokeHandler struct {
+	Service AccountManagementV1MFADeviceTokensRevokeHandlerService
+}
+
+func (h *AccountManagementV1MFADeviceTokensRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	output, err := h.Service.RevokeMFADeviceTokens(ctx, session.GetSession(ctx))
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+
+	httputil.WriteJSONResponse(ctx, w, &api.Response{Result: output})
+}
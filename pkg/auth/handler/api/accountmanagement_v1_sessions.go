@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/accountmanagement"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func ConfigureAccountManagementV1SessionsRoute(route httproute.Route) httproute.Route {
+	return route.WithMethods("OPTIONS", "GET").WithPathPattern("/api/v1/account/sessions")
+}
+
+type AccountManagementV1SessionsHandlerService interface {
+	ListSessions(ctx context.Context, resolvedSession session.ResolvedSession) (*accountmanagement.ListSessionsOutput, error)
+}
+
+type AccountManagementV1SessionsHandler struct {
+	Service AccountManagementV1SessionsHandlerService
+}
+
+func (h *AccountManagementV1SessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	output, err := h.Service.ListSessions(ctx, session.GetSession(ctx))
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+
+	httputil.WriteJSONResponse(ctx, w, &api.Response{Result: output})
+}
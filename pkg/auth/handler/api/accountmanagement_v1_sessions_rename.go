@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/accountmanagement"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+//go:generate go tool mockgen -source=accountmanagement_v1_sessions_rename.go -destination=accountmanagement_v1_sessions_rename_mock_test.go -package api
+
+func ConfigureAccountManagementV1SessionsRenameRoute(route httproute.Route) httproute.Route {
+	return route.WithMethods("OPTIONS", "POST").WithPathPattern("/api/v1/account/sessions/rename")
+}
+
+var AccountManagementV1SessionsRenameSchema = validation.NewSimpleSchema(`
+	{
+		"type": "object",
+		"properties": {
+			"session_id": {
+				"type": "string",
+				"minLength": 1
+			},
+			"device_name": {
+				"type": "string",
+				"minLength": 1,
+				"maxLength": 100
+			}
+		},
+		"required": ["session_id", "device_name"]
+	}
+`)
+
+type AccountManagementV1SessionsRenameRequest struct {
+	SessionID  string `json:"session_id,omitempty"`
+	DeviceName string `json:"device_name,omitempty"`
+}
+
+type AccountManagementV1SessionsRenameHandlerService interface {
+	RenameSession(ctx context.Context, resolvedSession session.ResolvedSession, input *accountmanagement.RenameSessionInput) (*accountmanagement.RenameSessionOutput, error)
+}
+
+type AccountManagementV1SessionsRenameHandler struct {
+	Service AccountManagementV1SessionsRenameHandlerService
+}
+
+func (h *AccountManagementV1SessionsRenameHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var request AccountManagementV1SessionsRenameRequest
+	err = httputil.BindJSONBody(r, w, AccountManagementV1SessionsRenameSchema.Validator(), &request)
+	ctx := r.Context()
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+	h.handle(ctx, w, r, request)
+}
+
+func (h *AccountManagementV1SessionsRenameHandler) handle(ctx context.Context, w http.ResponseWriter, r *http.Request, request AccountManagementV1SessionsRenameRequest) {
+	output, err := h.Service.RenameSession(ctx, session.GetSession(ctx), &accountmanagement.RenameSessionInput{
+		SessionID:  request.SessionID,
+		DeviceName: request.DeviceName,
+	})
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+
+	httputil.WriteJSONResponse(ctx, w, &api.Response{Result: output})
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/accountmanagement"
+	sessiontest "github.com/authgear/authgear-server/pkg/lib/session/test"
+)
+
+func TestAccountManagementV1SessionsRenameHandlerRequestValidation(t *testing.T) {
+	Convey("AccountManagementV1SessionsRenameHandler request validation", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := NewMockAccountManagementV1SessionsRenameHandlerService(ctrl)
+		h := AccountManagementV1SessionsRenameHandler{
+			Service: svc,
+		}
+
+		Convey("empty object", func() {
+			r, _ := http.NewRequest("POST", "", strings.NewReader("{}"))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+			So(w.Result().StatusCode, ShouldEqual, 400)
+			So(w.Body.String(), ShouldEqualJSON, `
+{
+    "error": {
+        "name": "Invalid",
+        "reason": "ValidationFailed",
+        "message": "invalid request body",
+        "code": 400,
+        "info": {
+            "causes": [
+                {
+                    "location": "",
+                    "kind": "required",
+                    "details": {
+                        "actual": null,
+                        "expected": [
+                            "device_name",
+                            "session_id"
+                        ],
+                        "missing": [
+                            "device_name",
+                            "session_id"
+                        ]
+                    }
+                }
+            ]
+        }
+    }
+}
+		`)
+		})
+
+		Convey("device name too long", func() {
+			r, _ := http.NewRequest("POST", "", strings.NewReader(`
+{
+	"session_id": "session-id",
+	"device_name": "`+strings.Repeat("a", 101)+`"
+}
+			`))
+			r.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+			So(w.Result().StatusCode, ShouldEqual, 400)
+		})
+
+		Convey("valid", func() {
+			r, _ := http.NewRequest("POST", "", strings.NewReader(`
+{
+	"session_id": "session-id",
+	"device_name": "My phone"
+}
+			`))
+			r.Header.Set("Content-Type", "application/json")
+			mockSession := sessiontest.NewMockSession()
+			r = mockSession.ToRequest(r)
+			w := httptest.NewRecorder()
+
+			svc.EXPECT().RenameSession(gomock.Any(), gomock.Any(), &accountmanagement.RenameSessionInput{
+				SessionID:  "session-id",
+				DeviceName: "My phone",
+			}).Times(1).Return(&accountmanagement.RenameSessionOutput{}, nil)
+			h.ServeHTTP(w, r)
+			So(w.Result().StatusCode, ShouldEqual, 200)
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/accountmanagement"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+//go:generate go tool mockgen -source=accountmanagement_v1_sessions_revoke.go -destination=accountmanagement_v1_sessions_revoke_mock_test.go -package api
+
+func ConfigureAccountManagementV1SessionsRevokeRoute(route httproute.Route) httproute.Route {
+	return route.WithMethods("OPTIONS", "POST").WithPathPattern("/api/v1/account/sessions/revoke")
+}
+
+var AccountManagementV1SessionsRevokeSchema = validation.NewSimpleSchema(`
+	{
+		"type": "object",
+		"properties": {
+			"session_id": {
+				"type": "string",
+				"minLength": 1
+			}
+		},
+		"required": ["session_id"]
+	}
+`)
+
+type AccountManagementV1SessionsRevokeRequest struct {
+	SessionID string `json:"session_id,omitempty"`
+}
+
+type AccountManagementV1SessionsRevokeHandlerService interface {
+	RevokeSession(ctx context.Context, resolvedSession session.ResolvedSession, input *accountmanagement.RevokeSessionInput) (*accountmanagement.RevokeSessionOutput, error)
+}
+
+type AccountManagementV1SessionsRevokeHandler struct {
+	Service AccountManagementV1SessionsRevokeHandlerService
+}
+
+func (h *AccountManagementV1SessionsRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var request AccountManagementV1SessionsRevokeRequest
+	err = httputil.BindJSONBody(r, w, AccountManagementV1SessionsRevokeSchema.Validator(), &request)
+	ctx := r.Context()
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+	h.handle(ctx, w, r, request)
+}
+
+func (h *AccountManagementV1SessionsRevokeHandler) handle(ctx context.Context, w http.ResponseWriter, r *http.Request, request AccountManagementV1SessionsRevokeRequest) {
+	output, err := h.Service.RevokeSession(ctx, session.GetSession(ctx), &accountmanagement.RevokeSessionInput{
+		SessionID: request.SessionID,
+	})
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+
+	httputil.WriteJSONResponse(ctx, w, &api.Response{Result: output})
+}
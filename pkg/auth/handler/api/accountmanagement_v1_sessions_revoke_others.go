@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/accountmanagement"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func ConfigureAccountManagementV1SessionsRevokeOthersRoute(route httproute.Route) httproute.Route {
+	return route.WithMethods("OPTIONS", "POST").WithPathPattern("/api/v1/account/sessions/revoke_others")
+}
+
+type AccountManagementV1SessionsRevokeOthersHandlerService interface {
+	RevokeOtherSessions(ctx context.Context, resolvedSession session.ResolvedSession) (*accountmanagement.RevokeOtherSessionsOutput, error)
+}
+
+type AccountManagementV1SessionsRevokeOthersHandler struct {
+	Service AccountManagementV1SessionsRevokeOthersHandlerService
+}
+
+func (h *AccountManagementV1SessionsRevokeOthersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	output, err := h.Service.RevokeOtherSessions(ctx, session.GetSession(ctx))
+	if err != nil {
+		httputil.WriteJSONResponse(ctx, w, &api.Response{Error: err})
+		return
+	}
+
+	httputil.WriteJSONResponse(ctx, w, &api.Response{Result: output})
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/botprotection"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+func ConfigureBotProtectionPoWChallengeRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("GET", "OPTIONS").
+		WithPathPattern("/api/bot_protection/pow/challenge")
+}
+
+type PoWChallengeIssuer interface {
+	NewPoWChallenge() (*botprotection.PoWChallenge, error)
+}
+
+var BotProtectionPoWChallengeHandlerLogger = slogutil.NewLogger("handler-bot-protection-pow-challenge")
+
+type BotProtectionPoWChallengeHandler struct {
+	Challenges PoWChallengeIssuer
+}
+
+func (h *BotProtectionPoWChallengeHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	// Every challenge must be solved afresh.
+	resp.Header().Set("Cache-Control", "no-store")
+
+	result, err := h.Challenges.NewPoWChallenge()
+	if err != nil {
+		if !apierrors.IsAPIError(err) {
+			logger := BotProtectionPoWChallengeHandlerLogger.GetLogger(ctx)
+			logger.WithError(err).Error(ctx, "bot protection pow challenge handler failed")
+		}
+		httputil.WriteJSONResponse(ctx, resp, &api.Response{Error: err})
+		return
+	}
+
+	httputil.WriteJSONResponse(ctx, resp, &api.Response{Result: result})
+}
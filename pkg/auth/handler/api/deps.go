@@ -8,6 +8,7 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(AnonymousUserSignupAPIHandler), "*"),
 	wire.Struct(new(AnonymousUserPromotionCodeAPIHandler), "*"),
 	wire.Struct(new(PresignImagesUploadHandler), "*"),
+	wire.Struct(new(BotProtectionPoWChallengeHandler), "*"),
 
 	wire.Struct(new(WorkflowNewHandler), "*"),
 	wire.Struct(new(WorkflowGetHandler), "*"),
@@ -22,4 +23,9 @@ var DependencySet = wire.NewSet(
 
 	wire.Struct(new(AccountManagementV1IdentificationHandler), "*"),
 	wire.Struct(new(AccountManagementV1IdentificationOAuthHandler), "*"),
+	wire.Struct(new(AccountManagementV1SessionsHandler), "*"),
+	wire.Struct(new(AccountManagementV1SessionsRenameHandler), "*"),
+	wire.Struct(new(AccountManagementV1SessionsRevokeHandler), "*"),
+	wire.Struct(new(AccountManagementV1SessionsRevokeOthersHandler), "*"),
+	wire.Struct(new(AccountManagementV1MFADeviceTokensRevokeHandler), "*"),
 )
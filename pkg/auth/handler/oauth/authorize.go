@@ -21,6 +21,7 @@ func ConfigureAuthorizeRoute(route httproute.Route) httproute.Route {
 var AuthorizeHandlerLogger = slogutil.NewLogger("handler-authz")
 
 type ProtocolAuthorizeHandler interface {
+	ResolveRequest(ctx context.Context, r protocol.AuthorizationRequest) (protocol.AuthorizationRequest, *handler.AuthorizationResultError)
 	ValidateRequestWithoutTx(ctx context.Context, r protocol.AuthorizationRequest) (context.Context, *handler.AuthorizationParams, *handler.AuthorizationResultError)
 	HandleRequest(ctx context.Context, r protocol.AuthorizationRequest, params *handler.AuthorizationParams) httputil.Result
 }
@@ -43,6 +44,12 @@ func (h *AuthorizeHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		req[name] = values[0]
 	}
 
+	req, errResult := h.AuthzHandler.ResolveRequest(r.Context(), req)
+	if errResult != nil {
+		errResult.WriteResponse(rw, r)
+		return
+	}
+
 	ctx, params, errResult := h.AuthzHandler.ValidateRequestWithoutTx(r.Context(), req)
 	if errResult != nil {
 		errResult.WriteResponse(rw, r)
@@ -0,0 +1,39 @@
+package oauth
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func ConfigureBackchannelAuthenticationRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("POST", "OPTIONS").
+		WithPathPattern("/oauth2/bc-authorize")
+}
+
+type ProtocolBackchannelAuthenticationHandler interface {
+	Handle(ctx context.Context, req *http.Request, r protocol.BackchannelAuthenticationRequest) httputil.Result
+}
+
+type BackchannelAuthenticationHandler struct {
+	BackchannelAuthenticationHandler ProtocolBackchannelAuthenticationHandler
+}
+
+func (h *BackchannelAuthenticationHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm() // #nosec G120 -- BodyLimitMiddleware caps POST bodies to 1MB for this backchannel authentication endpoint.
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	req := protocol.BackchannelAuthenticationRequest{}
+	maps.Copy(req, r.Form)
+
+	result := h.BackchannelAuthenticationHandler.Handle(r.Context(), r, req)
+	result.WriteResponse(rw, r)
+}
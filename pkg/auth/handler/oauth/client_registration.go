@@ -0,0 +1,79 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func ConfigureClientRegistrationRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("POST", "OPTIONS").
+		WithPathPattern("/oauth2/register")
+}
+
+func ConfigureClientConfigurationRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("GET", "PUT", "DELETE", "OPTIONS").
+		WithPathPattern("/oauth2/register/:client_id")
+}
+
+type ProtocolClientRegistrationHandler interface {
+	Register(ctx context.Context, req *http.Request, r protocol.ClientRegistrationRequest) httputil.Result
+	Read(ctx context.Context, req *http.Request, clientID string) httputil.Result
+	Update(ctx context.Context, req *http.Request, clientID string, r protocol.ClientRegistrationRequest) httputil.Result
+	Delete(ctx context.Context, req *http.Request, clientID string) httputil.Result
+}
+
+type ClientRegistrationHandler struct {
+	ClientRegistrationHandler ProtocolClientRegistrationHandler
+}
+
+func (h *ClientRegistrationHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	clientID := httproute.GetParam(r, "client_id")
+
+	var result httputil.Result
+	switch {
+	case clientID == "" && r.Method == "POST":
+		req, ok := h.parseRequest(rw, r)
+		if !ok {
+			return
+		}
+		result = h.ClientRegistrationHandler.Register(ctx, r, req)
+	case clientID != "" && r.Method == "GET":
+		result = h.ClientRegistrationHandler.Read(ctx, r, clientID)
+	case clientID != "" && r.Method == "PUT":
+		req, ok := h.parseRequest(rw, r)
+		if !ok {
+			return
+		}
+		result = h.ClientRegistrationHandler.Update(ctx, r, clientID, req)
+	case clientID != "" && r.Method == "DELETE":
+		result = h.ClientRegistrationHandler.Delete(ctx, r, clientID)
+	default:
+		http.Error(rw, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result.WriteResponse(rw, r)
+}
+
+// The client metadata is sent as a JSON object.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-3.1
+func (h *ClientRegistrationHandler) parseRequest(rw http.ResponseWriter, r *http.Request) (protocol.ClientRegistrationRequest, bool) {
+	req := protocol.ClientRegistrationRequest{}
+	// BodyLimitMiddleware caps the request body for this endpoint.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(rw).Encode(protocol.NewErrorResponse("invalid_client_metadata", "invalid JSON request body"))
+		return nil, false
+	}
+	return req, true
+}
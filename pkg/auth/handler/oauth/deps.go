@@ -9,6 +9,11 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(ConsentHandler), "*"),
 	wire.Struct(new(TokenHandler), "*"),
 	wire.Struct(new(RevokeHandler), "*"),
+	wire.Struct(new(IntrospectHandler), "*"),
+	wire.Struct(new(DeviceAuthorizationHandler), "*"),
+	wire.Struct(new(BackchannelAuthenticationHandler), "*"),
+	wire.Struct(new(PushedAuthorizationHandler), "*"),
+	wire.Struct(new(ClientRegistrationHandler), "*"),
 	wire.Struct(new(MetadataHandler), "*"),
 	wire.Struct(new(JWKSHandler), "*"),
 	wire.Struct(new(UserInfoHandler), "*"),
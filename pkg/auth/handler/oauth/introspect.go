@@ -0,0 +1,56 @@
+package oauth
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+func ConfigureIntrospectRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("POST", "OPTIONS").
+		WithPathPattern("/oauth2/introspect")
+}
+
+var IntrospectHandlerLogger = slogutil.NewLogger("handler-introspect")
+
+type ProtocolIntrospectHandler interface {
+	Handle(ctx context.Context, req *http.Request, r protocol.IntrospectRequest) httputil.Result
+}
+
+type IntrospectHandler struct {
+	Database          *appdb.Handle
+	IntrospectHandler ProtocolIntrospectHandler
+}
+
+func (h *IntrospectHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm() // #nosec G120 -- BodyLimitMiddleware caps POST bodies to 1MB for this introspect endpoint.
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	req := protocol.IntrospectRequest{}
+	maps.Copy(req, r.Form)
+
+	ctx := r.Context()
+	var result httputil.Result
+	err = h.Database.ReadOnly(ctx, func(ctx context.Context) error {
+		result = h.IntrospectHandler.Handle(ctx, r, req)
+		return nil
+	})
+	if err != nil {
+		logger := IntrospectHandlerLogger.GetLogger(ctx)
+		logger.WithError(err).Error(ctx, "oauth introspect handler failed")
+		http.Error(rw, "Internal Server Error", 500)
+		return
+	}
+
+	result.WriteResponse(rw, r)
+}
@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func ConfigurePushedAuthorizationRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("POST", "OPTIONS").
+		WithPathPattern("/oauth2/par")
+}
+
+type ProtocolPushedAuthorizationHandler interface {
+	Handle(ctx context.Context, req *http.Request, r protocol.PushedAuthorizationRequest) httputil.Result
+}
+
+type PushedAuthorizationHandler struct {
+	PushedAuthorizationHandler ProtocolPushedAuthorizationHandler
+}
+
+func (h *PushedAuthorizationHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm() // #nosec G120 -- BodyLimitMiddleware caps POST bodies to 1MB for this pushed authorization request endpoint.
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	// The parameters must be sent in the request body.
+	// See https://datatracker.ietf.org/doc/html/rfc9126#section-2.1
+	req := protocol.PushedAuthorizationRequest{}
+	maps.Copy(req, r.PostForm)
+
+	result := h.PushedAuthorizationHandler.Handle(r.Context(), r, req)
+	result.WriteResponse(rw, r)
+}
@@ -2,6 +2,8 @@ package oauth
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
@@ -19,7 +21,7 @@ func ConfigureRevokeRoute(route httproute.Route) httproute.Route {
 var RevokeHandlerLogger = slogutil.NewLogger("handler-revoke")
 
 type ProtocolRevokeHandler interface {
-	Handle(ctx context.Context, r protocol.RevokeRequest) error
+	Handle(ctx context.Context, req *http.Request, r protocol.RevokeRequest) error
 }
 
 type RevokeHandler struct {
@@ -41,10 +43,20 @@ func (h *RevokeHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	err = h.Database.WithTx(ctx, func(ctx context.Context) error {
-		return h.RevokeHandler.Handle(ctx, req)
+		return h.RevokeHandler.Handle(ctx, r, req)
 	})
 
-	if err != nil {
+	var oauthError *protocol.OAuthProtocolError
+	if errors.As(err, &oauthError) {
+		statusCode := oauthError.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusBadRequest
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Cache-Control", "no-store")
+		rw.WriteHeader(statusCode)
+		_ = json.NewEncoder(rw).Encode(oauthError.Response)
+	} else if err != nil {
 		logger := RevokeHandlerLogger.GetLogger(ctx)
 		logger.WithError(err).Error(ctx, "oauth revoke handler failed")
 		http.Error(rw, "Internal Server Error", 500)
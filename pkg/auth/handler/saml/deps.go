@@ -7,6 +7,7 @@ import (
 var DependencySet = wire.NewSet(
 	wire.Struct(new(LoginResultHandler), "*"),
 	wire.Struct(new(MetadataHandler), "*"),
+	wire.Struct(new(SPMetadataHandler), "*"),
 	wire.Struct(new(LoginHandler), "*"),
 	wire.Struct(new(LoginFinishHandler), "*"),
 	wire.Struct(new(LogoutHandler), "*"),
@@ -60,6 +60,7 @@ type LogoutHandler struct {
 	SAMLSLOSessionService SAMLSLOSessionService
 	SAMLSLOService        SAMLSLOService
 	Endpoints             Endpoints
+	FrontchannelLogout    FrontchannelLogoutService
 
 	BindingHTTPPostWriter     BindingHTTPPostWriter
 	BindingHTTPRedirectWriter BindingHTTPRedirectWriter
@@ -204,6 +205,10 @@ func (h *LogoutHandler) doLogoutRemainingSPs(
 		// This is not a logout triggered by SP, redirect to post logout url
 		// #nosec G710 -- PostLogoutRedirectURI is only ever populated from webapp.ResolvePostLogoutRedirectURI,
 		// which allow-lists it against the OAuth client's registered PostLogoutRedirectURIs or enforces same-origin.
+		if len(result.sloSession.Entry.FrontchannelLogoutURIs) > 0 {
+			h.FrontchannelLogout.WriteResponse(rw, r, result.sloSession.Entry.FrontchannelLogoutURIs, result.sloSession.Entry.PostLogoutRedirectURI)
+			return
+		}
 		http.Redirect(rw, r, result.sloSession.Entry.PostLogoutRedirectURI, http.StatusFound)
 		return
 	} else {
@@ -129,3 +129,7 @@ type SAMLSLOService interface {
 type Endpoints interface {
 	LogoutEndpointURL() *url.URL
 }
+
+type FrontchannelLogoutService interface {
+	WriteResponse(rw http.ResponseWriter, r *http.Request, frontchannelLogoutURIs []string, redirectURI string)
+}
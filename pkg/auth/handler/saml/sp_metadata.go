@@ -0,0 +1,48 @@
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+)
+
+func ConfigureSPMetadataRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("GET").
+		WithPathPattern("/saml2/sp/metadata/:identity_provider_name")
+}
+
+type SPMetadataHandlerSAMLSPService interface {
+	Metadata(identityProviderName string) (*samlprotocol.Metadata, error)
+}
+
+type SPMetadataHandler struct {
+	SAMLSPService SPMetadataHandlerSAMLSPService
+}
+
+func (h *SPMetadataHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	identityProviderName := httproute.GetParam(r, "identity_provider_name")
+
+	metadata, err := h.SAMLSPService.Metadata(identityProviderName)
+	if err != nil {
+		if errors.Is(err, api.ErrSAMLIdentityProviderNotFound) {
+			http.NotFound(rw, r)
+			return
+		}
+		panic(err)
+	}
+
+	metadataBytes := metadata.ToXMLBytes()
+	fileName := fmt.Sprintf("%s-sp-metadata.xml", identityProviderName)
+	rw.Header().Set("Content-Type", "application/samlmetadata+xml")
+	rw.Header().Set("content-disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+	// #nosec G705 -- The response is generated server-side SAML metadata XML.
+	_, err = rw.Write(metadataBytes)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -117,6 +117,11 @@ type AuthflowOAuthCallbackResponse struct {
 	State *webappoauth.WebappOAuthState
 }
 
+type AuthflowSAMLCallbackResponse struct {
+	SAMLResponse string
+	State        *webappoauth.WebappOAuthState
+}
+
 type AuthflowEndpoints interface {
 	SSOCallbackURL(alias string) *url.URL
 	SharedSSOCallbackURL() *url.URL
@@ -239,6 +244,39 @@ func (c *AuthflowController) HandleOAuthCallback(ctx context.Context, w http.Res
 	return
 }
 
+func (c *AuthflowController) HandleSAMLCallback(ctx context.Context, w http.ResponseWriter, r *http.Request, callbackResponse AuthflowSAMLCallbackResponse) {
+	state := callbackResponse.State
+
+	s, err := c.Sessions.Get(ctx, state.WebSessionID)
+	if err != nil {
+		c.renderError(ctx, w, r, err)
+		return
+	}
+
+	screen, err := c.GetScreen(ctx, s, state.XStep)
+	if err != nil {
+		c.renderError(ctx, w, r, err)
+		return
+	}
+
+	input := map[string]any{
+		"saml_response": callbackResponse.SAMLResponse,
+	}
+	result, err := c.AdvanceWithInput(ctx, r, s, screen, input, nil)
+	if err != nil {
+		u, parseURLErr := url.Parse(state.ErrorRedirectURI)
+		if parseURLErr != nil {
+			panic(parseURLErr)
+		}
+
+		c.ErrorRenderer.MakeAuthflowErrorResult(ctx, w, r, *u, err).WriteResponse(w, r)
+		return
+	}
+
+	result.WriteResponse(w, r)
+	return
+}
+
 func (c *AuthflowController) HandleResumeOfFlow(
 	ctx context.Context,
 	w http.ResponseWriter,
@@ -293,6 +331,39 @@ func (c *AuthflowController) HandleResumeOfFlow(
 	handler.ServeHTTP(w, r)
 }
 
+// HandleMagicLink resumes the flow which sent the magic link,
+// on the device where the magic link is opened.
+// It consumes the code, so it must only be called after the user confirms.
+func (c *AuthflowController) HandleMagicLink(ctx context.Context, w http.ResponseWriter, r *http.Request, input map[string]any) {
+	if err := r.ParseForm(); err != nil { // #nosec G120 -- BodyLimitMiddleware caps POST bodies to 1MB; query params are part of this webapp route contract.
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s, err := c.getOrCreateWebSession(ctx, w, r, webapp.SessionOptions{})
+	if err != nil {
+		c.renderError(ctx, w, r, err)
+		return
+	}
+
+	output, err := c.feedInput(ctx, "", input)
+	if err != nil {
+		c.renderError(ctx, w, r, err)
+		return
+	}
+
+	screen, err := c.createScreenWithOutput(ctx, r, s, output, "")
+	if err != nil {
+		c.renderError(ctx, w, r, err)
+		return
+	}
+
+	result := &webapp.Result{}
+	result.Cookies = append(result.Cookies, output.Cookies...)
+	screen.Navigate(ctx, c.Navigator, r, s.ID, result)
+	result.WriteResponse(w, r)
+}
+
 func (c *AuthflowController) HandleStep(ctx context.Context, w http.ResponseWriter, r *http.Request, handlers *AuthflowControllerHandlers) {
 	if handled := c.handleInlinePreviewIfNecessary(ctx, w, r, handlers); handled {
 		return
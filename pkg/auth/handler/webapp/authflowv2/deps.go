@@ -35,9 +35,11 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(AuthflowV2EnterTOTPHandler), "*"),
 	wire.Struct(new(AuthflowV2OOBOTPLinkHandler), "*"),
 	wire.Struct(new(AuthflowV2VerifyLoginLinkOTPHandler), "*"),
+	wire.Struct(new(AuthflowV2MagicLinkHandler), "*"),
 	wire.Struct(new(AuthflowV2PromptCreatePasskeyHandler), "*"),
 	wire.Struct(new(AuthflowV2UsePasskeyHandler), "*"),
 	wire.Struct(new(AuthflowV2TerminateOtherSessionsHandler), "*"),
+	wire.Struct(new(AuthflowV2DeviceHandler), "*"),
 	wire.Struct(new(AuthflowV2PromoteHandler), "*"),
 	wire.Struct(new(AuthflowV2FinishFlowHandler), "*"),
 	wire.Struct(new(AuthflowV2WechatHandler), "*"),
@@ -0,0 +1,152 @@
+package authflowv2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	handlerwebapp "github.com/authgear/authgear-server/pkg/auth/handler/webapp"
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+var TemplateWebAuthflowDeviceHTML = template.RegisterHTML(
+	"web/authflowv2/device.html",
+	handlerwebapp.Components...,
+)
+
+func ConfigureAuthflowV2DeviceRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/device")
+}
+
+const (
+	DeviceResultApproved = "approved"
+	DeviceResultDenied   = "denied"
+)
+
+type DeviceDeviceGrantService interface {
+	GetPendingDeviceGrant(ctx context.Context, userCode string) (*oauth.DeviceGrant, error)
+	ApproveDeviceGrant(ctx context.Context, userCode string, info authenticationinfo.T) error
+	DenyDeviceGrant(ctx context.Context, userCode string) error
+}
+
+type DeviceViewModel struct {
+	UserCode   string
+	ClientName string
+	Scopes     []string
+	// Confirming is true when UserCode refers to a pending device grant.
+	Confirming bool
+	// Result is either approved or denied after the user made a decision.
+	Result string
+}
+
+// AuthflowV2DeviceHandler is the verification page of OAuth 2.0 Device Authorization Grant.
+// See https://datatracker.ietf.org/doc/html/rfc8628#section-3.3
+type AuthflowV2DeviceHandler struct {
+	ControllerFactory   handlerwebapp.ControllerFactory
+	BaseViewModel       *viewmodels.BaseViewModeler
+	Renderer            handlerwebapp.Renderer
+	OAuthClientResolver handlerwebapp.WebappOAuthClientResolver
+	DeviceGrants        DeviceDeviceGrantService
+}
+
+func (h *AuthflowV2DeviceHandler) GetData(ctx context.Context, r *http.Request, w http.ResponseWriter) (map[string]any, error) {
+	data := map[string]any{}
+	baseViewModel := h.BaseViewModel.ViewModelForAuthFlow(r, w)
+	viewmodels.Embed(data, baseViewModel)
+
+	q := r.URL.Query()
+	deviceViewModel := DeviceViewModel{
+		UserCode: q.Get("user_code"),
+		Result:   q.Get("result"),
+	}
+
+	if deviceViewModel.Result == "" && deviceViewModel.UserCode != "" {
+		g, err := h.DeviceGrants.GetPendingDeviceGrant(ctx, deviceViewModel.UserCode)
+		if errors.Is(err, oauthhandler.ErrDeviceUserCodeInvalid) {
+			// Let the user enter the code again.
+		} else if err != nil {
+			return nil, err
+		} else {
+			deviceViewModel.Confirming = true
+			deviceViewModel.Scopes = g.Scopes
+			if client := h.OAuthClientResolver.ResolveClient(g.ClientID); client != nil {
+				deviceViewModel.ClientName = client.ClientName
+			}
+		}
+	}
+
+	viewmodels.Embed(data, deviceViewModel)
+	return data, nil
+}
+
+func (h *AuthflowV2DeviceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctrl, err := h.ControllerFactory.New(r, w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer ctrl.ServeWithDBTx(r.Context())
+
+	redirect := func(q url.Values) {
+		result := webapp.Result{RedirectURI: webapp.MakeRelativeURL(r.URL.Path, q).String()}
+		result.WriteResponse(w, r)
+	}
+
+	ctrl.Get(func(ctx context.Context) error {
+		data, err := h.GetData(ctx, r, w)
+		if err != nil {
+			return err
+		}
+
+		h.Renderer.RenderHTML(w, r, TemplateWebAuthflowDeviceHTML, data)
+		return nil
+	})
+
+	ctrl.PostAction("submit", func(ctx context.Context) error {
+		userCode := r.Form.Get("x_user_code")
+		_, err := h.DeviceGrants.GetPendingDeviceGrant(ctx, userCode)
+		if err != nil {
+			return err
+		}
+
+		redirect(url.Values{"user_code": {userCode}})
+		return nil
+	})
+
+	ctrl.PostAction("approve", func(ctx context.Context) error {
+		s := session.GetSession(ctx)
+		info := s.CreateNewAuthenticationInfoByThisSession()
+		// The device obtains a new session by continuing the session of this browser.
+		info.ShouldFireAuthenticatedEventWhenIssueOfflineGrant = true
+		info.ContinueFromSessionType = string(s.SessionType())
+		info.ContinueFromSessionID = s.SessionID()
+
+		err := h.DeviceGrants.ApproveDeviceGrant(ctx, r.Form.Get("x_user_code"), info)
+		if err != nil {
+			return err
+		}
+
+		redirect(url.Values{"result": {DeviceResultApproved}})
+		return nil
+	})
+
+	ctrl.PostAction("deny", func(ctx context.Context) error {
+		err := h.DeviceGrants.DenyDeviceGrant(ctx, r.Form.Get("x_user_code"))
+		if err != nil {
+			return err
+		}
+
+		redirect(url.Values{"result": {DeviceResultDenied}})
+		return nil
+	})
+}
@@ -200,6 +200,22 @@ func (h *InternalAuthflowV2SignupLoginHandler) ServeHTTP(w http.ResponseWriter,
 		return nil
 	})
 
+	handlers.PostAction("saml", func(ctx context.Context, s *webapp.Session, screen *webapp.AuthflowScreenWithFlowResponse) error {
+		identityProviderName := r.FormValue("x_identity_provider_name")
+		input := map[string]any{
+			"identification":         string(model.AuthenticationFlowIdentificationSAML),
+			"identity_provider_name": identityProviderName,
+		}
+
+		result, err := h.Controller.AdvanceWithInput(ctx, r, s, screen, input, nil)
+		if err != nil {
+			return err
+		}
+
+		result.WriteResponse(w, r)
+		return nil
+	})
+
 	handlers.PostAction("passkey", func(ctx context.Context, s *webapp.Session, screen *webapp.AuthflowScreenWithFlowResponse) error {
 		assertionResponseStr := r.Form.Get("x_assertion_response")
 
@@ -201,6 +201,22 @@ func (h *AuthflowV2LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return nil
 	})
 
+	handlers.PostAction("saml", func(ctx context.Context, s *webapp.Session, screen *webapp.AuthflowScreenWithFlowResponse) error {
+		identityProviderName := r.FormValue("x_identity_provider_name")
+		input := map[string]any{
+			"identification":         string(model.AuthenticationFlowIdentificationSAML),
+			"identity_provider_name": identityProviderName,
+		}
+
+		result, err := h.Controller.AdvanceWithInput(ctx, r, s, screen, input, nil)
+		if err != nil {
+			return err
+		}
+
+		result.WriteResponse(w, r)
+		return nil
+	})
+
 	handlers.PostAction("passkey", func(ctx context.Context, s *webapp.Session, screen *webapp.AuthflowScreenWithFlowResponse) error {
 		assertionResponseStr := r.Form.Get("x_assertion_response")
 
@@ -0,0 +1,51 @@
+package authflowv2
+
+import (
+	"net/http"
+
+	handlerwebapp "github.com/authgear/authgear-server/pkg/auth/handler/webapp"
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+var TemplateWebMagicLinkHTML = template.RegisterHTML(
+	"web/authflowv2/magic_link.html",
+	handlerwebapp.Components...,
+)
+
+func ConfigureAuthflowV2MagicLinkRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern(AuthflowV2RouteMagicLink)
+}
+
+type MagicLinkViewModel struct {
+	Code string
+}
+
+type AuthflowV2MagicLinkHandler struct {
+	Controller    *handlerwebapp.AuthflowController
+	BaseViewModel *viewmodels.BaseViewModeler
+	Renderer      handlerwebapp.Renderer
+}
+
+func (h *AuthflowV2MagicLinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Opening the link only shows a confirmation page,
+	// because mail scanners may follow the link before the user does.
+	// The code is consumed when the user confirms with POST.
+	if r.Method != http.MethodPost {
+		data := make(map[string]any)
+		viewmodels.Embed(data, h.BaseViewModel.ViewModel(r, w))
+		viewmodels.Embed(data, MagicLinkViewModel{
+			Code: r.URL.Query().Get("code"),
+		})
+		h.Renderer.RenderHTML(w, r, TemplateWebMagicLinkHTML, data)
+		return
+	}
+
+	code := r.FormValue("x_magic_link_code") // #nosec G120 -- BodyLimitMiddleware caps POST bodies to 1MB.
+	h.Controller.HandleMagicLink(r.Context(), w, r, map[string]any{
+		"magic_link_code": code,
+	})
+}
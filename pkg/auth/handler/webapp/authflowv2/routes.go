@@ -48,6 +48,7 @@ const (
 	AuthflowV2RouteEnterOOBOTP       = "/authflow/v2/enter_oob_otp"
 	AuthflowV2RouteOOBOTPLink        = "/authflow/v2/oob_otp_link"
 	AuthflowV2RouteVerifyLink        = "/authflow/v2/verify_login_link"
+	AuthflowV2RouteMagicLink         = "/authflow/v2/magic_link"
 	AuthflowV2RouteEnterTOTP         = "/authflow/v2/enter_totp"
 	AuthflowV2RouteSetupTOTP         = "/authflow/v2/setup_totp"
 	AuthflowV2RouteSetupOOBOTP       = "/authflow/v2/setup_oob_otp"
@@ -252,7 +253,7 @@ func (n *AuthflowV2Navigator) navigateSignupPromote(ctx context.Context, s *weba
 				switch data.OTPForm {
 				case otp.FormCode:
 					s.Advance(AuthflowV2RouteEnterOOBOTP, result)
-				case otp.FormLink:
+				case otp.FormLink, otp.FormMagicLink:
 					s.Advance(AuthflowV2RouteOOBOTPLink, result)
 				default:
 					panic(fmt.Errorf("unexpected otp form: %v", data.OTPForm))
@@ -308,7 +309,7 @@ func (n *AuthflowV2Navigator) navigateSignupPromote(ctx context.Context, s *weba
 			default:
 				panic(fmt.Errorf("unexpected channel: %v", channel))
 			}
-		case otp.FormLink:
+		case otp.FormLink, otp.FormMagicLink:
 			s.Advance(AuthflowV2RouteOOBOTPLink, result)
 		}
 	case config.AuthenticationFlowStepTypeFillInUserProfile:
@@ -386,6 +387,33 @@ func (n *AuthflowV2Navigator) navigateStepIdentify(ctx context.Context, s *webap
 			result.NavigationAction = webapp.NavigationActionRedirect
 			result.RedirectURI = authorizationURL.String()
 		}
+	case model.AuthenticationFlowIdentificationSAML:
+		data := s.StateTokenFlowResponse.Action.Data.(declarative.SAMLData)
+
+		authnRequestURL, _ := url.Parse(data.AuthnRequestURL)
+		q := authnRequestURL.Query()
+		// Back to the current screen if error
+		errorRedirectURI := url.URL{Path: r.URL.Path, RawQuery: r.URL.Query().Encode()}
+
+		state := &webappoauth.WebappOAuthState{
+			AppID:            string(n.AppID),
+			WebSessionID:     webSessionID,
+			UIImplementation: config.UIImplementationAuthflowV2,
+			XStep:            s.Screen.StateToken.XStep,
+			ErrorRedirectURI: errorRedirectURI.String(),
+			ProviderAlias:    data.IdentityProviderName,
+		}
+		stateToken, err := n.OAuthStateStore.GenerateState(ctx, state)
+		if err != nil {
+			panic(err)
+		}
+
+		// The identity provider returns RelayState as is to the assertion consumer service.
+		q.Set("RelayState", stateToken)
+		authnRequestURL.RawQuery = q.Encode()
+
+		result.NavigationAction = webapp.NavigationActionRedirect
+		result.RedirectURI = authnRequestURL.String()
 	case model.AuthenticationFlowIdentificationLDAP:
 		// Not expected to trigger this case
 		panic(fmt.Errorf("not expected to trigger: %v", identification))
@@ -463,7 +491,7 @@ func (n *AuthflowV2Navigator) navigateLoginStepAuthenticate(s *webapp.AuthflowSc
 		switch data.OTPForm {
 		case otp.FormCode:
 			s.Advance(AuthflowV2RouteEnterOOBOTP, result)
-		case otp.FormLink:
+		case otp.FormLink, otp.FormMagicLink:
 			s.Advance(AuthflowV2RouteOOBOTPLink, result)
 		default:
 			panic(fmt.Errorf("unexpected otp form: %v", data.OTPForm))
@@ -521,7 +549,7 @@ func (n *AuthflowV2Navigator) navigateReauth(ctx context.Context, s *webapp.Auth
 				switch data.OTPForm {
 				case otp.FormCode:
 					s.Advance(AuthflowV2RouteEnterOOBOTP, result)
-				case otp.FormLink:
+				case otp.FormLink, otp.FormMagicLink:
 					s.Advance(AuthflowV2RouteOOBOTPLink, result)
 				default:
 					panic(fmt.Errorf("unexpected otp form: %v", data.OTPForm))
@@ -0,0 +1,143 @@
+package webapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+var TemplateWebBackchannelAuthenticationHTML = template.RegisterHTML(
+	"web/authflowv2/backchannel_authentication.html",
+	Components...,
+)
+
+func ConfigureBackchannelAuthenticationRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/backchannel_authentication")
+}
+
+const (
+	BackchannelAuthenticationResultApproved = "approved"
+	BackchannelAuthenticationResultDenied   = "denied"
+)
+
+type CIBAGrantService interface {
+	GetPendingCIBAGrant(ctx context.Context, approvalCode string, userID string) (*oauth.CIBAGrant, error)
+	ApproveCIBAGrant(ctx context.Context, approvalCode string, info authenticationinfo.T) error
+	DenyCIBAGrant(ctx context.Context, approvalCode string, userID string) error
+}
+
+type BackchannelAuthenticationViewModel struct {
+	Code           string
+	ClientName     string
+	BindingMessage string
+	// Confirming is true when Code refers to a pending CIBA grant of the current user.
+	Confirming bool
+	// Result is either approved or denied after the user made a decision.
+	Result string
+}
+
+// BackchannelAuthenticationHandler is the page linked from the message sent to the user
+// in Client-Initiated Backchannel Authentication.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.8
+type BackchannelAuthenticationHandler struct {
+	ControllerFactory   ControllerFactory
+	BaseViewModel       *viewmodels.BaseViewModeler
+	Renderer            Renderer
+	OAuthClientResolver WebappOAuthClientResolver
+	CIBAGrants          CIBAGrantService
+}
+
+func (h *BackchannelAuthenticationHandler) GetData(ctx context.Context, r *http.Request, w http.ResponseWriter) (map[string]any, error) {
+	data := map[string]any{}
+	baseViewModel := h.BaseViewModel.ViewModelForAuthFlow(r, w)
+	viewmodels.Embed(data, baseViewModel)
+
+	q := r.URL.Query()
+	viewModel := BackchannelAuthenticationViewModel{
+		Code:   q.Get("code"),
+		Result: q.Get("result"),
+	}
+
+	if viewModel.Result == "" && viewModel.Code != "" {
+		userID := session.GetSession(ctx).GetAuthenticationInfo().UserID
+		g, err := h.CIBAGrants.GetPendingCIBAGrant(ctx, viewModel.Code, userID)
+		if errors.Is(err, oauthhandler.ErrCIBAApprovalCodeInvalid) {
+			// Show the request is invalid.
+		} else if err != nil {
+			return nil, err
+		} else {
+			viewModel.Confirming = true
+			viewModel.BindingMessage = g.BindingMessage
+			if client := h.OAuthClientResolver.ResolveClient(g.ClientID); client != nil {
+				viewModel.ClientName = client.ClientName
+			}
+		}
+	}
+
+	viewmodels.Embed(data, viewModel)
+	return data, nil
+}
+
+func (h *BackchannelAuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctrl, err := h.ControllerFactory.New(r, w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer ctrl.ServeWithDBTx(r.Context())
+
+	redirect := func(q url.Values) {
+		result := webapp.Result{RedirectURI: webapp.MakeRelativeURL(r.URL.Path, q).String()}
+		result.WriteResponse(w, r)
+	}
+
+	ctrl.Get(func(ctx context.Context) error {
+		data, err := h.GetData(ctx, r, w)
+		if err != nil {
+			return err
+		}
+
+		h.Renderer.RenderHTML(w, r, TemplateWebBackchannelAuthenticationHTML, data)
+		return nil
+	})
+
+	ctrl.PostAction("approve", func(ctx context.Context) error {
+		s := session.GetSession(ctx)
+		info := s.CreateNewAuthenticationInfoByThisSession()
+		// The client obtains a new session by continuing the session of this browser.
+		info.ShouldFireAuthenticatedEventWhenIssueOfflineGrant = true
+		info.ContinueFromSessionType = string(s.SessionType())
+		info.ContinueFromSessionID = s.SessionID()
+
+		err := h.CIBAGrants.ApproveCIBAGrant(ctx, r.Form.Get("x_code"), info)
+		if err != nil {
+			return err
+		}
+
+		redirect(url.Values{"result": {BackchannelAuthenticationResultApproved}})
+		return nil
+	})
+
+	ctrl.PostAction("deny", func(ctx context.Context) error {
+		userID := session.GetSession(ctx).GetAuthenticationInfo().UserID
+		err := h.CIBAGrants.DenyCIBAGrant(ctx, r.Form.Get("x_code"), userID)
+		if err != nil {
+			return err
+		}
+
+		redirect(url.Values{"result": {BackchannelAuthenticationResultDenied}})
+		return nil
+	})
+}
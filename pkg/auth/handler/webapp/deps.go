@@ -33,10 +33,12 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(RootHandler), "*"),
 	wire.Struct(new(OAuthEntrypointHandler), "*"),
 	wire.Struct(new(SSOCallbackHandler), "*"),
+	wire.Struct(new(SSOSAMLACSHandler), "*"),
 
 	wire.Struct(new(TesterHandler), "*"),
 
 	wire.Struct(new(LogoutHandler), "*"),
+	wire.Struct(new(BackchannelAuthenticationHandler), "*"),
 	wire.Struct(new(ReturnHandler), "*"),
 	wire.Struct(new(WebsocketHandler), "*"),
 	wire.Struct(new(WechatCallbackHandler), "*"),
@@ -50,6 +50,8 @@ func IdentitiesDisplayName(identities []*identity.Info) string {
 		return "siwe"
 	case model.IdentityTypeLDAP:
 		return "ldap"
+	case model.IdentityTypeSAML:
+		return "saml"
 	default:
 		return ""
 	}
@@ -47,6 +47,11 @@ type SAMLSLOService interface {
 	) error
 }
 
+type LogoutFrontchannelLogoutService interface {
+	FrontchannelLogoutURIs(sessions []session.ListableSession) []string
+	WriteResponse(rw http.ResponseWriter, r *http.Request, frontchannelLogoutURIs []string, redirectURI string)
+}
+
 type LogoutHandler struct {
 	ControllerFactory     ControllerFactory
 	Database              *appdb.Handle
@@ -60,6 +65,7 @@ type LogoutHandler struct {
 	OAuthClientResolver   WebappOAuthClientResolver
 	SAMLSLOSessionService SAMLSLOSessionService
 	SAMLSLOService        SAMLSLOService
+	FrontchannelLogout    LogoutFrontchannelLogoutService
 }
 
 func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -94,6 +100,8 @@ func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		postLogoutRedirectURI := webapp.ResolvePostLogoutRedirectURI(client, r.FormValue("post_logout_redirect_uri"), h.UIConfig)
 		redirectURI := webapp.GetRedirectURI(r, bool(h.TrustProxy), postLogoutRedirectURI)
 
+		frontchannelLogoutURIs := h.FrontchannelLogout.FrontchannelLogoutURIs(invalidatedSessions)
+
 		pendingLogoutServiceProviderIDs := setutil.Set[string]{}
 		for _, s := range invalidatedSessions {
 			pendingLogoutServiceProviderIDs = pendingLogoutServiceProviderIDs.Merge(s.GetParticipatedSAMLServiceProviderIDsSet())
@@ -104,6 +112,8 @@ func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				SID:                             oauth.EncodeSID(sess),
 				UserID:                          sess.GetAuthenticationInfo().UserID,
 				PostLogoutRedirectURI:           redirectURI,
+				// The front-channel logout is done after all service providers are logged out.
+				FrontchannelLogoutURIs: frontchannelLogoutURIs,
 			}
 			sloSession := samlslosession.NewSAMLSLOSession(sloSessionEntry)
 			err := h.SAMLSLOSessionService.Save(ctx, sloSession)
@@ -120,6 +130,10 @@ func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// If no saml service provider is pending logout
+		if len(frontchannelLogoutURIs) > 0 {
+			h.FrontchannelLogout.WriteResponse(w, r, frontchannelLogoutURIs, redirectURI)
+			return nil
+		}
 		// #nosec G710 -- redirectURI comes from webapp.GetRedirectURI, which enforces same-origin, or webapp.ResolvePostLogoutRedirectURI, which allow-lists against the OAuth client's registered PostLogoutRedirectURIs.
 		http.Redirect(w, r, redirectURI, http.StatusFound)
 		return nil
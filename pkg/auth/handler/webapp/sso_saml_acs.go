@@ -0,0 +1,59 @@
+package webapp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/webappoauth"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+)
+
+func ConfigureSSOSAMLACSRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST").
+		WithPathPattern("/sso/saml2/acs/:identity_provider_name")
+}
+
+type SSOSAMLACSHandlerOAuthStateStore interface {
+	PopAndRecoverState(ctx context.Context, stateToken string) (state *webappoauth.WebappOAuthState, err error)
+}
+
+// SSOSAMLACSHandler is the assertion consumer service of Authgear acting as a SAML service provider.
+// The identity provider posts the SAML response here with the HTTP-POST binding.
+type SSOSAMLACSHandler struct {
+	AuthflowController *AuthflowController
+	OAuthStateStore    SSOSAMLACSHandlerOAuthStateStore
+}
+
+func (h *SSOSAMLACSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil { // #nosec G120 -- BodyLimitMiddleware caps POST bodies to 1MB.
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The state token is round-tripped as RelayState.
+	stateToken := r.PostFormValue("RelayState")
+	state, err := h.OAuthStateStore.PopAndRecoverState(r.Context(), stateToken)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	identityProviderName := httproute.GetParam(r, "identity_provider_name")
+	if state.ProviderAlias != identityProviderName {
+		http.Error(w, webappoauth.ErrOAuthStateInvalid.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch state.UIImplementation {
+	case config.UIImplementationAuthflowV2:
+		h.AuthflowController.HandleSAMLCallback(r.Context(), w, r, AuthflowSAMLCallbackResponse{
+			SAMLResponse: r.PostFormValue("SAMLResponse"),
+			State:        state,
+		})
+	default:
+		panic(fmt.Errorf("expected ui implementation to be set in state"))
+	}
+}
@@ -283,6 +283,12 @@ func (m *AuthflowViewModeler) NewWithAuthflow(
 				"server_name": o.ServerName,
 			}
 			candidates = append(candidates, candidate)
+		case model.AuthenticationFlowIdentificationSAML:
+			candidate := map[string]any{
+				"type":                   string(model.IdentityTypeSAML),
+				"identity_provider_name": o.IdentityProviderName,
+			}
+			candidates = append(candidates, candidate)
 		case model.AuthenticationFlowIdentificationPasskey:
 			// Passkey was not handled by candidates.
 			break
@@ -480,6 +486,16 @@ func (m *AuthflowViewModeler) NewWithConfig() AuthflowViewModel {
 		candidates = append(candidates, candidate)
 	}
 
+	if m.Identity.SAML != nil {
+		for _, idp := range m.Identity.SAML.IdentityProviders {
+			candidate := map[string]any{
+				"type":                   string(model.IdentityTypeSAML),
+				"identity_provider_name": idp.Name,
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+
 	return AuthflowViewModel{
 		IdentityCandidates: candidates,
 
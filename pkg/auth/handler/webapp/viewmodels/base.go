@@ -265,7 +265,10 @@ func (m *BaseViewModeler) ViewModel(r *http.Request, rw http.ResponseWriter) Bas
 	switch bpProviderType {
 	case config.BotProtectionProviderTypeCloudflare:
 		bpLang = intl.ResolveCloudflareTurnstile(resolvedLanguageTag)
-	case config.BotProtectionProviderTypeRecaptchaV2:
+	case config.BotProtectionProviderTypeRecaptchaV2,
+		config.BotProtectionProviderTypeRecaptchaV3,
+		config.BotProtectionProviderTypeRecaptchaEnterprise:
+		// All reCAPTCHA versions share the same list of supported languages.
 		bpLang = intl.ResolveRecaptchaV2(resolvedLanguageTag)
 	}
 
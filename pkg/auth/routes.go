@@ -322,6 +322,9 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 	router.Add(webapphandler.ConfigureSSOCallbackRoute(webappSSOCallbackRoute), &webapphandler.ImplementationSwitcherHandler{
 		AuthflowV2: p.Handler(newWebAppAuthflowV2SSOCallbackHandler),
 	})
+	router.Add(webapphandler.ConfigureSSOSAMLACSRoute(webappSSOCallbackRoute), &webapphandler.ImplementationSwitcherHandler{
+		AuthflowV2: p.Handler(newWebAppAuthflowV2SSOSAMLACSHandler),
+	})
 	router.Add(webapphandler.ConfigureWechatCallbackRoute(webappSSOCallbackRoute), p.Handler(newWechatCallbackHandler))
 
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2SelectAccountRoute(webappSelectAccountRoute), p.Handler(newWebAppAuthflowV2SelectAccountHandler))
@@ -347,6 +350,7 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2EnterTOTPRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2EnterTOTPHandler))
 	router.Add(webapphandlerauthflowv2.ConfigureV2AuthflowOOBOTPLinkRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2OOBOTPLinkHandler))
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2VerifyLoginLinkOTPRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2VerifyLoginLinkOTPHandler))
+	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2MagicLinkRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2MagicLinkHandler))
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2PromptCreatePasskeyRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2PromptCreatePasskeyHandler))
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2UsePasskeyRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2UsePasskeyHandler))
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2TerminateOtherSessionsRoute(webappPageRoute), p.Handler(newWebAppAuthflowV2TerminateOtherSessionsHandler))
@@ -376,6 +380,8 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 	})
 
 	router.Add(webapphandler.ConfigureLogoutRoute(webappAuthenticatedRoute), p.Handler(newWebAppLogoutHandler))
+	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2DeviceRoute(webappAuthenticatedRoute), p.Handler(newWebAppAuthflowV2DeviceHandler))
+	router.Add(webapphandler.ConfigureBackchannelAuthenticationRoute(webappAuthenticatedRoute), p.Handler(newWebAppBackchannelAuthenticationHandler))
 	router.Add(webapphandlerauthflowv2.ConfigureAuthflowV2SettingsRoute(webappSettingsRoute), &webapphandler.SettingsImplementationSwitcherHandler{
 		SettingV2: p.Handler(newWebAppAuthflowV2SettingsHandler),
 	})
@@ -458,6 +464,12 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 	router.Add(oauthhandler.ConfigureAuthorizeRoute(oauthAuthzAPIRoute), p.Handler(newOAuthAuthorizeHandler))
 	router.Add(oauthhandler.ConfigureTokenRoute(dpopOauthAPIRoute), p.Handler(newOAuthTokenHandler))
 	router.Add(oauthhandler.ConfigureRevokeRoute(dpopOauthAPIRoute), p.Handler(newOAuthRevokeHandler))
+	router.Add(oauthhandler.ConfigureIntrospectRoute(oauthAPIRoute), p.Handler(newOAuthIntrospectHandler))
+	router.Add(oauthhandler.ConfigureDeviceAuthorizationRoute(oauthAPIRoute), p.Handler(newOAuthDeviceAuthorizationHandler))
+	router.Add(oauthhandler.ConfigureBackchannelAuthenticationRoute(oauthAPIRoute), p.Handler(newOAuthBackchannelAuthenticationHandler))
+	router.Add(oauthhandler.ConfigurePushedAuthorizationRoute(oauthAPIRoute), p.Handler(newOAuthPushedAuthorizationHandler))
+	router.Add(oauthhandler.ConfigureClientRegistrationRoute(oauthAPIRoute), p.Handler(newOAuthClientRegistrationHandler))
+	router.Add(oauthhandler.ConfigureClientConfigurationRoute(oauthAPIRoute), p.Handler(newOAuthClientRegistrationHandler))
 	router.Add(oauthhandler.ConfigureEndSessionRoute(oauthAPIRoute), p.Handler(newOAuthEndSessionHandler))
 
 	router.Add(oauthhandler.ConfigureChallengeRoute(apiRoute), p.Handler(newOAuthChallengeHandler))
@@ -469,6 +481,7 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 	router.Add(oauthhandler.ConfigureConsentRoute(webappPageRoute), p.Handler(newOAuthConsentHandler))
 
 	router.Add(samlhandler.ConfigureMetadataRoute(samlStaticRoute), p.Handler(newSAMLMetadataHandler))
+	router.Add(samlhandler.ConfigureSPMetadataRoute(samlStaticRoute), p.Handler(newSAMLSPMetadataHandler))
 	router.Add(samlhandler.ConfigureLoginRoute(samlAPIRoute), p.Handler(newSAMLLoginHandler))
 	router.Add(samlhandler.ConfigureLoginFinishRoute(samlAPIRoute), p.Handler(newSAMLLoginFinishHandler))
 	router.Add(samlhandler.ConfigureLogoutRoute(samlAPIRoute), p.Handler(newSAMLLogoutHandler))
@@ -476,6 +489,7 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 	router.Add(apihandler.ConfigureAnonymousUserSignupRoute(apiRoute), p.Handler(newAPIAnonymousUserSignupHandler))
 	router.Add(apihandler.ConfigureAnonymousUserPromotionCodeRoute(apiRoute), p.Handler(newAPIAnonymousUserPromotionCodeHandler))
 	router.Add(apihandler.ConfigurePresignImagesUploadRoute(apiAuthenticatedRoute), p.Handler(newAPIPresignImagesUploadHandler))
+	router.Add(apihandler.ConfigureBotProtectionPoWChallengeRoute(apiRoute), p.Handler(newAPIBotProtectionPoWChallengeHandler))
 
 	router.Add(webapphandler.ConfigureWebsocketRoute(webappWebsocketRoute), p.Handler(newWebAppWebsocketHandler))
 
@@ -496,6 +510,11 @@ func NewRouter(ctx context.Context, p *deps.RootProvider, configSource *configso
 
 	router.Add(apihandler.ConfigureAccountManagementV1IdentificationRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1IdentificationHandler))
 	router.Add(apihandler.ConfigureAccountManagementV1IdentificationOAuthRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1IdentificationOAuthHandler))
+	router.Add(apihandler.ConfigureAccountManagementV1SessionsRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1SessionsHandler))
+	router.Add(apihandler.ConfigureAccountManagementV1SessionsRenameRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1SessionsRenameHandler))
+	router.Add(apihandler.ConfigureAccountManagementV1SessionsRevokeRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1SessionsRevokeHandler))
+	router.Add(apihandler.ConfigureAccountManagementV1SessionsRevokeOthersRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1SessionsRevokeOthersHandler))
+	router.Add(apihandler.ConfigureAccountManagementV1MFADeviceTokensRevokeRoute(accountManagementRoute), p.Handler(newAPIAccountManagementV1MFADeviceTokensRevokeHandler))
 
 	// Routes without project context
 	router.Add(webapphandlerauthflowv2.ConfigureNoProjectPreviewWidgetRoute(noProjectRoute), p.RootHandler(newPreviewWidgetHandler))
@@ -87,6 +87,41 @@ func newOAuthRevokeHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newOAuthIntrospectHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.IntrospectHandler)),
+	))
+}
+
+func newOAuthDeviceAuthorizationHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.DeviceAuthorizationHandler)),
+	))
+}
+
+func newOAuthBackchannelAuthenticationHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.BackchannelAuthenticationHandler)),
+	))
+}
+
+func newOAuthPushedAuthorizationHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.PushedAuthorizationHandler)),
+	))
+}
+
+func newOAuthClientRegistrationHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.ClientRegistrationHandler)),
+	))
+}
+
 func newOAuthMetadataHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -157,6 +192,13 @@ func newAPIPresignImagesUploadHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newAPIBotProtectionPoWChallengeHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerapi.BotProtectionPoWChallengeHandler)),
+	))
+}
+
 func newWebAppOAuthEntrypointHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -192,6 +234,13 @@ func newWebAppAuthflowV2SSOCallbackHandler(p *deps.RequestProvider) http.Handler
 	))
 }
 
+func newWebAppAuthflowV2SSOSAMLACSHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		AuthflowV2UIHandlerDependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.SSOSAMLACSHandler)),
+	))
+}
+
 func newWechatCallbackHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -206,6 +255,13 @@ func newWebAppAuthflowV2VerifyLoginLinkOTPHandler(p *deps.RequestProvider) http.
 	))
 }
 
+func newWebAppAuthflowV2MagicLinkHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebappauthflowv2.AuthflowV2MagicLinkHandler)),
+	))
+}
+
 func newWebAppAuthflowV2SettingsHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -353,6 +409,20 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newWebAppAuthflowV2DeviceHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebappauthflowv2.AuthflowV2DeviceHandler)),
+	))
+}
+
+func newWebAppBackchannelAuthenticationHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.BackchannelAuthenticationHandler)),
+	))
+}
+
 func newWebAppAppStaticAssetsHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -507,6 +577,41 @@ func newAPIAccountManagementV1IdentificationOAuthHandler(p *deps.RequestProvider
 	))
 }
 
+func newAPIAccountManagementV1SessionsHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerapi.AccountManagementV1SessionsHandler)),
+	))
+}
+
+func newAPIAccountManagementV1SessionsRenameHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerapi.AccountManagementV1SessionsRenameHandler)),
+	))
+}
+
+func newAPIAccountManagementV1SessionsRevokeHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerapi.AccountManagementV1SessionsRevokeHandler)),
+	))
+}
+
+func newAPIAccountManagementV1SessionsRevokeOthersHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerapi.AccountManagementV1SessionsRevokeOthersHandler)),
+	))
+}
+
+func newAPIAccountManagementV1MFADeviceTokensRevokeHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerapi.AccountManagementV1MFADeviceTokensRevokeHandler)),
+	))
+}
+
 func newWebAppAuthflowV2LoginHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		AuthflowV2UIHandlerDependencySet,
@@ -738,6 +843,13 @@ func newSAMLMetadataHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newSAMLSPMetadataHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlersaml.SPMetadataHandler)),
+	))
+}
+
 func newSAMLLoginHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
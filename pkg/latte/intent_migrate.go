@@ -39,7 +39,7 @@ func (*IntentMigrate) JSONSchema() *validation.SimpleSchema {
 	return IntentMigrateSchema
 }
 
-func (*IntentMigrate) CanReactTo(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows) ([]workflow.Input, error) {
+func (i *IntentMigrate) CanReactTo(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows) ([]workflow.Input, error) {
 	switch len(workflows.Nearest.Nodes) {
 	case 0:
 		// Generate a new user ID.
@@ -53,9 +53,13 @@ func (*IntentMigrate) CanReactTo(ctx context.Context, deps *workflow.Dependencie
 		return nil, nil
 	case 3:
 		// Create a primary password.
+		// If the password is migrated, create a session instead.
 		return nil, nil
 	case 4:
 		// Create a session, if needed.
+		if i.hasMigratedPassword(workflows.Nearest) {
+			return nil, workflow.ErrEOF
+		}
 		return nil, nil
 	default:
 		return nil, workflow.ErrEOF
@@ -81,6 +85,9 @@ func (i *IntentMigrate) ReactTo(ctx context.Context, deps *workflow.Dependencies
 			LoginIDKey:  string(model.LoginIDKeyTypeEmail),
 		}), nil
 	case 3:
+		if i.hasMigratedPassword(workflows.Nearest) {
+			return i.ensureSession(ctx, workflows), nil
+		}
 		// The type, kind is fixed here.
 		return workflow.NewSubWorkflow(&IntentCreatePassword{
 			UserID:                 i.userID(workflows.Nearest),
@@ -88,19 +95,7 @@ func (i *IntentMigrate) ReactTo(ctx context.Context, deps *workflow.Dependencies
 			AuthenticatorIsDefault: false,
 		}), nil
 	case 4:
-		mode := EnsureSessionModeCreate
-		if workflow.GetSuppressIDPSessionCookie(ctx) {
-			mode = EnsureSessionModeNoop
-		}
-		return workflow.NewSubWorkflow(&IntentEnsureSession{
-			UserID:       i.userID(workflows.Nearest),
-			CreateReason: session.CreateReasonSignup,
-			// AMR is NOT populated because
-			// 1. Strictly speaking this is NOT an authentication. It is a sign up.
-			// 2. 3 authenticators were created. Should we report all 3?
-			AMR:  nil,
-			Mode: mode,
-		}), nil
+		return i.ensureSession(ctx, workflows), nil
 	}
 
 	return nil, workflow.ErrIncompatibleInput
@@ -186,3 +181,36 @@ func (i *IntentMigrate) userID(w *workflow.Workflow) string {
 	}
 	return node.UserID
 }
+
+func (i *IntentMigrate) ensureSession(ctx context.Context, workflows workflow.Workflows) *workflow.Node {
+	mode := EnsureSessionModeCreate
+	if workflow.GetSuppressIDPSessionCookie(ctx) {
+		mode = EnsureSessionModeNoop
+	}
+	return workflow.NewSubWorkflow(&IntentEnsureSession{
+		UserID:       i.userID(workflows.Nearest),
+		CreateReason: session.CreateReasonSignup,
+		// AMR is NOT populated because
+		// 1. Strictly speaking this is NOT an authentication. It is a sign up.
+		// 2. 3 authenticators were created. Should we report all 3?
+		AMR:  nil,
+		Mode: mode,
+	})
+}
+
+// hasMigratedPassword reports whether a primary password was migrated,
+// so that the user does not need to create one.
+func (i *IntentMigrate) hasMigratedPassword(w *workflow.Workflow) bool {
+	for _, subWorkflow := range workflow.FindSubWorkflows[*IntentMigrateAccount](w) {
+		authenticators, ok := subWorkflow.Intent.(*IntentMigrateAccount).GetNewAuthenticators(subWorkflow)
+		if !ok {
+			continue
+		}
+		for _, a := range authenticators {
+			if a.Type == model.AuthenticatorTypePassword {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -44,15 +44,24 @@ func (i *IntentMigrateAuthenticators) ReactTo(ctx context.Context, deps *workflo
 
 	idx := len(workflows.Nearest.Nodes)
 	spec := i.MigrateSpecs[idx]
-	if spec.Type != model.AuthenticatorTypeOOBEmail && spec.Type != model.AuthenticatorTypeOOBSMS {
+	switch spec.Type {
+	case model.AuthenticatorTypeOOBEmail, model.AuthenticatorTypeOOBSMS:
+		return workflow.NewSubWorkflow(&IntentMigrateOOBOTPAuthenticator{
+			UserID:      i.UserID,
+			MigrateSpec: spec,
+			// Mark the first authenticator in the migrate spec as default
+			AuthenticatorIsDefault: idx == 0,
+		}), nil
+	case model.AuthenticatorTypePassword:
+		return workflow.NewSubWorkflow(&IntentMigratePasswordAuthenticator{
+			UserID:      i.UserID,
+			MigrateSpec: spec,
+			// Mark the first authenticator in the migrate spec as default
+			AuthenticatorIsDefault: idx == 0,
+		}), nil
+	default:
 		panic(fmt.Sprintf("workflow: unsupported authenticator type for account migrations: %T", spec.Type))
 	}
-	return workflow.NewSubWorkflow(&IntentMigrateOOBOTPAuthenticator{
-		UserID:      i.UserID,
-		MigrateSpec: spec,
-		// Mark the first authenticator in the migrate spec as default
-		AuthenticatorIsDefault: idx == 0,
-	}), nil
 }
 
 func (*IntentMigrateAuthenticators) GetEffects(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows) (effs []workflow.Effect, err error) {
@@ -0,0 +1,69 @@
+package latte
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/workflow"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+func init() {
+	workflow.RegisterPrivateIntent(&IntentMigratePasswordAuthenticator{})
+}
+
+var IntentMigratePasswordAuthenticatorSchema = validation.NewSimpleSchema(`{}`)
+
+type IntentMigratePasswordAuthenticator struct {
+	UserID                 string                     `json:"user_id"`
+	MigrateSpec            *authenticator.MigrateSpec `json:"migrate_spec"`
+	AuthenticatorIsDefault bool                       `json:"authenticator_is_default"`
+}
+
+func (*IntentMigratePasswordAuthenticator) Kind() string {
+	return "latte.IntentMigratePasswordAuthenticator"
+}
+
+func (*IntentMigratePasswordAuthenticator) JSONSchema() *validation.SimpleSchema {
+	return IntentMigratePasswordAuthenticatorSchema
+}
+
+func (*IntentMigratePasswordAuthenticator) CanReactTo(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows) ([]workflow.Input, error) {
+	if len(workflows.Nearest.Nodes) == 0 {
+		return nil, nil
+	}
+	return nil, workflow.ErrEOF
+}
+
+func (i *IntentMigratePasswordAuthenticator) ReactTo(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows, input workflow.Input) (*workflow.Node, error) {
+	spec := i.MigrateSpec.GetSpec()
+	spec.UserID = i.UserID
+	spec.IsDefault = i.AuthenticatorIsDefault
+
+	authenticatorID := uuid.New()
+	info, err := deps.Authenticators.NewWithAuthenticatorID(ctx, authenticatorID, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow.NewNodeSimple(&NodeDoCreateAuthenticator{
+		Authenticator: info,
+	}), nil
+}
+
+func (*IntentMigratePasswordAuthenticator) GetEffects(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows) (effs []workflow.Effect, err error) {
+	return nil, nil
+}
+
+func (*IntentMigratePasswordAuthenticator) OutputData(ctx context.Context, deps *workflow.Dependencies, workflows workflow.Workflows) (any, error) {
+	return nil, nil
+}
+
+func (*IntentMigratePasswordAuthenticator) GetNewAuthenticators(w *workflow.Workflow) ([]*authenticator.Info, bool) {
+	node, ok := workflow.FindSingleNode[*NodeDoCreateAuthenticator](w)
+	if !ok {
+		return nil, false
+	}
+	return []*authenticator.Info{node.Authenticator}, true
+}
@@ -42,12 +42,28 @@ func (n *NodeDoEnsureSession) GetEffects(ctx context.Context, deps *workflow.Dep
 				return nil
 			}
 
-			err := deps.IDPSessions.Create(ctx, n.SessionToCreate)
+			// The current IdP session is revoked below, so it is not counted.
+			s := session.GetSession(ctx)
+			excludeSessionID := ""
+			if s != nil && s.SessionType() == session.TypeIdentityProvider {
+				excludeSessionID = s.SessionID()
+			}
+			sessionsToEnd, err := deps.IDPSessions.ListSessionsToEnd(ctx, n.UserID, excludeSessionID, false)
+			if err != nil {
+				return err
+			}
+			for _, sessionToEnd := range sessionsToEnd {
+				err = deps.Sessions.RevokeWithEvent(ctx, sessionToEnd, true, false)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = deps.IDPSessions.Create(ctx, n.SessionToCreate)
 			if err != nil {
 				return err
 			}
 
-			s := session.GetSession(ctx)
 			if s != nil && s.SessionType() == session.TypeIdentityProvider {
 				err = deps.Sessions.RevokeWithoutEvent(ctx, s)
 				if err != nil {
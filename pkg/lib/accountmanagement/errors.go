@@ -18,6 +18,9 @@ var ErrAccountManagementIdentityNotOwnedbyToUser = apierrors.Invalid.WithReason(
 var ErrAccountManagementAuthenticatorNotOwnedbyToUser = apierrors.Invalid.WithReason("AccountManagementAuthenticatorNotOwnedByUser").New("authenticator not owned by current user")
 var ErrAccountManagementSecondaryAuthenticatorIsRequired = apierrors.Invalid.WithReason("AccountManagementSecondaryAuthenticatorIsRequired").New("at least one secondary authenticator is needed")
 
+var ErrAccountManagementSessionNotFound = apierrors.NotFound.WithReason("AccountManagementSessionNotFound").New("session not found")
+var ErrAccountManagementCannotRevokeCurrentSession = apierrors.Invalid.WithReason("AccountManagementCannotRevokeCurrentSession").New("cannot revoke current session")
+
 func NewErrAccountManagementDuplicatedIdentity(originalErr error) error {
 	return errors.Join(
 		apierrors.AlreadyExists.WithReason("AccountManagementDuplicatedIdentity").New("identity already exists"),
@@ -15,6 +15,12 @@ func GenerateRandomState() string {
 	return state
 }
 
+// GenerateRandomNonce returns the nonce of the authorization request.
+// The nonce is also the key of the PKCE code verifier of the generic providers.
+func GenerateRandomNonce() string {
+	return rand.StringWithAlphabet(32, base32.Alphabet, rand.SecureRand)
+}
+
 func ExtractStateFromQuery(query string) (state string, err error) {
 	// query may start with a ?, remove it.
 	query = strings.TrimPrefix(query, "?")
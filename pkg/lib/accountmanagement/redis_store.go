@@ -29,6 +29,7 @@ type GenerateTokenOptions struct {
 	Alias       string
 	MaybeState  string
 	RedirectURI string
+	Nonce       string
 
 	// Phone
 	IdentityChannel     model.AuthenticatorOOBChannel
@@ -104,6 +105,7 @@ func (s *RedisStore) GenerateToken(ctx context.Context, options GenerateTokenOpt
 		Alias:       options.Alias,
 		State:       options.MaybeState,
 		RedirectURI: options.RedirectURI,
+		Nonce:       options.Nonce,
 
 		// Identity
 		Identity: tokenIdentity,
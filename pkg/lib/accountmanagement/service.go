@@ -86,6 +86,9 @@ type MFAService interface {
 
 	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []string) ([]*mfa.RecoveryCode, error)
 	ListRecoveryCodes(ctx context.Context, userID string) ([]*mfa.RecoveryCode, error)
+
+	InvalidateAllDeviceTokens(ctx context.Context, userID string) error
+	CountDeviceTokens(ctx context.Context, userID string) (int, error)
 }
 
 type PasskeyService interface {
@@ -129,6 +132,10 @@ type Service struct {
 	PasskeyService            PasskeyService
 	Verification              VerificationService
 	UIInfoResolver            UIInfoResolver
+	Sessions                  SessionManager
+	SessionListing            SessionListingService
+	OfflineGrants             OfflineGrantService
+	IDPSessions               IDPSessionService
 }
 
 type StartAddingInput struct {
@@ -149,9 +156,12 @@ func (s *Service) StartAdding(ctx context.Context, input *StartAddingInput) (*St
 		state = GenerateRandomState()
 	}
 
+	nonce := GenerateRandomNonce()
+
 	param := oauthrelyingparty.GetAuthorizationURLOptions{
 		RedirectURI: input.RedirectURI,
 		State:       state,
+		Nonce:       nonce,
 	}
 
 	authorizationURL, err := s.OAuthProvider.GetAuthorizationURL(ctx, input.Alias, param)
@@ -164,6 +174,7 @@ func (s *Service) StartAdding(ctx context.Context, input *StartAddingInput) (*St
 		Alias:       input.Alias,
 		RedirectURI: input.RedirectURI,
 		MaybeState:  state,
+		Nonce:       nonce,
 	})
 	if err != nil {
 		return nil, err
@@ -211,11 +222,10 @@ func (s *Service) FinishAdding(ctx context.Context, input *FinishAddingInput) (*
 		return nil, err
 	}
 
-	emptyNonce := ""
 	userProfile, err := s.OAuthProvider.GetUserProfile(ctx, token.Alias, oauthrelyingparty.GetUserProfileOptions{
 		Query:       input.Query,
 		RedirectURI: token.RedirectURI,
-		Nonce:       emptyNonce,
+		Nonce:       token.Nonce,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,265 @@
+package accountmanagement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/lib/sessionlisting"
+)
+
+//go:generate go tool mockgen -source=service_session.go -destination=service_session_mock_test.go -package accountmanagement
+
+type SessionManager interface {
+	List(ctx context.Context, userID string) ([]session.ListableSession, error)
+	Get(ctx context.Context, id string) (session.ListableSession, error)
+	RevokeWithEvent(ctx context.Context, s session.SessionBase, isTermination bool, isAdminAPI bool) error
+	TerminateAllExcept(ctx context.Context, userID string, currentSession session.ResolvedSession, isAdminAPI bool) error
+}
+
+type SessionListingService interface {
+	FilterForDisplay(ctx context.Context, sessions []session.ListableSession, currentSession session.ResolvedSession) ([]*sessionlisting.Session, error)
+}
+
+type OfflineGrantService interface {
+	UpdateDeviceName(ctx context.Context, grant *oauth.OfflineGrant, deviceName string) (*oauth.OfflineGrant, error)
+}
+
+type IDPSessionService interface {
+	UpdateDeviceName(ctx context.Context, session *idpsession.IDPSession, deviceName string) (*idpsession.IDPSession, error)
+}
+
+type Session struct {
+	ID                          string            `json:"id"`
+	Type                        model.SessionType `json:"type"`
+	DisplayName                 string            `json:"display_name"`
+	ApplicationName             string            `json:"application_name,omitempty"`
+	UserAgent                   string            `json:"user_agent,omitempty"`
+	CreatedAt                   time.Time         `json:"created_at"`
+	LastAccessedAt              time.Time         `json:"last_accessed_at"`
+	LastAccessedByIP            string            `json:"last_accessed_by_ip"`
+	LastAccessedByIPCountryCode string            `json:"last_accessed_by_ip_country_code,omitempty"`
+	IsDevice                    bool              `json:"is_device"`
+	IsCurrent                   bool              `json:"is_current"`
+}
+
+func newSession(s *sessionlisting.Session) *Session {
+	return &Session{
+		ID:                          s.ID,
+		Type:                        s.Type,
+		DisplayName:                 s.DisplayName,
+		ApplicationName:             s.ApplicationName,
+		UserAgent:                   s.UserAgent,
+		CreatedAt:                   s.CreatedAt,
+		LastAccessedAt:              s.LastAccessedAt,
+		LastAccessedByIP:            s.LastAccessedByIP,
+		LastAccessedByIPCountryCode: s.LastAccessedByIPCountryCode,
+		IsDevice:                    s.IsDevice,
+		IsCurrent:                   s.IsCurrent,
+	}
+}
+
+type ListSessionsOutput struct {
+	Sessions []*Session `json:"sessions"`
+	// MFADeviceTokenCount is the number of devices that skip MFA because the user chose to remember them.
+	MFADeviceTokenCount int `json:"mfa_device_token_count"`
+}
+
+func (s *Service) ListSessions(ctx context.Context, resolvedSession session.ResolvedSession) (*ListSessionsOutput, error) {
+	userID := resolvedSession.GetAuthenticationInfo().UserID
+
+	var output *ListSessionsOutput
+	err := s.Database.WithTx(ctx, func(ctx context.Context) error {
+		ss, err := s.Sessions.List(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		sessionModels, err := s.SessionListing.FilterForDisplay(ctx, ss, resolvedSession)
+		if err != nil {
+			return err
+		}
+
+		count, err := s.MFA.CountDeviceTokens(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		output = &ListSessionsOutput{
+			Sessions:            []*Session{},
+			MFADeviceTokenCount: count,
+		}
+		for _, m := range sessionModels {
+			output.Sessions = append(output.Sessions, newSession(m))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+type RenameSessionInput struct {
+	SessionID  string
+	DeviceName string
+}
+
+type RenameSessionOutput struct {
+	// It is intentionally empty.
+}
+
+// RenameSession gives a name to the device of a session.
+func (s *Service) RenameSession(ctx context.Context, resolvedSession session.ResolvedSession, input *RenameSessionInput) (*RenameSessionOutput, error) {
+	userID := resolvedSession.GetAuthenticationInfo().UserID
+
+	err := s.Database.WithTx(ctx, func(ctx context.Context) error {
+		return s.renameSession(ctx, userID, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenameSessionOutput{}, nil
+}
+
+func (s *Service) renameSession(ctx context.Context, userID string, input *RenameSessionInput) error {
+	target, err := s.prepareSession(ctx, userID, input.SessionID)
+	if err != nil {
+		return err
+	}
+
+	switch target := target.(type) {
+	case *oauth.OfflineGrant:
+		_, err = s.OfflineGrants.UpdateDeviceName(ctx, target, input.DeviceName)
+		return err
+	case *idpsession.IDPSession:
+		_, err = s.IDPSessions.UpdateDeviceName(ctx, target, input.DeviceName)
+		if err != nil {
+			return err
+		}
+
+		// The SSO-enabled offline grants of the IdP session are listed as the same device,
+		// and the name of the device is taken from them.
+		ss, err := s.Sessions.List(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, ls := range ss {
+			if grant, ok := ls.(*oauth.OfflineGrant); ok && grant.SSOGroupIDPSessionID() == target.SessionID() {
+				_, err = s.OfflineGrants.UpdateDeviceName(ctx, grant, input.DeviceName)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		return ErrAccountManagementSessionNotFound
+	}
+}
+
+type RevokeSessionInput struct {
+	SessionID string
+}
+
+type RevokeSessionOutput struct {
+	// It is intentionally empty.
+}
+
+func (s *Service) RevokeSession(ctx context.Context, resolvedSession session.ResolvedSession, input *RevokeSessionInput) (*RevokeSessionOutput, error) {
+	err := s.Database.WithTx(ctx, func(ctx context.Context) error {
+		return s.revokeSession(ctx, resolvedSession, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevokeSessionOutput{}, nil
+}
+
+func (s *Service) revokeSession(ctx context.Context, resolvedSession session.ResolvedSession, input *RevokeSessionInput) error {
+	userID := resolvedSession.GetAuthenticationInfo().UserID
+
+	target, err := s.prepareSession(ctx, userID, input.SessionID)
+	if err != nil {
+		return err
+	}
+
+	// Revoking a session revokes its whole SSO group,
+	// which must not include the current session.
+	if target.IsSameSSOGroup(resolvedSession) {
+		return ErrAccountManagementCannotRevokeCurrentSession
+	}
+
+	return s.Sessions.RevokeWithEvent(ctx, target, true, false)
+}
+
+type RevokeOtherSessionsOutput struct {
+	// It is intentionally empty.
+}
+
+func (s *Service) RevokeOtherSessions(ctx context.Context, resolvedSession session.ResolvedSession) (*RevokeOtherSessionsOutput, error) {
+	userID := resolvedSession.GetAuthenticationInfo().UserID
+
+	err := s.Database.WithTx(ctx, func(ctx context.Context) error {
+		return s.Sessions.TerminateAllExcept(ctx, userID, resolvedSession, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevokeOtherSessionsOutput{}, nil
+}
+
+type RevokeMFADeviceTokensOutput struct {
+	// It is intentionally empty.
+}
+
+func (s *Service) RevokeMFADeviceTokens(ctx context.Context, resolvedSession session.ResolvedSession) (*RevokeMFADeviceTokensOutput, error) {
+	userID := resolvedSession.GetAuthenticationInfo().UserID
+
+	err := s.Database.WithTx(ctx, func(ctx context.Context) error {
+		return s.MFA.InvalidateAllDeviceTokens(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevokeMFADeviceTokensOutput{}, nil
+}
+
+// prepareSession returns the session if it is owned by the user and listed to the user.
+func (s *Service) prepareSession(ctx context.Context, userID string, sessionID string) (session.ListableSession, error) {
+	target, err := s.Sessions.Get(ctx, sessionID)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return nil, ErrAccountManagementSessionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Do not disclose the existence of sessions of other users.
+	if target.GetAuthenticationInfo().UserID != userID {
+		return nil, ErrAccountManagementSessionNotFound
+	}
+
+	// Offline grants of third-party clients are managed via authorizations.
+	if grant, ok := target.(*oauth.OfflineGrant); ok {
+		thirdPartyClientIDs := []string{}
+		for _, c := range s.Config.OAuth.Clients {
+			if c.IsThirdParty() {
+				thirdPartyClientIDs = append(thirdPartyClientIDs, c.ClientID)
+			}
+		}
+		if grant.IsOnlyUsedInClientIDs(thirdPartyClientIDs) {
+			return nil, ErrAccountManagementSessionNotFound
+		}
+	}
+
+	return target, nil
+}
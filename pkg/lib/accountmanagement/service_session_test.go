@@ -0,0 +1,160 @@
+package accountmanagement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+)
+
+func TestServiceSession(t *testing.T) {
+	Convey("Service sessions", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		sessions := NewMockSessionManager(ctrl)
+		offlineGrants := NewMockOfflineGrantService(ctrl)
+		idpSessions := NewMockIDPSessionService(ctrl)
+		s := &Service{
+			Config: &config.AppConfig{
+				OAuth: &config.OAuthConfig{
+					Clients: []config.OAuthClientConfig{
+						{ClientID: "first-party", ApplicationType: config.OAuthClientApplicationTypeNative},
+						{ClientID: "third-party", ApplicationType: config.OAuthClientApplicationTypeThirdPartyApp},
+					},
+				},
+			},
+			Sessions:      sessions,
+			OfflineGrants: offlineGrants,
+			IDPSessions:   idpSessions,
+		}
+
+		current := &idpsession.IDPSession{
+			ID:    "current",
+			Attrs: session.Attrs{UserID: "user"},
+		}
+		other := &idpsession.IDPSession{
+			ID:    "other",
+			Attrs: session.Attrs{UserID: "user"},
+		}
+		ssoGrant := &oauth.OfflineGrant{
+			ID:              "sso-grant",
+			InitialClientID: "first-party",
+			Attrs:           session.Attrs{UserID: "user"},
+			IDPSessionID:    "other",
+			SSOEnabled:      true,
+		}
+		deviceGrant := &oauth.OfflineGrant{
+			ID:              "device-grant",
+			InitialClientID: "first-party",
+			Attrs:           session.Attrs{UserID: "user"},
+		}
+		thirdPartyGrant := &oauth.OfflineGrant{
+			ID:              "third-party-grant",
+			InitialClientID: "third-party",
+			Attrs:           session.Attrs{UserID: "user"},
+		}
+		otherUserSession := &idpsession.IDPSession{
+			ID:    "other-user",
+			Attrs: session.Attrs{UserID: "other-user"},
+		}
+
+		Convey("prepareSession", func() {
+			Convey("should return the session of the user", func() {
+				sessions.EXPECT().Get(ctx, "device-grant").Return(deviceGrant, nil)
+
+				target, err := s.prepareSession(ctx, "user", "device-grant")
+				So(err, ShouldBeNil)
+				So(target, ShouldEqual, deviceGrant)
+			})
+
+			Convey("should not find a session that does not exist", func() {
+				sessions.EXPECT().Get(ctx, "unknown").Return(nil, session.ErrSessionNotFound)
+
+				_, err := s.prepareSession(ctx, "user", "unknown")
+				So(err, ShouldBeError, ErrAccountManagementSessionNotFound)
+			})
+
+			Convey("should not find a session of another user", func() {
+				sessions.EXPECT().Get(ctx, "other-user").Return(otherUserSession, nil)
+
+				_, err := s.prepareSession(ctx, "user", "other-user")
+				So(err, ShouldBeError, ErrAccountManagementSessionNotFound)
+			})
+
+			Convey("should not find an offline grant of a third-party client", func() {
+				sessions.EXPECT().Get(ctx, "third-party-grant").Return(thirdPartyGrant, nil)
+
+				_, err := s.prepareSession(ctx, "user", "third-party-grant")
+				So(err, ShouldBeError, ErrAccountManagementSessionNotFound)
+			})
+		})
+
+		Convey("revokeSession", func() {
+			Convey("should revoke the session", func() {
+				sessions.EXPECT().Get(ctx, "other").Return(other, nil)
+				sessions.EXPECT().RevokeWithEvent(ctx, other, true, false).Return(nil)
+
+				err := s.revokeSession(ctx, current, &RevokeSessionInput{SessionID: "other"})
+				So(err, ShouldBeNil)
+			})
+
+			Convey("should not revoke the current session", func() {
+				sessions.EXPECT().Get(ctx, "current").Return(current, nil)
+
+				err := s.revokeSession(ctx, current, &RevokeSessionInput{SessionID: "current"})
+				So(err, ShouldBeError, ErrAccountManagementCannotRevokeCurrentSession)
+			})
+
+			Convey("should not revoke a session in the SSO group of the current session", func() {
+				sessions.EXPECT().Get(ctx, "sso-grant").Return(ssoGrant, nil)
+
+				err := s.revokeSession(ctx, other, &RevokeSessionInput{SessionID: "sso-grant"})
+				So(err, ShouldBeError, ErrAccountManagementCannotRevokeCurrentSession)
+			})
+
+			Convey("should not revoke an offline grant of a third-party client", func() {
+				sessions.EXPECT().Get(ctx, "third-party-grant").Return(thirdPartyGrant, nil)
+
+				err := s.revokeSession(ctx, current, &RevokeSessionInput{SessionID: "third-party-grant"})
+				So(err, ShouldBeError, ErrAccountManagementSessionNotFound)
+			})
+		})
+
+		Convey("renameSession", func() {
+			Convey("should rename an offline grant", func() {
+				sessions.EXPECT().Get(ctx, "device-grant").Return(deviceGrant, nil)
+				offlineGrants.EXPECT().UpdateDeviceName(ctx, deviceGrant, "iPhone").Return(deviceGrant, nil)
+
+				err := s.renameSession(ctx, "user", &RenameSessionInput{SessionID: "device-grant", DeviceName: "iPhone"})
+				So(err, ShouldBeNil)
+			})
+
+			Convey("should rename an IdP session without offline grants", func() {
+				sessions.EXPECT().Get(ctx, "current").Return(current, nil)
+				idpSessions.EXPECT().UpdateDeviceName(ctx, current, "Laptop").Return(current, nil)
+				sessions.EXPECT().List(ctx, "user").Return([]session.ListableSession{current, deviceGrant}, nil)
+
+				err := s.renameSession(ctx, "user", &RenameSessionInput{SessionID: "current", DeviceName: "Laptop"})
+				So(err, ShouldBeNil)
+			})
+
+			Convey("should rename an IdP session with the offline grants in its SSO group", func() {
+				sessions.EXPECT().Get(ctx, "other").Return(other, nil)
+				idpSessions.EXPECT().UpdateDeviceName(ctx, other, "iPhone").Return(other, nil)
+				sessions.EXPECT().List(ctx, "user").Return([]session.ListableSession{current, other, ssoGrant, deviceGrant}, nil)
+				offlineGrants.EXPECT().UpdateDeviceName(ctx, ssoGrant, "iPhone").Return(ssoGrant, nil)
+
+				err := s.renameSession(ctx, "user", &RenameSessionInput{SessionID: "other", DeviceName: "iPhone"})
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
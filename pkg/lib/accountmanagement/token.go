@@ -20,6 +20,7 @@ type Token struct {
 	Alias       string `json:"alias,omitempty"`
 	RedirectURI string `json:"redirect_uri,omitempty"`
 	State       string `json:"state,omitempty"`
+	Nonce       string `json:"nonce,omitempty"`
 
 	// Adding Identity
 	Identity *TokenIdentity `json:"token_identity,omitempty"`
@@ -46,11 +46,19 @@ var HookResponseSchema = validation.NewSimpleSchema(`
 				"properties": {
 					"type" : {
 						"type": "string",
-						"enum" : ["oob_otp_email", "oob_otp_sms"]
+						"enum" : ["oob_otp_email", "oob_otp_sms", "password"]
 					},
 					"oobotp": {
 						"email": { "type": "string" },
 						"phone": { "type": "string" }
+					},
+					"password": {
+						"type": "object",
+						"additionalProperties": false,
+						"properties": {
+							"password_hash": { "type": "string", "minLength": 1 }
+						},
+						"required": ["password_hash"]
 					}
 				},
 				"allOf": [
@@ -75,6 +83,12 @@ var HookResponseSchema = validation.NewSimpleSchema(`
 							},
 							"required": ["oobotp"]
 						}
+					},
+					{
+						"if": { "properties": { "type": { "const": "password" } } },
+						"then": {
+							"required": ["password"]
+						}
 					}
 				]
 			}
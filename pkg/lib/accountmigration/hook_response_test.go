@@ -97,6 +97,70 @@ func TestParseHookResponse(t *testing.T) {
 			},
 		})
 
+		pass(`
+		{
+			"identities": [
+				{
+					"type": "login_id",
+					"login_id": {
+						"key": "email",
+						"type": "email",
+						"value": "faseng@example.com"
+					}
+				}
+			],
+			"authenticators": [
+				{
+					"type": "password",
+					"password": {
+						"password_hash": "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+					}
+				}
+			]
+		}
+		`, &am.HookResponse{
+			Identities: []*identity.MigrateSpec{
+				{
+					Type: model.IdentityTypeLoginID,
+					LoginID: &identity.LoginIDMigrateSpec{
+						Key:   "email",
+						Type:  "email",
+						Value: "faseng@example.com",
+					},
+				},
+			},
+			Authenticators: []*authenticator.MigrateSpec{
+				{
+					Type: model.AuthenticatorTypePassword,
+					Password: &authenticator.PasswordMigrateSpec{
+						PasswordHash: "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+					},
+				},
+			},
+		})
+
+		fail(`
+		{
+			"identities": [
+				{
+					"type": "login_id",
+					"login_id": {
+						"key": "email",
+						"type": "email",
+						"value": "faseng@example.com"
+					}
+			  	}
+			],
+			"authenticators": [
+				{
+					"type": "password"
+				}
+			]
+		}
+		`, `invalid value:
+/authenticators/0: required
+  map[actual:[type] expected:[password] missing:[password]]`)
+
 		fail(`
 		{
 			"identities": [
@@ -63,6 +63,10 @@ func GetBotProtectionData(flows authflow.Flows, authflowCfg *config.Authenticati
 		return nil
 	case config.BotProtectionRiskModeAlways:
 		return NewBotProtectionData(appCfg.Provider.Type)
+	case config.BotProtectionRiskModeRiskLevelMedium, config.BotProtectionRiskModeRiskLevelHigh:
+		// Risk assessment is not enabled, so the risk level is unknown.
+		// In that case, risk_level_medium and risk_level_high mean always.
+		return NewBotProtectionData(appCfg.Provider.Type)
 	default:
 		return nil
 	}
@@ -51,6 +51,9 @@ type IdentificationOption struct {
 	// Server is specific to LDAP
 	ServerName string `json:"server_name,omitempty"`
 
+	// IdentityProviderName is specific to SAML
+	IdentityProviderName string `json:"identity_provider_name,omitempty"`
+
 	// DisplayName is specific to SelectAccount. Unmasked: it identifies the
 	// account already bound to the caller's own session cookie, not an
 	// as-yet-unauthenticated identity, so there is nothing to mask.
@@ -120,6 +123,20 @@ func NewIdentificationOptionLDAP(ldapConfig *config.LDAPConfig, authflowCfg *con
 	return output
 }
 
+func NewIdentificationOptionsSAML(samlConfig *config.SAMLIdentityConfig) []IdentificationOption {
+	output := []IdentificationOption{}
+	if samlConfig == nil {
+		return output
+	}
+	for _, idp := range samlConfig.IdentityProviders {
+		output = append(output, IdentificationOption{
+			Identification:       model.AuthenticationFlowIdentificationSAML,
+			IdentityProviderName: idp.Name,
+		})
+	}
+	return output
+}
+
 // NewIdentificationOptionsSelectAccount returns the select_account options
 // derived from the current IDP session cookie. Each option's UserID is both
 // exposed to the API response (so a UI can apply its own policy, e.g.
@@ -236,6 +253,8 @@ func selectAccountDisplayName(identities []*identity.Info) string {
 		return "siwe"
 	case model.IdentityTypeLDAP:
 		return "ldap"
+	case model.IdentityTypeSAML:
+		return "saml"
 	default:
 		return ""
 	}
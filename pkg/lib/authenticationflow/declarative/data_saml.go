@@ -0,0 +1,23 @@
+package declarative
+
+import (
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+)
+
+type SAMLData struct {
+	TypedData
+	IdentityProviderName string `json:"identity_provider_name,omitempty"`
+	// AuthnRequestURL sends the AuthnRequest to the identity provider with the HTTP-Redirect binding.
+	// The caller must append RelayState to it, and forward the SAMLResponse received
+	// at the assertion consumer service back to the flow.
+	AuthnRequestURL string `json:"authn_request_url,omitempty"`
+}
+
+var _ authflow.Data = SAMLData{}
+
+func (SAMLData) Data() {}
+
+func NewSAMLData(d SAMLData) SAMLData {
+	d.Type = DataTypeSAMLData
+	return d
+}
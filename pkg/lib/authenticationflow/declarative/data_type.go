@@ -6,6 +6,7 @@ const (
 	DataTypeIdentificationData                   DataType = "identification_data"
 	DataTypeAuthenticationData                   DataType = "authentication_data"
 	DataTypeOAuthData                            DataType = "oauth_data"
+	DataTypeSAMLData                             DataType = "saml_data"
 	DataTypeCreateAuthenticatorData              DataType = "create_authenticator_data"
 	DataTypeViewRecoveryCodeData                 DataType = "view_recovery_code_data"
 	DataTypeSelectOOBOTPChannelsData             DataType = "select_oob_otp_channels_data"
@@ -17,6 +18,7 @@ const (
 	DataTypeAccountRecoverySelectDestinationData DataType = "account_recovery_select_destination_data"
 	DataTypeAccountRecoveryVerifyCodeData        DataType = "account_recovery_verify_code_data"
 	DataTypeAccountLinkingIdentificationData     DataType = "account_linking_identification_data"
+	DataTypeSelectOrganizationData               DataType = "select_organization_data"
 )
 
 type TypedData struct {
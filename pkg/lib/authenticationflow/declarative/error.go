@@ -3,6 +3,7 @@ package declarative
 import (
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
 	"github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 )
 
 var ErrFlowNotFound = apierrors.NotFound.WithReason("AuthenticationFlowFlowNotFound").New("flow not found")
@@ -21,3 +22,5 @@ var ErrNoPublicSignup = apierrors.Forbidden.
 var ErrSelectAccountSessionChanged = apierrors.Unauthorized.
 	WithReason("SelectAccountSessionChanged").
 	New("session no longer matches the selected account")
+
+var ErrConcurrentSessionLimitExceeded = idpsession.ErrConcurrentSessionLimitExceeded
@@ -56,6 +56,9 @@ func generateLoginFlowStepIdentify(cfg *config.AppConfig) *config.Authentication
 		case model.IdentityTypeLDAP:
 			oneOf := generateLoginFlowStepIdentifyLDAP(cfg)
 			step.OneOf = append(step.OneOf, oneOf...)
+		case model.IdentityTypeSAML:
+			oneOf := generateLoginFlowStepIdentifySAML(cfg)
+			step.OneOf = append(step.OneOf, oneOf...)
 		}
 	}
 
@@ -157,6 +160,18 @@ func generateLoginFlowStepIdentifyLDAP(cfg *config.AppConfig) []*config.Authenti
 	}
 }
 
+func generateLoginFlowStepIdentifySAML(cfg *config.AppConfig) []*config.AuthenticationFlowLoginFlowOneOf {
+	if cfg.Identity.SAML == nil || len(cfg.Identity.SAML.IdentityProviders) == 0 {
+		return nil
+	}
+
+	return []*config.AuthenticationFlowLoginFlowOneOf{
+		{
+			Identification: model.AuthenticationFlowIdentificationSAML,
+		},
+	}
+}
+
 func generateLoginFlowStepAuthenticatePrimary(cfg *config.AppConfig, identification model.AuthenticationFlowIdentification) (*config.AuthenticationFlowLoginFlowStep, bool) {
 	allowed := identification.PrimaryAuthentications()
 
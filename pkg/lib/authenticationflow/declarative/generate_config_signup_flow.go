@@ -42,6 +42,9 @@ func generateSignupFlowStepIdentify(cfg *config.AppConfig) *config.Authenticatio
 		case model.IdentityTypeLDAP:
 			oneOf := generateSignupFlowStepIdentifyLDAP(cfg)
 			step.OneOf = append(step.OneOf, oneOf...)
+		case model.IdentityTypeSAML:
+			oneOf := generateSignupFlowStepIdentifySAML(cfg)
+			step.OneOf = append(step.OneOf, oneOf...)
 		}
 	}
 
@@ -195,6 +198,18 @@ func generateSignupFlowStepIdentifyLDAP(cfg *config.AppConfig) []*config.Authent
 	}
 }
 
+func generateSignupFlowStepIdentifySAML(cfg *config.AppConfig) []*config.AuthenticationFlowSignupFlowOneOf {
+	if cfg.Identity.SAML == nil || len(cfg.Identity.SAML.IdentityProviders) == 0 {
+		return nil
+	}
+
+	return []*config.AuthenticationFlowSignupFlowOneOf{
+		{
+			Identification: model.AuthenticationFlowIdentificationSAML,
+		},
+	}
+}
+
 func generateSignupFlowStepCreateAuthenticatorPrimary(cfg *config.AppConfig, identification model.AuthenticationFlowIdentification) (*config.AuthenticationFlowSignupFlowStep, bool) {
 	allowed := identification.PrimaryAuthentications()
 
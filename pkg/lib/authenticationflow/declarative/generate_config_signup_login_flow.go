@@ -44,6 +44,9 @@ func generateSignupLoginFlowStepIdentify(cfg *config.AppConfig) *config.Authenti
 		case model.IdentityTypeLDAP:
 			oneOf := generateSignupLoginFlowStepIdentifyLDAP(cfg)
 			step.OneOf = append(step.OneOf, oneOf...)
+		case model.IdentityTypeSAML:
+			oneOf := generateSignupLoginFlowStepIdentifySAML(cfg)
+			step.OneOf = append(step.OneOf, oneOf...)
 		}
 	}
 
@@ -132,3 +135,13 @@ func generateSignupLoginFlowStepIdentifyLDAP(cfg *config.AppConfig) []*config.Au
 		newSignupLoginFlowOneOf(model.AuthenticationFlowIdentificationLDAP),
 	}
 }
+
+func generateSignupLoginFlowStepIdentifySAML(cfg *config.AppConfig) []*config.AuthenticationFlowSignupLoginFlowOneOf {
+	if cfg.Identity.SAML == nil || len(cfg.Identity.SAML.IdentityProviders) == 0 {
+		return nil
+	}
+
+	return []*config.AuthenticationFlowSignupLoginFlowOneOf{
+		newSignupLoginFlowOneOf(model.AuthenticationFlowIdentificationSAML),
+	}
+}
@@ -77,6 +77,18 @@ type inputTakePasskeyAssertionResponse interface {
 	GetAssertionResponse() *protocol.CredentialAssertionResponse
 }
 
+type inputTakeSAMLRequest interface {
+	GetSAMLIdentityProviderName() string
+}
+
+type inputTakeSAMLResponse interface {
+	GetSAMLResponse() string
+}
+
+type syntheticInputSAML interface {
+	GetSAMLIdentitySpec() *identity.Spec
+}
+
 type inputTakeLDAP interface {
 	GetServerName() string
 	GetUsername() string
@@ -87,6 +99,10 @@ type inputTakeOOBOTPChannel interface {
 	GetChannel() model.AuthenticatorOOBChannel
 }
 
+type inputSelectOrganization interface {
+	GetOrganizationID() string
+}
+
 type inputTakeOOBOTPTarget interface {
 	GetTarget() string
 }
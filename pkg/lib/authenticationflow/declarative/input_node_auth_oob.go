@@ -48,6 +48,16 @@ func (i *InputSchemaNodeAuthenticationOOB) SchemaBuilder() validation.SchemaBuil
 		)
 
 		oneOf = append(oneOf, code)
+	case otp.FormMagicLink:
+		magicLinkCode := validation.SchemaBuilder{}.
+			Required("magic_link_code")
+		magicLinkCode.Properties().Property(
+			"magic_link_code", validation.SchemaBuilder{}.Type(validation.TypeString),
+		).Property(
+			"request_device_token", validation.SchemaBuilder{}.Type(validation.TypeBoolean),
+		)
+
+		oneOf = append(oneOf, magicLinkCode)
 	case otp.FormLink:
 		check := validation.SchemaBuilder{}.
 			Required("check")
@@ -72,6 +82,7 @@ func (i *InputSchemaNodeAuthenticationOOB) MakeInput(ctx context.Context, rawMes
 
 type InputNodeAuthenticationOOB struct {
 	Code               string `json:"code,omitempty"`
+	MagicLinkCode      string `json:"magic_link_code,omitempty"`
 	Resend             bool   `json:"resend,omitempty"`
 	Check              bool   `json:"check,omitempty"`
 	RequestDeviceToken bool   `json:"request_device_token,omitempty"`
@@ -84,10 +95,15 @@ var _ inputDeviceTokenRequested = &InputNodeAuthenticationOOB{}
 func (*InputNodeAuthenticationOOB) Input() {}
 
 func (i *InputNodeAuthenticationOOB) IsCode() bool {
-	return i.Code != ""
+	return i.GetCode() != ""
 }
 
+// GetCode returns the code entered by the end-user,
+// or the code in the magic link.
 func (i *InputNodeAuthenticationOOB) GetCode() string {
+	if i.MagicLinkCode != "" {
+		return i.MagicLinkCode
+	}
 	return i.Code
 }
 
@@ -0,0 +1,62 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/slice"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+type InputSchemaSelectOrganization struct {
+	JSONPointer     jsonpointer.T
+	FlowRootObject  config.AuthenticationFlowObject
+	OrganizationIDs []string
+}
+
+var _ authflow.InputSchema = &InputSchemaSelectOrganization{}
+
+func (s *InputSchemaSelectOrganization) GetJSONPointer() jsonpointer.T {
+	return s.JSONPointer
+}
+
+func (s *InputSchemaSelectOrganization) GetFlowRootObject() config.AuthenticationFlowObject {
+	return s.FlowRootObject
+}
+
+func (s *InputSchemaSelectOrganization) SchemaBuilder() validation.SchemaBuilder {
+	b := validation.SchemaBuilder{}.
+		Type(validation.TypeObject).
+		Required("organization_id")
+	b.Properties().Property("organization_id", validation.SchemaBuilder{}.
+		Type(validation.TypeString).
+		Enum(slice.Cast[string, any](s.OrganizationIDs)...))
+
+	return b
+}
+
+func (s *InputSchemaSelectOrganization) MakeInput(ctx context.Context, rawMessage json.RawMessage) (authflow.Input, error) {
+	var input InputSelectOrganization
+	err := s.SchemaBuilder().ToSimpleSchema().Validator().ParseJSONRawMessage(ctx, rawMessage, &input)
+	if err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+type InputSelectOrganization struct {
+	OrganizationID string `json:"organization_id,omitempty"`
+}
+
+var _ authflow.Input = &InputSelectOrganization{}
+var _ inputSelectOrganization = &InputSelectOrganization{}
+
+func (*InputSelectOrganization) Input() {}
+
+func (i *InputSelectOrganization) GetOrganizationID() string {
+	return i.OrganizationID
+}
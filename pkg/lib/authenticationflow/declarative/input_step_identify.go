@@ -134,6 +134,15 @@ func (i *InputSchemaStepIdentify) SchemaBuilder() validation.SchemaBuilder {
 					validation.SchemaBuilder{}.Type(validation.TypeString).MinLength(1),
 				)
 
+			setRequiredAndAppendOneOf()
+		case model.AuthenticationFlowIdentificationSAML:
+			required = append(required, "identity_provider_name")
+			b.Properties().
+				Property(
+					"identity_provider_name",
+					validation.SchemaBuilder{}.Type(validation.TypeString).Const(option.IdentityProviderName),
+				)
+
 			setRequiredAndAppendOneOf()
 		case model.AuthenticationFlowIdentificationSelectAccount:
 			required = append(required, "index")
@@ -181,6 +190,8 @@ type InputStepIdentify struct {
 	Username   string `json:"username"`
 	Password   string `json:"password"`
 
+	IdentityProviderName string `json:"identity_provider_name,omitempty"`
+
 	Index int `json:"index,omitempty"`
 }
 
@@ -192,6 +203,7 @@ var _ inputTakeLoginIDOrExternalJWT = &InputStepIdentify{}
 var _ inputTakeOAuthAuthorizationRequest = &InputStepIdentify{}
 var _ inputTakeBotProtection = &InputStepIdentify{}
 var _ inputTakeLDAP = &InputStepIdentify{}
+var _ inputTakeSAMLRequest = &InputStepIdentify{}
 var _ inputTakeIdentificationOptionIndex = &InputStepIdentify{}
 
 func (*InputStepIdentify) Input() {}
@@ -254,6 +266,10 @@ func (i *InputStepIdentify) GetPassword() string {
 	return i.Password
 }
 
+func (i *InputStepIdentify) GetSAMLIdentityProviderName() string {
+	return i.IdentityProviderName
+}
+
 func (i *InputStepIdentify) GetIdentificationOptionIndex() int {
 	return i.Index
 }
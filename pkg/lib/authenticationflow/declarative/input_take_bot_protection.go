@@ -71,7 +71,8 @@ func (i *InputTakeBotProtection) GetBotProtectionProviderResponse() string {
 
 type InputTakeBotProtectionBody struct {
 	Type config.BotProtectionProviderType `json:"type,omitempty"`
-	// Response is specific to cloudflare, recaptchav2
+	// Response is the token issued by the provider.
+	// For pow, it is the challenge and the nonce joined by a colon.
 	Response string `json:"response,omitempty"`
 }
 
@@ -89,7 +90,11 @@ func NewBotProtectionBodySchemaBuilder(bpCfg *config.BotProtectionConfig) valida
 	case config.BotProtectionProviderTypeCloudflare:
 		b.Properties().Property("response", validation.SchemaBuilder{}.Type(validation.TypeString))
 		b.AddRequired("response")
-	case config.BotProtectionProviderTypeRecaptchaV2:
+	case config.BotProtectionProviderTypeRecaptchaV2,
+		config.BotProtectionProviderTypeRecaptchaV3,
+		config.BotProtectionProviderTypeRecaptchaEnterprise,
+		config.BotProtectionProviderTypeHCaptcha,
+		config.BotProtectionProviderTypePoW:
 		b.Properties().Property("response", validation.SchemaBuilder{}.Type(validation.TypeString))
 		b.AddRequired("response")
 	}
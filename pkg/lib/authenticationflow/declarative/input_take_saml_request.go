@@ -0,0 +1,64 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+type InputSchemaTakeSAMLRequest struct {
+	JSONPointer    jsonpointer.T
+	FlowRootObject config.AuthenticationFlowObject
+	SAMLOptions    []IdentificationOption
+}
+
+var _ authflow.InputSchema = &InputSchemaTakeSAMLRequest{}
+
+func (i *InputSchemaTakeSAMLRequest) GetJSONPointer() jsonpointer.T {
+	return i.JSONPointer
+}
+
+func (i *InputSchemaTakeSAMLRequest) GetFlowRootObject() config.AuthenticationFlowObject {
+	return i.FlowRootObject
+}
+
+func (i *InputSchemaTakeSAMLRequest) SchemaBuilder() validation.SchemaBuilder {
+	var enumValues []any
+	for _, option := range i.SAMLOptions {
+		enumValues = append(enumValues, option.IdentityProviderName)
+	}
+
+	b := validation.SchemaBuilder{}.Type(validation.TypeObject)
+	b.Required("identity_provider_name")
+	b.Properties().Property("identity_provider_name", validation.SchemaBuilder{}.
+		Type(validation.TypeString).
+		Enum(enumValues...))
+	return b
+}
+
+func (i *InputSchemaTakeSAMLRequest) MakeInput(ctx context.Context, rawMessage json.RawMessage) (authflow.Input, error) {
+	var input InputTakeSAMLRequest
+	err := i.SchemaBuilder().ToSimpleSchema().Validator().ParseJSONRawMessage(ctx, rawMessage, &input)
+	if err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+type InputTakeSAMLRequest struct {
+	IdentityProviderName string `json:"identity_provider_name,omitempty"`
+}
+
+var _ authflow.Input = &InputTakeSAMLRequest{}
+var _ inputTakeSAMLRequest = &InputTakeSAMLRequest{}
+
+func (*InputTakeSAMLRequest) Input() {}
+
+func (i *InputTakeSAMLRequest) GetSAMLIdentityProviderName() string {
+	return i.IdentityProviderName
+}
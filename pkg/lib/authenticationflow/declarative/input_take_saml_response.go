@@ -0,0 +1,56 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+type InputSchemaTakeSAMLResponse struct {
+	JSONPointer    jsonpointer.T
+	FlowRootObject config.AuthenticationFlowObject
+}
+
+var _ authflow.InputSchema = &InputSchemaTakeSAMLResponse{}
+
+func (i *InputSchemaTakeSAMLResponse) GetJSONPointer() jsonpointer.T {
+	return i.JSONPointer
+}
+
+func (i *InputSchemaTakeSAMLResponse) GetFlowRootObject() config.AuthenticationFlowObject {
+	return i.FlowRootObject
+}
+
+func (i *InputSchemaTakeSAMLResponse) SchemaBuilder() validation.SchemaBuilder {
+	b := validation.SchemaBuilder{}.Type(validation.TypeObject)
+	b.Required("saml_response")
+	b.Properties().Property("saml_response", validation.SchemaBuilder{}.Type(validation.TypeString).MinLength(1))
+	return b
+}
+
+func (i *InputSchemaTakeSAMLResponse) MakeInput(ctx context.Context, rawMessage json.RawMessage) (authflow.Input, error) {
+	var input InputTakeSAMLResponse
+	err := i.SchemaBuilder().ToSimpleSchema().Validator().ParseJSONRawMessage(ctx, rawMessage, &input)
+	if err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+type InputTakeSAMLResponse struct {
+	SAMLResponse string `json:"saml_response,omitempty"`
+}
+
+var _ authflow.Input = &InputTakeSAMLResponse{}
+var _ inputTakeSAMLResponse = &InputTakeSAMLResponse{}
+
+func (*InputTakeSAMLResponse) Input() {}
+
+func (i *InputTakeSAMLResponse) GetSAMLResponse() string {
+	return i.SAMLResponse
+}
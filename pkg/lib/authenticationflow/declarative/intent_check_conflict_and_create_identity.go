@@ -95,6 +95,8 @@ func (i *IntentCheckConflictAndCreateIdenity) checkConflictByAccountLinkings(
 		return linkByIncomingLoginIDSpec(ctx, deps, flows, i.UserID, i.Request.LoginID, i.JSONPointer, i)
 	case model.IdentityTypeLDAP:
 		return linkByIncomingLDAPSpec(ctx, deps, flows, i.UserID, i.Request.LDAP, i.JSONPointer)
+	case model.IdentityTypeSAML:
+		return linkByIncomingSAMLSpec(ctx, deps, flows, i.UserID, i.Request.SAML, i.JSONPointer, i)
 	default:
 		// Linking of other types are not supported at the moment
 		return nil, nil
@@ -110,6 +112,8 @@ func (i *IntentCheckConflictAndCreateIdenity) getIdenitySpec() *identity.Spec {
 		spec = i.Request.OAuth.Spec
 	case model.IdentityTypeLDAP:
 		spec = i.Request.LDAP.Spec
+	case model.IdentityTypeSAML:
+		spec = i.Request.SAML.Spec
 	default:
 		panic(fmt.Errorf("unexpected identity type %v", i.Request.Type))
 	}
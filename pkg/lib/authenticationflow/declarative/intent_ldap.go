@@ -3,23 +3,35 @@ package declarative
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
 
 	"github.com/authgear/authgear-server/pkg/api/model"
 	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
 )
 
 func init() {
 	authflow.RegisterIntent(&IntentLDAP{})
 }
 
+var ldapLogger = slogutil.NewLogger("authflow-ldap")
+
 type IntentLDAP struct {
 	JSONPointer jsonpointer.T `json:"json_pointer,omitempty"`
 	NewUserID   string        `json:"new_user_id,omitempty"`
+	// LDAPGroups is the LDAP groups of the user, searched when the user authenticates.
+	// The search connects to the LDAP server, so it is not done in the commit transaction.
+	LDAPGroups []string `json:"ldap_groups,omitempty"`
+	// LDAPGroupsSearched is false if the search failed.
+	// The groups and roles of the user are left unchanged in that case.
+	LDAPGroupsSearched bool `json:"ldap_groups_searched,omitempty"`
 }
 
 var _ authflow.Intent = &IntentLDAP{}
+var _ authflow.EffectGetter = &IntentLDAP{}
 var _ authflow.Milestone = &IntentLDAP{}
 var _ MilestoneIdentificationMethod = &IntentLDAP{}
 var _ MilestoneFlowCreateIdentity = &IntentLDAP{}
@@ -87,6 +99,21 @@ func (i *IntentLDAP) ReactTo(ctx context.Context, deps *authflow.Dependencies, f
 			return nil, err
 		}
 
+		ldapGroups, err := deps.LDAPGroupSync.LDAPGroups(&identity.LDAP{
+			ServerName:   spec.LDAP.ServerName,
+			RawEntryJSON: spec.LDAP.RawEntryJSON,
+		}, nil)
+		if err != nil {
+			// Failing to search the groups should not block the user from signing in.
+			logger := ldapLogger.GetLogger(ctx)
+			logger.WithError(err).Error(ctx, "failed to search ldap groups",
+				slog.String("server_name", spec.LDAP.ServerName),
+			)
+		} else {
+			i.LDAPGroups = ldapGroups
+			i.LDAPGroupsSearched = true
+		}
+
 		// NewUserID is the id we assign to new user
 		// It is not the user id of an existing user
 		// Sign up
@@ -108,3 +135,42 @@ func (i *IntentLDAP) ReactTo(ctx context.Context, deps *authflow.Dependencies, f
 	}
 	return nil, nil
 }
+
+func (i *IntentLDAP) GetEffects(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) ([]authflow.Effect, error) {
+	return []authflow.Effect{
+		authflow.OnCommitEffect(func(ctx context.Context, deps *authflow.Dependencies) error {
+			if !i.LDAPGroupsSearched {
+				return nil
+			}
+
+			info, ok := i.identityInfo(flows)
+			if !ok {
+				return nil
+			}
+
+			// The creation of the identity could have been skipped,
+			// so only sync the groups of an identity that exists.
+			identities, err := deps.Identities.ListByUser(ctx, info.UserID)
+			if err != nil {
+				return err
+			}
+			for _, iden := range identities {
+				if iden.ID == info.ID && iden.LDAP != nil {
+					return deps.LDAPGroupSync.ApplyLDAPGroups(ctx, iden.LDAP, i.LDAPGroups, false)
+				}
+			}
+
+			return nil
+		}),
+	}, nil
+}
+
+func (i *IntentLDAP) identityInfo(flows authflow.Flows) (*identity.Info, bool) {
+	if m, _, ok := i.MilestoneFlowUseIdentity(flows); ok {
+		return m.MilestoneDoUseIdentity(), true
+	}
+	if m, _, ok := i.MilestoneFlowCreateIdentity(flows); ok {
+		return m.MilestoneDoCreateIdentity(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,116 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func init() {
+	authflow.RegisterIntent(&IntentLoginFlowAssessRisk{})
+}
+
+// IntentLoginFlowAssessRisk consults the risk engine after the user has authenticated.
+// If the decision is step_up, an additional secondary authenticate step is required,
+// unless the user has already done MFA in this flow.
+// If the decision is blocked, the risk engine returns an error and the flow cannot proceed.
+type IntentLoginFlowAssessRisk struct {
+	FlowReference authflow.FlowReference                  `json:"flow_reference,omitempty"`
+	FlowObject    *config.AuthenticationFlowLoginFlowStep `json:"flow_object"`
+	UserID        string                                  `json:"user_id"`
+}
+
+func NewIntentLoginFlowAssessRisk(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, flowRef authflow.FlowReference) (*IntentLoginFlowAssessRisk, error) {
+	userID, err := getUserID(flows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IntentLoginFlowAssessRisk{
+		FlowReference: flowRef,
+		FlowObject:    newLoginFlowSecondaryAuthenticateStep(deps),
+		UserID:        userID,
+	}, nil
+}
+
+var _ authflow.Intent = &IntentLoginFlowAssessRisk{}
+var _ authflow.Milestone = &IntentLoginFlowAssessRisk{}
+var _ MilestoneAuthenticationFlowObjectProvider = &IntentLoginFlowAssessRisk{}
+
+func (*IntentLoginFlowAssessRisk) Kind() string {
+	return "IntentLoginFlowAssessRisk"
+}
+
+func (i *IntentLoginFlowAssessRisk) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	switch len(flows.Nearest.Nodes) {
+	case 0:
+		// Let ReactTo assess the risk.
+		return nil, nil
+	case 1:
+		stepUpRequired, err := i.stepUpRequired(ctx, deps, flows)
+		if err != nil {
+			return nil, err
+		}
+		if !stepUpRequired {
+			return nil, authflow.ErrEOF
+		}
+		// Let ReactTo create the step-up authenticate step.
+		return nil, nil
+	case 2:
+		return nil, authflow.ErrEOF
+	}
+
+	panic(fmt.Errorf("unexpected node count"))
+}
+
+func (i *IntentLoginFlowAssessRisk) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	switch len(flows.Nearest.Nodes) {
+	case 0:
+		decision, err := deps.Risk.Assess(ctx, i.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return authflow.NewNodeSimple(&NodeDidAssessRisk{
+			Decision: decision,
+		}), nil
+	case 1:
+		stepAuthenticate, err := NewIntentLoginFlowStepAuthenticate(ctx, deps, flows, &IntentLoginFlowStepAuthenticate{
+			FlowReference: i.FlowReference,
+			StepName:      "",
+			JSONPointer:   nil,
+			UserID:        i.UserID,
+		}, i)
+		if err != nil {
+			return nil, err
+		}
+		// A remembered device must not bypass the step-up.
+		stepAuthenticate.DeviceTokenEnabled = false
+		return authflow.NewSubFlow(stepAuthenticate), nil
+	}
+
+	panic(fmt.Errorf("unexpected node count"))
+}
+
+func (i *IntentLoginFlowAssessRisk) stepUpRequired(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (bool, error) {
+	n, ok := flows.Nearest.Nodes[0].Simple.(*NodeDidAssessRisk)
+	if !ok || n.Decision != model.RiskDecisionStepUp {
+		return false, nil
+	}
+
+	amr, err := CollectAMR(ctx, deps, flows)
+	if err != nil {
+		return false, err
+	}
+	return !slices.Contains(amr, model.AMRMFA), nil
+}
+
+func (*IntentLoginFlowAssessRisk) Milestone() {}
+
+// This is needed so that the child authenticate intents display a correct flow action
+func (i *IntentLoginFlowAssessRisk) MilestoneAuthenticationFlowObjectProvider() config.AuthenticationFlowObject {
+	return i.FlowObject
+}
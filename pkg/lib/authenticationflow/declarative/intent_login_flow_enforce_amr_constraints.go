@@ -22,6 +22,16 @@ type IntentLoginFlowEnforceAMRConstraints struct {
 }
 
 func NewIntentLoginFlowEnforceAMRConstraints(ctx context.Context, deps *authenticationflow.Dependencies, flows authenticationflow.Flows, flowRef authenticationflow.FlowReference) (*IntentLoginFlowEnforceAMRConstraints, error) {
+	return &IntentLoginFlowEnforceAMRConstraints{
+		FlowReference: flowRef,
+		FlowObject:    newLoginFlowSecondaryAuthenticateStep(deps),
+		JSONPointer:   jsonpointer.T{},
+	}, nil
+}
+
+// newLoginFlowSecondaryAuthenticateStep generates a temporary authenticate step
+// which accepts any secondary authentication method enabled in the config.
+func newLoginFlowSecondaryAuthenticateStep(deps *authenticationflow.Dependencies) *config.AuthenticationFlowLoginFlowStep {
 	var oneOfs []*config.AuthenticationFlowLoginFlowOneOf
 
 	addOneOf := func(am model.AuthenticationFlowAuthentication, bpGetter func(*config.AppConfig) (*config.AuthenticationFlowBotProtection, bool)) {
@@ -59,16 +69,10 @@ func NewIntentLoginFlowEnforceAMRConstraints(ctx context.Context, deps *authenti
 	}
 
 	// Generate a temporary config for this step only
-	flowObject := &config.AuthenticationFlowLoginFlowStep{
+	return &config.AuthenticationFlowLoginFlowStep{
 		Type:  config.AuthenticationFlowLoginFlowStepTypeAuthenticate,
 		OneOf: oneOfs,
 	}
-
-	return &IntentLoginFlowEnforceAMRConstraints{
-		FlowReference: flowRef,
-		FlowObject:    flowObject,
-		JSONPointer:   jsonpointer.T{},
-	}, nil
 }
 
 var _ authenticationflow.Intent = &IntentLoginFlowEnforceAMRConstraints{}
@@ -15,6 +15,10 @@ var _ authflow.Intent = &IntentLoginFlowPreAuthenticated{}
 
 type IntentLoginFlowPreAuthenticated struct {
 	FlowReference authflow.FlowReference `json:"flow_reference"`
+	// AssessRisk is true if the risk assessment follows the AMR constraints.
+	// It is false in flows created before the risk assessment was introduced,
+	// so that those flows end after the AMR constraints as they did.
+	AssessRisk bool `json:"assess_risk,omitempty"`
 }
 
 func (i *IntentLoginFlowPreAuthenticated) Kind() string {
@@ -28,6 +32,11 @@ func (i *IntentLoginFlowPreAuthenticated) CanReactTo(ctx context.Context, deps *
 	case 1:
 		return nil, nil
 	case 2:
+		if !i.AssessRisk {
+			return nil, authflow.ErrEOF
+		}
+		return nil, nil
+	case 3:
 		return nil, authflow.ErrEOF
 	}
 
@@ -44,6 +53,12 @@ func (i *IntentLoginFlowPreAuthenticated) ReactTo(ctx context.Context, deps *aut
 			return nil, err
 		}
 		return authflow.NewSubFlow(subFlow), nil
+	case 2:
+		subFlow, err := NewIntentLoginFlowAssessRisk(ctx, deps, flows, i.FlowReference)
+		if err != nil {
+			return nil, err
+		}
+		return authflow.NewSubFlow(subFlow), nil
 	}
 
 	panic(fmt.Errorf("unexpected node count"))
@@ -109,6 +109,11 @@ func NewIntentLoginFlowStepIdentify(ctx context.Context, deps *authflow.Dependen
 				options = append(options, InternalIdentificationOption{Option: o})
 			}
 			break
+		case model.AuthenticationFlowIdentificationSAML:
+			samlOptions := NewIdentificationOptionsSAML(deps.Config.Identity.SAML)
+			for _, o := range samlOptions {
+				options = append(options, InternalIdentificationOption{Option: o})
+			}
 		case model.AuthenticationFlowIdentificationIDToken:
 			// ID token is an advanced usage, and it inheritly does not support user interaction.
 			// Thus bot protection is not supported.
@@ -208,6 +213,10 @@ func (i *IntentLoginFlowStepIdentify) ReactTo(ctx context.Context, deps *authflo
 				return authflow.NewSubFlow(&IntentLDAP{
 					JSONPointer: authflow.JSONPointerForOneOf(i.JSONPointer, idx),
 				}), nil
+			case model.AuthenticationFlowIdentificationSAML:
+				return authflow.NewSubFlow(&IntentSAML{
+					JSONPointer: authflow.JSONPointerForOneOf(i.JSONPointer, idx),
+				}), nil
 			case model.AuthenticationFlowIdentificationIDToken:
 				return authflow.NewSubFlow(&IntentIdentifyWithIDToken{
 					JSONPointer:    authflow.JSONPointerForOneOf(i.JSONPointer, idx),
@@ -0,0 +1,71 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+)
+
+func init() {
+	authflow.RegisterIntent(&IntentLoginFlowStepSelectOrganization{})
+}
+
+type IntentLoginFlowStepSelectOrganization struct {
+	FlowReference authflow.FlowReference `json:"flow_reference,omitempty"`
+	StepName      string                 `json:"step_name,omitempty"`
+	JSONPointer   jsonpointer.T          `json:"json_pointer,omitempty"`
+	UserID        string                 `json:"user_id,omitempty"`
+}
+
+var _ authflow.Intent = &IntentLoginFlowStepSelectOrganization{}
+
+func (*IntentLoginFlowStepSelectOrganization) Kind() string {
+	return "IntentLoginFlowStepSelectOrganization"
+}
+
+func (i *IntentLoginFlowStepSelectOrganization) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	switch len(flows.Nearest.Nodes) {
+	case 0:
+		// Accept the pending invitations first.
+		return nil, nil
+	case 1:
+		// Then decide whether the user has to select an organization.
+		return nil, nil
+	}
+
+	return nil, authflow.ErrEOF
+}
+
+func (i *IntentLoginFlowStepSelectOrganization) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	switch len(flows.Nearest.Nodes) {
+	case 0:
+		return authflow.NewNodeSimple(&NodeDoAcceptOrganizationInvitations{
+			UserID: i.UserID,
+		}), nil
+	case 1:
+		organizations, err := deps.OrganizationQueries.ListOrganizationsByUserID(ctx, i.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(organizations) {
+		case 0:
+			// The user does not belong to any organization.
+			// The token will not have org_id.
+			return authflow.NewNodeSimple(&NodeSentinel{}), nil
+		case 1:
+			return authflow.NewNodeSimple(&NodeDidSelectOrganization{
+				OrganizationID: organizations[0].ID,
+			}), nil
+		default:
+			return authflow.NewNodeSimple(&NodeLoginFlowSelectOrganization{
+				JSONPointer: i.JSONPointer,
+				UserID:      i.UserID,
+			}), nil
+		}
+	}
+
+	return nil, authflow.ErrIncompatibleInput
+}
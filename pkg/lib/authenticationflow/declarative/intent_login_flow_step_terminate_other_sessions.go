@@ -6,6 +6,7 @@ import (
 	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
 
 	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/uiparam"
 )
 
@@ -52,5 +53,20 @@ func (i *IntentLoginFlowStepTerminateOtherSessions) ReactTo(ctx context.Context,
 		}
 	}
 
+	// Let the user confirm to terminate other sessions instead of rejecting the login.
+	sessionConfig := deps.Config.Session
+	if sessionConfig.IsConcurrentSessionLimitEnabled() && sessionConfig.ConcurrentSessionLimitPolicy == config.ConcurrentSessionLimitPolicyReject {
+		excessSessions, err := deps.IDPSessions.ListExcessSessions(ctx, i.UserID, currentIDPSessionID(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(excessSessions) != 0 {
+			return authflow.NewNodeSimple(&NodeLoginFlowTerminateOtherSessions{
+				JSONPointer: i.JSONPointer,
+			}), nil
+		}
+	}
+
 	return authflow.NewNodeSimple(&NodeSentinel{}), nil
 }
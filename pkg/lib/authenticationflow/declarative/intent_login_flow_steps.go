@@ -60,6 +60,7 @@ func (i *IntentLoginFlowSteps) ReactTo(ctx context.Context, deps *authflow.Depen
 	if IsLastAuthentication(current, i.NextStepIndex) && !IsPreAuthenticatedTriggered(flows) {
 		return authflow.NewSubFlow(&IntentLoginFlowPreAuthenticated{
 			FlowReference: i.FlowReference,
+			AssessRisk:    true,
 		}), nil
 	}
 
@@ -123,6 +124,14 @@ func (i *IntentLoginFlowSteps) ReactTo(ctx context.Context, deps *authflow.Depen
 			UserID:        i.userID(flows),
 		})
 		break
+	case config.AuthenticationFlowLoginFlowStepTypeSelectOrganization:
+		result = authflow.NewSubFlow(&IntentLoginFlowStepSelectOrganization{
+			FlowReference: i.FlowReference,
+			StepName:      step.Name,
+			JSONPointer:   authflow.JSONPointerForStep(i.JSONPointer, i.NextStepIndex),
+			UserID:        i.userID(flows),
+		})
+		break
 	}
 
 	i.NextStepIndex = i.NextStepIndex + 1
@@ -99,6 +99,7 @@ func (i *IntentLookupIdentityOAuth) ReactTo(ctx context.Context, deps *authflow.
 				Alias:          alias,
 				RedirectURI:    redirectURI,
 				ResponseMode:   responseMode,
+				Nonce:          newOAuthNonce(),
 			}), bpSpecialErr
 		}
 	}
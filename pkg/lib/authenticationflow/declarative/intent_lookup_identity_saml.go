@@ -0,0 +1,62 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+)
+
+func init() {
+	authflow.RegisterIntent(&IntentLookupIdentitySAML{})
+}
+
+type IntentLookupIdentitySAML struct {
+	JSONPointer jsonpointer.T `json:"json_pointer,omitempty"`
+}
+
+var _ authflow.Intent = &IntentLookupIdentitySAML{}
+var _ authflow.Milestone = &IntentLookupIdentitySAML{}
+var _ MilestoneIdentificationMethod = &IntentLookupIdentitySAML{}
+
+func (*IntentLookupIdentitySAML) Kind() string {
+	return "IntentLookupIdentitySAML"
+}
+
+func (*IntentLookupIdentitySAML) Milestone() {}
+
+func (i *IntentLookupIdentitySAML) MilestoneIdentificationMethod() model.AuthenticationFlowIdentification {
+	return model.AuthenticationFlowIdentificationSAML
+}
+
+func (i *IntentLookupIdentitySAML) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	if len(flows.Nearest.Nodes) == 0 {
+		flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, i)
+		if err != nil {
+			return nil, err
+		}
+		return &InputSchemaTakeSAMLRequest{
+			FlowRootObject: flowRootObject,
+			JSONPointer:    i.JSONPointer,
+			SAMLOptions:    NewIdentificationOptionsSAML(deps.Config.Identity.SAML),
+		}, nil
+	}
+	return nil, authflow.ErrEOF
+}
+
+func (i *IntentLookupIdentitySAML) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	if len(flows.Nearest.Nodes) == 0 {
+		var inputSAML inputTakeSAMLRequest
+		if authflow.AsInput(input, &inputSAML) {
+			return authflow.NewNodeSimple(&NodeLookupIdentitySAML{
+				JSONPointer:          i.JSONPointer,
+				IdentityProviderName: inputSAML.GetSAMLIdentityProviderName(),
+				AuthnRequestID:       samlprotocol.GenerateAuthnRequestID(),
+			}), nil
+		}
+	}
+	return nil, authflow.ErrIncompatibleInput
+}
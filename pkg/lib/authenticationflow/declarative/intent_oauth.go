@@ -109,6 +109,7 @@ func (i *IntentOAuth) ReactTo(ctx context.Context, deps *authflow.Dependencies,
 				Alias:        alias,
 				RedirectURI:  redirectURI,
 				ResponseMode: responseMode,
+				Nonce:        newOAuthNonce(),
 			}), bpSpecialErr
 		}
 	}
@@ -103,6 +103,7 @@ func (i *IntentPromoteIdentityOAuth) ReactTo(ctx context.Context, deps *authflow
 				Alias:          alias,
 				RedirectURI:    redirectURI,
 				ResponseMode:   responseMode,
+				Nonce:          newOAuthNonce(),
 			}), bpSpecialErr
 		}
 	}
@@ -0,0 +1,81 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+)
+
+func init() {
+	authflow.RegisterIntent(&IntentSAML{})
+}
+
+type IntentSAML struct {
+	JSONPointer jsonpointer.T `json:"json_pointer,omitempty"`
+	NewUserID   string        `json:"new_user_id,omitempty"`
+}
+
+var _ authflow.Intent = &IntentSAML{}
+var _ authflow.Milestone = &IntentSAML{}
+var _ MilestoneIdentificationMethod = &IntentSAML{}
+var _ MilestoneFlowCreateIdentity = &IntentSAML{}
+var _ MilestoneFlowUseIdentity = &IntentSAML{}
+
+func (*IntentSAML) Kind() string {
+	return "IntentSAML"
+}
+
+func (*IntentSAML) Milestone() {}
+
+func (i *IntentSAML) MilestoneIdentificationMethod() model.AuthenticationFlowIdentification {
+	return model.AuthenticationFlowIdentificationSAML
+}
+
+func (*IntentSAML) MilestoneFlowCreateIdentity(flows authflow.Flows) (MilestoneDoCreateIdentity, authflow.Flows, bool) {
+	// Find IntentCheckConflictAndCreateIdenity
+	m, mFlows, ok := authflow.FindMilestoneInCurrentFlow[MilestoneFlowCreateIdentity](flows)
+	if !ok {
+		return nil, mFlows, false
+	}
+
+	// Delegate to IntentCheckConflictAndCreateIdenity
+	return m.MilestoneFlowCreateIdentity(mFlows)
+}
+
+func (*IntentSAML) MilestoneFlowUseIdentity(flows authflow.Flows) (MilestoneDoUseIdentity, authflow.Flows, bool) {
+	return authflow.FindMilestoneInCurrentFlow[MilestoneDoUseIdentity](flows)
+}
+
+func (i *IntentSAML) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	if len(flows.Nearest.Nodes) == 0 {
+		flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, i)
+		if err != nil {
+			return nil, err
+		}
+		return &InputSchemaTakeSAMLRequest{
+			FlowRootObject: flowRootObject,
+			JSONPointer:    i.JSONPointer,
+			SAMLOptions:    NewIdentificationOptionsSAML(deps.Config.Identity.SAML),
+		}, nil
+	}
+	return nil, authflow.ErrEOF
+}
+
+func (i *IntentSAML) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	if len(flows.Nearest.Nodes) == 0 {
+		var inputSAML inputTakeSAMLRequest
+		if authflow.AsInput(input, &inputSAML) {
+			return authflow.NewNodeSimple(&NodeSAML{
+				JSONPointer:          i.JSONPointer,
+				NewUserID:            i.NewUserID,
+				IdentityProviderName: inputSAML.GetSAMLIdentityProviderName(),
+				AuthnRequestID:       samlprotocol.GenerateAuthnRequestID(),
+			}), nil
+		}
+	}
+	return nil, authflow.ErrIncompatibleInput
+}
@@ -168,6 +168,9 @@ func NewIntentSignupFlowStepIdentify(ctx context.Context, deps *authflow.Depende
 			ldapOptions := NewIdentificationOptionLDAP(deps.Config.Identity.LDAP, b.BotProtection, deps.Config.BotProtection)
 			options = append(options, ldapOptions...)
 			break
+		case model.AuthenticationFlowIdentificationSAML:
+			samlOptions := NewIdentificationOptionsSAML(deps.Config.Identity.SAML)
+			options = append(options, samlOptions...)
 		}
 	}
 
@@ -279,6 +282,11 @@ func (i *IntentSignupFlowStepIdentify) ReactTo(ctx context.Context, deps *authfl
 					JSONPointer: authflow.JSONPointerForOneOf(i.JSONPointer, idx),
 					NewUserID:   i.UserID,
 				}), nil
+			case model.AuthenticationFlowIdentificationSAML:
+				return authflow.NewSubFlow(&IntentSAML{
+					JSONPointer: authflow.JSONPointerForOneOf(i.JSONPointer, idx),
+					NewUserID:   i.UserID,
+				}), nil
 			}
 		}
 		return nil, authflow.ErrIncompatibleInput
@@ -78,6 +78,11 @@ func NewIntentSignupLoginFlowStepIdentify(ctx context.Context, deps *authflow.De
 				options = append(options, InternalIdentificationOption{Option: o})
 			}
 			break
+		case model.AuthenticationFlowIdentificationSAML:
+			samlOptions := NewIdentificationOptionsSAML(deps.Config.Identity.SAML)
+			for _, o := range samlOptions {
+				options = append(options, InternalIdentificationOption{Option: o})
+			}
 		case model.AuthenticationFlowIdentificationIDToken:
 			// ID token is an advanced usage, and it inheritly does not support user interaction.
 			// Thus bot protection is not supported.
@@ -168,6 +173,10 @@ func (i *IntentSignupLoginFlowStepIdentify) ReactTo(ctx context.Context, deps *a
 				return authflow.NewSubFlow(&IntentLookupIdentityLDAP{
 					JSONPointer: authflow.JSONPointerForOneOf(i.JSONPointer, idx),
 				}), nil
+			case model.AuthenticationFlowIdentificationSAML:
+				return authflow.NewSubFlow(&IntentLookupIdentitySAML{
+					JSONPointer: authflow.JSONPointerForOneOf(i.JSONPointer, idx),
+				}), nil
 			case model.AuthenticationFlowIdentificationIDToken:
 				return authflow.NewSubFlow(&IntentLookupWithIDToken{
 					JSONPointer:    authflow.JSONPointerForOneOf(i.JSONPointer, idx),
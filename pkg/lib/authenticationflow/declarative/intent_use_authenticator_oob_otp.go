@@ -23,6 +23,8 @@ type IntentUseAuthenticatorOOBOTP struct {
 	UserID         string                                 `json:"user_id,omitempty"`
 	Authentication model.AuthenticationFlowAuthentication `json:"authentication,omitempty"`
 	Options        []AuthenticateOption                   `json:"options,omitempty"`
+	// OTPForm is the otp form of the selected option.
+	OTPForm otp.Form `json:"otp_form,omitempty"`
 }
 
 var _ authflow.Intent = &IntentUseAuthenticatorOOBOTP{}
@@ -97,6 +99,7 @@ func (n *IntentUseAuthenticatorOOBOTP) ReactTo(ctx context.Context, deps *authfl
 			if err != nil {
 				return nil, errors.Join(bpSpecialErr, err)
 			}
+			n.OTPForm = n.Options[index].OTPForm
 
 			if isNew {
 				return authflow.NewNodeSimple(&NodeDoJustInTimeCreateAuthenticator{
@@ -112,7 +115,10 @@ func (n *IntentUseAuthenticatorOOBOTP) ReactTo(ctx context.Context, deps *authfl
 		info := m.MilestoneDidSelectAuthenticator()
 		claimName, _ := info.OOBOTP.ToClaimPair()
 		purpose := otp.PurposeOOBOTP
-		otpForm := getOTPForm(purpose, claimName, deps.Config.Authenticator.OOB.Email)
+		otpForm := n.OTPForm
+		if otpForm == "" {
+			otpForm = getOTPForm(purpose, claimName, deps.Config.Authenticator.OOB.Email)
+		}
 		return authflow.NewSubFlow(&IntentAuthenticationOOB{
 			JSONPointer:    n.JSONPointer,
 			UserID:         n.UserID,
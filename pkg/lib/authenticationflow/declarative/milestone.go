@@ -272,6 +272,11 @@ type MilestoneDoUseAnonymousUser interface {
 	MilestoneDoUseAnonymousUser() *identity.Info
 }
 
+type MilestoneDidSelectOrganization interface {
+	authflow.Milestone
+	MilestoneDidSelectOrganization() string
+}
+
 type MilestoneDidReauthenticate interface {
 	authflow.Milestone
 	MilestoneDidReauthenticate()
@@ -350,3 +355,8 @@ type MilestoneOOBOTPLastUsedChannelUpdated interface {
 	authflow.Milestone
 	MilestoneOOBOTPLastUsedChannelUpdated()
 }
+
+type MilestoneDidConfirmTerminateOtherSessions interface {
+	authflow.Milestone
+	MilestoneDidConfirmTerminateOtherSessions()
+}
@@ -307,7 +307,8 @@ func (n *NodeAuthenticationOOB) SendCode(ctx context.Context, deps *authflow.Dep
 	// It is because otp.MessageTypeWhatsappCode will send a Whatsapp authentication message.
 	// which is optimized for delivering a authentication code to the end-user.
 	// See https://developers.facebook.com/docs/whatsapp/business-management-api/authentication-templates/
-	typ := n.otpMessageType(n.Info)
+	fallbackTyp := n.otpMessageType(n.Info)
+	typ := fallbackTyp
 	if n.Channel == model.AuthenticatorOOBChannelWhatsapp {
 		typ = translation.MessageTypeWhatsappCode
 	}
@@ -315,12 +316,13 @@ func (n *NodeAuthenticationOOB) SendCode(ctx context.Context, deps *authflow.Dep
 	err := deps.OTPSender.Send(
 		ctx,
 		otp.SendOptions{
-			Channel: n.Channel,
-			Target:  claimValue,
-			Form:    n.Form,
-			Kind:    n.otpKind(deps),
-			Type:    typ,
-			OTP:     code,
+			Channel:      n.Channel,
+			Target:       claimValue,
+			Form:         n.Form,
+			Kind:         n.otpKind(deps),
+			Type:         typ,
+			FallbackType: fallbackTyp,
+			OTP:          code,
 		},
 	)
 	if err != nil {
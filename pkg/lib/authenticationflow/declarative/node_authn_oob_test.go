@@ -38,6 +38,10 @@ func (s *captureOTPCodeServiceForAuthnOOB) LookupCode(ctx context.Context, purpo
 	return "", nil
 }
 
+func (s *captureOTPCodeServiceForAuthnOOB) InspectCode(ctx context.Context, purpose otp.Purpose, target string) (*otp.Code, error) {
+	return nil, nil
+}
+
 func (s *captureOTPCodeServiceForAuthnOOB) SetSubmittedCode(ctx context.Context, kind otp.Kind, target string, code string) (*otp.State, error) {
 	return nil, nil
 }
@@ -0,0 +1,20 @@
+package declarative
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+)
+
+func init() {
+	authflow.RegisterNode(&NodeDidAssessRisk{})
+}
+
+type NodeDidAssessRisk struct {
+	Decision model.RiskDecision `json:"decision"`
+}
+
+var _ authflow.NodeSimple = &NodeDidAssessRisk{}
+
+func (*NodeDidAssessRisk) Kind() string {
+	return "NodeDidAssessRisk"
+}
@@ -11,7 +11,12 @@ func init() {
 type NodeDidConfirmTerminateOtherSessions struct{}
 
 var _ authflow.NodeSimple = &NodeDidConfirmTerminateOtherSessions{}
+var _ authflow.Milestone = &NodeDidConfirmTerminateOtherSessions{}
+var _ MilestoneDidConfirmTerminateOtherSessions = &NodeDidConfirmTerminateOtherSessions{}
 
 func (n *NodeDidConfirmTerminateOtherSessions) Kind() string {
 	return "NodeDidConfirmTerminateOtherSessions"
 }
+
+func (*NodeDidConfirmTerminateOtherSessions) Milestone()                                 {}
+func (*NodeDidConfirmTerminateOtherSessions) MilestoneDidConfirmTerminateOtherSessions() {}
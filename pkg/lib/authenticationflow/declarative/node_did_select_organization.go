@@ -0,0 +1,26 @@
+package declarative
+
+import (
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+)
+
+func init() {
+	authflow.RegisterNode(&NodeDidSelectOrganization{})
+}
+
+type NodeDidSelectOrganization struct {
+	OrganizationID string `json:"organization_id,omitempty"`
+}
+
+var _ authflow.NodeSimple = &NodeDidSelectOrganization{}
+var _ authflow.Milestone = &NodeDidSelectOrganization{}
+var _ MilestoneDidSelectOrganization = &NodeDidSelectOrganization{}
+
+func (*NodeDidSelectOrganization) Kind() string {
+	return "NodeDidSelectOrganization"
+}
+
+func (n *NodeDidSelectOrganization) Milestone() {}
+func (n *NodeDidSelectOrganization) MilestoneDidSelectOrganization() string {
+	return n.OrganizationID
+}
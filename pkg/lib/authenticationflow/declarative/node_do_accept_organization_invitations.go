@@ -0,0 +1,77 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+)
+
+func init() {
+	authflow.RegisterNode(&NodeDoAcceptOrganizationInvitations{})
+}
+
+// NodeDoAcceptOrganizationInvitations accepts the pending organization invitations
+// addressed to any verified email of the user.
+type NodeDoAcceptOrganizationInvitations struct {
+	UserID string `json:"user_id,omitempty"`
+}
+
+var _ authflow.NodeSimple = &NodeDoAcceptOrganizationInvitations{}
+var _ authflow.EffectGetter = &NodeDoAcceptOrganizationInvitations{}
+
+func (*NodeDoAcceptOrganizationInvitations) Kind() string {
+	return "NodeDoAcceptOrganizationInvitations"
+}
+
+func (n *NodeDoAcceptOrganizationInvitations) GetEffects(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (effs []authflow.Effect, err error) {
+	return []authflow.Effect{
+		authflow.RunEffect(func(ctx context.Context, deps *authflow.Dependencies) error {
+			emails, err := n.verifiedEmails(ctx, deps)
+			if err != nil {
+				return err
+			}
+
+			accepted, err := deps.OrganizationCommands.AcceptPendingInvitations(ctx, n.UserID, emails)
+			if err != nil {
+				return err
+			}
+
+			// The events are delivered when the transaction commits.
+			for _, a := range accepted {
+				err := deps.Events.DispatchEventOnCommit(ctx, &nonblocking.OrganizationInvitationAcceptedEventPayload{
+					Organization: *a.Organization,
+					Invitation:   *a.Invitation,
+					Member:       *a.Member,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}, nil
+}
+
+func (n *NodeDoAcceptOrganizationInvitations) verifiedEmails(ctx context.Context, deps *authflow.Dependencies) ([]string, error) {
+	identities, err := deps.Identities.ListByUser(ctx, n.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var emails []string
+	for _, i := range identities {
+		claims, err := deps.Verification.GetIdentityVerificationStatus(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range claims {
+			if c.Name == string(model.ClaimEmail) && c.Verified {
+				emails = append(emails, c.Value)
+			}
+		}
+	}
+
+	return emails, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/authgear/authgear-server/pkg/api/event/blocking"
+	"github.com/authgear/authgear-server/pkg/api/model"
 	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
 	"github.com/authgear/authgear-server/pkg/lib/session"
@@ -39,6 +41,11 @@ func NewNodeDoCreateSession(ctx context.Context, deps *authflow.Dependencies, fl
 		return nil, err
 	}
 
+	var organizationID string
+	if milestones := authflow.FindAllMilestones[MilestoneDidSelectOrganization](flows.Root); len(milestones) > 0 {
+		organizationID = milestones[len(milestones)-1].MilestoneDidSelectOrganization()
+	}
+
 	var authnInfo authenticationinfo.T
 	var newSession *idpsession.IDPSession = nil
 	var sessionCookie *http.Cookie = nil
@@ -57,6 +64,9 @@ func NewNodeDoCreateSession(ctx context.Context, deps *authflow.Dependencies, fl
 		authnInfo.IdentitySpecs = identitySpecs
 		authnInfo.ContinueFromSessionType = string(n.ContinueFromSessionType)
 		authnInfo.ContinueFromSessionID = n.ContinueFromSessionID
+		if organizationID != "" {
+			authnInfo.OrganizationID = organizationID
+		}
 	} else {
 		amr, err := CollectAMR(ctx, deps, flows)
 		if err != nil {
@@ -67,11 +77,13 @@ func NewNodeDoCreateSession(ctx context.Context, deps *authflow.Dependencies, fl
 			AuthenticatedAt: deps.Clock.NowUTC(),
 			AMR:             amr,
 			IdentitySpecs:   identitySpecs,
+			OrganizationID:  organizationID,
 		}
 
 		if !n.SkipCreate {
 			attrs := session.NewAttrs(n.UserID)
 			attrs.SetAMR(amr)
+			attrs.SetOrganizationID(organizationID)
 			s, token := deps.IDPSessions.MakeSession(attrs)
 			newSession = s
 			sessionCookie = deps.Cookies.ValueCookie(deps.SessionCookie.Def, token)
@@ -130,7 +142,24 @@ func (n *NodeDoCreateSession) GetEffects(ctx context.Context, deps *authflow.Dep
 				return nil
 			}
 
-			err := deps.IDPSessions.Create(ctx, n.Session)
+			err := deps.Events.DispatchEventOnCommit(ctx, &blocking.SessionPreCreateBlockingEventPayload{
+				UserRef: model.UserRef{
+					Meta: model.Meta{
+						ID: n.UserID,
+					},
+				},
+				Session: *n.Session.ToAPIModel(),
+			})
+			if err != nil {
+				return err
+			}
+
+			err = n.enforceConcurrentSessionLimit(ctx, deps, flows)
+			if err != nil {
+				return err
+			}
+
+			err = deps.IDPSessions.Create(ctx, n.Session)
 			if err != nil {
 				return err
 			}
@@ -149,6 +178,35 @@ func (n *NodeDoCreateSession) GetEffects(ctx context.Context, deps *authflow.Dep
 	}, nil
 }
 
+// enforceConcurrentSessionLimit ends other sessions of the user, or rejects the new session,
+// so that the user does not exceed the concurrent session limit.
+func (n *NodeDoCreateSession) enforceConcurrentSessionLimit(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) error {
+	confirmed := len(authflow.FindAllMilestones[MilestoneDidConfirmTerminateOtherSessions](flows.Root)) > 0
+	sessionsToEnd, err := deps.IDPSessions.ListSessionsToEnd(ctx, n.UserID, currentIDPSessionID(ctx), confirmed)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sessionsToEnd {
+		err = deps.Sessions.RevokeWithEvent(ctx, s, true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// currentIDPSessionID returns the ID of the IdP session of the request, if any.
+// The session is revoked when a new session is created.
+func currentIDPSessionID(ctx context.Context) string {
+	s := session.GetSession(ctx)
+	if s != nil && s.SessionType() == session.TypeIdentityProvider {
+		return s.SessionID()
+	}
+	return ""
+}
+
 func (n *NodeDoCreateSession) GetCookies(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) ([]*http.Cookie, error) {
 	var cookies []*http.Cookie
 	if n.SessionCookie != nil {
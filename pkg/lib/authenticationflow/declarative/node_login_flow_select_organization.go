@@ -0,0 +1,107 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+)
+
+func init() {
+	authflow.RegisterNode(&NodeLoginFlowSelectOrganization{})
+}
+
+type SelectOrganizationOption struct {
+	ID          string  `json:"id"`
+	Key         string  `json:"key"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+type NodeLoginFlowSelectOrganizationData struct {
+	TypedData
+	Organizations []SelectOrganizationOption `json:"organizations"`
+}
+
+func NewNodeLoginFlowSelectOrganizationData(d NodeLoginFlowSelectOrganizationData) NodeLoginFlowSelectOrganizationData {
+	d.Type = DataTypeSelectOrganizationData
+	return d
+}
+
+var _ authflow.Data = NodeLoginFlowSelectOrganizationData{}
+
+func (NodeLoginFlowSelectOrganizationData) Data() {}
+
+type NodeLoginFlowSelectOrganization struct {
+	JSONPointer jsonpointer.T `json:"json_pointer,omitempty"`
+	UserID      string        `json:"user_id,omitempty"`
+}
+
+var _ authflow.NodeSimple = &NodeLoginFlowSelectOrganization{}
+var _ authflow.InputReactor = &NodeLoginFlowSelectOrganization{}
+var _ authflow.DataOutputer = &NodeLoginFlowSelectOrganization{}
+
+func (*NodeLoginFlowSelectOrganization) Kind() string {
+	return "NodeLoginFlowSelectOrganization"
+}
+
+func (n *NodeLoginFlowSelectOrganization) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, n)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := deps.OrganizationQueries.ListOrganizationsByUserID(ctx, n.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIDs := make([]string, len(organizations))
+	for i, o := range organizations {
+		organizationIDs[i] = o.ID
+	}
+
+	return &InputSchemaSelectOrganization{
+		JSONPointer:     n.JSONPointer,
+		FlowRootObject:  flowRootObject,
+		OrganizationIDs: organizationIDs,
+	}, nil
+}
+
+func (n *NodeLoginFlowSelectOrganization) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	var inputSelectOrganization inputSelectOrganization
+	if authflow.AsInput(input, &inputSelectOrganization) {
+		return authflow.NewNodeSimple(&NodeDidSelectOrganization{
+			OrganizationID: inputSelectOrganization.GetOrganizationID(),
+		}), nil
+	}
+
+	return nil, authflow.ErrIncompatibleInput
+}
+
+func (n *NodeLoginFlowSelectOrganization) OutputData(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.Data, error) {
+	organizations, err := deps.OrganizationQueries.ListOrganizationsByUserID(ctx, n.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]SelectOrganizationOption, len(organizations))
+	for i, o := range organizations {
+		options[i] = newSelectOrganizationOption(o)
+	}
+
+	return NewNodeLoginFlowSelectOrganizationData(NodeLoginFlowSelectOrganizationData{
+		Organizations: options,
+	}), nil
+}
+
+func newSelectOrganizationOption(o *model.Organization) SelectOrganizationOption {
+	return SelectOrganizationOption{
+		ID:          o.ID,
+		Key:         o.Key,
+		Name:        o.Name,
+		Description: o.Description,
+	}
+}
@@ -22,6 +22,7 @@ type NodeLookupIdentityOAuth struct {
 	Alias          string             `json:"alias,omitempty"`
 	RedirectURI    string             `json:"redirect_uri,omitempty"`
 	ResponseMode   string             `json:"response_mode,omitempty"`
+	Nonce          string             `json:"nonce,omitempty"`
 }
 
 var _ authflow.NodeSimple = &NodeLookupIdentityOAuth{}
@@ -60,6 +61,7 @@ func (n *NodeLookupIdentityOAuth) ReactTo(ctx context.Context, deps *authflow.De
 		spec, err := handleOAuthAuthorizationResponse(ctx, deps, HandleOAuthAuthorizationResponseOptions{
 			Alias:       n.Alias,
 			RedirectURI: n.RedirectURI,
+			Nonce:       n.Nonce,
 		}, inputOAuth)
 		if err != nil {
 			return nil, err
@@ -108,6 +110,7 @@ func (n *NodeLookupIdentityOAuth) OutputData(ctx context.Context, deps *authflow
 		RedirectURI:  n.RedirectURI,
 		Alias:        n.Alias,
 		ResponseMode: n.ResponseMode,
+		Nonce:        n.Nonce,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,121 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func init() {
+	authflow.RegisterNode(&NodeLookupIdentitySAML{})
+}
+
+type NodeLookupIdentitySAML struct {
+	JSONPointer          jsonpointer.T `json:"json_pointer,omitempty"`
+	IdentityProviderName string        `json:"identity_provider_name,omitempty"`
+	AuthnRequestID       string        `json:"authn_request_id,omitempty"`
+}
+
+var _ authflow.NodeSimple = &NodeLookupIdentitySAML{}
+var _ authflow.InputReactor = &NodeLookupIdentitySAML{}
+var _ authflow.DataOutputer = &NodeLookupIdentitySAML{}
+
+func (*NodeLookupIdentitySAML) Kind() string {
+	return "NodeLookupIdentitySAML"
+}
+
+func (n *NodeLookupIdentitySAML) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, n)
+	if err != nil {
+		return nil, err
+	}
+	return &InputSchemaTakeSAMLResponse{
+		FlowRootObject: flowRootObject,
+		JSONPointer:    n.JSONPointer,
+	}, nil
+}
+
+func (n *NodeLookupIdentitySAML) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, n)
+	if err != nil {
+		return nil, err
+	}
+	current, err := authflow.FlowObject(flowRootObject, n.JSONPointer)
+	if err != nil {
+		return nil, err
+	}
+
+	oneOf := n.oneOf(current)
+
+	var inputSAML inputTakeSAMLResponse
+	if authflow.AsInput(input, &inputSAML) {
+		spec, err := handleSAMLResponse(ctx, deps, HandleSAMLResponseOptions{
+			IdentityProviderName: n.IdentityProviderName,
+			AuthnRequestID:       n.AuthnRequestID,
+		}, inputSAML)
+		if err != nil {
+			return nil, err
+		}
+
+		syntheticInput := &SyntheticInputSAML{
+			Identification:       model.AuthenticationFlowIdentificationSAML,
+			IdentityProviderName: n.IdentityProviderName,
+			IdentitySpec:         spec,
+		}
+
+		_, err = findExactOneIdentityInfo(ctx, deps, spec)
+		if err != nil {
+			if apierrors.IsKind(err, api.UserNotFound) {
+				// signup
+				return nil, &authflow.ErrorSwitchFlow{
+					FlowReference: authflow.FlowReference{
+						Type: authflow.FlowTypeSignup,
+						Name: oneOf.SignupFlow,
+					},
+					SyntheticInput: syntheticInput,
+				}
+			}
+			// general error
+			return nil, err
+		}
+
+		// login
+		return nil, &authflow.ErrorSwitchFlow{
+			FlowReference: authflow.FlowReference{
+				Type: authflow.FlowTypeLogin,
+				Name: oneOf.LoginFlow,
+			},
+			SyntheticInput: syntheticInput,
+		}
+	}
+
+	return nil, authflow.ErrIncompatibleInput
+}
+
+func (n *NodeLookupIdentitySAML) OutputData(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.Data, error) {
+	data, err := getSAMLData(ctx, deps, GetSAMLDataOptions{
+		IdentityProviderName: n.IdentityProviderName,
+		AuthnRequestID:       n.AuthnRequestID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (n *NodeLookupIdentitySAML) oneOf(o config.AuthenticationFlowObject) *config.AuthenticationFlowSignupLoginFlowOneOf {
+	oneOf, ok := o.(*config.AuthenticationFlowSignupLoginFlowOneOf)
+	if !ok {
+		panic(fmt.Errorf("flow object is %T", o))
+	}
+
+	return oneOf
+}
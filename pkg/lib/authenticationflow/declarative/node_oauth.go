@@ -19,6 +19,7 @@ type NodeOAuth struct {
 	Alias        string        `json:"alias,omitempty"`
 	RedirectURI  string        `json:"redirect_uri,omitempty"`
 	ResponseMode string        `json:"response_mode,omitempty"`
+	Nonce        string        `json:"nonce,omitempty"`
 }
 
 var _ authflow.NodeSimple = &NodeOAuth{}
@@ -56,6 +57,7 @@ func (n *NodeOAuth) ReactTo(ctx context.Context, deps *authflow.Dependencies, fl
 		spec, err := handleOAuthAuthorizationResponse(ctx, deps, HandleOAuthAuthorizationResponseOptions{
 			Alias:       n.Alias,
 			RedirectURI: n.RedirectURI,
+			Nonce:       n.Nonce,
 		}, inputOAuth)
 		if err != nil {
 			return nil, err
@@ -72,6 +74,7 @@ func (n *NodeOAuth) OutputData(ctx context.Context, deps *authflow.Dependencies,
 		RedirectURI:  n.RedirectURI,
 		Alias:        n.Alias,
 		ResponseMode: n.ResponseMode,
+		Nonce:        n.Nonce,
 	})
 	if err != nil {
 		return nil, err
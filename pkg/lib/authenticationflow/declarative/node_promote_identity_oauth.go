@@ -24,6 +24,7 @@ type NodePromoteIdentityOAuth struct {
 	Alias          string             `json:"alias,omitempty"`
 	RedirectURI    string             `json:"redirect_uri,omitempty"`
 	ResponseMode   string             `json:"response_mode,omitempty"`
+	Nonce          string             `json:"nonce,omitempty"`
 }
 
 var _ authflow.NodeSimple = &NodePromoteIdentityOAuth{}
@@ -51,6 +52,7 @@ func (n *NodePromoteIdentityOAuth) ReactTo(ctx context.Context, deps *authflow.D
 		spec, err := handleOAuthAuthorizationResponse(ctx, deps, HandleOAuthAuthorizationResponseOptions{
 			Alias:       n.Alias,
 			RedirectURI: n.RedirectURI,
+			Nonce:       n.Nonce,
 		}, inputOAuth)
 		if err != nil {
 			return nil, err
@@ -116,6 +118,7 @@ func (n *NodePromoteIdentityOAuth) OutputData(ctx context.Context, deps *authflo
 		RedirectURI:  n.RedirectURI,
 		Alias:        n.Alias,
 		ResponseMode: n.ResponseMode,
+		Nonce:        n.Nonce,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,100 @@
+package declarative
+
+import (
+	"context"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+)
+
+func init() {
+	authflow.RegisterNode(&NodeSAML{})
+}
+
+type NodeSAML struct {
+	JSONPointer          jsonpointer.T `json:"json_pointer,omitempty"`
+	NewUserID            string        `json:"new_user_id,omitempty"`
+	IdentityProviderName string        `json:"identity_provider_name,omitempty"`
+	// AuthnRequestID is the ID of the AuthnRequest sent to the identity provider.
+	// The response must be InResponseTo it.
+	AuthnRequestID string `json:"authn_request_id,omitempty"`
+}
+
+var _ authflow.NodeSimple = &NodeSAML{}
+var _ authflow.InputReactor = &NodeSAML{}
+var _ authflow.DataOutputer = &NodeSAML{}
+
+func (*NodeSAML) Kind() string {
+	return "NodeSAML"
+}
+
+func (n *NodeSAML) CanReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.InputSchema, error) {
+	flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, n)
+	if err != nil {
+		return nil, err
+	}
+	return &InputSchemaTakeSAMLResponse{
+		FlowRootObject: flowRootObject,
+		JSONPointer:    n.JSONPointer,
+	}, nil
+}
+
+func (n *NodeSAML) ReactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, input authflow.Input) (authflow.ReactToResult, error) {
+	var syntheticInputSAML syntheticInputSAML
+	var inputSAML inputTakeSAMLResponse
+	// The order of the cases is important.
+	// We must handle the synthetic input first.
+	// It is because if it is synthetic input,
+	// then the response has been consumed by another flow,
+	// and it is InResponseTo an AuthnRequest of that flow.
+	switch {
+	case authflow.AsInput(input, &syntheticInputSAML):
+		spec := syntheticInputSAML.GetSAMLIdentitySpec()
+		return n.reactTo(ctx, deps, flows, spec)
+	case authflow.AsInput(input, &inputSAML):
+		spec, err := handleSAMLResponse(ctx, deps, HandleSAMLResponseOptions{
+			IdentityProviderName: n.IdentityProviderName,
+			AuthnRequestID:       n.AuthnRequestID,
+		}, inputSAML)
+		if err != nil {
+			return nil, err
+		}
+
+		return n.reactTo(ctx, deps, flows, spec)
+	}
+
+	return nil, authflow.ErrIncompatibleInput
+}
+
+func (n *NodeSAML) OutputData(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows) (authflow.Data, error) {
+	data, err := getSAMLData(ctx, deps, GetSAMLDataOptions{
+		IdentityProviderName: n.IdentityProviderName,
+		AuthnRequestID:       n.AuthnRequestID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (n *NodeSAML) reactTo(ctx context.Context, deps *authflow.Dependencies, flows authflow.Flows, spec *identity.Spec) (authflow.ReactToResult, error) {
+	// signup
+	if n.NewUserID != "" {
+		return authflow.NewSubFlow(&IntentCheckConflictAndCreateIdenity{
+			JSONPointer: n.JSONPointer,
+			UserID:      n.NewUserID,
+			Request:     NewCreateSAMLIdentityRequest(spec),
+		}), nil
+	}
+	// Else login
+
+	exactMatch, err := findExactOneIdentityInfo(ctx, deps, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNodeDoUseIdentityWithUpdate(ctx, deps, flows, exactMatch, spec)
+}
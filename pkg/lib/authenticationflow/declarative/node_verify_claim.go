@@ -272,12 +272,13 @@ func (n *NodeVerifyClaim) SendCode(ctx context.Context, deps *authflow.Dependenc
 	err := deps.OTPSender.Send(
 		ctx,
 		otp.SendOptions{
-			Channel: n.Channel,
-			Target:  n.ClaimValue,
-			Form:    n.Form,
-			Kind:    n.otpKind(deps),
-			Type:    typ,
-			OTP:     code,
+			Channel:      n.Channel,
+			Target:       n.ClaimValue,
+			Form:         n.Form,
+			Kind:         n.otpKind(deps),
+			Type:         typ,
+			FallbackType: n.MessageType,
+			OTP:          code,
 		},
 	)
 	if err != nil {
@@ -37,6 +37,10 @@ func (s *captureOTPCodeServiceForVerifyClaim) LookupCode(ctx context.Context, pu
 	return "", nil
 }
 
+func (s *captureOTPCodeServiceForVerifyClaim) InspectCode(ctx context.Context, purpose otp.Purpose, target string) (*otp.Code, error) {
+	return nil, nil
+}
+
 func (s *captureOTPCodeServiceForVerifyClaim) SetSubmittedCode(ctx context.Context, kind otp.Kind, target string, code string) (*otp.State, error) {
 	return nil, nil
 }
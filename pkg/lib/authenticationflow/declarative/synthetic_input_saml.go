@@ -0,0 +1,32 @@
+package declarative
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/model"
+	authflow "github.com/authgear/authgear-server/pkg/lib/authenticationflow"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+)
+
+type SyntheticInputSAML struct {
+	Identification       model.AuthenticationFlowIdentification `json:"identification,omitempty"`
+	IdentityProviderName string                                 `json:"identity_provider_name,omitempty"`
+	IdentitySpec         *identity.Spec                         `json:"identity_spec,omitempty"`
+}
+
+var _ authflow.Input = &SyntheticInputSAML{}
+var _ inputTakeIdentificationMethod = &SyntheticInputSAML{}
+var _ inputTakeSAMLRequest = &SyntheticInputSAML{}
+var _ syntheticInputSAML = &SyntheticInputSAML{}
+
+func (*SyntheticInputSAML) Input() {}
+
+func (i *SyntheticInputSAML) GetIdentificationMethod() model.AuthenticationFlowIdentification {
+	return i.Identification
+}
+
+func (i *SyntheticInputSAML) GetSAMLIdentityProviderName() string {
+	return i.IdentityProviderName
+}
+
+func (i *SyntheticInputSAML) GetSAMLIdentitySpec() *identity.Spec {
+	return i.IdentitySpec
+}
@@ -86,6 +86,30 @@ func resolveAccountLinkingConfigsLDAP(
 	return matches, nil
 }
 
+func resolveAccountLinkingConfigsSAML(
+	ctx context.Context,
+	deps *authflow.Dependencies,
+	flows authflow.Flows,
+	request *CreateIdentityRequestSAML,
+) ([]*config.AccountLinkingSAMLItem, error) {
+	cfg := deps.Config.AccountLinking
+
+	matches := []*config.AccountLinkingSAMLItem{}
+
+	for _, samlConfig := range cfg.SAML {
+		if samlConfig.IdentityProviderName == request.Spec.SAML.IdentityProviderName {
+			matches = append(matches, samlConfig)
+		}
+	}
+
+	if len(matches) == 0 {
+		// By default, always error on email conflict
+		matches = append(matches, config.DefaultAccountLinkingSAMLItem)
+	}
+
+	return matches, nil
+}
+
 type AccountLinkingConflict struct {
 	Identity  *identity.Info              `json:"identity"`
 	Action    config.AccountLinkingAction `json:"action"`
@@ -120,6 +144,34 @@ func newAccountLinkingConflictWithIncomingOAuth(identity *identity.Info, cfg *co
 	return conflict
 }
 
+func newAccountLinkingConflictWithIncomingSAML(iden *identity.Info, cfg *config.AccountLinkingSAMLItem, overrides *config.AuthenticationFlowAccountLinking) *AccountLinkingConflict {
+	conflict := &AccountLinkingConflict{
+		Identity:  iden,
+		Action:    cfg.Action,
+		LoginFlow: "",
+	}
+
+	if overrides != nil && cfg.Name != "" {
+		var overrideItem *config.AuthenticationFlowAccountLinkingSAMLItem
+		for _, item := range overrides.SAML {
+			if item.Name != cfg.Name {
+				continue
+			}
+			overrideItem = item
+		}
+		if overrideItem != nil {
+			if overrideItem.Action != "" {
+				conflict.Action = overrideItem.Action
+			}
+			if overrideItem.LoginFlow != "" {
+				conflict.LoginFlow = overrideItem.LoginFlow
+			}
+		}
+	}
+
+	return conflict
+}
+
 func newAccountLinkingConflictWithIncomingLoginID(iden *identity.Info, cfg *config.AccountLinkingLoginIDItem, overrides *config.AuthenticationFlowAccountLinking) *AccountLinkingConflict {
 	conflict := &AccountLinkingConflict{
 		Identity:  iden,
@@ -148,15 +200,14 @@ func newAccountLinkingConflictWithIncomingLoginID(iden *identity.Info, cfg *conf
 	return conflict
 }
 
-func linkByIncomingOAuthSpec(
-	ctx context.Context,
+// getAccountLinkingConfigOverride returns the account linking config of the flow object
+// at identificationJSONPointer, or nil if the flow object does not override it.
+func getAccountLinkingConfigOverride(
 	deps *authflow.Dependencies,
 	flows authflow.Flows,
-	userID string,
-	request *CreateIdentityRequestOAuth,
 	identificationJSONPointer jsonpointer.T,
 	originNode authflow.NodeOrIntent,
-) (conflicts []*AccountLinkingConflict, err error) {
+) (*config.AuthenticationFlowAccountLinking, error) {
 	flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, originNode)
 	if err != nil {
 		return nil, err
@@ -167,10 +218,76 @@ func linkByIncomingOAuthSpec(
 		return nil, err
 	}
 
-	var configOverride *config.AuthenticationFlowAccountLinking
 	configOverrider, ok := current.(config.AuthenticationFlowObjectAccountLinkingConfigProvider)
-	if ok {
-		configOverride = configOverrider.GetAccountLinkingConfig()
+	if !ok {
+		return nil, nil
+	}
+	return configOverrider.GetAccountLinkingConfig(), nil
+}
+
+// getAccountLinkingClaimValue returns the value of the incoming claim at pointer.
+// It returns an empty string if the value does not exist or is not a string.
+func getAccountLinkingClaimValue(pointer *config.AccountLinkingJSONPointer, claims map[string]any) string {
+	value, err := pointer.MustGetOneLevelJSONPointerOrPanic().Traverse(claims)
+	if err != nil {
+		return ""
+	}
+
+	valueStr, ok := value.(string)
+	if !ok {
+		return ""
+	}
+
+	return valueStr
+}
+
+// listAccountLinkingConflictIdentities returns the identities of other users
+// whose userProfile claim is value.
+// Identities in conflictedIdentityIDs are excluded, and the returned identities are added to it.
+func listAccountLinkingConflictIdentities(
+	ctx context.Context,
+	deps *authflow.Dependencies,
+	userID string,
+	userProfile *config.AccountLinkingJSONPointer,
+	value string,
+	conflictedIdentityIDs map[string]any,
+) ([]*identity.Info, error) {
+	idens, err := deps.Identities.ListByClaim(ctx, userProfile.MustGetFirstLevelReferenceTokenOrPanic(), value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*identity.Info
+	for _, iden := range idens {
+		// Exclude identities that actually belong to this user.
+		if iden.UserID == userID {
+			continue
+		}
+
+		// Exclude duplicates
+		if _, exist := conflictedIdentityIDs[iden.ID]; exist {
+			continue
+		}
+		conflictedIdentityIDs[iden.ID] = iden.ID
+
+		result = append(result, iden)
+	}
+
+	return result, nil
+}
+
+func linkByIncomingOAuthSpec(
+	ctx context.Context,
+	deps *authflow.Dependencies,
+	flows authflow.Flows,
+	userID string,
+	request *CreateIdentityRequestOAuth,
+	identificationJSONPointer jsonpointer.T,
+	originNode authflow.NodeOrIntent,
+) (conflicts []*AccountLinkingConflict, err error) {
+	configOverride, err := getAccountLinkingConfigOverride(deps, flows, identificationJSONPointer, originNode)
+	if err != nil {
+		return nil, err
 	}
 
 	oauthConfigs, err := resolveAccountLinkingConfigsOAuth(ctx, deps, flows, request)
@@ -182,40 +299,19 @@ func linkByIncomingOAuthSpec(
 	conflictedIdentityIDs := map[string]any{}
 
 	for _, oauthConfig := range oauthConfigs {
-		value, traverseErr := oauthConfig.OAuthClaim.MustGetOneLevelJSONPointerOrPanic().Traverse(request.Spec.OAuth.StandardClaims)
-		if traverseErr != nil {
-			// If we failed to obtain value using the json pointer, just treat it as empty
-			value = ""
-		}
-
-		valueStr, ok := value.(string)
-		if !ok {
-			// If value is not string, treat it as empty
-			valueStr = ""
-		}
+		valueStr := getAccountLinkingClaimValue(oauthConfig.OAuthClaim, request.Spec.OAuth.StandardClaims)
 
 		// If value is empty or doesn't exist, no conflicts should occur
 		if valueStr == "" {
 			continue
 		}
 
-		idenConflicts, err := deps.Identities.ListByClaim(ctx, oauthConfig.UserProfile.MustGetFirstLevelReferenceTokenOrPanic(), valueStr)
+		idenConflicts, err := listAccountLinkingConflictIdentities(ctx, deps, userID, oauthConfig.UserProfile, valueStr, conflictedIdentityIDs)
 		if err != nil {
 			return nil, err
 		}
 
 		for _, iden := range idenConflicts {
-
-			// Exclude identities that actually belong to this user.
-			if iden.UserID == userID {
-				continue
-			}
-
-			// Exclude duplicates
-			if _, exist := conflictedIdentityIDs[iden.ID]; exist {
-				continue
-			}
-			conflictedIdentityIDs[iden.ID] = iden.ID
 			conflict := newAccountLinkingConflictWithIncomingOAuth(iden, oauthConfig, configOverride)
 			conflicts = append(conflicts, conflict)
 		}
@@ -251,22 +347,11 @@ func linkByIncomingLoginIDSpec(
 	identificationJSONPointer jsonpointer.T,
 	originNode authflow.NodeOrIntent,
 ) (conflicts []*AccountLinkingConflict, err error) {
-	flowRootObject, err := findNearestFlowObjectInFlow(deps, flows, originNode)
+	configOverride, err := getAccountLinkingConfigOverride(deps, flows, identificationJSONPointer, originNode)
 	if err != nil {
 		return nil, err
 	}
 
-	current, err := authflow.FlowObject(flowRootObject, identificationJSONPointer)
-	if err != nil {
-		return nil, err
-	}
-
-	var configOverride *config.AuthenticationFlowAccountLinking
-	configOverrider, ok := current.(config.AuthenticationFlowObjectAccountLinkingConfigProvider)
-	if ok {
-		configOverride = configOverrider.GetAccountLinkingConfig()
-	}
-
 	loginIDConfigs, err := resolveAccountLinkingConfigsLoginID(ctx, deps, flows, request)
 	if err != nil {
 		return nil, err
@@ -282,26 +367,12 @@ func linkByIncomingLoginIDSpec(
 
 	for _, loginIDConfig := range loginIDConfigs {
 
-		idenConflicts, err := deps.Identities.ListByClaim(ctx,
-			loginIDConfig.UserProfile.MustGetFirstLevelReferenceTokenOrPanic(),
-			normalizedValue,
-		)
+		idenConflicts, err := listAccountLinkingConflictIdentities(ctx, deps, userID, loginIDConfig.UserProfile, normalizedValue, conflictedIdentityIDs)
 		if err != nil {
 			return nil, err
 		}
 
 		for _, iden := range idenConflicts {
-
-			// Exclude identities that actually belong to this user.
-			if iden.UserID == userID {
-				continue
-			}
-
-			// Exclude duplicates
-			if _, exist := conflictedIdentityIDs[iden.ID]; exist {
-				continue
-			}
-			conflictedIdentityIDs[iden.ID] = iden.ID
 			conflict := newAccountLinkingConflictWithIncomingLoginID(iden, loginIDConfig, configOverride)
 			conflicts = append(conflicts, conflict)
 		}
@@ -350,41 +421,19 @@ func linkByIncomingLDAPSpec(
 	conflictedIdentityIDs := map[string]any{}
 
 	for _, ldapConfig := range ldapConfigs {
-		value, traverseErr := ldapConfig.AttributeName.MustGetOneLevelJSONPointerOrPanic().Traverse(request.Spec.LDAP.Claims)
-		if traverseErr != nil {
-			// If we failed to obtain value using the json pointer, just treat it as empty
-			value = ""
-		}
-
-		valueStr, ok := value.(string)
-		if !ok {
-			// If value is not string, treat it as empty
-			valueStr = ""
-		}
+		valueStr := getAccountLinkingClaimValue(ldapConfig.AttributeName, request.Spec.LDAP.Claims)
 
 		// If value is empty or doesn't exist, no conflicts should occur
 		if valueStr == "" {
 			continue
 		}
 
-		idenConflicts, err := deps.Identities.ListByClaim(ctx, ldapConfig.UserProfile.MustGetFirstLevelReferenceTokenOrPanic(), valueStr)
+		idenConflicts, err := listAccountLinkingConflictIdentities(ctx, deps, userID, ldapConfig.UserProfile, valueStr, conflictedIdentityIDs)
 		if err != nil {
 			return nil, err
 		}
 
 		for _, iden := range idenConflicts {
-
-			// Exclude identities that actually belong to this user.
-			if iden.UserID == userID {
-				continue
-			}
-
-			// Exclude duplicates
-			if _, exist := conflictedIdentityIDs[iden.ID]; exist {
-				continue
-			}
-			conflictedIdentityIDs[iden.ID] = iden.ID
-
 			conflict := &AccountLinkingConflict{
 				Identity:  iden,
 				Action:    ldapConfig.Action,
@@ -419,12 +468,75 @@ func linkByIncomingLDAPSpec(
 	return conflicts, nil
 }
 
+func linkByIncomingSAMLSpec(
+	ctx context.Context,
+	deps *authflow.Dependencies,
+	flows authflow.Flows,
+	userID string,
+	request *CreateIdentityRequestSAML,
+	identificationJSONPointer jsonpointer.T,
+	originNode authflow.NodeOrIntent,
+) (conflicts []*AccountLinkingConflict, err error) {
+	configOverride, err := getAccountLinkingConfigOverride(deps, flows, identificationJSONPointer, originNode)
+	if err != nil {
+		return nil, err
+	}
+
+	samlConfigs, err := resolveAccountLinkingConfigsSAML(ctx, deps, flows, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// For deduplication
+	conflictedIdentityIDs := map[string]any{}
+
+	for _, samlConfig := range samlConfigs {
+		valueStr := getAccountLinkingClaimValue(samlConfig.SAMLClaim, request.Spec.SAML.Claims)
+
+		// If value is empty or doesn't exist, no conflicts should occur
+		if valueStr == "" {
+			continue
+		}
+
+		idenConflicts, err := listAccountLinkingConflictIdentities(ctx, deps, userID, samlConfig.UserProfile, valueStr, conflictedIdentityIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, iden := range idenConflicts {
+			conflict := newAccountLinkingConflictWithIncomingSAML(iden, samlConfig, configOverride)
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	// check for identical identities
+	for _, conflict := range conflicts {
+		if conflict.Identity.Type != model.IdentityTypeSAML {
+			// Not the same type, so must be not identical
+			continue
+		}
+		if conflict.Identity.SAML.IdentityProviderEntityID != request.Spec.SAML.IdentityProviderEntityID {
+			// Not the same identity provider
+			continue
+		}
+		if conflict.Identity.SAML.NameID == request.Spec.SAML.NameID {
+			// The identity is identical, throw error directly
+			spec := request.Spec
+			otherSpec := conflict.Identity.ToSpec()
+			return nil, identity.NewErrDuplicatedIdentity(spec, &otherSpec)
+		}
+	}
+
+	return conflicts, nil
+}
+
 type CreateIdentityRequest struct {
 	Type model.IdentityType `json:"type,omitempty"`
 
 	LoginID *CreateIdentityRequestLoginID `json:"login_id,omitempty"`
 	OAuth   *CreateIdentityRequestOAuth   `json:"oauth,omitempty"`
 	LDAP    *CreateIdentityRequestLDAP    `json:"ldap,omitempty"`
+	SAML    *CreateIdentityRequestSAML    `json:"saml,omitempty"`
 }
 
 type CreateIdentityRequestOAuth struct {
@@ -439,6 +551,10 @@ type CreateIdentityRequestLDAP struct {
 	Spec *identity.Spec `json:"spec,omitempty"`
 }
 
+type CreateIdentityRequestSAML struct {
+	Spec *identity.Spec `json:"spec,omitempty"`
+}
+
 func NewCreateOAuthIdentityRequest(spec *identity.Spec) *CreateIdentityRequest {
 	return &CreateIdentityRequest{
 		Type: model.IdentityTypeOAuth,
@@ -465,3 +581,12 @@ func NewCreateLDAPIdentityRequest(spec *identity.Spec) *CreateIdentityRequest {
 		},
 	}
 }
+
+func NewCreateSAMLIdentityRequest(spec *identity.Spec) *CreateIdentityRequest {
+	return &CreateIdentityRequest{
+		Type: model.IdentityTypeSAML,
+		SAML: &CreateIdentityRequestSAML{
+			Spec: spec,
+		},
+	}
+}
@@ -19,8 +19,10 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/wechat"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/uiparam"
+	"github.com/authgear/authgear-server/pkg/util/base32"
 	"github.com/authgear/authgear-server/pkg/util/errorutil"
 	"github.com/authgear/authgear-server/pkg/util/phone"
+	"github.com/authgear/authgear-server/pkg/util/rand"
 	"github.com/authgear/authgear-server/pkg/util/setutil"
 	"github.com/authgear/authgear-server/pkg/util/slice"
 	"github.com/authgear/authgear-server/pkg/util/stringutil"
@@ -424,6 +426,7 @@ func getAuthenticationOptionsForLogin(ctx context.Context, deps *authflow.Depend
 		case model.AuthenticationFlowAuthenticationPrimaryOOBOTPEmail:
 			fallthrough
 		case model.AuthenticationFlowAuthenticationPrimaryOOBOTPSMS:
+			branchOptionsStart := len(options)
 			if targetStepName := branch.TargetStep; targetStepName != "" {
 				info, err := findIdentity(targetStepName)
 				if err != nil {
@@ -440,6 +443,12 @@ func getAuthenticationOptionsForLogin(ctx context.Context, deps *authflow.Depend
 					return nil, false, err
 				}
 			}
+			// otp_form of the branch takes precedence over email_otp_mode.
+			if branch.OTPForm != "" {
+				for idx := branchOptionsStart; idx < len(options); idx++ {
+					options[idx].OTPForm = otp.Form(branch.OTPForm)
+				}
+			}
 		case model.AuthenticationFlowAuthenticationSecondaryOOBOTPEmail:
 			fallthrough
 		case model.AuthenticationFlowAuthenticationSecondaryOOBOTPSMS:
@@ -764,6 +773,15 @@ func findExactOneIdentityInfo(ctx context.Context, deps *authflow.Dependencies,
 type HandleOAuthAuthorizationResponseOptions struct {
 	Alias       string
 	RedirectURI string
+	Nonce       string
+}
+
+// newOAuthNonce returns the nonce of the authorization request.
+// It is kept in the node, instead of in cookies like in the interaction,
+// because cookies are not sent in Safari in third-party context.
+// The nonce is also the key of the PKCE code verifier of the generic providers.
+func newOAuthNonce() string {
+	return rand.StringWithAlphabet(32, base32.Alphabet, rand.SecureRand)
 }
 
 func handleOAuthAuthorizationResponse(ctx context.Context, deps *authflow.Dependencies, opts HandleOAuthAuthorizationResponseOptions, inputOAuth inputTakeOAuthAuthorizationResponse) (*identity.Spec, error) {
@@ -772,16 +790,12 @@ func handleOAuthAuthorizationResponse(ctx context.Context, deps *authflow.Depend
 		return nil, err
 	}
 
-	// TODO(authflow): support nonce but do not save nonce in cookies.
-	// Nonce in the current implementation is stored in cookies.
-	// In the Authentication Flow API, cookies are not sent in Safari in third-party context.
-	emptyNonce := ""
 	authInfo, err := deps.OAuthProviderFactory.GetUserProfile(ctx,
 		opts.Alias,
 		oauthrelyingparty.GetUserProfileOptions{
 			Query:       inputOAuth.GetQuery(),
 			RedirectURI: opts.RedirectURI,
-			Nonce:       emptyNonce,
+			Nonce:       opts.Nonce,
 		},
 	)
 	if err != nil {
@@ -800,6 +814,7 @@ type GetOAuthDataOptions struct {
 	RedirectURI  string
 	Alias        string
 	ResponseMode string
+	Nonce        string
 }
 
 func getOAuthData(ctx context.Context, deps *authflow.Dependencies, opts GetOAuthDataOptions) (data OAuthData, err error) {
@@ -814,6 +829,7 @@ func getOAuthData(ctx context.Context, deps *authflow.Dependencies, opts GetOAut
 		RedirectURI:  opts.RedirectURI,
 		ResponseMode: opts.ResponseMode,
 		Prompt:       uiParam.Prompt,
+		Nonce:        opts.Nonce,
 	}
 
 	authorizationURL, err := deps.OAuthProviderFactory.GetAuthorizationURL(ctx, opts.Alias, param)
@@ -834,6 +850,48 @@ func getOAuthData(ctx context.Context, deps *authflow.Dependencies, opts GetOAut
 	return
 }
 
+type HandleSAMLResponseOptions struct {
+	IdentityProviderName string
+	AuthnRequestID       string
+}
+
+func handleSAMLResponse(ctx context.Context, deps *authflow.Dependencies, opts HandleSAMLResponseOptions, inputSAML inputTakeSAMLResponse) (*identity.Spec, error) {
+	idpConfig, ok := deps.Config.Identity.SAML.GetIdentityProviderConfig(opts.IdentityProviderName)
+	if !ok {
+		return nil, api.ErrSAMLIdentityProviderNotFound
+	}
+
+	assertion, err := deps.SAMLSP.ParseResponse(opts.IdentityProviderName, opts.AuthnRequestID, inputSAML.GetSAMLResponse())
+	if err != nil {
+		return nil, err
+	}
+
+	return deps.SAML.MakeSpecFromAssertion(ctx,
+		idpConfig,
+		assertion.NameID,
+		assertion.NameIDFormat,
+		assertion.Attributes,
+	)
+}
+
+type GetSAMLDataOptions struct {
+	IdentityProviderName string
+	AuthnRequestID       string
+}
+
+func getSAMLData(ctx context.Context, deps *authflow.Dependencies, opts GetSAMLDataOptions) (data SAMLData, err error) {
+	authnRequestURL, err := deps.SAMLSP.MakeAuthnRequestURL(opts.IdentityProviderName, opts.AuthnRequestID)
+	if err != nil {
+		return
+	}
+
+	data = NewSAMLData(SAMLData{
+		IdentityProviderName: opts.IdentityProviderName,
+		AuthnRequestURL:      authnRequestURL.String(),
+	})
+	return
+}
+
 func getMaskedOTPTarget(claimName model.ClaimName, claimValue string) string {
 	switch claimName {
 	case model.ClaimEmail:
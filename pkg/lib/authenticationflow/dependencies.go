@@ -3,6 +3,7 @@ package authenticationflow
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwt"
@@ -30,7 +31,9 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/fraudprotection"
 	"github.com/authgear/authgear-server/pkg/lib/ldap"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlsp"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/util/accesscontrol"
@@ -69,6 +72,7 @@ type OTPCodeService interface {
 	InspectState(ctx context.Context, kind otp.Kind, target string, opts *otp.InspectStateOptions) (*otp.State, error)
 
 	LookupCode(ctx context.Context, purpose otp.Purpose, code string) (target string, err error)
+	InspectCode(ctx context.Context, purpose otp.Purpose, target string) (*otp.Code, error)
 	SetSubmittedCode(ctx context.Context, kind otp.Kind, target string, code string) (*otp.State, error)
 }
 
@@ -144,10 +148,14 @@ type IDPSessionService interface {
 	MakeSession(*session.Attrs) (*idpsession.IDPSession, string)
 	Create(ctx context.Context, s *idpsession.IDPSession) error
 	Reauthenticate(ctx context.Context, idpSessionID string, amr []string) error
+	ListActive(ctx context.Context, userID string) ([]*idpsession.IDPSession, error)
+	ListExcessSessions(ctx context.Context, userID string, excludeSessionID string) ([]*idpsession.IDPSession, error)
+	ListSessionsToEnd(ctx context.Context, userID string, excludeSessionID string, endOthers bool) ([]*idpsession.IDPSession, error)
 }
 
 type SessionService interface {
 	RevokeWithoutEvent(ctx context.Context, s session.SessionBase) error
+	RevokeWithEvent(ctx context.Context, s session.SessionBase, isTermination bool, isAdminAPI bool) error
 }
 
 type StdAttrsService interface {
@@ -181,6 +189,10 @@ type CaptchaService interface {
 	VerifyToken(ctx context.Context, token string) error
 }
 
+type RiskService interface {
+	Assess(ctx context.Context, userID string) (model.RiskDecision, error)
+}
+
 type ChallengeService interface {
 	Consume(ctx context.Context, token string) (*challenge.Purpose, error)
 	Get(ctx context.Context, token string) (*challenge.Challenge, error)
@@ -244,10 +256,32 @@ type LDAPClientFactory interface {
 	MakeClient(serverConfig *config.LDAPServerConfig) *ldap.Client
 }
 
+type LDAPGroupSyncService interface {
+	LDAPGroups(iden *identity.LDAP, searcher *ldap.GroupSearcher) ([]string, error)
+	ApplyLDAPGroups(ctx context.Context, iden *identity.LDAP, ldapGroups []string, isDirectorySync bool) error
+}
+
+type SAMLService interface {
+	MakeSpecFromAssertion(ctx context.Context, idpConfig *config.SAMLIdentityProviderConfig, nameID string, nameIDFormat string, attributes map[string][]string) (*identity.Spec, error)
+}
+
+type SAMLSPService interface {
+	MakeAuthnRequestURL(identityProviderName string, authnRequestID string) (*url.URL, error)
+	ParseResponse(identityProviderName string, authnRequestID string, samlResponse string) (*samlsp.Assertion, error)
+}
+
 type UserFacade interface {
 	GetUserIDsByLoginIDLoginHint(ctx context.Context, hint *oauth.LoginHint) ([]string, error)
 }
 
+type OrganizationQueries interface {
+	ListOrganizationsByUserID(ctx context.Context, userID string) ([]*model.Organization, error)
+}
+
+type OrganizationCommands interface {
+	AcceptPendingInvitations(ctx context.Context, userID string, emails []string) ([]*organization.AcceptedInvitation, error)
+}
+
 type Dependencies struct {
 	Config                  *config.AppConfig
 	FeatureConfig           *config.FeatureConfig
@@ -278,6 +312,7 @@ type Dependencies struct {
 	Captcha                         CaptchaService
 	BotProtection                   BotProtectionService
 	FraudProtection                 *fraudprotection.Service
+	Risk                            RiskService
 	OAuthProviderFactory            OAuthProviderFactory
 	PasskeyRequestOptionsService    PasskeyRequestOptionsService
 	PasskeyCreationOptionsService   PasskeyCreationOptionsService
@@ -286,6 +321,9 @@ type Dependencies struct {
 	LoginIDs                        LoginIDService
 	LDAP                            LDAPService
 	LDAPClientFactory               LDAPClientFactory
+	LDAPGroupSync                   LDAPGroupSyncService
+	SAML                            SAMLService
+	SAMLSP                          SAMLSPService
 
 	IDPSessions          IDPSessionService
 	Sessions             SessionService
@@ -295,6 +333,9 @@ type Dependencies struct {
 
 	UserFacade UserFacade
 
+	OrganizationQueries  OrganizationQueries
+	OrganizationCommands OrganizationCommands
+
 	Cookies CookieManager
 
 	Events      EventService
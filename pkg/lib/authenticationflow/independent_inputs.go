@@ -8,6 +8,7 @@ import (
 )
 
 var InputTakeAccountRecoveryCodeSchemaBuilder validation.SchemaBuilder
+var InputTakeMagicLinkCodeSchemaBuilder validation.SchemaBuilder
 
 func init() {
 	InputTakeAccountRecoveryCodeSchemaBuilder = validation.SchemaBuilder{}.
@@ -18,6 +19,15 @@ func init() {
 		"account_recovery_code",
 		validation.SchemaBuilder{}.Type(validation.TypeString),
 	)
+
+	InputTakeMagicLinkCodeSchemaBuilder = validation.SchemaBuilder{}.
+		Type(validation.TypeObject).
+		Required("magic_link_code")
+
+	InputTakeMagicLinkCodeSchemaBuilder.Properties().Property(
+		"magic_link_code",
+		validation.SchemaBuilder{}.Type(validation.TypeString),
+	)
 }
 
 type InputTakeAccountRecoveryCode struct {
@@ -32,3 +42,16 @@ func MakeInputTakeAccountRecoveryCode(ctx context.Context, rawMessage json.RawMe
 	}
 	return &input, true
 }
+
+type InputTakeMagicLinkCode struct {
+	MagicLinkCode string `json:"magic_link_code"`
+}
+
+func MakeInputTakeMagicLinkCode(ctx context.Context, rawMessage json.RawMessage) (*InputTakeMagicLinkCode, bool) {
+	var input InputTakeMagicLinkCode
+	err := InputTakeMagicLinkCodeSchemaBuilder.ToSimpleSchema().Validator().ParseJSONRawMessage(ctx, rawMessage, &input)
+	if err != nil {
+		return nil, false
+	}
+	return &input, true
+}
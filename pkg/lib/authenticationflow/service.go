@@ -9,6 +9,7 @@ import (
 	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
 
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/authn/otp"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/oauthsession"
 	"github.com/authgear/authgear-server/pkg/lib/otelauthgear"
@@ -49,6 +50,7 @@ type Store interface {
 
 	CreateFlow(ctx context.Context, flow *Flow) error
 	GetFlowByStateToken(ctx context.Context, stateToken string) (*Flow, error)
+	GetLatestFlowByFlowID(ctx context.Context, flowID string) (*Flow, error)
 	DeleteFlow(ctx context.Context, flow *Flow) error
 }
 
@@ -715,6 +717,27 @@ func (s *Service) resolveStateTokenFromInput(ctx context.Context, inputRawMessag
 		return newFlowOutput.Flow.StateToken, nil
 
 	}
+	if input, ok := MakeInputTakeMagicLinkCode(ctx, inputRawMessage); ok {
+		// The magic link is bound to the flow which sent it.
+		// The flow is resumed at its latest state, so that it can be completed
+		// on the device where the link is opened.
+		target, err := s.Deps.OTPCodes.LookupCode(ctx, otp.PurposeOOBOTP, input.MagicLinkCode)
+		if err != nil {
+			return "", err
+		}
+		code, err := s.Deps.OTPCodes.InspectCode(ctx, otp.PurposeOOBOTP, target)
+		if err != nil {
+			return "", err
+		}
+		if code.Form != otp.FormMagicLink || code.AuthenticationFlowID == "" {
+			return "", otp.ErrInvalidCode
+		}
+		flow, err := s.Store.GetLatestFlowByFlowID(ctx, code.AuthenticationFlowID)
+		if err != nil {
+			return "", err
+		}
+		return flow.StateToken, nil
+	}
 	return "", ErrFlowNotFound
 }
 
@@ -108,6 +108,21 @@ func (mr *MockStoreMockRecorder) GetFlowByStateToken(ctx, stateToken interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlowByStateToken", reflect.TypeOf((*MockStore)(nil).GetFlowByStateToken), ctx, stateToken)
 }
 
+// GetLatestFlowByFlowID mocks base method.
+func (m *MockStore) GetLatestFlowByFlowID(ctx context.Context, flowID string) (*Flow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestFlowByFlowID", ctx, flowID)
+	ret0, _ := ret[0].(*Flow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestFlowByFlowID indicates an expected call of GetLatestFlowByFlowID.
+func (mr *MockStoreMockRecorder) GetLatestFlowByFlowID(ctx, flowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestFlowByFlowID", reflect.TypeOf((*MockStore)(nil).GetLatestFlowByFlowID), ctx, flowID)
+}
+
 // GetSession mocks base method.
 func (m *MockStore) GetSession(ctx context.Context, flowID string) (*Session, error) {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ import (
 	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/authgear/authgear-server/pkg/lib/authn/otp"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
 	"github.com/authgear/authgear-server/pkg/lib/uiparam"
@@ -452,3 +453,86 @@ func TestServiceContext(t *testing.T) {
 		})
 	})
 }
+
+type magicLinkOTPCodeService struct {
+	OTPCodeService
+	Codes map[string]*otp.Code
+}
+
+func (s *magicLinkOTPCodeService) LookupCode(ctx context.Context, purpose otp.Purpose, code string) (string, error) {
+	for target, c := range s.Codes {
+		if c.Code == code {
+			return target, nil
+		}
+	}
+	return "", otp.ErrInvalidCode
+}
+
+func (s *magicLinkOTPCodeService) InspectCode(ctx context.Context, purpose otp.Purpose, target string) (*otp.Code, error) {
+	c, ok := s.Codes[target]
+	if !ok {
+		return nil, otp.ErrCodeNotFound
+	}
+	return c, nil
+}
+
+func TestServiceResolveStateTokenFromInput(t *testing.T) {
+	Convey("Service resolveStateTokenFromInput", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+		otpCodes := &magicLinkOTPCodeService{
+			Codes: map[string]*otp.Code{
+				"user@example.com": {
+					Target:               "user@example.com",
+					Purpose:              otp.PurposeOOBOTP,
+					Form:                 otp.FormMagicLink,
+					Code:                 "magic",
+					AuthenticationFlowID: "authflow_1",
+				},
+				"other@example.com": {
+					Target:               "other@example.com",
+					Purpose:              otp.PurposeOOBOTP,
+					Form:                 otp.FormLink,
+					Code:                 "link",
+					AuthenticationFlowID: "authflow_2",
+				},
+			},
+		}
+		store := NewMockStore(ctrl)
+
+		service := &Service{
+			Deps: &Dependencies{
+				OTPCodes: otpCodes,
+			},
+			Store: store,
+		}
+
+		Convey("resolve the latest state of the flow bound to the magic link", func() {
+			store.EXPECT().GetLatestFlowByFlowID(gomock.Any(), "authflow_1").Return(&Flow{
+				FlowID:     "authflow_1",
+				StateToken: "authflowstate_latest",
+			}, nil)
+
+			stateToken, err := service.resolveStateTokenFromInput(ctx, json.RawMessage(`{"magic_link_code": "magic"}`))
+			So(err, ShouldBeNil)
+			So(stateToken, ShouldEqual, "authflowstate_latest")
+		})
+
+		Convey("reject login link", func() {
+			_, err := service.resolveStateTokenFromInput(ctx, json.RawMessage(`{"magic_link_code": "link"}`))
+			So(errors.Is(err, otp.ErrInvalidCode), ShouldBeTrue)
+		})
+
+		Convey("reject unknown code", func() {
+			_, err := service.resolveStateTokenFromInput(ctx, json.RawMessage(`{"magic_link_code": "unknown"}`))
+			So(errors.Is(err, otp.ErrInvalidCode), ShouldBeTrue)
+		})
+
+		Convey("return flow not found for other input", func() {
+			_, err := service.resolveStateTokenFromInput(ctx, json.RawMessage(`{"code": "magic"}`))
+			So(errors.Is(err, ErrFlowNotFound), ShouldBeTrue)
+		})
+	})
+}
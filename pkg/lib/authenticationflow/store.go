@@ -32,7 +32,8 @@ func (s *StoreImpl) CreateFlow(ctx context.Context, flow *Flow) error {
 		stateKey := redisFlowStateKey(s.AppID, flow.StateToken)
 		ttl := Lifetime
 
-		_, err := conn.SetEx(ctx, flowKey, []byte(flowKey), ttl).Result()
+		// The flow key stores the latest state token of the flow.
+		_, err := conn.SetEx(ctx, flowKey, []byte(flow.StateToken), ttl).Result()
 		if err != nil {
 			return err
 		}
@@ -77,6 +78,24 @@ func (s *StoreImpl) GetFlowByStateToken(ctx context.Context, stateToken string)
 	return &flow, err
 }
 
+func (s *StoreImpl) GetLatestFlowByFlowID(ctx context.Context, flowID string) (*Flow, error) {
+	flowKey := redisFlowKey(s.AppID, flowID)
+	var stateToken string
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		var err error
+		stateToken, err = conn.Get(ctx, flowKey).Result()
+		if errors.Is(err, goredis.Nil) {
+			return ErrFlowNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetFlowByStateToken(ctx, stateToken)
+}
+
 func (s *StoreImpl) DeleteFlow(ctx context.Context, flow *Flow) error {
 	// We do not delete the states because there are many of them.
 	// Deleting the flowID is enough to make GetFlowByStateToken to return ErrFlowNotFound.
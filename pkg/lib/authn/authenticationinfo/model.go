@@ -34,6 +34,9 @@ type T struct {
 	AuthenticatedBySessionType string
 	AuthenticatedBySessionID   string
 
+	// OrganizationID is the organization selected in the select_organization step.
+	OrganizationID string `json:"organization_id,omitempty"`
+
 	IdentitySpecs []*identity.Spec `json:"identity_specs,omitzero"`
 }
 
@@ -7,22 +7,37 @@ import (
 type MigrateSpec struct {
 	Type model.AuthenticatorType `json:"type,omitempty"`
 
-	OOBOTP *OOBOTPMigrateSpec `json:"oobotp,omitempty"`
+	OOBOTP   *OOBOTPMigrateSpec   `json:"oobotp,omitempty"`
+	Password *PasswordMigrateSpec `json:"password,omitempty"`
 }
 
 func (s *MigrateSpec) GetSpec() *Spec {
-	return &Spec{
+	spec := &Spec{
 		Type: s.Type,
 		// Support migrate primary authenticator only
 		Kind: KindPrimary,
-		OOBOTP: &OOBOTPSpec{
+	}
+
+	switch s.Type {
+	case model.AuthenticatorTypePassword:
+		spec.Password = &PasswordSpec{
+			PasswordHash: s.Password.PasswordHash,
+		}
+	default:
+		spec.OOBOTP = &OOBOTPSpec{
 			Email: s.OOBOTP.Email,
 			Phone: s.OOBOTP.Phone,
-		},
+		}
 	}
+
+	return spec
 }
 
 type OOBOTPMigrateSpec struct {
 	Email string `json:"email,omitempty"`
 	Phone string `json:"phone,omitempty"`
 }
+
+type PasswordMigrateSpec struct {
+	PasswordHash string `json:"password_hash,omitempty"`
+}
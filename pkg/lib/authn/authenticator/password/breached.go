@@ -0,0 +1,113 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+var BreachedPasswordLogger = slogutil.NewLogger("breached-password")
+
+// pwnedPasswordsPrefixLength is the length of the hash prefix sent to the range API.
+const pwnedPasswordsPrefixLength = 5
+
+type BreachedPasswordHTTPClient struct {
+	*http.Client
+}
+
+func NewBreachedPasswordHTTPClient() BreachedPasswordHTTPClient {
+	return BreachedPasswordHTTPClient{
+		httputil.NewExternalClient(5 * time.Second),
+	}
+}
+
+// BreachedPasswordService looks up a password in the Pwned Passwords dataset.
+// It uses the k-anonymity model, so the password and its full hash never leave the server.
+// See https://haveibeenpwned.com/API/v3#PwnedPasswords
+type BreachedPasswordService struct {
+	EnvConfig  config.PwnedPasswordsEnvironmentConfig
+	HTTPClient BreachedPasswordHTTPClient
+}
+
+func (s *BreachedPasswordService) IsBreached(ctx context.Context, password string) (bool, error) {
+	prefix, suffix := pwnedPasswordsHashRange(password)
+
+	r, err := s.openRange(ctx, prefix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	return findPwnedPasswordsSuffix(r, suffix)
+}
+
+func (s *BreachedPasswordService) openRange(ctx context.Context, prefix string) (io.ReadCloser, error) {
+	if s.EnvConfig.Directory != "" {
+		return os.Open(filepath.Join(s.EnvConfig.Directory, prefix+".txt"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", s.EnvConfig.RangeAPIEndpoint+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Padding prevents the size of the response from revealing the prefix.
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("breached password: unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// pwnedPasswordsHashRange returns the prefix and the suffix of the uppercase hex SHA-1 hash of password.
+func pwnedPasswordsHashRange(password string) (prefix string, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	h := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return h[:pwnedPasswordsPrefixLength], h[pwnedPasswordsPrefixLength:]
+}
+
+// findPwnedPasswordsSuffix scans lines in the format of SUFFIX:COUNT.
+// Padding entries have a count of 0 and are ignored.
+func findPwnedPasswordsSuffix(r io.Reader, suffix string) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		s, countStr, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(s, suffix) {
+			continue
+		}
+		count, err := strconv.Atoi(countStr)
+		if err != nil {
+			return false, fmt.Errorf("breached password: invalid count %q", countStr)
+		}
+		return count > 0, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+	return false, nil
+}
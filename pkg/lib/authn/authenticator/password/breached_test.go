@@ -0,0 +1,94 @@
+package password
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func TestBreachedPasswordService(t *testing.T) {
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+	rangeContent := strings.Join([]string{
+		"003D68EB55068C33ACE09247EE4C639306B:3",
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365",
+		"2DC2FA3E7F1C8F5A1C7B9E6B5B3E2F1A0A9:0",
+	}, "\r\n")
+
+	Convey("pwnedPasswordsHashRange", t, func() {
+		prefix, suffix := pwnedPasswordsHashRange("password")
+		So(prefix, ShouldEqual, "5BAA6")
+		So(suffix, ShouldEqual, "1E4C9B93F3F0682250B6CF8331B7EE68FD8")
+	})
+
+	Convey("findPwnedPasswordsSuffix", t, func() {
+		found, err := findPwnedPasswordsSuffix(strings.NewReader(rangeContent), "1E4C9B93F3F0682250B6CF8331B7EE68FD8")
+		So(err, ShouldBeNil)
+		So(found, ShouldBeTrue)
+
+		found, err = findPwnedPasswordsSuffix(strings.NewReader(rangeContent), "1e4c9b93f3f0682250b6cf8331b7ee68fd8")
+		So(err, ShouldBeNil)
+		So(found, ShouldBeTrue)
+
+		// Padding entries are ignored.
+		found, err = findPwnedPasswordsSuffix(strings.NewReader(rangeContent), "2DC2FA3E7F1C8F5A1C7B9E6B5B3E2F1A0A9")
+		So(err, ShouldBeNil)
+		So(found, ShouldBeFalse)
+
+		found, err = findPwnedPasswordsSuffix(strings.NewReader(rangeContent), "0000000000000000000000000000000000")
+		So(err, ShouldBeNil)
+		So(found, ShouldBeFalse)
+	})
+
+	Convey("BreachedPasswordService with range API", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/range/5BAA6" || r.Header.Get("Add-Padding") != "true" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(rangeContent))
+		}))
+		defer server.Close()
+
+		s := &BreachedPasswordService{
+			EnvConfig: config.PwnedPasswordsEnvironmentConfig{
+				RangeAPIEndpoint: server.URL + "/range/",
+			},
+			HTTPClient: BreachedPasswordHTTPClient{server.Client()},
+		}
+
+		breached, err := s.IsBreached(context.Background(), "password")
+		So(err, ShouldBeNil)
+		So(breached, ShouldBeTrue)
+
+		_, err = s.IsBreached(context.Background(), "a_good_password")
+		So(err, ShouldBeError, "breached password: unexpected status code 404")
+	})
+
+	Convey("BreachedPasswordService with directory", t, func() {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(rangeContent), 0600)
+		So(err, ShouldBeNil)
+
+		s := &BreachedPasswordService{
+			EnvConfig: config.PwnedPasswordsEnvironmentConfig{
+				Directory: dir,
+			},
+		}
+
+		breached, err := s.IsBreached(context.Background(), "password")
+		So(err, ShouldBeNil)
+		So(breached, ShouldBeTrue)
+
+		breached, err = s.IsBreached(context.Background(), "a_good_password")
+		So(err, ShouldBeNil)
+		So(breached, ShouldBeFalse)
+	})
+}
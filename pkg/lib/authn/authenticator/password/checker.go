@@ -117,6 +117,10 @@ type CheckerHistoryStore interface {
 	GetPasswordHistory(ctx context.Context, userID string, historySize int, historyDays config.DurationDays) ([]History, error)
 }
 
+type CheckerBreachedPasswordService interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
 type Checker struct {
 	PwMinLength            int
 	PwUppercaseRequired    bool
@@ -130,6 +134,10 @@ type Checker struct {
 	PwHistoryDays          config.DurationDays
 	PasswordHistoryEnabled bool
 	PasswordHistoryStore   CheckerHistoryStore
+
+	BreachedPasswordCheckEnabled    bool
+	BreachedPasswordCheckFailClosed bool
+	BreachedPasswords               CheckerBreachedPasswordService
 }
 
 func (pc *Checker) policyPasswordLength() Policy {
@@ -247,6 +255,28 @@ func (pc *Checker) checkPasswordHistory(ctx context.Context, password, authID st
 	return nil, nil
 }
 
+func (pc *Checker) checkPasswordBreached(ctx context.Context, password string) (*Policy, error) {
+	if !pc.BreachedPasswordCheckEnabled {
+		return nil, nil
+	}
+
+	breached, err := pc.BreachedPasswords.IsBreached(ctx, password)
+	if err != nil {
+		logger := BreachedPasswordLogger.GetLogger(ctx)
+		logger.WithError(err).Warn(ctx, "failed to check breached password")
+		if pc.BreachedPasswordCheckFailClosed {
+			return nil, BreachedPasswordCheckUnavailable.New("breached password check is unavailable")
+		}
+		// Otherwise, do not block the user when the dataset is unavailable.
+		return nil, nil
+	}
+
+	if breached {
+		return &Policy{Name: PasswordBreached}, nil
+	}
+	return nil, nil
+}
+
 func (pc *Checker) checkCommonPolicies(plainPassword string) []apierrors.Cause {
 	var violations []apierrors.Cause
 	check := func(v *Policy) {
@@ -281,6 +311,14 @@ func (pc *Checker) ValidateNewPassword(ctx context.Context, userID string, plain
 		violations = append(violations, p)
 	}
 
+	p, err = pc.checkPasswordBreached(ctx, plainPassword)
+	if err != nil {
+		return err
+	}
+	if p != nil {
+		violations = append(violations, p)
+	}
+
 	if len(violations) == 0 {
 		return nil
 	}
@@ -353,6 +391,9 @@ func (pc *Checker) PasswordPolicy() (out []Policy) {
 	if pc.shouldCheckPasswordHistory() {
 		out = append(out, pc.policyPasswordHistory())
 	}
+	if pc.BreachedPasswordCheckEnabled {
+		out = append(out, Policy{Name: PasswordBreached})
+	}
 	if out == nil {
 		out = []Policy{}
 	}
@@ -3,6 +3,7 @@ package password
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -429,6 +430,55 @@ func TestValidateNewPassword(t *testing.T) {
 
 		test(pc, authID, "coffee", "")
 	})
+
+	Convey("validate breached password", t, func() {
+		pc := &Checker{
+			BreachedPasswordCheckEnabled: true,
+			BreachedPasswords: mockBreachedPasswordService{
+				"password": true,
+			},
+		}
+
+		test(pc, authID, "password", `
+		{
+			"name": "Invalid",
+			"reason": "PasswordPolicyViolated",
+			"message": "password policy violated",
+			"code": 400,
+			"info": {
+				"causes": [
+					{
+						"Name": "PasswordBreached"
+					}
+				]
+			}
+		}
+		`)
+
+		test(pc, authID, "a_good_password", "")
+
+		test(pc, authID, "unavailable", "")
+
+		pc.BreachedPasswordCheckFailClosed = true
+		test(pc, authID, "unavailable", `
+		{
+			"name": "ServiceUnavailable",
+			"reason": "BreachedPasswordCheckUnavailable",
+			"message": "breached password check is unavailable",
+			"code": 503
+		}
+		`)
+		test(pc, authID, "a_good_password", "")
+	})
+}
+
+type mockBreachedPasswordService map[string]bool
+
+func (s mockBreachedPasswordService) IsBreached(ctx context.Context, password string) (bool, error) {
+	if password == "unavailable" {
+		return false, errors.New("dataset is unavailable")
+	}
+	return s[password], nil
 }
 
 func TestPasswordPolicy(t *testing.T) {
@@ -12,9 +12,11 @@ func ProvideChecker(
 	cfg *config.AuthenticatorPasswordConfig,
 	featureCfg *config.AuthenticatorFeatureConfig,
 	s CheckerHistoryStore,
+	b CheckerBreachedPasswordService,
 ) *Checker {
 	checker := &Checker{
 		PasswordHistoryStore: s,
+		BreachedPasswords:    b,
 	}
 	checker.PwMinLength = *cfg.Policy.MinLength
 	checker.PwUppercaseRequired = cfg.Policy.UppercaseRequired
@@ -34,6 +36,10 @@ func ProvideChecker(
 	if !*featureCfg.Password.Policy.ExcludedKeywords.Disabled {
 		checker.PwExcludedKeywords = cfg.Policy.ExcludedKeywords
 	}
+	if !*featureCfg.Password.Policy.BreachedPasswordCheck.Disabled {
+		checker.BreachedPasswordCheckEnabled = cfg.Policy.BreachedPasswordCheck.IsEnabled()
+		checker.BreachedPasswordCheckFailClosed = cfg.Policy.BreachedPasswordCheck.IsFailClosed()
+	}
 	return checker
 }
 
@@ -60,6 +66,9 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(HistoryStore), "*"),
 	wire.Bind(new(CheckerHistoryStore), new(*HistoryStore)),
 	ProvideExpiry,
+	NewBreachedPasswordHTTPClient,
+	wire.Struct(new(BreachedPasswordService), "*"),
+	wire.Bind(new(CheckerBreachedPasswordService), new(*BreachedPasswordService)),
 	NewRandSource,
 	wire.Value(DefaultMaxTrials),
 	wire.Struct(new(Generator), "*"),
@@ -6,9 +6,11 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	pwd "github.com/authgear/authgear-server/pkg/util/password"
 )
 
 var InvalidBcryptHash = apierrors.Invalid.WithReason("InvalidBcryptHash")
+var InvalidPasswordHash = apierrors.Invalid.WithReason("InvalidPasswordHash")
 
 func TranslateBcryptError(err error) error {
 	if err == nil {
@@ -38,6 +40,10 @@ func TranslateBcryptError(err error) error {
 		return InvalidBcryptHash.New(cost.Error())
 	}
 
+	if errors.Is(err, pwd.ErrInvalidPasswordFormat) {
+		return InvalidPasswordHash.New(err.Error())
+	}
+
 	// Otherwise it is not a bcrypt error.
 	return err
 }
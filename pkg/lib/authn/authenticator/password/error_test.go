@@ -9,6 +9,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	pwd "github.com/authgear/authgear-server/pkg/util/password"
 )
 
 func TestTranslateBcryptError(t *testing.T) {
@@ -63,6 +64,15 @@ func TestTranslateBcryptError(t *testing.T) {
 			Message:       "crypto/bcrypt: cost 100 is outside allowed inclusive range 4..31",
 			Info_ReadOnly: make(map[string]any),
 		})
+		test(fmt.Errorf("%w: missing parameter \"t\"", pwd.ErrInvalidPasswordFormat), &apierrors.APIError{
+			Kind: apierrors.Kind{
+				Name:   "Invalid",
+				Reason: "InvalidPasswordHash",
+			},
+			Code:          400,
+			Message:       "invalid password format: missing parameter \"t\"",
+			Info_ReadOnly: make(map[string]any),
+		})
 		test(fmt.Errorf("something else"), &apierrors.APIError{
 			Kind: apierrors.Kind{
 				Name:   "InternalError",
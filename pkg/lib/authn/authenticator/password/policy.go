@@ -22,6 +22,8 @@ const (
 	PasswordBelowGuessableLevel PolicyName = "PasswordBelowGuessableLevel"
 	// PasswordReused is self-explanatory
 	PasswordReused PolicyName = "PasswordReused"
+	// PasswordBreached means the password is found in the Pwned Passwords dataset.
+	PasswordBreached PolicyName = "PasswordBreached"
 )
 
 type Policy struct {
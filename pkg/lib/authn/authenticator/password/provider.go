@@ -70,7 +70,7 @@ func (p *Provider) New(ctx context.Context, id string, userID string, passwordSp
 		}
 		authen = p.populatePasswordHash(authen, passwordSpec.PlainPassword)
 		return authen, nil
-	// The input password is a bcrypt hash.
+	// The input password is a hash in one of the supported formats.
 	case passwordSpec.PasswordHash != "":
 		hash := []byte(passwordSpec.PasswordHash)
 		err := pwd.CheckHash(hash)
@@ -142,7 +142,7 @@ func (p *Provider) Authenticate(ctx context.Context, a *authenticator.Password,
 		return
 	}
 
-	migrated, err := pwd.TryMigrate([]byte(password), &a.PasswordHash)
+	migrated, err := pwd.TryMigrateToAlgorithm([]byte(password), &a.PasswordHash, p.hashAlgorithm())
 	if err != nil {
 		logger.WithError(err).Warn(ctx, "Failed to migrate password", slog.String("authenticator_id", a.ID))
 		return
@@ -198,7 +198,7 @@ func sortAuthenticators(as []*authenticator.Password) {
 }
 
 func (p *Provider) populatePasswordHash(a *authenticator.Password, password string) *authenticator.Password {
-	hash, err := pwd.Hash([]byte(password))
+	hash, err := pwd.HashWithAlgorithm([]byte(password), p.hashAlgorithm())
 	if err != nil {
 		panic(fmt.Errorf("password: failed to hash password: %w", err))
 	}
@@ -209,6 +209,10 @@ func (p *Provider) populatePasswordHash(a *authenticator.Password, password stri
 	return &newAuthn
 }
 
+func (p *Provider) hashAlgorithm() pwd.Algorithm {
+	return pwd.Algorithm(p.Config.HashAlgorithm)
+}
+
 func (p *Provider) populatePasswordHashWithHash(a *authenticator.Password, hash []byte) *authenticator.Password {
 	newAuthn := *a
 	newAuthn.PasswordHash = hash
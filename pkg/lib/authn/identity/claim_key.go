@@ -54,4 +54,15 @@ const (
 	IdentityClaimLDAPAttributes string = "https://authgear.com/claims/ldap/attributes"
 	// IdentityClaimLDAPRawAttributes is a claim with a map value.
 	IdentityClaimLDAPRawAttributes string = "https://authgear.com/claims/ldap/raw_attributes"
+
+	// IdentityClaimSAMLIdentityProviderName is a claim with a string value.
+	IdentityClaimSAMLIdentityProviderName string = "https://authgear.com/claims/saml/identity_provider_name"
+	// IdentityClaimSAMLIdentityProviderEntityID is a claim with a string value.
+	IdentityClaimSAMLIdentityProviderEntityID string = "https://authgear.com/claims/saml/identity_provider_entity_id"
+	// IdentityClaimSAMLNameID is a claim with a string value.
+	IdentityClaimSAMLNameID string = "https://authgear.com/claims/saml/name_id"
+	// IdentityClaimSAMLNameIDFormat is a claim with a string value.
+	IdentityClaimSAMLNameIDFormat string = "https://authgear.com/claims/saml/name_id_format"
+	// IdentityClaimSAMLAttributes is a claim with a map value.
+	IdentityClaimSAMLAttributes string = "https://authgear.com/claims/saml/attributes"
 )
@@ -24,6 +24,7 @@ type Info struct {
 	Passkey   *Passkey   `json:"passkey,omitempty"`
 	SIWE      *SIWE      `json:"siwe,omitempty"`
 	LDAP      *LDAP      `json:"ldap,omitempty"`
+	SAML      *SAML      `json:"saml,omitempty"`
 }
 
 func (i *Info) ToSpec() Spec {
@@ -86,6 +87,11 @@ func (i *Info) ToSpec() Spec {
 			Type: i.Type,
 			LDAP: i.LDAP.ToLDAPSpec(),
 		}
+	case model.IdentityTypeSAML:
+		return Spec{
+			Type: i.Type,
+			SAML: i.SAML.ToSAMLSpec(),
+		}
 	default:
 		panic("identity: unknown identity type: " + i.Type)
 	}
@@ -127,6 +133,8 @@ func (i *Info) AMR() []string {
 		return nil
 	case model.IdentityTypeLDAP:
 		return []string{model.AMRPWD}
+	case model.IdentityTypeSAML:
+		return nil
 	default:
 		panic("identity: unknown identity type: " + i.Type)
 	}
@@ -174,6 +182,14 @@ func (i *Info) ToModel() model.Identity {
 		claims[IdentityClaimLDAPAttributes] = i.LDAP.EntryJSON()
 		claims[IdentityClaimLDAPRawAttributes] = i.LDAP.RawEntryJSON
 
+	case model.IdentityTypeSAML:
+		maps.Copy(claims, i.SAML.Claims)
+		claims[IdentityClaimSAMLIdentityProviderName] = i.SAML.IdentityProviderName
+		claims[IdentityClaimSAMLIdentityProviderEntityID] = i.SAML.IdentityProviderEntityID
+		claims[IdentityClaimSAMLNameID] = i.SAML.NameID
+		claims[IdentityClaimSAMLNameIDFormat] = i.SAML.NameIDFormat
+		claims[IdentityClaimSAMLAttributes] = i.SAML.Attributes
+
 	default:
 		panic("identity: unknown identity type: " + i.Type)
 	}
@@ -194,6 +210,7 @@ func (i *Info) ToModel() model.Identity {
 // If it is a passkey identity, the name is returned.
 // If it is a SIWE identity, EIP681 of the address and chainID is returned
 // If it is a LDAP identity, dn or user id attribute value is returned
+// If it is a SAML identity, email, phone_number, preferred_username or NameID is returned.
 func (i *Info) DisplayID() string {
 	switch i.Type {
 	case model.IdentityTypeLoginID:
@@ -223,6 +240,8 @@ func (i *Info) DisplayID() string {
 		return eip681.URL().String()
 	case model.IdentityTypeLDAP:
 		return i.LDAP.DisplayID()
+	case model.IdentityTypeSAML:
+		return i.SAML.DisplayID()
 	default:
 		panic(fmt.Errorf("identity: unexpected identity type %v", i.Type))
 	}
@@ -246,6 +265,8 @@ func (i *Info) IdentityAwareStandardClaims() map[model.ClaimName]string {
 		break
 	case model.IdentityTypeLDAP:
 		return i.LDAP.IdentityAwareStandardClaims()
+	case model.IdentityTypeSAML:
+		return i.SAML.IdentityAwareStandardClaims()
 	default:
 		panic(fmt.Errorf("identity: unexpected identity type %v", i.Type))
 	}
@@ -269,6 +290,8 @@ func (i *Info) AllStandardClaims() map[string]any {
 		break
 	case model.IdentityTypeLDAP:
 		return i.LDAP.Claims
+	case model.IdentityTypeSAML:
+		return i.SAML.Claims
 	default:
 		panic(fmt.Errorf("identity: unexpected identity type %v", i.Type))
 	}
@@ -339,6 +362,9 @@ func (i *Info) CreateDisabled(c *config.IdentityConfig) bool {
 	case model.IdentityTypeLDAP:
 		// TODO(DEV-1671): Support LDAP in settings page
 		return true
+	case model.IdentityTypeSAML:
+		// SAML identity is managed by the upstream identity provider.
+		return true
 	default:
 		panic(fmt.Sprintf("identity: unexpected identity type: %s", i.Type))
 	}
@@ -371,6 +397,9 @@ func (i *Info) DeleteDisabled(c *config.IdentityConfig) bool {
 	case model.IdentityTypeLDAP:
 		// TODO(DEV-1671): Support LDAP in settings page
 		return true
+	case model.IdentityTypeSAML:
+		// SAML identity is managed by the upstream identity provider.
+		return true
 	default:
 		panic(fmt.Sprintf("identity: unexpected identity type: %s", i.Type))
 	}
@@ -400,6 +429,9 @@ func (i *Info) UpdateDisabled(c *config.IdentityConfig) bool {
 	case model.IdentityTypeLDAP:
 		// TODO(DEV-1671): Support LDAP in settings page
 		return true
+	case model.IdentityTypeSAML:
+		// SAML identity is managed by the upstream identity provider.
+		return true
 	default:
 		panic(fmt.Sprintf("identity: unexpected identity type: %s", i.Type))
 	}
@@ -418,6 +450,8 @@ func (i *Info) UpdateUserID(newUserID string) *Info {
 		i.Passkey.UserID = newUserID
 	case model.IdentityTypeLDAP:
 		i.LDAP.UserID = newUserID
+	case model.IdentityTypeSAML:
+		i.SAML.UserID = newUserID
 	case model.IdentityTypeSIWE:
 		i.SIWE.UserID = newUserID
 	case model.IdentityTypeAnonymous:
@@ -447,6 +481,8 @@ func (i *Info) ToIdentification() model.AuthenticationFlowIdentification {
 		return model.AuthenticationFlowIdentificationPasskey
 	case model.IdentityTypeLDAP:
 		return model.AuthenticationFlowIdentificationLDAP
+	case model.IdentityTypeSAML:
+		return model.AuthenticationFlowIdentificationSAML
 	case model.IdentityTypeAnonymous:
 		fallthrough
 	case model.IdentityTypeBiometric:
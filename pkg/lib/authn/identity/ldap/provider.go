@@ -53,6 +53,15 @@ func (p *Provider) ListByClaim(ctx context.Context, name string, value string) (
 	return is, nil
 }
 
+func (p *Provider) ListByServerName(ctx context.Context, serverName string) ([]*identity.LDAP, error) {
+	is, err := p.Store.ListByServerName(ctx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	sortIdentities(is)
+	return is, nil
+}
+
 func (p *Provider) New(
 	userID string,
 	serverName string,
@@ -150,6 +150,28 @@ func (s *Store) ListByClaim(ctx context.Context, name string, value string) ([]*
 	return is, nil
 }
 
+func (s *Store) ListByServerName(ctx context.Context, serverName string) ([]*identity.LDAP, error) {
+	q := s.selectQuery().
+		Where("l.server_name = ?", serverName)
+
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var is []*identity.LDAP
+	for rows.Next() {
+		i, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, i)
+	}
+
+	return is, nil
+}
+
 func (s *Store) GetByServerUserID(ctx context.Context, serverName string, userIDAttributeName string, userIDAttributeValue []byte) (*identity.LDAP, error) {
 	q := s.selectQuery().
 		Where(
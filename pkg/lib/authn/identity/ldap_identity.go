@@ -3,6 +3,7 @@ package identity
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 	"unicode"
 	"unicode/utf8"
@@ -53,6 +54,31 @@ func (i *LDAP) EntryJSON() map[string]any {
 	return result
 }
 
+// MemberOf returns the values of the member of attribute.
+// Attribute names are case-insensitive in LDAP.
+func (i *LDAP) MemberOf(attributeName string) []string {
+	var dns []string
+	for name, values := range i.RawEntryJSON {
+		if name == "dn" || !strings.EqualFold(name, attributeName) {
+			continue
+		}
+		for _, byteStr := range values.([]any) {
+			bytes, err := base64.StdEncoding.DecodeString(byteStr.(string))
+			if err != nil {
+				panic(fmt.Errorf("ldap: unexpected malformed base64 encoded string: %w", err))
+			}
+			dns = append(dns, string(bytes))
+		}
+	}
+	return dns
+}
+
+// DN returns the DN of the entry.
+func (i *LDAP) DN() (string, bool) {
+	dn, ok := i.RawEntryJSON["dn"].(string)
+	return dn, ok
+}
+
 func (i *LDAP) DisplayID() string {
 	dn, ok := i.RawEntryJSON["dn"].(string)
 	if !ok {
@@ -73,6 +73,32 @@ func TestLDAPIdentity(t *testing.T) {
 			})
 		})
 
+		Convey("Test MemberOf", func() {
+			Convey("It should match the attribute name case-insensitively", func() {
+				ldap := &LDAP{
+					RawEntryJSON: map[string]any{
+						"dn": "dn",
+						"memberof": []any{
+							"Y249YWRtaW5zLGRjPWV4YW1wbGUsZGM9Y29t",
+							"Y249c3RhZmYsZGM9ZXhhbXBsZSxkYz1jb20=",
+						},
+					},
+				}
+				So(ldap.MemberOf("memberOf"), ShouldResemble, []string{
+					"cn=admins,dc=example,dc=com",
+					"cn=staff,dc=example,dc=com",
+				})
+			})
+			Convey("It should return nil if the attribute does not exist", func() {
+				ldap := &LDAP{
+					RawEntryJSON: map[string]any{
+						"dn": "dn",
+					},
+				}
+				So(ldap.MemberOf("memberOf"), ShouldBeNil)
+			})
+		})
+
 		Convey("Test DisplayID", func() {
 			Convey("It should DN if exists", func() {
 				ldap := &LDAP{
@@ -0,0 +1,10 @@
+package saml
+
+import (
+	"github.com/google/wire"
+)
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(Store), "*"),
+	wire.Struct(new(Provider), "*"),
+)
@@ -0,0 +1,149 @@
+package saml
+
+import (
+	"context"
+	"sort"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+type StandardAttributesNormalizer interface {
+	Normalize(context.Context, stdattrs.T) error
+}
+
+type Provider struct {
+	Store                        *Store
+	Clock                        clock.Clock
+	StandardAttributesNormalizer StandardAttributesNormalizer
+}
+
+func (p *Provider) Get(ctx context.Context, userID string, id string) (*identity.SAML, error) {
+	return p.Store.Get(ctx, userID, id)
+}
+
+func (p *Provider) GetMany(ctx context.Context, ids []string) ([]*identity.SAML, error) {
+	return p.Store.GetMany(ctx, ids)
+}
+
+func (p *Provider) List(ctx context.Context, userID string) ([]*identity.SAML, error) {
+	is, err := p.Store.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sortIdentities(is)
+	return is, nil
+}
+
+func (p *Provider) GetByNameID(ctx context.Context, entityID string, nameID string) (*identity.SAML, error) {
+	return p.Store.GetByNameID(ctx, entityID, nameID)
+}
+
+func (p *Provider) ListByClaim(ctx context.Context, name string, value string) ([]*identity.SAML, error) {
+	is, err := p.Store.ListByClaim(ctx, name, value)
+	if err != nil {
+		return nil, err
+	}
+	sortIdentities(is)
+	return is, nil
+}
+
+func (p *Provider) New(userID string, spec *identity.SAMLSpec) *identity.SAML {
+	claims := spec.Claims
+	if claims == nil {
+		claims = make(map[string]any)
+	}
+	attributes := spec.Attributes
+	if attributes == nil {
+		attributes = make(map[string]any)
+	}
+	return &identity.SAML{
+		ID:                       uuid.New(),
+		UserID:                   userID,
+		IdentityProviderName:     spec.IdentityProviderName,
+		IdentityProviderEntityID: spec.IdentityProviderEntityID,
+		NameID:                   spec.NameID,
+		NameIDFormat:             spec.NameIDFormat,
+		Claims:                   claims,
+		Attributes:               attributes,
+	}
+}
+
+func (p *Provider) WithUpdate(iden *identity.SAML, spec *identity.SAMLSpec) *identity.SAML {
+	newIden := *iden
+	newIden.IdentityProviderName = spec.IdentityProviderName
+	newIden.NameIDFormat = spec.NameIDFormat
+	newIden.Claims = spec.Claims
+	newIden.Attributes = spec.Attributes
+	return &newIden
+}
+
+func (p *Provider) Create(ctx context.Context, i *identity.SAML) error {
+	now := p.Clock.NowUTC()
+	i.CreatedAt = now
+	i.UpdatedAt = now
+	return p.Store.Create(ctx, i)
+}
+
+func (p *Provider) Update(ctx context.Context, i *identity.SAML) error {
+	now := p.Clock.NowUTC()
+	i.UpdatedAt = now
+	return p.Store.Update(ctx, i)
+}
+
+func (p *Provider) Delete(ctx context.Context, i *identity.SAML) error {
+	return p.Store.Delete(ctx, i)
+}
+
+func sortIdentities(is []*identity.SAML) {
+	sort.Slice(is, func(i, j int) bool {
+		return is[i].CreatedAt.Before(is[j].CreatedAt)
+	})
+}
+
+// MakeSpecFromAssertion maps the attributes of a verified assertion to standard attributes
+// according to the attribute mapping of the identity provider.
+func (p *Provider) MakeSpecFromAssertion(
+	ctx context.Context,
+	idpConfig *config.SAMLIdentityProviderConfig,
+	nameID string,
+	nameIDFormat string,
+	attributes map[string][]string,
+) (*identity.Spec, error) {
+	claims := map[string]any{}
+	if nameIDFormat == string(samlprotocol.SAMLNameIDFormatEmailAddress) {
+		claims[string(model.ClaimEmail)] = nameID
+	}
+	for claimName, attributeName := range idpConfig.AttributeMapping {
+		if values := attributes[attributeName]; len(values) > 0 && values[0] != "" {
+			claims[claimName] = values[0]
+		}
+	}
+
+	err := p.StandardAttributesNormalizer.Normalize(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	rawAttributes := map[string]any{}
+	for name, values := range attributes {
+		rawAttributes[name] = values
+	}
+
+	return &identity.Spec{
+		Type: model.IdentityTypeSAML,
+		SAML: &identity.SAMLSpec{
+			IdentityProviderName:     idpConfig.Name,
+			IdentityProviderEntityID: idpConfig.EntityID,
+			NameID:                   nameID,
+			NameIDFormat:             nameIDFormat,
+			Claims:                   claims,
+			Attributes:               rawAttributes,
+		},
+	}, nil
+}
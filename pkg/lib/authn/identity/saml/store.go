@@ -0,0 +1,262 @@
+package saml
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+)
+
+const (
+	tableNameAuthIdentitySAML = "_auth_identity_saml"
+)
+
+type Store struct {
+	SQLBuilder  *appdb.SQLBuilderApp
+	SQLExecutor *appdb.SQLExecutor
+}
+
+func (s *Store) selectQuery() db.SelectBuilder {
+	return s.SQLBuilder.
+		Select(
+			"p.id",
+			"p.user_id",
+			"p.created_at",
+			"p.updated_at",
+
+			"s.identity_provider_name",
+			"s.identity_provider_entity_id",
+			"s.name_id",
+			"s.name_id_format",
+			"s.claims",
+			"s.attributes",
+		).
+		From(s.SQLBuilder.TableName("_auth_identity"), "p").
+		Join(s.SQLBuilder.TableName(tableNameAuthIdentitySAML), "s", "p.id = s.id")
+}
+
+func (s *Store) scan(scn db.Scanner) (*identity.SAML, error) {
+	i := &identity.SAML{}
+	var claims []byte
+	var attributes []byte
+
+	err := scn.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IdentityProviderName,
+		&i.IdentityProviderEntityID,
+		&i.NameID,
+		&i.NameIDFormat,
+		&claims,
+		&attributes,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, api.ErrIdentityNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(claims, &i.Claims); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(attributes, &i.Attributes); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func (s *Store) queryMany(ctx context.Context, q db.SelectBuilder) ([]*identity.SAML, error) {
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var is []*identity.SAML
+	for rows.Next() {
+		i, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, i)
+	}
+
+	return is, nil
+}
+
+func (s *Store) Get(ctx context.Context, userID string, id string) (*identity.SAML, error) {
+	q := s.selectQuery().Where("p.user_id = ? AND p.id = ?", userID, id)
+	rows, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return s.scan(rows)
+}
+
+func (s *Store) GetMany(ctx context.Context, ids []string) ([]*identity.SAML, error) {
+	return s.queryMany(ctx, s.selectQuery().Where("p.id = ANY (?)", pq.Array(ids)))
+}
+
+func (s *Store) List(ctx context.Context, userID string) ([]*identity.SAML, error) {
+	return s.queryMany(ctx, s.selectQuery().Where("p.user_id = ?", userID))
+}
+
+func (s *Store) ListByClaim(ctx context.Context, name string, value string) ([]*identity.SAML, error) {
+	return s.queryMany(ctx, s.selectQuery().Where("(s.claims ->> ?) = ?", name, value))
+}
+
+func (s *Store) GetByNameID(ctx context.Context, entityID string, nameID string) (*identity.SAML, error) {
+	q := s.selectQuery().
+		Where(
+			"s.identity_provider_entity_id = ? AND s.name_id = ?",
+			entityID,
+			nameID,
+		)
+	rows, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return s.scan(rows)
+}
+
+func (s *Store) Create(ctx context.Context, i *identity.SAML) (err error) {
+	builder := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName("_auth_identity")).
+		Columns(
+			"id",
+			"type",
+			"user_id",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			i.ID,
+			model.IdentityTypeSAML,
+			i.UserID,
+			i.CreatedAt,
+			i.UpdatedAt,
+		)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, builder)
+	if err != nil {
+		return err
+	}
+
+	claims, err := json.Marshal(i.Claims)
+	if err != nil {
+		return err
+	}
+	attributes, err := json.Marshal(i.Attributes)
+	if err != nil {
+		return err
+	}
+
+	q := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName(tableNameAuthIdentitySAML)).
+		Columns(
+			"id",
+			"identity_provider_name",
+			"identity_provider_entity_id",
+			"name_id",
+			"name_id_format",
+			"claims",
+			"attributes",
+		).
+		Values(
+			i.ID,
+			i.IdentityProviderName,
+			i.IdentityProviderEntityID,
+			i.NameID,
+			i.NameIDFormat,
+			claims,
+			attributes,
+		)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) Update(ctx context.Context, i *identity.SAML) error {
+	claims, err := json.Marshal(i.Claims)
+	if err != nil {
+		return err
+	}
+	attributes, err := json.Marshal(i.Attributes)
+	if err != nil {
+		return err
+	}
+
+	q := s.SQLBuilder.
+		Update(s.SQLBuilder.TableName(tableNameAuthIdentitySAML)).
+		Set("identity_provider_name", i.IdentityProviderName).
+		Set("name_id_format", i.NameIDFormat).
+		Set("claims", claims).
+		Set("attributes", attributes).
+		Where("id = ?", i.ID)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return api.ErrIdentityNotFound
+	} else if rowsAffected > 1 {
+		panic(fmt.Sprintf("identity_saml: want 1 row updated, got %v", rowsAffected))
+	}
+
+	q = s.SQLBuilder.
+		Update(s.SQLBuilder.TableName("_auth_identity")).
+		Set("updated_at", i.UpdatedAt).
+		Where("id = ?", i.ID)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, i *identity.SAML) error {
+	q := s.SQLBuilder.
+		Delete(s.SQLBuilder.TableName(tableNameAuthIdentitySAML)).
+		Where("id = ?", i.ID)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.
+		Delete(s.SQLBuilder.TableName("_auth_identity")).
+		Where("id = ?", i.ID)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+type SAML struct {
+	ID                       string         `json:"id"`
+	CreatedAt                time.Time      `json:"created_at"`
+	UpdatedAt                time.Time      `json:"updated_at"`
+	UserID                   string         `json:"user_id"`
+	IdentityProviderName     string         `json:"identity_provider_name"`
+	IdentityProviderEntityID string         `json:"identity_provider_entity_id"`
+	NameID                   string         `json:"name_id"`
+	NameIDFormat             string         `json:"name_id_format"`
+	Claims                   map[string]any `json:"claims,omitempty"`
+	Attributes               map[string]any `json:"attributes,omitempty"`
+}
+
+func (i *SAML) ToInfo() *Info {
+	return &Info{
+		ID:        i.ID,
+		UserID:    i.UserID,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+		Type:      model.IdentityTypeSAML,
+
+		SAML: i,
+	}
+}
+
+func (i *SAML) ToSAMLSpec() *SAMLSpec {
+	return &SAMLSpec{
+		IdentityProviderName:     i.IdentityProviderName,
+		IdentityProviderEntityID: i.IdentityProviderEntityID,
+		NameID:                   i.NameID,
+		NameIDFormat:             i.NameIDFormat,
+		Claims:                   i.Claims,
+		Attributes:               i.Attributes,
+	}
+}
+
+func (i *SAML) DisplayID() string {
+	if email, ok := i.Claims[string(model.ClaimEmail)].(string); ok {
+		return email
+	}
+	if phoneNumber, ok := i.Claims[string(model.ClaimPhoneNumber)].(string); ok {
+		return phoneNumber
+	}
+	if preferredUsername, ok := i.Claims[string(model.ClaimPreferredUsername)].(string); ok {
+		return preferredUsername
+	}
+	return i.NameID
+}
+
+func (i *SAML) IdentityAwareStandardClaims() map[model.ClaimName]string {
+	claims := map[model.ClaimName]string{}
+	if email, ok := i.Claims[string(model.ClaimEmail)].(string); ok {
+		claims[model.ClaimEmail] = email
+	}
+	if phoneNumber, ok := i.Claims[string(model.ClaimPhoneNumber)].(string); ok {
+		claims[model.ClaimPhoneNumber] = phoneNumber
+	}
+	if username, ok := i.Claims[string(model.ClaimPreferredUsername)].(string); ok {
+		claims[model.ClaimPreferredUsername] = username
+	}
+	return claims
+}
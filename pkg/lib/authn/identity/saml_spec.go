@@ -0,0 +1,10 @@
+package identity
+
+type SAMLSpec struct {
+	IdentityProviderName     string         `json:"identity_provider_name"`
+	IdentityProviderEntityID string         `json:"identity_provider_entity_id"`
+	NameID                   string         `json:"name_id"`
+	NameIDFormat             string         `json:"name_id_format"`
+	Claims                   map[string]any `json:"claims,omitempty"`
+	Attributes               map[string]any `json:"attributes,omitempty"`
+}
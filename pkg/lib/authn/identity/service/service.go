@@ -113,6 +113,20 @@ type LDAPIdentityProvider interface {
 	Delete(ctx context.Context, i *identity.LDAP) error
 }
 
+type SAMLIdentityProvider interface {
+	New(userID string, spec *identity.SAMLSpec) *identity.SAML
+	WithUpdate(iden *identity.SAML, spec *identity.SAMLSpec) *identity.SAML
+
+	Get(ctx context.Context, userID, id string) (*identity.SAML, error)
+	GetMany(ctx context.Context, ids []string) ([]*identity.SAML, error)
+	List(ctx context.Context, userID string) ([]*identity.SAML, error)
+	GetByNameID(ctx context.Context, entityID string, nameID string) (*identity.SAML, error)
+	ListByClaim(ctx context.Context, name string, value string) ([]*identity.SAML, error)
+	Create(ctx context.Context, i *identity.SAML) error
+	Update(ctx context.Context, i *identity.SAML) error
+	Delete(ctx context.Context, i *identity.SAML) error
+}
+
 type Service struct {
 	Authentication          *config.AuthenticationConfig
 	Identity                *config.IdentityConfig
@@ -126,6 +140,7 @@ type Service struct {
 	Passkey                 PasskeyIdentityProvider
 	SIWE                    SIWEIdentityProvider
 	LDAP                    LDAPIdentityProvider
+	SAML                    SAMLIdentityProvider
 }
 
 func (s *Service) Get(ctx context.Context, id string) (*identity.Info, error) {
@@ -176,6 +191,12 @@ func (s *Service) Get(ctx context.Context, id string) (*identity.Info, error) {
 			return nil, err
 		}
 		return s.ToInfo(), nil
+	case model.IdentityTypeSAML:
+		s, err := s.SAML.Get(ctx, ref.UserID, id)
+		if err != nil {
+			return nil, err
+		}
+		return s.ToInfo(), nil
 	}
 
 	panic("identity: unknown identity type " + ref.Type)
@@ -198,7 +219,7 @@ func (s *Service) GetMany(ctx context.Context, ids []string) ([]*identity.Info,
 		return nil, err
 	}
 
-	var loginIDs, oauthIDs, anonymousIDs, biometricIDs, passkeyIDs, siweIDs, ldapIDs []string
+	var loginIDs, oauthIDs, anonymousIDs, biometricIDs, passkeyIDs, siweIDs, ldapIDs, samlIDs []string
 	for _, ref := range refs {
 		switch ref.Type {
 		case model.IdentityTypeLoginID:
@@ -215,6 +236,8 @@ func (s *Service) GetMany(ctx context.Context, ids []string) ([]*identity.Info,
 			siweIDs = append(siweIDs, ref.ID)
 		case model.IdentityTypeLDAP:
 			ldapIDs = append(ldapIDs, ref.ID)
+		case model.IdentityTypeSAML:
+			samlIDs = append(samlIDs, ref.ID)
 		default:
 			panic("identity: unknown identity type " + ref.Type)
 		}
@@ -278,6 +301,14 @@ func (s *Service) GetMany(ctx context.Context, ids []string) ([]*identity.Info,
 		infos = append(infos, i.ToInfo())
 	}
 
+	samlIdentities, err := s.SAML.GetMany(ctx, samlIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range samlIdentities {
+		infos = append(infos, i.ToInfo())
+	}
+
 	return infos, nil
 }
 
@@ -349,6 +380,12 @@ func (s *Service) getBySpec(ctx context.Context, spec *identity.Spec) (*identity
 			return nil, err
 		}
 		return l.ToInfo(), nil
+	case model.IdentityTypeSAML:
+		i, err := s.SAML.GetByNameID(ctx, spec.SAML.IdentityProviderEntityID, spec.SAML.NameID)
+		if err != nil {
+			return nil, err
+		}
+		return i.ToInfo(), nil
 	}
 
 	panic("identity: unknown identity type " + spec.Type)
@@ -399,6 +436,10 @@ func (s *Service) SearchBySpec(ctx context.Context, spec *identity.Spec) (exactM
 		if spec.LDAP.Claims != nil {
 			claimsToSearch = spec.LDAP.Claims
 		}
+	case model.IdentityTypeSAML:
+		if spec.SAML.Claims != nil {
+			claimsToSearch = spec.SAML.Claims
+		}
 	default:
 		break
 	}
@@ -442,6 +483,16 @@ func (s *Service) SearchBySpec(ctx context.Context, spec *identity.Spec) (exactM
 			for _, l := range ldaps {
 				otherMatches = append(otherMatches, l.ToInfo())
 			}
+
+			var samls []*identity.SAML
+			samls, err = s.SAML.ListByClaim(ctx, name, str)
+			if err != nil {
+				return
+			}
+
+			for _, i := range samls {
+				otherMatches = append(otherMatches, i.ToInfo())
+			}
 		}
 	}
 
@@ -549,6 +600,17 @@ func (s *Service) ListByUserIDs(ctx context.Context, userIDs []string) (map[stri
 		}
 	}
 
+	// saml
+	if samlRefs, ok := refsByType[model.IdentityTypeSAML]; ok && len(samlRefs) > 0 {
+		samlIdens, err := s.SAML.GetMany(ctx, extractIDs(samlRefs))
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range samlIdens {
+			infos = append(infos, i.ToInfo())
+		}
+	}
+
 	infosByUserID := map[string][]*identity.Info{}
 	for _, info := range infos {
 		arr := infosByUserID[info.UserID]
@@ -642,6 +704,24 @@ func (s *Service) ListIdentitiesThatHaveStandardAttributes(ctx context.Context,
 		}
 	}
 
+	{
+		typeSAML := model.IdentityTypeSAML
+		samlRefs, err := s.Store.ListRefsByUsers(ctx, userIDs, &typeSAML)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(samlRefs) > 0 {
+			samls, err := s.SAML.GetMany(ctx, extractIDs(samlRefs))
+			if err != nil {
+				return nil, err
+			}
+			for _, i := range samls {
+				infos = append(infos, i.ToInfo())
+			}
+		}
+	}
+
 	return infos, nil
 }
 
@@ -683,6 +763,15 @@ func (s *Service) ListByClaim(ctx context.Context, name string, value string) ([
 		infos = append(infos, i.ToInfo())
 	}
 
+	// saml
+	samlIdentities, err := s.SAML.ListByClaim(ctx, name, value)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range samlIdentities {
+		infos = append(infos, i.ToInfo())
+	}
+
 	return infos, nil
 }
 
@@ -734,6 +823,9 @@ func (s *Service) New(ctx context.Context, userID string, spec *identity.Spec, o
 		rawEntryJSON := spec.LDAP.RawEntryJSON
 		l := s.LDAP.New(userID, serverName, loginUserName, userIDAttributeName, userIDAttributeValue, claims, rawEntryJSON)
 		return l.ToInfo(), nil
+	case model.IdentityTypeSAML:
+		i := s.SAML.New(userID, spec.SAML)
+		return i.ToInfo(), nil
 	}
 
 	panic("identity: unknown identity type " + spec.Type)
@@ -827,6 +919,12 @@ func (s *Service) Create(ctx context.Context, info *identity.Info) error {
 			return err
 		}
 		*info = *i.ToInfo()
+	case model.IdentityTypeSAML:
+		i := info.SAML
+		if err := s.SAML.Create(ctx, i); err != nil {
+			return err
+		}
+		*info = *i.ToInfo()
 	default:
 		panic("identity: unknown identity type " + info.Type)
 	}
@@ -849,6 +947,9 @@ func (s *Service) UpdateWithSpec(ctx context.Context, info *identity.Info, spec
 	case model.IdentityTypeLDAP:
 		i := s.LDAP.WithUpdate(info.LDAP, spec.LDAP.LastLoginUserName, spec.LDAP.Claims, spec.LDAP.RawEntryJSON)
 		return i.ToInfo(), nil
+	case model.IdentityTypeSAML:
+		i := s.SAML.WithUpdate(info.SAML, spec.SAML)
+		return i.ToInfo(), nil
 	default:
 		panic("identity: cannot update identity type " + info.Type)
 	}
@@ -884,6 +985,12 @@ func (s *Service) Update(ctx context.Context, info *identity.Info) error {
 			return err
 		}
 		*info = *i.ToInfo()
+	case model.IdentityTypeSAML:
+		i := info.SAML
+		if err := s.SAML.Update(ctx, i); err != nil {
+			return err
+		}
+		*info = *i.ToInfo()
 	default:
 		panic("identity: unknown identity type " + info.Type)
 	}
@@ -928,6 +1035,11 @@ func (s *Service) Delete(ctx context.Context, info *identity.Info) error {
 		if err := s.LDAP.Delete(ctx, i); err != nil {
 			return err
 		}
+	case model.IdentityTypeSAML:
+		i := info.SAML
+		if err := s.SAML.Delete(ctx, i); err != nil {
+			return err
+		}
 	default:
 		panic("identity: unknown identity type " + info.Type)
 	}
@@ -997,6 +1109,24 @@ func (s *Service) CheckDuplicated(ctx context.Context, info *identity.Info) (dup
 			err = identity.NewErrDuplicatedIdentity(&incoming, &existing)
 			return
 		}
+
+		var samlIdentities []*identity.SAML
+		samlIdentities, err = s.SAML.ListByClaim(ctx, string(name), value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range samlIdentities {
+			if i.UserID == info.UserID {
+				continue
+			}
+			dupeIdentity = i.ToInfo()
+
+			incoming := info.ToSpec()
+			existing := dupeIdentity.ToSpec()
+			err = identity.NewErrDuplicatedIdentity(&incoming, &existing)
+			return
+		}
 	}
 
 	// 2. Check duplicate by considering type-specific unique key.
@@ -1047,6 +1177,21 @@ func (s *Service) CheckDuplicatedByUniqueKey(ctx context.Context, info *identity
 		} else if l.UserID != info.UserID {
 			dupeIdentity = l.ToInfo()
 
+			incoming := info.ToSpec()
+			existing := dupeIdentity.ToSpec()
+			err = identity.NewErrDuplicatedIdentity(&incoming, &existing)
+		}
+	case model.IdentityTypeSAML:
+		var i *identity.SAML
+		i, err = s.SAML.GetByNameID(ctx, info.SAML.IdentityProviderEntityID, info.SAML.NameID)
+		if err != nil {
+			if !errors.Is(err, api.ErrIdentityNotFound) {
+				return
+			}
+			err = nil
+		} else if i.UserID != info.UserID {
+			dupeIdentity = i.ToInfo()
+
 			incoming := info.ToSpec()
 			existing := dupeIdentity.ToSpec()
 			err = identity.NewErrDuplicatedIdentity(&incoming, &existing)
@@ -1091,6 +1236,9 @@ func (s *Service) ListCandidates(ctx context.Context, userID string) (out []iden
 		case model.IdentityTypeLDAP:
 			// TODO(DEV-1671): Support LDAP in settings page
 			break
+		case model.IdentityTypeSAML:
+			// SAML identity is managed by the upstream identity provider.
+			break
 		}
 
 	}
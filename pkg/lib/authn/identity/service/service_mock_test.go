@@ -1083,3 +1083,171 @@ func (mr *MockLDAPIdentityProviderMockRecorder) WithUpdate(iden, loginUserName,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithUpdate", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).WithUpdate), iden, loginUserName, claims, rawEntryJSON)
 }
+
+// MockSAMLIdentityProvider is a mock of SAMLIdentityProvider interface.
+type MockSAMLIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockSAMLIdentityProviderMockRecorder
+}
+
+// MockSAMLIdentityProviderMockRecorder is the mock recorder for MockSAMLIdentityProvider.
+type MockSAMLIdentityProviderMockRecorder struct {
+	mock *MockSAMLIdentityProvider
+}
+
+// NewMockSAMLIdentityProvider creates a new mock instance.
+func NewMockSAMLIdentityProvider(ctrl *gomock.Controller) *MockSAMLIdentityProvider {
+	mock := &MockSAMLIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockSAMLIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSAMLIdentityProvider) EXPECT() *MockSAMLIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSAMLIdentityProvider) Create(ctx context.Context, i *identity.SAML) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSAMLIdentityProviderMockRecorder) Create(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).Create), ctx, i)
+}
+
+// Delete mocks base method.
+func (m *MockSAMLIdentityProvider) Delete(ctx context.Context, i *identity.SAML) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSAMLIdentityProviderMockRecorder) Delete(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).Delete), ctx, i)
+}
+
+// Get mocks base method.
+func (m *MockSAMLIdentityProvider) Get(ctx context.Context, userID, id string) (*identity.SAML, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID, id)
+	ret0, _ := ret[0].(*identity.SAML)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSAMLIdentityProviderMockRecorder) Get(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).Get), ctx, userID, id)
+}
+
+// GetByNameID mocks base method.
+func (m *MockSAMLIdentityProvider) GetByNameID(ctx context.Context, entityID, nameID string) (*identity.SAML, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNameID", ctx, entityID, nameID)
+	ret0, _ := ret[0].(*identity.SAML)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNameID indicates an expected call of GetByNameID.
+func (mr *MockSAMLIdentityProviderMockRecorder) GetByNameID(ctx, entityID, nameID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNameID", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).GetByNameID), ctx, entityID, nameID)
+}
+
+// GetMany mocks base method.
+func (m *MockSAMLIdentityProvider) GetMany(ctx context.Context, ids []string) ([]*identity.SAML, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
+	ret0, _ := ret[0].([]*identity.SAML)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockSAMLIdentityProviderMockRecorder) GetMany(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).GetMany), ctx, ids)
+}
+
+// List mocks base method.
+func (m *MockSAMLIdentityProvider) List(ctx context.Context, userID string) ([]*identity.SAML, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]*identity.SAML)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSAMLIdentityProviderMockRecorder) List(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).List), ctx, userID)
+}
+
+// ListByClaim mocks base method.
+func (m *MockSAMLIdentityProvider) ListByClaim(ctx context.Context, name, value string) ([]*identity.SAML, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByClaim", ctx, name, value)
+	ret0, _ := ret[0].([]*identity.SAML)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByClaim indicates an expected call of ListByClaim.
+func (mr *MockSAMLIdentityProviderMockRecorder) ListByClaim(ctx, name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClaim", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).ListByClaim), ctx, name, value)
+}
+
+// New mocks base method.
+func (m *MockSAMLIdentityProvider) New(userID string, spec *identity.SAMLSpec) *identity.SAML {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", userID, spec)
+	ret0, _ := ret[0].(*identity.SAML)
+	return ret0
+}
+
+// New indicates an expected call of New.
+func (mr *MockSAMLIdentityProviderMockRecorder) New(userID, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).New), userID, spec)
+}
+
+// Update mocks base method.
+func (m *MockSAMLIdentityProvider) Update(ctx context.Context, i *identity.SAML) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSAMLIdentityProviderMockRecorder) Update(ctx, i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).Update), ctx, i)
+}
+
+// WithUpdate mocks base method.
+func (m *MockSAMLIdentityProvider) WithUpdate(iden *identity.SAML, spec *identity.SAMLSpec) *identity.SAML {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithUpdate", iden, spec)
+	ret0, _ := ret[0].(*identity.SAML)
+	return ret0
+}
+
+// WithUpdate indicates an expected call of WithUpdate.
+func (mr *MockSAMLIdentityProviderMockRecorder) WithUpdate(iden, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithUpdate", reflect.TypeOf((*MockSAMLIdentityProvider)(nil).WithUpdate), iden, spec)
+}
@@ -268,6 +268,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 		loginIDProvider := NewMockLoginIDIdentityProvider(ctrl)
 		oauthProvider := NewMockOAuthIdentityProvider(ctrl)
 		ldapProvider := NewMockLDAPIdentityProvider(ctrl)
+		samlProvider := NewMockSAMLIdentityProvider(ctrl)
 
 		p := &Service{
 			Authentication: &config.AuthenticationConfig{},
@@ -287,6 +288,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			LoginID: loginIDProvider,
 			OAuth:   oauthProvider,
 			LDAP:    ldapProvider,
+			SAML:    samlProvider,
 		}
 
 		makeEmailLoginID := func(userID string, email string) *identity.Info {
@@ -345,6 +347,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, info.LoginID.UniqueKey).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, info)
@@ -360,6 +363,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, info.OAuth.ProviderID, info.OAuth.ProviderSubjectID).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 
 			actual, err := p.CheckDuplicated(ctx, info)
 			So(err, ShouldBeNil)
@@ -374,6 +378,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, info.LDAP.ServerName, info.LDAP.UserIDAttributeName, info.LDAP.UserIDAttributeValue).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, info)
@@ -389,6 +394,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return([]*identity.LoginID{info.LoginID}, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", info.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, info.LoginID.UniqueKey).AnyTimes().Return(info.LoginID, nil)
 
 			actual, err := p.CheckDuplicated(ctx, info)
@@ -405,6 +411,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return([]*identity.LoginID{existing.LoginID}, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, incoming.LoginID.UniqueKey).AnyTimes().Return(existing.LoginID, nil)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -420,6 +427,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return([]*identity.OAuth{info.OAuth}, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", info.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, info.OAuth.ProviderID, info.OAuth.ProviderSubjectID).AnyTimes().Return(info.OAuth, nil)
 
 			actual, err := p.CheckDuplicated(ctx, info)
@@ -436,6 +444,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return([]*identity.OAuth{existing.OAuth}, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, incoming.OAuth.ProviderID, incoming.OAuth.ProviderSubjectID).AnyTimes().Return(existing.OAuth, nil)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -451,6 +460,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return([]*identity.LDAP{info.LDAP}, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", info.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, info.LDAP.ServerName, info.LDAP.UserIDAttributeName, info.LDAP.UserIDAttributeValue).AnyTimes().Return(info.LDAP, nil)
 
 			actual, err := p.CheckDuplicated(ctx, info)
@@ -467,6 +477,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return([]*identity.LDAP{existing.LDAP}, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, incoming.LDAP.ServerName, incoming.LDAP.UserIDAttributeName, incoming.LDAP.UserIDAttributeValue).AnyTimes().Return(existing.LDAP, nil)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -483,6 +494,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return([]*identity.OAuth{existing.OAuth}, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, incoming.LoginID.UniqueKey).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -499,6 +511,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return([]*identity.OAuth{existing.OAuth}, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, incoming.LoginID.UniqueKey).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -515,6 +528,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return([]*identity.LDAP{existing.LDAP}, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, incoming.LoginID.UniqueKey).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -531,6 +545,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return([]*identity.LDAP{existing.LDAP}, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LoginID.Claims["email"]).AnyTimes().Return(nil, nil)
 			loginIDProvider.EXPECT().GetByUniqueKey(ctx, incoming.LoginID.UniqueKey).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -547,6 +562,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return([]*identity.LoginID{existing.LoginID}, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, incoming.OAuth.ProviderID, incoming.OAuth.ProviderSubjectID).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -563,6 +579,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return([]*identity.LoginID{existing.LoginID}, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, incoming.OAuth.ProviderID, incoming.OAuth.ProviderSubjectID).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -579,6 +596,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return([]*identity.LDAP{existing.LDAP}, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, incoming.OAuth.ProviderID, incoming.OAuth.ProviderSubjectID).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -595,6 +613,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return([]*identity.LDAP{existing.LDAP}, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.OAuth.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().GetByProviderSubject(ctx, incoming.OAuth.ProviderID, incoming.OAuth.ProviderSubjectID).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -611,6 +630,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return([]*identity.LoginID{existing.LoginID}, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, incoming.LDAP.ServerName, incoming.LDAP.UserIDAttributeName, incoming.LDAP.UserIDAttributeValue).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -627,6 +647,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return([]*identity.LoginID{existing.LoginID}, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, incoming.LDAP.ServerName, incoming.LDAP.UserIDAttributeName, incoming.LDAP.UserIDAttributeValue).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -643,6 +664,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return([]*identity.OAuth{existing.OAuth}, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, incoming.LDAP.ServerName, incoming.LDAP.UserIDAttributeName, incoming.LDAP.UserIDAttributeValue).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -659,6 +681,7 @@ func TestProviderCheckDuplicated(t *testing.T) {
 			loginIDProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			oauthProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return([]*identity.OAuth{existing.OAuth}, nil)
 			ldapProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
+			samlProvider.EXPECT().ListByClaim(ctx, "email", incoming.LDAP.Claims["email"]).AnyTimes().Return(nil, nil)
 			ldapProvider.EXPECT().GetByServerUserID(ctx, incoming.LDAP.ServerName, incoming.LDAP.UserIDAttributeName, incoming.LDAP.UserIDAttributeValue).AnyTimes().Return(nil, api.ErrIdentityNotFound)
 
 			actual, err := p.CheckDuplicated(ctx, incoming)
@@ -14,4 +14,5 @@ type Spec struct {
 	Passkey   *PasskeySpec   `json:"passkey,omitempty"`
 	SIWE      *SIWESpec      `json:"siwe,omitempty"`
 	LDAP      *LDAPSpec      `json:"ldap,omitempty"`
+	SAML      *SAMLSpec      `json:"saml,omitempty"`
 }
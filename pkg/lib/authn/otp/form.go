@@ -10,17 +10,24 @@ type Form string
 const (
 	FormCode Form = "code"
 	FormLink Form = "link"
+	// FormMagicLink is a link which completes the authentication flow
+	// on the device where the link is opened.
+	FormMagicLink Form = "magic_link"
 )
 
+func (f Form) IsLink() bool {
+	return f == FormLink || f == FormMagicLink
+}
+
 func (f Form) AllowLookupByCode() bool {
-	return f == FormLink
+	return f.IsLink()
 }
 
 func (f Form) codeType() secretCode {
 	switch f {
 	case FormCode:
 		return secretcode.OOBOTPSecretCode
-	case FormLink:
+	case FormLink, FormMagicLink:
 		return secretcode.LinkOTPSecretCode
 	default:
 		panic("unexpected form: " + f)
@@ -227,6 +227,23 @@ func TestGenerateCode(t *testing.T) {
 			userID:   "user1",
 			expected: "[[random_code]]",
 		},
+		{
+			name: "Magic link - Should use deterministic link code",
+			form: FormMagicLink,
+			cfg: &config.TestModeConfig{
+				Email: &config.TestModeEmailConfig{
+					Enabled: false,
+				},
+			},
+			featureCfg: &config.TestModeFeatureConfig{
+				DeterministicLinkOTP: &config.TestModeDeterministicLinkOTPFeatureConfig{
+					Enabled: true,
+				},
+			},
+			target:   "test@example.com",
+			userID:   "user1",
+			expected: secretcode.LinkOTPSecretCode.GenerateDeterministic("user1"),
+		},
 	}
 
 	for _, tc := range testCases {
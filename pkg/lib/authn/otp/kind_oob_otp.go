@@ -45,7 +45,7 @@ func (k kindOOBOTP) ValidPeriod() time.Duration {
 			k.config.Authenticator.OOB.SMS.ValidPeriods.Code,
 			k.config.Authenticator.OOB.SMS.ValidPeriods.Code,
 		).Duration()
-	case FormLink:
+	case FormLink, FormMagicLink:
 		return selectByChannel(k.channel,
 			k.config.Authenticator.OOB.Email.ValidPeriods.Link,
 			k.config.Authenticator.OOB.SMS.ValidPeriods.Link,
@@ -2,6 +2,7 @@ package otp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	neturl "net/url"
 	"path/filepath"
@@ -26,6 +27,7 @@ type SendOptions struct {
 	Target                  string
 	Form                    Form
 	Type                    translation.MessageType
+	FallbackType            translation.MessageType
 	Kind                    Kind
 	OTP                     string
 	AdditionalContext       *AdditionalContext
@@ -35,6 +37,7 @@ type SendOptions struct {
 type EndpointsProvider interface {
 	Origin() *neturl.URL
 	LoginLinkVerificationEndpointURL() *neturl.URL
+	MagicLinkEndpointURL() *neturl.URL
 	ResetPasswordEndpointURL() *neturl.URL
 }
 
@@ -45,10 +48,10 @@ type TranslationService interface {
 }
 
 type Sender interface {
-	SendEmailInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error
+	SendEmailAsync(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error
 	SendSMSImmediately(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions) error
-	SendSMSInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions) error
-	SendWhatsappInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *whatsapp.SendAuthenticationOTPOptions, resultCallback messaging.SendWhatsappResultCallback, errCalllback messaging.SendWhatsappErrorCallback) error
+	SendSMSAsync(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions) error
+	SendWhatsappAsync(ctx context.Context, msgType translation.MessageType, opts *whatsapp.SendAuthenticationOTPOptions, fallbackSMS *messaging.WhatsappFallbackSMS, callbackState json.RawMessage) (*messaging.SendWhatsappResult, error)
 }
 
 type SenderCodeStore interface {
@@ -72,7 +75,7 @@ var FromAdminAPIQueryKey = "x_from_admin_api"
 
 func (s *MessageSender) setupTemplateContext(msgType translation.MessageType, opts SendOptions) (*translation.PartialTemplateVariables, error) {
 	url := ""
-	if opts.Form == FormLink {
+	if opts.Form.IsLink() {
 		var linkURL *neturl.URL
 		switch msgType {
 		case translation.MessageTypeSetupPrimaryOOB,
@@ -80,7 +83,11 @@ func (s *MessageSender) setupTemplateContext(msgType translation.MessageType, op
 			translation.MessageTypeAuthenticatePrimaryOOB,
 			translation.MessageTypeAuthenticateSecondaryOOB:
 
-			linkURL = s.Endpoints.LoginLinkVerificationEndpointURL()
+			if opts.Form == FormMagicLink {
+				linkURL = s.Endpoints.MagicLinkEndpointURL()
+			} else {
+				linkURL = s.Endpoints.LoginLinkVerificationEndpointURL()
+			}
 			query := linkURL.Query()
 			query.Set("code", opts.OTP)
 			linkURL.RawQuery = query.Encode()
@@ -133,31 +140,31 @@ func (s *MessageSender) selectMessage(form Form, typ translation.MessageType) *t
 	case translation.MessageTypeVerification:
 		spec = translation.MessageVerification
 	case translation.MessageTypeSetupPrimaryOOB:
-		if form == FormLink {
+		if form.IsLink() {
 			spec = translation.MessageSetupPrimaryLoginLink
 		} else {
 			spec = translation.MessageSetupPrimaryOOB
 		}
 	case translation.MessageTypeSetupSecondaryOOB:
-		if form == FormLink {
+		if form.IsLink() {
 			spec = translation.MessageSetupSecondaryLoginLink
 		} else {
 			spec = translation.MessageSetupSecondaryOOB
 		}
 	case translation.MessageTypeAuthenticatePrimaryOOB:
-		if form == FormLink {
+		if form.IsLink() {
 			spec = translation.MessageAuthenticatePrimaryLoginLink
 		} else {
 			spec = translation.MessageAuthenticatePrimaryOOB
 		}
 	case translation.MessageTypeAuthenticateSecondaryOOB:
-		if form == FormLink {
+		if form.IsLink() {
 			spec = translation.MessageAuthenticateSecondaryLoginLink
 		} else {
 			spec = translation.MessageAuthenticateSecondaryOOB
 		}
 	case translation.MessageTypeForgotPassword:
-		if form == FormLink {
+		if form.IsLink() {
 			spec = translation.MessageForgotPasswordLink
 		} else {
 			spec = translation.MessageForgotPasswordOOB
@@ -194,22 +201,36 @@ func (s *MessageSender) sendEmail(ctx context.Context, opts SendOptions) error {
 		HTMLBody:  data.HTMLBody.String,
 	}
 
-	err = s.Sender.SendEmailInNewGoroutine(ctx, msgType, mailSendOptions)
+	err = s.Sender.SendEmailAsync(ctx, msgType, mailSendOptions)
 	return err
 }
 
 func (s *MessageSender) sendSMS(ctx context.Context, opts SendOptions, preferAsync bool) error {
+	msgType, smsSendOptions, err := s.prepareSMS(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if preferAsync {
+		err = s.Sender.SendSMSAsync(ctx, msgType, smsSendOptions)
+	} else {
+		err = s.Sender.SendSMSImmediately(ctx, msgType, smsSendOptions)
+	}
+	return err
+}
+
+func (s *MessageSender) prepareSMS(ctx context.Context, opts SendOptions) (translation.MessageType, *sms.SendOptions, error) {
 	spec := s.selectMessage(opts.Form, opts.Type)
 	msgType := spec.MessageType
 
 	variables, err := s.setupTemplateContext(msgType, opts)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	data, err := s.Translation.SMSMessageData(ctx, spec, variables)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	smsSendOptions := &sms.SendOptions{
@@ -221,12 +242,7 @@ func (s *MessageSender) sendSMS(ctx context.Context, opts SendOptions, preferAsy
 		LanguageTag:       data.Body.LanguageTag,
 		TemplateVariables: sms.NewTemplateVariablesFromPreparedTemplateVariables(data.PreparedTemplateVariables),
 	}
-	if preferAsync {
-		err = s.Sender.SendSMSInNewGoroutine(ctx, msgType, smsSendOptions)
-	} else {
-		err = s.Sender.SendSMSImmediately(ctx, msgType, smsSendOptions)
-	}
-	return err
+	return msgType, smsSendOptions, nil
 }
 
 func (s *MessageSender) sendWhatsapp(ctx context.Context, opts SendOptions) (err error) {
@@ -239,20 +255,57 @@ func (s *MessageSender) sendWhatsapp(ctx context.Context, opts SendOptions) (err
 		OTP: opts.OTP,
 	}
 
-	resultCallback := func(ctx context.Context, result *messaging.SendWhatsappResult) {
+	var fallbackSMS *messaging.WhatsappFallbackSMS
+	if s.WhatsappConfig.FallbackChannel == config.WhatsappFallbackChannelSMS {
+		fallbackSMS, err = s.prepareWhatsappFallbackSMS(ctx, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	callbackState, err := json.Marshal(whatsappCallbackState{
+		Purpose: opts.Kind.Purpose(),
+		Target:  opts.Target,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := s.Sender.SendWhatsappAsync(ctx, msgType, whatsappSendAuthenticationOTPOptions, fallbackSMS, callbackState)
+	if err != nil {
+		return err
+	}
+
+	// The message was suppressed, so the result is known now.
+	if result != nil {
 		_ = s.updateCodeAfterSent(ctx, opts, afterSentResult{
 			WhatsappMessageID: result.MessageID,
 		})
 	}
+	return nil
+}
+
+// prepareWhatsappFallbackSMS prepares the SMS that is sent after Whatsapp failed to send the OTP.
+// The Whatsapp template has no SMS counterpart, so the SMS of opts.FallbackType is sent,
+// which is the message type before it was replaced with the Whatsapp one.
+func (s *MessageSender) prepareWhatsappFallbackSMS(ctx context.Context, opts SendOptions) (*messaging.WhatsappFallbackSMS, error) {
+	fallbackOpts := opts
+	fallbackOpts.Channel = model.AuthenticatorOOBChannelSMS
+	fallbackOpts.Form = FormCode
+	fallbackOpts.Type = opts.FallbackType
+	if fallbackOpts.Type == "" {
+		fallbackOpts.Type = translation.MessageTypeVerification
+	}
 
-	errorCallback := func(ctx context.Context, err error) {
-		_ = s.updateCodeAfterSent(ctx, opts, afterSentResult{
-			SendError: err,
-		})
+	msgType, smsSendOptions, err := s.prepareSMS(ctx, fallbackOpts)
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.Sender.SendWhatsappInNewGoroutine(ctx, msgType, whatsappSendAuthenticationOTPOptions, resultCallback, errorCallback)
-	return err
+	return &messaging.WhatsappFallbackSMS{
+		MessageType: msgType,
+		SMS:         smsSendOptions,
+	}, nil
 }
 
 type afterSentResult struct {
@@ -261,8 +314,12 @@ type afterSentResult struct {
 }
 
 func (s *MessageSender) updateCodeAfterSent(ctx context.Context, opts SendOptions, result afterSentResult) error {
+	return updateCodeAfterSent(ctx, s.CodeStore, opts.Kind.Purpose(), opts.Target, opts.Channel, result)
+}
+
+func updateCodeAfterSent(ctx context.Context, store SenderCodeStore, purpose Purpose, target string, channel model.AuthenticatorOOBChannel, result afterSentResult) error {
 	logger := SenderLogger.GetLogger(ctx)
-	code, err := s.CodeStore.Get(ctx, opts.Kind.Purpose(), opts.Target)
+	code, err := store.Get(ctx, purpose, target)
 	if err != nil {
 		logger.WithError(err).Error(ctx, "failed to get code in result callback")
 		return err
@@ -277,8 +334,8 @@ func (s *MessageSender) updateCodeAfterSent(ctx context.Context, opts SendOption
 	if result.WhatsappMessageID != "" {
 		code.WhatsappMessageID = result.WhatsappMessageID
 	}
-	code.OOBChannel = opts.Channel
-	err = s.CodeStore.Update(ctx, opts.Kind.Purpose(), code)
+	code.OOBChannel = channel
+	err = store.Update(ctx, purpose, code)
 	if err != nil {
 		logger.WithError(err).Error(ctx, "failed to update code in result callback")
 		return err
@@ -207,6 +207,7 @@ func (s *Service) newCode(kind Kind, target string, form Form, opts *GenerateOpt
 
 		UserID:                                 opts.UserID,
 		WorkflowID:                             opts.WorkflowID,
+		AuthenticationFlowID:                   opts.AuthenticationFlowID,
 		AuthenticationFlowWebsocketChannelName: opts.AuthenticationFlowWebsocketChannelName,
 		AuthenticationFlowType:                 opts.AuthenticationFlowType,
 		AuthenticationFlowName:                 opts.AuthenticationFlowName,
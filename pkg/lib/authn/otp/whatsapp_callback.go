@@ -0,0 +1,47 @@
+package otp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/messaging"
+)
+
+// whatsappCallbackState identifies the code of a Whatsapp message delivered by the queue.
+type whatsappCallbackState struct {
+	Purpose Purpose `json:"purpose"`
+	Target  string  `json:"target"`
+}
+
+// WhatsappDeliveryCallback updates the code once the Whatsapp message is delivered by the queue.
+type WhatsappDeliveryCallback struct {
+	CodeStore SenderCodeStore
+}
+
+var _ messaging.WhatsappDeliveryCallback = &WhatsappDeliveryCallback{}
+
+func (c *WhatsappDeliveryCallback) OnWhatsappSent(ctx context.Context, state json.RawMessage, result *messaging.SendWhatsappResult) error {
+	return c.update(ctx, state, model.AuthenticatorOOBChannelWhatsapp, afterSentResult{
+		WhatsappMessageID: result.MessageID,
+	})
+}
+
+func (c *WhatsappDeliveryCallback) OnWhatsappFallbackSMSSent(ctx context.Context, state json.RawMessage) error {
+	return c.update(ctx, state, model.AuthenticatorOOBChannelSMS, afterSentResult{})
+}
+
+func (c *WhatsappDeliveryCallback) OnWhatsappError(ctx context.Context, state json.RawMessage, err error) error {
+	return c.update(ctx, state, model.AuthenticatorOOBChannelWhatsapp, afterSentResult{
+		SendError: err,
+	})
+}
+
+func (c *WhatsappDeliveryCallback) update(ctx context.Context, state json.RawMessage, channel model.AuthenticatorOOBChannel, result afterSentResult) error {
+	var s whatsappCallbackState
+	err := json.Unmarshal(state, &s)
+	if err != nil {
+		return err
+	}
+	return updateCodeAfterSent(ctx, c.CodeStore, s.Purpose, s.Target, channel, result)
+}
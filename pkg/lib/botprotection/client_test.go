@@ -0,0 +1,262 @@
+package botprotection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+type testRequest struct {
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// newTestServer responds with statusCode and body, and records the last request.
+func newTestServer(statusCode int, body string, req *testRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Path = r.URL.Path
+		req.Header = r.Header
+		req.Body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestHCaptchaClient(t *testing.T) {
+	Convey("HCaptchaClient", t, func() {
+		ctx := context.Background()
+		credentials := &config.BotProtectionProviderCredentials{SecretKey: "secret"}
+
+		verify := func(body string) (*HCaptchaResponse, error) {
+			var req testRequest
+			server := newTestServer(http.StatusOK, body, &req)
+			defer server.Close()
+
+			c := &HCaptchaClient{
+				HTTPClient:     server.Client(),
+				Credentials:    credentials,
+				VerifyEndpoint: server.URL,
+			}
+			resp, err := c.Verify(ctx, "token", "1.2.3.4", "sitekey")
+
+			form, parseErr := url.ParseQuery(string(req.Body))
+			So(parseErr, ShouldBeNil)
+			So(form.Get("secret"), ShouldEqual, "secret")
+			So(form.Get("response"), ShouldEqual, "token")
+			So(form.Get("sitekey"), ShouldEqual, "sitekey")
+			So(form.Get("remoteip"), ShouldEqual, "1.2.3.4")
+
+			return resp, err
+		}
+
+		Convey("should pass", func() {
+			resp, err := verify(`{"success":true,"hostname":"example.com"}`)
+			So(err, ShouldBeNil)
+			So(resp.Hostname, ShouldEqual, "example.com")
+		})
+
+		Convey("should fail with error codes", func() {
+			_, err := verify(`{"success":false,"error-codes":["invalid-input-response"]}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "invalid-input-response")
+		})
+
+		Convey("should return internal error for unexpected response", func() {
+			_, err := verify(`{"success":false}`)
+			So(err, ShouldNotBeNil)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeFalse)
+		})
+	})
+}
+
+func TestRecaptchaV3Client(t *testing.T) {
+	Convey("RecaptchaV3Client", t, func() {
+		ctx := context.Background()
+		credentials := &config.BotProtectionProviderCredentials{SecretKey: "secret"}
+		hostnames := []string{"auth.example.com", "app.example.com"}
+
+		verify := func(body string) (*RecaptchaV3Response, error) {
+			var req testRequest
+			server := newTestServer(http.StatusOK, body, &req)
+			defer server.Close()
+
+			c := &RecaptchaV3Client{
+				HTTPClient:     server.Client(),
+				Credentials:    credentials,
+				VerifyEndpoint: server.URL,
+			}
+			resp, err := c.Verify(ctx, "token", "1.2.3.4", hostnames, 0.5)
+
+			form, parseErr := url.ParseQuery(string(req.Body))
+			So(parseErr, ShouldBeNil)
+			So(form.Get("secret"), ShouldEqual, "secret")
+			So(form.Get("response"), ShouldEqual, "token")
+			So(form.Get("remoteip"), ShouldEqual, "1.2.3.4")
+
+			return resp, err
+		}
+
+		Convey("should pass", func() {
+			resp, err := verify(`{"success":true,"score":0.9,"action":"authenticate","hostname":"app.example.com"}`)
+			So(err, ShouldBeNil)
+			So(*resp.Score, ShouldEqual, 0.9)
+		})
+
+		Convey("should fail if score is below threshold", func() {
+			_, err := verify(`{"success":true,"score":0.1,"action":"authenticate","hostname":"auth.example.com"}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+			var scoreErr *ScoreBelowThresholdError
+			So(errors.As(err, &scoreErr), ShouldBeTrue)
+		})
+
+		Convey("should fail if action is different", func() {
+			_, err := verify(`{"success":true,"score":0.9,"action":"submit","hostname":"auth.example.com"}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+			var mismatchErr *TokenMismatchError
+			So(errors.As(err, &mismatchErr), ShouldBeTrue)
+			So(mismatchErr.Property, ShouldEqual, "action")
+		})
+
+		Convey("should fail if hostname is different", func() {
+			_, err := verify(`{"success":true,"score":0.9,"action":"authenticate","hostname":"evil.example.com"}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+			var mismatchErr *TokenMismatchError
+			So(errors.As(err, &mismatchErr), ShouldBeTrue)
+			So(mismatchErr.Property, ShouldEqual, "hostname")
+		})
+
+		Convey("should fail with error codes", func() {
+			_, err := verify(`{"success":false,"error-codes":["timeout-or-duplicate"]}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+		})
+
+		Convey("should return internal error if score is missing", func() {
+			_, err := verify(`{"success":true,"action":"authenticate","hostname":"auth.example.com"}`)
+			So(err, ShouldNotBeNil)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeFalse)
+		})
+	})
+}
+
+func TestRecaptchaEnterpriseClient(t *testing.T) {
+	Convey("RecaptchaEnterpriseClient", t, func() {
+		ctx := context.Background()
+		credentials := &config.BotProtectionProviderCredentials{SecretKey: "apikey"}
+		hostnames := []string{"auth.example.com"}
+		provider := &config.BotProtectionProvider{
+			Type:      config.BotProtectionProviderTypeRecaptchaEnterprise,
+			SiteKey:   "sitekey",
+			ProjectID: "project",
+		}
+
+		verify := func(statusCode int, body string) (*RecaptchaEnterpriseAssessment, error) {
+			var req testRequest
+			server := newTestServer(statusCode, body, &req)
+			defer server.Close()
+
+			c := &RecaptchaEnterpriseClient{
+				HTTPClient:  server.Client(),
+				Credentials: credentials,
+				Endpoint:    server.URL,
+			}
+			assessment, err := c.Verify(ctx, "token", "1.2.3.4", hostnames, provider)
+
+			So(req.Path, ShouldEqual, "/v1/projects/project/assessments")
+			So(req.Header.Get("X-Goog-Api-Key"), ShouldEqual, "apikey")
+			var assessmentRequest recaptchaEnterpriseAssessmentRequest
+			So(json.Unmarshal(req.Body, &assessmentRequest), ShouldBeNil)
+			So(assessmentRequest.Event, ShouldResemble, recaptchaEnterpriseEvent{
+				Token:          "token",
+				SiteKey:        "sitekey",
+				ExpectedAction: "authenticate",
+				UserIPAddress:  "1.2.3.4",
+			})
+
+			return assessment, err
+		}
+
+		Convey("should pass", func() {
+			assessment, err := verify(http.StatusOK, `{
+				"tokenProperties": {"valid": true, "action": "authenticate", "hostname": "auth.example.com"},
+				"riskAnalysis": {"score": 0.9}
+			}`)
+			So(err, ShouldBeNil)
+			So(assessment.RiskAnalysis.Score, ShouldEqual, 0.9)
+		})
+
+		Convey("should fail if token is invalid", func() {
+			_, err := verify(http.StatusOK, `{
+				"tokenProperties": {"valid": false, "invalidReason": "EXPIRED"}
+			}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "EXPIRED")
+		})
+
+		Convey("should fail if score is below threshold", func() {
+			_, err := verify(http.StatusOK, `{
+				"tokenProperties": {"valid": true, "action": "authenticate", "hostname": "auth.example.com"},
+				"riskAnalysis": {"score": 0.1}
+			}`)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeTrue)
+		})
+
+		Convey("should fail if action is different", func() {
+			_, err := verify(http.StatusOK, `{
+				"tokenProperties": {"valid": true, "action": "submit", "hostname": "auth.example.com"},
+				"riskAnalysis": {"score": 0.9}
+			}`)
+			var mismatchErr *TokenMismatchError
+			So(errors.As(err, &mismatchErr), ShouldBeTrue)
+			So(mismatchErr.Property, ShouldEqual, "action")
+		})
+
+		Convey("should fail if hostname is different", func() {
+			_, err := verify(http.StatusOK, `{
+				"tokenProperties": {"valid": true, "action": "authenticate", "hostname": "evil.example.com"},
+				"riskAnalysis": {"score": 0.9}
+			}`)
+			var mismatchErr *TokenMismatchError
+			So(errors.As(err, &mismatchErr), ShouldBeTrue)
+			So(mismatchErr.Property, ShouldEqual, "hostname")
+		})
+
+		Convey("should be service unavailable if the server errors", func() {
+			_, err := verify(http.StatusServiceUnavailable, `{}`)
+			So(errors.Is(err, ErrVerificationServiceUnavailable), ShouldBeTrue)
+		})
+
+		Convey("should return internal error if the request is rejected", func() {
+			_, err := verify(http.StatusForbidden, `{}`)
+			So(err, ShouldNotBeNil)
+			So(errors.Is(err, ErrVerificationServiceUnavailable), ShouldBeFalse)
+			So(errors.Is(err, ErrVerificationFailed), ShouldBeFalse)
+		})
+	})
+}
+
+func TestProviderHostnames(t *testing.T) {
+	Convey("Provider.hostnames", t, func() {
+		p := &Provider{
+			HTTPHost: "auth.example.com:3000",
+			OAuthConfig: &config.OAuthConfig{
+				Clients: []config.OAuthClientConfig{
+					{ClientID: "a", CustomUIURI: "https://app.example.com/auth"},
+					{ClientID: "b"},
+					{ClientID: "c", CustomUIURI: "https://app.example.com/other"},
+				},
+			},
+		}
+		So(p.hostnames(), ShouldResemble, []string{"auth.example.com", "app.example.com"})
+	})
+}
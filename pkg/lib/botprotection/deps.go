@@ -8,4 +8,9 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(Provider), "*"),
 	NewCloudflareClient,
 	NewRecaptchaV2Client,
+	NewRecaptchaV3Client,
+	NewRecaptchaEnterpriseClient,
+	NewHCaptchaClient,
+	NewPoWClient,
+	wire.Struct(new(PoWStore), "*"),
 )
@@ -1,9 +1,49 @@
 package botprotection
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
 )
 
 var ErrVerificationFailed = apierrors.Forbidden.WithReason("BotProtectionVerificationFailed").New("bot protection verification failed")
 
 var ErrVerificationServiceUnavailable = apierrors.ServiceUnavailable.WithReason("BotProtectionVerificationServiceUnavailable").New("bot protection service unavailable")
+
+// ScoreBelowThresholdError is the reason of a failed verification
+// by a score-based provider.
+type ScoreBelowThresholdError struct {
+	Score     float64
+	Threshold float64
+}
+
+func (e *ScoreBelowThresholdError) Error() string {
+	return fmt.Sprintf("score %v is below threshold %v", e.Score, e.Threshold)
+}
+
+// TokenMismatchError is the reason of a failed verification
+// when the token was issued for another action or hostname.
+type TokenMismatchError struct {
+	Property string
+	Actual   string
+	Expected []string
+}
+
+func (e *TokenMismatchError) Error() string {
+	return fmt.Sprintf("%v %q is not one of %q", e.Property, e.Actual, e.Expected)
+}
+
+func checkTokenProperty(property string, actual string, expected []string) error {
+	if slices.Contains(expected, actual) {
+		return nil
+	}
+	return errors.Join(ErrVerificationFailed, &TokenMismatchError{
+		Property: property,
+		Actual:   actual,
+		Expected: expected,
+	})
+}
+
+var ErrPoWNotEnabled = apierrors.NotFound.WithReason("BotProtectionPoWNotEnabled").New("proof-of-work bot protection is not enabled")
@@ -0,0 +1,41 @@
+package botprotection
+
+import (
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/util/slice"
+)
+
+// raw API response from hcaptcha
+type HCaptchaResponse struct {
+	Success *bool `json:"success,omitempty"`
+
+	// specific to Success == false
+	ErrorCodes []HCaptchaErrorCode `json:"error-codes,omitempty"`
+
+	// specific to Success == true
+	ChallengeTs string `json:"challenge_ts,omitempty"` // timestamp of the challenge (ISO format yyyy-MM-dd'T'HH:mm:ssZZ)
+	Hostname    string `json:"hostname,omitempty"`     // the hostname of the site where the challenge was solved
+}
+
+// returns a comma separated string of error codes
+func (e *HCaptchaResponse) Error() string {
+	errorCodeStrings := slice.Map(e.ErrorCodes, func(c HCaptchaErrorCode) string {
+		return string(c)
+	})
+
+	return strings.Join(errorCodeStrings, ",")
+}
+
+type HCaptchaErrorCode string
+
+const (
+	HCaptchaErrorCodeMissingInputSecret           HCaptchaErrorCode = "missing-input-secret"
+	HCaptchaErrorCodeInvalidInputSecret           HCaptchaErrorCode = "invalid-input-secret"
+	HCaptchaErrorCodeMissingInputResponse         HCaptchaErrorCode = "missing-input-response"
+	HCaptchaErrorCodeInvalidInputResponse         HCaptchaErrorCode = "invalid-input-response"
+	HCaptchaErrorCodeBadRequest                   HCaptchaErrorCode = "bad-request"
+	HCaptchaErrorCodeInvalidOrAlreadySeenResponse HCaptchaErrorCode = "invalid-or-already-seen-response"
+	HCaptchaErrorCodeNotUsingDummyPasscode        HCaptchaErrorCode = "not-using-dummy-passcode"
+	HCaptchaErrorCodeSitekeySecretMismatch        HCaptchaErrorCode = "sitekey-secret-mismatch"
+)
@@ -0,0 +1,171 @@
+package botprotection
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/base32"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/crypto"
+	"github.com/authgear/authgear-server/pkg/util/duration"
+	"github.com/authgear/authgear-server/pkg/util/rand"
+)
+
+// PoWChallengeLifetime is how long a client has to solve a challenge and submit it.
+const PoWChallengeLifetime = duration.UserInteraction
+
+var errPoWMalformedToken = errors.New("malformed proof-of-work token")
+var errPoWInvalidSignature = errors.New("invalid proof-of-work challenge signature")
+var errPoWChallengeExpired = errors.New("proof-of-work challenge expired")
+var errPoWInsufficientDifficulty = errors.New("proof-of-work challenge difficulty is lower than required")
+var errPoWInvalidSolution = errors.New("invalid proof-of-work solution")
+var errPoWChallengeUsed = errors.New("proof-of-work challenge already used")
+
+type PoWChallenge struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpireAt   time.Time `json:"expire_at"`
+}
+
+// powChallenge is a parsed challenge.
+// Its string form is <expire_at>.<difficulty>.<random>.<signature>,
+// where signature is HMAC-SHA256 of the preceding part keyed by the secret key.
+type powChallenge struct {
+	ExpireAt   time.Time
+	Difficulty int
+	Random     string
+}
+
+func (c powChallenge) payload() string {
+	return fmt.Sprintf("%d.%d.%s", c.ExpireAt.Unix(), c.Difficulty, c.Random)
+}
+
+func signPoWChallenge(secret []byte, c powChallenge) string {
+	payload := c.payload()
+	return payload + "." + crypto.HMACSHA256String(secret, []byte(payload))
+}
+
+// parsePoWToken parses a token of the form <challenge>:<nonce>,
+// and verifies the signature of the challenge.
+func parsePoWToken(secret []byte, token string) (challenge string, c *powChallenge, err error) {
+	challenge, _, ok := strings.Cut(token, ":")
+	if !ok {
+		return "", nil, errPoWMalformedToken
+	}
+
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 4 {
+		return "", nil, errPoWMalformedToken
+	}
+
+	expireAtUnix, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", nil, errPoWMalformedToken
+	}
+	difficulty, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", nil, errPoWMalformedToken
+	}
+
+	c = &powChallenge{
+		ExpireAt:   time.Unix(expireAtUnix, 0).UTC(),
+		Difficulty: difficulty,
+		Random:     parts[2],
+	}
+
+	expected := crypto.HMACSHA256String(secret, []byte(c.payload()))
+	if !hmac.Equal([]byte(expected), []byte(parts[3])) {
+		return "", nil, errPoWInvalidSignature
+	}
+
+	return challenge, c, nil
+}
+
+// leadingZeroBits counts the number of leading zero bits of b.
+func leadingZeroBits(b []byte) int {
+	n := 0
+	for _, x := range b {
+		if x != 0 {
+			return n + bits.LeadingZeros8(x)
+		}
+		n += 8
+	}
+	return n
+}
+
+// isPoWSolved tells whether SHA-256 of token has at least difficulty leading zero bits.
+func isPoWSolved(token string, difficulty int) bool {
+	h := sha256.Sum256([]byte(token))
+	return leadingZeroBits(h[:]) >= difficulty
+}
+
+type PoWClient struct {
+	Credentials *config.BotProtectionProviderCredentials
+	Clock       clock.Clock
+	Store       *PoWStore
+}
+
+func NewPoWClient(c *config.BotProtectionProviderCredentials, clock clock.Clock, store *PoWStore) *PoWClient {
+	if c == nil {
+		return nil
+	}
+	return &PoWClient{
+		Credentials: c,
+		Clock:       clock,
+		Store:       store,
+	}
+}
+
+func (c *PoWClient) NewChallenge(difficulty int) *PoWChallenge {
+	pc := powChallenge{
+		ExpireAt:   c.Clock.NowUTC().Add(PoWChallengeLifetime).Truncate(time.Second),
+		Difficulty: difficulty,
+		Random:     rand.StringWithAlphabet(32, base32.Alphabet, rand.SecureRand),
+	}
+	return &PoWChallenge{
+		Challenge:  signPoWChallenge([]byte(c.Credentials.SecretKey), pc),
+		Difficulty: pc.Difficulty,
+		ExpireAt:   pc.ExpireAt,
+	}
+}
+
+// Verify verifies token, which is a challenge issued by NewChallenge and a nonce joined by a colon.
+// Each challenge can be used once only.
+func (c *PoWClient) Verify(ctx context.Context, token string, difficulty int) error {
+	challenge, pc, err := parsePoWToken([]byte(c.Credentials.SecretKey), token)
+	if err != nil {
+		return errors.Join(ErrVerificationFailed, err)
+	}
+
+	now := c.Clock.NowUTC()
+	if !now.Before(pc.ExpireAt) {
+		return errors.Join(ErrVerificationFailed, errPoWChallengeExpired)
+	}
+
+	// The difficulty may have been raised after the challenge was issued.
+	if pc.Difficulty < difficulty {
+		return errors.Join(ErrVerificationFailed, errPoWInsufficientDifficulty)
+	}
+
+	if !isPoWSolved(token, pc.Difficulty) {
+		return errors.Join(ErrVerificationFailed, errPoWInvalidSolution)
+	}
+
+	consumed, err := c.Store.Consume(ctx, challenge, pc.ExpireAt.Sub(now))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return errors.Join(ErrVerificationFailed, errPoWChallengeUsed)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package botprotection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis/appredis"
+	"github.com/authgear/authgear-server/pkg/util/crypto"
+)
+
+type PoWStore struct {
+	AppID config.AppID
+	Redis *appredis.Handle
+}
+
+// Consume marks challenge as used until ttl elapses.
+// It returns false if challenge has been used before.
+func (s *PoWStore) Consume(ctx context.Context, challenge string, ttl time.Duration) (consumed bool, err error) {
+	key := powChallengeKey(s.AppID, crypto.SHA256String(challenge))
+	err = s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		consumed, err = conn.SetNX(ctx, key, []byte{}, ttl).Result()
+		return err
+	})
+	return
+}
+
+func powChallengeKey(appID config.AppID, challengeHash string) string {
+	return fmt.Sprintf("app:%s:bot-protection-pow-challenge:%s", appID, challengeHash)
+}
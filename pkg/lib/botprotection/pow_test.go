@@ -0,0 +1,80 @@
+package botprotection
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLeadingZeroBits(t *testing.T) {
+	Convey("leadingZeroBits", t, func() {
+		So(leadingZeroBits([]byte{0x80}), ShouldEqual, 0)
+		So(leadingZeroBits([]byte{0x01}), ShouldEqual, 7)
+		So(leadingZeroBits([]byte{0x00, 0x10}), ShouldEqual, 11)
+		So(leadingZeroBits([]byte{0x00, 0x00}), ShouldEqual, 16)
+		So(leadingZeroBits(nil), ShouldEqual, 0)
+	})
+}
+
+func TestParsePoWToken(t *testing.T) {
+	Convey("parsePoWToken", t, func() {
+		secret := []byte("secret")
+		c := powChallenge{
+			ExpireAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Difficulty: 8,
+			Random:     "abcdef",
+		}
+		challenge := signPoWChallenge(secret, c)
+
+		Convey("parse a signed challenge", func() {
+			actualChallenge, actual, err := parsePoWToken(secret, challenge+":123")
+			So(err, ShouldBeNil)
+			So(actualChallenge, ShouldEqual, challenge)
+			So(*actual, ShouldResemble, c)
+		})
+
+		Convey("reject a challenge signed by another secret", func() {
+			_, _, err := parsePoWToken([]byte("another"), challenge+":123")
+			So(err, ShouldEqual, errPoWInvalidSignature)
+		})
+
+		Convey("reject a tampered difficulty", func() {
+			tampered := c
+			tampered.Difficulty = 1
+			forged := tampered.payload() + challenge[len(c.payload()):]
+			_, _, err := parsePoWToken(secret, forged+":123")
+			So(err, ShouldEqual, errPoWInvalidSignature)
+		})
+
+		Convey("reject a token without nonce", func() {
+			_, _, err := parsePoWToken(secret, challenge)
+			So(err, ShouldEqual, errPoWMalformedToken)
+		})
+
+		Convey("reject a malformed challenge", func() {
+			_, _, err := parsePoWToken(secret, "a.b.c:123")
+			So(err, ShouldEqual, errPoWMalformedToken)
+		})
+	})
+}
+
+func TestIsPoWSolved(t *testing.T) {
+	Convey("isPoWSolved", t, func() {
+		challenge := "challenge"
+
+		var solution string
+		for i := 0; ; i++ {
+			token := challenge + ":" + strconv.Itoa(i)
+			if isPoWSolved(token, 8) {
+				solution = token
+				break
+			}
+		}
+
+		So(isPoWSolved(solution, 8), ShouldBeTrue)
+		So(isPoWSolved(solution, 0), ShouldBeTrue)
+		So(isPoWSolved(challenge+":", 256), ShouldBeFalse)
+	})
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/url"
 
 	"github.com/authgear/authgear-server/pkg/api/event"
 	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slice"
 	"github.com/authgear/authgear-server/pkg/util/slogutil"
 )
 
@@ -20,11 +23,17 @@ type EventService interface {
 }
 
 type Provider struct {
-	RemoteIP          httputil.RemoteIP
-	Config            *config.BotProtectionConfig
-	CloudflareClient  *CloudflareClient
-	RecaptchaV2Client *RecaptchaV2Client
-	Events            EventService
+	RemoteIP                  httputil.RemoteIP
+	HTTPHost                  httputil.HTTPHost
+	Config                    *config.BotProtectionConfig
+	OAuthConfig               *config.OAuthConfig
+	CloudflareClient          *CloudflareClient
+	RecaptchaV2Client         *RecaptchaV2Client
+	RecaptchaV3Client         *RecaptchaV3Client
+	RecaptchaEnterpriseClient *RecaptchaEnterpriseClient
+	HCaptchaClient            *HCaptchaClient
+	PoWClient                 *PoWClient
+	Events                    EventService
 }
 
 func (p *Provider) Verify(ctx context.Context, token string) (err error) {
@@ -40,6 +49,14 @@ func (p *Provider) Verify(ctx context.Context, token string) (err error) {
 		err = p.verifyTokenByCloudflare(ctx, token)
 	case config.BotProtectionProviderTypeRecaptchaV2:
 		err = p.verifyTokenByRecaptchaV2(ctx, token)
+	case config.BotProtectionProviderTypeRecaptchaV3:
+		err = p.verifyTokenByRecaptchaV3(ctx, token)
+	case config.BotProtectionProviderTypeRecaptchaEnterprise:
+		err = p.verifyTokenByRecaptchaEnterprise(ctx, token)
+	case config.BotProtectionProviderTypeHCaptcha:
+		err = p.verifyTokenByHCaptcha(ctx, token)
+	case config.BotProtectionProviderTypePoW:
+		err = p.verifyTokenByPoW(ctx, token)
 	default:
 		panic(fmt.Errorf("unknown bot_protection provider"))
 	}
@@ -88,3 +105,87 @@ func (p *Provider) verifyTokenByRecaptchaV2(ctx context.Context, token string) e
 
 	return nil
 }
+
+func (p *Provider) verifyTokenByRecaptchaV3(ctx context.Context, token string) error {
+	if p.RecaptchaV3Client == nil {
+		return fmt.Errorf("missing recaptchaV3 credentials")
+	}
+
+	_, err := p.RecaptchaV3Client.Verify(ctx, token, string(p.RemoteIP), p.hostnames(), p.Config.Provider.GetScoreThreshold())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provider) verifyTokenByRecaptchaEnterprise(ctx context.Context, token string) error {
+	if p.RecaptchaEnterpriseClient == nil {
+		return fmt.Errorf("missing recaptcha enterprise credentials")
+	}
+
+	_, err := p.RecaptchaEnterpriseClient.Verify(ctx, token, string(p.RemoteIP), p.hostnames(), p.Config.Provider)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// hostnames returns the hostnames a token can be obtained on.
+// They are the hostname of Authgear and the hostnames of the custom UIs.
+func (p *Provider) hostnames() []string {
+	hostnames := []string{hostnameOf(string(p.HTTPHost))}
+	for _, client := range p.OAuthConfig.Clients {
+		if client.CustomUIURI == "" {
+			continue
+		}
+		u, err := url.Parse(client.CustomUIURI)
+		if err != nil {
+			continue
+		}
+		hostnames = append(hostnames, u.Hostname())
+	}
+	return slice.Deduplicate(hostnames)
+}
+
+// hostnameOf removes the port from host.
+func hostnameOf(host string) string {
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+	return hostname
+}
+
+func (p *Provider) verifyTokenByHCaptcha(ctx context.Context, token string) error {
+	if p.HCaptchaClient == nil {
+		return fmt.Errorf("missing hcaptcha credentials")
+	}
+
+	_, err := p.HCaptchaClient.Verify(ctx, token, string(p.RemoteIP), p.Config.Provider.SiteKey)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Provider) verifyTokenByPoW(ctx context.Context, token string) error {
+	if p.PoWClient == nil {
+		return fmt.Errorf("missing proof-of-work credentials")
+	}
+
+	return p.PoWClient.Verify(ctx, token, p.Config.Provider.GetDifficulty())
+}
+
+// NewPoWChallenge issues a challenge for the proof-of-work provider.
+func (p *Provider) NewPoWChallenge() (*PoWChallenge, error) {
+	if !p.Config.IsEnabled() || p.Config.Provider.Type != config.BotProtectionProviderTypePoW {
+		return nil, ErrPoWNotEnabled
+	}
+	if p.PoWClient == nil {
+		return nil, fmt.Errorf("missing proof-of-work credentials")
+	}
+	return p.PoWClient.NewChallenge(p.Config.Provider.GetDifficulty()), nil
+}
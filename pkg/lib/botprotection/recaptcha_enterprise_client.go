@@ -0,0 +1,139 @@
+package botprotection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+const (
+	RecaptchaEnterpriseEndpoint string = "https://recaptchaenterprise.googleapis.com"
+)
+
+type RecaptchaEnterpriseClient struct {
+	HTTPClient  *http.Client
+	Credentials *config.BotProtectionProviderCredentials
+	Endpoint    string
+}
+
+func NewRecaptchaEnterpriseClient(c *config.BotProtectionProviderCredentials, e *config.EnvironmentConfig) *RecaptchaEnterpriseClient {
+	if c == nil {
+		return nil
+	}
+	ept := RecaptchaEnterpriseEndpoint
+	if e.End2EndBotProtection.RecaptchaEnterpriseEndpoint != "" {
+		ept = e.End2EndBotProtection.RecaptchaEnterpriseEndpoint
+	}
+	return &RecaptchaEnterpriseClient{
+		HTTPClient:  httputil.NewExternalClient(60 * time.Second),
+		Endpoint:    ept,
+		Credentials: c,
+	}
+}
+
+type recaptchaEnterpriseAssessmentRequest struct {
+	Event recaptchaEnterpriseEvent `json:"event"`
+}
+
+type recaptchaEnterpriseEvent struct {
+	Token          string `json:"token"`
+	SiteKey        string `json:"siteKey"`
+	ExpectedAction string `json:"expectedAction,omitempty"`
+	UserIPAddress  string `json:"userIpAddress,omitempty"`
+}
+
+// Verify creates an assessment.
+// The token must be executed with RecaptchaAction on one of hostnames.
+// See https://cloud.google.com/recaptcha/docs/reference/rest/v1/projects.assessments/create
+func (c *RecaptchaEnterpriseClient) Verify(ctx context.Context, token string, remoteip string, hostnames []string, provider *config.BotProtectionProvider) (*RecaptchaEnterpriseAssessment, error) {
+	reqBody, err := json.Marshal(recaptchaEnterpriseAssessmentRequest{
+		Event: recaptchaEnterpriseEvent{
+			Token:          token,
+			SiteKey:        provider.SiteKey,
+			ExpectedAction: RecaptchaAction,
+			UserIPAddress:  remoteip,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u = u.JoinPath("v1", "projects", provider.ProjectID, "assessments")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Pass the API key in the header instead of the query,
+	// so that it does not appear in the error message of the request.
+	req.Header.Set("X-Goog-Api-Key", c.Credentials.SecretKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Join(ErrVerificationServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	httpBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Join(ErrVerificationServiceUnavailable, fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	if resp.StatusCode >= 500 {
+		return nil, errors.Join(ErrVerificationServiceUnavailable, fmt.Errorf("unexpected status code %v: %v", resp.StatusCode, string(httpBodyBytes)))
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Most likely the API key or the project ID is invalid.
+		return nil, fmt.Errorf("unexpected status code %v: %v", resp.StatusCode, string(httpBodyBytes)) // internal server error
+	}
+
+	assessment := &RecaptchaEnterpriseAssessment{}
+	err = json.Unmarshal(httpBodyBytes, &assessment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err) // internal server error
+	}
+
+	if assessment.TokenProperties == nil {
+		return nil, fmt.Errorf("unexpected response body: %v", string(httpBodyBytes)) // internal server error
+	}
+
+	if !assessment.TokenProperties.Valid {
+		return nil, errors.Join(ErrVerificationFailed, assessment)
+	}
+
+	// expectedAction only annotates the assessment, so the action is checked here.
+	if err := checkTokenProperty("action", assessment.TokenProperties.Action, []string{RecaptchaAction}); err != nil {
+		return nil, err
+	}
+	if err := checkTokenProperty("hostname", assessment.TokenProperties.Hostname, hostnames); err != nil {
+		return nil, err
+	}
+
+	if assessment.RiskAnalysis == nil {
+		return nil, fmt.Errorf("unexpected response body: %v", string(httpBodyBytes)) // internal server error
+	}
+
+	scoreThreshold := provider.GetScoreThreshold()
+	if assessment.RiskAnalysis.Score < scoreThreshold {
+		return nil, errors.Join(ErrVerificationFailed, &ScoreBelowThresholdError{
+			Score:     assessment.RiskAnalysis.Score,
+			Threshold: scoreThreshold,
+		})
+	}
+
+	return assessment, nil
+}
@@ -0,0 +1,29 @@
+package botprotection
+
+// raw API response from recaptcha enterprise
+type RecaptchaEnterpriseAssessment struct {
+	Name            string                              `json:"name,omitempty"`
+	TokenProperties *RecaptchaEnterpriseTokenProperties `json:"tokenProperties,omitempty"`
+	RiskAnalysis    *RecaptchaEnterpriseRiskAnalysis    `json:"riskAnalysis,omitempty"`
+}
+
+type RecaptchaEnterpriseTokenProperties struct {
+	Valid         bool   `json:"valid"`
+	InvalidReason string `json:"invalidReason,omitempty"`
+	Hostname      string `json:"hostname,omitempty"`
+	Action        string `json:"action,omitempty"`
+	CreateTime    string `json:"createTime,omitempty"`
+}
+
+type RecaptchaEnterpriseRiskAnalysis struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// returns the reason why the token is invalid
+func (e *RecaptchaEnterpriseAssessment) Error() string {
+	if e.TokenProperties == nil {
+		return ""
+	}
+	return e.TokenProperties.InvalidReason
+}
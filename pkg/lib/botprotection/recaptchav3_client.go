@@ -0,0 +1,105 @@
+package botprotection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+const (
+	// reCAPTCHA v3 shares the siteverify API with reCAPTCHA v2.
+	RecaptchaV3VerifyEndpoint string = "https://www.google.com/recaptcha/api/siteverify"
+)
+
+// RecaptchaAction is the action the web UI executes reCAPTCHA v3 and reCAPTCHA Enterprise with.
+// It must be kept in sync with RECAPTCHA_V3_ACTION in authui.
+const RecaptchaAction = "authenticate"
+
+type RecaptchaV3Client struct {
+	HTTPClient     *http.Client
+	Credentials    *config.BotProtectionProviderCredentials
+	VerifyEndpoint string
+}
+
+func NewRecaptchaV3Client(c *config.BotProtectionProviderCredentials, e *config.EnvironmentConfig) *RecaptchaV3Client {
+	if c == nil {
+		return nil
+	}
+	ept := RecaptchaV3VerifyEndpoint
+	if e.End2EndBotProtection.RecaptchaV3Endpoint != "" {
+		ept = e.End2EndBotProtection.RecaptchaV3Endpoint
+	}
+	return &RecaptchaV3Client{
+		HTTPClient:     httputil.NewExternalClient(60 * time.Second),
+		VerifyEndpoint: ept,
+		Credentials:    c,
+	}
+}
+
+// Verify verifies token, which must be executed with RecaptchaAction on one of hostnames.
+func (c *RecaptchaV3Client) Verify(ctx context.Context, token string, remoteip string, hostnames []string, scoreThreshold float64) (*RecaptchaV3Response, error) {
+	formValues := url.Values{}
+	formValues.Add("secret", c.Credentials.SecretKey)
+	formValues.Add("response", token)
+
+	if remoteip != "" {
+		formValues.Add("remoteip", remoteip)
+	}
+
+	resp, err := httputil.PostFormWithContext(ctx, c.HTTPClient, c.VerifyEndpoint, formValues)
+	if err != nil {
+		return nil, errors.Join(ErrVerificationServiceUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	httpBodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Join(ErrVerificationServiceUnavailable, fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	respBody := &RecaptchaV3Response{}
+	err = json.Unmarshal(httpBodyBytes, &respBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err) // internal server error
+	}
+
+	if respBody.Success == nil {
+		return nil, fmt.Errorf("unexpected response body: %v", string(httpBodyBytes)) // internal server error
+	}
+
+	if *respBody.Success {
+		if respBody.Score == nil {
+			return nil, fmt.Errorf("unexpected response body: %v", string(httpBodyBytes)) // internal server error
+		}
+		// The secret key is shared by all the sites using the same key,
+		// so the token could be obtained elsewhere.
+		if err := checkTokenProperty("action", respBody.Action, []string{RecaptchaAction}); err != nil {
+			return nil, err
+		}
+		if err := checkTokenProperty("hostname", respBody.Hostname, hostnames); err != nil {
+			return nil, err
+		}
+		if *respBody.Score < scoreThreshold {
+			return nil, errors.Join(ErrVerificationFailed, &ScoreBelowThresholdError{
+				Score:     *respBody.Score,
+				Threshold: scoreThreshold,
+			})
+		}
+		return respBody, nil
+	}
+
+	// failed
+	if len(respBody.ErrorCodes) == 0 {
+		return nil, fmt.Errorf("unexpected response body: %v", string(httpBodyBytes)) // internal server error
+	}
+
+	return nil, errors.Join(ErrVerificationFailed, respBody)
+}
@@ -0,0 +1,31 @@
+package botprotection
+
+import (
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/util/slice"
+)
+
+// raw API response from recaptchav3
+type RecaptchaV3Response struct {
+	Success *bool `json:"success,omitempty"`
+
+	// specific to Success == false
+	// The error codes are the same as recaptchav2.
+	ErrorCodes []RecaptchaV2ErrorCode `json:"error-codes"`
+
+	// specific to Success == true
+	Score       *float64 `json:"score,omitempty"` // the score for this request (0.0 - 1.0)
+	Action      string   `json:"action"`          // the action name for this request
+	ChallengeTs string   `json:"challenge_ts"`    // timestamp of the challenge load (ISO format yyyy-MM-dd'T'HH:mm:ssZZ)
+	Hostname    string   `json:"hostname"`        // hostname for which the challenge was served.
+}
+
+// returns a comma separated string of error codes
+func (e *RecaptchaV3Response) Error() string {
+	errorCodeStrings := slice.Map(e.ErrorCodes, func(c RecaptchaV2ErrorCode) string {
+		return string(c)
+	})
+
+	return strings.Join(errorCodeStrings, ",")
+}
@@ -18,6 +18,10 @@ var _ = Schema.Add("AccountLinkingConfig", `
 		"login_id": {
 			"type": "array",
 			"items": { "$ref": "#/$defs/AccountLinkingLoginIDItem" }
+		},
+		"saml": {
+			"type": "array",
+			"items": { "$ref": "#/$defs/AccountLinkingSAMLItem" }
 		}
 	}
 }
@@ -64,6 +68,20 @@ var _ = Schema.Add("AccountLinkingLoginIDItem", `
 }
 `)
 
+var _ = Schema.Add("AccountLinkingSAMLItem", `
+{
+	"type": "object",
+	"required": ["identity_provider_name", "saml_claim", "user_profile", "action"],
+	"properties": {
+		"name": { "type": "string" },
+		"identity_provider_name": { "type": "string" },
+		"saml_claim": { "$ref": "#/$defs/AccountLinkingJSONPointer" },
+		"user_profile": { "$ref": "#/$defs/AccountLinkingJSONPointer" },
+		"action": { "$ref": "#/$defs/AccountLinkingAction" }
+	}
+}
+`)
+
 var _ = Schema.Add("AccountLinkingAction", `
 {
 	"type": "string",
@@ -99,6 +117,7 @@ var _ = Schema.Add("AccountLinkingJSONPointer", `
 type AccountLinkingConfig struct {
 	OAuth   []*AccountLinkingOAuthItem   `json:"oauth,omitempty"`
 	LoginID []*AccountLinkingLoginIDItem `json:"login_id,omitempty"`
+	SAML    []*AccountLinkingSAMLItem    `json:"saml,omitempty"`
 }
 
 type AccountLinkingLoginIDItem struct {
@@ -123,6 +142,14 @@ type AccountLinkingOAuthItem struct {
 	Action      AccountLinkingAction       `json:"action,omitempty"`
 }
 
+type AccountLinkingSAMLItem struct {
+	Name                 string                     `json:"name,omitempty"`
+	IdentityProviderName string                     `json:"identity_provider_name,omitempty"`
+	SAMLClaim            *AccountLinkingJSONPointer `json:"saml_claim,omitempty"`
+	UserProfile          *AccountLinkingJSONPointer `json:"user_profile,omitempty"`
+	Action               AccountLinkingAction       `json:"action,omitempty"`
+}
+
 type AccountLinkingAction string
 
 const (
@@ -154,6 +181,12 @@ var DefaultAccountLinkingOAuthItem = &AccountLinkingOAuthItem{
 	Action:      AccountLinkingActionError,
 }
 
+var DefaultAccountLinkingSAMLItem = &AccountLinkingSAMLItem{
+	SAMLClaim:   &AccountLinkingJSONPointer{Pointer: "/email"},
+	UserProfile: &AccountLinkingJSONPointer{Pointer: "/email"},
+	Action:      AccountLinkingActionError,
+}
+
 var DefaultAccountLinkingLDAPEmailItem = &AccountLinkingLDAPItem{
 	AttributeName: &AccountLinkingJSONPointer{Pointer: "/email"},
 	UserProfile:   &AccountLinkingJSONPointer{Pointer: "/email"},
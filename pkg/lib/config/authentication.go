@@ -130,7 +130,7 @@ var _ = Schema.Add("AuthenticationConfig", `
 var _ = Schema.Add("IdentityType", `
 {
 	"type": "string",
-	"enum": ["login_id", "oauth", "anonymous", "biometric", "passkey", "siwe", "ldap"]
+	"enum": ["login_id", "oauth", "anonymous", "biometric", "passkey", "siwe", "ldap", "saml"]
 }
 `)
 
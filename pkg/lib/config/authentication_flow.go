@@ -169,7 +169,8 @@ var _ = Schema.Add("AuthenticationFlowSignupFlowIdentify", `
 				"username",
 				"oauth",
 				"passkey",
-				"ldap"
+				"ldap",
+				"saml"
 			]
 		},
 		"bot_protection": { "$ref": "#/$defs/AuthenticationFlowBotProtection" },
@@ -253,7 +254,8 @@ var _ = Schema.Add("AuthenticationFlowLoginFlowStep", `
 				"check_account_status",
 				"terminate_other_sessions",
 				"change_password",
-				"prompt_create_passkey"
+				"prompt_create_passkey",
+				"select_organization"
 			]
 		}
 	},
@@ -325,6 +327,7 @@ var _ = Schema.Add("AuthenticationFlowLoginFlowIdentify", `
 				"oauth",
 				"passkey",
 				"ldap",
+				"saml",
 				"id_token",
 				"select_account"
 			]
@@ -360,11 +363,31 @@ var _ = Schema.Add("AuthenticationFlowLoginFlowAuthenticate", `
 		},
 		"bot_protection": { "$ref": "#/$defs/AuthenticationFlowBotProtection" },
 		"target_step": { "$ref": "#/$defs/AuthenticationFlowObjectName" },
+		"otp_form": { "$ref": "#/$defs/AuthenticationFlowOTPForm" },
 		"steps": {
 			"type": "array",
 			"items": { "$ref": "#/$defs/AuthenticationFlowLoginFlowStep" }
 		}
-	}
+	},
+	"allOf": [
+		{
+			"if": {
+				"required": ["otp_form"]
+			},
+			"then": {
+				"properties": {
+					"authentication": { "const": "primary_oob_otp_email" }
+				}
+			}
+		}
+	]
+}
+`)
+
+var _ = Schema.Add("AuthenticationFlowOTPForm", `
+{
+	"type": "string",
+	"enum": ["code", "link", "magic_link"]
 }
 `)
 
@@ -430,6 +453,7 @@ var _ = Schema.Add("AuthenticationFlowSignupLoginFlowIdentify", `
 				"oauth",
 				"passkey",
 				"ldap",
+				"saml",
 				"id_token",
 				"select_account"
 			]
@@ -682,6 +706,10 @@ var _ = Schema.Add("AuthenticationFlowAccountLinking", `
 		"login_id": {
 			"type": "array",
 			"items": { "$ref": "#/$defs/AuthenticationFlowAccountLinkingLoginIDItem" }
+		},
+		"saml": {
+			"type": "array",
+			"items": { "$ref": "#/$defs/AuthenticationFlowAccountLinkingSAMLItem" }
 		}
 	}
 }
@@ -711,6 +739,18 @@ var _ = Schema.Add("AuthenticationFlowAccountLinkingLoginIDItem", `
 }
 `)
 
+var _ = Schema.Add("AuthenticationFlowAccountLinkingSAMLItem", `
+{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": { "type": "string" },
+		"action": { "$ref": "#/$defs/AccountLinkingAction" },
+		"login_flow": { "type": "string" }
+	}
+}
+`)
+
 type AuthenticationFlowObject interface {
 	IsFlowObject()
 }
@@ -771,6 +811,7 @@ const (
 	AuthenticationFlowStepTypeSelectDestination         AuthenticationFlowStepType = "select_destination"
 	AuthenticationFlowStepTypeVerifyAccountRecoveryCode AuthenticationFlowStepType = "verify_account_recovery_code"
 	AuthenticationFlowStepTypeResetPassword             AuthenticationFlowStepType = "reset_password"
+	AuthenticationFlowStepTypeSelectOrganization        AuthenticationFlowStepType = "select_organization"
 )
 
 type AuthenticationFlowConfig struct {
@@ -968,6 +1009,7 @@ const (
 	AuthenticationFlowLoginFlowStepTypeTerminateOtherSessions = AuthenticationFlowLoginFlowStepType(AuthenticationFlowStepTypeTerminateOtherSessions)
 	AuthenticationFlowLoginFlowStepTypeChangePassword         = AuthenticationFlowLoginFlowStepType(AuthenticationFlowStepTypeChangePassword)
 	AuthenticationFlowLoginFlowStepTypePromptCreatePasskey    = AuthenticationFlowLoginFlowStepType(AuthenticationFlowStepTypePromptCreatePasskey)
+	AuthenticationFlowLoginFlowStepTypeSelectOrganization     = AuthenticationFlowLoginFlowStepType(AuthenticationFlowStepTypeSelectOrganization)
 )
 
 type AuthenticationFlowLoginFlowStep struct {
@@ -1042,6 +1084,9 @@ type AuthenticationFlowLoginFlowOneOf struct {
 	Authentication model.AuthenticationFlowAuthentication `json:"authentication,omitempty"`
 	// TargetStep is specific to authenticate.
 	TargetStep string `json:"target_step,omitempty"`
+	// OTPForm is specific to authenticate with primary_oob_otp_email.
+	// When it is absent, authenticator.oob_otp.email.email_otp_mode is used.
+	OTPForm AuthenticationFlowOTPForm `json:"otp_form,omitempty"`
 
 	// BotProtection is common
 	BotProtection *AuthenticationFlowBotProtection `json:"bot_protection,omitempty" nullable:"true"`
@@ -1089,6 +1134,16 @@ func (f *AuthenticationFlowLoginFlowOneOf) GetTargetStepName() string {
 	return f.TargetStep
 }
 
+type AuthenticationFlowOTPForm string
+
+const (
+	AuthenticationFlowOTPFormCode AuthenticationFlowOTPForm = "code"
+	AuthenticationFlowOTPFormLink AuthenticationFlowOTPForm = "link"
+	// AuthenticationFlowOTPFormMagicLink sends a link which completes the flow
+	// on the device where the link is opened.
+	AuthenticationFlowOTPFormMagicLink AuthenticationFlowOTPForm = "magic_link"
+)
+
 type AuthenticationFlowSignupLoginFlow struct {
 	Name  string                                   `json:"name,omitempty"`
 	Steps []*AuthenticationFlowSignupLoginFlowStep `json:"steps,omitempty"`
@@ -1431,6 +1486,7 @@ const (
 type AuthenticationFlowAccountLinking struct {
 	OAuth   []*AuthenticationFlowAccountLinkingOAuthItem   `json:"oauth,omitempty"`
 	LoginID []*AuthenticationFlowAccountLinkingLoginIDItem `json:"login_id,omitempty"`
+	SAML    []*AuthenticationFlowAccountLinkingSAMLItem    `json:"saml,omitempty"`
 }
 
 type AuthenticationFlowAccountLinkingOAuthItem struct {
@@ -1445,6 +1501,12 @@ type AuthenticationFlowAccountLinkingLoginIDItem struct {
 	LoginFlow string               `json:"login_flow,omitempty"`
 }
 
+type AuthenticationFlowAccountLinkingSAMLItem struct {
+	Name      string               `json:"name,omitempty"`
+	Action    AccountLinkingAction `json:"action,omitempty"`
+	LoginFlow string               `json:"login_flow,omitempty"`
+}
+
 type AuthenticationFlowObjectAccountLinkingConfigProvider interface {
 	GetAccountLinkingConfig() *AuthenticationFlowAccountLinking
 }
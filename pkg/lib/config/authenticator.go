@@ -32,6 +32,7 @@ var _ = Schema.Add("AuthenticatorPasswordConfig", `
 		"policy": { "$ref": "#/$defs/PasswordPolicyConfig" },
 		"expiry": { "$ref": "#/$defs/PasswordExpiryConfig" },
 		"force_change": { "type": "boolean" },
+		"hash_algorithm": { "$ref": "#/$defs/PasswordHashAlgorithm" },
 		"ratelimit": { "$ref": "#/$defs/PasswordRatelimitConfig" }
 	}
 }
@@ -41,6 +42,7 @@ type AuthenticatorPasswordConfig struct {
 	Policy               *PasswordPolicyConfig    `json:"policy,omitempty"`
 	Expiry               *PasswordExpiryConfig    `json:"expiry,omitempty"`
 	ForceChange          *bool                    `json:"force_change,omitempty"`
+	HashAlgorithm        PasswordHashAlgorithm    `json:"hash_algorithm,omitempty"`
 	Deprecated_Ratelimit *PasswordRatelimitConfig `json:"ratelimit,omitempty"`
 }
 
@@ -49,9 +51,31 @@ func (c *AuthenticatorPasswordConfig) SetDefaults() {
 		c.ForceChange = new(true)
 	}
 
+	if c.HashAlgorithm == "" {
+		c.HashAlgorithm = PasswordHashAlgorithmBcryptSHA512
+	}
+
 	c.Deprecated_Ratelimit = nil
 }
 
+var _ = Schema.Add("PasswordHashAlgorithm", `
+{
+	"type": "string",
+	"enum": ["bcrypt_sha512", "argon2id", "scrypt", "pbkdf2_sha256"]
+}
+`)
+
+// PasswordHashAlgorithm is the algorithm used to hash new passwords.
+// Existing hashes of other algorithms are upgraded to it after a successful login.
+type PasswordHashAlgorithm string
+
+const (
+	PasswordHashAlgorithmBcryptSHA512 PasswordHashAlgorithm = "bcrypt_sha512"
+	PasswordHashAlgorithmArgon2id     PasswordHashAlgorithm = "argon2id"
+	PasswordHashAlgorithmScrypt       PasswordHashAlgorithm = "scrypt"
+	PasswordHashAlgorithmPBKDF2SHA256 PasswordHashAlgorithm = "pbkdf2_sha256"
+)
+
 var _ = Schema.Add("PasswordPolicyConfig", `
 {
 	"type": "object",
@@ -66,7 +90,8 @@ var _ = Schema.Add("PasswordPolicyConfig", `
 		"minimum_guessable_level": { "type": "integer" },
 		"excluded_keywords": { "type": "array", "items": { "type": "string" } },
 		"history_size": { "type": "integer" },
-		"history_days": { "$ref": "#/$defs/DurationDays" }
+		"history_days": { "$ref": "#/$defs/DurationDays" },
+		"breached_password_check": { "$ref": "#/$defs/PasswordBreachedPasswordCheckConfig" }
 	}
 }
 `)
@@ -82,6 +107,8 @@ type PasswordPolicyConfig struct {
 	ExcludedKeywords      []string     `json:"excluded_keywords,omitempty"`
 	HistorySize           int          `json:"history_size,omitempty"`
 	HistoryDays           DurationDays `json:"history_days,omitempty"`
+
+	BreachedPasswordCheck *PasswordBreachedPasswordCheckConfig `json:"breached_password_check,omitempty"`
 }
 
 func (c *PasswordPolicyConfig) IsEnabled() bool {
@@ -94,6 +121,48 @@ func (c *PasswordPolicyConfig) SetDefaults() {
 	}
 }
 
+var _ = Schema.Add("PasswordBreachedPasswordCheckConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"fail_mode": { "type": "string", "enum": ["open", "closed"] }
+	}
+}
+`)
+
+type BreachedPasswordCheckFailMode string
+
+const (
+	// BreachedPasswordCheckFailModeOpen accepts the password if the dataset is unavailable.
+	BreachedPasswordCheckFailModeOpen BreachedPasswordCheckFailMode = "open"
+	// BreachedPasswordCheckFailModeClosed rejects the password if the dataset is unavailable.
+	BreachedPasswordCheckFailModeClosed BreachedPasswordCheckFailMode = "closed"
+)
+
+// PasswordBreachedPasswordCheckConfig rejects passwords found in the Pwned Passwords dataset.
+// The new password is checked when it is set or changed.
+// The existing password is not checked on login.
+type PasswordBreachedPasswordCheckConfig struct {
+	Enabled  bool                          `json:"enabled,omitempty"`
+	FailMode BreachedPasswordCheckFailMode `json:"fail_mode,omitempty"`
+}
+
+func (c *PasswordBreachedPasswordCheckConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *PasswordBreachedPasswordCheckConfig) SetDefaults() {
+	if c.FailMode == "" {
+		c.FailMode = BreachedPasswordCheckFailModeOpen
+	}
+}
+
+func (c *PasswordBreachedPasswordCheckConfig) IsFailClosed() bool {
+	return c != nil && c.FailMode == BreachedPasswordCheckFailModeClosed
+}
+
 var _ = Schema.Add("PasswordExpiryConfig", `
 {
 	"type": "object",
@@ -1,5 +1,9 @@
 package config
 
+import (
+	"slices"
+)
+
 var _ = Schema.Add("BotProtectionConfig", `
 {
 	"type": "object",
@@ -18,15 +22,18 @@ var _ = Schema.Add("BotProtectionProvider", `
 	"additionalProperties": false,
 	"required": ["type"],
 	"properties": {
-		"type": { "type": "string", "enum": ["cloudflare", "recaptchav2"] },
-		"site_key": { "type": "string", "minLength": 1 }
+		"type": { "type": "string", "enum": ["cloudflare", "recaptchav2", "recaptchav3", "recaptcha_enterprise", "hcaptcha", "pow"] },
+		"site_key": { "type": "string", "minLength": 1 },
+		"score_threshold": { "type": "number", "minimum": 0, "maximum": 1 },
+		"project_id": { "type": "string", "minLength": 1 },
+		"difficulty": { "type": "integer", "minimum": 1, "maximum": 32 }
 	},
 	"allOf": [
 		{
 			"if": {
 				"properties": {
 					"type": {
-						"enum": ["cloudflare", "recaptchav2"]
+						"enum": ["cloudflare", "recaptchav2", "recaptchav3", "recaptcha_enterprise", "hcaptcha"]
 					}
 				},
 				"required": ["type"]
@@ -34,6 +41,19 @@ var _ = Schema.Add("BotProtectionProvider", `
 			"then": {
 				"required": ["site_key"]
 			}
+		},
+		{
+			"if": {
+				"properties": {
+					"type": {
+						"const": "recaptcha_enterprise"
+					}
+				},
+				"required": ["type"]
+			},
+			"then": {
+				"required": ["project_id"]
+			}
 		}
 	]
 }
@@ -67,7 +87,7 @@ var _ = Schema.Add("BotProtectionRequirementsObject", `
 var _ = Schema.Add("BotProtectionRiskMode", `
 {
 	"type": "string",
-	"enum": ["never", "always"]
+	"enum": ["never", "always", "risk_level_medium", "risk_level_high"]
 }
 `)
 
@@ -78,7 +98,11 @@ type BotProtectionConfig struct {
 }
 
 func (c *BotProtectionConfig) IsEnabled() bool {
-	return c != nil && c.Enabled && c.Provider != nil && c.Provider.Type != "" && c.Provider.SiteKey != ""
+	if c == nil || !c.Enabled || c.Provider == nil || c.Provider.Type == "" {
+		return false
+	}
+	// The proof-of-work provider is self-hosted, so it has no site key.
+	return c.Provider.Type == BotProtectionProviderTypePoW || c.Provider.SiteKey != ""
 }
 func (c *BotProtectionConfig) GetProviderType() BotProtectionProviderType {
 	if !c.IsEnabled() {
@@ -95,14 +119,43 @@ func (c *BotProtectionConfig) GetSiteKey() string {
 
 type BotProtectionProvider struct {
 	Type    BotProtectionProviderType `json:"type,omitempty"`
-	SiteKey string                    `json:"site_key,omitempty"` // only for cloudflare, recaptchav2
+	SiteKey string                    `json:"site_key,omitempty"` // for all providers except pow
+	// ScoreThreshold is the minimum score to pass. Only for recaptchav3, recaptcha_enterprise.
+	ScoreThreshold *float64 `json:"score_threshold,omitempty"`
+	// ProjectID is the Google Cloud project ID. Only for recaptcha_enterprise.
+	ProjectID string `json:"project_id,omitempty"`
+	// Difficulty is the number of leading zero bits required in the hash. Only for pow.
+	Difficulty *int `json:"difficulty,omitempty"`
+}
+
+const (
+	DefaultBotProtectionScoreThreshold = 0.5
+	DefaultBotProtectionPoWDifficulty  = 18
+)
+
+func (p *BotProtectionProvider) GetScoreThreshold() float64 {
+	if p.ScoreThreshold == nil {
+		return DefaultBotProtectionScoreThreshold
+	}
+	return *p.ScoreThreshold
+}
+
+func (p *BotProtectionProvider) GetDifficulty() int {
+	if p.Difficulty == nil {
+		return DefaultBotProtectionPoWDifficulty
+	}
+	return *p.Difficulty
 }
 
 type BotProtectionProviderType string
 
 const (
-	BotProtectionProviderTypeCloudflare  BotProtectionProviderType = "cloudflare"
-	BotProtectionProviderTypeRecaptchaV2 BotProtectionProviderType = "recaptchav2"
+	BotProtectionProviderTypeCloudflare          BotProtectionProviderType = "cloudflare"
+	BotProtectionProviderTypeRecaptchaV2         BotProtectionProviderType = "recaptchav2"
+	BotProtectionProviderTypeRecaptchaV3         BotProtectionProviderType = "recaptchav3"
+	BotProtectionProviderTypeRecaptchaEnterprise BotProtectionProviderType = "recaptcha_enterprise"
+	BotProtectionProviderTypeHCaptcha            BotProtectionProviderType = "hcaptcha"
+	BotProtectionProviderTypePoW                 BotProtectionProviderType = "pow"
 )
 
 type BotProtectionRequirements struct {
@@ -117,20 +170,32 @@ type BotProtectionRequirementsObject struct {
 	Mode BotProtectionRiskMode `json:"mode,omitempty"`
 }
 
-// NOTE: If you add any new BotProtectionRiskMode, please make corresponding changes in GetStricterBotProtectionRiskMode too
+// NOTE: If you add any new BotProtectionRiskMode, please make corresponding changes in botProtectionRiskModeStrictness too
 type BotProtectionRiskMode string
 
 const (
 	BotProtectionRiskModeNever  BotProtectionRiskMode = "never"
 	BotProtectionRiskModeAlways BotProtectionRiskMode = "always"
+	// BotProtectionRiskModeRiskLevelMedium requires bot protection when the risk level is medium or above.
+	BotProtectionRiskModeRiskLevelMedium BotProtectionRiskMode = "risk_level_medium"
+	// BotProtectionRiskModeRiskLevelHigh requires bot protection when the risk level is high.
+	BotProtectionRiskModeRiskLevelHigh BotProtectionRiskMode = "risk_level_high"
 )
 
+// botProtectionRiskModeStrictness orders the modes from the least strict to the most strict.
+var botProtectionRiskModeStrictness = []BotProtectionRiskMode{
+	BotProtectionRiskModeNever,
+	BotProtectionRiskModeRiskLevelHigh,
+	BotProtectionRiskModeRiskLevelMedium,
+	BotProtectionRiskModeAlways,
+}
+
 func GetStricterBotProtectionRiskMode(rmA BotProtectionRiskMode, rmB BotProtectionRiskMode) BotProtectionRiskMode {
-	if rmA == BotProtectionRiskModeAlways || rmB == BotProtectionRiskModeAlways {
-		return BotProtectionRiskModeAlways
+	strictest := BotProtectionRiskModeNever
+	for _, rm := range []BotProtectionRiskMode{rmA, rmB} {
+		if slices.Index(botProtectionRiskModeStrictness, rm) > slices.Index(botProtectionRiskModeStrictness, strictest) {
+			strictest = rm
+		}
 	}
-
-	// Add more risk modes here
-
-	return BotProtectionRiskModeNever
+	return strictest
 }
@@ -3,4 +3,9 @@ package config
 type End2EndBotProtectionEnvironmentConfig struct {
 	CloudflareEndpoint  string `envconfig:"E2E_BOT_PROTECTION_CLOUDFLARE_ENDPOINT"`
 	RecaptchaV2Endpoint string `envconfig:"E2E_BOT_PROTECTION_RECAPTCHAV2_ENDPOINT"`
+	// RecaptchaV3Endpoint is separate from RecaptchaV2Endpoint
+	// even though they share the same siteverify API in production.
+	RecaptchaV3Endpoint         string `envconfig:"E2E_BOT_PROTECTION_RECAPTCHAV3_ENDPOINT"`
+	RecaptchaEnterpriseEndpoint string `envconfig:"E2E_BOT_PROTECTION_RECAPTCHA_ENTERPRISE_ENDPOINT"`
+	HCaptchaEndpoint            string `envconfig:"E2E_BOT_PROTECTION_HCAPTCHA_ENDPOINT"`
 }
@@ -0,0 +1,25 @@
+package config_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func TestGetStricterBotProtectionRiskMode(t *testing.T) {
+	Convey("GetStricterBotProtectionRiskMode", t, func() {
+		test := func(a config.BotProtectionRiskMode, b config.BotProtectionRiskMode, expected config.BotProtectionRiskMode) {
+			So(config.GetStricterBotProtectionRiskMode(a, b), ShouldEqual, expected)
+			So(config.GetStricterBotProtectionRiskMode(b, a), ShouldEqual, expected)
+		}
+
+		test("", "", config.BotProtectionRiskModeNever)
+		test("", config.BotProtectionRiskModeNever, config.BotProtectionRiskModeNever)
+		test(config.BotProtectionRiskModeNever, config.BotProtectionRiskModeRiskLevelHigh, config.BotProtectionRiskModeRiskLevelHigh)
+		test(config.BotProtectionRiskModeRiskLevelHigh, config.BotProtectionRiskModeRiskLevelMedium, config.BotProtectionRiskModeRiskLevelMedium)
+		test(config.BotProtectionRiskModeRiskLevelMedium, config.BotProtectionRiskModeAlways, config.BotProtectionRiskModeAlways)
+		test(config.BotProtectionRiskModeNever, config.BotProtectionRiskModeAlways, config.BotProtectionRiskModeAlways)
+	})
+}
@@ -55,6 +55,7 @@ var _ = Schema.Add("AppConfig", `
 		"bot_protection": { "$ref": "#/$defs/BotProtectionConfig" },
 		"network_protection": { "$ref": "#/$defs/NetworkProtectionConfig" },
 		"fraud_protection": { "$ref": "#/$defs/FraudProtectionConfig" },
+		"risk": { "$ref": "#/$defs/RiskConfig" },
 		"test_mode": { "$ref": "#/$defs/TestModeConfig" },
 		"authentication_flow": { "$ref": "#/$defs/AuthenticationFlowConfig" },
 		"external_jwt": { "$ref": "#/$defs/ExternalJWTConfig" }
@@ -101,6 +102,7 @@ type AppConfig struct {
 	BotProtection     *BotProtectionConfig     `json:"bot_protection,omitempty"`
 	NetworkProtection *NetworkProtectionConfig `json:"network_protection,omitempty"`
 	FraudProtection   *FraudProtectionConfig   `json:"fraud_protection,omitempty"`
+	Risk              *RiskConfig              `json:"risk,omitempty"`
 
 	TestMode *TestModeConfig `json:"test_mode,omitempty"`
 
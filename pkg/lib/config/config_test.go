@@ -20,6 +20,8 @@ import (
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/facebook"
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/google"
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/linkedin"
+	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/oauth2"
+	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/oidc"
 	_ "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/wechat"
 )
 
@@ -627,8 +627,12 @@ func (d AuthgearSecretYAMLDescriptor) validateBasedOnFeatureConfig(secretConfig
 	validationCtx := &validation.Context{}
 
 	if *fc.Messaging.CustomSMTPDisabled {
-		if _, _, ok := secretConfig.Lookup(config.SMTPServerCredentialsKey); ok {
-			validationCtx.EmitErrorMessage("custom smtp is not allowed")
+		// CustomSMTPDisabled applies to all email providers.
+		for _, key := range config.EmailProviderSecretKeys {
+			if _, _, ok := secretConfig.Lookup(key); ok {
+				validationCtx.EmitErrorMessage("custom smtp is not allowed")
+				break
+			}
 		}
 	}
 
@@ -38,6 +38,10 @@ type GlobalWhatsappAPIType WhatsappAPIType
 
 type SharedAuthgearEndpoint string
 
+// TLSClientCertificateHeader is the name of the HTTP header
+// in which the TLS terminating proxy forwards the client certificate.
+type TLSClientCertificateHeader string
+
 func (s AppHostSuffixes) CheckIsDefaultDomain(host string) bool {
 	for _, suffix := range s {
 		if before, found := strings.CutSuffix(host, suffix); found {
@@ -136,6 +140,14 @@ type EnvironmentConfig struct {
 
 	SharedAuthgearEndpoint SharedAuthgearEndpoint `envconfig:"SHARED_AUTHGEAR_ENDPOINT"`
 
+	// TLSClientCertificateHeader enables self_signed_tls_client_auth.
+	// The header is expected to contain the URL-escaped PEM of the client certificate,
+	// for example, $ssl_client_escaped_cert of nginx.
+	TLSClientCertificateHeader TLSClientCertificateHeader `envconfig:"TLS_CLIENT_CERTIFICATE_HEADER"`
+
+	// PwnedPasswords configures the source of the breached password check.
+	PwnedPasswords PwnedPasswordsEnvironmentConfig `envconfig:"PWNED_PASSWORDS"`
+
 	// Analytic configures analytics forwarding (e.g. PostHog) from the server runtime.
 	Analytic AnalyticConfig `envconfig:"ANALYTIC"`
 }
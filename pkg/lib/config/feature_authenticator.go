@@ -66,7 +66,8 @@ var _ = FeatureConfigSchema.Add("PasswordPolicyFeatureConfig", `
 	"properties": {
 		"minimum_guessable_level": { "$ref": "#/$defs/PasswordPolicyItemFeatureConfig" },
 		"excluded_keywords": { "$ref": "#/$defs/PasswordPolicyItemFeatureConfig" },
-		"history": { "$ref": "#/$defs/PasswordPolicyItemFeatureConfig" }
+		"history": { "$ref": "#/$defs/PasswordPolicyItemFeatureConfig" },
+		"breached_password_check": { "$ref": "#/$defs/PasswordPolicyItemFeatureConfig" }
 	}
 }
 `)
@@ -75,6 +76,7 @@ type PasswordPolicyFeatureConfig struct {
 	MinimumGuessableLevel *PasswordPolicyItemFeatureConfig `json:"minimum_guessable_level,omitempty"`
 	ExcludedKeywords      *PasswordPolicyItemFeatureConfig `json:"excluded_keywords,omitempty"`
 	History               *PasswordPolicyItemFeatureConfig `json:"history,omitempty"`
+	BreachedPasswordCheck *PasswordPolicyItemFeatureConfig `json:"breached_password_check,omitempty"`
 }
 
 func (c *PasswordPolicyFeatureConfig) Merge(layer *PasswordPolicyFeatureConfig) *PasswordPolicyFeatureConfig {
@@ -96,6 +98,9 @@ func (c *PasswordPolicyFeatureConfig) Merge(layer *PasswordPolicyFeatureConfig)
 	if layer.History != nil {
 		c.History = layer.History
 	}
+	if layer.BreachedPasswordCheck != nil {
+		c.BreachedPasswordCheck = layer.BreachedPasswordCheck
+	}
 	return c
 }
 
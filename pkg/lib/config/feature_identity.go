@@ -143,7 +143,9 @@ var _ = FeatureConfigSchema.Add("OAuthSSOProvidersFeatureConfig", `
 		"azureadb2c": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" },
 		"adfs": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" },
 		"apple": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" },
-		"wechat": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" }
+		"wechat": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" },
+		"oidc": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" },
+		"oauth2": { "$ref": "#/$defs/OAuthSSOProviderFeatureConfig" }
 	}
 }
 `)
@@ -158,6 +160,8 @@ type OAuthSSOProvidersFeatureConfig struct {
 	ADFS       *OAuthSSOProviderFeatureConfig `json:"adfs,omitempty"`
 	Apple      *OAuthSSOProviderFeatureConfig `json:"apple,omitempty"`
 	Wechat     *OAuthSSOProviderFeatureConfig `json:"wechat,omitempty"`
+	OIDC       *OAuthSSOProviderFeatureConfig `json:"oidc,omitempty"`
+	OAuth2     *OAuthSSOProviderFeatureConfig `json:"oauth2,omitempty"`
 }
 
 func (c *OAuthSSOProvidersFeatureConfig) Merge(layer *OAuthSSOProvidersFeatureConfig) *OAuthSSOProvidersFeatureConfig {
@@ -197,6 +201,12 @@ func (c *OAuthSSOProvidersFeatureConfig) Merge(layer *OAuthSSOProvidersFeatureCo
 	if layer.Wechat != nil {
 		c.Wechat = layer.Wechat
 	}
+	if layer.OIDC != nil {
+		c.OIDC = layer.OIDC
+	}
+	if layer.OAuth2 != nil {
+		c.OAuth2 = layer.OAuth2
+	}
 	return c
 }
 
@@ -220,6 +230,10 @@ func (c *OAuthSSOProvidersFeatureConfig) IsDisabled(cfg oauthrelyingparty.Provid
 		return c.Apple.Disabled
 	case liboauthrelyingparty.TypeWechat:
 		return c.Wechat.Disabled
+	case liboauthrelyingparty.TypeOIDC:
+		return c.OIDC.Disabled
+	case liboauthrelyingparty.TypeOAuth2:
+		return c.OAuth2.Disabled
 	default:
 		// Not a provider we recognize here. Allow it.
 		return false
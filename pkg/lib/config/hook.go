@@ -41,10 +41,15 @@ var _ = Schema.Add("BlockingHookHandlersConfig", `
 			"user.pre_schedule_anonymization",
 			"oidc.jwt.pre_create",
 			"oidc.id_token.pre_create",
+			"oauth.token.pre_refresh",
 			"authentication.pre_initialize",
 			"authentication.post_identified",
-			"authentication.pre_authenticated"
-
+			"authentication.pre_authenticated",
+			"identity.pre_create",
+			"identity.pre_delete",
+			"authenticator.pre_create",
+			"authenticator.pre_delete",
+			"session.pre_create"
 		] },
 		"url": { "type": "string", "format": "x_hook_uri" }
 	},
@@ -94,6 +99,17 @@ var _ = Schema.Add("NonBlockingHookHandlersConfig", `
 					"identity.oauth.disconnected",
 					"identity.biometric.enabled",
 					"identity.biometric.disabled",
+					"identity.ldap.updated",
+					"identity.ldap.groups_synced",
+					"organization.created",
+					"organization.updated",
+					"organization.deleted",
+					"organization.member.added",
+					"organization.member.updated",
+					"organization.member.removed",
+					"organization.invitation.created",
+					"organization.invitation.deleted",
+					"organization.invitation.accepted",
 					"usage.alert.triggered"
 				]
 			}
@@ -15,6 +15,7 @@ var _ = Schema.Add("IdentityConfig", `
 		"ldap": { "$ref": "#/$defs/LDAPConfig" },
 		"login_id": { "$ref": "#/$defs/LoginIDConfig" },
 		"oauth": { "$ref": "#/$defs/OAuthSSOConfig" },
+		"saml": { "$ref": "#/$defs/SAMLIdentityConfig" },
 		"biometric": { "$ref": "#/$defs/BiometricConfig" },
 		"on_conflict": { "$ref": "#/$defs/IdentityConflictConfig" }
 	}
@@ -25,6 +26,7 @@ type IdentityConfig struct {
 	LDAP       *LDAPConfig             `json:"ldap,omitempty"`
 	LoginID    *LoginIDConfig          `json:"login_id,omitempty"`
 	OAuth      *OAuthSSOConfig         `json:"oauth,omitempty"`
+	SAML       *SAMLIdentityConfig     `json:"saml,omitempty"`
 	Biometric  *BiometricConfig        `json:"biometric,omitempty"`
 	OnConflict *IdentityConflictConfig `json:"on_conflict,omitempty"`
 }
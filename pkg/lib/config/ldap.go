@@ -38,15 +38,145 @@ var _ = Schema.Add("LDAPServerConfig", `
 		"url": { "type": "string", "format": "ldap_url" },
 		"base_dn": { "type": "string", "format": "ldap_dn" },
 		"search_filter_template": { "type": "string", "format": "ldap_search_filter_template" },
-		"user_id_attribute_name": { "type": "string", "format": "ldap_attribute_name" }
+		"user_id_attribute_name": { "type": "string", "format": "ldap_attribute_name" },
+		"group_sync": { "$ref": "#/$defs/LDAPGroupSyncConfig" },
+		"directory_sync": { "$ref": "#/$defs/LDAPDirectorySyncConfig" }
 	}
 }
 `)
 
 type LDAPServerConfig struct {
-	Name                 string `json:"name,omitempty"`
-	URL                  string `json:"url,omitempty"`
-	BaseDN               string `json:"base_dn,omitempty"`
-	SearchFilterTemplate string `json:"search_filter_template,omitempty"`
-	UserIDAttributeName  string `json:"user_id_attribute_name,omitempty"`
+	Name                 string                   `json:"name,omitempty"`
+	URL                  string                   `json:"url,omitempty"`
+	BaseDN               string                   `json:"base_dn,omitempty"`
+	SearchFilterTemplate string                   `json:"search_filter_template,omitempty"`
+	UserIDAttributeName  string                   `json:"user_id_attribute_name,omitempty"`
+	GroupSync            *LDAPGroupSyncConfig     `json:"group_sync,omitempty"`
+	DirectorySync        *LDAPDirectorySyncConfig `json:"directory_sync,omitempty"`
+}
+
+func (c *LDAPServerConfig) IsGroupSyncEnabled() bool {
+	return c.GroupSync != nil && c.GroupSync.Enabled
+}
+
+func (c *LDAPServerConfig) IsDirectorySyncEnabled() bool {
+	return c.DirectorySync != nil && c.DirectorySync.Enabled
+}
+
+var _ = Schema.Add("LDAPGroupSyncConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"member_of_attribute_name": { "type": "string", "format": "ldap_attribute_name" },
+		"group_search": { "$ref": "#/$defs/LDAPGroupSearchConfig" },
+		"mappings": {
+			"type": "array",
+			"items": { "$ref": "#/$defs/LDAPGroupMappingConfig" }
+		}
+	}
+}
+`)
+
+const DefaultLDAPMemberOfAttributeName = "memberOf"
+
+// LDAPGroupSyncConfig maps the LDAP groups of a user to Authgear groups and roles.
+// The LDAP groups of a user are the values of the member of attribute,
+// plus the results of the group search if it is configured.
+type LDAPGroupSyncConfig struct {
+	Enabled               bool                      `json:"enabled,omitempty"`
+	MemberOfAttributeName string                    `json:"member_of_attribute_name,omitempty"`
+	GroupSearch           *LDAPGroupSearchConfig    `json:"group_search,omitempty"`
+	Mappings              []*LDAPGroupMappingConfig `json:"mappings,omitempty"`
+}
+
+func (c *LDAPGroupSyncConfig) SetDefaults() {
+	if c.MemberOfAttributeName == "" {
+		c.MemberOfAttributeName = DefaultLDAPMemberOfAttributeName
+	}
+}
+
+var _ = Schema.Add("LDAPGroupSearchConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"required": ["filter_template"],
+	"properties": {
+		"base_dn": { "type": "string", "format": "ldap_dn" },
+		"filter_template": { "type": "string", "format": "ldap_group_search_filter_template" }
+	}
+}
+`)
+
+// LDAPGroupSearchConfig searches for groups having the user as a member.
+// It is for directories that do not maintain a member of attribute on users.
+// FilterTemplate is rendered with {{.DN}} being the DN of the user.
+// BaseDN defaults to the base DN of the server.
+type LDAPGroupSearchConfig struct {
+	BaseDN         string `json:"base_dn,omitempty"`
+	FilterTemplate string `json:"filter_template,omitempty"`
+}
+
+var _ = Schema.Add("LDAPGroupMappingConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"required": ["ldap_group"],
+	"properties": {
+		"ldap_group": { "type": "string", "format": "ldap_dn" },
+		"groups": {
+			"type": "array",
+			"items": { "type": "string", "format": "x_role_group_key" }
+		},
+		"roles": {
+			"type": "array",
+			"items": { "type": "string", "format": "x_role_group_key" }
+		}
+	}
+}
+`)
+
+// LDAPGroupMappingConfig grants Groups and Roles to members of LDAPGroup.
+type LDAPGroupMappingConfig struct {
+	LDAPGroup string   `json:"ldap_group,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+var _ = Schema.Add("LDAPDirectorySyncConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"user_filter": { "type": "string", "format": "ldap_filter" },
+		"disable_missing_users": { "type": "boolean" }
+	},
+	"allOf": [
+		{
+			"if": {
+				"properties": { "enabled": { "const": true } },
+				"required": ["enabled"]
+			},
+			"then": {
+				"required": ["user_filter"]
+			}
+		}
+	]
+}
+`)
+
+// LDAPDirectorySyncConfig configures the periodic directory sync.
+// UserFilter selects all the users of the directory under the base DN of the server.
+type LDAPDirectorySyncConfig struct {
+	Enabled             bool   `json:"enabled,omitempty"`
+	UserFilter          string `json:"user_filter,omitempty"`
+	DisableMissingUsers *bool  `json:"disable_missing_users,omitempty"`
+}
+
+func (c *LDAPDirectorySyncConfig) SetDefaults() {
+	if c.DisableMissingUsers == nil {
+		c.DisableMissingUsers = new(true)
+	}
 }
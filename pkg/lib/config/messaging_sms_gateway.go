@@ -20,7 +20,12 @@ var _ = Schema.Add("SMSGatewayConfig", `
 	"additionalProperties": false,
 	"properties": {
 		"use_config_from": { "$ref": "#/$defs/SMSGatewayConfigUseConfigFrom" },
-		"provider": { "$ref": "#/$defs/SMSProvider" }
+		"provider": { "$ref": "#/$defs/SMSProvider" },
+		"fallback_providers": {
+			"type": "array",
+			"items": { "$ref": "#/$defs/SMSProvider" },
+			"uniqueItems": true
+		}
 	},
 	"required": ["use_config_from"],
 	"allOf": [
@@ -38,4 +43,7 @@ var _ = Schema.Add("SMSGatewayConfig", `
 type SMSGatewayConfig struct {
 	UseConfigFrom SMSGatewayConfigUseConfigFrom `json:"use_config_from,omitempty"`
 	Provider      SMSProvider                   `json:"provider,omitempty"`
+	// FallbackProviders are tried in order when the provider fails to send.
+	// Their credentials are read from the same place as the provider.
+	FallbackProviders []SMSProvider `json:"fallback_providers,omitempty"`
 }
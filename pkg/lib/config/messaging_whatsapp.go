@@ -20,14 +20,30 @@ var _ = Schema.Add("WhatsappConfig", `
 	"additionalProperties": false,
 	"properties": {
 		"api_type": { "$ref": "#/$defs/WhatsappAPIType" },
-		"message_sent_callback_timeout": { "$ref": "#/$defs/DurationString" }
+		"message_sent_callback_timeout": { "$ref": "#/$defs/DurationString" },
+		"fallback_channel": { "$ref": "#/$defs/WhatsappFallbackChannel" }
 	}
 }
 `)
 
+var _ = Schema.Add("WhatsappFallbackChannel", `
+{
+	"type": "string",
+	"enum": ["sms"]
+}
+`)
+
+type WhatsappFallbackChannel string
+
+const (
+	WhatsappFallbackChannelSMS WhatsappFallbackChannel = "sms"
+)
+
 type WhatsappConfig struct {
 	APIType_NoDefault          WhatsappAPIType `json:"api_type,omitempty"`
 	MessageSentCallbackTimeout DurationString  `json:"message_sent_callback_timeout,omitempty"`
+	// FallbackChannel is used to send the OTP when Whatsapp fails to send it.
+	FallbackChannel WhatsappFallbackChannel `json:"fallback_channel,omitempty"`
 }
 
 func (c *WhatsappConfig) SetDefaults() {
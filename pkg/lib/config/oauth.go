@@ -1,5 +1,11 @@
 package config
 
+import (
+	"encoding/json"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
 var _ = Schema.Add("OAuthConfig", `
 {
 	"type": "object",
@@ -38,6 +44,29 @@ const (
 	OAuthClientApplicationTypeUnspecified    OAuthClientApplicationType = ""
 )
 
+// OAuthClientAuthMethod is the client authentication method used at the token endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type OAuthClientAuthMethod string
+
+const (
+	OAuthClientAuthMethodClientSecretBasic       OAuthClientAuthMethod = "client_secret_basic"
+	OAuthClientAuthMethodClientSecretPost        OAuthClientAuthMethod = "client_secret_post"
+	OAuthClientAuthMethodPrivateKeyJWT           OAuthClientAuthMethod = "private_key_jwt"
+	OAuthClientAuthMethodSelfSignedTLSClientAuth OAuthClientAuthMethod = "self_signed_tls_client_auth"
+	OAuthClientAuthMethodNone                    OAuthClientAuthMethod = "none"
+	OAuthClientAuthMethodUnspecified             OAuthClientAuthMethod = ""
+)
+
+// OAuthClientBackchannelTokenDeliveryMode is how the client obtains the result of
+// Client-Initiated Backchannel Authentication.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.5
+type OAuthClientBackchannelTokenDeliveryMode string
+
+const (
+	OAuthClientBackchannelTokenDeliveryModePoll OAuthClientBackchannelTokenDeliveryMode = "poll"
+	OAuthClientBackchannelTokenDeliveryModePing OAuthClientBackchannelTokenDeliveryMode = "ping"
+)
+
 func (t OAuthClientApplicationType) IsThirdParty() bool {
 	switch t {
 	case OAuthClientApplicationTypeSPA:
@@ -177,7 +206,18 @@ var _ = Schema.Add("OAuthClientConfig", `
 		"x_pre_authenticated_url_enabled": { "type": "boolean" },
 		"x_pre_authenticated_url_allowed_origins": { "type": "array", "items": { "type": "string", "format": "http_origin" } },
 		"logo_uri": { "type": "string", "format": "x_public_https_url" },
-		"x_replace_project_logo_with_logo_uri": { "type": "boolean" }
+		"x_replace_project_logo_with_logo_uri": { "type": "boolean" },
+		"jwks": { "$ref": "#/$defs/OAuthClientJWKS" },
+		"jwks_uri": { "type": "string", "format": "uri" },
+		"require_pushed_authorization_requests": { "type": "boolean" },
+		"token_endpoint_auth_method": {
+			"type": "string",
+			"enum": ["client_secret_basic", "client_secret_post", "private_key_jwt", "self_signed_tls_client_auth", "none"]
+		},
+		"backchannel_logout_uri": { "type": "string", "format": "uri" },
+		"frontchannel_logout_uri": { "type": "string", "format": "uri" },
+		"backchannel_token_delivery_mode": { "type": "string", "enum": ["poll", "ping"] },
+		"backchannel_client_notification_endpoint": { "type": "string", "format": "uri" }
 	},
 	"required": ["name", "client_id"],
 	"allOf": [
@@ -245,6 +285,33 @@ var _ = Schema.Add("OAuthClientConfig", `
 					}
 				}
 			}
+		},
+		{
+			"if": {
+				"properties": {
+					"token_endpoint_auth_method": {
+						"enum": ["private_key_jwt", "self_signed_tls_client_auth"]
+					}
+				},
+				"required": ["token_endpoint_auth_method"]
+			},
+			"then": {
+				"oneOf": [
+					{ "required": ["jwks"] },
+					{ "required": ["jwks_uri"] }
+				]
+			}
+		},
+		{
+			"if": {
+				"properties": {
+					"backchannel_token_delivery_mode": { "const": "ping" }
+				},
+				"required": ["backchannel_token_delivery_mode"]
+			},
+			"then": {
+				"required": ["backchannel_client_notification_endpoint"]
+			}
 		}
 	]
 }
@@ -254,38 +321,86 @@ type OAuthClientConfig struct {
 	ClientID  string `json:"client_id,omitempty"`
 	ClientURI string `json:"client_uri,omitempty"`
 	// client_name is for 3rd party app only. Use `name` for display name
-	ClientName                             string                       `json:"client_name,omitempty"`
-	Name                                   string                       `json:"name,omitempty"`
-	ApplicationType                        OAuthClientApplicationType   `json:"x_application_type,omitempty"`
-	Framework                              string                       `json:"x_framework,omitempty"`
-	MaxConcurrentSession                   int                          `json:"x_max_concurrent_session,omitempty"`
-	RedirectURIs                           []string                     `json:"redirect_uris,omitempty"`
-	GrantTypes_do_not_use_directly         []string                     `json:"grant_types,omitempty"`
-	ResponseTypes                          []string                     `json:"response_types,omitempty"`
-	PostLogoutRedirectURIs                 []string                     `json:"post_logout_redirect_uris,omitempty"`
-	AccessTokenLifetime                    DurationSeconds              `json:"access_token_lifetime_seconds,omitempty"`
-	RefreshTokenLifetime                   DurationSeconds              `json:"refresh_token_lifetime_seconds,omitempty"`
-	RefreshTokenIdleTimeoutEnabled         *bool                        `json:"refresh_token_idle_timeout_enabled,omitempty"`
-	RefreshTokenIdleTimeout                DurationSeconds              `json:"refresh_token_idle_timeout_seconds,omitempty"`
-	RefreshTokenRotationEnabled            bool                         `json:"refresh_token_rotation_enabled,omitempty"`
-	IssueJWTAccessToken                    bool                         `json:"issue_jwt_access_token,omitempty"`
-	PolicyURI                              string                       `json:"policy_uri,omitempty"`
-	TOSURI                                 string                       `json:"tos_uri,omitempty"`
-	CustomUIURI                            string                       `json:"x_custom_ui_uri,omitempty"`
-	App2appEnabled                         bool                         `json:"x_app2app_enabled,omitempty"`
-	App2appInsecureDeviceKeyBindingEnabled bool                         `json:"x_app2app_insecure_device_key_binding_enabled,omitempty"`
-	DPoPDisabled                           bool                         `json:"x_dpop_disabled,omitempty"`
-	AuthenticationFlowAllowlist            *AuthenticationFlowAllowlist `json:"x_authentication_flow_allowlist,omitempty"`
-	PreAuthenticatedURLEnabled             bool                         `json:"x_pre_authenticated_url_enabled,omitempty"`
-	PreAuthenticatedURLAllowedOrigins      []string                     `json:"x_pre_authenticated_url_allowed_origins,omitempty"`
-	LogoURI                                string                       `json:"logo_uri,omitempty"`
-	ReplaceProjectLogoWithLogoURI          bool                         `json:"x_replace_project_logo_with_logo_uri,omitempty"`
+	ClientName                             string                                  `json:"client_name,omitempty"`
+	Name                                   string                                  `json:"name,omitempty"`
+	ApplicationType                        OAuthClientApplicationType              `json:"x_application_type,omitempty"`
+	Framework                              string                                  `json:"x_framework,omitempty"`
+	MaxConcurrentSession                   int                                     `json:"x_max_concurrent_session,omitempty"`
+	RedirectURIs                           []string                                `json:"redirect_uris,omitempty"`
+	GrantTypes_do_not_use_directly         []string                                `json:"grant_types,omitempty"`
+	ResponseTypes                          []string                                `json:"response_types,omitempty"`
+	PostLogoutRedirectURIs                 []string                                `json:"post_logout_redirect_uris,omitempty"`
+	AccessTokenLifetime                    DurationSeconds                         `json:"access_token_lifetime_seconds,omitempty"`
+	RefreshTokenLifetime                   DurationSeconds                         `json:"refresh_token_lifetime_seconds,omitempty"`
+	RefreshTokenIdleTimeoutEnabled         *bool                                   `json:"refresh_token_idle_timeout_enabled,omitempty"`
+	RefreshTokenIdleTimeout                DurationSeconds                         `json:"refresh_token_idle_timeout_seconds,omitempty"`
+	RefreshTokenRotationEnabled            bool                                    `json:"refresh_token_rotation_enabled,omitempty"`
+	IssueJWTAccessToken                    bool                                    `json:"issue_jwt_access_token,omitempty"`
+	PolicyURI                              string                                  `json:"policy_uri,omitempty"`
+	TOSURI                                 string                                  `json:"tos_uri,omitempty"`
+	CustomUIURI                            string                                  `json:"x_custom_ui_uri,omitempty"`
+	App2appEnabled                         bool                                    `json:"x_app2app_enabled,omitempty"`
+	App2appInsecureDeviceKeyBindingEnabled bool                                    `json:"x_app2app_insecure_device_key_binding_enabled,omitempty"`
+	DPoPDisabled                           bool                                    `json:"x_dpop_disabled,omitempty"`
+	AuthenticationFlowAllowlist            *AuthenticationFlowAllowlist            `json:"x_authentication_flow_allowlist,omitempty"`
+	PreAuthenticatedURLEnabled             bool                                    `json:"x_pre_authenticated_url_enabled,omitempty"`
+	PreAuthenticatedURLAllowedOrigins      []string                                `json:"x_pre_authenticated_url_allowed_origins,omitempty"`
+	LogoURI                                string                                  `json:"logo_uri,omitempty"`
+	ReplaceProjectLogoWithLogoURI          bool                                    `json:"x_replace_project_logo_with_logo_uri,omitempty"`
+	JWKS                                   *OAuthClientJWKS                        `json:"jwks,omitempty"`
+	JWKSURI                                string                                  `json:"jwks_uri,omitempty"`
+	RequirePushedAuthorizationRequests     bool                                    `json:"require_pushed_authorization_requests,omitempty"`
+	TokenEndpointAuthMethod                OAuthClientAuthMethod                   `json:"token_endpoint_auth_method,omitempty"`
+	BackchannelLogoutURI                   string                                  `json:"backchannel_logout_uri,omitempty"`
+	FrontchannelLogoutURI                  string                                  `json:"frontchannel_logout_uri,omitempty"`
+	BackchannelTokenDeliveryMode           OAuthClientBackchannelTokenDeliveryMode `json:"backchannel_token_delivery_mode,omitempty"`
+	BackchannelClientNotificationEndpoint  string                                  `json:"backchannel_client_notification_endpoint,omitempty"`
+}
+
+// RequiresStrongClientAuthentication reports whether the client authenticates
+// with its keys instead of a client secret.
+func (c *OAuthClientConfig) RequiresStrongClientAuthentication() bool {
+	switch c.TokenEndpointAuthMethod {
+	case OAuthClientAuthMethodPrivateKeyJWT, OAuthClientAuthMethodSelfSignedTLSClientAuth:
+		return true
+	default:
+		return false
+	}
 }
 
 func (c *OAuthClientConfig) UseHTTP200() bool {
 	return c.CustomUIURI != ""
 }
 
+var _ = Schema.Add("OAuthClientJWKS", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"keys": {
+			"type": "array",
+			"items": { "type": "object" },
+			"minItems": 1
+		}
+	},
+	"required": ["keys"]
+}
+`)
+
+// OAuthClientJWKS is the public keys of a client, in the form of a JWK Set.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type OAuthClientJWKS struct {
+	Keys []map[string]any `json:"keys"`
+}
+
+func (j *OAuthClientJWKS) Set() (jwk.Set, error) {
+	b, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+	return jwk.Parse(b)
+}
+
 var _ = Schema.Add("AuthenticationFlowAllowlist", `
 {
 	"type": "object",
@@ -0,0 +1,15 @@
+package config
+
+// PwnedPasswordsEnvironmentConfig configures where the SHA-1 hashes of breached passwords are looked up.
+// Only the first 5 characters of the hash of a password leave the server,
+// see https://haveibeenpwned.com/API/v3#SearchingPwnedPasswordsByRange
+type PwnedPasswordsEnvironmentConfig struct {
+	// RangeAPIEndpoint is the endpoint of a Pwned Passwords range API.
+	// The hash prefix is appended to it.
+	RangeAPIEndpoint string `envconfig:"RANGE_API_ENDPOINT" default:"https://api.pwnedpasswords.com/range/"`
+	// Directory is a directory of files named after the hash prefix, for example, 21BD1.txt.
+	// Each file has the same content as the response of the range API.
+	// It is the output of https://github.com/HaveIBeenPwned/PwnedPasswordsDownloader
+	// It takes precedence over RangeAPIEndpoint, so that air-gapped deployments can use it.
+	Directory string `envconfig:"DIRECTORY"`
+}
@@ -0,0 +1,164 @@
+package config
+
+var _ = Schema.Add("RiskConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"signals": { "$ref": "#/$defs/RiskSignalsConfig" },
+		"decision": { "$ref": "#/$defs/RiskDecisionConfig" }
+	}
+}
+`)
+
+var _ = Schema.Add("RiskSignalsConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"new_device": { "$ref": "#/$defs/RiskNewDeviceSignalConfig" },
+		"new_country": { "$ref": "#/$defs/RiskNewCountrySignalConfig" },
+		"impossible_travel": { "$ref": "#/$defs/RiskImpossibleTravelSignalConfig" },
+		"deny_ip": { "$ref": "#/$defs/RiskDenyIPSignalConfig" }
+	}
+}
+`)
+
+var _ = Schema.Add("RiskNewDeviceSignalConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"score": { "type": "integer", "minimum": 0 }
+	}
+}
+`)
+
+var _ = Schema.Add("RiskNewCountrySignalConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"score": { "type": "integer", "minimum": 0 }
+	}
+}
+`)
+
+var _ = Schema.Add("RiskImpossibleTravelSignalConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"score": { "type": "integer", "minimum": 0 },
+		"max_speed_kmh": { "type": "integer", "minimum": 1 }
+	}
+}
+`)
+
+var _ = Schema.Add("RiskDenyIPSignalConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"score": { "type": "integer", "minimum": 0 },
+		"cidrs": {
+			"type": "array",
+			"items": { "type": "string", "format": "x_cidr" }
+		}
+	}
+}
+`)
+
+var _ = Schema.Add("RiskDecisionConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"step_up_threshold": { "type": "integer", "minimum": 1 },
+		"block_threshold": { "type": "integer", "minimum": 1 }
+	}
+}
+`)
+
+type RiskConfig struct {
+	Enabled  *bool               `json:"enabled,omitempty"`
+	Signals  *RiskSignalsConfig  `json:"signals,omitempty"`
+	Decision *RiskDecisionConfig `json:"decision,omitempty"`
+}
+
+func (c *RiskConfig) SetDefaults() {
+	if c.Enabled == nil {
+		c.Enabled = new(false)
+	}
+}
+
+type RiskSignalsConfig struct {
+	NewDevice        *RiskNewDeviceSignalConfig        `json:"new_device,omitempty"`
+	NewCountry       *RiskNewCountrySignalConfig       `json:"new_country,omitempty"`
+	ImpossibleTravel *RiskImpossibleTravelSignalConfig `json:"impossible_travel,omitempty"`
+	DenyIP           *RiskDenyIPSignalConfig           `json:"deny_ip,omitempty"`
+}
+
+// A score of 0 disables the signal.
+type RiskNewDeviceSignalConfig struct {
+	Score *int `json:"score,omitempty"`
+}
+
+func (c *RiskNewDeviceSignalConfig) SetDefaults() {
+	if c.Score == nil {
+		c.Score = new(30)
+	}
+}
+
+type RiskNewCountrySignalConfig struct {
+	Score *int `json:"score,omitempty"`
+}
+
+func (c *RiskNewCountrySignalConfig) SetDefaults() {
+	if c.Score == nil {
+		c.Score = new(30)
+	}
+}
+
+type RiskImpossibleTravelSignalConfig struct {
+	Score *int `json:"score,omitempty"`
+	// MaxSpeedKMH is the speed in km/h above which travelling from
+	// the location of the last access to the current location is considered impossible.
+	MaxSpeedKMH *int `json:"max_speed_kmh,omitempty"`
+}
+
+func (c *RiskImpossibleTravelSignalConfig) SetDefaults() {
+	if c.Score == nil {
+		c.Score = new(60)
+	}
+	if c.MaxSpeedKMH == nil {
+		// Roughly the cruising speed of a commercial airliner.
+		c.MaxSpeedKMH = new(1000)
+	}
+}
+
+type RiskDenyIPSignalConfig struct {
+	Score *int     `json:"score,omitempty"`
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+func (c *RiskDenyIPSignalConfig) SetDefaults() {
+	if c.Score == nil {
+		c.Score = new(100)
+	}
+}
+
+type RiskDecisionConfig struct {
+	StepUpThreshold *int `json:"step_up_threshold,omitempty"`
+	BlockThreshold  *int `json:"block_threshold,omitempty"`
+}
+
+func (c *RiskDecisionConfig) SetDefaults() {
+	if c.StepUpThreshold == nil {
+		c.StepUpThreshold = new(50)
+	}
+	if c.BlockThreshold == nil {
+		c.BlockThreshold = new(100)
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+)
+
+var _ = Schema.Add("SAMLIdentityConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"identity_providers": {
+			"type": "array",
+			"items": { "$ref": "#/$defs/SAMLIdentityProviderConfig" }
+		}
+	}
+}
+`)
+
+// SAMLIdentityConfig configures the upstream SAML identity providers that
+// Authgear, acting as a SAML service provider, can authenticate users with.
+type SAMLIdentityConfig struct {
+	IdentityProviders []*SAMLIdentityProviderConfig `json:"identity_providers,omitempty"`
+}
+
+func (c *SAMLIdentityConfig) GetIdentityProviderConfig(name string) (*SAMLIdentityProviderConfig, bool) {
+	for _, idpConfig := range c.IdentityProviders {
+		if idpConfig.Name == name {
+			return idpConfig, true
+		}
+	}
+	return nil, false
+}
+
+var _ = Schema.Add("SAMLIdentityProviderConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"required": ["name", "entity_id", "sso_url"],
+	"properties": {
+		"name": { "type": "string", "minLength": 1 },
+		"entity_id": { "type": "string", "minLength": 1 },
+		"sso_url": { "type": "string", "format": "uri" },
+		"nameid_format": { "$ref": "#/$defs/SAMLNameIDFormat" },
+		"attribute_mapping": { "$ref": "#/$defs/SAMLIdentityProviderAttributeMapping" }
+	}
+}
+`)
+
+type SAMLIdentityProviderConfig struct {
+	Name             string                               `json:"name,omitempty"`
+	EntityID         string                               `json:"entity_id,omitempty"`
+	SSOURL           string                               `json:"sso_url,omitempty"`
+	NameIDFormat     samlprotocol.SAMLNameIDFormat        `json:"nameid_format,omitempty"`
+	AttributeMapping SAMLIdentityProviderAttributeMapping `json:"attribute_mapping,omitempty"`
+}
+
+// SAMLIdentityProviderAttributeMapping maps a standard attribute to
+// the name of a SAML attribute in the assertion issued by the identity provider.
+var _ = Schema.Add("SAMLIdentityProviderAttributeMapping", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"email": { "type": "string", "minLength": 1 },
+		"phone_number": { "type": "string", "minLength": 1 },
+		"preferred_username": { "type": "string", "minLength": 1 },
+		"name": { "type": "string", "minLength": 1 },
+		"given_name": { "type": "string", "minLength": 1 },
+		"family_name": { "type": "string", "minLength": 1 },
+		"middle_name": { "type": "string", "minLength": 1 },
+		"nickname": { "type": "string", "minLength": 1 },
+		"picture": { "type": "string", "minLength": 1 },
+		"profile": { "type": "string", "minLength": 1 },
+		"website": { "type": "string", "minLength": 1 },
+		"gender": { "type": "string", "minLength": 1 },
+		"birthdate": { "type": "string", "minLength": 1 },
+		"zoneinfo": { "type": "string", "minLength": 1 },
+		"locale": { "type": "string", "minLength": 1 }
+	}
+}
+`)
+
+type SAMLIdentityProviderAttributeMapping map[string]string
@@ -53,6 +53,8 @@ func TestAppConfigSchema(t *testing.T) {
 		"testdata/captcha_tests.yaml",
 		"testdata/bot_protection_tests.yaml",
 		"testdata/fraud_protection_tests.yaml",
+		"testdata/risk_tests.yaml",
+		"testdata/ldap_tests.yaml",
 	}
 
 	type TestCase struct {
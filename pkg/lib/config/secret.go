@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"sigs.k8s.io/yaml"
 
@@ -236,6 +237,18 @@ func (c *SecretConfig) validateLDAPServerUserSecrets(ctx *validation.Context, ld
 	}
 }
 
+func (c *SecretConfig) validateEmailProviders(ctx *validation.Context) {
+	var keys []string
+	for _, key := range EmailProviderSecretKeys {
+		if _, _, ok := c.Lookup(key); ok {
+			keys = append(keys, string(key))
+		}
+	}
+	if len(keys) > 1 {
+		ctx.EmitErrorMessage(fmt.Sprintf("at most one email provider can be configured, but found %s", strings.Join(keys, ", ")))
+	}
+}
+
 func (c *SecretConfig) validateSAMLSigningKey(ctx *validation.Context, keyID string) {
 	c.validateRequire(ctx, SAMLIdpSigningMaterialsKey, "saml idp signing key materials")
 	_, data, ok := c.LookupDataWithIndex(SAMLIdpSigningMaterialsKey)
@@ -260,6 +273,17 @@ func (c *SecretConfig) validateSAMLServiceProviderCerts(ctx *validation.Context,
 	}
 }
 
+func (c *SecretConfig) validateSAMLIdentityProviderCerts(ctx *validation.Context, idpConfigs []*SAMLIdentityProviderConfig) {
+	_, data, _ := c.LookupDataWithIndex(SAMLIdentityProviderCertificatesKey)
+	certificates, _ := data.(*SAMLIdentityProviderCertificates)
+	for _, idpConfig := range idpConfigs {
+		certs, _, ok := certificates.Resolve(idpConfig.Name)
+		if !ok || len(certs.Certificates) < 1 {
+			ctx.EmitErrorMessage(fmt.Sprintf("certificates of saml identity provider '%s' is not configured", idpConfig.Name))
+		}
+	}
+}
+
 func (c *SecretConfig) validateSSOOAuthDemoCredentials(ctx context.Context, vctx *validation.Context, demoCredentials *SSOOAuthDemoCredentials) {
 	for i, item := range demoCredentials.Items {
 		providerConfig := item.ProviderConfig
@@ -316,10 +340,16 @@ func (c *SecretConfig) Validate(ctx context.Context, appConfig *AppConfig) error
 			}
 		}
 	}
+	if appConfig.Identity.SAML != nil && len(appConfig.Identity.SAML.IdentityProviders) > 0 {
+		c.validateRequire(vctx, SAMLIdentityProviderCertificatesKey, "saml identity provider certificates")
+		c.validateSAMLIdentityProviderCerts(vctx, appConfig.Identity.SAML.IdentityProviders)
+	}
 	if appConfig.SAML.Signing.KeyID != "" {
 		c.validateSAMLSigningKey(vctx, appConfig.SAML.Signing.KeyID)
 	}
 
+	c.validateEmailProviders(vctx)
+
 	idx, demoCredentials, ok := c.LookupDataWithIndex(SSOOAuthDemoCredentialsKey)
 	if ok {
 		childCtx := vctx.Child("secrets", strconv.Itoa(idx), "data")
@@ -351,6 +381,14 @@ const (
 	SSOOAuthDemoCredentialsKey SecretKey = "sso.oauth.demo_credentials"
 	SMTPServerCredentialsKey   SecretKey = "mail.smtp"
 	// nolint: gosec
+	SESCredentialsKey SecretKey = "mail.ses"
+	// nolint: gosec
+	SendGridCredentialsKey SecretKey = "mail.sendgrid"
+	// nolint: gosec
+	MailgunCredentialsKey SecretKey = "mail.mailgun"
+	// nolint: gosec
+	PostmarkCredentialsKey SecretKey = "mail.postmark"
+	// nolint: gosec
 	TwilioCredentialsKey SecretKey = "sms.twilio"
 	// nolint: gosec
 	NexmoCredentialsKey        SecretKey = "sms.nexmo"
@@ -371,8 +409,20 @@ const (
 
 	SAMLIdpSigningMaterialsKey SecretKey = "saml.idp.signing"
 	SAMLSpSigningMaterialsKey  SecretKey = "saml.service_providers.signing"
+
+	SAMLIdentityProviderCertificatesKey SecretKey = "saml.identity_providers.certificates"
 )
 
+// EmailProviderSecretKeys are the secret keys of the email providers.
+// At most one of them can be configured.
+var EmailProviderSecretKeys = []SecretKey{
+	SMTPServerCredentialsKey,
+	SESCredentialsKey,
+	SendGridCredentialsKey,
+	MailgunCredentialsKey,
+	PostmarkCredentialsKey,
+}
+
 func (key SecretKey) IsUpdatable() bool {
 	switch key {
 	case OAuthSSOProviderCredentialsKey,
@@ -403,6 +453,10 @@ var secretItemKeys = map[SecretKey]secretKeyDef{
 	OAuthSSOProviderCredentialsKey:             {"OAuthSSOProviderCredentials", func() SecretItemData { return &OAuthSSOProviderCredentials{} }},
 	SSOOAuthDemoCredentialsKey:                 {"SSOOAuthDemoCredentials", func() SecretItemData { return &SSOOAuthDemoCredentials{} }},
 	SMTPServerCredentialsKey:                   {"SMTPServerCredentials", func() SecretItemData { return &SMTPServerCredentials{} }},
+	SESCredentialsKey:                          {"SESCredentials", func() SecretItemData { return &SESCredentials{} }},
+	SendGridCredentialsKey:                     {"SendGridCredentials", func() SecretItemData { return &SendGridCredentials{} }},
+	MailgunCredentialsKey:                      {"MailgunCredentials", func() SecretItemData { return &MailgunCredentials{} }},
+	PostmarkCredentialsKey:                     {"PostmarkCredentials", func() SecretItemData { return &PostmarkCredentials{} }},
 	TwilioCredentialsKey:                       {"TwilioCredentials", func() SecretItemData { return &TwilioCredentials{} }},
 	NexmoCredentialsKey:                        {"NexmoCredentials", func() SecretItemData { return &NexmoCredentials{} }},
 	OAuthKeyMaterialsKey:                       {"OAuthKeyMaterials", func() SecretItemData { return &OAuthKeyMaterials{} }},
@@ -419,6 +473,7 @@ var secretItemKeys = map[SecretKey]secretKeyDef{
 	LDAPServerUserCredentialsKey:               {"LDAPServerUserCredentials", func() SecretItemData { return &LDAPServerUserCredentials{} }},
 	SAMLIdpSigningMaterialsKey:                 {"SAMLIdpSigningMaterials", func() SecretItemData { return &SAMLIdpSigningMaterials{} }},
 	SAMLSpSigningMaterialsKey:                  {"SAMLSpSigningMaterials", func() SecretItemData { return &SAMLSpSigningMaterials{} }},
+	SAMLIdentityProviderCertificatesKey:        {"SAMLIdentityProviderCertificates", func() SecretItemData { return &SAMLIdentityProviderCertificates{} }},
 }
 
 var _ = SecretConfigSchema.AddJSON("SecretKey", map[string]any{
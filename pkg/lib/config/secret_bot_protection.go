@@ -5,7 +5,7 @@ var _ = SecretConfigSchema.Add("BotProtectionProviderCredentials", `
 	"type": "object",
 	"additionalProperties": false,
 	"properties": {
-		"type": { "type": "string", "enum": ["cloudflare", "recaptchav2"] },
+		"type": { "type": "string", "enum": ["cloudflare", "recaptchav2", "recaptchav3", "recaptcha_enterprise", "hcaptcha", "pow"] },
 		"secret_key": { "type": "string", "minLength": 1 }
 	},
 	"allOf": [
@@ -13,7 +13,7 @@ var _ = SecretConfigSchema.Add("BotProtectionProviderCredentials", `
 			"if": {
 				"properties": {
 					"type": {
-						"enum": ["cloudflare", "recaptchav2"]
+						"enum": ["cloudflare", "recaptchav2", "recaptchav3", "recaptcha_enterprise", "hcaptcha", "pow"]
 					}
 				},
 				"required": ["type"]
@@ -27,8 +27,10 @@ var _ = SecretConfigSchema.Add("BotProtectionProviderCredentials", `
 `)
 
 type BotProtectionProviderCredentials struct {
-	Type      BotProtectionProviderType `json:"type,omitempty"`
-	SecretKey string                    `json:"secret_key,omitempty"`
+	Type BotProtectionProviderType `json:"type,omitempty"`
+	// SecretKey is the API key for recaptcha_enterprise,
+	// and the key to sign challenges for pow.
+	SecretKey string `json:"secret_key,omitempty"`
 }
 
 func (c *BotProtectionProviderCredentials) SensitiveStrings() (sensitiveStrings []string) {
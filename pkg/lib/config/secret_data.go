@@ -263,6 +263,117 @@ func (c *SMTPServerCredentials) SetDefaults() {
 	}
 }
 
+var _ = SecretConfigSchema.Add("SESCredentials", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"region": { "type": "string", "minLength": 1 },
+		"access_key_id": { "type": "string", "minLength": 1 },
+		"secret_access_key": { "type": "string", "minLength": 1 },
+		"configuration_set_name": { "type": "string" }
+	},
+	"required": ["region", "access_key_id", "secret_access_key"]
+}
+`)
+
+type SESCredentials struct {
+	Region               string `json:"region,omitempty"`
+	AccessKeyID          string `json:"access_key_id,omitempty"`
+	SecretAccessKey      string `json:"secret_access_key,omitempty"`
+	ConfigurationSetName string `json:"configuration_set_name,omitempty"`
+}
+
+func (c *SESCredentials) SensitiveStrings() []string {
+	return []string{
+		c.AccessKeyID,
+		c.SecretAccessKey,
+	}
+}
+
+var _ = SecretConfigSchema.Add("SendGridCredentials", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"api_key": { "type": "string", "minLength": 1 }
+	},
+	"required": ["api_key"]
+}
+`)
+
+type SendGridCredentials struct {
+	APIKey string `json:"api_key,omitempty"`
+}
+
+func (c *SendGridCredentials) SensitiveStrings() []string {
+	return []string{c.APIKey}
+}
+
+var _ = SecretConfigSchema.Add("MailgunRegion", `
+{
+	"type": "string",
+	"enum": ["us", "eu"]
+}
+`)
+
+type MailgunRegion string
+
+const (
+	MailgunRegionUS MailgunRegion = "us"
+	MailgunRegionEU MailgunRegion = "eu"
+)
+
+var _ = SecretConfigSchema.Add("MailgunCredentials", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"region": { "$ref": "#/$defs/MailgunRegion" },
+		"domain": { "type": "string", "minLength": 1 },
+		"api_key": { "type": "string", "minLength": 1 }
+	},
+	"required": ["domain", "api_key"]
+}
+`)
+
+type MailgunCredentials struct {
+	Region MailgunRegion `json:"region,omitempty"`
+	Domain string        `json:"domain,omitempty"`
+	APIKey string        `json:"api_key,omitempty"`
+}
+
+func (c *MailgunCredentials) SensitiveStrings() []string {
+	return []string{c.APIKey}
+}
+
+func (c *MailgunCredentials) SetDefaults() {
+	if c.Region == "" {
+		c.Region = MailgunRegionUS
+	}
+}
+
+var _ = SecretConfigSchema.Add("PostmarkCredentials", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"server_token": { "type": "string", "minLength": 1 },
+		"message_stream": { "type": "string" }
+	},
+	"required": ["server_token"]
+}
+`)
+
+type PostmarkCredentials struct {
+	ServerToken   string `json:"server_token,omitempty"`
+	MessageStream string `json:"message_stream,omitempty"`
+}
+
+func (c *PostmarkCredentials) SensitiveStrings() []string {
+	return []string{c.ServerToken}
+}
+
 var _ = SecretConfigSchema.Add("TwilioCredentials", `
 {
 	"type": "object",
@@ -97,3 +97,53 @@ type SAMLSpSigningCertificate struct {
 	// Use `omitzero` instead so empty array will be outputted, while nil will still be omitted.
 	Certificates []X509Certificate `json:"certificates,omitzero"`
 }
+
+var _ = SecretConfigSchema.Add("SAMLIdentityProviderCertificates", `
+{
+	"type": "array",
+	"items": { "$ref": "#/$defs/SAMLIdentityProviderCertificate" }
+}
+`)
+
+// SAMLIdentityProviderCertificates are the certificates used to verify
+// the signed assertions issued by the upstream SAML identity providers.
+type SAMLIdentityProviderCertificates []SAMLIdentityProviderCertificate
+
+func (m *SAMLIdentityProviderCertificates) Resolve(identityProviderName string) (*SAMLIdentityProviderCertificate, int, bool) {
+	if m == nil {
+		return nil, -1, false
+	}
+	for idx, item := range *m {
+		if item.IdentityProviderName == identityProviderName {
+			return &item, idx, true
+		}
+	}
+
+	return nil, -1, false
+}
+
+var _ SecretItemData = &SAMLIdentityProviderCertificates{}
+
+func (s *SAMLIdentityProviderCertificates) SensitiveStrings() []string {
+	return nil
+}
+
+var _ = SecretConfigSchema.Add("SAMLIdentityProviderCertificate", `
+{
+	"type": "object",
+	"properties": {
+		"identity_provider_name": { "type": "string" },
+		"certificates": {
+			"type": "array",
+			"items": { "$ref": "#/$defs/X509Certificate" },
+			"minItems": 1
+		}
+	},
+	"required": ["identity_provider_name", "certificates"]
+}
+`)
+
+type SAMLIdentityProviderCertificate struct {
+	IdentityProviderName string            `json:"identity_provider_name,omitempty"`
+	Certificates         []X509Certificate `json:"certificates,omitempty"`
+}
@@ -9,11 +9,30 @@ var _ = Schema.Add("SessionConfig", `
 		"idle_timeout_enabled": { "type": "boolean" },
 		"idle_timeout_seconds": { "$ref": "#/$defs/DurationSeconds" },
 		"cookie_non_persistent": { "type": "boolean" },
-		"use_session_cookie": { "type": "boolean" }
+		"use_session_cookie": { "type": "boolean" },
+		"max_concurrent_sessions": { "type": "integer", "minimum": 1 },
+		"concurrent_session_limit_policy": { "$ref": "#/$defs/ConcurrentSessionLimitPolicy" }
 	}
 }
 `)
 
+var _ = Schema.Add("ConcurrentSessionLimitPolicy", `
+{
+	"type": "string",
+	"enum": ["evict_oldest", "reject"]
+}
+`)
+
+type ConcurrentSessionLimitPolicy string
+
+const (
+	// ConcurrentSessionLimitPolicyEvictOldest ends the oldest IdP sessions to make room for the new one.
+	ConcurrentSessionLimitPolicyEvictOldest ConcurrentSessionLimitPolicy = "evict_oldest"
+	// ConcurrentSessionLimitPolicyReject rejects the new login,
+	// unless the user confirms to terminate the other sessions.
+	ConcurrentSessionLimitPolicyReject ConcurrentSessionLimitPolicy = "reject"
+)
+
 const (
 	// DefaultIDPSessionLifetime is 52 weeks (364 days).
 	DefaultIDPSessionLifetime DurationSeconds = 52 * 7 * 86400
@@ -44,6 +63,10 @@ type SessionConfig struct {
 	// i.e. it has no Max-Age, so the browser discards it when it is closed.
 	// This does NOT affect server-side session expiry.
 	UseSessionCookie bool `json:"use_session_cookie,omitempty"`
+	// MaxConcurrentSessions is the maximum number of active IdP sessions a user can have across all clients.
+	// Zero means unlimited.
+	MaxConcurrentSessions        int                          `json:"max_concurrent_sessions,omitempty"`
+	ConcurrentSessionLimitPolicy ConcurrentSessionLimitPolicy `json:"concurrent_session_limit_policy,omitempty"`
 }
 
 func (c *SessionConfig) SetDefaults() {
@@ -57,4 +80,11 @@ func (c *SessionConfig) SetDefaults() {
 	if c.IdleTimeout == 0 {
 		c.IdleTimeout = DefaultIDPSessionIdleTimeout
 	}
+	if c.MaxConcurrentSessions > 0 && c.ConcurrentSessionLimitPolicy == "" {
+		c.ConcurrentSessionLimitPolicy = ConcurrentSessionLimitPolicyEvictOldest
+	}
+}
+
+func (c *SessionConfig) IsConcurrentSessionLimitEnabled() bool {
+	return c.MaxConcurrentSessions > 0
 }
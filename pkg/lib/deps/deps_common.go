@@ -26,6 +26,7 @@ import (
 	identityloginid "github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
 	identityoauth "github.com/authgear/authgear-server/pkg/lib/authn/identity/oauth"
 	identitypasskey "github.com/authgear/authgear-server/pkg/lib/authn/identity/passkey"
+	identitysaml "github.com/authgear/authgear-server/pkg/lib/authn/identity/saml"
 	identityservice "github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
 	identitysiwe "github.com/authgear/authgear-server/pkg/lib/authn/identity/siwe"
 	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
@@ -34,6 +35,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
 	"github.com/authgear/authgear-server/pkg/lib/authn/user"
 	"github.com/authgear/authgear-server/pkg/lib/botprotection"
+	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
 	"github.com/authgear/authgear-server/pkg/lib/dpop"
 	libes "github.com/authgear/authgear-server/pkg/lib/elasticsearch"
 	"github.com/authgear/authgear-server/pkg/lib/endpoints"
@@ -49,12 +51,14 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/fraudprotection"
 	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/ldap"
+	"github.com/authgear/authgear-server/pkg/lib/ldapsync"
 	networkprotection "github.com/authgear/authgear-server/pkg/lib/networkprotection"
 	"github.com/authgear/authgear-server/pkg/lib/resourcescope"
 	"github.com/authgear/authgear-server/pkg/lib/saml"
 	"github.com/authgear/authgear-server/pkg/lib/saml/samlbinding"
 	"github.com/authgear/authgear-server/pkg/lib/saml/samlsession"
 	"github.com/authgear/authgear-server/pkg/lib/saml/samlslosession"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlsp"
 	"github.com/authgear/authgear-server/pkg/lib/search/pgsearch"
 	searchreindex "github.com/authgear/authgear-server/pkg/lib/search/reindex"
 	"github.com/authgear/authgear-server/pkg/lib/userexport"
@@ -82,8 +86,10 @@ import (
 	oauthpq "github.com/authgear/authgear-server/pkg/lib/oauth/pq"
 	oauthredis "github.com/authgear/authgear-server/pkg/lib/oauth/redis"
 	"github.com/authgear/authgear-server/pkg/lib/oauthclient"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/presign"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/risk"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
 	"github.com/authgear/authgear-server/pkg/lib/search"
 	"github.com/authgear/authgear-server/pkg/lib/session"
@@ -184,8 +190,10 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(forgotpassword.EventService), new(*event.Service)),
 		wire.Bind(new(ratelimit.LimiterEventService), new(*event.Service)),
 		wire.Bind(new(fraudprotection.EventService), new(*event.Service)),
+		wire.Bind(new(risk.EventService), new(*event.Service)),
 		wire.Bind(new(usage.EventService), new(*event.Service)),
 		wire.Bind(new(saml.EventService), new(*event.Service)),
+		wire.Bind(new(ldapsync.EventService), new(*event.Service)),
 	),
 
 	wire.NewSet(
@@ -212,6 +220,12 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(otp.FraudProtectionService), new(*fraudprotection.Service)),
 	),
 
+	wire.NewSet(
+		risk.DependencySet,
+		wire.Bind(new(risk.SessionLister), new(*session.Manager)),
+		wire.Bind(new(authenticationflow.RiskService), new(*risk.Service)),
+	),
+
 	wire.NewSet(
 		saml.DependencySet,
 
@@ -243,6 +257,7 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(interaction.SessionProvider), new(*idpsession.Provider)),
 		wire.Bind(new(workflow.IDPSessionService), new(*idpsession.Provider)),
 		wire.Bind(new(oauthhandler.TokenHandlerIDPSessionProvider), new(*idpsession.Provider)),
+		wire.Bind(new(oauthhandler.IntrospectHandlerIDPSessionProvider), new(*idpsession.Provider)),
 		wire.Bind(new(authenticationflow.IDPSessionService), new(*idpsession.Provider)),
 		wire.Bind(new(sessionlisting.IDPSessionProvider), new(*idpsession.Provider)),
 		wire.Bind(new(saml.IDPSessionProvider), new(*idpsession.Provider)),
@@ -295,6 +310,7 @@ var CommonDependencySet = wire.NewSet(
 		stdattrs.DependencySet,
 		wire.Bind(new(sso.StandardAttributesNormalizer), new(*stdattrs.Normalizer)),
 		wire.Bind(new(identityldap.StandardAttributesNormalizer), new(*stdattrs.Normalizer)),
+		wire.Bind(new(identitysaml.StandardAttributesNormalizer), new(*stdattrs.Normalizer)),
 	),
 
 	wire.NewSet(
@@ -312,6 +328,7 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(authenticatoroob.LoginIDNormalizerFactory), new(*identityloginid.NormalizerFactory)),
 		wire.Bind(new(authenticationflow.LoginIDService), new(*identityloginid.Provider)),
 		wire.Bind(new(authenticationflow.LDAPService), new(*identityldap.Provider)),
+		wire.Bind(new(authenticationflow.SAMLService), new(*identitysaml.Provider)),
 
 		identityoauth.DependencySet,
 
@@ -330,6 +347,9 @@ var CommonDependencySet = wire.NewSet(
 		identitysiwe.DependencySet,
 
 		identityldap.DependencySet,
+		wire.Bind(new(ldapsync.LDAPIdentityProvider), new(*identityldap.Provider)),
+
+		identitysaml.DependencySet,
 
 		identityservice.DependencySet,
 		wire.Bind(new(identityservice.LoginIDIdentityProvider), new(*identityloginid.Provider)),
@@ -339,12 +359,14 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(identityservice.BiometricIdentityProvider), new(*identitybiometric.Provider)),
 		wire.Bind(new(identityservice.SIWEIdentityProvider), new(*identitysiwe.Provider)),
 		wire.Bind(new(identityservice.LDAPIdentityProvider), new(*identityldap.Provider)),
+		wire.Bind(new(identityservice.SAMLIdentityProvider), new(*identitysaml.Provider)),
 
 		wire.Bind(new(facade.IdentityService), new(*identityservice.Service)),
 		wire.Bind(new(user.IdentityService), new(*identityservice.Service)),
 		wire.Bind(new(featurestdattrs.IdentityService), new(*identityservice.Service)),
 		wire.Bind(new(featurepasskey.IdentityService), new(*identityservice.Service)),
 		wire.Bind(new(forgotpassword.IdentityService), new(*identityservice.Service)),
+		wire.Bind(new(oauthhandler.CIBAMessageSenderIdentityService), new(*identityservice.Service)),
 		wire.Bind(new(userinfo.UserInfoIdentityService), new(*identityservice.Service)),
 
 		wire.Bind(new(oauthhandler.PromotionCodeStore), new(*identityanonymous.StoreRedis)),
@@ -374,6 +396,9 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(oauth.UserBlockingEventContextIdentityService), new(*facade.IdentityFacade)),
 		wire.Bind(new(authenticationflow.UserFacade), new(*facade.UserFacade)),
 		wire.Bind(new(handlersaml.SAMLUserFacade), new(*facade.UserFacade)),
+		wire.Bind(new(oauthhandler.BackchannelAuthenticationHandlerUserFacade), new(*facade.UserFacade)),
+		wire.Bind(new(ldapsync.IdentityService), new(*facade.IdentityFacade)),
+		wire.Bind(new(ldapsync.UserService), new(*facade.UserFacade)),
 	),
 
 	wire.NewSet(
@@ -410,6 +435,15 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(hook.RolesAndGroupsServiceNoEvent), new(*rolesgroups.Commands)),
 		wire.Bind(new(user.RolesAndGroupsService), new(*rolesgroups.Queries)),
 		wire.Bind(new(userimport.RolesGroupsCommands), new(*rolesgroups.Commands)),
+		wire.Bind(new(ldapsync.RolesGroupsQueries), new(*rolesgroups.Queries)),
+		wire.Bind(new(ldapsync.RolesGroupsCommands), new(*rolesgroups.Commands)),
+	),
+
+	wire.NewSet(
+		organization.DependencySet,
+		wire.Bind(new(facade.OrganizationCommands), new(*organization.Commands)),
+		wire.Bind(new(authenticationflow.OrganizationQueries), new(*organization.Queries)),
+		wire.Bind(new(authenticationflow.OrganizationCommands), new(*organization.Commands)),
 	),
 
 	wire.NewSet(
@@ -455,6 +489,18 @@ var CommonDependencySet = wire.NewSet(
 	wire.NewSet(
 		ldap.DependencySet,
 		wire.Bind(new(authenticationflow.LDAPClientFactory), new(*ldap.ClientFactory)),
+		wire.Bind(new(ldapsync.LDAPClientFactory), new(*ldap.ClientFactory)),
+	),
+
+	wire.NewSet(
+		ldapsync.DependencySet,
+		wire.Bind(new(authenticationflow.LDAPGroupSyncService), new(*ldapsync.GroupSyncService)),
+	),
+
+	wire.NewSet(
+		samlsp.DependencySet,
+		wire.Bind(new(authenticationflow.SAMLSPService), new(*samlsp.Service)),
+		wire.Bind(new(handlersaml.SPMetadataHandlerSAMLSPService), new(*samlsp.Service)),
 	),
 
 	wire.NewSet(
@@ -462,6 +508,8 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(oauth.AuthorizationStore), new(*oauthpq.AuthorizationStore)),
 		wire.Bind(new(facade.OAuthService), new(*oauthpq.AuthorizationStore)),
 		wire.Bind(new(handler.TokenServiceAuthorizationStore), new(*oauthpq.AuthorizationStore)),
+		wire.Bind(new(handler.IntrospectHandlerAuthorizationStore), new(*oauthpq.AuthorizationStore)),
+		wire.Bind(new(handler.RevokeHandlerAuthorizationStore), new(*oauthpq.AuthorizationStore)),
 
 		oauthredis.DependencySet,
 		wire.Bind(new(oauth.AccessGrantStore), new(*oauthredis.Store)),
@@ -473,10 +521,24 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(oauth.AppSessionStore), new(*oauthredis.Store)),
 		wire.Bind(new(oauth.PreAuthenticatedURLTokenStore), new(*oauthredis.Store)),
 		wire.Bind(new(oauth.SettingsActionGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(oauth.DeviceGrantStore), new(*oauthredis.Store)),
 		wire.Bind(new(handler.TokenHandlerCodeGrantStore), new(*oauthredis.Store)),
 		wire.Bind(new(handler.TokenHandlerSettingsActionGrantStore), new(*oauthredis.Store)),
 		wire.Bind(new(handler.TokenHandlerOfflineGrantStore), new(*oauthredis.Store)),
 		wire.Bind(new(handler.TokenHandlerAppSessionTokenStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.TokenHandlerDeviceGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.DeviceAuthorizationHandlerDeviceGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(oauth.CIBAGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.TokenHandlerCIBAGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.BackchannelAuthenticationHandlerCIBAGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.CIBAGrantDatabase), new(*appdb.Handle)),
+		wire.Bind(new(oauth.PushedAuthorizationRequestStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.PushedAuthorizationHandlerPushedAuthorizationRequestStore), new(*oauthredis.Store)),
+		wire.Bind(new(oauth.InitialAccessTokenStore), new(*oauthredis.Store)),
+		wire.Bind(new(oauth.ClientRegistrationStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.ClientRegistrationHandlerInitialAccessTokenStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.ClientRegistrationHandlerClientRegistrationStore), new(*oauthredis.Store)),
+		wire.Bind(new(handler.ClientRegistrationConfigSourceStore), new(*configsource.Store)),
 
 		oauth.DependencySet,
 		wire.Bind(new(session.AccessTokenSessionResolver), new(*oauth.Resolver)),
@@ -498,6 +560,9 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(oidchandler.IDTokenHintOfflineGrantService), new(*oauth.OfflineGrantService)),
 		wire.Bind(new(oauthhandler.RevokeHandlerOfflineGrantService), new(*oauth.OfflineGrantService)),
 		wire.Bind(new(oauthhandler.RevokeHandlerAccessGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(oauthhandler.IntrospectHandlerAccessGrantStore), new(*oauthredis.Store)),
+		wire.Bind(new(oauthhandler.IntrospectHandlerOfflineGrantService), new(*oauth.OfflineGrantService)),
+		wire.Bind(new(oauthhandler.IntrospectHandlerAccessTokenDecoder), new(*oauth.AccessTokenEncoding)),
 		wire.Bind(new(saml.OfflineGrantService), new(*oauth.OfflineGrantService)),
 		wire.Bind(new(handler.TokenServiceOfflineGrantService), new(*oauth.OfflineGrantService)),
 		wire.Bind(new(handler.TokenServiceAccessGrantService), new(*oauth.AccessGrantService)),
@@ -510,11 +575,18 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(oauthhandler.UIInfoResolver), new(*oidc.UIInfoResolver)),
 		wire.Bind(new(authenticationflow.IDTokenService), new(*oidc.IDTokenIssuer)),
 		wire.Bind(new(oauthhandler.IDTokenIssuer), new(*oidc.IDTokenIssuer)),
+		wire.Bind(new(oauthhandler.IntrospectHandlerIssuer), new(*oidc.IDTokenIssuer)),
+		wire.Bind(new(oauthhandler.AuthorizationRequestResolverIssuer), new(*oidc.IDTokenIssuer)),
+		wire.Bind(new(oauthhandler.ClientAuthenticatorIssuer), new(*oidc.IDTokenIssuer)),
 		wire.Bind(new(oidchandler.IDTokenVerifier), new(*oidc.IDTokenIssuer)),
 		wire.Bind(new(oauthhandler.TokenServiceAccessTokenIssuer), new(*oauth.AccessTokenEncoding)),
 		wire.Bind(new(oauth.IDTokenIssuer), new(*oidc.IDTokenIssuer)),
 		wire.Bind(new(oauthhandler.UIURLBuilder), new(*oidc.UIURLBuilder)),
 		wire.Bind(new(saml.SAMLUserInfoProvider), new(*oidc.IDTokenIssuer)),
+		wire.Bind(new(session.BackchannelLogoutService), new(*oidc.BackchannelLogoutService)),
+		wire.Bind(new(oidc.BackchannelLogoutDatabase), new(*appdb.Handle)),
+		wire.Bind(new(oidchandler.FrontchannelLogoutService), new(*oidc.FrontchannelLogoutService)),
+		wire.Bind(new(handlersaml.FrontchannelLogoutService), new(*oidc.FrontchannelLogoutService)),
 
 		oidchandler.DependencySet,
 	),
@@ -563,6 +635,8 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(otp.TranslationService), new(*translation.Service)),
 		wire.Bind(new(featurepasskey.TranslationService), new(*translation.Service)),
 		wire.Bind(new(forgotpassword.TranslationService), new(*translation.Service)),
+		wire.Bind(new(oauthhandler.CIBAMessageSenderTranslationService), new(*translation.Service)),
+		wire.Bind(new(organization.InvitationMessageTranslationService), new(*translation.Service)),
 		wire.Bind(new(usage.TranslationService), new(*translation.Service)),
 	),
 
@@ -583,6 +657,11 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(messaging.RateLimiter), new(*ratelimit.Limiter)),
 		wire.Bind(new(mfa.RateLimiter), new(*ratelimit.Limiter)),
 		wire.Bind(new(oauthhandler.TokenHandlerRateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(oauthhandler.IntrospectHandlerRateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(oauthhandler.DeviceAuthorizationHandlerRateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(oauthhandler.DeviceGrantServiceRateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(oauthhandler.BackchannelAuthenticationHandlerRateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(oauthhandler.PushedAuthorizationHandlerRateLimiter), new(*ratelimit.Limiter)),
 	),
 
 	wire.NewSet(
@@ -635,6 +714,8 @@ var CommonDependencySet = wire.NewSet(
 		messaging.DependencySet,
 		wire.Bind(new(otp.Sender), new(*messaging.Sender)),
 		wire.Bind(new(forgotpassword.SenderService), new(*messaging.Sender)),
+		wire.Bind(new(oauthhandler.CIBAMessageSenderSender), new(*messaging.Sender)),
+		wire.Bind(new(organization.InvitationMessageSenderService), new(*messaging.Sender)),
 	),
 
 	wire.NewSet(
@@ -662,6 +743,10 @@ var CommonDependencySet = wire.NewSet(
 
 	wire.NewSet(
 		accountmanagement.DependencySet,
+		wire.Bind(new(accountmanagement.SessionManager), new(*session.Manager)),
+		wire.Bind(new(accountmanagement.SessionListingService), new(*sessionlisting.SessionListingService)),
+		wire.Bind(new(accountmanagement.OfflineGrantService), new(*oauth.OfflineGrantService)),
+		wire.Bind(new(accountmanagement.IDPSessionService), new(*idpsession.Provider)),
 	),
 
 	wire.NewSet(
@@ -702,6 +787,10 @@ var CommonDependencySet = wire.NewSet(
 		endpoints.DependencySet,
 		wire.Bind(new(oauth.BaseURLProvider), new(*endpoints.Endpoints)),
 		wire.Bind(new(oauth.EndpointsProvider), new(*endpoints.Endpoints)),
+		wire.Bind(new(oauthhandler.DeviceAuthorizationHandlerEndpointsProvider), new(*endpoints.Endpoints)),
+		wire.Bind(new(oauthhandler.BackchannelAuthenticationHandlerEndpointsProvider), new(*endpoints.Endpoints)),
+		wire.Bind(new(oauthhandler.ClientRegistrationHandlerEndpointsProvider), new(*endpoints.Endpoints)),
+		wire.Bind(new(organization.InvitationMessageEndpoints), new(*endpoints.Endpoints)),
 		wire.Bind(new(oidc.BaseURLProvider), new(*endpoints.Endpoints)),
 		wire.Bind(new(oidc.EndpointsProvider), new(*endpoints.Endpoints)),
 		wire.Bind(new(oidc.UIURLBuilderAuthUIEndpointsProvider), new(*endpoints.Endpoints)),
@@ -712,12 +801,16 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(samlsession.UIServiceAuthUIEndpointsProvider), new(*endpoints.Endpoints)),
 		wire.Bind(new(authenticationinfo.UIServiceEndpointsProvider), new(*endpoints.Endpoints)),
 		wire.Bind(new(handlersaml.Endpoints), new(*endpoints.Endpoints)),
+		wire.Bind(new(samlsp.SAMLSPEndpoints), new(*endpoints.Endpoints)),
 	),
 
 	wire.NewSet(
 		redisqueue.ProducerDependencySet,
 		wire.Bind(new(searchreindex.UserReindexCreateProducer), new(*redisqueue.UserReindexProducer)),
 		wire.Bind(new(userimport.TaskProducer), new(*redisqueue.UserImportProducer)),
+		wire.Bind(new(oidc.BackchannelLogoutProducer), new(*redisqueue.BackchannelLogoutProducer)),
+		wire.Bind(new(handler.CIBANotificationProducer), new(*redisqueue.CIBANotificationProducer)),
+		wire.Bind(new(messaging.MessageDeliveryProducer), new(*redisqueue.MessageDeliveryProducer)),
 	),
 
 	wire.NewSet(
@@ -35,6 +35,7 @@ var ConfigDeps = wire.NewSet(
 		"BotProtection",
 		"NetworkProtection",
 		"FraudProtection",
+		"Risk",
 		"TestMode",
 		"AuthenticationFlow",
 		"ExternalJWT",
@@ -48,6 +49,7 @@ var ConfigDeps = wire.NewSet(
 		"Biometric",
 		"OnConflict",
 		"LDAP",
+		"SAML",
 	),
 	wire.FieldsOf(new(*config.MessagingConfig),
 		"Deprecated_SMSProvider",
@@ -128,6 +130,10 @@ var secretDeps = wire.NewSet(
 	ProvideOAuthSSOProviderCredentials,
 	ProvideSMTPServerCredentials,
 	ProvideSMTPServerCredentialsItem,
+	ProvideSESCredentials,
+	ProvideSendGridCredentials,
+	ProvideMailgunCredentials,
+	ProvidePostmarkCredentials,
 	ProvideTwilioCredentials,
 	ProvideNexmoCredentials,
 	ProvideCustomSMSProviderConfig,
@@ -144,6 +150,7 @@ var secretDeps = wire.NewSet(
 	ProvideLDAPServerUserCredentials,
 	ProvideSAMLIdpSigningMaterials,
 	ProvideSAMLSpSigningMaterials,
+	ProvideSAMLIdentityProviderCertificates,
 	ProvideSSOOAuthDemoCredentials,
 )
 
@@ -197,6 +204,26 @@ func ProvideSMTPServerCredentialsItem(c *config.SecretConfig) *config.SMTPServer
 	return (*config.SMTPServerCredentialsSecretItem)(s)
 }
 
+func ProvideSESCredentials(c *config.SecretConfig) *config.SESCredentials {
+	s, _ := c.LookupData(config.SESCredentialsKey).(*config.SESCredentials)
+	return s
+}
+
+func ProvideSendGridCredentials(c *config.SecretConfig) *config.SendGridCredentials {
+	s, _ := c.LookupData(config.SendGridCredentialsKey).(*config.SendGridCredentials)
+	return s
+}
+
+func ProvideMailgunCredentials(c *config.SecretConfig) *config.MailgunCredentials {
+	s, _ := c.LookupData(config.MailgunCredentialsKey).(*config.MailgunCredentials)
+	return s
+}
+
+func ProvidePostmarkCredentials(c *config.SecretConfig) *config.PostmarkCredentials {
+	s, _ := c.LookupData(config.PostmarkCredentialsKey).(*config.PostmarkCredentials)
+	return s
+}
+
 func ProvideTwilioCredentials(c *config.SecretConfig) *config.TwilioCredentials {
 	s, _ := c.LookupData(config.TwilioCredentialsKey).(*config.TwilioCredentials)
 	return s
@@ -280,3 +307,8 @@ func ProvideSAMLSpSigningMaterials(c *config.SecretConfig) *config.SAMLSpSigning
 	s, _ := c.LookupData(config.SAMLSpSigningMaterialsKey).(*config.SAMLSpSigningMaterials)
 	return s
 }
+
+func ProvideSAMLIdentityProviderCertificates(c *config.SecretConfig) *config.SAMLIdentityProviderCertificates {
+	s, _ := c.LookupData(config.SAMLIdentityProviderCertificatesKey).(*config.SAMLIdentityProviderCertificates)
+	return s
+}
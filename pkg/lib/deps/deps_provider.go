@@ -15,6 +15,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/globaldb"
 	"github.com/authgear/authgear-server/pkg/lib/infra/redis/globalredis"
+	"github.com/authgear/authgear-server/pkg/lib/risk"
 	"github.com/authgear/authgear-server/pkg/lib/web"
 	"github.com/authgear/authgear-server/pkg/lib/workflow"
 	"github.com/authgear/authgear-server/pkg/util/clock"
@@ -49,6 +50,8 @@ var EnvConfigDeps = wire.NewSet(
 		"UserExportObjectStore",
 		"SMSGatewayConfig",
 		"SharedAuthgearEndpoint",
+		"TLSClientCertificateHeader",
+		"PwnedPasswords",
 	),
 	wire.FieldsOf(new(*config.SMSGatewayEnvironmentConfig),
 		"Default",
@@ -102,6 +105,7 @@ var AppRootDeps = wire.NewSet(
 	wire.Bind(new(workflow.ServiceDatabase), new(*appdb.Handle)),
 	wire.Bind(new(authenticationflow.ServiceDatabase), new(*appdb.Handle)),
 	wire.Bind(new(fraudprotection.DatabaseHandle), new(*appdb.Handle)),
+	wire.Bind(new(risk.DatabaseHandle), new(*appdb.Handle)),
 	wire.Bind(new(template.ResourceManager), new(*resource.Manager)),
 	wire.Bind(new(loginid.ResourceManager), new(*resource.Manager)),
 	wire.Bind(new(web.ResourceManager), new(*resource.Manager)),
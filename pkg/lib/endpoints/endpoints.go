@@ -68,9 +68,28 @@ func (e *Endpoints) AuthorizeEndpointURL() *url.URL  { return e.urlOf("oauth2/au
 func (e *Endpoints) ConsentEndpointURL() *url.URL    { return e.urlOf("oauth2/consent") }
 func (e *Endpoints) TokenEndpointURL() *url.URL      { return e.urlOf("oauth2/token") }
 func (e *Endpoints) RevokeEndpointURL() *url.URL     { return e.urlOf("oauth2/revoke") }
+func (e *Endpoints) IntrospectEndpointURL() *url.URL { return e.urlOf("oauth2/introspect") }
 func (e *Endpoints) JWKSEndpointURL() *url.URL       { return e.urlOf("oauth2/jwks") }
 func (e *Endpoints) UserInfoEndpointURL() *url.URL   { return e.urlOf("oauth2/userinfo") }
 func (e *Endpoints) EndSessionEndpointURL() *url.URL { return e.urlOf("oauth2/end_session") }
+func (e *Endpoints) DeviceAuthorizationEndpointURL() *url.URL {
+	return e.urlOf("oauth2/device_authorization")
+}
+func (e *Endpoints) DeviceVerificationEndpointURL() *url.URL {
+	return e.urlOf("./device")
+}
+func (e *Endpoints) BackchannelAuthenticationEndpointURL() *url.URL {
+	return e.urlOf("oauth2/bc-authorize")
+}
+func (e *Endpoints) BackchannelAuthenticationApprovalEndpointURL() *url.URL {
+	return e.urlOf("./backchannel_authentication")
+}
+func (e *Endpoints) PushedAuthorizationRequestEndpointURL() *url.URL {
+	return e.urlOf("oauth2/par")
+}
+func (e *Endpoints) RegistrationEndpointURL() *url.URL {
+	return e.urlOf("oauth2/register")
+}
 func (e *Endpoints) OAuthEntrypointURL() *url.URL {
 	return e.urlOf("_internals/oauth_entrypoint")
 }
@@ -131,6 +150,12 @@ func (e *Endpoints) LoginLinkVerificationEndpointURL() *url.URL {
 	}
 }
 
+// MagicLinkEndpointURL does not depend on the UI implementation,
+// because the magic link page is served regardless of it.
+func (e *Endpoints) MagicLinkEndpointURL() *url.URL {
+	return e.urlOf("/authflow/v2/magic_link")
+}
+
 func (e *Endpoints) LogoutURL(redirectURI *url.URL) *url.URL {
 	return urlutil.WithQueryParamsAdded(
 		e.LogoutEndpointURL(),
@@ -218,3 +243,14 @@ func (e *Endpoints) SAMLLoginFinishURL() *url.URL {
 func (e *Endpoints) SAMLLogoutURL(serviceProviderId string) *url.URL {
 	return e.urlOf(fmt.Sprintf("saml2/logout/%s", serviceProviderId))
 }
+
+func (e *Endpoints) SAMLSPMetadataURL(identityProviderName string) *url.URL {
+	u := e.urlOf("saml2/sp/metadata")
+	u.Path = path.Join(u.Path, url.PathEscape(identityProviderName))
+	return u
+}
+func (e *Endpoints) SAMLSPACSURL(identityProviderName string) *url.URL {
+	u := e.urlOf("sso/saml2/acs")
+	u.Path = path.Join(u.Path, url.PathEscape(identityProviderName))
+	return u
+}
@@ -26,6 +26,13 @@ func TestEndpoints(t *testing.T) {
 		So(endpoints.ConsentEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/consent")
 		So(endpoints.TokenEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/token")
 		So(endpoints.RevokeEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/revoke")
+		So(endpoints.IntrospectEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/introspect")
+		So(endpoints.DeviceAuthorizationEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/device_authorization")
+		So(endpoints.DeviceVerificationEndpointURL().String(), ShouldEqual, "https://example.com/device")
+		So(endpoints.PushedAuthorizationRequestEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/par")
+		So(endpoints.BackchannelAuthenticationEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/bc-authorize")
+		So(endpoints.BackchannelAuthenticationApprovalEndpointURL().String(), ShouldEqual, "https://example.com/backchannel_authentication")
+		So(endpoints.RegistrationEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/register")
 		So(endpoints.JWKSEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/jwks")
 		So(endpoints.UserInfoEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/userinfo")
 		So(endpoints.EndSessionEndpointURL().String(), ShouldEqual, "https://example.com/oauth2/end_session")
@@ -36,6 +36,7 @@ func NewService(
 	searchSink *reindex.Sink,
 	userInfoSink *userinfo.Sink,
 	firstAuthSink *analytic.FirstAuthSink,
+	webhookOutbox *hook.WebhookDeliveryService,
 ) *Service {
 	return &Service{
 		AppID:           appID,
@@ -54,5 +55,6 @@ func NewService(
 			userInfoSink,
 			firstAuthSink,
 		},
+		WebhookOutbox: webhookOutbox,
 	}
 }
@@ -30,6 +30,11 @@ type Sink interface {
 	WillDeliverBlockingEvent(eventType event.Type) bool
 }
 
+// WebhookOutbox persists the webhooks of a non-blocking event in the ongoing transaction.
+type WebhookOutbox interface {
+	EnqueueNonBlockingEvent(ctx context.Context, e *event.Event) error
+}
+
 type Store interface {
 	NextSequenceNumber(ctx context.Context) (int64, error)
 }
@@ -52,6 +57,7 @@ type Service struct {
 	Store           Store
 	Resolver        Resolver
 	Sinks           []Sink
+	WebhookOutbox   WebhookOutbox
 
 	NonBlockingPayloads []event.NonBlockingPayload `wire:"-"`
 	NonBlockingEvents   []*event.Event             `wire:"-"`
@@ -177,6 +183,12 @@ func (s *Service) WillCommitTx(ctx context.Context) (err error) {
 		if err != nil {
 			return err
 		}
+		// Write the webhooks in the same transaction so that they are not lost
+		// if the delivery fails after commit.
+		err = s.WebhookOutbox.EnqueueNonBlockingEvent(ctx, e)
+		if err != nil {
+			return err
+		}
 		s.NonBlockingEvents = append(s.NonBlockingEvents, e)
 	}
 
@@ -114,6 +114,43 @@ func (mr *MockSinkMockRecorder) WillDeliverBlockingEvent(eventType interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WillDeliverBlockingEvent", reflect.TypeOf((*MockSink)(nil).WillDeliverBlockingEvent), eventType)
 }
 
+// MockWebhookOutbox is a mock of WebhookOutbox interface.
+type MockWebhookOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookOutboxMockRecorder
+}
+
+// MockWebhookOutboxMockRecorder is the mock recorder for MockWebhookOutbox.
+type MockWebhookOutboxMockRecorder struct {
+	mock *MockWebhookOutbox
+}
+
+// NewMockWebhookOutbox creates a new mock instance.
+func NewMockWebhookOutbox(ctrl *gomock.Controller) *MockWebhookOutbox {
+	mock := &MockWebhookOutbox{ctrl: ctrl}
+	mock.recorder = &MockWebhookOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookOutbox) EXPECT() *MockWebhookOutboxMockRecorder {
+	return m.recorder
+}
+
+// EnqueueNonBlockingEvent mocks base method.
+func (m *MockWebhookOutbox) EnqueueNonBlockingEvent(ctx context.Context, e *event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueNonBlockingEvent", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueNonBlockingEvent indicates an expected call of EnqueueNonBlockingEvent.
+func (mr *MockWebhookOutboxMockRecorder) EnqueueNonBlockingEvent(ctx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueNonBlockingEvent", reflect.TypeOf((*MockWebhookOutbox)(nil).EnqueueNonBlockingEvent), ctx, e)
+}
+
 // MockStore is a mock of Store interface.
 type MockStore struct {
 	ctrl     *gomock.Controller
@@ -29,6 +29,7 @@ func TestServiceDispatchEvent(t *testing.T) {
 		sink := NewMockSink(ctrl)
 		store := NewMockStore(ctrl)
 		resolver := NewMockResolver(ctrl)
+		webhookOutbox := NewMockWebhookOutbox(ctrl)
 		fallbackLanguage := "en"
 		supportedLanguages := []string{"en"}
 		localization := &config.LocalizationConfig{
@@ -37,12 +38,13 @@ func TestServiceDispatchEvent(t *testing.T) {
 		}
 
 		service := &Service{
-			Database:     database,
-			Clock:        clock,
-			Localization: localization,
-			Store:        store,
-			Resolver:     resolver,
-			Sinks:        []Sink{sink},
+			Database:      database,
+			Clock:         clock,
+			Localization:  localization,
+			Store:         store,
+			Resolver:      resolver,
+			Sinks:         []Sink{sink},
+			WebhookOutbox: webhookOutbox,
 		}
 
 		var seq0 int64
@@ -250,6 +252,7 @@ func TestServiceDispatchEvent(t *testing.T) {
 
 			store.EXPECT().NextSequenceNumber(ctx).AnyTimes().Return(seq0, nil)
 			resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+			webhookOutbox.EXPECT().EnqueueNonBlockingEvent(ctx, gomock.Any()).Times(1).Return(nil)
 			sink.EXPECT().ReceiveNonBlockingEvent(ctx, &event.Event{
 				ID:      "0000000000000000",
 				Type:    payload.NonBlockingEventType(),
@@ -307,6 +310,7 @@ func TestServiceDispatchEvent(t *testing.T) {
 				},
 			}).Return(fmt.Errorf("e"))
 			sink.EXPECT().ReceiveNonBlockingEvent(ctx, gomock.Any()).Times(0)
+			webhookOutbox.EXPECT().EnqueueNonBlockingEvent(ctx, gomock.Any()).Times(0)
 
 			err := service.DispatchEventOnCommit(ctx, nonBlocking)
 			So(err, ShouldBeNil)
@@ -443,6 +447,7 @@ func TestServiceDispatchEvent(t *testing.T) {
 			store.EXPECT().NextSequenceNumber(ctx).AnyTimes().Return(seq0, nil)
 			database.EXPECT().UseHook(gomock.Any(), service).AnyTimes()
 			resolver.EXPECT().Resolve(ctx, payload).Times(1).Return(nil)
+			webhookOutbox.EXPECT().EnqueueNonBlockingEvent(ctx, gomock.Any()).Times(1).Return(nil)
 
 			err := service.DispatchEventOnCommit(ctx, payload)
 			So(err, ShouldBeNil)
@@ -466,6 +471,7 @@ func TestServiceDispatchEvent(t *testing.T) {
 			store.EXPECT().NextSequenceNumber(ctx).AnyTimes().Return(seq0, nil)
 			database.EXPECT().UseHook(gomock.Any(), service).AnyTimes()
 			resolver.EXPECT().Resolve(ctx, payload).Times(1).Return(nil)
+			webhookOutbox.EXPECT().EnqueueNonBlockingEvent(ctx, gomock.Any()).Times(1).Return(nil)
 
 			err := service.DispatchEventOnCommit(ctx, payload)
 			So(err, ShouldBeNil)
@@ -474,6 +480,30 @@ func TestServiceDispatchEvent(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("fail the transaction if the webhooks cannot be enqueued", func() {
+			userID := "user-id"
+			payload := &MockNonBlockingEvent1{
+				MockUserEventBase: MockUserEventBase{model.User{
+					Meta: model.Meta{ID: userID},
+				}},
+			}
+			service.NonBlockingPayloads = []event.NonBlockingPayload{
+				payload,
+			}
+
+			ctx := context.Background()
+
+			store.EXPECT().NextSequenceNumber(ctx).AnyTimes().Return(seq0, nil)
+			resolver.EXPECT().Resolve(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+			webhookOutbox.EXPECT().EnqueueNonBlockingEvent(ctx, gomock.Any()).Times(1).Return(fmt.Errorf("e"))
+			sink.EXPECT().ReceiveNonBlockingEvent(ctx, gomock.Any()).Times(0)
+
+			err := service.WillCommitTx(ctx)
+			So(err, ShouldBeError, "e")
+
+			service.DidCommitTx(ctx)
+		})
+
 		Convey("DispatchEventImmediately calls Resolve exactly once", func() {
 			userID := "user-id"
 			user := model.User{
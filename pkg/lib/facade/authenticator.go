@@ -32,7 +32,11 @@ func (a AuthenticatorFacade) NewWithAuthenticatorID(ctx context.Context, authent
 }
 
 func (a AuthenticatorFacade) Create(ctx context.Context, authenticatorInfo *authenticator.Info, markVerified bool) error {
-	return a.Coordinator.AuthenticatorCreate(ctx, authenticatorInfo, markVerified)
+	return a.Coordinator.AuthenticatorCreate(ctx, authenticatorInfo, markVerified, false)
+}
+
+func (a AuthenticatorFacade) CreateByAdmin(ctx context.Context, authenticatorInfo *authenticator.Info) error {
+	return a.Coordinator.AuthenticatorCreate(ctx, authenticatorInfo, false, true)
 }
 
 func (a AuthenticatorFacade) Update(ctx context.Context, authenticatorInfo *authenticator.Info) error {
@@ -44,7 +48,11 @@ func (a AuthenticatorFacade) UpdatePassword(ctx context.Context, authenticatorIn
 }
 
 func (a AuthenticatorFacade) Delete(ctx context.Context, authenticatorInfo *authenticator.Info) error {
-	return a.Coordinator.AuthenticatorDelete(ctx, authenticatorInfo)
+	return a.Coordinator.AuthenticatorDelete(ctx, authenticatorInfo, false)
+}
+
+func (a AuthenticatorFacade) DeleteByAdmin(ctx context.Context, authenticatorInfo *authenticator.Info) error {
+	return a.Coordinator.AuthenticatorDelete(ctx, authenticatorInfo, true)
 }
 
 func (a AuthenticatorFacade) VerifyWithSpec(ctx context.Context, info *authenticator.Info, spec *authenticator.Spec, options *VerifyOptions) (verifyResult *service.VerifyResult, err error) {
@@ -26,6 +26,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	liboauthrelyingparty "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
 	"github.com/authgear/authgear-server/pkg/util/accesscontrol"
@@ -97,6 +98,7 @@ type MFAService interface {
 	CreateDeviceToken(ctx context.Context, userID string, token string) (*mfa.DeviceToken, error)
 	VerifyDeviceToken(ctx context.Context, userID string, token string) error
 	InvalidateAllDeviceTokens(ctx context.Context, userID string) error
+	CountDeviceTokens(ctx context.Context, userID string) (int, error)
 	VerifyRecoveryCode(ctx context.Context, userID string, code string) (*mfa.RecoveryCode, error)
 	ConsumeRecoveryCode(ctx context.Context, rc *mfa.RecoveryCode) error
 	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []string) ([]*mfa.RecoveryCode, error)
@@ -132,6 +134,10 @@ type RolesGroupsCommands interface {
 	DeleteUserRole(ctx context.Context, userID string) error
 }
 
+type OrganizationCommands interface {
+	DeleteUserMembership(ctx context.Context, userID string) error
+}
+
 type PasswordHistoryStore interface {
 	ResetPasswordHistory(ctx context.Context, userID string) error
 }
@@ -176,6 +182,7 @@ type Coordinator struct {
 	UserCommands               UserCommands
 	UserQueries                UserQueries
 	RolesGroupsCommands        RolesGroupsCommands
+	OrganizationCommands       OrganizationCommands
 	StdAttrsService            StdAttrsService
 	PasswordHistory            PasswordHistoryStore
 	OAuth                      OAuthService
@@ -222,7 +229,12 @@ func (c *Coordinator) IdentityUpdateWithSpec(ctx context.Context, is *identity.I
 }
 
 func (c *Coordinator) IdentityCreate(ctx context.Context, is *identity.Info) error {
-	err := c.Identities.Create(ctx, is)
+	err := c.dispatchIdentityPreCreateEvent(ctx, is, false)
+	if err != nil {
+		return err
+	}
+
+	err = c.Identities.Create(ctx, is)
 	if err != nil {
 		return err
 	}
@@ -250,6 +262,10 @@ func (c *Coordinator) IdentityCreateByAdmin(ctx context.Context, userID string,
 		return nil, err
 	}
 
+	if err := c.dispatchIdentityPreCreateEvent(ctx, iden, true); err != nil {
+		return nil, err
+	}
+
 	if err := c.Identities.Create(ctx, iden); err != nil {
 		return nil, err
 	}
@@ -299,6 +315,18 @@ func (c *Coordinator) IdentityCreateByAdmin(ctx context.Context, userID string,
 	return iden, nil
 }
 
+func (c *Coordinator) dispatchIdentityPreCreateEvent(ctx context.Context, is *identity.Info, isAdminAPI bool) error {
+	return c.Events.DispatchEventOnCommit(ctx, &blocking.IdentityPreCreateBlockingEventPayload{
+		UserRef: model.UserRef{
+			Meta: model.Meta{
+				ID: is.UserID,
+			},
+		},
+		Identity: is.ToModel(),
+		AdminAPI: isAdminAPI,
+	})
+}
+
 func (c *Coordinator) removeAnonymousIdentitiesOfUser(ctx context.Context, userID string) (err error) {
 	idens, err := c.Identities.ListByUser(ctx, userID)
 	if err != nil {
@@ -410,7 +438,21 @@ func (c *Coordinator) IdentityUpdate(ctx context.Context, oldInfo *identity.Info
 func (c *Coordinator) IdentityDelete(ctx context.Context, is *identity.Info, bypassChecks bool) error {
 	userID := is.UserID
 
-	err := c.Identities.Delete(ctx, is)
+	// Only the Admin API bypasses the checks.
+	err := c.Events.DispatchEventOnCommit(ctx, &blocking.IdentityPreDeleteBlockingEventPayload{
+		UserRef: model.UserRef{
+			Meta: model.Meta{
+				ID: userID,
+			},
+		},
+		Identity: is.ToModel(),
+		AdminAPI: bypassChecks,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Identities.Delete(ctx, is)
 	if err != nil {
 		return err
 	}
@@ -506,8 +548,21 @@ func (c *Coordinator) AuthenticatorNewWithAuthenticatorID(ctx context.Context, a
 	return c.Authenticators.NewWithAuthenticatorID(ctx, authenticatorID, spec)
 }
 
-func (c *Coordinator) AuthenticatorCreate(ctx context.Context, authenticatorInfo *authenticator.Info, markVerified bool) error {
-	err := c.Authenticators.Create(ctx, authenticatorInfo)
+func (c *Coordinator) AuthenticatorCreate(ctx context.Context, authenticatorInfo *authenticator.Info, markVerified bool, isAdminAPI bool) error {
+	err := c.Events.DispatchEventOnCommit(ctx, &blocking.AuthenticatorPreCreateBlockingEventPayload{
+		UserRef: model.UserRef{
+			Meta: model.Meta{
+				ID: authenticatorInfo.UserID,
+			},
+		},
+		Authenticator: authenticatorInfo.ToModel(),
+		AdminAPI:      isAdminAPI,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Authenticators.Create(ctx, authenticatorInfo)
 	if err != nil {
 		return err
 	}
@@ -531,8 +586,21 @@ func (c *Coordinator) AuthenticatorUpdatePassword(ctx context.Context, authentic
 	return c.Authenticators.UpdatePassword(ctx, authenticatorInfo, options)
 }
 
-func (c *Coordinator) AuthenticatorDelete(ctx context.Context, authenticatorInfo *authenticator.Info) error {
-	err := c.Authenticators.Delete(ctx, authenticatorInfo)
+func (c *Coordinator) AuthenticatorDelete(ctx context.Context, authenticatorInfo *authenticator.Info, isAdminAPI bool) error {
+	err := c.Events.DispatchEventOnCommit(ctx, &blocking.AuthenticatorPreDeleteBlockingEventPayload{
+		UserRef: model.UserRef{
+			Meta: model.Meta{
+				ID: authenticatorInfo.UserID,
+			},
+		},
+		Authenticator: authenticatorInfo.ToModel(),
+		AdminAPI:      isAdminAPI,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.Authenticators.Delete(ctx, authenticatorInfo)
 	if err != nil {
 		return err
 	}
@@ -853,6 +921,11 @@ func (c *Coordinator) UserDelete(ctx context.Context, userID string, isScheduled
 		return err
 	}
 
+	// Organizations:
+	if err = c.OrganizationCommands.DeleteUserMembership(ctx, userID); err != nil {
+		return err
+	}
+
 	userModel, err := c.UserQueries.Get(ctx, userID, accesscontrol.RoleGreatest)
 	if err != nil {
 		return err
@@ -984,8 +1057,10 @@ func (c *Coordinator) markOAuthEmailAsVerified(ctx context.Context, info *identi
 	email, ok := standardClaims[model.ClaimEmail]
 	if ok && cfg != nil {
 		assumedVerified := cfg.EmailClaimConfig().AssumeVerified()
-		if assumedVerified {
-			// Mark as verified if OAuth email is assumed to be verified
+		emailVerified, _ := info.AllStandardClaims()[stdattrs.EmailVerified].(bool)
+		if assumedVerified || (emailVerified && liboauthrelyingparty.TrustsEmailVerifiedClaim(cfg.Type())) {
+			// Mark as verified if OAuth email is assumed to be verified,
+			// or the provider says it is verified.
 			err := c.markVerified(ctx, info.UserID, map[model.ClaimName]string{
 				model.ClaimEmail: email,
 			})
@@ -1086,6 +1161,8 @@ type SetDisabledOptions struct {
 	Reason                   *string
 	TemporarilyDisabledFrom  *time.Time
 	TemporarilyDisabledUntil *time.Time
+	// IsSystem is true when the user is disabled by a background job.
+	IsSystem bool
 }
 
 func (c *Coordinator) UserDisable(ctx context.Context, options SetDisabledOptions) error {
@@ -1132,6 +1209,7 @@ func (c *Coordinator) UserDisable(ctx context.Context, options SetDisabledOption
 		},
 		TemporarilyDisabledFrom:  options.TemporarilyDisabledFrom,
 		TemporarilyDisabledUntil: options.TemporarilyDisabledUntil,
+		IsSystem:                 options.IsSystem,
 	}
 
 	err = c.Events.DispatchEventOnCommit(ctx, e)
@@ -1387,6 +1465,11 @@ func (c *Coordinator) UserAnonymize(ctx context.Context, userID string, IsSchedu
 		return err
 	}
 
+	// Organizations:
+	if err = c.OrganizationCommands.DeleteUserMembership(ctx, userID); err != nil {
+		return err
+	}
+
 	userModel, err := c.UserQueries.Get(ctx, userID, accesscontrol.RoleGreatest)
 	if err != nil {
 		return err
@@ -1668,6 +1751,10 @@ func (c *Coordinator) MFAInvalidateAllDeviceTokens(ctx context.Context, userID s
 	return c.MFA.InvalidateAllDeviceTokens(ctx, userID)
 }
 
+func (c *Coordinator) MFACountDeviceTokens(ctx context.Context, userID string) (int, error) {
+	return c.MFA.CountDeviceTokens(ctx, userID)
+}
+
 func (c *Coordinator) MFAVerifyRecoveryCode(ctx context.Context, userID string, code string) (*mfa.RecoveryCode, error) {
 	rc, err := c.MFA.VerifyRecoveryCode(ctx, userID, code)
 	authnType := authn.AuthenticationTypeRecoveryCode
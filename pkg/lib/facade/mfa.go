@@ -26,6 +26,10 @@ func (f *MFAFacade) InvalidateAllDeviceTokens(ctx context.Context, userID string
 	return f.Coordinator.MFAInvalidateAllDeviceTokens(ctx, userID)
 }
 
+func (f *MFAFacade) CountDeviceTokens(ctx context.Context, userID string) (int, error) {
+	return f.Coordinator.MFACountDeviceTokens(ctx, userID)
+}
+
 func (f *MFAFacade) VerifyRecoveryCode(ctx context.Context, userID string, code string) (*mfa.RecoveryCode, error) {
 	return f.Coordinator.MFAVerifyRecoveryCode(ctx, userID, code)
 }
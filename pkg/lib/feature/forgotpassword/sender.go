@@ -14,7 +14,7 @@ type TranslationService interface {
 }
 
 type SenderService interface {
-	SendEmailInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error
+	SendEmailAsync(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error
 }
 
 type Sender struct {
@@ -86,7 +86,7 @@ func (s *Sender) Send(ctx context.Context, userID string, password string, msgTy
 			HTMLBody:  data.HTMLBody.String,
 		}
 
-		if err := s.Sender.SendEmailInNewGoroutine(ctx, msgType, mailSendOptions); err != nil {
+		if err := s.Sender.SendEmailAsync(ctx, msgType, mailSendOptions); err != nil {
 			return err
 		}
 	}
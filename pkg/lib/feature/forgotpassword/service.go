@@ -289,6 +289,7 @@ func (s *Service) sendToPhone(ctx context.Context, phone string, userID string,
 			Target:            phone,
 			Form:              otpForm,
 			Type:              msgType,
+			FallbackType:      translation.MessageTypeForgotPassword,
 			Kind:              otpKind,
 			OTP:               code,
 			AdditionalContext: &otpCtx,
@@ -0,0 +1,52 @@
+package ldapdirectorysync
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/globaldb"
+	"github.com/authgear/authgear-server/pkg/util/backgroundjob"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// RunInterval is longer than backgroundjob.DefaultAfterDuration
+// because every run searches the whole directory.
+const RunInterval = 1 * time.Hour
+
+func NewRunner(ctx context.Context, runnableFactory backgroundjob.RunnableFactory) *backgroundjob.Runner {
+	return backgroundjob.NewRunner(
+		ctx,
+		runnableFactory,
+		backgroundjob.WithAfterDuration(RunInterval),
+	)
+}
+
+func NewRunnableFactory(
+	pool *db.Pool,
+	globalDBCredentials *config.GlobalDatabaseCredentialsEnvironmentConfig,
+	databaseCfg *config.DatabaseEnvironmentConfig,
+	clock clock.Clock,
+	appContextResolver AppContextResolver,
+	syncServiceFactory SyncServiceFactory,
+) backgroundjob.RunnableFactory {
+	factory := func() backgroundjob.Runnable {
+		return newRunnable(pool, globalDBCredentials, databaseCfg, clock, appContextResolver, syncServiceFactory)
+	}
+	return factory
+}
+
+var DependencySet = wire.NewSet(
+	NewRunnableFactory,
+	NewRunner,
+)
+
+var RunnableDependencySet = wire.NewSet(
+	globaldb.DependencySet,
+	wire.Struct(new(Store), "*"),
+	wire.Struct(new(Runnable), "*"),
+	wire.Bind(new(backgroundjob.Runnable), new(*Runnable)),
+)
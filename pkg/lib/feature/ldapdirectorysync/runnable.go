@@ -0,0 +1,52 @@
+package ldapdirectorysync
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+type AppContextResolver interface {
+	ResolveContext(ctx context.Context, appID string, fn func(context.Context, *config.AppContext) error) error
+}
+
+type SyncService interface {
+	SyncDirectory(ctx context.Context) error
+}
+
+type SyncServiceFactory interface {
+	MakeSyncService(appID string, appContext *config.AppContext) SyncService
+}
+
+var RunnableLogger = slogutil.NewLogger("ldap-directory-sync-runner")
+
+type Runnable struct {
+	Store              *Store
+	AppContextResolver AppContextResolver
+	SyncServiceFactory SyncServiceFactory
+}
+
+func (r *Runnable) Run(ctx context.Context) error {
+	logger := RunnableLogger.GetLogger(ctx)
+
+	appIDs, err := r.Store.ListAppIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, appID := range appIDs {
+		err = r.AppContextResolver.ResolveContext(ctx, appID, func(ctx context.Context, appCtx *config.AppContext) error {
+			syncService := r.SyncServiceFactory.MakeSyncService(appID, appCtx)
+			return syncService.SyncDirectory(ctx)
+		})
+		if err != nil {
+			// Do not let an unreachable directory block the other apps.
+			logger.WithError(err).Error(ctx, "failed to sync ldap directory",
+				slog.String("app_id", appID),
+			)
+		}
+	}
+	return nil
+}
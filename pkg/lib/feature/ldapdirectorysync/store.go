@@ -0,0 +1,44 @@
+package ldapdirectorysync
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/globaldb"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type Store struct {
+	Handle      *globaldb.Handle
+	SQLBuilder  *globaldb.SQLBuilder
+	SQLExecutor *globaldb.SQLExecutor
+	Clock       clock.Clock
+}
+
+// ListAppIDs returns the apps that have LDAP identities.
+// Whether directory sync is enabled is only known after the app config is resolved.
+func (s *Store) ListAppIDs(ctx context.Context) (appIDs []string, err error) {
+	err = s.Handle.ReadOnly(ctx, func(ctx context.Context) (err error) {
+		q := s.SQLBuilder.
+			Select("DISTINCT app_id").
+			From(s.SQLBuilder.TableName("_auth_identity_ldap"))
+		rows, err := s.SQLExecutor.QueryWith(ctx, q)
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var appID string
+			err = rows.Scan(&appID)
+			if err != nil {
+				return
+			}
+			appIDs = append(appIDs, appID)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
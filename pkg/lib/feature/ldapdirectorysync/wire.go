@@ -0,0 +1,23 @@
+//go:build wireinject
+
+package ldapdirectorysync
+
+import (
+	"github.com/google/wire"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/backgroundjob"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+func newRunnable(
+	pool *db.Pool,
+	globalDBCredentials *config.GlobalDatabaseCredentialsEnvironmentConfig,
+	databaseCfg *config.DatabaseEnvironmentConfig,
+	clock clock.Clock,
+	appContextResolver AppContextResolver,
+	syncServiceFactory SyncServiceFactory,
+) backgroundjob.Runnable {
+	panic(wire.Build(RunnableDependencySet))
+}
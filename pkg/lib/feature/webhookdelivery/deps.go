@@ -0,0 +1,52 @@
+package webhookdelivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/globaldb"
+	"github.com/authgear/authgear-server/pkg/util/backgroundjob"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// RunInterval is shorter than backgroundjob.DefaultAfterDuration
+// because a new delivery is only attempted by the runner.
+const RunInterval = 10 * time.Second
+
+func NewRunner(ctx context.Context, runnableFactory backgroundjob.RunnableFactory) *backgroundjob.Runner {
+	return backgroundjob.NewRunner(
+		ctx,
+		runnableFactory,
+		backgroundjob.WithAfterDuration(RunInterval),
+	)
+}
+
+func NewRunnableFactory(
+	pool *db.Pool,
+	globalDBCredentials *config.GlobalDatabaseCredentialsEnvironmentConfig,
+	databaseCfg *config.DatabaseEnvironmentConfig,
+	clock clock.Clock,
+	appContextResolver AppContextResolver,
+	deliveryServiceFactory DeliveryServiceFactory,
+) backgroundjob.RunnableFactory {
+	factory := func() backgroundjob.Runnable {
+		return newRunnable(pool, globalDBCredentials, databaseCfg, clock, appContextResolver, deliveryServiceFactory)
+	}
+	return factory
+}
+
+var DependencySet = wire.NewSet(
+	NewRunnableFactory,
+	NewRunner,
+)
+
+var RunnableDependencySet = wire.NewSet(
+	globaldb.DependencySet,
+	wire.Struct(new(Store), "*"),
+	wire.Struct(new(Runnable), "*"),
+	wire.Bind(new(backgroundjob.Runnable), new(*Runnable)),
+)
@@ -0,0 +1,109 @@
+package webhookdelivery
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/panicutil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+// MaxConcurrentApps is the number of apps whose deliveries are attempted in parallel.
+// The deliveries of an app are attempted serially.
+const MaxConcurrentApps = 8
+
+type AppContextResolver interface {
+	ResolveContext(ctx context.Context, appID string, fn func(context.Context, *config.AppContext) error) error
+}
+
+type DeliveryService interface {
+	Deliver(ctx context.Context, deliveryID string) error
+}
+
+type DeliveryServiceFactory interface {
+	MakeDeliveryService(appID string, appContext *config.AppContext) DeliveryService
+}
+
+var RunnableLogger = slogutil.NewLogger("webhook-delivery-runner")
+
+type Runnable struct {
+	Store                  *Store
+	AppContextResolver     AppContextResolver
+	DeliveryServiceFactory DeliveryServiceFactory
+}
+
+func (r *Runnable) Run(ctx context.Context) error {
+	logger := RunnableLogger.GetLogger(ctx)
+
+	deletedCount, err := r.Store.DeleteExpiredDeliveries(ctx)
+	if err != nil {
+		// Failing to clean up must not stop the deliveries.
+		logger.WithError(err).Error(ctx, "failed to delete expired webhook deliveries")
+	} else if deletedCount > 0 {
+		logger.Info(ctx, "deleted expired webhook deliveries", slog.Int64("count", deletedCount))
+	}
+
+	appDeliveries, err := r.Store.ListDueDeliveries(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Group the deliveries by app so that each app context is resolved once.
+	var appIDs []string
+	deliveryIDsByAppID := map[string][]string{}
+	for _, appDelivery := range appDeliveries {
+		if _, ok := deliveryIDsByAppID[appDelivery.AppID]; !ok {
+			appIDs = append(appIDs, appDelivery.AppID)
+		}
+		deliveryIDsByAppID[appDelivery.AppID] = append(deliveryIDsByAppID[appDelivery.AppID], appDelivery.DeliveryID)
+	}
+
+	// Slow endpoints of an app must not delay the deliveries of the other apps.
+	var waitGroup sync.WaitGroup
+	sem := make(chan struct{}, MaxConcurrentApps)
+	for _, appID := range appIDs {
+		sem <- struct{}{}
+		waitGroup.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				waitGroup.Done()
+			}()
+			r.deliver(ctx, appID, deliveryIDsByAppID[appID])
+		}()
+	}
+	waitGroup.Wait()
+
+	return nil
+}
+
+func (r *Runnable) deliver(ctx context.Context, appID string, deliveryIDs []string) {
+	logger := RunnableLogger.GetLogger(ctx)
+	defer func() {
+		if anyValue := recover(); anyValue != nil {
+			err := panicutil.MakeError(anyValue)
+			logger.WithError(err).Error(ctx, "panic occurred when delivering webhooks",
+				slog.String("app_id", appID),
+			)
+		}
+	}()
+
+	err := r.AppContextResolver.ResolveContext(ctx, appID, func(ctx context.Context, appCtx *config.AppContext) error {
+		deliveryService := r.DeliveryServiceFactory.MakeDeliveryService(appID, appCtx)
+		for _, deliveryID := range deliveryIDs {
+			err := deliveryService.Deliver(ctx, deliveryID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Do not let a single app block the deliveries of the other apps.
+		logger.WithError(err).Error(ctx, "failed to deliver webhooks",
+			slog.String("app_id", appID),
+		)
+	}
+}
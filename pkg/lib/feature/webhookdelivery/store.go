@@ -0,0 +1,131 @@
+package webhookdelivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/globaldb"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// BatchSize is the maximum number of deliveries attempted in one run.
+const BatchSize = 200
+
+// AppBatchSize is the maximum number of deliveries of an app attempted in one run,
+// so that an app with a large backlog, or slow endpoints, does not starve the other apps.
+const AppBatchSize = 20
+
+// RetentionPeriod is how long succeeded and failed deliveries are kept
+// for the webhook delivery history.
+const RetentionPeriod = 30 * 24 * time.Hour
+
+// CleanupBatchSize is the maximum number of deliveries deleted in one run.
+const CleanupBatchSize = 1000
+
+type Store struct {
+	Handle      *globaldb.Handle
+	SQLBuilder  *globaldb.SQLBuilder
+	SQLExecutor *globaldb.SQLExecutor
+	Clock       clock.Clock
+}
+
+type AppDelivery struct {
+	AppID      string
+	DeliveryID string
+}
+
+// ListDueDeliveries returns a list of (appID, deliveryID) pairs that
+// it is the time to attempt delivery.
+// At most AppBatchSize deliveries are returned for each app.
+func (s *Store) ListDueDeliveries(ctx context.Context) (appDeliveries []AppDelivery, err error) {
+	now := s.Clock.NowUTC()
+	err = s.Handle.ReadOnly(ctx, func(ctx context.Context) (err error) {
+		subquery := s.SQLBuilder.
+			Select(
+				"app_id",
+				"id",
+				"next_attempt_at",
+				"ROW_NUMBER() OVER (PARTITION BY app_id ORDER BY next_attempt_at ASC) AS app_rank",
+			).
+			From(s.SQLBuilder.TableName("_auth_webhook_delivery")).
+			Where("status = 'pending' AND next_attempt_at <= ?", now)
+
+		q := s.SQLBuilder.
+			Select("app_id", "id").
+			FromSelect(subquery, "d").
+			Where("app_rank <= ?", AppBatchSize).
+			OrderBy("next_attempt_at ASC").
+			Limit(BatchSize)
+		rows, err := s.SQLExecutor.QueryWith(ctx, q)
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var appDelivery AppDelivery
+			err = rows.Scan(
+				&appDelivery.AppID,
+				&appDelivery.DeliveryID,
+			)
+			if err != nil {
+				return
+			}
+			appDeliveries = append(appDeliveries, appDelivery)
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// DeleteExpiredDeliveries deletes the succeeded and failed deliveries
+// that were last updated before RetentionPeriod, and their attempts.
+func (s *Store) DeleteExpiredDeliveries(ctx context.Context) (count int64, err error) {
+	before := s.Clock.NowUTC().Add(-RetentionPeriod)
+	err = s.Handle.WithTx(ctx, func(ctx context.Context) (err error) {
+		q := s.SQLBuilder.
+			Select("id").
+			From(s.SQLBuilder.TableName("_auth_webhook_delivery")).
+			Where("status <> 'pending' AND updated_at < ?", before).
+			Limit(CleanupBatchSize)
+		rows, err := s.SQLExecutor.QueryWith(ctx, q)
+		if err != nil {
+			return
+		}
+		defer rows.Close()
+
+		var ids []string
+		for rows.Next() {
+			var id string
+			err = rows.Scan(&id)
+			if err != nil {
+				return
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) == 0 {
+			return
+		}
+
+		// The attempts are deleted by ON DELETE CASCADE.
+		result, err := s.SQLExecutor.ExecWith(ctx, s.SQLBuilder.
+			Delete(s.SQLBuilder.TableName("_auth_webhook_delivery")).
+			Where("id = ANY (?)", pq.Array(ids)),
+		)
+		if err != nil {
+			return
+		}
+		count, err = result.RowsAffected()
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -17,4 +17,8 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(DenoHook), "*"),
 	wire.Bind(new(EventDenoHook), new(*EventDenoHookImpl)),
 	wire.Struct(new(EventDenoHookImpl), "*"),
+	wire.Struct(new(WebhookDeliveryStore), "*"),
+	wire.Struct(new(WebhookDeliveryService), "*"),
+	wire.Bind(new(WebhookDeliveryServiceStore), new(*WebhookDeliveryStore)),
+	wire.Bind(new(WebhookDeliveryEventWebHook), new(*EventWebHookImpl)),
 )
@@ -160,6 +160,37 @@ func (h *EventWebHookImpl) DeliverNonBlockingEvent(ctx context.Context, u *url.U
 	return h.PerformNoResponse(ctx, h.AsyncHTTP.Client, request)
 }
 
+// DeliverNonBlockingEventPayload delivers an already serialized event synchronously.
+// The status code is returned whenever a response is received, even if err is not nil.
+func (h *EventWebHookImpl) DeliverNonBlockingEventPayload(ctx context.Context, u *url.URL, payload json.RawMessage) (statusCode int, err error) {
+	request, err := h.PrepareRequest(ctx, u, payload)
+	if err != nil {
+		return
+	}
+
+	resp, err := performRequest(h.AsyncHTTP.Client, request)
+	if resp != nil {
+		defer resp.Body.Close()
+		statusCode = resp.StatusCode
+	}
+
+	if err != nil {
+		otelutil.IntCounterAddOne(
+			ctx,
+			otelauthgear.CounterNonBlockingWebhookCount,
+			otelauthgear.WithStatusError(),
+		)
+	} else {
+		otelutil.IntCounterAddOne(
+			ctx,
+			otelauthgear.CounterNonBlockingWebhookCount,
+			otelauthgear.WithStatusOk(),
+		)
+	}
+
+	return
+}
+
 func performRequest(
 	client *http.Client,
 	request *http.Request) (resp *http.Response, err error) {
@@ -143,31 +143,7 @@ func (s *Sink) DeliverNonBlockingEvent(ctx context.Context, e *event.Event) erro
 		return nil
 	}
 
-	checkDeliver := func(events []string, target string) bool {
-		for _, event := range events {
-			if event == "*" {
-				return true
-			}
-			if event == target {
-				return true
-			}
-		}
-		return false
-	}
-
-	for _, hook := range s.Config.NonBlockingHandlers {
-		shouldDeliver := checkDeliver(hook.Events, string(e.Type))
-		if !shouldDeliver {
-			continue
-		}
-
-		errToIgnore := s.deliverNonBlockingEvent(ctx, hook, e)
-		if errToIgnore != nil {
-			logger.WithError(errToIgnore).Error(ctx, "failed to dispatch non blocking event")
-		}
-	}
-
-	for _, hookURL := range extraHookURLsFromEvent(e) {
+	for _, hookURL := range nonBlockingHandlerURLs(s.Config, e) {
 		errToIgnore := s.deliverNonBlockingEvent(ctx, config.NonBlockingHandlersConfig{
 			URL: hookURL,
 		}, e)
@@ -224,6 +200,10 @@ func (s *Sink) deliverNonBlockingEvent(ctx context.Context, cfg config.NonBlocki
 	}
 	switch {
 	case s.EventWebHook.SupportURL(u):
+		if e.WebhookDeliveryEnqueued {
+			// The delivery is handled by WebhookDeliveryService.
+			return nil
+		}
 		return s.EventWebHook.DeliverNonBlockingEvent(ctx, u, e)
 	case s.EventDenoHook.SupportURL(u):
 		return s.EventDenoHook.DeliverNonBlockingEvent(ctx, u, e)
@@ -232,6 +212,30 @@ func (s *Sink) deliverNonBlockingEvent(ctx context.Context, cfg config.NonBlocki
 	}
 }
 
+// nonBlockingHandlerURLs returns the URLs that e should be delivered to.
+func nonBlockingHandlerURLs(cfg *config.HookConfig, e *event.Event) []string {
+	checkDeliver := func(events []string, target string) bool {
+		for _, event := range events {
+			if event == "*" {
+				return true
+			}
+			if event == target {
+				return true
+			}
+		}
+		return false
+	}
+
+	var urls []string
+	for _, hook := range cfg.NonBlockingHandlers {
+		if checkDeliver(hook.Events, string(e.Type)) {
+			urls = append(urls, hook.URL)
+		}
+	}
+	urls = append(urls, extraHookURLsFromEvent(e)...)
+	return urls
+}
+
 func extraHookURLs(payload event.NonBlockingPayload) []string {
 	provider, ok := payload.(event.ExtraHookURLsProvider)
 	if !ok {
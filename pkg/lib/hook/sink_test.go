@@ -379,6 +379,35 @@ func TestSink(t *testing.T) {
 
 				So(err, ShouldBeNil)
 			})
+
+			Convey("should skip webhooks enqueued for durable delivery", func() {
+				cfg.NonBlockingHandlers = []config.NonBlockingHandlersConfig{
+					{
+						Events: []string{string(MockNonBlockingEventType3)},
+						URL:    "https://example.com/a",
+					},
+					{
+						Events: []string{string(MockNonBlockingEventType3)},
+						URL:    "authgeardeno:///deno/a.ts",
+					},
+				}
+				e.WebhookDeliveryEnqueued = true
+
+				webhook.EXPECT().SupportURL(mustURL(cfg.NonBlockingHandlers[0].URL)).AnyTimes().Return(true)
+				webhook.EXPECT().SupportURL(mustURL(cfg.NonBlockingHandlers[1].URL)).AnyTimes().Return(false)
+				denohook.EXPECT().SupportURL(mustURL(cfg.NonBlockingHandlers[1].URL)).AnyTimes().Return(true)
+				webhook.EXPECT().DeliverNonBlockingEvent(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				denohook.EXPECT().DeliverNonBlockingEvent(
+					gomock.Any(),
+					mustURL(cfg.NonBlockingHandlers[1].URL),
+					&e,
+				).Times(1).Return(nil)
+
+				ctx := context.Background()
+				err := s.DeliverNonBlockingEvent(ctx, &e)
+
+				So(err, ShouldBeNil)
+			})
 		})
 	})
 }
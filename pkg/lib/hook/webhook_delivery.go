@@ -0,0 +1,277 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/util/backoff"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+var WebhookDeliveryLogger = slogutil.NewLogger("webhook-delivery")
+
+var ErrWebhookDeliveryNotFound = apierrors.NotFound.WithReason("WebhookDeliveryNotFound").New("webhook delivery not found")
+
+const (
+	// WebhookDeliveryMaxAttempts is the number of attempts before a delivery is marked as failed.
+	WebhookDeliveryMaxAttempts = 12
+	// WebhookDeliveryRetryInterval is the backoff interval after the first failed attempt.
+	// It doubles after each failed attempt, up to WebhookDeliveryMaxRetryInterval.
+	WebhookDeliveryRetryInterval    = 1 * time.Minute
+	WebhookDeliveryMaxRetryInterval = 6 * time.Hour
+	// WebhookDeliveryLease is how long a claimed delivery is hidden from other workers.
+	// It must be longer than the timeout of AsyncHTTPClient.
+	WebhookDeliveryLease = 5 * time.Minute
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID            string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	EventID       string
+	EventType     string
+	URL           string
+	Payload       json.RawMessage
+	Status        WebhookDeliveryStatus
+	AttemptCount  int
+	NextAttemptAt *time.Time
+	LastAttemptAt *time.Time
+}
+
+type WebhookDeliveryAttempt struct {
+	ID         string
+	CreatedAt  time.Time
+	DeliveryID string
+	StatusCode *int
+	Latency    time.Duration
+	Error      *string
+}
+
+type WebhookDeliveryListOptions struct {
+	Status    *WebhookDeliveryStatus
+	EventType string
+}
+
+type WebhookDeliveryServiceStore interface {
+	CreateDelivery(ctx context.Context, d *WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, d *WebhookDelivery) error
+	ClaimDelivery(ctx context.Context, id string, now time.Time, leaseUntil time.Time) (bool, error)
+	GetDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error)
+	CreateAttempt(ctx context.Context, a *WebhookDeliveryAttempt) error
+}
+
+type WebhookDeliveryEventWebHook interface {
+	SupportURL(u *url.URL) bool
+	DeliverNonBlockingEventPayload(ctx context.Context, u *url.URL, payload json.RawMessage) (statusCode int, err error)
+}
+
+// WebhookDeliveryService is the transactional outbox of non-blocking webhooks.
+// Deliveries are written in the same transaction as the event,
+// and are delivered with retry by the background worker.
+type WebhookDeliveryService struct {
+	Clock        clock.Clock
+	Config       *config.HookConfig
+	Database     *appdb.Handle
+	Store        WebhookDeliveryServiceStore
+	EventWebHook WebhookDeliveryEventWebHook
+}
+
+// EnqueueNonBlockingEvent writes a pending delivery for each webhook URL of e.
+// It must be called within a transaction.
+func (s *WebhookDeliveryService) EnqueueNonBlockingEvent(ctx context.Context, e *event.Event) error {
+	payload, ok := e.Payload.(event.NonBlockingPayload)
+	if !ok || !e.IsNonBlocking || !payload.ForHook() {
+		return nil
+	}
+
+	var body []byte
+	for _, hookURL := range nonBlockingHandlerURLs(s.Config, e) {
+		u, err := url.Parse(hookURL)
+		if err != nil || !s.EventWebHook.SupportURL(u) {
+			// Let Sink deliver or report it.
+			continue
+		}
+
+		if body == nil {
+			body, err = json.Marshal(e)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := s.Clock.NowUTC()
+		d := &WebhookDelivery{
+			ID:            uuid.New(),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+			EventID:       e.ID,
+			EventType:     string(e.Type),
+			URL:           hookURL,
+			Payload:       body,
+			Status:        WebhookDeliveryStatusPending,
+			AttemptCount:  0,
+			NextAttemptAt: &now,
+		}
+		err = s.Store.CreateDelivery(ctx, d)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.WebhookDeliveryEnqueued = true
+	return nil
+}
+
+// Deliver makes an attempt to deliver a pending delivery that is due.
+// It is a no-op if the delivery has been claimed by another worker.
+func (s *WebhookDeliveryService) Deliver(ctx context.Context, deliveryID string) error {
+	logger := WebhookDeliveryLogger.GetLogger(ctx)
+
+	var d *WebhookDelivery
+	err := s.Database.WithTx(ctx, func(ctx context.Context) error {
+		now := s.Clock.NowUTC()
+		claimed, err := s.Store.ClaimDelivery(ctx, deliveryID, now, now.Add(WebhookDeliveryLease))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return nil
+		}
+
+		d, err = s.Store.GetDeliveryByID(ctx, deliveryID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return nil
+	}
+
+	attempt := s.attempt(ctx, d)
+
+	err = s.Database.WithTx(ctx, func(ctx context.Context) error {
+		return s.recordAttempt(ctx, d, attempt)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "attempted webhook delivery",
+		slog.String("delivery_id", d.ID),
+		slog.String("event_type", d.EventType),
+		slog.String("status", string(d.Status)),
+		slog.Int("attempt_count", d.AttemptCount),
+	)
+	return nil
+}
+
+// Redeliver enqueues a new delivery with the same payload and URL as deliveryID.
+// It must be called within a transaction.
+func (s *WebhookDeliveryService) Redeliver(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	original, err := s.Store.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.NowUTC()
+	d := &WebhookDelivery{
+		ID:            uuid.New(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		URL:           original.URL,
+		Payload:       original.Payload,
+		Status:        WebhookDeliveryStatusPending,
+		AttemptCount:  0,
+		NextAttemptAt: &now,
+	}
+	err = s.Store.CreateDelivery(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (s *WebhookDeliveryService) attempt(ctx context.Context, d *WebhookDelivery) *WebhookDeliveryAttempt {
+	a := &WebhookDeliveryAttempt{
+		ID:         uuid.New(),
+		DeliveryID: d.ID,
+	}
+
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		errString := err.Error()
+		a.Error = &errString
+		return a
+	}
+
+	startTime := s.Clock.NowMonotonic()
+	statusCode, err := s.EventWebHook.DeliverNonBlockingEventPayload(ctx, u, d.Payload)
+	a.Latency = s.Clock.NowMonotonic().Sub(startTime)
+
+	if statusCode != 0 {
+		a.StatusCode = &statusCode
+	}
+	if err != nil {
+		errString := err.Error()
+		a.Error = &errString
+	}
+	return a
+}
+
+func (s *WebhookDeliveryService) recordAttempt(ctx context.Context, d *WebhookDelivery, a *WebhookDeliveryAttempt) error {
+	now := s.Clock.NowUTC()
+	a.CreatedAt = now
+	err := s.Store.CreateAttempt(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	d.AttemptCount++
+	d.UpdatedAt = now
+	d.LastAttemptAt = &now
+	switch {
+	case a.Error == nil:
+		d.Status = WebhookDeliveryStatusSucceeded
+		d.NextAttemptAt = nil
+	case d.AttemptCount >= WebhookDeliveryMaxAttempts:
+		d.Status = WebhookDeliveryStatusFailed
+		d.NextAttemptAt = nil
+	default:
+		nextAttemptAt := now.Add(webhookDeliveryBackoff(d.AttemptCount))
+		d.NextAttemptAt = &nextAttemptAt
+	}
+
+	return s.Store.UpdateDelivery(ctx, d)
+}
+
+func webhookDeliveryBackoff(attemptCount int) time.Duration {
+	counter := backoff.Counter{
+		Interval:    WebhookDeliveryRetryInterval,
+		MaxInterval: WebhookDeliveryMaxRetryInterval,
+	}
+	for i := 0; i < attemptCount; i++ {
+		counter.Increment()
+	}
+	return counter.BackoffDuration()
+}
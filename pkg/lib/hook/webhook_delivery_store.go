@@ -0,0 +1,297 @@
+package hook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type WebhookDeliveryStore struct {
+	SQLBuilder  *appdb.SQLBuilderApp
+	SQLExecutor *appdb.SQLExecutor
+}
+
+func (s *WebhookDeliveryStore) CreateDelivery(ctx context.Context, d *WebhookDelivery) error {
+	q := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName("_auth_webhook_delivery")).
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"event_id",
+			"event_type",
+			"url",
+			"payload",
+			"status",
+			"attempt_count",
+			"next_attempt_at",
+			"last_attempt_at",
+		).
+		Values(
+			d.ID,
+			d.CreatedAt,
+			d.UpdatedAt,
+			d.EventID,
+			d.EventType,
+			d.URL,
+			[]byte(d.Payload),
+			string(d.Status),
+			d.AttemptCount,
+			d.NextAttemptAt,
+			d.LastAttemptAt,
+		)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *WebhookDeliveryStore) UpdateDelivery(ctx context.Context, d *WebhookDelivery) error {
+	q := s.SQLBuilder.
+		Update(s.SQLBuilder.TableName("_auth_webhook_delivery")).
+		Set("updated_at", d.UpdatedAt).
+		Set("status", string(d.Status)).
+		Set("attempt_count", d.AttemptCount).
+		Set("next_attempt_at", d.NextAttemptAt).
+		Set("last_attempt_at", d.LastAttemptAt).
+		Where("id = ?", d.ID)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// ClaimDelivery postpones the next attempt of a due pending delivery to leaseUntil.
+// It reports false if the delivery is not due, or has been claimed by another worker.
+func (s *WebhookDeliveryStore) ClaimDelivery(ctx context.Context, id string, now time.Time, leaseUntil time.Time) (bool, error) {
+	q := s.SQLBuilder.
+		Update(s.SQLBuilder.TableName("_auth_webhook_delivery")).
+		Set("next_attempt_at", leaseUntil).
+		Where("id = ?", id).
+		Where("status = ?", string(WebhookDeliveryStatusPending)).
+		Where("next_attempt_at <= ?", now)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (s *WebhookDeliveryStore) GetDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error) {
+	q := s.selectDeliveryQuery().Where("id = ?", id)
+
+	row, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.scanDelivery(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (s *WebhookDeliveryStore) ListDeliveries(ctx context.Context, options *WebhookDeliveryListOptions, pageArgs graphqlutil.PageArgs) ([]*WebhookDelivery, uint64, error) {
+	q := s.applyListOptions(s.selectDeliveryQuery(), options).
+		// Sort by created_at and id to ensure we have a stable order.
+		OrderBy("created_at DESC", "id DESC")
+
+	q, offset, err := db.ApplyPageArgs(q, pageArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		d, err := s.scanDelivery(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, offset, nil
+}
+
+func (s *WebhookDeliveryStore) CountDeliveries(ctx context.Context, options *WebhookDeliveryListOptions) (uint64, error) {
+	q := s.applyListOptions(
+		s.SQLBuilder.
+			Select("count(*)").
+			From(s.SQLBuilder.TableName("_auth_webhook_delivery")),
+		options,
+	)
+	scanner, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err = scanner.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *WebhookDeliveryStore) CreateAttempt(ctx context.Context, a *WebhookDeliveryAttempt) error {
+	q := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName("_auth_webhook_delivery_attempt")).
+		Columns(
+			"id",
+			"created_at",
+			"delivery_id",
+			"status_code",
+			"latency_ms",
+			"error",
+		).
+		Values(
+			a.ID,
+			a.CreatedAt,
+			a.DeliveryID,
+			a.StatusCode,
+			a.Latency.Milliseconds(),
+			a.Error,
+		)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *WebhookDeliveryStore) ListAttemptsByDeliveryID(ctx context.Context, deliveryID string) ([]*WebhookDeliveryAttempt, error) {
+	q := s.SQLBuilder.
+		Select(
+			"id",
+			"created_at",
+			"delivery_id",
+			"status_code",
+			"latency_ms",
+			"error",
+		).
+		From(s.SQLBuilder.TableName("_auth_webhook_delivery_attempt")).
+		Where("delivery_id = ?", deliveryID).
+		OrderBy("created_at ASC")
+
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*WebhookDeliveryAttempt
+	for rows.Next() {
+		a := &WebhookDeliveryAttempt{}
+		var latencyMS int64
+		err := rows.Scan(
+			&a.ID,
+			&a.CreatedAt,
+			&a.DeliveryID,
+			&a.StatusCode,
+			&latencyMS,
+			&a.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+		a.Latency = time.Duration(latencyMS) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+
+	return attempts, nil
+}
+
+func (s *WebhookDeliveryStore) applyListOptions(q db.SelectBuilder, options *WebhookDeliveryListOptions) db.SelectBuilder {
+	if options.Status != nil {
+		q = q.Where("status = ?", string(*options.Status))
+	}
+	if options.EventType != "" {
+		q = q.Where("event_type = ?", options.EventType)
+	}
+	return q
+}
+
+func (s *WebhookDeliveryStore) selectDeliveryQuery() db.SelectBuilder {
+	return s.SQLBuilder.
+		Select(
+			"id",
+			"created_at",
+			"updated_at",
+			"event_id",
+			"event_type",
+			"url",
+			"payload",
+			"status",
+			"attempt_count",
+			"next_attempt_at",
+			"last_attempt_at",
+		).
+		From(s.SQLBuilder.TableName("_auth_webhook_delivery"))
+}
+
+func (s *WebhookDeliveryStore) scanDelivery(scanner db.Scanner) (*WebhookDelivery, error) {
+	d := &WebhookDelivery{}
+	var payload []byte
+	var status string
+
+	err := scanner.Scan(
+		&d.ID,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.EventID,
+		&d.EventType,
+		&d.URL,
+		&payload,
+		&status,
+		&d.AttemptCount,
+		&d.NextAttemptAt,
+		&d.LastAttemptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Payload = payload
+	d.Status = WebhookDeliveryStatus(status)
+	return d, nil
+}
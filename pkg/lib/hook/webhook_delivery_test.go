@@ -0,0 +1,225 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type fakeWebhookDeliveryStore struct {
+	deliveries map[string]*WebhookDelivery
+	attempts   []*WebhookDeliveryAttempt
+}
+
+func (s *fakeWebhookDeliveryStore) CreateDelivery(ctx context.Context, d *WebhookDelivery) error {
+	s.deliveries[d.ID] = d
+	return nil
+}
+
+func (s *fakeWebhookDeliveryStore) UpdateDelivery(ctx context.Context, d *WebhookDelivery) error {
+	if _, ok := s.deliveries[d.ID]; !ok {
+		return ErrWebhookDeliveryNotFound
+	}
+	s.deliveries[d.ID] = d
+	return nil
+}
+
+func (s *fakeWebhookDeliveryStore) ClaimDelivery(ctx context.Context, id string, now time.Time, leaseUntil time.Time) (bool, error) {
+	d, ok := s.deliveries[id]
+	if !ok || d.Status != WebhookDeliveryStatusPending || d.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	d.NextAttemptAt = &leaseUntil
+	return true, nil
+}
+
+func (s *fakeWebhookDeliveryStore) GetDeliveryByID(ctx context.Context, id string) (*WebhookDelivery, error) {
+	d, ok := s.deliveries[id]
+	if !ok {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	return d, nil
+}
+
+func (s *fakeWebhookDeliveryStore) CreateAttempt(ctx context.Context, a *WebhookDeliveryAttempt) error {
+	s.attempts = append(s.attempts, a)
+	return nil
+}
+
+type fakeWebhookDeliveryEventWebHook struct {
+	statusCode int
+	err        error
+}
+
+func (h *fakeWebhookDeliveryEventWebHook) SupportURL(u *url.URL) bool {
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+func (h *fakeWebhookDeliveryEventWebHook) DeliverNonBlockingEventPayload(ctx context.Context, u *url.URL, payload json.RawMessage) (int, error) {
+	return h.statusCode, h.err
+}
+
+func TestWebhookDeliveryService(t *testing.T) {
+	Convey("WebhookDeliveryService", t, func() {
+		ctx := context.Background()
+		clk := clock.NewMockClockAt("2006-01-02T15:04:05Z")
+		cfg := &config.HookConfig{}
+		store := &fakeWebhookDeliveryStore{
+			deliveries: map[string]*WebhookDelivery{},
+		}
+		webhook := &fakeWebhookDeliveryEventWebHook{}
+
+		s := &WebhookDeliveryService{
+			Clock:        clk,
+			Config:       cfg,
+			Store:        store,
+			EventWebHook: webhook,
+		}
+
+		Convey("EnqueueNonBlockingEvent", func() {
+			cfg.NonBlockingHandlers = []config.NonBlockingHandlersConfig{
+				{
+					Events: []string{string(MockNonBlockingEventType1)},
+					URL:    "https://example.com/a",
+				},
+				{
+					Events: []string{"*"},
+					URL:    "https://example.com/b",
+				},
+				{
+					Events: []string{string(MockNonBlockingEventType1)},
+					URL:    "authgeardeno:///deno/a.ts",
+				},
+				{
+					Events: []string{string(MockNonBlockingEventType2)},
+					URL:    "https://example.com/c",
+				},
+			}
+
+			e := &event.Event{
+				ID:            "event-id",
+				Type:          MockNonBlockingEventType1,
+				Payload:       &MockNonBlockingEvent1{},
+				IsNonBlocking: true,
+			}
+
+			err := s.EnqueueNonBlockingEvent(ctx, e)
+			So(err, ShouldBeNil)
+			So(e.WebhookDeliveryEnqueued, ShouldBeTrue)
+
+			var urls []string
+			for _, d := range store.deliveries {
+				So(d.EventID, ShouldEqual, "event-id")
+				So(d.EventType, ShouldEqual, string(MockNonBlockingEventType1))
+				So(d.Status, ShouldEqual, WebhookDeliveryStatusPending)
+				So(*d.NextAttemptAt, ShouldEqual, clk.NowUTC())
+				urls = append(urls, d.URL)
+			}
+			So(urls, ShouldHaveLength, 2)
+			So(urls, ShouldContain, "https://example.com/a")
+			So(urls, ShouldContain, "https://example.com/b")
+		})
+
+		Convey("recordAttempt", func() {
+			now := clk.NowUTC()
+			d := &WebhookDelivery{
+				ID:            "delivery-id",
+				Status:        WebhookDeliveryStatusPending,
+				NextAttemptAt: &now,
+			}
+			store.deliveries[d.ID] = d
+
+			Convey("should mark the delivery as succeeded", func() {
+				statusCode := 200
+				err := s.recordAttempt(ctx, d, &WebhookDeliveryAttempt{
+					ID:         "attempt-id",
+					DeliveryID: d.ID,
+					StatusCode: &statusCode,
+				})
+				So(err, ShouldBeNil)
+				So(store.attempts, ShouldHaveLength, 1)
+				So(d.Status, ShouldEqual, WebhookDeliveryStatusSucceeded)
+				So(d.AttemptCount, ShouldEqual, 1)
+				So(d.NextAttemptAt, ShouldBeNil)
+				So(*d.LastAttemptAt, ShouldEqual, now)
+			})
+
+			Convey("should schedule a retry with backoff", func() {
+				statusCode := 503
+				errString := "invalid status code"
+				err := s.recordAttempt(ctx, d, &WebhookDeliveryAttempt{
+					ID:         "attempt-id",
+					DeliveryID: d.ID,
+					StatusCode: &statusCode,
+					Error:      &errString,
+				})
+				So(err, ShouldBeNil)
+				So(d.Status, ShouldEqual, WebhookDeliveryStatusPending)
+				So(d.AttemptCount, ShouldEqual, 1)
+				So(*d.NextAttemptAt, ShouldHappenOnOrBetween, now.Add(WebhookDeliveryRetryInterval), now.Add(WebhookDeliveryRetryInterval+time.Second))
+			})
+
+			Convey("should mark the delivery as failed after the last attempt", func() {
+				d.AttemptCount = WebhookDeliveryMaxAttempts - 1
+				errString := "webhook delivery timeout"
+				err := s.recordAttempt(ctx, d, &WebhookDeliveryAttempt{
+					ID:         "attempt-id",
+					DeliveryID: d.ID,
+					Error:      &errString,
+				})
+				So(err, ShouldBeNil)
+				So(d.Status, ShouldEqual, WebhookDeliveryStatusFailed)
+				So(d.AttemptCount, ShouldEqual, WebhookDeliveryMaxAttempts)
+				So(d.NextAttemptAt, ShouldBeNil)
+			})
+		})
+
+		Convey("attempt", func() {
+			d := &WebhookDelivery{
+				ID:  "delivery-id",
+				URL: "https://example.com/a",
+			}
+
+			webhook.statusCode = 500
+			webhook.err = HookInvalidResponse.New("invalid status code")
+
+			a := s.attempt(ctx, d)
+			So(a.DeliveryID, ShouldEqual, "delivery-id")
+			So(*a.StatusCode, ShouldEqual, 500)
+			So(*a.Error, ShouldEqual, "invalid status code")
+		})
+
+		Convey("Redeliver", func() {
+			store.deliveries["delivery-id"] = &WebhookDelivery{
+				ID:           "delivery-id",
+				EventID:      "event-id",
+				EventType:    string(MockNonBlockingEventType1),
+				URL:          "https://example.com/a",
+				Payload:      json.RawMessage(`{"id":"event-id"}`),
+				Status:       WebhookDeliveryStatusFailed,
+				AttemptCount: WebhookDeliveryMaxAttempts,
+			}
+
+			d, err := s.Redeliver(ctx, "delivery-id")
+			So(err, ShouldBeNil)
+			So(d.ID, ShouldNotEqual, "delivery-id")
+			So(d.EventID, ShouldEqual, "event-id")
+			So(d.URL, ShouldEqual, "https://example.com/a")
+			So(string(d.Payload), ShouldEqual, `{"id":"event-id"}`)
+			So(d.Status, ShouldEqual, WebhookDeliveryStatusPending)
+			So(d.AttemptCount, ShouldEqual, 0)
+			So(store.deliveries, ShouldHaveLength, 2)
+
+			_, err = s.Redeliver(ctx, "unknown")
+			So(err, ShouldBeError, ErrWebhookDeliveryNotFound)
+		})
+	})
+}
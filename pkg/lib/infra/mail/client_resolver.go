@@ -0,0 +1,48 @@
+package mail
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailgun"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/postmark"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/sendgrid"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/ses"
+)
+
+// ClientResolver resolves the email provider configured in authgear.secrets.yaml.
+// At most one provider can be configured, which is enforced by config validation.
+type ClientResolver struct {
+	SMTPServerCredentials *config.SMTPServerCredentials
+	SESCredentials        *config.SESCredentials
+	SendGridCredentials   *config.SendGridCredentials
+	MailgunCredentials    *config.MailgunCredentials
+	PostmarkCredentials   *config.PostmarkCredentials
+}
+
+func (r *ClientResolver) ResolveClient() (mailapi.Client, error) {
+	var clients []mailapi.Client
+	if r.SMTPServerCredentials != nil {
+		clients = append(clients, NewSMTPClient(r.SMTPServerCredentials))
+	}
+	if r.SESCredentials != nil {
+		clients = append(clients, ses.NewSESClient(r.SESCredentials))
+	}
+	if r.SendGridCredentials != nil {
+		clients = append(clients, sendgrid.NewSendGridClient(r.SendGridCredentials))
+	}
+	if r.MailgunCredentials != nil {
+		clients = append(clients, mailgun.NewMailgunClient(r.MailgunCredentials))
+	}
+	if r.PostmarkCredentials != nil {
+		clients = append(clients, postmark.NewPostmarkClient(r.PostmarkCredentials))
+	}
+
+	switch len(clients) {
+	case 0:
+		return nil, ErrNoAvailableSMTPConfiguration
+	case 1:
+		return clients[0], nil
+	default:
+		return nil, ErrAmbiguousClient
+	}
+}
@@ -0,0 +1,57 @@
+package mail
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/sendgrid"
+)
+
+func TestClientResolver(t *testing.T) {
+	Convey("ClientResolver", t, func() {
+		Convey("no provider", func() {
+			r := &ClientResolver{}
+			_, err := r.ResolveClient()
+			So(err, ShouldBeError, ErrNoAvailableSMTPConfiguration)
+		})
+
+		Convey("smtp", func() {
+			r := &ClientResolver{
+				SMTPServerCredentials: &config.SMTPServerCredentials{
+					Host: "smtp.example.com",
+					Port: 587,
+				},
+			}
+			client, err := r.ResolveClient()
+			So(err, ShouldBeNil)
+			So(client, ShouldHaveSameTypeAs, &SMTPClient{})
+		})
+
+		Convey("http api provider", func() {
+			r := &ClientResolver{
+				SendGridCredentials: &config.SendGridCredentials{
+					APIKey: "key",
+				},
+			}
+			client, err := r.ResolveClient()
+			So(err, ShouldBeNil)
+			So(client, ShouldHaveSameTypeAs, &sendgrid.SendGridClient{})
+		})
+
+		Convey("ambiguous", func() {
+			r := &ClientResolver{
+				SMTPServerCredentials: &config.SMTPServerCredentials{
+					Host: "smtp.example.com",
+					Port: 587,
+				},
+				PostmarkCredentials: &config.PostmarkCredentials{
+					ServerToken: "token",
+				},
+			}
+			_, err := r.ResolveClient()
+			So(err, ShouldBeError, ErrAmbiguousClient)
+		})
+	})
+}
@@ -5,6 +5,12 @@ import (
 )
 
 var DependencySet = wire.NewSet(
-	NewGomailDialer,
+	wire.Struct(new(ClientResolver), "*"),
+	wire.Struct(new(Sender), "*"),
+)
+
+// SMTPDependencySet is for the portal, which only sends email with SMTP.
+var SMTPDependencySet = wire.NewSet(
+	wire.Struct(new(ClientResolver), "SMTPServerCredentials"),
 	wire.Struct(new(Sender), "*"),
 )
@@ -0,0 +1,101 @@
+package mailapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/mail"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/util/errorutil"
+)
+
+type ProviderType string
+
+const (
+	ProviderTypeSMTP     ProviderType = "smtp"
+	ProviderTypeSES      ProviderType = "ses"
+	ProviderTypeSendGrid ProviderType = "sendgrid"
+	ProviderTypeMailgun  ProviderType = "mailgun"
+	ProviderTypePostmark ProviderType = "postmark"
+)
+
+var ErrKindAuthenticationFailed = apierrors.InternalError.WithReason("EmailGatewayAuthenticationFailed")
+var ErrKindDeliveryRejected = apierrors.InternalError.WithReason("EmailGatewayDeliveryRejected")
+var ErrKindRateLimited = apierrors.TooManyRequest.WithReason("EmailGatewayRateLimited")
+
+// ErrKindRecipientSuppressed means the provider refused to send to the recipient
+// because the recipient is on its suppression list, typically due to previous bounces or complaints.
+var ErrKindRecipientSuppressed = apierrors.BadRequest.WithReason("EmailGatewayRecipientSuppressed")
+
+type SendOptions struct {
+	Sender    string
+	ReplyTo   string
+	Subject   string
+	Recipient string
+	TextBody  string
+	HTMLBody  string
+}
+
+// Validate validates the options that every provider requires.
+func (o SendOptions) Validate() error {
+	if _, err := mail.ParseAddress(o.Sender); err != nil {
+		return errors.New("mail: sender address is invalid")
+	}
+	if o.Recipient == "" {
+		return errors.New("mail: recipient address is missing")
+	}
+	if o.TextBody == "" {
+		return errors.New("mail: text body is missing")
+	}
+	return nil
+}
+
+type Client interface {
+	Send(ctx context.Context, opts SendOptions) error
+}
+
+type SendError struct {
+	DumpedResponse []byte          `json:"dumped_response,omitempty"`
+	APIErrorKind   *apierrors.Kind `json:"api_error_kind,omitempty"`
+	ProviderType   ProviderType    `json:"provider_type,omitempty"`
+
+	ProviderErrorCode string `json:"provider_error_code,omitempty"`
+}
+
+var _ error = (*SendError)(nil)
+
+func (e *SendError) Error() string {
+	jsonText, _ := json.Marshal(e)
+	return string(jsonText)
+}
+
+func (e *SendError) As(target any) bool {
+	switch target.(type) {
+	case **apierrors.APIError:
+		apierr := e.asAPIError()
+		*target.(**apierrors.APIError) = apierr
+		return true
+	case *errorutil.Detailer:
+		apierr := e.asAPIError()
+		*target.(*errorutil.Detailer) = apierr
+		return true
+	}
+	return false
+}
+
+func (e *SendError) IsRecipientSuppressed() bool {
+	return e.APIErrorKind != nil && *e.APIErrorKind == ErrKindRecipientSuppressed
+}
+
+func (e *SendError) asAPIError() *apierrors.APIError {
+	details := apierrors.Details{
+		"ProviderErrorCode": e.ProviderErrorCode,
+		"ProviderType":      e.ProviderType,
+	}
+	kind := apierrors.UnexpectedError
+	if e.APIErrorKind != nil {
+		kind = *e.APIErrorKind
+	}
+	return kind.NewWithInfo("email gateway send error", details)
+}
@@ -0,0 +1,114 @@
+package mailgun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	utilhttputil "github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+type MailgunClient struct {
+	Client             *http.Client
+	MailgunCredentials *config.MailgunCredentials
+}
+
+func NewMailgunClient(c *config.MailgunCredentials) *MailgunClient {
+	if c == nil {
+		return nil
+	}
+
+	return &MailgunClient{
+		Client:             utilhttputil.NewExternalClient(10 * time.Second),
+		MailgunCredentials: c,
+	}
+}
+
+func (c *MailgunClient) baseURL() string {
+	// See https://documentation.mailgun.com/docs/mailgun/api-reference/#base-url
+	switch c.MailgunCredentials.Region {
+	case config.MailgunRegionEU:
+		return "https://api.eu.mailgun.net"
+	default:
+		return "https://api.mailgun.net"
+	}
+}
+
+func (c *MailgunClient) Send(ctx context.Context, opts mailapi.SendOptions) error {
+	// Written against
+	// https://documentation.mailgun.com/docs/mailgun/api-reference/openapi-final/tag/Messages/
+	u, err := url.Parse(c.baseURL())
+	if err != nil {
+		return err
+	}
+	u = u.JoinPath("v3", c.MailgunCredentials.Domain, "messages")
+
+	values := url.Values{}
+	values.Set("from", opts.Sender)
+	values.Set("to", opts.Recipient)
+	values.Set("subject", opts.Subject)
+	values.Set("text", opts.TextBody)
+	if opts.HTMLBody != "" {
+		values.Set("html", opts.HTMLBody)
+	}
+	if opts.ReplyTo != "" {
+		values.Set("h:Reply-To", opts.ReplyTo)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("api", c.MailgunCredentials.APIKey)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	dumpedResponse, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+
+	return makeError(resp.StatusCode, dumpedResponse)
+}
+
+func makeError(statusCode int, dumpedResponse []byte) error {
+	err := &mailapi.SendError{
+		DumpedResponse:    dumpedResponse,
+		ProviderType:      mailapi.ProviderTypeMailgun,
+		ProviderErrorCode: strconv.Itoa(statusCode),
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		fallthrough
+	case http.StatusForbidden:
+		err.APIErrorKind = &mailapi.ErrKindAuthenticationFailed
+	case http.StatusTooManyRequests:
+		err.APIErrorKind = &mailapi.ErrKindRateLimited
+	case http.StatusBadRequest:
+		fallthrough
+	case http.StatusNotFound: // Domain not found
+		fallthrough
+	case http.StatusRequestEntityTooLarge:
+		err.APIErrorKind = &mailapi.ErrKindDeliveryRejected
+	}
+
+	return err
+}
+
+var _ mailapi.Client = (*MailgunClient)(nil)
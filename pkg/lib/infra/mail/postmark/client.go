@@ -0,0 +1,141 @@
+package postmark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	utilhttputil "github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+type PostmarkClient struct {
+	Client              *http.Client
+	PostmarkCredentials *config.PostmarkCredentials
+}
+
+func NewPostmarkClient(c *config.PostmarkCredentials) *PostmarkClient {
+	if c == nil {
+		return nil
+	}
+
+	return &PostmarkClient{
+		Client:              utilhttputil.NewExternalClient(10 * time.Second),
+		PostmarkCredentials: c,
+	}
+}
+
+func (c *PostmarkClient) send0(ctx context.Context, opts mailapi.SendOptions) (int, []byte, []byte, error) {
+	// Written against
+	// https://postmarkapp.com/developer/api/email-api#send-a-single-email
+	body := SendRequest{
+		From:          opts.Sender,
+		To:            opts.Recipient,
+		ReplyTo:       opts.ReplyTo,
+		Subject:       opts.Subject,
+		TextBody:      opts.TextBody,
+		HTMLBody:      opts.HTMLBody,
+		MessageStream: c.PostmarkCredentials.MessageStream,
+	}
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.postmarkapp.com/email", bytes.NewReader(requestBody))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Postmark-Server-Token", c.PostmarkCredentials.ServerToken)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	dumpedResponse, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, errors.Join(err, &mailapi.SendError{
+			ProviderType:   mailapi.ProviderTypePostmark,
+			DumpedResponse: dumpedResponse,
+		})
+	}
+
+	return resp.StatusCode, bodyBytes, dumpedResponse, nil
+}
+
+func (c *PostmarkClient) Send(ctx context.Context, opts mailapi.SendOptions) error {
+	statusCode, bodyBytes, dumpedResponse, err := c.send0(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if statusCode == http.StatusTooManyRequests {
+		return &mailapi.SendError{
+			DumpedResponse:    dumpedResponse,
+			ProviderType:      mailapi.ProviderTypePostmark,
+			ProviderErrorCode: strconv.Itoa(statusCode),
+			APIErrorKind:      &mailapi.ErrKindRateLimited,
+		}
+	}
+
+	sendResponse, err := ParseSendResponse(bodyBytes)
+	if err != nil {
+		return errors.Join(err, &mailapi.SendError{
+			ProviderType:   mailapi.ProviderTypePostmark,
+			DumpedResponse: dumpedResponse,
+		})
+	}
+
+	if statusCode == http.StatusOK && sendResponse.ErrorCode == 0 {
+		return nil
+	}
+
+	return makeError(sendResponse.ErrorCode, dumpedResponse)
+}
+
+func makeError(errorCode int, dumpedResponse []byte) error {
+	err := &mailapi.SendError{
+		DumpedResponse:    dumpedResponse,
+		ProviderType:      mailapi.ProviderTypePostmark,
+		ProviderErrorCode: strconv.Itoa(errorCode),
+	}
+
+	// See https://postmarkapp.com/developer/api/overview#error-codes
+	switch errorCode {
+	case 10: // Bad or missing API token
+		err.APIErrorKind = &mailapi.ErrKindAuthenticationFailed
+	case 406: // Inactive recipient
+		err.APIErrorKind = &mailapi.ErrKindRecipientSuppressed
+	case 300: // Invalid email request
+		fallthrough
+	case 400: // Sender signature not found
+		fallthrough
+	case 401: // Sender signature not confirmed
+		fallthrough
+	case 405: // Not allowed to send
+		fallthrough
+	case 412: // Account is pending approval
+		err.APIErrorKind = &mailapi.ErrKindDeliveryRejected
+	}
+
+	return err
+}
+
+var _ mailapi.Client = (*PostmarkClient)(nil)
@@ -0,0 +1,32 @@
+package postmark
+
+import (
+	"encoding/json"
+)
+
+// See https://postmarkapp.com/developer/api/email-api#send-a-single-email
+type SendRequest struct {
+	From          string `json:"From"`
+	To            string `json:"To"`
+	ReplyTo       string `json:"ReplyTo,omitempty"`
+	Subject       string `json:"Subject"`
+	TextBody      string `json:"TextBody"`
+	HTMLBody      string `json:"HtmlBody,omitempty"`
+	MessageStream string `json:"MessageStream,omitempty"`
+}
+
+type SendResponse struct {
+	// https://postmarkapp.com/developer/api/overview#error-codes
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message"`
+	MessageID string `json:"MessageID,omitempty"`
+}
+
+func ParseSendResponse(jsonData []byte) (*SendResponse, error) {
+	var response SendResponse
+	err := json.Unmarshal(jsonData, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
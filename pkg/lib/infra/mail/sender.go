@@ -1,6 +1,7 @@
 package mail
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	netmail "net/mail"
@@ -8,78 +9,25 @@ import (
 	"gopkg.in/gomail.v2"
 
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
-	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
 )
 
+// The reason is kept for compatibility, though the provider may not be SMTP.
 var ErrNoAvailableSMTPConfiguration = apierrors.InternalError.WithReason("NoAvailableSMTPConfiguration").New("no available SMTP configuration")
+var ErrAmbiguousClient = errors.New("ambiguous email provider")
 
-type SendOptions struct {
-	Sender    string
-	ReplyTo   string
-	Subject   string
-	Recipient string
-	TextBody  string
-	HTMLBody  string
-}
+type SendOptions = mailapi.SendOptions
 
 type Sender struct {
-	GomailDialer *gomail.Dialer
-}
-
-func NewGomailDialer(smtp *config.SMTPServerCredentials) *gomail.Dialer {
-	if smtp != nil {
-		dialer := gomail.NewDialer(smtp.Host, smtp.Port, smtp.Username, smtp.Password)
-		switch smtp.Mode {
-		case config.SMTPModeNormal:
-			// gomail will infer according to port
-		case config.SMTPModeSSL:
-			dialer.SSL = true
-		}
-		return dialer
-	}
-	return nil
+	ClientResolver *ClientResolver
 }
 
-type updateGomailMessageFunc func(opts *SendOptions, msg *gomail.Message) error
-
-func (s *Sender) PrepareMessage(opts SendOptions) (message *gomail.Message, err error) {
-	if s.GomailDialer == nil {
-		err = ErrNoAvailableSMTPConfiguration
-		return
-	}
-
-	message = gomail.NewMessage()
-
-	funcs := []updateGomailMessageFunc{
-		s.applyFrom,
-		applyTo,
-		s.applyReplyTo,
-		s.applySubject,
-		applyTextBody,
-		applyHTMLBody,
-	}
-
-	for _, f := range funcs {
-		if err = f(&opts, message); err != nil {
-			return
-		}
-	}
-
-	return
+func (s *Sender) ResolveClient() (mailapi.Client, error) {
+	return s.ClientResolver.ResolveClient()
 }
 
-func (s *Sender) Send(message *gomail.Message) (err error) {
-	if s.GomailDialer == nil {
-		err = ErrNoAvailableSMTPConfiguration
-		return
-	}
-
-	err = s.GomailDialer.DialAndSend(message)
-	if err != nil {
-		return err
-	}
-
-	return nil
+func (s *Sender) Send(ctx context.Context, client mailapi.Client, opts SendOptions) error {
+	return client.Send(ctx, opts)
 }
 
 // SetFromHeader sets the RFC 5322 From header so that only the display
@@ -112,46 +60,3 @@ func SetFromHeader(message *gomail.Message, sender string) error {
 	message.SetAddressHeader("From", addr.Address, addr.Name)
 	return nil
 }
-
-func (s *Sender) applyFrom(opts *SendOptions, message *gomail.Message) error {
-	return SetFromHeader(message, opts.Sender)
-}
-
-func applyTo(opts *SendOptions, message *gomail.Message) error {
-	if opts.Recipient == "" {
-		return errors.New("mail: recipient address is missing")
-	}
-
-	message.SetHeader("To", opts.Recipient)
-	return nil
-}
-
-func (s *Sender) applyReplyTo(opts *SendOptions, message *gomail.Message) error {
-	if opts.ReplyTo != "" {
-		message.SetHeader("Reply-To", opts.ReplyTo)
-	}
-	return nil
-}
-
-func (s *Sender) applySubject(opts *SendOptions, message *gomail.Message) error {
-	message.SetHeader("Subject", opts.Subject)
-	return nil
-}
-
-func applyTextBody(opts *SendOptions, message *gomail.Message) error {
-	if opts.TextBody == "" {
-		return errors.New("mail: text body is missing")
-	}
-
-	message.SetBody("text/plain", opts.TextBody)
-	return nil
-}
-
-func applyHTMLBody(opts *SendOptions, message *gomail.Message) error {
-	if opts.HTMLBody == "" {
-		return nil
-	}
-
-	message.AddAlternative("text/html", opts.HTMLBody)
-	return nil
-}
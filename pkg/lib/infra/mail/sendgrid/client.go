@@ -0,0 +1,147 @@
+package sendgrid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httputil"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	utilhttputil "github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+type SendGridClient struct {
+	Client              *http.Client
+	SendGridCredentials *config.SendGridCredentials
+}
+
+func NewSendGridClient(c *config.SendGridCredentials) *SendGridClient {
+	if c == nil {
+		return nil
+	}
+
+	return &SendGridClient{
+		Client:              utilhttputil.NewExternalClient(10 * time.Second),
+		SendGridCredentials: c,
+	}
+}
+
+func (c *SendGridClient) Send(ctx context.Context, opts mailapi.SendOptions) error {
+	// Written against
+	// https://www.twilio.com/docs/sendgrid/api-reference/mail-send/mail-send
+	from, err := mail.ParseAddress(opts.Sender)
+	if err != nil {
+		return err
+	}
+
+	body := SendRequest{
+		Personalizations: []Personalization{
+			{To: []Address{{Email: opts.Recipient}}},
+		},
+		From: Address{
+			Email: from.Address,
+			Name:  from.Name,
+		},
+		Subject: opts.Subject,
+		Content: []Content{
+			{Type: "text/plain", Value: opts.TextBody},
+		},
+	}
+	if opts.ReplyTo != "" {
+		body.ReplyTo = &Address{Email: opts.ReplyTo}
+	}
+	if opts.HTMLBody != "" {
+		body.Content = append(body.Content, Content{Type: "text/html", Value: opts.HTMLBody})
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.sendgrid.com/v3/mail/send", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.SendGridCredentials.APIKey)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	dumpedResponse, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+
+	// DumpResponse restores the body, so it can be read again.
+	var errorResponse ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errorResponse)
+
+	return makeError(resp.StatusCode, errorResponse, dumpedResponse)
+}
+
+// suppressedRecipientMessages are the phrases in the error messages,
+// that mean the recipient is on a suppression list, such as bounces, blocks, spam reports or unsubscribes.
+var suppressedRecipientMessages = []string{
+	"suppress",
+	"bounce",
+	"block",
+	"spam report",
+	"unsubscribe",
+}
+
+func isRecipientSuppressed(errorResponse ErrorResponse) bool {
+	for _, e := range errorResponse.Errors {
+		message := strings.ToLower(e.Message)
+		for _, phrase := range suppressedRecipientMessages {
+			if strings.Contains(message, phrase) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func makeError(statusCode int, errorResponse ErrorResponse, dumpedResponse []byte) error {
+	err := &mailapi.SendError{
+		DumpedResponse:    dumpedResponse,
+		ProviderType:      mailapi.ProviderTypeSendGrid,
+		ProviderErrorCode: strconv.Itoa(statusCode),
+	}
+
+	// See https://www.twilio.com/docs/sendgrid/api-reference/how-to-use-the-sendgrid-v3-api/responses
+	switch statusCode {
+	case http.StatusUnauthorized:
+		fallthrough
+	case http.StatusForbidden:
+		err.APIErrorKind = &mailapi.ErrKindAuthenticationFailed
+	case http.StatusTooManyRequests:
+		err.APIErrorKind = &mailapi.ErrKindRateLimited
+	case http.StatusBadRequest:
+		if isRecipientSuppressed(errorResponse) {
+			err.APIErrorKind = &mailapi.ErrKindRecipientSuppressed
+		} else {
+			err.APIErrorKind = &mailapi.ErrKindDeliveryRejected
+		}
+	case http.StatusRequestEntityTooLarge:
+		err.APIErrorKind = &mailapi.ErrKindDeliveryRejected
+	}
+
+	return err
+}
+
+var _ mailapi.Client = (*SendGridClient)(nil)
@@ -0,0 +1,105 @@
+package sendgrid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+)
+
+type mockTransport struct {
+	ResponseStatusCode int
+	ResponseBody       string
+}
+
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: m.ResponseStatusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(m.ResponseBody)),
+		Request:    req,
+	}, nil
+}
+
+func TestSendGridClient(t *testing.T) {
+	Convey("SendGridClient", t, func() {
+		ctx := context.Background()
+		opts := mailapi.SendOptions{
+			Sender:    "noreply@example.com",
+			Recipient: "user@example.com",
+			Subject:   "Subject",
+			TextBody:  "Text",
+		}
+
+		send := func(transport *mockTransport) error {
+			c := &SendGridClient{
+				Client: &http.Client{Transport: transport},
+				SendGridCredentials: &config.SendGridCredentials{
+					APIKey: "api-key",
+				},
+			}
+			return c.Send(ctx, opts)
+		}
+
+		asSendError := func(err error) *mailapi.SendError {
+			var sendError *mailapi.SendError
+			So(errors.As(err, &sendError), ShouldBeTrue)
+			return sendError
+		}
+
+		Convey("should succeed", func() {
+			err := send(&mockTransport{ResponseStatusCode: 202})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should return ErrKindRecipientSuppressed if the recipient has bounced", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 400,
+				ResponseBody:       `{"errors":[{"message":"The email address is on the bounce list.","field":"personalizations.0.to.0.email"}]}`,
+			})
+			sendError := asSendError(err)
+			So(sendError.IsRecipientSuppressed(), ShouldBeTrue)
+			So(sendError.ProviderErrorCode, ShouldEqual, "400")
+		})
+
+		Convey("should return ErrKindRecipientSuppressed if the recipient is blocked", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 400,
+				ResponseBody:       `{"errors":[{"message":"The email address is on the block list."}]}`,
+			})
+			sendError := asSendError(err)
+			So(sendError.IsRecipientSuppressed(), ShouldBeTrue)
+		})
+
+		Convey("should return ErrKindDeliveryRejected for other bad requests", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 400,
+				ResponseBody:       `{"errors":[{"message":"Does not contain a valid address.","field":"from.email"}]}`,
+			})
+			sendError := asSendError(err)
+			So(*sendError.APIErrorKind, ShouldResemble, mailapi.ErrKindDeliveryRejected)
+		})
+
+		Convey("should return ErrKindAuthenticationFailed", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 401,
+				ResponseBody:       `{"errors":[{"message":"The provided authorization grant is invalid, expired, or revoked"}]}`,
+			})
+			sendError := asSendError(err)
+			So(*sendError.APIErrorKind, ShouldResemble, mailapi.ErrKindAuthenticationFailed)
+		})
+
+		Convey("should return ErrKindRateLimited", func() {
+			err := send(&mockTransport{ResponseStatusCode: 429})
+			sendError := asSendError(err)
+			So(*sendError.APIErrorKind, ShouldResemble, mailapi.ErrKindRateLimited)
+		})
+	})
+}
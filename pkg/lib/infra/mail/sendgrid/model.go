@@ -0,0 +1,35 @@
+package sendgrid
+
+// See https://www.twilio.com/docs/sendgrid/api-reference/mail-send/mail-send
+type SendRequest struct {
+	Personalizations []Personalization `json:"personalizations"`
+	From             Address           `json:"from"`
+	ReplyTo          *Address          `json:"reply_to,omitempty"`
+	Subject          string            `json:"subject"`
+	Content          []Content         `json:"content"`
+}
+
+type Personalization struct {
+	To []Address `json:"to"`
+}
+
+type Address struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type Content struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// ErrorResponse is the body of an error response.
+// See https://www.twilio.com/docs/sendgrid/api-reference/how-to-use-the-sendgrid-v3-api/responses#failure
+type ErrorResponse struct {
+	Errors []Error `json:"errors"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+}
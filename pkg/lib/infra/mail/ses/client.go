@@ -0,0 +1,175 @@
+package ses
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	utilhttputil "github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+type SESClient struct {
+	Client         *http.Client
+	SESCredentials *config.SESCredentials
+}
+
+func NewSESClient(c *config.SESCredentials) *SESClient {
+	if c == nil {
+		return nil
+	}
+
+	return &SESClient{
+		Client:         utilhttputil.NewExternalClient(10 * time.Second),
+		SESCredentials: c,
+	}
+}
+
+func (c *SESClient) Send(ctx context.Context, opts mailapi.SendOptions) error {
+	// Written against
+	// https://docs.aws.amazon.com/ses/latest/APIReference-V2/API_SendEmail.html
+	body := SendEmailRequest{
+		FromEmailAddress: opts.Sender,
+		Destination: Destination{
+			ToAddresses: []string{opts.Recipient},
+		},
+		Content: Content{
+			Simple: SimpleContent{
+				Subject: ContentData{Data: opts.Subject, Charset: "UTF-8"},
+				Body: Body{
+					Text: &ContentData{Data: opts.TextBody, Charset: "UTF-8"},
+				},
+			},
+		},
+		ConfigurationSetName: c.SESCredentials.ConfigurationSetName,
+	}
+	if opts.ReplyTo != "" {
+		body.ReplyToAddresses = []string{opts.ReplyTo}
+	}
+	if opts.HTMLBody != "" {
+		body.Content.Simple.Body.HTML = &ContentData{Data: opts.HTMLBody, Charset: "UTF-8"}
+	}
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("https://email.%s.amazonaws.com/v2/email/outbound-emails", c.SESCredentials.Region)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	payloadHash := sha256.Sum256(requestBody)
+	err = v4.NewSigner().SignHTTP(
+		ctx,
+		aws.Credentials{
+			AccessKeyID:     c.SESCredentials.AccessKeyID,
+			SecretAccessKey: c.SESCredentials.SecretAccessKey,
+		},
+		req,
+		hex.EncodeToString(payloadHash[:]),
+		"ses",
+		c.SESCredentials.Region,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	dumpedResponse, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+
+	// DumpResponse restores the body, so it can be read again.
+	var errorResponse ErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&errorResponse)
+
+	return makeError(resp.StatusCode, errorType(resp.Header), errorResponse.Message, dumpedResponse)
+}
+
+// errorType extracts the error type from a header value like
+// "MessageRejected:http://internal.amazon.com/coral/com.amazonaws.sesv2/".
+func errorType(header http.Header) string {
+	typ, _, _ := strings.Cut(header.Get("X-Amzn-ErrorType"), ":")
+	return typ
+}
+
+// suppressedRecipientMessages are the phrases in the message of MessageRejected,
+// that mean the recipient is suppressed, has bounced, or has complained before.
+var suppressedRecipientMessages = []string{
+	"suppression list",
+	"suppressed",
+	"blacklisted",
+	"bounce",
+	"complaint",
+}
+
+func isRecipientSuppressed(message string) bool {
+	message = strings.ToLower(message)
+	for _, phrase := range suppressedRecipientMessages {
+		if strings.Contains(message, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+func makeError(statusCode int, errorType string, message string, dumpedResponse []byte) error {
+	err := &mailapi.SendError{
+		DumpedResponse:    dumpedResponse,
+		ProviderType:      mailapi.ProviderTypeSES,
+		ProviderErrorCode: errorType,
+	}
+	if err.ProviderErrorCode == "" {
+		err.ProviderErrorCode = fmt.Sprintf("%d", statusCode)
+	}
+
+	// See https://docs.aws.amazon.com/ses/latest/APIReference-V2/API_SendEmail.html#API_SendEmail_Errors
+	switch {
+	case statusCode == http.StatusForbidden:
+		err.APIErrorKind = &mailapi.ErrKindAuthenticationFailed
+	case statusCode == http.StatusTooManyRequests:
+		err.APIErrorKind = &mailapi.ErrKindRateLimited
+	case errorType == "LimitExceededException":
+		err.APIErrorKind = &mailapi.ErrKindRateLimited
+	case errorType == "MessageRejected" && isRecipientSuppressed(message):
+		err.APIErrorKind = &mailapi.ErrKindRecipientSuppressed
+	case errorType == "MessageRejected":
+		fallthrough
+	case errorType == "MailFromDomainNotVerifiedException":
+		fallthrough
+	case errorType == "AccountSuspendedException":
+		fallthrough
+	case errorType == "SendingPausedException":
+		err.APIErrorKind = &mailapi.ErrKindDeliveryRejected
+	}
+
+	return err
+}
+
+var _ mailapi.Client = (*SESClient)(nil)
@@ -0,0 +1,113 @@
+package ses
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+)
+
+type mockTransport struct {
+	ResponseStatusCode int
+	ResponseHeader     http.Header
+	ResponseBody       string
+}
+
+func (m *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header := m.ResponseHeader
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: m.ResponseStatusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(m.ResponseBody)),
+		Request:    req,
+	}, nil
+}
+
+func TestSESClient(t *testing.T) {
+	Convey("SESClient", t, func() {
+		ctx := context.Background()
+		opts := mailapi.SendOptions{
+			Sender:    "noreply@example.com",
+			Recipient: "user@example.com",
+			Subject:   "Subject",
+			TextBody:  "Text",
+		}
+
+		send := func(transport *mockTransport) error {
+			c := &SESClient{
+				Client: &http.Client{Transport: transport},
+				SESCredentials: &config.SESCredentials{
+					Region:          "us-east-1",
+					AccessKeyID:     "access-key-id",
+					SecretAccessKey: "secret-access-key",
+				},
+			}
+			return c.Send(ctx, opts)
+		}
+
+		asSendError := func(err error) *mailapi.SendError {
+			var sendError *mailapi.SendError
+			So(errors.As(err, &sendError), ShouldBeTrue)
+			return sendError
+		}
+
+		Convey("should succeed", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 200,
+				ResponseBody:       `{"MessageId":"message-id"}`,
+			})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should return ErrKindRecipientSuppressed if the recipient is suppressed", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 400,
+				ResponseHeader:     http.Header{"X-Amzn-Errortype": []string{"MessageRejected:http://internal.amazon.com/coral/com.amazonaws.sesv2/"}},
+				ResponseBody:       `{"message":"Email address is on the suppression list for your account."}`,
+			})
+			sendError := asSendError(err)
+			So(sendError.IsRecipientSuppressed(), ShouldBeTrue)
+			So(sendError.ProviderErrorCode, ShouldEqual, "MessageRejected")
+		})
+
+		Convey("should return ErrKindDeliveryRejected for other rejected messages", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 400,
+				ResponseHeader:     http.Header{"X-Amzn-Errortype": []string{"MessageRejected"}},
+				ResponseBody:       `{"message":"Email address is not verified."}`,
+			})
+			sendError := asSendError(err)
+			So(*sendError.APIErrorKind, ShouldResemble, mailapi.ErrKindDeliveryRejected)
+		})
+
+		Convey("should return ErrKindAuthenticationFailed", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 403,
+				ResponseBody:       `{"message":"The security token included in the request is invalid."}`,
+			})
+			sendError := asSendError(err)
+			So(*sendError.APIErrorKind, ShouldResemble, mailapi.ErrKindAuthenticationFailed)
+			So(sendError.ProviderErrorCode, ShouldEqual, "403")
+		})
+
+		Convey("should return ErrKindRateLimited", func() {
+			err := send(&mockTransport{
+				ResponseStatusCode: 400,
+				ResponseHeader:     http.Header{"X-Amzn-Errortype": []string{"LimitExceededException"}},
+				ResponseBody:       `{"message":"Maximum sending rate exceeded."}`,
+			})
+			sendError := asSendError(err)
+			So(*sendError.APIErrorKind, ShouldResemble, mailapi.ErrKindRateLimited)
+		})
+	})
+}
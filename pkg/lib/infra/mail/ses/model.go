@@ -0,0 +1,38 @@
+package ses
+
+// See https://docs.aws.amazon.com/ses/latest/APIReference-V2/API_SendEmail.html
+type SendEmailRequest struct {
+	FromEmailAddress     string      `json:"FromEmailAddress"`
+	Destination          Destination `json:"Destination"`
+	ReplyToAddresses     []string    `json:"ReplyToAddresses,omitempty"`
+	Content              Content     `json:"Content"`
+	ConfigurationSetName string      `json:"ConfigurationSetName,omitempty"`
+}
+
+type Destination struct {
+	ToAddresses []string `json:"ToAddresses"`
+}
+
+type Content struct {
+	Simple SimpleContent `json:"Simple"`
+}
+
+type SimpleContent struct {
+	Subject ContentData `json:"Subject"`
+	Body    Body        `json:"Body"`
+}
+
+type Body struct {
+	Text *ContentData `json:"Text,omitempty"`
+	HTML *ContentData `json:"Html,omitempty"`
+}
+
+type ContentData struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+// ErrorResponse is the body of an error response.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
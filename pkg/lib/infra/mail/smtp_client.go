@@ -0,0 +1,152 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"strconv"
+
+	"gopkg.in/gomail.v2"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+)
+
+type SMTPClient struct {
+	GomailDialer *gomail.Dialer
+}
+
+func NewGomailDialer(smtp *config.SMTPServerCredentials) *gomail.Dialer {
+	if smtp != nil {
+		dialer := gomail.NewDialer(smtp.Host, smtp.Port, smtp.Username, smtp.Password)
+		switch smtp.Mode {
+		case config.SMTPModeNormal:
+			// gomail will infer according to port
+		case config.SMTPModeSSL:
+			dialer.SSL = true
+		}
+		return dialer
+	}
+	return nil
+}
+
+func NewSMTPClient(smtp *config.SMTPServerCredentials) *SMTPClient {
+	if smtp == nil {
+		return nil
+	}
+
+	return &SMTPClient{
+		GomailDialer: NewGomailDialer(smtp),
+	}
+}
+
+type updateGomailMessageFunc func(opts *SendOptions, msg *gomail.Message) error
+
+func (c *SMTPClient) PrepareMessage(opts SendOptions) (message *gomail.Message, err error) {
+	message = gomail.NewMessage()
+
+	funcs := []updateGomailMessageFunc{
+		applyFrom,
+		applyTo,
+		applyReplyTo,
+		applySubject,
+		applyTextBody,
+		applyHTMLBody,
+	}
+
+	for _, f := range funcs {
+		if err = f(&opts, message); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (c *SMTPClient) Send(ctx context.Context, opts SendOptions) error {
+	message, err := c.PrepareMessage(opts)
+	if err != nil {
+		return err
+	}
+
+	err = c.GomailDialer.DialAndSend(message)
+	if err != nil {
+		var textprotoErr *textproto.Error
+		if errors.As(err, &textprotoErr) {
+			return errors.Join(err, makeSMTPError(textprotoErr.Code))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func makeSMTPError(code int) error {
+	err := &mailapi.SendError{
+		ProviderType:      mailapi.ProviderTypeSMTP,
+		ProviderErrorCode: strconv.Itoa(code),
+	}
+
+	// See https://datatracker.ietf.org/doc/html/rfc5321#section-4.2.3
+	switch code {
+	case 530: // Authentication required
+		fallthrough
+	case 535: // Authentication credentials invalid
+		err.APIErrorKind = &mailapi.ErrKindAuthenticationFailed
+	case 550: // Mailbox unavailable
+		fallthrough
+	case 551: // User not local
+		fallthrough
+	case 553: // Mailbox name not allowed
+		fallthrough
+	case 554: // Transaction failed
+		err.APIErrorKind = &mailapi.ErrKindDeliveryRejected
+	}
+
+	return err
+}
+
+func applyFrom(opts *SendOptions, message *gomail.Message) error {
+	return SetFromHeader(message, opts.Sender)
+}
+
+func applyTo(opts *SendOptions, message *gomail.Message) error {
+	if opts.Recipient == "" {
+		return errors.New("mail: recipient address is missing")
+	}
+
+	message.SetHeader("To", opts.Recipient)
+	return nil
+}
+
+func applyReplyTo(opts *SendOptions, message *gomail.Message) error {
+	if opts.ReplyTo != "" {
+		message.SetHeader("Reply-To", opts.ReplyTo)
+	}
+	return nil
+}
+
+func applySubject(opts *SendOptions, message *gomail.Message) error {
+	message.SetHeader("Subject", opts.Subject)
+	return nil
+}
+
+func applyTextBody(opts *SendOptions, message *gomail.Message) error {
+	if opts.TextBody == "" {
+		return errors.New("mail: text body is missing")
+	}
+
+	message.SetBody("text/plain", opts.TextBody)
+	return nil
+}
+
+func applyHTMLBody(opts *SendOptions, message *gomail.Message) error {
+	if opts.HTMLBody == "" {
+		return nil
+	}
+
+	message.AddAlternative("text/html", opts.HTMLBody)
+	return nil
+}
+
+var _ mailapi.Client = (*SMTPClient)(nil)
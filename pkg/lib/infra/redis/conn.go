@@ -207,6 +207,33 @@ func (c *otelRedisConn) BRPop(ctx context.Context, timeout time.Duration, keys .
 	return cmd
 }
 
+func (c *otelRedisConn) BRPopLPush(ctx context.Context, source string, destination string, timeout time.Duration) *goredis.StringCmd {
+	var cmd *goredis.StringCmd
+	c.withSpan(ctx, "BRPOPLPUSH", func(ctx context.Context) error {
+		cmd = c.conn.BRPopLPush(ctx, source, destination, timeout)
+		return cmd.Err()
+	})
+	return cmd
+}
+
+func (c *otelRedisConn) LRem(ctx context.Context, key string, count int64, value any) *goredis.IntCmd {
+	var cmd *goredis.IntCmd
+	c.withSpan(ctx, "LREM", func(ctx context.Context) error {
+		cmd = c.conn.LRem(ctx, key, count, value)
+		return cmd.Err()
+	})
+	return cmd
+}
+
+func (c *otelRedisConn) ZAdd(ctx context.Context, key string, members ...goredis.Z) *goredis.IntCmd {
+	var cmd *goredis.IntCmd
+	c.withSpan(ctx, "ZADD", func(ctx context.Context) error {
+		cmd = c.conn.ZAdd(ctx, key, members...)
+		return cmd.Err()
+	})
+	return cmd
+}
+
 func (c *otelRedisConn) PFCount(ctx context.Context, keys ...string) *goredis.IntCmd {
 	var cmd *goredis.IntCmd
 	c.withSpan(ctx, "PFCOUNT", func(ctx context.Context) error {
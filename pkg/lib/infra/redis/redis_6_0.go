@@ -35,6 +35,10 @@ type Redis_6_0_Cmdable interface {
 
 	LPush(ctx context.Context, key string, values ...any) *goredis.IntCmd
 	BRPop(ctx context.Context, timeout time.Duration, keys ...string) *goredis.StringSliceCmd
+	BRPopLPush(ctx context.Context, source string, destination string, timeout time.Duration) *goredis.StringCmd
+	LRem(ctx context.Context, key string, count int64, value any) *goredis.IntCmd
+
+	ZAdd(ctx context.Context, key string, members ...goredis.Z) *goredis.IntCmd
 
 	// HyperLogLog.
 	PFCount(ctx context.Context, keys ...string) *goredis.IntCmd
@@ -0,0 +1,52 @@
+package redisqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+)
+
+// promoteDelayedBatchSize bounds the work of a single promotion,
+// so that a large backlog of due items does not block redis.
+const promoteDelayedBatchSize = 100
+
+var promoteDelayedLuaScript = goredis.NewScript(`
+local delayed_queue_key = KEYS[1]
+local queue_key = KEYS[2]
+local now = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+local items = redis.call("ZRANGEBYSCORE", delayed_queue_key, "-inf", now, "LIMIT", 0, limit)
+for _, item in ipairs(items) do
+	redis.call("ZREM", delayed_queue_key, item)
+	redis.call("LPUSH", queue_key, item)
+end
+
+local next_item = redis.call("ZRANGE", delayed_queue_key, 0, 0, "WITHSCORES")
+if #next_item == 0 then
+	return false
+end
+return tonumber(next_item[2])
+`)
+
+// PromoteDelayedTasks moves the delayed queue items that are due to the queue.
+// It returns the due time of the earliest item that remains delayed, if any.
+func PromoteDelayedTasks(ctx context.Context, conn redis.Redis_6_0_Cmdable, queueName QueueName, now time.Time) (*time.Time, error) {
+	nextMilli, err := promoteDelayedLuaScript.Run(ctx, conn,
+		[]string{RedisKeyForDelayedQueue(queueName), RedisKeyForQueue(queueName)},
+		now.UnixMilli(), promoteDelayedBatchSize,
+	).Int64()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	next := time.UnixMilli(nextMilli).UTC()
+	return &next, nil
+}
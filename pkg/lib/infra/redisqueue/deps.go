@@ -11,6 +11,9 @@ var ProducerDependencySet = wire.NewSet(
 	NewUserImportProducer,
 	NewUserExportProducer,
 	NewUserReindexProducer,
+	NewBackchannelLogoutProducer,
+	NewCIBANotificationProducer,
+	NewMessageDeliveryProducer,
 )
 
 type UserImportProducer struct {
@@ -54,3 +57,45 @@ func NewUserReindexProducer(redis *appredis.Handle, clock clock.Clock) *UserRein
 		},
 	}
 }
+
+type BackchannelLogoutProducer struct {
+	*Producer
+}
+
+func NewBackchannelLogoutProducer(redis *appredis.Handle, clock clock.Clock) *BackchannelLogoutProducer {
+	return &BackchannelLogoutProducer{
+		&Producer{
+			QueueName: QueueBackchannelLogout,
+			Redis:     redis,
+			Clock:     clock,
+		},
+	}
+}
+
+type CIBANotificationProducer struct {
+	*Producer
+}
+
+func NewCIBANotificationProducer(redis *appredis.Handle, clock clock.Clock) *CIBANotificationProducer {
+	return &CIBANotificationProducer{
+		&Producer{
+			QueueName: QueueCIBANotification,
+			Redis:     redis,
+			Clock:     clock,
+		},
+	}
+}
+
+type MessageDeliveryProducer struct {
+	*Producer
+}
+
+func NewMessageDeliveryProducer(redis *appredis.Handle, clock clock.Clock) *MessageDeliveryProducer {
+	return &MessageDeliveryProducer{
+		&Producer{
+			QueueName: QueueMessageDelivery,
+			Redis:     redis,
+			Clock:     clock,
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package redisqueue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+)
+
+// A consumer moves a queue item to its own processing queue when it dequeues the item,
+// and removes the item when the task is completed.
+// If the consumer stops sending heartbeats, for example, the process crashed,
+// the items in its processing queue are moved back to the queue,
+// so that another consumer can process them.
+//
+// The queue items moved back are consumed before the other items in the queue.
+
+const requeueLuaFunction = `
+local function requeue(queue_key, processing_key)
+	local count = 0
+	local item = redis.call("LPOP", processing_key)
+	while item do
+		redis.call("RPUSH", queue_key, item)
+		count = count + 1
+		item = redis.call("LPOP", processing_key)
+	end
+	return count
+end
+`
+
+var requeueAbandonedLuaScript = goredis.NewScript(requeueLuaFunction + `
+local consumers_key = KEYS[1]
+local queue_key = KEYS[2]
+local processing_key_prefix = ARGV[1]
+local expire_before = tonumber(ARGV[2])
+
+local count = 0
+local consumers = redis.call("ZRANGEBYSCORE", consumers_key, "-inf", expire_before)
+for _, consumer_id in ipairs(consumers) do
+	count = count + requeue(queue_key, processing_key_prefix .. consumer_id)
+	redis.call("ZREM", consumers_key, consumer_id)
+end
+return count
+`)
+
+var removeConsumerLuaScript = goredis.NewScript(requeueLuaFunction + `
+local consumers_key = KEYS[1]
+local queue_key = KEYS[2]
+local processing_key = KEYS[3]
+local consumer_id = ARGV[1]
+
+local count = requeue(queue_key, processing_key)
+redis.call("ZREM", consumers_key, consumer_id)
+return count
+`)
+
+// ConsumerHeartbeat records that the consumer is alive at now.
+func ConsumerHeartbeat(ctx context.Context, conn redis.Redis_6_0_Cmdable, queueName QueueName, consumerID string, now time.Time) error {
+	_, err := conn.ZAdd(ctx, RedisKeyForConsumers(queueName), goredis.Z{
+		Score:  float64(now.UnixMilli()),
+		Member: consumerID,
+	}).Result()
+	return err
+}
+
+// RequeueAbandonedTasks moves the queue items of the consumers
+// whose last heartbeat is before expireBefore back to the queue.
+// It returns the number of queue items moved.
+func RequeueAbandonedTasks(ctx context.Context, conn redis.Redis_6_0_Cmdable, queueName QueueName, expireBefore time.Time) (int64, error) {
+	return requeueAbandonedLuaScript.Run(ctx, conn,
+		[]string{RedisKeyForConsumers(queueName), RedisKeyForQueue(queueName)},
+		RedisKeyForProcessingQueue(queueName, ""), expireBefore.UnixMilli(),
+	).Int64()
+}
+
+// RemoveConsumer moves the queue items of the consumer back to the queue,
+// and forgets the consumer.
+// It returns the number of queue items moved.
+func RemoveConsumer(ctx context.Context, conn redis.Redis_6_0_Cmdable, queueName QueueName, consumerID string) (int64, error) {
+	return removeConsumerLuaScript.Run(ctx, conn,
+		[]string{RedisKeyForConsumers(queueName), RedisKeyForQueue(queueName), RedisKeyForProcessingQueue(queueName, consumerID)},
+		consumerID,
+	).Int64()
+}
+
+// DequeueTask moves the oldest queue item to the processing queue of the consumer.
+// It blocks until a queue item is available, or timeout.
+// It returns goredis.Nil on timeout.
+//
+// BLMOVE is not used because it is available since Redis 6.2.
+func DequeueTask(ctx context.Context, conn redis.Redis_6_0_Cmdable, queueName QueueName, consumerID string, timeout time.Duration) (string, error) {
+	return conn.BRPopLPush(ctx,
+		RedisKeyForQueue(queueName),
+		RedisKeyForProcessingQueue(queueName, consumerID),
+		timeout,
+	).Result()
+}
+
+// AckTask removes the queue item from the processing queue of the consumer.
+// It must be called after the task is completed, or is to be dropped.
+func AckTask(ctx context.Context, conn redis.Redis_6_0_Cmdable, queueName QueueName, consumerID string, queueItem string) error {
+	n, err := conn.LRem(ctx, RedisKeyForProcessingQueue(queueName, consumerID), 1, queueItem).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("queue item not found in processing queue")
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package redisqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func TestProcessingQueue(t *testing.T) {
+	Convey("Processing queue", t, func() {
+		s := miniredis.RunT(t)
+		ctx := context.Background()
+		cli := goredis.NewClient(&goredis.Options{Addr: s.Addr()})
+		conn := cli.Conn()
+
+		queueName := QueueMessageDelivery
+		queueKey := RedisKeyForQueue(queueName)
+		now := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+		_, err := conn.LPush(ctx, queueKey, "task1", "task2", "task3").Result()
+		So(err, ShouldBeNil)
+
+		Convey("should keep the dequeued task until it is acknowledged", func() {
+			So(ConsumerHeartbeat(ctx, conn, queueName, "a", now), ShouldBeNil)
+
+			item, err := DequeueTask(ctx, conn, queueName, "a", time.Second)
+			So(err, ShouldBeNil)
+			So(item, ShouldEqual, "task1")
+			So(s.Exists(RedisKeyForProcessingQueue(queueName, "a")), ShouldBeTrue)
+
+			So(AckTask(ctx, conn, queueName, "a", item), ShouldBeNil)
+			So(s.Exists(RedisKeyForProcessingQueue(queueName, "a")), ShouldBeFalse)
+
+			So(AckTask(ctx, conn, queueName, "a", item), ShouldNotBeNil)
+		})
+
+		Convey("should requeue the tasks of a consumer without heartbeat", func() {
+			So(ConsumerHeartbeat(ctx, conn, queueName, "a", now), ShouldBeNil)
+			So(ConsumerHeartbeat(ctx, conn, queueName, "b", now.Add(time.Minute)), ShouldBeNil)
+
+			item, err := DequeueTask(ctx, conn, queueName, "a", time.Second)
+			So(err, ShouldBeNil)
+			So(item, ShouldEqual, "task1")
+			item, err = DequeueTask(ctx, conn, queueName, "a", time.Second)
+			So(err, ShouldBeNil)
+			So(item, ShouldEqual, "task2")
+			item, err = DequeueTask(ctx, conn, queueName, "b", time.Second)
+			So(err, ShouldBeNil)
+			So(item, ShouldEqual, "task3")
+
+			n, err := RequeueAbandonedTasks(ctx, conn, queueName, now.Add(30*time.Second))
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 2)
+
+			items, err := s.List(queueKey)
+			So(err, ShouldBeNil)
+			// The requeued tasks are consumed first, in the original order.
+			So(items, ShouldResemble, []string{"task2", "task1"})
+			So(s.Exists(RedisKeyForProcessingQueue(queueName, "a")), ShouldBeFalse)
+			So(s.Exists(RedisKeyForProcessingQueue(queueName, "b")), ShouldBeTrue)
+
+			members, err := s.ZMembers(RedisKeyForConsumers(queueName))
+			So(err, ShouldBeNil)
+			So(members, ShouldResemble, []string{"b"})
+		})
+
+		Convey("should requeue the tasks of a removed consumer", func() {
+			So(ConsumerHeartbeat(ctx, conn, queueName, "a", now), ShouldBeNil)
+
+			_, err := DequeueTask(ctx, conn, queueName, "a", time.Second)
+			So(err, ShouldBeNil)
+
+			n, err := RemoveConsumer(ctx, conn, queueName, "a")
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 1)
+
+			items, err := s.List(queueKey)
+			So(err, ShouldBeNil)
+			So(items, ShouldResemble, []string{"task3", "task2", "task1"})
+			So(s.Exists(RedisKeyForConsumers(queueName)), ShouldBeFalse)
+		})
+
+		Convey("should time out if the queue is empty", func() {
+			s.Del(queueKey)
+			_, err := DequeueTask(ctx, conn, queueName, "a", 100*time.Millisecond)
+			So(err, ShouldEqual, goredis.Nil)
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"time"
 
 	goredis "github.com/redis/go-redis/v9"
 
@@ -77,6 +78,41 @@ func (p *Producer) EnqueueTask(ctx context.Context, task *Task) error {
 	})
 }
 
+// EnqueueTaskAt enqueues the task so that it is not consumed before notBefore.
+// The consumer moves the task to the queue when it is due.
+func (p *Producer) EnqueueTaskAt(ctx context.Context, task *Task, notBefore time.Time) error {
+	return p.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		taskBytes, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+
+		key := task.RedisKey()
+		_, err = conn.SetNX(ctx, key, taskBytes, p.QueueName.GetTTLForEnqueue()).Result()
+		if err != nil {
+			return err
+		}
+
+		queueItem := task.ToQueueItem()
+		queueItemBytes, err := json.Marshal(queueItem)
+		if err != nil {
+			return err
+		}
+
+		delayedQueueKey := RedisKeyForDelayedQueue(p.QueueName)
+
+		_, err = conn.ZAdd(ctx, delayedQueueKey, goredis.Z{
+			Score:  float64(notBefore.UnixMilli()),
+			Member: queueItemBytes,
+		}).Result()
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
 func (p *Producer) GetTask(ctx context.Context, item *QueueItem) (*Task, error) {
 	var task Task
 	err := p.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
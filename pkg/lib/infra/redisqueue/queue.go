@@ -10,12 +10,21 @@ const (
 	QueueUserImport  QueueName = "user-import"
 	QueueUserExport  QueueName = "user-export"
 	QueueUserReindex QueueName = "user-reindex"
+
+	QueueBackchannelLogout QueueName = "backchannel-logout"
+	QueueCIBANotification  QueueName = "ciba-notification"
+
+	QueueMessageDelivery QueueName = "message-delivery"
 )
 
 func (q QueueName) GetTTLForEnqueue() time.Duration {
 	switch q {
 	case QueueUserReindex:
 		return 20 * time.Minute
+	case QueueBackchannelLogout, QueueCIBANotification:
+		return 1 * time.Hour
+	case QueueMessageDelivery:
+		return 1 * time.Hour
 	default:
 		return 24 * time.Hour
 	}
@@ -23,7 +32,7 @@ func (q QueueName) GetTTLForEnqueue() time.Duration {
 
 func (q QueueName) GetTTLForRetention() time.Duration {
 	switch q {
-	case QueueUserReindex:
+	case QueueUserReindex, QueueBackchannelLogout, QueueCIBANotification, QueueMessageDelivery:
 		return 5 * time.Minute
 	default:
 		return 24 * time.Hour
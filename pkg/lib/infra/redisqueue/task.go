@@ -53,3 +53,21 @@ func RedisKeyForTask(appID string, taskID string) string {
 func RedisKeyForQueue(queueName QueueName) string {
 	return fmt.Sprintf("redis-queue:%v", string(queueName))
 }
+
+// RedisKeyForDelayedQueue is the sorted set of the queue items that are not due yet.
+// The score is the due time in unix milliseconds.
+func RedisKeyForDelayedQueue(queueName QueueName) string {
+	return fmt.Sprintf("redis-queue-delayed:%v", string(queueName))
+}
+
+// RedisKeyForProcessingQueue is the list of the queue items that are being processed by a consumer.
+// A queue item stays in the list until the consumer has saved the output of the task.
+func RedisKeyForProcessingQueue(queueName QueueName, consumerID string) string {
+	return fmt.Sprintf("redis-queue-processing:%v:%v", string(queueName), consumerID)
+}
+
+// RedisKeyForConsumers is the sorted set of the consumers of the queue.
+// The score is the time of the last heartbeat of the consumer in unix milliseconds.
+func RedisKeyForConsumers(queueName QueueName) string {
+	return fmt.Sprintf("redis-queue-consumers:%v", string(queueName))
+}
@@ -83,6 +83,65 @@ type ClientResolver struct {
 }
 
 func (r *ClientResolver) ResolveClient() (smsapi.Client, SMSClientCredentials, error) {
+	_, client, smsClientCredentials, err := r.resolveClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, smsClientCredentials, nil
+}
+
+type ProviderClient struct {
+	Provider config.SMSProvider
+	Client   smsapi.Client
+}
+
+// ResolveFailoverClients resolves the client of the provider,
+// followed by the clients of sms_gateway.fallback_providers in order.
+func (r *ClientResolver) ResolveFailoverClients() ([]ProviderClient, error) {
+	provider, client, _, err := r.resolveClient()
+	if err != nil {
+		return nil, err
+	}
+
+	clients := []ProviderClient{{Provider: provider, Client: client}}
+	if r.AuthgearYAMLSMSGateway == nil {
+		return clients, nil
+	}
+
+	nexmoClient, _, twilioClient, _, customClient, _ := r.resolveRawClients()
+	for _, fallbackProvider := range r.AuthgearYAMLSMSGateway.FallbackProviders {
+		if fallbackProvider == provider {
+			continue
+		}
+
+		var fallbackClient smsapi.Client
+		switch fallbackProvider {
+		case config.SMSProviderNexmo:
+			if nexmoClient == nil {
+				return nil, smsapi.ErrNoAvailableClient
+			}
+			fallbackClient = nexmoClient
+		case config.SMSProviderTwilio:
+			if twilioClient == nil {
+				return nil, smsapi.ErrNoAvailableClient
+			}
+			fallbackClient = twilioClient
+		case config.SMSProviderCustom:
+			if customClient == nil {
+				return nil, smsapi.ErrNoAvailableClient
+			}
+			fallbackClient = customClient
+		default:
+			panic(fmt.Errorf("unknown sms provider %v", fallbackProvider))
+		}
+
+		clients = append(clients, ProviderClient{Provider: fallbackProvider, Client: fallbackClient})
+	}
+
+	return clients, nil
+}
+
+func (r *ClientResolver) resolveClient() (config.SMSProvider, smsapi.Client, SMSClientCredentials, error) {
 	nexmoClient, nexmoClientCredentials, twilioClient, twilioClientCredentials, customClient, customClientCredentials := r.resolveRawClients()
 	provider := r.resolveProvider()
 
@@ -91,68 +150,62 @@ func (r *ClientResolver) ResolveClient() (smsapi.Client, SMSClientCredentials, e
 	switch provider {
 	case config.SMSProviderNexmo:
 		if nexmoClient == nil {
-			return nil, nil, smsapi.ErrNoAvailableClient
+			return "", nil, nil, smsapi.ErrNoAvailableClient
 		}
 		client = nexmoClient
 		smsClientCredentials = nexmoClientCredentials
 	case config.SMSProviderTwilio:
 		if twilioClient == nil {
-			return nil, nil, smsapi.ErrNoAvailableClient
+			return "", nil, nil, smsapi.ErrNoAvailableClient
 		}
 		client = twilioClient
 		smsClientCredentials = twilioClientCredentials
 	case config.SMSProviderCustom:
 		if customClient == nil {
-			return nil, nil, smsapi.ErrNoAvailableClient
+			return "", nil, nil, smsapi.ErrNoAvailableClient
 		}
 		client = customClient
 		smsClientCredentials = customClientCredentials
 	default:
-		var availableClients []struct {
-			RawClient            smsapi.Client
-			SMSClientCredentials SMSClientCredentials
-		} = []struct {
+		type availableClient struct {
+			Provider             config.SMSProvider
 			RawClient            smsapi.Client
 			SMSClientCredentials SMSClientCredentials
-		}{}
+		}
+		var availableClients []availableClient
 
 		if nexmoClient != nil {
-			availableClients = append(availableClients, struct {
-				RawClient            smsapi.Client
-				SMSClientCredentials SMSClientCredentials
-			}{
+			availableClients = append(availableClients, availableClient{
+				Provider:             config.SMSProviderNexmo,
 				RawClient:            nexmoClient,
 				SMSClientCredentials: nexmoClientCredentials,
 			})
 		}
 		if twilioClient != nil {
-			availableClients = append(availableClients, struct {
-				RawClient            smsapi.Client
-				SMSClientCredentials SMSClientCredentials
-			}{
+			availableClients = append(availableClients, availableClient{
+				Provider:             config.SMSProviderTwilio,
 				RawClient:            twilioClient,
 				SMSClientCredentials: twilioClientCredentials,
 			})
 		}
 		if customClient != nil {
-			availableClients = append(availableClients, struct {
-				RawClient            smsapi.Client
-				SMSClientCredentials SMSClientCredentials
-			}{
+			availableClients = append(availableClients, availableClient{
+				Provider:             config.SMSProviderCustom,
 				RawClient:            customClient,
 				SMSClientCredentials: customClientCredentials,
 			})
 		}
 		if len(availableClients) == 0 {
-			return nil, nil, smsapi.ErrNoAvailableClient
+			return "", nil, nil, smsapi.ErrNoAvailableClient
 		}
 		if len(availableClients) > 1 {
-			return nil, nil, smsapi.ErrAmbiguousClient
+			return "", nil, nil, smsapi.ErrAmbiguousClient
 		}
+		provider = availableClients[0].Provider
 		client = availableClients[0].RawClient
 		smsClientCredentials = availableClients[0].SMSClientCredentials
 	}
-	return client, smsClientCredentials, nil
+	return provider, client, smsClientCredentials, nil
 }
 
 func (r *ClientResolver) resolveProvider() config.SMSProvider {
@@ -201,3 +201,37 @@ func toMap(c SMSClientCredentials) map[string]any {
 	}
 	return nil
 }
+
+func TestClientResolverResolveFailoverClients(t *testing.T) {
+	Convey("ResolveFailoverClients", t, func() {
+		clientResolver := ClientResolver{
+			AuthgearYAMLSMSGateway: &config.SMSGatewayConfig{
+				UseConfigFrom:     config.SMSGatewayUseConfigFromAuthgearSecretsYAML,
+				Provider:          config.SMSProviderTwilio,
+				FallbackProviders: []config.SMSProvider{config.SMSProviderTwilio, config.SMSProviderNexmo},
+			},
+			AuthgearSecretsYAMLNexmoCredentials: &config.NexmoCredentials{
+				APIKey:    "my-api-key",
+				APISecret: "my-api-secret",
+			},
+			AuthgearSecretsYAMLTwilioCredentials: &config.TwilioCredentials{
+				AccountSID: "my-account-sid",
+				AuthToken:  "my-auth-token",
+			},
+		}
+
+		Convey("should resolve the provider followed by the fallback providers", func() {
+			clients, err := clientResolver.ResolveFailoverClients()
+			So(err, ShouldBeNil)
+			So(clients, ShouldHaveLength, 2)
+			So(clients[0].Provider, ShouldEqual, config.SMSProviderTwilio)
+			So(clients[1].Provider, ShouldEqual, config.SMSProviderNexmo)
+		})
+
+		Convey("should fail if a fallback provider is not configured", func() {
+			clientResolver.AuthgearYAMLSMSGateway.FallbackProviders = []config.SMSProvider{config.SMSProviderCustom}
+			_, err := clientResolver.ResolveFailoverClients()
+			So(err, ShouldBeError, "no available SMS client")
+		})
+	})
+}
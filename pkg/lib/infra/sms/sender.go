@@ -23,3 +23,8 @@ func (c *Sender) ResolveClient() (smsapi.Client, error) {
 
 	return client, err
 }
+
+// ResolveFailoverClients resolves the clients to try in order.
+func (c *Sender) ResolveFailoverClients() ([]ProviderClient, error) {
+	return c.ClientResolver.ResolveFailoverClients()
+}
@@ -59,8 +59,10 @@ type AuthenticatorService interface {
 	Get(ctx context.Context, id string) (*authenticator.Info, error)
 	List(ctx context.Context, userID string, filters ...authenticator.Filter) ([]*authenticator.Info, error)
 	Create(ctx context.Context, authenticatorInfo *authenticator.Info, markVerified bool) error
+	CreateByAdmin(ctx context.Context, authenticatorInfo *authenticator.Info) error
 	Update(ctx context.Context, authenticatorInfo *authenticator.Info) error
 	Delete(ctx context.Context, authenticatorInfo *authenticator.Info) error
+	DeleteByAdmin(ctx context.Context, authenticatorInfo *authenticator.Info) error
 	VerifyWithSpec(ctx context.Context, info *authenticator.Info, spec *authenticator.Spec, options *facade.VerifyOptions) (verifyResult *service.VerifyResult, err error)
 	VerifyOneWithSpec(ctx context.Context, userID string, authenticatorType model.AuthenticatorType, infos []*authenticator.Info, spec *authenticator.Spec, options *facade.VerifyOptions) (info *authenticator.Info, verifyResult *service.VerifyResult, err error)
 	ClearLockoutAttempts(ctx context.Context, userID string, usedMethods []config.AuthenticationLockoutMethod) error
@@ -141,10 +143,12 @@ type SessionProvider interface {
 
 	Create(ctx context.Context, s *idpsession.IDPSession) error
 	Reauthenticate(ctx context.Context, idpSessionID string, amr []string) error
+	ListSessionsToEnd(ctx context.Context, userID string, excludeSessionID string, endOthers bool) ([]*idpsession.IDPSession, error)
 }
 
 type SessionManager interface {
 	RevokeWithoutEvent(ctx context.Context, s session.SessionBase) error
+	RevokeWithEvent(ctx context.Context, s session.SessionBase, isTermination bool, isAdminAPI bool) error
 }
 
 type OAuthProviderFactory interface {
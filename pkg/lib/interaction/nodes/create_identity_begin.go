@@ -63,6 +63,9 @@ func (n *NodeCreateIdentityBegin) deriveEdges() []interaction.Edge {
 		case model.IdentityTypeLDAP:
 			break
 
+		case model.IdentityTypeSAML:
+			break
+
 		case model.IdentityTypeSIWE:
 			edges = append(edges, &EdgeUseIdentitySIWE{})
 
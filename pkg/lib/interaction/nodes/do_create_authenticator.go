@@ -40,7 +40,12 @@ func (n *NodeDoCreateAuthenticator) GetEffects(goCtx context.Context) ([]interac
 	return []interaction.Effect{
 		interaction.EffectRun(func(goCtx context.Context, ctx *interaction.Context, graph *interaction.Graph, nodeIndex int) error {
 			for _, a := range n.Authenticators {
-				err := ctx.Authenticators.Create(goCtx, a, !n.IsAdminAPI)
+				var err error
+				if n.IsAdminAPI {
+					err = ctx.Authenticators.CreateByAdmin(goCtx, a)
+				} else {
+					err = ctx.Authenticators.Create(goCtx, a, true)
+				}
 				if err != nil {
 					return err
 				}
@@ -229,13 +229,29 @@ func (n *NodeDoEnsureSession) GetEffects(goCtx context.Context) ([]interaction.E
 			}
 
 			if n.SessionToCreate != nil {
+				// The current IdP session is revoked below, so it is not counted.
+				s := session.GetSession(goCtx)
+				excludeSessionID := ""
+				if s != nil && s.SessionType() == session.TypeIdentityProvider {
+					excludeSessionID = s.SessionID()
+				}
+				sessionsToEnd, err := ctx.Sessions.ListSessionsToEnd(goCtx, userID, excludeSessionID, false)
+				if err != nil {
+					return err
+				}
+				for _, sessionToEnd := range sessionsToEnd {
+					err = ctx.SessionManager.RevokeWithEvent(goCtx, sessionToEnd, true, false)
+					if err != nil {
+						return err
+					}
+				}
+
 				err = ctx.Sessions.Create(goCtx, n.SessionToCreate)
 				if err != nil {
 					return err
 				}
 
 				// Clean up unreachable IdP Session.
-				s := session.GetSession(goCtx)
 				if s != nil && s.SessionType() == session.TypeIdentityProvider {
 					err = ctx.SessionManager.RevokeWithoutEvent(goCtx, s)
 					if err != nil {
@@ -106,7 +106,11 @@ func (n *NodeDoRemoveAuthenticator) GetEffects(goCtx context.Context) ([]interac
 				}
 			}
 
-			err = ctx.Authenticators.Delete(goCtx, n.Authenticator)
+			if n.IsAdminAPI {
+				err = ctx.Authenticators.DeleteByAdmin(goCtx, n.Authenticator)
+			} else {
+				err = ctx.Authenticators.Delete(goCtx, n.Authenticator)
+			}
 			if err != nil {
 				return err
 			}
@@ -69,6 +69,8 @@ func (n *NodeSelectIdentityBegin) deriveEdges() []interaction.Edge {
 			break
 		case model.IdentityTypeLDAP:
 			break
+		case model.IdentityTypeSAML:
+			break
 		case model.IdentityTypeSIWE:
 			edges = append(edges, &EdgeUseIdentitySIWE{
 				IsAuthentication: n.IsAuthentication,
@@ -90,6 +90,8 @@ func (e *EdgeSelectIdentityEnd) Instantiate(goCtx context.Context, ctx *interact
 				break
 			case model.IdentityTypeLDAP:
 				break
+			case model.IdentityTypeSAML:
+				break
 			default:
 				panic(fmt.Errorf("interaction: unknown identity type: %v", e.IdentitySpec.Type))
 			}
@@ -14,6 +14,7 @@ import (
 
 const (
 	sizeLimit        = 2     // Set to 2 to check 0 or more than 1 entry returned
+	pagingSize       = 500   // The page size of searches that may return many entries.
 	timeoutInSeconds = 10    // 10 seconds timeout, may want to make it configurable in the future
 	typesOnly        = false // FALSE to return both attribute descriptions and values, TRUE to return attribute description only.
 )
@@ -90,7 +91,7 @@ func (c *Client) search(conn *ldap.Conn, searchFilter string) (*ldap.SearchResul
 		ldap.ScopeWholeSubtree, ldap.DerefAlways, sizeLimit, timeoutInSeconds,
 		typesOnly,
 		searchFilter,
-		[]string{"*"}, // return all attributes
+		c.searchAttributes(),
 		controls,
 	)
 
@@ -102,6 +103,26 @@ func (c *Client) search(conn *ldap.Conn, searchFilter string) (*ldap.SearchResul
 	return sr, nil
 }
 
+// searchAttributes returns the attributes to return in a user search.
+// Some directories, for example OpenLDAP with the memberof overlay,
+// return the member of attribute only when it is requested explicitly.
+func (c *Client) searchAttributes() []string {
+	attributes := []string{"*"} // return all attributes
+	if c.Config.IsGroupSyncEnabled() {
+		attributes = append(attributes, c.Config.GroupSync.MemberOfAttributeName)
+	}
+	return attributes
+}
+
+// bindSearchUser binds the search user if it is configured.
+// Otherwise, we will do an anonymous search.
+func (c *Client) bindSearchUser(conn *ldap.Conn) error {
+	if c.SecretConfig.DN != "" && c.SecretConfig.Password != "" {
+		return conn.Bind(c.SecretConfig.DN, c.SecretConfig.Password)
+	}
+	return nil
+}
+
 func (c *Client) AuthenticateUser(username string, password string) (*Entry, error) {
 	conn, err := c.connect()
 	if err != nil {
@@ -150,6 +171,130 @@ func (c *Client) AuthenticateUser(username string, password string) (*Entry, err
 		return nil, api.ErrInvalidCredentials
 	}
 
+	return sanitizeEntry(entry), nil
+}
+
+// SearchUsers returns all the users matched by the user filter of the directory sync.
+// Users without the user ID attribute are skipped.
+func (c *Client) SearchUsers() ([]*Entry, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	err = c.bindSearchUser(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		c.Config.BaseDN,
+		ldap.ScopeWholeSubtree, ldap.DerefAlways, 0, timeoutInSeconds,
+		typesOnly,
+		c.Config.DirectorySync.UserFilter,
+		c.searchAttributes(),
+		controls,
+	)
+
+	sr, err := conn.SearchWithPaging(searchRequest, pagingSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for _, entry := range sr.Entries {
+		if entry.GetAttributeValue(c.Config.UserIDAttributeName) == "" {
+			continue
+		}
+		entries = append(entries, sanitizeEntry(entry))
+	}
+
+	return entries, nil
+}
+
+// SearchGroups returns the DNs of the groups found by the group search of the group sync.
+func (c *Client) SearchGroups(userDN string) ([]string, error) {
+	searcher, err := c.NewGroupSearcher()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = searcher.Close()
+	}()
+
+	return searcher.SearchGroups(userDN)
+}
+
+// GroupSearcher runs the group search of the group sync with a single connection,
+// so that searching the groups of many users does not connect and bind once per user.
+type GroupSearcher struct {
+	client *Client
+	conn   *ldap.Conn
+}
+
+// NewGroupSearcher connects and binds the search user.
+// The caller must close the returned GroupSearcher.
+func (c *Client) NewGroupSearcher() (*GroupSearcher, error) {
+	conn, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.bindSearchUser(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return &GroupSearcher{
+		client: c,
+		conn:   conn,
+	}, nil
+}
+
+func (s *GroupSearcher) SearchGroups(userDN string) ([]string, error) {
+	groupSearch := s.client.Config.GroupSync.GroupSearch
+
+	searchFilter, err := ldaputil.ParseGroupSearchFilter(groupSearch.FilterTemplate, userDN)
+	if err != nil {
+		return nil, err
+	}
+
+	baseDN := groupSearch.BaseDN
+	if baseDN == "" {
+		baseDN = s.client.Config.BaseDN
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.DerefAlways, 0, timeoutInSeconds,
+		typesOnly,
+		searchFilter,
+		[]string{"1.1"}, // return no attributes, DN is always returned
+		controls,
+	)
+
+	sr, err := s.conn.SearchWithPaging(searchRequest, pagingSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var dns []string
+	for _, entry := range sr.Entries {
+		dns = append(dns, entry.DN)
+	}
+
+	return dns, nil
+}
+
+func (s *GroupSearcher) Close() error {
+	return s.conn.Close()
+}
+
+func sanitizeEntry(entry *ldap.Entry) *Entry {
 	entryAttributes := []*ldap.EntryAttribute{}
 	for _, attr := range entry.Attributes {
 		_, isSensitiveAttribute := sensitiveAttributes[attr.Name]
@@ -159,11 +304,11 @@ func (c *Client) AuthenticateUser(username string, password string) (*Entry, err
 	}
 
 	sensitizedEntry := &ldap.Entry{
-		DN:         userDN,
+		DN:         entry.DN,
 		Attributes: entryAttributes,
 	}
 
-	return &Entry{sensitizedEntry}, nil
+	return &Entry{sensitizedEntry}
 }
 
 func (c *Client) TestConnection(username string) error {
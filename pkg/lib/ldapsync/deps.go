@@ -0,0 +1,10 @@
+package ldapsync
+
+import (
+	"github.com/google/wire"
+)
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(GroupSyncService), "*"),
+	wire.Struct(new(DirectorySyncService), "*"),
+)
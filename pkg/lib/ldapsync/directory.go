@@ -0,0 +1,208 @@
+package ldapsync
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/facade"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/lib/ldap"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+// DisableReasonMissingFromDirectory is the disable reason of users removed from the directory.
+const DisableReasonMissingFromDirectory = "The user is removed from the LDAP directory."
+
+type LDAPIdentityProvider interface {
+	ListByServerName(ctx context.Context, serverName string) ([]*identity.LDAP, error)
+	MakeSpecFromEntry(ctx context.Context, serverConfig *config.LDAPServerConfig, loginUserName string, entry *ldap.Entry) (*identity.Spec, error)
+	WithUpdate(iden *identity.LDAP, loginUserName *string, claims map[string]any, rawEntryJSON map[string]any) *identity.LDAP
+}
+
+type IdentityService interface {
+	Update(ctx context.Context, oldInfo *identity.Info, newInfo *identity.Info) error
+}
+
+type UserService interface {
+	GetRaw(ctx context.Context, id string) (*user.User, error)
+	Disable(ctx context.Context, options facade.SetDisabledOptions) error
+}
+
+var DirectorySyncServiceLogger = slogutil.NewLogger("ldap-directory-sync")
+
+type DirectorySyncService struct {
+	Database          *appdb.Handle
+	Clock             clock.Clock
+	LDAPConfig        *config.LDAPConfig
+	LDAPClientFactory LDAPClientFactory
+	LDAPIdentities    LDAPIdentityProvider
+	Identities        IdentityService
+	Users             UserService
+	GroupSync         *GroupSyncService
+	Events            EventService
+}
+
+// SyncDirectory syncs the LDAP identities of every LDAP server with directory sync enabled.
+// A failure of a server or an identity is logged, so that it does not block the others.
+func (s *DirectorySyncService) SyncDirectory(ctx context.Context) error {
+	logger := DirectorySyncServiceLogger.GetLogger(ctx)
+
+	for _, serverConfig := range s.LDAPConfig.Servers {
+		if !serverConfig.IsDirectorySyncEnabled() {
+			continue
+		}
+
+		err := s.syncServer(ctx, serverConfig)
+		if err != nil {
+			logger.WithError(err).Error(ctx, "failed to sync ldap server",
+				slog.String("server_name", serverConfig.Name),
+			)
+		}
+	}
+	return nil
+}
+
+func (s *DirectorySyncService) syncServer(ctx context.Context, serverConfig *config.LDAPServerConfig) error {
+	logger := DirectorySyncServiceLogger.GetLogger(ctx)
+
+	client := s.LDAPClientFactory.MakeClient(serverConfig)
+	entries, err := client.SearchUsers()
+	if err != nil {
+		return err
+	}
+
+	entriesByUserID := make(map[string]*ldap.Entry)
+	for _, entry := range entries {
+		userIDAttributeValue := entry.GetRawAttributeValue(serverConfig.UserIDAttributeName)
+		entriesByUserID[string(userIDAttributeValue)] = entry
+	}
+
+	var idens []*identity.LDAP
+	err = s.Database.ReadOnly(ctx, func(ctx context.Context) (err error) {
+		idens, err = s.LDAPIdentities.ListByServerName(ctx, serverConfig.Name)
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	// An empty result is more likely a misconfigured user filter than an empty directory.
+	// Disabling every user in this case is too destructive.
+	disableMissingUsers := *serverConfig.DirectorySync.DisableMissingUsers
+	if disableMissingUsers && len(entries) == 0 && len(idens) > 0 {
+		logger.Warn(ctx, "skip disabling users because no users are found in the directory",
+			slog.String("server_name", serverConfig.Name),
+		)
+		disableMissingUsers = false
+	}
+
+	// Search the groups of all users with one connection.
+	var groupSearcher *ldap.GroupSearcher
+	if serverConfig.IsGroupSyncEnabled() && serverConfig.GroupSync.GroupSearch != nil {
+		groupSearcher, err = client.NewGroupSearcher()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = groupSearcher.Close()
+		}()
+	}
+
+	for _, iden := range idens {
+		entry, ok := entriesByUserID[string(iden.UserIDAttributeValue)]
+		var err error
+		if ok {
+			err = s.refreshIdentity(ctx, serverConfig, groupSearcher, iden, entry)
+		} else if disableMissingUsers {
+			err = s.Database.WithTx(ctx, func(ctx context.Context) error {
+				return s.disableUser(ctx, iden)
+			})
+		}
+		if err != nil {
+			logger.WithError(err).Error(ctx, "failed to sync ldap identity",
+				slog.String("server_name", serverConfig.Name),
+				slog.String("identity_id", iden.ID),
+				slog.String("user_id", iden.UserID),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *DirectorySyncService) disableUser(ctx context.Context, iden *identity.LDAP) error {
+	u, err := s.Users.GetRaw(ctx, iden.UserID)
+	if err != nil {
+		return err
+	}
+
+	if u.AccountStatus(s.Clock.NowUTC()).IsDisabled() {
+		return nil
+	}
+
+	reason := DisableReasonMissingFromDirectory
+	return s.Users.Disable(ctx, facade.SetDisabledOptions{
+		UserID:     iden.UserID,
+		IsDisabled: true,
+		Reason:     &reason,
+		IsSystem:   true,
+	})
+}
+
+func (s *DirectorySyncService) refreshIdentity(ctx context.Context, serverConfig *config.LDAPServerConfig, groupSearcher *ldap.GroupSearcher, iden *identity.LDAP, entry *ldap.Entry) error {
+	loginUserName := ""
+	if iden.LastLoginUserName != nil {
+		loginUserName = *iden.LastLoginUserName
+	}
+
+	spec, err := s.LDAPIdentities.MakeSpecFromEntry(ctx, serverConfig, loginUserName, entry)
+	if err != nil {
+		return err
+	}
+
+	newIden := s.LDAPIdentities.WithUpdate(iden, iden.LastLoginUserName, spec.LDAP.Claims, spec.LDAP.RawEntryJSON)
+
+	// Search the groups before the transaction, so that the transaction is not held open by the directory.
+	ldapGroups, err := s.GroupSync.LDAPGroups(newIden, groupSearcher)
+	if err != nil {
+		return err
+	}
+
+	return s.Database.WithTx(ctx, func(ctx context.Context) error {
+		return s.updateIdentity(ctx, iden, newIden, ldapGroups)
+	})
+}
+
+func (s *DirectorySyncService) updateIdentity(ctx context.Context, iden *identity.LDAP, newIden *identity.LDAP, ldapGroups []string) error {
+	changed := !reflect.DeepEqual(iden.Claims, newIden.Claims) || !reflect.DeepEqual(iden.RawEntryJSON, newIden.RawEntryJSON)
+	if changed {
+		oldInfo := iden.ToInfo()
+		newInfo := newIden.ToInfo()
+		err := s.Identities.Update(ctx, oldInfo, newInfo)
+		if err != nil {
+			return err
+		}
+
+		err = s.Events.DispatchEventOnCommit(ctx, &nonblocking.IdentityLDAPUpdatedEventPayload{
+			UserRef: model.UserRef{
+				Meta: model.Meta{
+					ID: iden.UserID,
+				},
+			},
+			OldIdentity: oldInfo.ToModel(),
+			NewIdentity: newInfo.ToModel(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.GroupSync.ApplyLDAPGroups(ctx, newIden, ldapGroups, true)
+}
@@ -0,0 +1,187 @@
+package ldapsync
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/ldap"
+	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
+	"github.com/authgear/authgear-server/pkg/util/slice"
+)
+
+type LDAPClientFactory interface {
+	MakeClient(serverConfig *config.LDAPServerConfig) *ldap.Client
+}
+
+type RolesGroupsQueries interface {
+	ListGroupsByUserID(ctx context.Context, userID string) ([]*model.Group, error)
+	ListRolesByUserID(ctx context.Context, userID string) ([]*model.Role, error)
+	ListAllGroupsByKeys(ctx context.Context, keys []string) ([]*model.Group, error)
+	ListAllRolesByKeys(ctx context.Context, keys []string) ([]*model.Role, error)
+}
+
+type RolesGroupsCommands interface {
+	AddUserToGroups(ctx context.Context, options *rolesgroups.AddUserToGroupsOptions) error
+	RemoveUserFromGroups(ctx context.Context, options *rolesgroups.RemoveUserFromGroupsOptions) error
+	AddUserToRoles(ctx context.Context, options *rolesgroups.AddUserToRolesOptions) error
+	RemoveUserFromRoles(ctx context.Context, options *rolesgroups.RemoveUserFromRolesOptions) error
+}
+
+type EventService interface {
+	DispatchEventOnCommit(ctx context.Context, payload event.Payload) error
+}
+
+type GroupSyncService struct {
+	LDAPConfig          *config.LDAPConfig
+	LDAPClientFactory   LDAPClientFactory
+	RolesGroupsQueries  RolesGroupsQueries
+	RolesGroupsCommands RolesGroupsCommands
+	Events              EventService
+}
+
+// LDAPGroups returns the DNs of the LDAP groups of iden.
+// The group search uses searcher if it is non-nil, or a new connection otherwise.
+// It does not access the database, so it can be called outside a transaction.
+func (s *GroupSyncService) LDAPGroups(iden *identity.LDAP, searcher *ldap.GroupSearcher) ([]string, error) {
+	serverConfig, ok := s.LDAPConfig.GetServerConfig(iden.ServerName)
+	if !ok || !serverConfig.IsGroupSyncEnabled() {
+		return nil, nil
+	}
+	groupSync := serverConfig.GroupSync
+
+	ldapGroups := iden.MemberOf(groupSync.MemberOfAttributeName)
+	if groupSync.GroupSearch != nil {
+		if dn, ok := iden.DN(); ok {
+			var searched []string
+			var err error
+			if searcher != nil {
+				searched, err = searcher.SearchGroups(dn)
+			} else {
+				searched, err = s.LDAPClientFactory.MakeClient(serverConfig).SearchGroups(dn)
+			}
+			if err != nil {
+				return nil, err
+			}
+			ldapGroups = append(ldapGroups, searched...)
+		}
+	}
+
+	return ldapGroups, nil
+}
+
+// ApplyLDAPGroups adds the owner of iden to, or removes from, the groups and roles
+// in the group sync mappings of the LDAP server, according to ldapGroups returned by LDAPGroups.
+// It must be called within a transaction.
+func (s *GroupSyncService) ApplyLDAPGroups(ctx context.Context, iden *identity.LDAP, ldapGroups []string, isDirectorySync bool) error {
+	serverConfig, ok := s.LDAPConfig.GetServerConfig(iden.ServerName)
+	if !ok || !serverConfig.IsGroupSyncEnabled() {
+		return nil
+	}
+	groupSync := serverConfig.GroupSync
+
+	managed := managedMemberships(groupSync)
+	desired := mapLDAPGroups(groupSync, ldapGroups)
+
+	// Groups and roles in the mappings may not have been created yet.
+	// They are ignored until they are created.
+	existingGroups, err := s.RolesGroupsQueries.ListAllGroupsByKeys(ctx, managed.GroupKeys)
+	if err != nil {
+		return err
+	}
+	existingRoles, err := s.RolesGroupsQueries.ListAllRolesByKeys(ctx, managed.RoleKeys)
+	if err != nil {
+		return err
+	}
+
+	currentGroups, err := s.RolesGroupsQueries.ListGroupsByUserID(ctx, iden.UserID)
+	if err != nil {
+		return err
+	}
+	currentRoles, err := s.RolesGroupsQueries.ListRolesByUserID(ctx, iden.UserID)
+	if err != nil {
+		return err
+	}
+
+	addedGroupKeys, removedGroupKeys := diffKeys(
+		slice.Map(currentGroups, groupKey),
+		desired.GroupKeys,
+		slice.Map(existingGroups, groupKey),
+	)
+	addedRoleKeys, removedRoleKeys := diffKeys(
+		slice.Map(currentRoles, roleKey),
+		desired.RoleKeys,
+		slice.Map(existingRoles, roleKey),
+	)
+
+	if len(addedGroupKeys) > 0 {
+		err = s.RolesGroupsCommands.AddUserToGroups(ctx, &rolesgroups.AddUserToGroupsOptions{
+			UserID:    iden.UserID,
+			GroupKeys: addedGroupKeys,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(removedGroupKeys) > 0 {
+		err = s.RolesGroupsCommands.RemoveUserFromGroups(ctx, &rolesgroups.RemoveUserFromGroupsOptions{
+			UserID:    iden.UserID,
+			GroupKeys: removedGroupKeys,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(addedRoleKeys) > 0 {
+		err = s.RolesGroupsCommands.AddUserToRoles(ctx, &rolesgroups.AddUserToRolesOptions{
+			UserID:   iden.UserID,
+			RoleKeys: addedRoleKeys,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(removedRoleKeys) > 0 {
+		err = s.RolesGroupsCommands.RemoveUserFromRoles(ctx, &rolesgroups.RemoveUserFromRolesOptions{
+			UserID:   iden.UserID,
+			RoleKeys: removedRoleKeys,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(addedGroupKeys) == 0 && len(removedGroupKeys) == 0 && len(addedRoleKeys) == 0 && len(removedRoleKeys) == 0 {
+		return nil
+	}
+
+	err = s.Events.DispatchEventOnCommit(ctx, &nonblocking.IdentityLDAPGroupsSyncedEventPayload{
+		UserRef: model.UserRef{
+			Meta: model.Meta{
+				ID: iden.UserID,
+			},
+		},
+		Identity:         iden.ToInfo().ToModel(),
+		AddedGroupKeys:   addedGroupKeys,
+		RemovedGroupKeys: removedGroupKeys,
+		AddedRoleKeys:    addedRoleKeys,
+		RemovedRoleKeys:  removedRoleKeys,
+		IsDirectorySync:  isDirectorySync,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func groupKey(g *model.Group) string {
+	return g.Key
+}
+
+func roleKey(r *model.Role) string {
+	return r.Key
+}
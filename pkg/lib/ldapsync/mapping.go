@@ -0,0 +1,96 @@
+package ldapsync
+
+import (
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/setutil"
+)
+
+// Memberships is a set of group keys and role keys.
+type Memberships struct {
+	GroupKeys []string
+	RoleKeys  []string
+}
+
+// managedMemberships returns all the group keys and role keys in the mappings.
+// The group sync only adds or removes these keys,
+// so that the groups and roles assigned by other means are kept.
+func managedMemberships(c *config.LDAPGroupSyncConfig) Memberships {
+	var groupKeys setutil.Set[string]
+	var roleKeys setutil.Set[string]
+	for _, m := range c.Mappings {
+		for _, k := range m.Groups {
+			groupKeys.Add(k)
+		}
+		for _, k := range m.Roles {
+			roleKeys.Add(k)
+		}
+	}
+	return Memberships{
+		GroupKeys: groupKeys.Keys(),
+		RoleKeys:  roleKeys.Keys(),
+	}
+}
+
+// mapLDAPGroups returns the group keys and role keys granted to a member of ldapGroups.
+func mapLDAPGroups(c *config.LDAPGroupSyncConfig, ldapGroups []string) Memberships {
+	var groupKeys setutil.Set[string]
+	var roleKeys setutil.Set[string]
+	for _, m := range c.Mappings {
+		isMember := false
+		for _, ldapGroup := range ldapGroups {
+			if isSameDN(m.LDAPGroup, ldapGroup) {
+				isMember = true
+				break
+			}
+		}
+		if !isMember {
+			continue
+		}
+
+		for _, k := range m.Groups {
+			groupKeys.Add(k)
+		}
+		for _, k := range m.Roles {
+			roleKeys.Add(k)
+		}
+	}
+	return Memberships{
+		GroupKeys: groupKeys.Keys(),
+		RoleKeys:  roleKeys.Keys(),
+	}
+}
+
+// diffKeys returns the keys to add and to remove so that current agrees with desired.
+// Keys not in managed are never added or removed.
+func diffKeys(current []string, desired []string, managed []string) (added []string, removed []string) {
+	currentSet := setutil.NewSetFromSlice(current, setutil.Identity[string])
+	desiredSet := setutil.NewSetFromSlice(desired, setutil.Identity[string])
+	managedSet := setutil.NewSetFromSlice(managed, setutil.Identity[string])
+
+	for _, k := range desiredSet.Subtract(currentSet).Keys() {
+		if managedSet.Has(k) {
+			added = append(added, k)
+		}
+	}
+	for _, k := range currentSet.Subtract(desiredSet).Keys() {
+		if managedSet.Has(k) {
+			removed = append(removed, k)
+		}
+	}
+	return
+}
+
+// isSameDN compares two DNs.
+// Attribute types and values in a DN are compared case-insensitively.
+func isSameDN(a string, b string) bool {
+	dnA, errA := ldap.ParseDN(a)
+	dnB, errB := ldap.ParseDN(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return dnA.EqualFold(dnB)
+}
@@ -0,0 +1,82 @@
+package ldapsync
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func TestMapping(t *testing.T) {
+	c := &config.LDAPGroupSyncConfig{
+		Mappings: []*config.LDAPGroupMappingConfig{
+			{
+				LDAPGroup: "cn=admins,ou=groups,dc=example,dc=com",
+				Groups:    []string{"admins"},
+				Roles:     []string{"admin", "staff"},
+			},
+			{
+				LDAPGroup: "cn=staff,ou=groups,dc=example,dc=com",
+				Roles:     []string{"staff"},
+			},
+		},
+	}
+
+	Convey("managedMemberships", t, func() {
+		So(managedMemberships(c), ShouldResemble, Memberships{
+			GroupKeys: []string{"admins"},
+			RoleKeys:  []string{"admin", "staff"},
+		})
+	})
+
+	Convey("mapLDAPGroups", t, func() {
+		So(mapLDAPGroups(c, nil), ShouldResemble, Memberships{
+			GroupKeys: []string{},
+			RoleKeys:  []string{},
+		})
+
+		So(mapLDAPGroups(c, []string{
+			"cn=staff,ou=groups,dc=example,dc=com",
+			"cn=others,ou=groups,dc=example,dc=com",
+		}), ShouldResemble, Memberships{
+			GroupKeys: []string{},
+			RoleKeys:  []string{"staff"},
+		})
+
+		// DNs are compared case-insensitively and regardless of spaces.
+		So(mapLDAPGroups(c, []string{
+			"CN=Admins, OU=Groups, DC=example, DC=com",
+		}), ShouldResemble, Memberships{
+			GroupKeys: []string{"admins"},
+			RoleKeys:  []string{"admin", "staff"},
+		})
+	})
+
+	Convey("diffKeys", t, func() {
+		added, removed := diffKeys(
+			[]string{"admin", "manual"},
+			[]string{"staff"},
+			[]string{"admin", "staff"},
+		)
+		So(added, ShouldResemble, []string{"staff"})
+		So(removed, ShouldResemble, []string{"admin"})
+
+		added, removed = diffKeys(
+			[]string{"staff"},
+			[]string{"staff"},
+			[]string{"admin", "staff"},
+		)
+		So(added, ShouldBeNil)
+		So(removed, ShouldBeNil)
+
+		// Keys not in the mappings are never touched.
+		added, removed = diffKeys(
+			[]string{"manual"},
+			[]string{"unknown"},
+			[]string{},
+		)
+		So(added, ShouldBeNil)
+		So(removed, ShouldBeNil)
+	})
+}
@@ -0,0 +1,422 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
+	"github.com/authgear/authgear-server/pkg/lib/infra/sms/smsapi"
+	"github.com/authgear/authgear-server/pkg/lib/infra/whatsapp"
+	"github.com/authgear/authgear-server/pkg/lib/otelauthgear"
+	"github.com/authgear/authgear-server/pkg/lib/translation"
+	"github.com/authgear/authgear-server/pkg/util/otelutil"
+	"github.com/authgear/authgear-server/pkg/util/phone"
+)
+
+// MessageDeliveryMaxAttempts is the number of times a message is delivered
+// before the delivery is given up.
+// OTPs expire in minutes, so it is pointless to retry for long.
+const MessageDeliveryMaxAttempts = 5
+
+// MessageDeliveryRetryBackoff is the delay before the first retry.
+// The delay is doubled on every subsequent retry.
+const MessageDeliveryRetryBackoff = 2 * time.Second
+
+// ErrUnknownMessageDeliveryChannel is returned when the task cannot be delivered by any channel.
+// The task is dropped instead of retried.
+var ErrUnknownMessageDeliveryChannel = errors.New("messaging: unknown message delivery channel")
+
+type MessageDeliveryChannel string
+
+const (
+	MessageDeliveryChannelEmail    MessageDeliveryChannel = "email"
+	MessageDeliveryChannelSMS      MessageDeliveryChannel = "sms"
+	MessageDeliveryChannelWhatsapp MessageDeliveryChannel = "whatsapp"
+)
+
+// MessageDeliveryRequest is the input of a message delivery task.
+type MessageDeliveryRequest struct {
+	Channel     MessageDeliveryChannel                 `json:"channel"`
+	MessageType translation.MessageType                `json:"message_type"`
+	Email       *mail.SendOptions                      `json:"email,omitempty"`
+	SMS         *sms.SendOptions                       `json:"sms,omitempty"`
+	Whatsapp    *whatsapp.SendAuthenticationOTPOptions `json:"whatsapp,omitempty"`
+	// WhatsappFallbackSMS is sent if the Whatsapp message cannot be delivered.
+	WhatsappFallbackSMS *WhatsappFallbackSMS `json:"whatsapp_fallback_sms,omitempty"`
+	// WhatsappCallbackState is given back to WhatsappDeliveryCallback once the outcome is final.
+	WhatsappCallbackState json.RawMessage `json:"whatsapp_callback_state,omitempty"`
+	Attempt               int             `json:"attempt"`
+}
+
+type WhatsappFallbackSMS struct {
+	MessageType translation.MessageType `json:"message_type"`
+	SMS         *sms.SendOptions        `json:"sms"`
+}
+
+// WhatsappDeliveryCallback is told the final outcome of a Whatsapp message delivered by the queue.
+type WhatsappDeliveryCallback interface {
+	OnWhatsappSent(ctx context.Context, state json.RawMessage, result *SendWhatsappResult) error
+	OnWhatsappFallbackSMSSent(ctx context.Context, state json.RawMessage) error
+	OnWhatsappError(ctx context.Context, state json.RawMessage, err error) error
+}
+
+type MessageDeliveryProducer interface {
+	NewTask(appID string, input json.RawMessage, taskIDPrefix string) *redisqueue.Task
+	EnqueueTask(ctx context.Context, task *redisqueue.Task) error
+	EnqueueTaskAt(ctx context.Context, task *redisqueue.Task, notBefore time.Time) error
+}
+
+func (s *Sender) enqueueMessageDelivery(ctx context.Context, request *MessageDeliveryRequest) error {
+	rawMessage, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	task := s.Producer.NewTask(string(s.AppID), rawMessage, "task")
+	return s.Producer.EnqueueTask(ctx, task)
+}
+
+// enqueueMessageDeliveryRetry enqueues the retry as a delayed task,
+// so that the consumer is not blocked by the backoff.
+func (s *Sender) enqueueMessageDeliveryRetry(ctx context.Context, request *MessageDeliveryRequest, notBefore time.Time) error {
+	rawMessage, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	task := s.Producer.NewTask(string(s.AppID), rawMessage, "task")
+	return s.Producer.EnqueueTaskAt(ctx, task, notBefore)
+}
+
+// Deliver delivers a message enqueued by SendEmailAsync, SendSMSAsync or SendWhatsappAsync.
+// Transient failures are enqueued again as delayed tasks with exponential backoff.
+// The sent or error event is dispatched once the outcome is final.
+func (s *Sender) Deliver(ctx context.Context, request *MessageDeliveryRequest) error {
+	var provider config.SMSProvider
+	var whatsappResult *SendWhatsappResult
+	var err error
+	switch request.Channel {
+	case MessageDeliveryChannelEmail:
+		err = s.deliverEmail(ctx, request.Email)
+	case MessageDeliveryChannelSMS:
+		provider, err = s.deliverSMS(ctx, request.SMS)
+	case MessageDeliveryChannelWhatsapp:
+		whatsappResult, err = s.sendWhatsapp(ctx, request.Whatsapp)
+	default:
+		// Retrying would not help, so the task is dropped.
+		return fmt.Errorf("%w: %v", ErrUnknownMessageDeliveryChannel, request.Channel)
+	}
+
+	if err == nil {
+		switch request.Channel {
+		case MessageDeliveryChannelEmail:
+			s.reportEmailSent(ctx, request.MessageType, request.Email)
+		case MessageDeliveryChannelSMS:
+			s.reportSMSSent(ctx, request.MessageType, request.SMS, provider)
+		case MessageDeliveryChannelWhatsapp:
+			s.reportWhatsappSent(ctx, request.MessageType, request.Whatsapp)
+			s.onWhatsappSent(ctx, request, whatsappResult)
+		}
+		return nil
+	}
+
+	if isMessageDeliveryRetryable(err) && request.Attempt+1 < MessageDeliveryMaxAttempts {
+		retry := *request
+		retry.Attempt = request.Attempt + 1
+		notBefore := s.Clock.NowUTC().Add(MessageDeliveryRetryBackoff << request.Attempt)
+
+		logger := SenderLogger.GetLogger(ctx)
+		logger.WithError(err).Warn(ctx, "message delivery: retry delivery",
+			slog.String("channel", string(request.Channel)),
+			slog.Int("attempt", retry.Attempt),
+			slog.Time("not_before", notBefore),
+		)
+
+		enqueueErr := s.enqueueMessageDeliveryRetry(ctx, &retry, notBefore)
+		if enqueueErr == nil {
+			return err
+		}
+		err = errors.Join(err, enqueueErr)
+	}
+
+	switch request.Channel {
+	case MessageDeliveryChannelEmail:
+		s.reportEmailError(ctx, request.Email, err)
+	case MessageDeliveryChannelSMS:
+		s.reportSMSError(ctx, request.SMS, provider, err)
+	case MessageDeliveryChannelWhatsapp:
+		s.reportWhatsappError(ctx, request.Whatsapp, err)
+		err = s.onWhatsappError(ctx, request, err)
+	}
+	return err
+}
+
+func (s *Sender) onWhatsappSent(ctx context.Context, request *MessageDeliveryRequest, result *SendWhatsappResult) {
+	err := s.WhatsappCallback.OnWhatsappSent(ctx, request.WhatsappCallbackState, result)
+	if err != nil {
+		SenderLogger.GetLogger(ctx).WithError(err).Error(ctx, "failed to run whatsapp callback")
+	}
+}
+
+// onWhatsappError sends the fallback SMS, if any.
+// It returns nil if the fallback SMS is enqueued.
+func (s *Sender) onWhatsappError(ctx context.Context, request *MessageDeliveryRequest, err error) error {
+	logger := SenderLogger.GetLogger(ctx)
+
+	if fallback := request.WhatsappFallbackSMS; fallback != nil {
+		fallbackErr := s.sendSMS(ctx, fallback.MessageType, fallback.SMS, true)
+		if fallbackErr == nil {
+			callbackErr := s.WhatsappCallback.OnWhatsappFallbackSMSSent(ctx, request.WhatsappCallbackState)
+			if callbackErr != nil {
+				logger.WithError(callbackErr).Error(ctx, "failed to run whatsapp callback")
+			}
+			return nil
+		}
+		err = errors.Join(err, fallbackErr)
+	}
+
+	callbackErr := s.WhatsappCallback.OnWhatsappError(ctx, request.WhatsappCallbackState, err)
+	if callbackErr != nil {
+		logger.WithError(callbackErr).Error(ctx, "failed to run whatsapp callback")
+	}
+	return err
+}
+
+func (s *Sender) deliverEmail(ctx context.Context, opts *mail.SendOptions) error {
+	client, err := s.MailSender.ResolveClient()
+	if err != nil {
+		return err
+	}
+
+	return s.MailSender.Send(ctx, client, *opts)
+}
+
+// deliverSMS tries the SMS gateways in order until one of them delivers the message.
+// It returns the gateway that delivered the message, or the last gateway that failed.
+func (s *Sender) deliverSMS(ctx context.Context, opts *sms.SendOptions) (config.SMSProvider, error) {
+	clients, err := s.SMSSender.ResolveFailoverClients()
+	if err != nil {
+		return "", err
+	}
+
+	logger := SenderLogger.GetLogger(ctx)
+	var provider config.SMSProvider
+	for i, c := range clients {
+		provider = c.Provider
+		err = s.SMSSender.Send(ctx, c.Client, *opts)
+		if err == nil {
+			return provider, nil
+		}
+
+		// Other gateways would not accept an invalid phone number either.
+		if isSMSInvalidPhoneNumber(err) {
+			break
+		}
+
+		if i+1 < len(clients) {
+			logger.WithError(err).With(
+				slog.String("phone", phone.Mask(opts.To)),
+				slog.String("provider", string(provider)),
+				slog.String("fallback_provider", string(clients[i+1].Provider)),
+			).Warn(ctx, "failed to send SMS, falling back to next provider")
+		}
+	}
+
+	return provider, err
+}
+
+func (s *Sender) reportEmailSent(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) {
+	logger := SenderLogger.GetLogger(ctx)
+
+	otelutil.IntCounterAddOne(
+		ctx,
+		otelauthgear.CounterEmailRequestCount,
+		otelauthgear.WithStatusOk(),
+	)
+
+	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.EmailSentEventPayload{
+		Sender:    opts.Sender,
+		Recipient: opts.Recipient,
+		Type:      string(msgType),
+	})
+	if dispatchErr != nil {
+		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.EmailSent)))
+	}
+}
+
+func (s *Sender) reportEmailError(ctx context.Context, opts *mail.SendOptions, err error) {
+	logger := SenderLogger.GetLogger(ctx)
+
+	logger.WithError(err).With(
+		slog.String("email", mail.MaskAddress(opts.Recipient)),
+	).Error(ctx, "failed to send email")
+
+	otelutil.IntCounterAddOne(
+		ctx,
+		otelauthgear.CounterEmailRequestCount,
+		otelauthgear.WithStatusError(),
+	)
+
+	// The provider tells us the recipient is on its suppression list.
+	var sendErr *mailapi.SendError
+	if errors.As(err, &sendErr) && sendErr.IsRecipientSuppressed() {
+		dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.EmailSuppressedEventPayload{
+			Description: s.errorToDescription(ctx, err),
+			Recipient:   opts.Recipient,
+		})
+		if dispatchErr != nil {
+			logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.EmailSuppressed)))
+		}
+		return
+	}
+
+	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.EmailErrorEventPayload{
+		Description: s.errorToDescription(ctx, err),
+		Recipient:   opts.Recipient,
+	})
+	if dispatchErr != nil {
+		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.EmailError)))
+	}
+}
+
+func (s *Sender) reportSMSSent(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions, provider config.SMSProvider) {
+	logger := SenderLogger.GetLogger(ctx)
+
+	otelutil.IntCounterAddOne(
+		ctx,
+		otelauthgear.CounterSMSRequestCount,
+		otelauthgear.WithStatusOk(),
+	)
+
+	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.SMSSentEventPayload{
+		Sender:              opts.Sender,
+		Recipient:           opts.To,
+		Type:                string(msgType),
+		IsNotCountedInUsage: *s.MessagingFeatureConfig.SMSUsageCountDisabled,
+		Provider:            string(provider),
+	})
+	if dispatchErr != nil {
+		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.SMSSent)))
+	}
+}
+
+func (s *Sender) reportWhatsappSent(ctx context.Context, msgType translation.MessageType, opts *whatsapp.SendAuthenticationOTPOptions) {
+	logger := SenderLogger.GetLogger(ctx)
+
+	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.WhatsappSentEventPayload{
+		Recipient:           opts.To,
+		Type:                string(msgType),
+		IsNotCountedInUsage: *s.MessagingFeatureConfig.WhatsappUsageCountDisabled,
+	})
+	if dispatchErr != nil {
+		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.WhatsappSent)))
+	}
+}
+
+func (s *Sender) reportWhatsappError(ctx context.Context, opts *whatsapp.SendAuthenticationOTPOptions, err error) {
+	logger := SenderLogger.GetLogger(ctx)
+
+	logger.WithError(err).With(
+		slog.String("phone", phone.Mask(opts.To)),
+	).Error(ctx, "failed to send Whatsapp")
+
+	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.WhatsappErrorEventPayload{
+		Description: s.errorToDescription(ctx, err),
+		Recipient:   opts.To,
+	})
+	if dispatchErr != nil {
+		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.WhatsappError)))
+	}
+}
+
+func (s *Sender) reportSMSError(ctx context.Context, opts *sms.SendOptions, provider config.SMSProvider, err error) {
+	logger := SenderLogger.GetLogger(ctx)
+
+	logger.WithError(err).With(
+		slog.String("phone", phone.Mask(opts.To)),
+		slog.String("provider", string(provider)),
+	).Error(ctx, "failed to send SMS")
+
+	var smsapiErr *smsapi.SendError
+	metricOptions := []otelutil.MetricOption{
+		otelauthgear.WithStatusError(),
+	}
+	if errors.As(err, &smsapiErr) {
+		metricOptions = append(metricOptions, ApplySMSAPIErrorMetrics(smsapiErr)...)
+	}
+
+	otelutil.IntCounterAddOne(
+		ctx,
+		otelauthgear.CounterSMSRequestCount,
+		metricOptions...,
+	)
+
+	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.SMSErrorEventPayload{
+		Description: s.errorToDescription(ctx, err),
+		Recipient:   opts.To,
+		Provider:    string(provider),
+	})
+	if dispatchErr != nil {
+		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.SMSError)))
+	}
+}
+
+// isMessageDeliveryRetryable reports whether a later attempt could succeed.
+// Errors classified by the provider are permanent, except rate limit and timeout.
+func isMessageDeliveryRetryable(err error) bool {
+	var smsapiErr *smsapi.SendError
+	if errors.As(err, &smsapiErr) {
+		if smsapiErr.APIErrorKind == nil {
+			return true
+		}
+		switch smsapiErr.APIErrorKind.Reason {
+		case smsapi.ErrKindRateLimited.Reason, smsapi.ErrKindTimeout.Reason:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var mailapiErr *mailapi.SendError
+	if errors.As(err, &mailapiErr) {
+		if mailapiErr.APIErrorKind == nil {
+			return true
+		}
+		return mailapiErr.APIErrorKind.Reason == mailapi.ErrKindRateLimited.Reason
+	}
+
+	if errors.Is(err, smsapi.ErrNoAvailableClient) || errors.Is(err, mail.ErrNoAvailableSMTPConfiguration) {
+		return false
+	}
+
+	if errors.Is(err, whatsapp.ErrInvalidWhatsappUser) ||
+		errors.Is(err, whatsapp.ErrNoAvailableWhatsappClient) ||
+		errors.Is(err, whatsapp.ErrUnauthorized) ||
+		errors.Is(err, whatsapp.ErrBadRequest) {
+		return false
+	}
+
+	var whatsappErr *whatsapp.WhatsappAPIError
+	if errors.As(err, &whatsappErr) {
+		// Client errors are permanent, except rate limit.
+		code := whatsappErr.HTTPStatusCode
+		isClientError := code >= 400 && code < 500
+		return !isClientError || code == http.StatusTooManyRequests
+	}
+
+	// Network errors, and errors of unknown kinds.
+	return true
+}
+
+func isSMSInvalidPhoneNumber(err error) bool {
+	var smsapiErr *smsapi.SendError
+	return errors.As(err, &smsapiErr) && smsapiErr.APIErrorKind != nil && smsapiErr.APIErrorKind.Reason == smsapi.ErrKindInvalidPhoneNumber.Reason
+}
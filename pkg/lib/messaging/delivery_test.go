@@ -0,0 +1,403 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	. "github.com/smartystreets/goconvey/convey"
+
+	apievent "github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	dbinfra "github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
+	"github.com/authgear/authgear-server/pkg/lib/infra/sms/smsapi"
+	"github.com/authgear/authgear-server/pkg/lib/infra/whatsapp"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/translation"
+	"github.com/authgear/authgear-server/pkg/lib/usage"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/otelutil/oteldatabasesql"
+)
+
+type testEventService struct {
+	payloads []apievent.NonBlockingPayload
+}
+
+func (s *testEventService) DispatchEventImmediately(ctx context.Context, payload apievent.NonBlockingPayload) error {
+	s.payloads = append(s.payloads, payload)
+	return nil
+}
+
+type testFraudProtectionService struct{}
+
+func (testFraudProtectionService) CheckAndRecord(ctx context.Context, phoneNumber, messageType string) error {
+	return nil
+}
+
+type testUsageLimiter struct{}
+
+func (testUsageLimiter) Reserve(ctx context.Context, name model.UsageName, n int) (*usage.Reservation, error) {
+	return nil, nil
+}
+
+func (testUsageLimiter) Cancel(ctx context.Context, r *usage.Reservation) {}
+
+type testRateLimiter struct{}
+
+func (testRateLimiter) Reserve(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.Reservation, *ratelimit.FailedReservation, error) {
+	return &ratelimit.Reservation{}, nil, nil
+}
+
+func (testRateLimiter) Cancel(ctx context.Context, r *ratelimit.Reservation) {}
+
+type testMailSender struct {
+	err  error
+	sent []mail.SendOptions
+}
+
+func (s *testMailSender) ResolveClient() (mailapi.Client, error) {
+	return nil, nil
+}
+
+func (s *testMailSender) Send(ctx context.Context, client mailapi.Client, opts mail.SendOptions) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, opts)
+	return nil
+}
+
+type testSMSClient struct {
+	provider config.SMSProvider
+}
+
+func (c *testSMSClient) Send(ctx context.Context, opts smsapi.SendOptions) error {
+	panic("unreachable")
+}
+
+type testSMSSender struct {
+	providers []config.SMSProvider
+	errs      map[config.SMSProvider]error
+	attempted []config.SMSProvider
+}
+
+func (s *testSMSSender) ResolveFailoverClients() ([]sms.ProviderClient, error) {
+	var clients []sms.ProviderClient
+	for _, p := range s.providers {
+		clients = append(clients, sms.ProviderClient{
+			Provider: p,
+			Client:   &testSMSClient{provider: p},
+		})
+	}
+	return clients, nil
+}
+
+func (s *testSMSSender) Send(ctx context.Context, client smsapi.Client, opts sms.SendOptions) error {
+	provider := client.(*testSMSClient).provider
+	s.attempted = append(s.attempted, provider)
+	return s.errs[provider]
+}
+
+type testWhatsappSender struct {
+	err error
+}
+
+func (s *testWhatsappSender) SendAuthenticationOTP(ctx context.Context, opts *whatsapp.SendAuthenticationOTPOptions) (*whatsapp.SendAuthenticationOTPResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &whatsapp.SendAuthenticationOTPResult{
+		MessageID:     "message-id",
+		MessageStatus: whatsapp.WhatsappMessageStatusSent,
+	}, nil
+}
+
+func (s *testWhatsappSender) SendSuppressedAuthenticationOTP(ctx context.Context, opts *whatsapp.SendAuthenticationOTPOptions) (*whatsapp.SendAuthenticationOTPResult, error) {
+	panic("unreachable")
+}
+
+type testEnqueuedTask struct {
+	request   MessageDeliveryRequest
+	notBefore *time.Time
+}
+
+type testMessageDeliveryProducer struct {
+	tasks []testEnqueuedTask
+}
+
+func (p *testMessageDeliveryProducer) NewTask(appID string, input json.RawMessage, taskIDPrefix string) *redisqueue.Task {
+	return &redisqueue.Task{AppID: appID, Input: input}
+}
+
+func (p *testMessageDeliveryProducer) EnqueueTask(ctx context.Context, task *redisqueue.Task) error {
+	return p.enqueue(task, nil)
+}
+
+func (p *testMessageDeliveryProducer) EnqueueTaskAt(ctx context.Context, task *redisqueue.Task, notBefore time.Time) error {
+	return p.enqueue(task, &notBefore)
+}
+
+func (p *testMessageDeliveryProducer) enqueue(task *redisqueue.Task, notBefore *time.Time) error {
+	var request MessageDeliveryRequest
+	err := json.Unmarshal(task.Input, &request)
+	if err != nil {
+		return err
+	}
+	p.tasks = append(p.tasks, testEnqueuedTask{request: request, notBefore: notBefore})
+	return nil
+}
+
+type testWhatsappCallback struct {
+	outcomes []string
+}
+
+func (c *testWhatsappCallback) OnWhatsappSent(ctx context.Context, state json.RawMessage, result *SendWhatsappResult) error {
+	c.outcomes = append(c.outcomes, "sent:"+string(state)+":"+result.MessageID)
+	return nil
+}
+
+func (c *testWhatsappCallback) OnWhatsappFallbackSMSSent(ctx context.Context, state json.RawMessage) error {
+	c.outcomes = append(c.outcomes, "fallback:"+string(state))
+	return nil
+}
+
+func (c *testWhatsappCallback) OnWhatsappError(ctx context.Context, state json.RawMessage, err error) error {
+	c.outcomes = append(c.outcomes, "error:"+string(state))
+	return nil
+}
+
+type testPool struct {
+	connPool oteldatabasesql.ConnPool_
+}
+
+func (p *testPool) Open(info dbinfra.ConnectionInfo, opts dbinfra.ConnectionOptions) (oteldatabasesql.ConnPool_, error) {
+	return p.connPool, nil
+}
+
+func (p *testPool) Close() error {
+	return nil
+}
+
+func newTestAppDBHandle() *appdb.Handle {
+	connPool, err := oteldatabasesql.Open(oteldatabasesql.OpenOptions{
+		DriverName: "sqlite3",
+		DSN:        ":memory:",
+	})
+	So(err, ShouldBeNil)
+	connPool.SetMaxOpenConns(1)
+
+	return &appdb.Handle{
+		HookHandle: &dbinfra.HookHandle{
+			Pool: &testPool{connPool: connPool},
+		},
+	}
+}
+
+func TestSenderDeliver(t *testing.T) {
+	Convey("Sender.Deliver", t, func() {
+		ctx := context.Background()
+
+		appConfig, err := config.Parse(ctx, []byte("id: test\nhttp:\n  public_origin: http://test\n"))
+		So(err, ShouldBeNil)
+
+		f := false
+		events := &testEventService{}
+		mailSender := &testMailSender{}
+		smsSender := &testSMSSender{
+			providers: []config.SMSProvider{config.SMSProviderTwilio, config.SMSProviderNexmo},
+			errs:      map[config.SMSProvider]error{},
+		}
+		whatsappSender := &testWhatsappSender{}
+		producer := &testMessageDeliveryProducer{}
+		whatsappCallback := &testWhatsappCallback{}
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		sender := &Sender{
+			Limits: Limits{
+				RateLimiter:   testRateLimiter{},
+				UsageLimiter:  testUsageLimiter{},
+				Config:        appConfig,
+				FeatureConfig: config.NewEffectiveDefaultFeatureConfig(),
+				EnvConfig:     &config.RateLimitsEnvironmentConfig{},
+			},
+			Events:           events,
+			FraudProtection:  testFraudProtectionService{},
+			MailSender:       mailSender,
+			SMSSender:        smsSender,
+			WhatsappSender:   whatsappSender,
+			Producer:         producer,
+			WhatsappCallback: whatsappCallback,
+			Database:         newTestAppDBHandle(),
+			Clock:            clk,
+			AppID:            "app-id",
+			MessagingFeatureConfig: &config.MessagingFeatureConfig{
+				SMSUsageCountDisabled:      &f,
+				WhatsappUsageCountDisabled: &f,
+			},
+			TestModeSMSConfig: &config.TestModeSMSConfig{},
+		}
+
+		smsRequest := &MessageDeliveryRequest{
+			Channel:     MessageDeliveryChannelSMS,
+			MessageType: translation.MessageTypeVerification,
+			SMS: &sms.SendOptions{
+				To:   "+85298765432",
+				Body: "123456",
+			},
+		}
+		networkError := errors.New("connection reset by peer")
+
+		Convey("should deliver email", func() {
+			err := sender.Deliver(ctx, &MessageDeliveryRequest{
+				Channel:     MessageDeliveryChannelEmail,
+				MessageType: translation.MessageTypeVerification,
+				Email: &mail.SendOptions{
+					Sender:    "no-reply@example.com",
+					Recipient: "user@example.com",
+				},
+			})
+			So(err, ShouldBeNil)
+			So(mailSender.sent, ShouldHaveLength, 1)
+			So(events.payloads, ShouldHaveLength, 1)
+			So(events.payloads[0], ShouldHaveSameTypeAs, &nonblocking.EmailSentEventPayload{})
+		})
+
+		Convey("should deliver SMS with the first provider", func() {
+			err := sender.Deliver(ctx, smsRequest)
+			So(err, ShouldBeNil)
+			So(smsSender.attempted, ShouldResemble, []config.SMSProvider{config.SMSProviderTwilio})
+			So(events.payloads, ShouldHaveLength, 1)
+			So(events.payloads[0].(*nonblocking.SMSSentEventPayload).Provider, ShouldEqual, "twilio")
+		})
+
+		Convey("should fail over to the next provider", func() {
+			smsSender.errs[config.SMSProviderTwilio] = networkError
+
+			err := sender.Deliver(ctx, smsRequest)
+			So(err, ShouldBeNil)
+			So(smsSender.attempted, ShouldResemble, []config.SMSProvider{config.SMSProviderTwilio, config.SMSProviderNexmo})
+			So(events.payloads, ShouldHaveLength, 1)
+			So(events.payloads[0].(*nonblocking.SMSSentEventPayload).Provider, ShouldEqual, "nexmo")
+			So(producer.tasks, ShouldBeEmpty)
+		})
+
+		Convey("should not fail over an invalid phone number", func() {
+			smsSender.errs[config.SMSProviderTwilio] = &smsapi.SendError{
+				APIErrorKind: &smsapi.ErrKindInvalidPhoneNumber,
+			}
+
+			err := sender.Deliver(ctx, smsRequest)
+			So(err, ShouldNotBeNil)
+			So(smsSender.attempted, ShouldResemble, []config.SMSProvider{config.SMSProviderTwilio})
+			So(producer.tasks, ShouldBeEmpty)
+			So(events.payloads, ShouldHaveLength, 1)
+			So(events.payloads[0].(*nonblocking.SMSErrorEventPayload).Provider, ShouldEqual, "twilio")
+		})
+
+		Convey("should retry if all providers fail with transient errors", func() {
+			smsSender.errs[config.SMSProviderTwilio] = networkError
+			smsSender.errs[config.SMSProviderNexmo] = &smsapi.SendError{
+				APIErrorKind: &smsapi.ErrKindTimeout,
+			}
+
+			err := sender.Deliver(ctx, smsRequest)
+			So(err, ShouldNotBeNil)
+			So(events.payloads, ShouldBeEmpty)
+			So(producer.tasks, ShouldHaveLength, 1)
+			So(producer.tasks[0].request.Attempt, ShouldEqual, 1)
+			So(*producer.tasks[0].notBefore, ShouldEqual, clk.NowUTC().Add(MessageDeliveryRetryBackoff))
+
+			Convey("with exponential backoff", func() {
+				retry := producer.tasks[0].request
+				err := sender.Deliver(ctx, &retry)
+				So(err, ShouldNotBeNil)
+				So(producer.tasks, ShouldHaveLength, 2)
+				So(*producer.tasks[1].notBefore, ShouldEqual, clk.NowUTC().Add(2*MessageDeliveryRetryBackoff))
+			})
+		})
+
+		Convey("should give up after the last attempt", func() {
+			smsSender.errs[config.SMSProviderTwilio] = networkError
+			smsSender.errs[config.SMSProviderNexmo] = networkError
+
+			request := *smsRequest
+			request.Attempt = MessageDeliveryMaxAttempts - 1
+			err := sender.Deliver(ctx, &request)
+			So(err, ShouldNotBeNil)
+			So(producer.tasks, ShouldBeEmpty)
+			So(events.payloads, ShouldHaveLength, 1)
+			So(events.payloads[0], ShouldHaveSameTypeAs, &nonblocking.SMSErrorEventPayload{})
+		})
+
+		Convey("should drop the task of an unknown channel", func() {
+			err := sender.Deliver(ctx, &MessageDeliveryRequest{
+				Channel: "pigeon",
+			})
+			So(errors.Is(err, ErrUnknownMessageDeliveryChannel), ShouldBeTrue)
+			So(producer.tasks, ShouldBeEmpty)
+			So(events.payloads, ShouldBeEmpty)
+		})
+
+		Convey("Whatsapp", func() {
+			whatsappRequest := &MessageDeliveryRequest{
+				Channel:     MessageDeliveryChannelWhatsapp,
+				MessageType: translation.MessageTypeWhatsappCode,
+				Whatsapp: &whatsapp.SendAuthenticationOTPOptions{
+					To:  "+85298765432",
+					OTP: "123456",
+				},
+				WhatsappCallbackState: json.RawMessage(`"state"`),
+			}
+
+			Convey("should tell the callback the message ID", func() {
+				err := sender.Deliver(ctx, whatsappRequest)
+				So(err, ShouldBeNil)
+				So(whatsappCallback.outcomes, ShouldResemble, []string{`sent:"state":message-id`})
+				So(events.payloads[0], ShouldHaveSameTypeAs, &nonblocking.WhatsappSentEventPayload{})
+			})
+
+			Convey("should tell the callback the error", func() {
+				whatsappSender.err = whatsapp.ErrInvalidWhatsappUser
+
+				err := sender.Deliver(ctx, whatsappRequest)
+				So(err, ShouldNotBeNil)
+				So(producer.tasks, ShouldBeEmpty)
+				So(whatsappCallback.outcomes, ShouldResemble, []string{`error:"state"`})
+			})
+
+			Convey("should enqueue the fallback SMS", func() {
+				whatsappSender.err = whatsapp.ErrInvalidWhatsappUser
+				whatsappRequest.WhatsappFallbackSMS = &WhatsappFallbackSMS{
+					MessageType: translation.MessageTypeVerification,
+					SMS:         smsRequest.SMS,
+				}
+
+				err := sender.Deliver(ctx, whatsappRequest)
+				So(err, ShouldBeNil)
+				So(producer.tasks, ShouldHaveLength, 1)
+				So(producer.tasks[0].request.Channel, ShouldEqual, MessageDeliveryChannelSMS)
+				So(producer.tasks[0].notBefore, ShouldBeNil)
+				So(whatsappCallback.outcomes, ShouldResemble, []string{`fallback:"state"`})
+			})
+
+			Convey("should retry a transient error without telling the callback", func() {
+				whatsappSender.err = networkError
+
+				err := sender.Deliver(ctx, whatsappRequest)
+				So(err, ShouldNotBeNil)
+				So(producer.tasks, ShouldHaveLength, 1)
+				So(producer.tasks[0].request.Channel, ShouldEqual, MessageDeliveryChannelWhatsapp)
+				So(whatsappCallback.outcomes, ShouldBeEmpty)
+			})
+		})
+	})
+}
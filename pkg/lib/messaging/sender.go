@@ -3,25 +3,21 @@ package messaging
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
-	"gopkg.in/gomail.v2"
-
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
 	"github.com/authgear/authgear-server/pkg/api/event"
 	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
 	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms/smsapi"
 	"github.com/authgear/authgear-server/pkg/lib/infra/whatsapp"
-	"github.com/authgear/authgear-server/pkg/lib/otelauthgear"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
-	"github.com/authgear/authgear-server/pkg/util/otelutil"
-	"github.com/authgear/authgear-server/pkg/util/phone"
+	"github.com/authgear/authgear-server/pkg/util/clock"
 	"github.com/authgear/authgear-server/pkg/util/slogutil"
 )
 
@@ -36,13 +32,13 @@ type FraudProtectionService interface {
 }
 
 type MailSender interface {
-	PrepareMessage(opts mail.SendOptions) (*gomail.Message, error)
-	Send(*gomail.Message) error
+	Send(ctx context.Context, client mailapi.Client, opts mail.SendOptions) error
+	ResolveClient() (mailapi.Client, error)
 }
 
 type SMSSender interface {
 	Send(ctx context.Context, client smsapi.Client, opts sms.SendOptions) error
-	ResolveClient() (smsapi.Client, error)
+	ResolveFailoverClients() ([]sms.ProviderClient, error)
 }
 
 type WhatsappSender interface {
@@ -51,13 +47,17 @@ type WhatsappSender interface {
 }
 
 type Sender struct {
-	Limits          Limits
-	Events          EventService
-	FraudProtection FraudProtectionService
-	MailSender      MailSender
-	SMSSender       SMSSender
-	WhatsappSender  WhatsappSender
-	Database        *appdb.Handle
+	Limits           Limits
+	Events           EventService
+	FraudProtection  FraudProtectionService
+	MailSender       MailSender
+	SMSSender        SMSSender
+	WhatsappSender   WhatsappSender
+	Producer         MessageDeliveryProducer
+	WhatsappCallback WhatsappDeliveryCallback
+	Database         *appdb.Handle
+	Clock            clock.Clock
+	AppID            config.AppID
 
 	DevMode config.DevMode
 
@@ -78,10 +78,8 @@ type SendWhatsappResult struct {
 	MessageStatus whatsapp.WhatsappMessageStatus
 }
 
-type SendWhatsappResultCallback func(ctx context.Context, result *SendWhatsappResult)
-type SendWhatsappErrorCallback func(ctx context.Context, err error)
-
-func (s *Sender) SendEmailInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error {
+// SendEmailAsync enqueues the email to be delivered by the message delivery queue.
+func (s *Sender) SendEmailAsync(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error {
 	if s.TestModeEmailConfig.Enabled {
 		if r, ok := s.TestModeEmailConfig.MatchTarget(opts.Recipient); ok && r.Suppressed {
 			return s.testModeSendEmail(ctx, msgType, opts)
@@ -101,64 +99,22 @@ func (s *Sender) SendEmailInNewGoroutine(ctx context.Context, msgType translatio
 		return s.devModeSendEmail(ctx, msgType, opts)
 	}
 
-	message, err := s.MailSender.PrepareMessage(*opts)
+	err = opts.Validate()
 	if err != nil {
 		return err
 	}
 
-	sendInTx := func(ctx context.Context) error {
-		logger := SenderLogger.GetLogger(ctx)
-		err := s.MailSender.Send(message)
-		if err != nil {
-			// Log the send error immediately.
-			logger.WithError(err).With(
-				slog.String("email", mail.MaskAddress(opts.Recipient)),
-			).Error(ctx, "failed to send email")
-
-			otelutil.IntCounterAddOne(
-				ctx,
-				otelauthgear.CounterEmailRequestCount,
-				otelauthgear.WithStatusError(),
-			)
-
-			dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.EmailErrorEventPayload{
-				Description: s.errorToDescription(ctx, err),
-				Recipient:   opts.Recipient,
-			})
-			if dispatchErr != nil {
-				logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.EmailError)))
-			}
-			return err
-		}
-
-		otelutil.IntCounterAddOne(
-			ctx,
-			otelauthgear.CounterEmailRequestCount,
-			otelauthgear.WithStatusOk(),
-		)
-
-		dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.EmailSentEventPayload{
-			Sender:    opts.Sender,
-			Recipient: opts.Recipient,
-			Type:      string(msgType),
-		})
-		if dispatchErr != nil {
-			logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.EmailSent)))
-		}
-		return nil
+	// Fail early if no provider is configured.
+	_, err = s.MailSender.ResolveClient()
+	if err != nil {
+		return err
 	}
 
-	// Detach the deadline so that the context is not canceled along with the request.
-	ctxWithoutCancel := context.WithoutCancel(ctx)
-	go func(ctx context.Context) {
-		// Always use a new transaction to send in async routine
-		// No need to handle the error as sendInTx is assumed to have handle it by logging.
-		_ = s.Database.ReadOnly(ctx, func(ctx context.Context) error {
-			return sendInTx(ctx)
-		})
-	}(ctxWithoutCancel)
-
-	return nil
+	return s.enqueueMessageDelivery(ctx, &MessageDeliveryRequest{
+		Channel:     MessageDeliveryChannelEmail,
+		MessageType: msgType,
+		Email:       opts,
+	})
 }
 
 func (s *Sender) testModeSendEmail(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error {
@@ -199,7 +155,8 @@ func (s *Sender) devModeSendEmail(ctx context.Context, msgType translation.Messa
 	})
 }
 
-func (s *Sender) SendSMSInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions) error {
+// SendSMSAsync enqueues the SMS to be delivered by the message delivery queue.
+func (s *Sender) SendSMSAsync(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions) error {
 	return s.sendSMS(ctx, msgType, opts, true)
 }
 
@@ -208,8 +165,6 @@ func (s *Sender) SendSMSImmediately(ctx context.Context, msgType translation.Mes
 }
 
 func (s *Sender) sendSMS(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions, isAsync bool) error {
-	logger := SenderLogger.GetLogger(ctx)
-
 	if s.TestModeSMSConfig.Enabled {
 		if r, ok := s.TestModeSMSConfig.MatchTarget(opts.To); ok && r.Suppressed {
 			return s.testModeSendSMS(ctx, msgType, opts)
@@ -234,80 +189,27 @@ func (s *Sender) sendSMS(ctx context.Context, msgType translation.MessageType, o
 		return s.devModeSendSMS(ctx, msgType, opts)
 	}
 
-	client, err := s.SMSSender.ResolveClient()
+	// Fail early if no provider is configured.
+	_, err = s.SMSSender.ResolveFailoverClients()
 	if err != nil {
 		return err
 	}
 
-	sendInTx := func(ctx context.Context) error {
-		logger := SenderLogger.GetLogger(ctx)
-
-		err = s.SMSSender.Send(ctx, client, *opts)
-		if err != nil {
-			// Log the send error immediately.
-			logger.WithError(err).With(
-				slog.String("phone", phone.Mask(opts.To)),
-			).Error(ctx, "failed to send SMS")
-
-			var smsapiErr *smsapi.SendError
-			metricOptions := []otelutil.MetricOption{
-				otelauthgear.WithStatusError(),
-			}
-			if errors.As(err, &smsapiErr) {
-				metricOptions = append(metricOptions, ApplySMSAPIErrorMetrics(smsapiErr)...)
-			}
-
-			otelutil.IntCounterAddOne(
-				ctx,
-				otelauthgear.CounterSMSRequestCount,
-				metricOptions...,
-			)
-
-			dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.SMSErrorEventPayload{
-				Description: s.errorToDescription(ctx, err),
-				Recipient:   opts.To,
-			})
-			if dispatchErr != nil {
-				logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.SMSError)))
-			}
-			return err
-		}
-		return nil
-	}
-
 	if isAsync {
-		// Detach the deadline so that the context is not canceled along with the request.
-		ctxWithoutCancel := context.WithoutCancel(ctx)
-		go func(ctx context.Context) {
-			// Always use a new transaction to send in async routine
-			// No need to handle the error as sendInTx is assumed to have handle it by logging.
-			_ = s.Database.ReadOnly(ctx, func(ctx context.Context) error {
-				return sendInTx(ctx)
-			})
-		}(ctxWithoutCancel)
-	} else {
-		err = sendInTx(ctx)
-		if err != nil {
-			return err
-		}
+		return s.enqueueMessageDelivery(ctx, &MessageDeliveryRequest{
+			Channel:     MessageDeliveryChannelSMS,
+			MessageType: msgType,
+			SMS:         opts,
+		})
 	}
 
-	otelutil.IntCounterAddOne(
-		ctx,
-		otelauthgear.CounterSMSRequestCount,
-		otelauthgear.WithStatusOk(),
-	)
-
-	dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.SMSSentEventPayload{
-		Sender:              opts.Sender,
-		Recipient:           opts.To,
-		Type:                string(msgType),
-		IsNotCountedInUsage: *s.MessagingFeatureConfig.SMSUsageCountDisabled,
-	})
-	if dispatchErr != nil {
-		logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.SMSSent)))
+	provider, err := s.deliverSMS(ctx, opts)
+	if err != nil {
+		s.reportSMSError(ctx, opts, provider, err)
+		return err
 	}
 
+	s.reportSMSSent(ctx, msgType, opts, provider)
 	return nil
 }
 
@@ -351,85 +253,40 @@ func (s *Sender) devModeSendSMS(ctx context.Context, msgType translation.Message
 	})
 }
 
-func (s *Sender) SendWhatsappInNewGoroutine(ctx context.Context, msgType translation.MessageType, opts *whatsapp.SendAuthenticationOTPOptions, resultCallback SendWhatsappResultCallback, errorCallback SendWhatsappErrorCallback) error {
-	logger := SenderLogger.GetLogger(ctx)
-
+// SendWhatsappAsync enqueues the Whatsapp message to be delivered by the message delivery queue.
+// The outcome is told to WhatsappCallback with callbackState.
+// If the message is suppressed, the result is returned immediately instead.
+func (s *Sender) SendWhatsappAsync(ctx context.Context, msgType translation.MessageType, opts *whatsapp.SendAuthenticationOTPOptions, fallbackSMS *WhatsappFallbackSMS, callbackState json.RawMessage) (*SendWhatsappResult, error) {
 	if s.TestModeWhatsappConfig.Enabled {
 		if r, ok := s.TestModeWhatsappConfig.MatchTarget(opts.To); ok && r.Suppressed {
-			result, err := s.testModeSendWhatsapp(ctx, msgType, opts)
-			if err != nil {
-				return err
-			}
-			resultCallback(ctx, result)
-			return nil
+			return s.testModeSendWhatsapp(ctx, msgType, opts)
 		}
 	}
 
 	err := s.Limits.checkWhatsapp(ctx, opts.To)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if s.FeatureTestModeWhatsappSuppressed {
-		result, err := s.testModeSendWhatsapp(ctx, msgType, opts)
-		if err != nil {
-			return err
-		}
-		resultCallback(ctx, result)
-		return nil
+		return s.testModeSendWhatsapp(ctx, msgType, opts)
 	}
 
 	if s.DevMode {
-		result, err := s.devModeSendWhatsapp(ctx, msgType, opts)
-		if err != nil {
-			return err
-		}
-		resultCallback(ctx, result)
-		return nil
+		return s.devModeSendWhatsapp(ctx, msgType, opts)
 	}
 
-	sendSync := func(ctx context.Context) error {
-		result, err := s.sendWhatsapp(ctx, opts)
-		if err != nil {
-			// Log the send error immediately.
-			logger.WithError(err).With(
-				slog.String("phone", phone.Mask(opts.To)),
-			).Error(ctx, "failed to send Whatsapp")
-
-			dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.WhatsappErrorEventPayload{
-				Description: s.errorToDescription(ctx, err),
-				Recipient:   opts.To,
-			})
-			if dispatchErr != nil {
-				logger.WithError(dispatchErr).Error(ctx, "failed to emit event", slog.String("event", string(nonblocking.WhatsappError)))
-			}
-
-			return err
-		}
-
-		dispatchErr := s.DispatchEventImmediatelyWithTx(ctx, &nonblocking.WhatsappSentEventPayload{
-			Recipient:           opts.To,
-			Type:                string(msgType),
-			IsNotCountedInUsage: *s.MessagingFeatureConfig.WhatsappUsageCountDisabled,
-		})
-		if dispatchErr != nil {
-			logger.WithError(dispatchErr).Error(ctx, "failed to emit %v event", slog.String("event", string(nonblocking.WhatsappSent)))
-		}
-
-		resultCallback(ctx, result)
-		return nil
+	err = s.enqueueMessageDelivery(ctx, &MessageDeliveryRequest{
+		Channel:               MessageDeliveryChannelWhatsapp,
+		MessageType:           msgType,
+		Whatsapp:              opts,
+		WhatsappFallbackSMS:   fallbackSMS,
+		WhatsappCallbackState: callbackState,
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	// Send in new goroutine.
-	ctxWithoutCancel := context.WithoutCancel(ctx)
-	go func(ctx context.Context) {
-		err = sendSync(ctx)
-		if err != nil {
-			errorCallback(ctx, err)
-		}
-	}(ctxWithoutCancel)
-
-	return nil
+	return nil, nil
 }
 
 func (s *Sender) sendWhatsapp(ctx context.Context, opts *whatsapp.SendAuthenticationOTPOptions) (*SendWhatsappResult, error) {
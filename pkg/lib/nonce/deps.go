@@ -4,4 +4,5 @@ import "github.com/google/wire"
 
 var DependencySet = wire.NewSet(
 	wire.Struct(new(Service), "*"),
+	wire.Struct(new(Store), "*"),
 )
@@ -0,0 +1,46 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis/appredis"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+var ErrNonceReused = errors.New("nonce has been used")
+
+// Store records nonces that can be used once only, for example, the jti of a JWT.
+type Store struct {
+	AppID config.AppID
+	Redis *appredis.Handle
+	Clock clock.Clock
+}
+
+// Consume marks nonce in scope as used until expireAt.
+// ErrNonceReused is returned if nonce has been used before.
+func (s *Store) Consume(ctx context.Context, scope string, nonce string, expireAt time.Time) error {
+	ttl := expireAt.Sub(s.Clock.NowUTC())
+	if ttl <= 0 {
+		return ErrNonceReused
+	}
+
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		ok, err := conn.SetNX(ctx, nonceKey(string(s.AppID), scope, nonce), 1, ttl).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNonceReused
+		}
+		return nil
+	})
+}
+
+func nonceKey(appID string, scope string, nonce string) string {
+	return fmt.Sprintf("app:%s:nonce:%s:%s", appID, scope, nonce)
+}
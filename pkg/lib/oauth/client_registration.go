@@ -0,0 +1,98 @@
+package oauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+const (
+	// InitialAccessTokenDefaultLifetime is the lifetime of an initial access token
+	// when the Admin API does not specify one.
+	InitialAccessTokenDefaultLifetime = 24 * time.Hour
+	// InitialAccessTokenMaxLifetime bounds the lifetime of an initial access token.
+	InitialAccessTokenMaxLifetime = 30 * 24 * time.Hour
+)
+
+// InitialAccessToken authorizes a single call to the client registration endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-3
+type InitialAccessToken struct {
+	AppID     string `json:"app_id"`
+	TokenHash string `json:"token_hash"`
+
+	CreatedAt time.Time `json:"created_at"`
+	ExpireAt  time.Time `json:"expire_at"`
+}
+
+// ClientRegistration records a client created by the client registration endpoint.
+// The registration access token authorizes the client to manage itself
+// at the client configuration endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc7592#section-3
+type ClientRegistration struct {
+	AppID                       string    `json:"app_id"`
+	ClientID                    string    `json:"client_id"`
+	RegistrationAccessTokenHash string    `json:"registration_access_token_hash"`
+	CreatedAt                   time.Time `json:"created_at"`
+}
+
+type InitialAccessTokenService struct {
+	AppID config.AppID
+	Store InitialAccessTokenStore
+	Clock clock.Clock
+}
+
+// CreateInitialAccessToken returns a new initial access token.
+// The token is only shown once; only its hash is stored.
+func (s *InitialAccessTokenService) CreateInitialAccessToken(ctx context.Context, lifetime time.Duration) (string, *InitialAccessToken, error) {
+	if lifetime <= 0 {
+		lifetime = InitialAccessTokenDefaultLifetime
+	}
+	if lifetime > InitialAccessTokenMaxLifetime {
+		lifetime = InitialAccessTokenMaxLifetime
+	}
+
+	token := GenerateToken()
+	now := s.Clock.NowUTC()
+	t := &InitialAccessToken{
+		AppID:     string(s.AppID),
+		TokenHash: HashToken(token),
+		CreatedAt: now,
+		ExpireAt:  now.Add(lifetime),
+	}
+
+	err := s.Store.CreateInitialAccessToken(ctx, t)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, t, nil
+}
+
+type RegistrationAccessTokenService struct {
+	AppID config.AppID
+	Store ClientRegistrationStore
+	Clock clock.Clock
+}
+
+// ResetRegistrationAccessToken issues a new registration access token to the client,
+// and revokes the previous one.
+// The developer uses it when the registration access token is lost,
+// or when the registration is lost in Redis.
+func (s *RegistrationAccessTokenService) ResetRegistrationAccessToken(ctx context.Context, clientID string) (string, error) {
+	token := GenerateToken()
+	r := &ClientRegistration{
+		AppID:                       string(s.AppID),
+		ClientID:                    clientID,
+		RegistrationAccessTokenHash: HashToken(token),
+		CreatedAt:                   s.Clock.NowUTC(),
+	}
+
+	err := s.Store.CreateClientRegistration(ctx, r)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
@@ -22,6 +22,9 @@ var DependencySet = wire.NewSet(
 
 	wire.Struct(new(AccessGrantService), "*"),
 
+	wire.Struct(new(InitialAccessTokenService), "*"),
+	wire.Struct(new(RegistrationAccessTokenService), "*"),
+
 	wire.Bind(new(PreAuthenticatedURLTokenAccessGrantService), new(*AccessGrantService)),
 	wire.Bind(new(PreAuthenticatedURLTokenOfflineGrantService), new(*OfflineGrantService)),
 )
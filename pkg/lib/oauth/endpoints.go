@@ -7,4 +7,9 @@ type EndpointsProvider interface {
 	ConsentEndpointURL() *url.URL
 	TokenEndpointURL() *url.URL
 	RevokeEndpointURL() *url.URL
+	IntrospectEndpointURL() *url.URL
+	DeviceAuthorizationEndpointURL() *url.URL
+	PushedAuthorizationRequestEndpointURL() *url.URL
+	RegistrationEndpointURL() *url.URL
+	BackchannelAuthenticationEndpointURL() *url.URL
 }
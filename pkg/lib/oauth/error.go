@@ -7,3 +7,5 @@ var ErrAuthorizationScopesNotGranted = errors.New("oauth authorization scopes no
 var ErrGrantNotFound = errors.New("oauth grant not found")
 var ErrUnmatchedClient = errors.New("unmatched client ID")
 var ErrUnmatchedSession = errors.New("unmatched session ID")
+var ErrUserCodeCollision = errors.New("oauth device grant user code collision")
+var ErrGrantConflict = errors.New("oauth grant was updated concurrently")
@@ -0,0 +1,47 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+type CIBAGrantStatus string
+
+const (
+	CIBAGrantStatusPending  CIBAGrantStatus = "pending"
+	CIBAGrantStatusApproved CIBAGrantStatus = "approved"
+	CIBAGrantStatusDenied   CIBAGrantStatus = "denied"
+)
+
+// CIBAGrant is the state of a Client-Initiated Backchannel Authentication request.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html
+type CIBAGrant struct {
+	AppID          string   `json:"app_id"`
+	ClientID       string   `json:"client_id"`
+	UserID         string   `json:"user_id"`
+	AuthReqIDHash  string   `json:"auth_req_id_hash"`
+	Scopes         []string `json:"scopes"`
+	BindingMessage string   `json:"binding_message,omitempty"`
+
+	// ApprovalCodeHash identifies the grant in the link sent to the user.
+	ApprovalCodeHash string `json:"approval_code_hash"`
+
+	DeliveryMode config.OAuthClientBackchannelTokenDeliveryMode `json:"delivery_mode"`
+	// The following fields are set when DeliveryMode is ping.
+	// The notification sent to the client carries auth_req_id.
+	AuthReqID               string `json:"auth_req_id,omitempty"`
+	ClientNotificationToken string `json:"client_notification_token,omitempty"`
+
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpireAt     time.Time  `json:"expire_at"`
+	Interval     int        `json:"interval"`
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+
+	Status CIBAGrantStatus `json:"status"`
+
+	// The following fields are set when Status is approved.
+	AuthorizationID    string               `json:"authz_id,omitempty"`
+	AuthenticationInfo authenticationinfo.T `json:"authentication_info,omitzero"`
+}
@@ -0,0 +1,74 @@
+package oauth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/util/rand"
+)
+
+const (
+	// userCodeAlphabet excludes vowels and easily confused characters.
+	// See https://datatracker.ietf.org/doc/html/rfc8628#section-6.1
+	userCodeAlphabet string = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   int    = 8
+)
+
+type DeviceGrantStatus string
+
+const (
+	DeviceGrantStatusPending  DeviceGrantStatus = "pending"
+	DeviceGrantStatusApproved DeviceGrantStatus = "approved"
+	DeviceGrantStatusDenied   DeviceGrantStatus = "denied"
+)
+
+// DeviceGrant is the state of an OAuth 2.0 Device Authorization Grant.
+// See https://datatracker.ietf.org/doc/html/rfc8628
+type DeviceGrant struct {
+	AppID          string   `json:"app_id"`
+	ClientID       string   `json:"client_id"`
+	DeviceCodeHash string   `json:"device_code_hash"`
+	UserCodeHash   string   `json:"user_code_hash"`
+	Scopes         []string `json:"scopes"`
+
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpireAt     time.Time  `json:"expire_at"`
+	Interval     int        `json:"interval"`
+	LastPolledAt *time.Time `json:"last_polled_at,omitempty"`
+
+	Status DeviceGrantStatus `json:"status"`
+
+	// The following fields are set when Status is approved.
+	AuthorizationID    string               `json:"authz_id,omitempty"`
+	AuthenticationInfo authenticationinfo.T `json:"authentication_info,omitzero"`
+	IdentitySpecs      []*identity.Spec     `json:"identity_specs,omitzero"`
+
+	// Revision is incremented on every update.
+	// An update is rejected if the grant has been updated since it was read.
+	Revision int `json:"revision,omitempty"`
+}
+
+// GenerateUserCode generates a user code in the form of XXXX-XXXX.
+func GenerateUserCode() string {
+	code := rand.StringWithAlphabet(userCodeLength, userCodeAlphabet, rand.SecureRand)
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+// NormalizeUserCode removes the dash and whitespaces, and uppercases the input,
+// so that the user can type the user code in a relaxed way.
+func NormalizeUserCode(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func HashUserCode(userCode string) string {
+	return HashToken(NormalizeUserCode(userCode))
+}
@@ -0,0 +1,25 @@
+package oauth
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUserCode(t *testing.T) {
+	Convey("GenerateUserCode", t, func() {
+		code := GenerateUserCode()
+		So(code, ShouldHaveLength, 9)
+		So(code[4], ShouldEqual, '-')
+		for _, r := range NormalizeUserCode(code) {
+			So(strings.ContainsRune(userCodeAlphabet, r), ShouldBeTrue)
+		}
+	})
+
+	Convey("NormalizeUserCode", t, func() {
+		So(NormalizeUserCode("BCDF-GHJK"), ShouldEqual, "BCDFGHJK")
+		So(NormalizeUserCode("bcdf ghjk"), ShouldEqual, "BCDFGHJK")
+		So(HashUserCode("bcdf-ghjk"), ShouldEqual, HashUserCode("BCDFGHJK"))
+	})
+}
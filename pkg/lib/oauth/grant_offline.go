@@ -54,6 +54,9 @@ type OfflineGrant struct {
 	AccessInfo access.Info   `json:"access_info"`
 
 	DeviceInfo map[string]any `json:"device_info,omitempty"`
+	// DeviceName is the name given to the device by the user.
+	// It takes precedence over the name derived from DeviceInfo.
+	DeviceName string `json:"device_name,omitempty"`
 
 	SSOEnabled bool `json:"sso_enabled,omitempty"`
 
@@ -115,10 +118,12 @@ func (o *OfflineGrantSession) SSOGroupIDPSessionID() string {
 }
 func (o *OfflineGrantSession) CreateNewAuthenticationInfoByThisSession() authenticationinfo.T {
 	amr, _ := o.OfflineGrant.GetOIDCAMR()
+	orgID, _ := o.OfflineGrant.Attrs.GetOrganizationID()
 	return authenticationinfo.T{
 		UserID:                     o.OfflineGrant.GetUserID(),
 		AuthenticatedAt:            o.OfflineGrant.GetAuthenticatedAt(),
 		AMR:                        amr,
+		OrganizationID:             orgID,
 		AuthenticatedBySessionType: string(o.SessionType()),
 		AuthenticatedBySessionID:   o.SessionID(),
 	}
@@ -168,7 +173,9 @@ func (g *OfflineGrant) ToAPIModel() *model.Session {
 	var displayName string
 	formattedDeviceInfo := deviceinfo.DeviceModel(g.DeviceInfo)
 	ua := model.ParseUserAgent(g.AccessInfo.LastAccess.UserAgent)
-	if formattedDeviceInfo != "" {
+	if g.DeviceName != "" {
+		displayName = g.DeviceName
+	} else if formattedDeviceInfo != "" {
 		displayName = formattedDeviceInfo
 	} else {
 		displayName = ua.Format()
@@ -208,10 +215,12 @@ func (g *OfflineGrant) ToAPIModel() *model.Session {
 
 func (g *OfflineGrant) GetAuthenticationInfo() authenticationinfo.T {
 	amr, _ := g.GetOIDCAMR()
+	orgID, _ := g.Attrs.GetOrganizationID()
 	return authenticationinfo.T{
 		UserID:          g.GetUserID(),
 		AuthenticatedAt: g.GetAuthenticatedAt(),
 		AMR:             amr,
+		OrganizationID:  orgID,
 	}
 }
 
@@ -304,6 +304,25 @@ func (s *OfflineGrantService) AddSAMLServiceProviderParticipant(
 	return newGrant, nil
 }
 
+func (s *OfflineGrantService) UpdateDeviceName(
+	ctx context.Context,
+	grant *OfflineGrant,
+	deviceName string,
+) (*OfflineGrant, error) {
+	expiry, err := s.ComputeOfflineGrantExpiry(grant)
+	if err != nil {
+		return nil, err
+	}
+	newGrant, err := s.OfflineGrants.UpdateOfflineGrantWithMutator(ctx, grant.ID, expiry, func(g *OfflineGrant) *OfflineGrant {
+		g.DeviceName = deviceName
+		return g
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newGrant, nil
+}
+
 func (s *OfflineGrantService) housekeepOfflineGrant(ctx context.Context, grant *OfflineGrant) (*OfflineGrant, error) {
 	now := s.Clock.NowUTC()
 
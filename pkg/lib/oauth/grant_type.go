@@ -9,6 +9,9 @@ const (
 	RefreshTokenGrantType      = "refresh_token"
 	ClientCredentialsGrantType = "client_credentials"
 	// nolint:gosec
+	DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	CIBAGrantType       = "urn:openid:params:grant-type:ciba"
+	// nolint:gosec
 	TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
 
 	AnonymousRequestGrantType = "urn:authgear:params:oauth:grant-type:anonymous-request"
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+// ClientAssertionTypeJWTBearer is the only supported client_assertion_type.
+// See https://datatracker.ietf.org/doc/html/rfc7523#section-2.2
+const ClientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+type ClientAuthenticator interface {
+	AuthenticatePrivateKeyJWT(ctx context.Context, req *http.Request, client *config.OAuthClientConfig, clientAssertionType string, clientAssertion string) error
+	AuthenticateSelfSignedTLSClient(ctx context.Context, req *http.Request, client *config.OAuthClientConfig) error
+}
+
+type clientAuthenticatedContextKeyType struct{}
+
+var clientAuthenticatedContextKey = clientAuthenticatedContextKeyType{}
+
+func withClientAuthenticated(ctx context.Context) context.Context {
+	return context.WithValue(ctx, clientAuthenticatedContextKey, true)
+}
+
+// isClientAuthenticated reports whether the client has authenticated with
+// private_key_jwt or self_signed_tls_client_auth in this request.
+// In that case, the client secret is not required.
+func isClientAuthenticated(ctx context.Context) bool {
+	authenticated, _ := ctx.Value(clientAuthenticatedContextKey).(bool)
+	return authenticated
+}
+
+func newInvalidClientError(description string) error {
+	return protocol.NewErrorStatusCode("invalid_client", description, http.StatusUnauthorized)
+}
+
+// applyClientAssertion copies the subject of client_assertion into form,
+// since client_id is optional when the client authenticates with private_key_jwt.
+// See https://datatracker.ietf.org/doc/html/rfc7521#section-4.2
+func applyClientAssertion(form url.Values) error {
+	clientAssertion := form.Get("client_assertion")
+	if clientAssertion == "" || form.Get("client_id") != "" {
+		return nil
+	}
+
+	_, token, err := jwtutil.SplitWithoutVerify([]byte(clientAssertion))
+	if err != nil {
+		return newInvalidClientError("invalid client_assertion")
+	}
+
+	form["client_id"] = []string{token.Subject()}
+	return nil
+}
+
+// authenticateClientWithKeys authenticates the client with private_key_jwt or self_signed_tls_client_auth,
+// if the client is configured to do so.
+// The returned context is marked as client authenticated on success.
+// Otherwise, the context is returned as is, and the caller should authenticate the client with client secret.
+func authenticateClientWithKeys(
+	ctx context.Context,
+	authenticator ClientAuthenticator,
+	req *http.Request,
+	client *config.OAuthClientConfig,
+	form url.Values,
+) (context.Context, error) {
+	clientAssertion := form.Get("client_assertion")
+
+	var err error
+	switch client.TokenEndpointAuthMethod {
+	case config.OAuthClientAuthMethodPrivateKeyJWT:
+		if form.Get("client_secret") != "" {
+			return ctx, newInvalidClientError("client_secret is not allowed for the client")
+		}
+		if clientAssertion == "" {
+			return ctx, newInvalidClientError("client_assertion is required")
+		}
+		err = authenticator.AuthenticatePrivateKeyJWT(ctx, req, client, form.Get("client_assertion_type"), clientAssertion)
+	case config.OAuthClientAuthMethodSelfSignedTLSClientAuth:
+		if form.Get("client_secret") != "" || clientAssertion != "" {
+			return ctx, newInvalidClientError("only client certificate is allowed for the client")
+		}
+		err = authenticator.AuthenticateSelfSignedTLSClient(ctx, req, client)
+	default:
+		if clientAssertion != "" {
+			return ctx, newInvalidClientError("client_assertion is not allowed for the client")
+		}
+		return ctx, nil
+	}
+	if err != nil {
+		return ctx, err
+	}
+
+	return withClientAuthenticated(ctx), nil
+}
+
+// applyClientSecretBasic copies the client credentials in the Authorization
+// header into form, unless the client has authenticated in the request body.
+//
+// Prefer client authentication in the request body over the Authorization
+// header: an Authorization header may come from an unrelated proxy (e.g.
+// one protecting a staging environment with HTTP Basic auth) rather than
+// the client itself, so it must not take precedence over, or conflict
+// with, credentials the client actually placed in the body.
+func applyClientSecretBasic(req *http.Request, form url.Values) error {
+	if form.Get("client_id") != "" || form.Get("client_secret") != "" {
+		return nil
+	}
+
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		return nil
+	}
+
+	// RFC 6749 Appendix B: client_id and client_secret are encoded with
+	// application/x-www-form-urlencoded before being used in HTTP Basic auth.
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return protocol.NewError("invalid_request", "invalid client_id in Authorization header")
+	}
+	clientSecret, err := url.QueryUnescape(password)
+	if err != nil {
+		return protocol.NewError("invalid_request", "invalid client_secret in Authorization header")
+	}
+
+	form["client_id"] = []string{clientID}
+	form["client_secret"] = []string{clientSecret}
+	return nil
+}
+
+func validateClientSecret(credentials *config.OAuthClientCredentials, client *config.OAuthClientConfig, clientSecret string) (maskedSecret string, err error) {
+	credentialsItem, ok := credentials.Lookup(client.ClientID)
+	if !ok {
+		return "", protocol.NewError("invalid_request", "client secret is not supported for the client")
+	}
+
+	keys := credentialsItem.Keys()
+	for _, secret := range keys {
+		if subtle.ConstantTimeCompare([]byte(clientSecret), secret.Key) == 1 {
+			maskedSecret = secret.Mask()
+			break
+		}
+	}
+	if maskedSecret == "" {
+		return "", protocol.NewError("invalid_request", "invalid client secret")
+	}
+
+	return maskedSecret, nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+// clientJWKSMaxSize is the maximum size of the response of jwks_uri.
+const clientJWKSMaxSize = 256 * 1024
+
+// clientJWKSCache caches the key sets fetched from jwks_uri, keyed by the URI.
+// A client rotating its keys has to wait for the cached key set to expire.
+var clientJWKSCache = cache.New(5*time.Minute, 10*time.Minute)
+
+// ClientJWKSHTTPClient fetches the jwks_uri of clients.
+// jwks_uri is supplied by the client, so only public addresses are connected.
+type ClientJWKSHTTPClient struct {
+	*http.Client
+}
+
+func NewClientJWKSHTTPClient() ClientJWKSHTTPClient {
+	return ClientJWKSHTTPClient{
+		httputil.NewExternalClientWithOptions(5*time.Second, httputil.ExternalClientOptions{
+			Transport: httputil.NewPublicNetworkTransport(),
+		}),
+	}
+}
+
+// FetchJWKS returns the key set at uri.
+func (c ClientJWKSHTTPClient) FetchJWKS(ctx context.Context, uri string) (jwk.Set, error) {
+	if cached, ok := clientJWKSCache.Get(uri); ok {
+		return cached.(jwk.Set), nil
+	}
+
+	resp, err := httputil.GetWithContext(ctx, c.Client, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %v", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, clientJWKSMaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > clientJWKSMaxSize {
+		return nil, fmt.Errorf("jwks is larger than %v bytes", clientJWKSMaxSize)
+	}
+
+	set, err := jwk.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	clientJWKSCache.Set(uri, set, cache.DefaultExpiration)
+	return set, nil
+}
@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+)
+
+func TestClientJWKSHTTPClient(t *testing.T) {
+	Convey("ClientJWKSHTTPClient", t, func() {
+		ctx := context.Background()
+		jwks := `{"keys":[{"kty":"oct","kid":"mykey","k":"c2VjcmV0"}]}`
+
+		// The test server listens on the loopback address,
+		// which the client from NewClientJWKSHTTPClient refuses to connect.
+		c := handler.ClientJWKSHTTPClient{Client: &http.Client{}}
+
+		Convey("should fetch and cache the jwks", func() {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				_, _ = w.Write([]byte(jwks))
+			}))
+			defer server.Close()
+
+			set, err := c.FetchJWKS(ctx, server.URL)
+			So(err, ShouldBeNil)
+			So(set.Len(), ShouldEqual, 1)
+
+			set, err = c.FetchJWKS(ctx, server.URL)
+			So(err, ShouldBeNil)
+			So(set.Len(), ShouldEqual, 1)
+			So(requests, ShouldEqual, 1)
+		})
+
+		Convey("should reject non-200 response", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			_, err := c.FetchJWKS(ctx, server.URL)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should reject response that is too large", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"keys":[],"padding":"` + strings.Repeat("a", 256*1024) + `"}`))
+			}))
+			defer server.Close()
+
+			_, err := c.FetchJWKS(ctx, server.URL)
+			So(err, ShouldBeError, "jwks is larger than 262144 bytes")
+		})
+
+		Convey("should refuse to connect to non-public address", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(jwks))
+			}))
+			defer server.Close()
+
+			_, err := handler.NewClientJWKSHTTPClient().FetchJWKS(ctx, server.URL+"/public")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -8,10 +8,30 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(AuthorizationHandler), "*"),
 	wire.Struct(new(TokenHandler), "*"),
 	wire.Struct(new(RevokeHandler), "*"),
+	wire.Struct(new(IntrospectHandler), "*"),
+	wire.Struct(new(DeviceAuthorizationHandler), "*"),
+	wire.Struct(new(PushedAuthorizationHandler), "*"),
+	wire.Struct(new(BackchannelAuthenticationHandler), "*"),
+	wire.Struct(new(CIBAMessageSender), "*"),
+	wire.Bind(new(BackchannelAuthenticationHandlerMessageSender), new(*CIBAMessageSender)),
+	wire.Struct(new(ClientRegistrationHandler), "*"),
+	wire.Struct(new(ClientRegistrationService), "*"),
+	wire.Bind(new(ClientRegistrationHandlerService), new(*ClientRegistrationService)),
 	wire.Struct(new(AnonymousUserHandler), "*"),
 	wire.Struct(new(TokenService), "*"),
 	wire.Struct(new(CodeGrantService), "*"),
 	wire.Struct(new(SettingsActionGrantService), "*"),
+	wire.Struct(new(DeviceGrantService), "*"),
+	wire.Struct(new(CIBAGrantService), "*"),
+	NewCIBANotificationHTTPClient,
+	wire.Struct(new(CIBANotificationDeliverer), "*"),
+	wire.Struct(new(AuthorizationRequestResolver), "*"),
+	wire.Bind(new(AuthorizationHandlerRequestResolver), new(*AuthorizationRequestResolver)),
+	wire.Bind(new(PushedAuthorizationHandlerRequestResolver), new(*AuthorizationRequestResolver)),
+	wire.Bind(new(PushedAuthorizationHandlerAuthorizationValidator), new(*AuthorizationHandler)),
+	wire.Struct(new(ClientAuthenticatorImpl), "*"),
+	wire.Bind(new(ClientAuthenticator), new(*ClientAuthenticatorImpl)),
+	NewClientJWKSHTTPClient,
 	wire.Struct(new(PreAuthenticatedURLTokenServiceImpl), "*"),
 	wire.Bind(new(PreAuthenticatedURLTokenService), new(*PreAuthenticatedURLTokenServiceImpl)),
 	wire.Struct(new(ProxyRedirectHandler), "*"),
@@ -126,6 +126,11 @@ type AuthorizationHandlerPreAuthenticatedURLTokenService interface {
 	) (oauth.PrepareUserAccessTokenResult, error)
 }
 
+type AuthorizationHandlerRequestResolver interface {
+	ResolveRequestURI(ctx context.Context, client *config.OAuthClientConfig, requestURI string) (protocol.AuthorizationRequest, error)
+	ResolveRequestObject(ctx context.Context, client *config.OAuthClientConfig, requestObject string) (protocol.AuthorizationRequest, error)
+}
+
 type AuthorizationHandlerDatabase interface {
 	WithTx(ctx context.Context, do func(ctx context.Context) error) (err error)
 }
@@ -158,6 +163,7 @@ type AuthorizationHandler struct {
 	PreAuthenticatedURLTokenService         AuthorizationHandlerPreAuthenticatedURLTokenService
 	IDTokenIssuer                           IDTokenIssuer
 	AuthorizationHandlerAccessTokenEncoding AuthorizationHandlerAccessTokenEncoding
+	RequestResolver                         AuthorizationHandlerRequestResolver
 }
 
 func (h *AuthorizationHandler) HandleConsentWithoutUserConsent(ctx context.Context, req *http.Request) (httputil.Result, *ConsentRequired) {
@@ -875,6 +881,54 @@ func (h *AuthorizationHandler) validatePreAuthenticatedURLTokenRequest(
 	return nil
 }
 
+// ResolveRequest returns the authorization request parameters
+// passed by reference (request_uri) or by value (request).
+// See https://datatracker.ietf.org/doc/html/rfc9126#section-4
+// See https://datatracker.ietf.org/doc/html/rfc9101#section-5
+func (h *AuthorizationHandler) ResolveRequest(
+	ctx context.Context,
+	r protocol.AuthorizationRequest,
+) (protocol.AuthorizationRequest, *AuthorizationResultError) {
+	ctx, client := resolveClient(ctx, h.ClientResolver, r.ClientID())
+	if client == nil {
+		// The invalid client is reported by ValidateRequestWithoutTx.
+		return r, nil
+	}
+
+	var resolved protocol.AuthorizationRequest
+	var err error
+	switch {
+	case r.RequestURI() != "" && r.Request() != "":
+		err = protocol.NewError("invalid_request", "request and request_uri cannot be used together")
+	case r.RequestURI() != "":
+		resolved, err = h.RequestResolver.ResolveRequestURI(ctx, client, r.RequestURI())
+	case client.RequirePushedAuthorizationRequests:
+		err = protocol.NewError("invalid_request", "pushed authorization request is required for this client")
+	case r.Request() != "":
+		resolved, err = h.RequestResolver.ResolveRequestObject(ctx, client, r.Request())
+	default:
+		return r, nil
+	}
+
+	if err != nil {
+		// The redirect URI is not validated yet, so the error is not redirected.
+		resultErr := AuthorizationResultError{
+			ResponseMode: r.ResponseMode(),
+		}
+		var oauthError *protocol.OAuthProtocolError
+		if errors.As(err, &oauthError) {
+			resultErr.Response = oauthError.Response
+		} else {
+			AuthorizationHandlerLogger.GetLogger(ctx).WithError(err).Error(ctx, "failed to resolve authorization request")
+			resultErr.Response = protocol.NewErrorResponse("server_error", "internal server error")
+			resultErr.InternalError = true
+		}
+		return nil, &resultErr
+	}
+
+	return resolved, nil
+}
+
 // nolint:gocognit
 func (h *AuthorizationHandler) ValidateRequestWithoutTx(
 	ctx context.Context,
@@ -516,6 +516,59 @@ func (mr *MockAuthorizationHandlerPreAuthenticatedURLTokenServiceMockRecorder) E
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeForAccessToken", reflect.TypeOf((*MockAuthorizationHandlerPreAuthenticatedURLTokenService)(nil).ExchangeForAccessToken), ctx, client, sessionID, token)
 }
 
+// MockAuthorizationHandlerRequestResolver is a mock of AuthorizationHandlerRequestResolver interface.
+type MockAuthorizationHandlerRequestResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationHandlerRequestResolverMockRecorder
+}
+
+// MockAuthorizationHandlerRequestResolverMockRecorder is the mock recorder for MockAuthorizationHandlerRequestResolver.
+type MockAuthorizationHandlerRequestResolverMockRecorder struct {
+	mock *MockAuthorizationHandlerRequestResolver
+}
+
+// NewMockAuthorizationHandlerRequestResolver creates a new mock instance.
+func NewMockAuthorizationHandlerRequestResolver(ctrl *gomock.Controller) *MockAuthorizationHandlerRequestResolver {
+	mock := &MockAuthorizationHandlerRequestResolver{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationHandlerRequestResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationHandlerRequestResolver) EXPECT() *MockAuthorizationHandlerRequestResolverMockRecorder {
+	return m.recorder
+}
+
+// ResolveRequestObject mocks base method.
+func (m *MockAuthorizationHandlerRequestResolver) ResolveRequestObject(ctx context.Context, client *config.OAuthClientConfig, requestObject string) (protocol.AuthorizationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRequestObject", ctx, client, requestObject)
+	ret0, _ := ret[0].(protocol.AuthorizationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRequestObject indicates an expected call of ResolveRequestObject.
+func (mr *MockAuthorizationHandlerRequestResolverMockRecorder) ResolveRequestObject(ctx, client, requestObject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRequestObject", reflect.TypeOf((*MockAuthorizationHandlerRequestResolver)(nil).ResolveRequestObject), ctx, client, requestObject)
+}
+
+// ResolveRequestURI mocks base method.
+func (m *MockAuthorizationHandlerRequestResolver) ResolveRequestURI(ctx context.Context, client *config.OAuthClientConfig, requestURI string) (protocol.AuthorizationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRequestURI", ctx, client, requestURI)
+	ret0, _ := ret[0].(protocol.AuthorizationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRequestURI indicates an expected call of ResolveRequestURI.
+func (mr *MockAuthorizationHandlerRequestResolverMockRecorder) ResolveRequestURI(ctx, client, requestURI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRequestURI", reflect.TypeOf((*MockAuthorizationHandlerRequestResolver)(nil).ResolveRequestURI), ctx, client, requestURI)
+}
+
 // MockAuthorizationHandlerDatabase is a mock of AuthorizationHandlerDatabase interface.
 type MockAuthorizationHandlerDatabase struct {
 	ctrl     *gomock.Controller
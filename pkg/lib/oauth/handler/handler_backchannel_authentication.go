@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/duration"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+var BackchannelAuthenticationHandlerLogger = slogutil.NewLogger("oauth-backchannel-authentication")
+
+const (
+	CIBAGrantLifetime = duration.UserInteraction
+	// CIBAGrantInterval is the minimum number of seconds between polls.
+	CIBAGrantInterval = 5
+	// CIBAGrantSlowDownIncrement is the number of seconds added to the interval
+	// when the client polls too fast.
+	CIBAGrantSlowDownIncrement = 5
+	// CIBABindingMessageMaxLength is the maximum number of characters in binding_message,
+	// so that it fits in a SMS.
+	CIBABindingMessageMaxLength = 64
+)
+
+type BackchannelAuthenticationHandlerDatabase interface {
+	WithTx(ctx context.Context, do func(ctx context.Context) error) (err error)
+}
+
+type BackchannelAuthenticationHandlerUserFacade interface {
+	GetUserIDsByLoginIDLoginHint(ctx context.Context, hint *oauth.LoginHint) ([]string, error)
+}
+
+type BackchannelAuthenticationHandlerCIBAGrantStore interface {
+	CreateCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error
+}
+
+type BackchannelAuthenticationHandlerMessageSender interface {
+	Send(ctx context.Context, opts *SendCIBAMessageOptions) error
+}
+
+type BackchannelAuthenticationHandlerEndpointsProvider interface {
+	BackchannelAuthenticationApprovalEndpointURL() *url.URL
+}
+
+type BackchannelAuthenticationHandlerRateLimiter interface {
+	Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error)
+}
+
+// BackchannelAuthenticationHandler implements the backchannel authentication endpoint.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7
+type BackchannelAuthenticationHandler struct {
+	AppID                  config.AppID
+	Database               BackchannelAuthenticationHandlerDatabase
+	OAuthClientCredentials *config.OAuthClientCredentials
+	ClientResolver         OAuthClientResolver
+	ClientAuthenticator    ClientAuthenticator
+	UserFacade             BackchannelAuthenticationHandlerUserFacade
+	CIBAGrants             BackchannelAuthenticationHandlerCIBAGrantStore
+	MessageSender          BackchannelAuthenticationHandlerMessageSender
+	Endpoints              BackchannelAuthenticationHandlerEndpointsProvider
+	RateLimiter            BackchannelAuthenticationHandlerRateLimiter
+	Clock                  clock.Clock
+	RemoteIP               httputil.RemoteIP
+}
+
+func (h *BackchannelAuthenticationHandler) Handle(ctx context.Context, req *http.Request, r protocol.BackchannelAuthenticationRequest) httputil.Result {
+	logger := BackchannelAuthenticationHandlerLogger.GetLogger(ctx)
+	if err := applyClientAssertion(url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := applyClientSecretBasic(req, url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := checkRateLimit(ctx, h.RateLimiter, NewBucketSpecOAuthBackchannelAuthenticationPerIP(string(h.RemoteIP))); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	client, err := h.authenticateClient(ctx, req, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	loginHint, lifetime, err := h.validateRequest(client, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	var resp protocol.BackchannelAuthenticationResponse
+	err = h.Database.WithTx(ctx, func(ctx context.Context) error {
+		resp, err = h.createCIBAGrant(ctx, client, r, loginHint, lifetime)
+		return err
+	})
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	return tokenResultOK{Response: protocol.TokenResponse(resp)}
+}
+
+// authenticateClient authenticates the client.
+// Only confidential clients can use backchannel authentication.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7.1
+func (h *BackchannelAuthenticationHandler) authenticateClient(ctx context.Context, req *http.Request, r protocol.BackchannelAuthenticationRequest) (*config.OAuthClientConfig, error) {
+	_, client := resolveClient(ctx, h.ClientResolver, r.ClientID())
+	if client == nil {
+		return nil, protocol.NewErrorStatusCode("invalid_client", "invalid client ID", http.StatusUnauthorized)
+	}
+
+	if !slices.Contains(oauth.GetAllowedGrantTypes(client), oauth.CIBAGrantType) {
+		return nil, protocol.NewError("unauthorized_client", "grant type is not allowed for this client")
+	}
+
+	if !client.IsConfidential() {
+		return nil, protocol.NewError("unauthorized_client", "only confidential clients can use backchannel authentication")
+	}
+
+	ctx, err := authenticateClientWithKeys(ctx, h.ClientAuthenticator, req, client, url.Values(r))
+	if err != nil {
+		return nil, err
+	}
+
+	if !isClientAuthenticated(ctx) {
+		if r.ClientSecret() == "" {
+			return nil, protocol.NewErrorStatusCode("invalid_client", "client secret is required", http.StatusUnauthorized)
+		}
+		if _, err := validateClientSecret(h.OAuthClientCredentials, client, r.ClientSecret()); err != nil {
+			return nil, protocol.NewErrorStatusCode("invalid_client", "invalid client secret", http.StatusUnauthorized)
+		}
+	}
+
+	return client, nil
+}
+
+func (h *BackchannelAuthenticationHandler) validateRequest(
+	client *config.OAuthClientConfig,
+	r protocol.BackchannelAuthenticationRequest,
+) (*oauth.LoginHint, time.Duration, error) {
+	if !slices.Contains(r.Scope(), "openid") {
+		return nil, 0, protocol.NewError("invalid_scope", "openid scope is required")
+	}
+
+	if r.LoginHint() == "" {
+		return nil, 0, protocol.NewError("invalid_request", "login_hint is required")
+	}
+	loginHint, err := oauth.ParseLoginHint(r.LoginHint())
+	if err != nil {
+		return nil, 0, protocol.NewError("invalid_request", err.Error())
+	}
+	if loginHint.Type != oauth.LoginHintTypeLoginID {
+		return nil, 0, protocol.NewError("invalid_request", "login_hint must be of type login_id")
+	}
+
+	if utf8.RuneCountInString(r.BindingMessage()) > CIBABindingMessageMaxLength {
+		return nil, 0, protocol.NewError("invalid_binding_message", "binding_message is too long")
+	}
+
+	if h.deliveryMode(client) == config.OAuthClientBackchannelTokenDeliveryModePing && r.ClientNotificationToken() == "" {
+		return nil, 0, protocol.NewError("invalid_request", "client_notification_token is required")
+	}
+
+	lifetime := CIBAGrantLifetime
+	requestedExpiry, ok := r.RequestedExpiry()
+	if !ok {
+		return nil, 0, protocol.NewError("invalid_request", "invalid requested_expiry")
+	}
+	if requestedExpiry > 0 {
+		lifetime = min(lifetime, time.Duration(requestedExpiry)*time.Second)
+	}
+
+	return loginHint, lifetime, nil
+}
+
+func (h *BackchannelAuthenticationHandler) createCIBAGrant(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	r protocol.BackchannelAuthenticationRequest,
+	loginHint *oauth.LoginHint,
+	lifetime time.Duration,
+) (protocol.BackchannelAuthenticationResponse, error) {
+	userIDs, err := h.UserFacade.GetUserIDsByLoginIDLoginHint(ctx, loginHint)
+	if err != nil {
+		return nil, err
+	}
+	if len(userIDs) != 1 {
+		return nil, protocol.NewError("unknown_user_id", "the user cannot be identified by login_hint")
+	}
+	userID := userIDs[0]
+
+	now := h.Clock.NowUTC()
+	authReqID := oauth.GenerateToken()
+	approvalCode := oauth.GenerateToken()
+
+	g := &oauth.CIBAGrant{
+		AppID:            string(h.AppID),
+		ClientID:         client.ClientID,
+		UserID:           userID,
+		AuthReqIDHash:    oauth.HashToken(authReqID),
+		Scopes:           r.Scope(),
+		BindingMessage:   r.BindingMessage(),
+		ApprovalCodeHash: oauth.HashToken(approvalCode),
+		DeliveryMode:     h.deliveryMode(client),
+		CreatedAt:        now,
+		ExpireAt:         now.Add(lifetime),
+		Interval:         CIBAGrantInterval,
+		Status:           oauth.CIBAGrantStatusPending,
+	}
+	if g.DeliveryMode == config.OAuthClientBackchannelTokenDeliveryModePing {
+		g.AuthReqID = authReqID
+		g.ClientNotificationToken = r.ClientNotificationToken()
+	}
+
+	err = h.CIBAGrants.CreateCIBAGrant(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	link := h.Endpoints.BackchannelAuthenticationApprovalEndpointURL()
+	q := link.Query()
+	q.Set("code", approvalCode)
+	link.RawQuery = q.Encode()
+
+	err = h.MessageSender.Send(ctx, &SendCIBAMessageOptions{
+		ClientID:       client.ClientID,
+		UserID:         userID,
+		LoginHint:      loginHint,
+		BindingMessage: r.BindingMessage(),
+		Link:           link.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := protocol.BackchannelAuthenticationResponse{}
+	resp.AuthReqID(authReqID)
+	resp.ExpiresIn(int(lifetime / time.Second))
+	resp.Interval(CIBAGrantInterval)
+	return resp, nil
+}
+
+func (h *BackchannelAuthenticationHandler) deliveryMode(client *config.OAuthClientConfig) config.OAuthClientBackchannelTokenDeliveryMode {
+	if client.BackchannelTokenDeliveryMode == "" {
+		return config.OAuthClientBackchannelTokenDeliveryModePoll
+	}
+	return client.BackchannelTokenDeliveryMode
+}
@@ -0,0 +1,270 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type cibaGrantStore struct {
+	grants map[string]*oauth.CIBAGrant
+}
+
+func (s *cibaGrantStore) GetCIBAGrant(ctx context.Context, authReqIDHash string) (*oauth.CIBAGrant, error) {
+	g, ok := s.grants[authReqIDHash]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	gg := *g
+	return &gg, nil
+}
+
+func (s *cibaGrantStore) CreateCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error {
+	gg := *g
+	s.grants[g.AuthReqIDHash] = &gg
+	return nil
+}
+
+func (s *cibaGrantStore) UpdateCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error {
+	gg := *g
+	s.grants[g.AuthReqIDHash] = &gg
+	return nil
+}
+
+func (s *cibaGrantStore) DeleteCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error {
+	delete(s.grants, g.AuthReqIDHash)
+	return nil
+}
+
+type cibaUserFacade struct {
+	userIDsByEmail map[string][]string
+}
+
+func (f cibaUserFacade) GetUserIDsByLoginIDLoginHint(ctx context.Context, hint *oauth.LoginHint) ([]string, error) {
+	return f.userIDsByEmail[hint.LoginIDEmail], nil
+}
+
+type cibaMessageSender struct {
+	sent []*handler.SendCIBAMessageOptions
+}
+
+func (s *cibaMessageSender) Send(ctx context.Context, opts *handler.SendCIBAMessageOptions) error {
+	s.sent = append(s.sent, opts)
+	return nil
+}
+
+type cibaEndpoints struct{}
+
+func (cibaEndpoints) BackchannelAuthenticationApprovalEndpointURL() *url.URL {
+	u, _ := url.Parse("http://accounts.example.com/backchannel_authentication")
+	return u
+}
+
+func TestBackchannelAuthentication(t *testing.T) {
+	Convey("Client-Initiated Backchannel Authentication", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+
+		clientResolver := &multiClientResolver{
+			ClientConfigs: map[string]*config.OAuthClientConfig{
+				"rp": {
+					ClientID:                       "rp",
+					ApplicationType:                config.OAuthClientApplicationTypeConfidential,
+					GrantTypes_do_not_use_directly: []string{oauth.CIBAGrantType},
+				},
+				"rp-ping": {
+					ClientID:                              "rp-ping",
+					ApplicationType:                       config.OAuthClientApplicationTypeConfidential,
+					GrantTypes_do_not_use_directly:        []string{oauth.CIBAGrantType},
+					BackchannelTokenDeliveryMode:          config.OAuthClientBackchannelTokenDeliveryModePing,
+					BackchannelClientNotificationEndpoint: "https://rp.example.com/ciba",
+				},
+				"app": {
+					ClientID:        "app",
+					ApplicationType: config.OAuthClientApplicationTypeConfidential,
+				},
+			},
+		}
+
+		key, err := jwk.FromRaw([]byte("supersecret"))
+		So(err, ShouldBeNil)
+		keySet := jwk.NewSet()
+		_ = keySet.AddKey(key)
+		credentials := &config.OAuthClientCredentials{
+			Items: []config.OAuthClientCredentialsItem{
+				{ClientID: "rp", OAuthClientCredentialsKeySet: config.OAuthClientCredentialsKeySet{Set: keySet}},
+				{ClientID: "rp-ping", OAuthClientCredentialsKeySet: config.OAuthClientCredentialsKeySet{Set: keySet}},
+				{ClientID: "app", OAuthClientCredentialsKeySet: config.OAuthClientCredentialsKeySet{Set: keySet}},
+			},
+		}
+
+		store := &cibaGrantStore{grants: map[string]*oauth.CIBAGrant{}}
+		sender := &cibaMessageSender{}
+
+		h := &handler.BackchannelAuthenticationHandler{
+			AppID:                  "app-id",
+			Database:               deviceAppDatabase{},
+			OAuthClientCredentials: credentials,
+			ClientResolver:         clientResolver,
+			UserFacade: cibaUserFacade{userIDsByEmail: map[string][]string{
+				"user@example.com": {"user-id"},
+			}},
+			CIBAGrants:    store,
+			MessageSender: sender,
+			Endpoints:     cibaEndpoints{},
+			RateLimiter:   introspectRateLimiter{},
+			Clock:         clk,
+			RemoteIP:      "1.2.3.4",
+		}
+
+		tokenHandler := &handler.TokenHandler{
+			Database:               deviceAppDatabase{},
+			OAuthClientCredentials: credentials,
+			ClientResolver:         clientResolver,
+			CIBAGrants:             store,
+			RateLimiter:            introspectRateLimiter{},
+			Clock:                  clk,
+			RemoteIP:               "1.2.3.4",
+		}
+
+		loginHint := (&oauth.LoginHint{
+			Type:         oauth.LoginHintTypeLoginID,
+			LoginIDEmail: "user@example.com",
+		}).String()
+
+		authorize := func(form url.Values) (int, map[string]any) {
+			req, _ := http.NewRequest("POST", "/oauth2/bc-authorize", nil)
+			result := h.Handle(context.Background(), req, protocol.BackchannelAuthenticationRequest(form))
+			rw := httptest.NewRecorder()
+			result.WriteResponse(rw, req)
+
+			var body map[string]any
+			err := json.Unmarshal(rw.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return rw.Code, body
+		}
+
+		poll := func(authReqID string) map[string]any {
+			req, _ := http.NewRequest("POST", "/oauth2/token", nil)
+			result := tokenHandler.Handle(context.Background(), httptest.NewRecorder(), req, protocol.TokenRequest{
+				"grant_type":    {oauth.CIBAGrantType},
+				"client_id":     {"rp"},
+				"client_secret": {"supersecret"},
+				"auth_req_id":   {authReqID},
+			})
+			rw := httptest.NewRecorder()
+			result.WriteResponse(rw, req)
+
+			var body map[string]any
+			err := json.Unmarshal(rw.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return body
+		}
+
+		Convey("should reject client without the CIBA grant type", func() {
+			_, body := authorize(url.Values{
+				"client_id":     {"app"},
+				"client_secret": {"supersecret"},
+				"scope":         {"openid"},
+				"login_hint":    {loginHint},
+			})
+			So(body["error"], ShouldEqual, "unauthorized_client")
+		})
+
+		Convey("should reject unknown user", func() {
+			_, body := authorize(url.Values{
+				"client_id":     {"rp"},
+				"client_secret": {"supersecret"},
+				"scope":         {"openid"},
+				"login_hint": {(&oauth.LoginHint{
+					Type:         oauth.LoginHintTypeLoginID,
+					LoginIDEmail: "nobody@example.com",
+				}).String()},
+			})
+			So(body["error"], ShouldEqual, "unknown_user_id")
+			So(store.grants, ShouldBeEmpty)
+		})
+
+		Convey("should require client_notification_token in ping mode", func() {
+			_, body := authorize(url.Values{
+				"client_id":     {"rp-ping"},
+				"client_secret": {"supersecret"},
+				"scope":         {"openid"},
+				"login_hint":    {loginHint},
+			})
+			So(body["error"], ShouldEqual, "invalid_request")
+		})
+
+		Convey("should issue auth_req_id and send the link to the user", func() {
+			code, body := authorize(url.Values{
+				"client_id":        {"rp"},
+				"client_secret":    {"supersecret"},
+				"scope":            {"openid offline_access"},
+				"login_hint":       {loginHint},
+				"binding_message":  {"W4SCT"},
+				"requested_expiry": {"120"},
+			})
+			So(code, ShouldEqual, 200)
+			So(body["auth_req_id"], ShouldNotBeEmpty)
+			So(body["expires_in"], ShouldEqual, 120)
+			So(body["interval"], ShouldEqual, 5)
+
+			g := store.grants[oauth.HashToken(body["auth_req_id"].(string))]
+			So(g.Status, ShouldEqual, oauth.CIBAGrantStatusPending)
+			So(g.UserID, ShouldEqual, "user-id")
+			So(g.DeliveryMode, ShouldEqual, config.OAuthClientBackchannelTokenDeliveryModePoll)
+			So(g.Scopes, ShouldResemble, []string{"openid", "offline_access"})
+
+			So(sender.sent, ShouldHaveLength, 1)
+			So(sender.sent[0].UserID, ShouldEqual, "user-id")
+			So(sender.sent[0].BindingMessage, ShouldEqual, "W4SCT")
+			link, err := url.Parse(sender.sent[0].Link)
+			So(err, ShouldBeNil)
+			So(oauth.HashToken(link.Query().Get("code")), ShouldEqual, g.ApprovalCodeHash)
+		})
+
+		Convey("should poll the token endpoint", func() {
+			_, body := authorize(url.Values{
+				"client_id":     {"rp"},
+				"client_secret": {"supersecret"},
+				"scope":         {"openid"},
+				"login_hint":    {loginHint},
+			})
+			authReqID := body["auth_req_id"].(string)
+
+			So(poll(authReqID)["error"], ShouldEqual, "authorization_pending")
+
+			Convey("should slow down if polling too fast", func() {
+				So(poll(authReqID)["error"], ShouldEqual, "slow_down")
+				So(store.grants[oauth.HashToken(authReqID)].Interval, ShouldEqual, 10)
+
+				clk.AdvanceSeconds(10)
+				So(poll(authReqID)["error"], ShouldEqual, "authorization_pending")
+			})
+
+			Convey("should return access_denied if denied", func() {
+				store.grants[oauth.HashToken(authReqID)].Status = oauth.CIBAGrantStatusDenied
+				clk.AdvanceSeconds(5)
+				So(poll(authReqID)["error"], ShouldEqual, "access_denied")
+				So(store.grants, ShouldBeEmpty)
+			})
+
+			Convey("should return expired_token if expired", func() {
+				clk.AdvanceSeconds(int(handler.CIBAGrantLifetime / time.Second))
+				So(poll(authReqID)["error"], ShouldEqual, "expired_token")
+			})
+		})
+	})
+}
@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/rand"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+var ClientRegistrationHandlerLogger = slogutil.NewLogger("oauth-client-registration")
+
+type ClientRegistrationHandlerInitialAccessTokenStore interface {
+	ConsumeInitialAccessToken(ctx context.Context, tokenHash string) (*oauth.InitialAccessToken, error)
+}
+
+type ClientRegistrationHandlerClientRegistrationStore interface {
+	GetClientRegistration(ctx context.Context, clientID string) (*oauth.ClientRegistration, error)
+	CreateClientRegistration(ctx context.Context, r *oauth.ClientRegistration) error
+	DeleteClientRegistration(ctx context.Context, r *oauth.ClientRegistration) error
+}
+
+type ClientRegistrationHandlerService interface {
+	CreateClient(ctx context.Context, clientID string, metadata map[string]any) (*config.OAuthClientConfig, string, error)
+	UpdateClient(ctx context.Context, clientID string, metadata map[string]any, rotateClientSecret bool) (*config.OAuthClientConfig, string, error)
+	DeleteClient(ctx context.Context, clientID string) error
+}
+
+type ClientRegistrationHandlerEndpointsProvider interface {
+	RegistrationEndpointURL() *url.URL
+}
+
+// ClientRegistrationHandler implements the client registration endpoint,
+// and the client configuration endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc7591
+// See https://datatracker.ietf.org/doc/html/rfc7592
+type ClientRegistrationHandler struct {
+	AppID                  config.AppID
+	OAuthClientCredentials *config.OAuthClientCredentials
+	ClientResolver         OAuthClientResolver
+	InitialAccessTokens    ClientRegistrationHandlerInitialAccessTokenStore
+	ClientRegistrations    ClientRegistrationHandlerClientRegistrationStore
+	Service                ClientRegistrationHandlerService
+	Endpoints              ClientRegistrationHandlerEndpointsProvider
+	Clock                  clock.Clock
+}
+
+// Register creates a client. The request must carry an initial access token minted from the Admin API.
+func (h *ClientRegistrationHandler) Register(ctx context.Context, req *http.Request, r protocol.ClientRegistrationRequest) httputil.Result {
+	logger := ClientRegistrationHandlerLogger.GetLogger(ctx)
+	token := parseBearerToken(req)
+	if token == "" {
+		return errorResult(ctx, logger, newInvalidTokenError("initial access token is required"))
+	}
+	_, err := h.InitialAccessTokens.ConsumeInitialAccessToken(ctx, oauth.HashToken(token))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return errorResult(ctx, logger, newInvalidTokenError("invalid initial access token"))
+	} else if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	// The registration is created before the client,
+	// so that a client is never left without its registration access token.
+	clientID := rand.StringWithAlphabet(16, "0123456789abcdef", rand.SecureRand)
+	now := h.Clock.NowUTC()
+	registrationAccessToken := oauth.GenerateToken()
+	registration := &oauth.ClientRegistration{
+		AppID:                       string(h.AppID),
+		ClientID:                    clientID,
+		RegistrationAccessTokenHash: oauth.HashToken(registrationAccessToken),
+		CreatedAt:                   now,
+	}
+	err = h.ClientRegistrations.CreateClientRegistration(ctx, registration)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	client, clientSecret, err := h.Service.CreateClient(ctx, clientID, r.ClientMetadata())
+	if err != nil {
+		if delErr := h.ClientRegistrations.DeleteClientRegistration(ctx, registration); delErr != nil {
+			logger.WithError(delErr).Error(ctx, "failed to delete client registration", slog.String("client_id", clientID))
+		}
+		return errorResult(ctx, logger, err)
+	}
+
+	resp, err := h.makeResponse(client, clientSecret)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+	resp.ClientIDIssuedAt(now.Unix())
+	resp.RegistrationAccessToken(registrationAccessToken)
+
+	return tokenResultOK{
+		StatusCode: http.StatusCreated,
+		Response:   protocol.TokenResponse(resp),
+	}
+}
+
+// Read returns the current metadata of the client.
+func (h *ClientRegistrationHandler) Read(ctx context.Context, req *http.Request, clientID string) httputil.Result {
+	logger := ClientRegistrationHandlerLogger.GetLogger(ctx)
+	registration, err := h.authenticate(ctx, req, clientID)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	client := h.ClientResolver.ResolveClient(clientID)
+	if client == nil {
+		// The client was deleted by the developer. The registration is useless now.
+		err = h.ClientRegistrations.DeleteClientRegistration(ctx, registration)
+		if err != nil {
+			return errorResult(ctx, logger, err)
+		}
+		return errorResult(ctx, logger, newInvalidTokenError("invalid registration access token"))
+	}
+
+	var clientSecret string
+	if item, ok := h.OAuthClientCredentials.Lookup(clientID); ok {
+		if keys := item.Keys(); len(keys) > 0 {
+			clientSecret = string(keys[len(keys)-1].Key)
+		}
+	}
+
+	resp, err := h.makeResponse(client, clientSecret)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+	resp.ClientIDIssuedAt(registration.CreatedAt.Unix())
+
+	return tokenResultOK{Response: protocol.TokenResponse(resp)}
+}
+
+// Update replaces the metadata of the client.
+// With x_rotate_client_secret, a new client secret is issued.
+func (h *ClientRegistrationHandler) Update(ctx context.Context, req *http.Request, clientID string, r protocol.ClientRegistrationRequest) httputil.Result {
+	logger := ClientRegistrationHandlerLogger.GetLogger(ctx)
+	registration, err := h.authenticate(ctx, req, clientID)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	// See https://datatracker.ietf.org/doc/html/rfc7592#section-2.2
+	if r.ClientID() != clientID {
+		return errorResult(ctx, logger, protocol.NewError("invalid_client_metadata", "client_id does not match"))
+	}
+	if r.ClientSecret() != "" {
+		if _, err := validateClientSecret(h.OAuthClientCredentials, &config.OAuthClientConfig{ClientID: clientID}, r.ClientSecret()); err != nil {
+			return errorResult(ctx, logger, protocol.NewError("invalid_client_metadata", "client_secret does not match"))
+		}
+	}
+
+	client, clientSecret, err := h.Service.UpdateClient(ctx, clientID, r.ClientMetadata(), r.RotateClientSecret())
+	if errors.Is(err, ErrClientRegistrationNotFound) {
+		// The client was deleted by the developer. The registration is useless now.
+		err = h.ClientRegistrations.DeleteClientRegistration(ctx, registration)
+		if err != nil {
+			return errorResult(ctx, logger, err)
+		}
+		return errorResult(ctx, logger, newInvalidTokenError("invalid registration access token"))
+	} else if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	resp, err := h.makeResponse(client, clientSecret)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+	resp.ClientIDIssuedAt(registration.CreatedAt.Unix())
+
+	return tokenResultOK{Response: protocol.TokenResponse(resp)}
+}
+
+// Delete removes the client and revokes the registration access token.
+func (h *ClientRegistrationHandler) Delete(ctx context.Context, req *http.Request, clientID string) httputil.Result {
+	logger := ClientRegistrationHandlerLogger.GetLogger(ctx)
+	registration, err := h.authenticate(ctx, req, clientID)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	err = h.Service.DeleteClient(ctx, clientID)
+	if err != nil && !errors.Is(err, ErrClientRegistrationNotFound) {
+		return errorResult(ctx, logger, err)
+	}
+
+	err = h.ClientRegistrations.DeleteClientRegistration(ctx, registration)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	return tokenResultEmpty{StatusCode: http.StatusNoContent}
+}
+
+func (h *ClientRegistrationHandler) authenticate(ctx context.Context, req *http.Request, clientID string) (*oauth.ClientRegistration, error) {
+	token := parseBearerToken(req)
+	if token == "" {
+		return nil, newInvalidTokenError("registration access token is required")
+	}
+
+	registration, err := h.ClientRegistrations.GetClientRegistration(ctx, clientID)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, newInvalidTokenError("invalid registration access token")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(oauth.HashToken(token)), []byte(registration.RegistrationAccessTokenHash)) != 1 {
+		return nil, newInvalidTokenError("invalid registration access token")
+	}
+
+	return registration, nil
+}
+
+func (h *ClientRegistrationHandler) makeResponse(client *config.OAuthClientConfig, clientSecret string) (protocol.ClientRegistrationResponse, error) {
+	b, err := json.Marshal(client)
+	if err != nil {
+		return nil, err
+	}
+	var metadata map[string]any
+	err = json.Unmarshal(b, &metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only return the metadata that the client can register,
+	// so that the client can send the response back in an update request.
+	resp := protocol.ClientRegistrationResponse{}
+	for k, v := range metadata {
+		if _, ok := registrableClientMetadata[k]; ok {
+			resp[k] = v
+		}
+	}
+	resp.ClientID(client.ClientID)
+
+	// The client secret is useless to clients authenticating with their keys.
+	if clientSecret != "" && !client.RequiresStrongClientAuthentication() {
+		resp.ClientSecret(clientSecret)
+		// The client secret does not expire.
+		resp.ClientSecretExpiresAt(0)
+	}
+
+	u := h.Endpoints.RegistrationEndpointURL()
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + url.PathEscape(client.ClientID)
+	resp.RegistrationClientURI(u.String())
+
+	return resp, nil
+}
+
+func newInvalidTokenError(description string) error {
+	return protocol.NewErrorStatusCode("invalid_token", description, http.StatusUnauthorized)
+}
+
+func parseBearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return token
+}
@@ -0,0 +1,262 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+type clientRegistrationStore struct {
+	initialAccessTokens map[string]*oauth.InitialAccessToken
+	registrations       map[string]*oauth.ClientRegistration
+}
+
+func (s *clientRegistrationStore) ConsumeInitialAccessToken(ctx context.Context, tokenHash string) (*oauth.InitialAccessToken, error) {
+	t, ok := s.initialAccessTokens[tokenHash]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	delete(s.initialAccessTokens, tokenHash)
+	return t, nil
+}
+
+func (s *clientRegistrationStore) GetClientRegistration(ctx context.Context, clientID string) (*oauth.ClientRegistration, error) {
+	r, ok := s.registrations[clientID]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	return r, nil
+}
+
+func (s *clientRegistrationStore) CreateClientRegistration(ctx context.Context, r *oauth.ClientRegistration) error {
+	s.registrations[r.ClientID] = r
+	return nil
+}
+
+func (s *clientRegistrationStore) DeleteClientRegistration(ctx context.Context, r *oauth.ClientRegistration) error {
+	delete(s.registrations, r.ClientID)
+	return nil
+}
+
+type clientRegistrationService struct {
+	clients   *multiClientResolver
+	createErr error
+}
+
+func (s *clientRegistrationService) CreateClient(ctx context.Context, clientID string, metadata map[string]any) (*config.OAuthClientConfig, string, error) {
+	if s.createErr != nil {
+		return nil, "", s.createErr
+	}
+	client := &config.OAuthClientConfig{
+		ClientID:        clientID,
+		ClientName:      metadata["client_name"].(string),
+		ApplicationType: config.OAuthClientApplicationTypeThirdPartyApp,
+		// Not registrable, so it should not appear in the response.
+		AccessTokenLifetime: 1800,
+	}
+	s.clients.ClientConfigs[clientID] = client
+	return client, "secret", nil
+}
+
+func (s *clientRegistrationService) UpdateClient(ctx context.Context, clientID string, metadata map[string]any, rotateClientSecret bool) (*config.OAuthClientConfig, string, error) {
+	client, ok := s.clients.ClientConfigs[clientID]
+	if !ok {
+		return nil, "", handler.ErrClientRegistrationNotFound
+	}
+	client.ClientName = metadata["client_name"].(string)
+	if rotateClientSecret {
+		return client, "new-secret", nil
+	}
+	return client, "", nil
+}
+
+func (s *clientRegistrationService) DeleteClient(ctx context.Context, clientID string) error {
+	if _, ok := s.clients.ClientConfigs[clientID]; !ok {
+		return handler.ErrClientRegistrationNotFound
+	}
+	delete(s.clients.ClientConfigs, clientID)
+	return nil
+}
+
+type clientRegistrationEndpoints struct{}
+
+func (clientRegistrationEndpoints) RegistrationEndpointURL() *url.URL {
+	u, _ := url.Parse("http://accounts.example.com/oauth2/register")
+	return u
+}
+
+func TestClientRegistrationHandler(t *testing.T) {
+	Convey("Client registration", t, func() {
+		ctx := context.Background()
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+
+		clientResolver := &multiClientResolver{
+			ClientConfigs: map[string]*config.OAuthClientConfig{},
+		}
+		store := &clientRegistrationStore{
+			initialAccessTokens: map[string]*oauth.InitialAccessToken{
+				oauth.HashToken("initial-access-token"): {
+					AppID:     "app-id",
+					TokenHash: oauth.HashToken("initial-access-token"),
+				},
+			},
+			registrations: map[string]*oauth.ClientRegistration{},
+		}
+		service := &clientRegistrationService{clients: clientResolver}
+		h := &handler.ClientRegistrationHandler{
+			AppID:                  "app-id",
+			OAuthClientCredentials: &config.OAuthClientCredentials{},
+			ClientResolver:         clientResolver,
+			InitialAccessTokens:    store,
+			ClientRegistrations:    store,
+			Service:                service,
+			Endpoints:              clientRegistrationEndpoints{},
+			Clock:                  clk,
+		}
+
+		do := func(token string, f func(req *http.Request) httputil.Result) (int, map[string]any) {
+			req, _ := http.NewRequest("POST", "/oauth2/register", nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			rw := httptest.NewRecorder()
+			f(req).WriteResponse(rw, req)
+
+			var body map[string]any
+			if rw.Body.Len() > 0 {
+				err := json.Unmarshal(rw.Body.Bytes(), &body)
+				So(err, ShouldBeNil)
+			}
+			return rw.Code, body
+		}
+
+		register := func(token string) (int, map[string]any) {
+			return do(token, func(req *http.Request) httputil.Result {
+				return h.Register(ctx, req, protocol.ClientRegistrationRequest{
+					"client_name":   "Example",
+					"redirect_uris": []any{"https://example.com/callback"},
+				})
+			})
+		}
+
+		Convey("should register a client", func() {
+			code, body := register("initial-access-token")
+			So(code, ShouldEqual, 201)
+
+			clientID := body["client_id"].(string)
+			registrationAccessToken := body["registration_access_token"].(string)
+			So(body["client_secret"], ShouldEqual, "secret")
+			So(body["client_name"], ShouldEqual, "Example")
+			So(body["registration_client_uri"], ShouldEqual, "http://accounts.example.com/oauth2/register/"+clientID)
+			So(body, ShouldNotContainKey, "x_application_type")
+			So(body, ShouldNotContainKey, "access_token_lifetime_seconds")
+			So(store.registrations, ShouldContainKey, clientID)
+
+			Convey("should read the client", func() {
+				code, body := do(registrationAccessToken, func(req *http.Request) httputil.Result {
+					return h.Read(ctx, req, clientID)
+				})
+				So(code, ShouldEqual, 200)
+				So(body["client_id"], ShouldEqual, clientID)
+				So(body["client_name"], ShouldEqual, "Example")
+				So(body, ShouldNotContainKey, "registration_access_token")
+			})
+
+			Convey("should update the client", func() {
+				code, body := do(registrationAccessToken, func(req *http.Request) httputil.Result {
+					return h.Update(ctx, req, clientID, protocol.ClientRegistrationRequest{
+						"client_id":              clientID,
+						"client_name":            "New Example",
+						"x_rotate_client_secret": true,
+					})
+				})
+				So(code, ShouldEqual, 200)
+				So(body["client_name"], ShouldEqual, "New Example")
+				So(body["client_secret"], ShouldEqual, "new-secret")
+			})
+
+			Convey("should reject update with another client_id", func() {
+				code, body := do(registrationAccessToken, func(req *http.Request) httputil.Result {
+					return h.Update(ctx, req, clientID, protocol.ClientRegistrationRequest{
+						"client_id":   "another",
+						"client_name": "New Example",
+					})
+				})
+				So(code, ShouldEqual, 400)
+				So(body["error"], ShouldEqual, "invalid_client_metadata")
+			})
+
+			Convey("should delete the client", func() {
+				code, _ := do(registrationAccessToken, func(req *http.Request) httputil.Result {
+					return h.Delete(ctx, req, clientID)
+				})
+				So(code, ShouldEqual, 204)
+				So(clientResolver.ClientConfigs, ShouldNotContainKey, clientID)
+				So(store.registrations, ShouldNotContainKey, clientID)
+			})
+
+			Convey("should reject an invalid registration access token", func() {
+				code, body := do("wrong", func(req *http.Request) httputil.Result {
+					return h.Read(ctx, req, clientID)
+				})
+				So(code, ShouldEqual, 401)
+				So(body["error"], ShouldEqual, "invalid_token")
+			})
+
+			Convey("should delete the registration of a client deleted by the developer", func() {
+				delete(clientResolver.ClientConfigs, clientID)
+				code, body := do(registrationAccessToken, func(req *http.Request) httputil.Result {
+					return h.Read(ctx, req, clientID)
+				})
+				So(code, ShouldEqual, 401)
+				So(body["error"], ShouldEqual, "invalid_token")
+				So(store.registrations, ShouldNotContainKey, clientID)
+			})
+		})
+
+		Convey("should not reuse the initial access token", func() {
+			code, _ := register("initial-access-token")
+			So(code, ShouldEqual, 201)
+
+			code, body := register("initial-access-token")
+			So(code, ShouldEqual, 401)
+			So(body["error"], ShouldEqual, "invalid_token")
+			So(clientResolver.ClientConfigs, ShouldHaveLength, 1)
+		})
+
+		Convey("should require the initial access token", func() {
+			code, body := register("")
+			So(code, ShouldEqual, 401)
+			So(body["error"], ShouldEqual, "invalid_token")
+		})
+
+		Convey("should not leave the registration if the client is rejected", func() {
+			service.createErr = protocol.NewError("invalid_client_metadata", "unsupported client metadata: x_application_type")
+			code, body := register("initial-access-token")
+			So(code, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "invalid_client_metadata")
+			So(store.registrations, ShouldBeEmpty)
+		})
+
+		Convey("should report other errors as server_error", func() {
+			service.createErr = errors.New("database is down")
+			code, body := register("initial-access-token")
+			So(code, ShouldEqual, 500)
+			So(body["error"], ShouldEqual, "server_error")
+			So(store.registrations, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/duration"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+var DeviceAuthorizationHandlerLogger = slogutil.NewLogger("oauth-device-authorization")
+
+const (
+	DeviceGrantLifetime = duration.UserInteraction
+	// DeviceGrantInterval is the minimum number of seconds between polls.
+	DeviceGrantInterval = 5
+	// DeviceGrantSlowDownIncrement is the number of seconds added to the interval
+	// when the client polls too fast.
+	// See https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+	DeviceGrantSlowDownIncrement = 5
+)
+
+// maxUserCodeCollisionRetry bounds the retries when the generated user code is in use.
+const maxUserCodeCollisionRetry = 3
+
+type DeviceAuthorizationHandlerDeviceGrantStore interface {
+	CreateDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error
+}
+
+type DeviceAuthorizationHandlerEndpointsProvider interface {
+	DeviceVerificationEndpointURL() *url.URL
+}
+
+type DeviceAuthorizationHandlerRateLimiter interface {
+	Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error)
+}
+
+// DeviceAuthorizationHandler implements the device authorization endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc8628#section-3.1
+type DeviceAuthorizationHandler struct {
+	AppID                  config.AppID
+	OAuthClientCredentials *config.OAuthClientCredentials
+	ClientResolver         OAuthClientResolver
+	ClientAuthenticator    ClientAuthenticator
+	DeviceGrants           DeviceAuthorizationHandlerDeviceGrantStore
+	Endpoints              DeviceAuthorizationHandlerEndpointsProvider
+	RateLimiter            DeviceAuthorizationHandlerRateLimiter
+	Clock                  clock.Clock
+	RemoteIP               httputil.RemoteIP
+}
+
+func (h *DeviceAuthorizationHandler) Handle(ctx context.Context, req *http.Request, r protocol.DeviceAuthorizationRequest) httputil.Result {
+	logger := DeviceAuthorizationHandlerLogger.GetLogger(ctx)
+	if err := applyClientAssertion(url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := applyClientSecretBasic(req, url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := checkRateLimit(ctx, h.RateLimiter, NewBucketSpecOAuthDeviceAuthorizationPerIP(string(h.RemoteIP))); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	client, err := h.authenticateClient(ctx, req, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	resp, err := h.createDeviceGrant(ctx, client, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	return tokenResultOK{Response: protocol.TokenResponse(resp)}
+}
+
+func (h *DeviceAuthorizationHandler) authenticateClient(ctx context.Context, req *http.Request, r protocol.DeviceAuthorizationRequest) (*config.OAuthClientConfig, error) {
+	_, client := resolveClient(ctx, h.ClientResolver, r.ClientID())
+	if client == nil {
+		return nil, protocol.NewErrorStatusCode("invalid_client", "invalid client ID", http.StatusUnauthorized)
+	}
+
+	if !slices.Contains(oauth.GetAllowedGrantTypes(client), oauth.DeviceCodeGrantType) {
+		return nil, protocol.NewError("unauthorized_client", "grant type is not allowed for this client")
+	}
+
+	ctx, err := authenticateClientWithKeys(ctx, h.ClientAuthenticator, req, client, url.Values(r))
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsConfidential() && !isClientAuthenticated(ctx) {
+		if r.ClientSecret() == "" {
+			return nil, protocol.NewErrorStatusCode("invalid_client", "client secret is required", http.StatusUnauthorized)
+		}
+		if _, err := validateClientSecret(h.OAuthClientCredentials, client, r.ClientSecret()); err != nil {
+			return nil, protocol.NewErrorStatusCode("invalid_client", "invalid client secret", http.StatusUnauthorized)
+		}
+	}
+
+	return client, nil
+}
+
+func (h *DeviceAuthorizationHandler) createDeviceGrant(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	r protocol.DeviceAuthorizationRequest,
+) (protocol.DeviceAuthorizationResponse, error) {
+	now := h.Clock.NowUTC()
+	deviceCode := oauth.GenerateToken()
+
+	var userCode string
+	var err error
+	for i := 0; i < maxUserCodeCollisionRetry; i++ {
+		userCode = oauth.GenerateUserCode()
+		err = h.DeviceGrants.CreateDeviceGrant(ctx, &oauth.DeviceGrant{
+			AppID:          string(h.AppID),
+			ClientID:       client.ClientID,
+			DeviceCodeHash: oauth.HashToken(deviceCode),
+			UserCodeHash:   oauth.HashUserCode(userCode),
+			Scopes:         r.Scope(),
+			CreatedAt:      now,
+			ExpireAt:       now.Add(DeviceGrantLifetime),
+			Interval:       DeviceGrantInterval,
+			Status:         oauth.DeviceGrantStatusPending,
+		})
+		if !errors.Is(err, oauth.ErrUserCodeCollision) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	verificationURI := h.Endpoints.DeviceVerificationEndpointURL()
+	verificationURIComplete := *verificationURI
+	q := verificationURIComplete.Query()
+	q.Set("user_code", userCode)
+	verificationURIComplete.RawQuery = q.Encode()
+
+	resp := protocol.DeviceAuthorizationResponse{}
+	resp.DeviceCode(deviceCode)
+	resp.UserCode(userCode)
+	resp.VerificationURI(verificationURI.String())
+	resp.VerificationURIComplete(verificationURIComplete.String())
+	resp.ExpiresIn(int(DeviceGrantLifetime / time.Second))
+	resp.Interval(DeviceGrantInterval)
+	return resp, nil
+}
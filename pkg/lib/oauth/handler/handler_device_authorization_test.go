@@ -0,0 +1,217 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type deviceGrantStore struct {
+	grants map[string]*oauth.DeviceGrant
+	// beforeUpdate is called before a grant is updated, to simulate a concurrent update.
+	beforeUpdate func()
+}
+
+func (s *deviceGrantStore) GetDeviceGrant(ctx context.Context, deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	g, ok := s.grants[deviceCodeHash]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	gg := *g
+	return &gg, nil
+}
+
+func (s *deviceGrantStore) CreateDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error {
+	gg := *g
+	s.grants[g.DeviceCodeHash] = &gg
+	return nil
+}
+
+func (s *deviceGrantStore) UpdateDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error {
+	if s.beforeUpdate != nil {
+		s.beforeUpdate()
+	}
+	stored, ok := s.grants[g.DeviceCodeHash]
+	if !ok {
+		return oauth.ErrGrantNotFound
+	}
+	if stored.Revision != g.Revision {
+		return oauth.ErrGrantConflict
+	}
+	g.Revision++
+	gg := *g
+	s.grants[g.DeviceCodeHash] = &gg
+	return nil
+}
+
+func (s *deviceGrantStore) DeleteDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error {
+	if _, ok := s.grants[g.DeviceCodeHash]; !ok {
+		return oauth.ErrGrantNotFound
+	}
+	delete(s.grants, g.DeviceCodeHash)
+	return nil
+}
+
+type deviceEndpoints struct{}
+
+func (deviceEndpoints) DeviceVerificationEndpointURL() *url.URL {
+	u, _ := url.Parse("http://accounts.example.com/device")
+	return u
+}
+
+type deviceAppDatabase struct{}
+
+func (deviceAppDatabase) WithTx(ctx context.Context, do func(ctx context.Context) error) error {
+	return do(ctx)
+}
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	Convey("Device authorization grant", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+
+		clientResolver := &multiClientResolver{
+			ClientConfigs: map[string]*config.OAuthClientConfig{
+				"tv": {
+					ClientID:                       "tv",
+					ApplicationType:                config.OAuthClientApplicationTypeNative,
+					GrantTypes_do_not_use_directly: []string{oauth.DeviceCodeGrantType},
+				},
+				"app": {
+					ClientID:        "app",
+					ApplicationType: config.OAuthClientApplicationTypeNative,
+				},
+			},
+		}
+		store := &deviceGrantStore{grants: map[string]*oauth.DeviceGrant{}}
+
+		h := &handler.DeviceAuthorizationHandler{
+			AppID:                  "app-id",
+			OAuthClientCredentials: &config.OAuthClientCredentials{},
+			ClientResolver:         clientResolver,
+			DeviceGrants:           store,
+			Endpoints:              deviceEndpoints{},
+			RateLimiter:            introspectRateLimiter{},
+			Clock:                  clk,
+			RemoteIP:               "1.2.3.4",
+		}
+
+		tokenHandler := &handler.TokenHandler{
+			Database:               deviceAppDatabase{},
+			OAuthClientCredentials: &config.OAuthClientCredentials{},
+			ClientResolver:         clientResolver,
+			DeviceGrants:           store,
+			RateLimiter:            introspectRateLimiter{},
+			Clock:                  clk,
+			RemoteIP:               "1.2.3.4",
+		}
+
+		authorize := func(form url.Values) (int, map[string]any) {
+			req, _ := http.NewRequest("POST", "/oauth2/device_authorization", nil)
+			result := h.Handle(context.Background(), req, protocol.DeviceAuthorizationRequest(form))
+			rw := httptest.NewRecorder()
+			result.WriteResponse(rw, req)
+
+			var body map[string]any
+			err := json.Unmarshal(rw.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return rw.Code, body
+		}
+
+		poll := func(deviceCode string) map[string]any {
+			req, _ := http.NewRequest("POST", "/oauth2/token", nil)
+			result := tokenHandler.Handle(context.Background(), httptest.NewRecorder(), req, protocol.TokenRequest{
+				"grant_type":  {oauth.DeviceCodeGrantType},
+				"client_id":   {"tv"},
+				"device_code": {deviceCode},
+			})
+			rw := httptest.NewRecorder()
+			result.WriteResponse(rw, req)
+
+			var body map[string]any
+			err := json.Unmarshal(rw.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return body
+		}
+
+		Convey("should reject client without the device code grant type", func() {
+			_, body := authorize(url.Values{"client_id": {"app"}})
+			So(body["error"], ShouldEqual, "unauthorized_client")
+		})
+
+		Convey("should issue device code and user code", func() {
+			code, body := authorize(url.Values{
+				"client_id": {"tv"},
+				"scope":     {"openid offline_access"},
+			})
+			So(code, ShouldEqual, 200)
+			So(body["device_code"], ShouldNotBeEmpty)
+			So(body["user_code"], ShouldHaveLength, 9)
+			So(body["verification_uri"], ShouldEqual, "http://accounts.example.com/device")
+			So(body["verification_uri_complete"], ShouldEqual, "http://accounts.example.com/device?user_code="+body["user_code"].(string))
+			So(body["expires_in"], ShouldEqual, 1200)
+			So(body["interval"], ShouldEqual, 5)
+
+			g := store.grants[oauth.HashToken(body["device_code"].(string))]
+			So(g.Status, ShouldEqual, oauth.DeviceGrantStatusPending)
+			So(g.UserCodeHash, ShouldEqual, oauth.HashUserCode(body["user_code"].(string)))
+			So(g.Scopes, ShouldResemble, []string{"openid", "offline_access"})
+		})
+
+		Convey("should poll the token endpoint", func() {
+			_, body := authorize(url.Values{"client_id": {"tv"}})
+			deviceCode := body["device_code"].(string)
+
+			So(poll(deviceCode)["error"], ShouldEqual, "authorization_pending")
+
+			Convey("should slow down if polling too fast", func() {
+				So(poll(deviceCode)["error"], ShouldEqual, "slow_down")
+				So(store.grants[oauth.HashToken(deviceCode)].Interval, ShouldEqual, 10)
+
+				clk.AdvanceSeconds(10)
+				So(poll(deviceCode)["error"], ShouldEqual, "authorization_pending")
+			})
+
+			Convey("should return access_denied if denied", func() {
+				store.grants[oauth.HashToken(deviceCode)].Status = oauth.DeviceGrantStatusDenied
+				clk.AdvanceSeconds(5)
+				So(poll(deviceCode)["error"], ShouldEqual, "access_denied")
+				So(store.grants, ShouldBeEmpty)
+			})
+
+			Convey("should return expired_token if expired", func() {
+				clk.AdvanceSeconds(int(handler.DeviceGrantLifetime / time.Second))
+				So(poll(deviceCode)["error"], ShouldEqual, "expired_token")
+			})
+
+			Convey("should not overwrite the decision made while polling", func() {
+				store.beforeUpdate = func() {
+					g := store.grants[oauth.HashToken(deviceCode)]
+					g.Status = oauth.DeviceGrantStatusDenied
+					g.Revision++
+					store.beforeUpdate = nil
+				}
+				clk.AdvanceSeconds(5)
+				So(poll(deviceCode)["error"], ShouldEqual, "authorization_pending")
+				So(store.grants[oauth.HashToken(deviceCode)].Status, ShouldEqual, oauth.DeviceGrantStatusDenied)
+
+				So(poll(deviceCode)["error"], ShouldEqual, "access_denied")
+			})
+		})
+
+		Convey("should return invalid_grant for unknown device code", func() {
+			So(poll("unknown")["error"], ShouldEqual, "invalid_grant")
+		})
+	})
+}
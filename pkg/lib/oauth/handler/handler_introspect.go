@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+var IntrospectHandlerLogger = slogutil.NewLogger("oauth-introspect")
+
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+type IntrospectHandlerAccessGrantStore interface {
+	GetAccessGrant(ctx context.Context, tokenHash string) (*oauth.AccessGrant, error)
+}
+
+type IntrospectHandlerAuthorizationStore interface {
+	GetByID(ctx context.Context, id string) (*oauth.Authorization, error)
+}
+
+type IntrospectHandlerOfflineGrantService interface {
+	GetOfflineGrant(ctx context.Context, id string) (*oauth.OfflineGrant, error)
+}
+
+type IntrospectHandlerIDPSessionProvider interface {
+	Get(ctx context.Context, id string) (*idpsession.IDPSession, error)
+	CheckSessionExpired(session *idpsession.IDPSession) (expired bool)
+}
+
+type IntrospectHandlerAccessTokenDecoder interface {
+	DecodeAccessToken(encodedToken string) (tok string, isHash bool, err error)
+}
+
+type IntrospectHandlerIssuer interface {
+	Iss() string
+}
+
+type IntrospectHandlerRateLimiter interface {
+	Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error)
+}
+
+// IntrospectHandler implements OAuth 2.0 Token Introspection.
+// See https://datatracker.ietf.org/doc/html/rfc7662
+type IntrospectHandler struct {
+	OAuthClientCredentials *config.OAuthClientCredentials
+	ClientResolver         OAuthClientResolver
+	ClientAuthenticator    ClientAuthenticator
+	AccessGrants           IntrospectHandlerAccessGrantStore
+	Authorizations         IntrospectHandlerAuthorizationStore
+	OfflineGrantService    IntrospectHandlerOfflineGrantService
+	IDPSessions            IntrospectHandlerIDPSessionProvider
+	AccessTokenDecoder     IntrospectHandlerAccessTokenDecoder
+	Issuer                 IntrospectHandlerIssuer
+	RateLimiter            IntrospectHandlerRateLimiter
+	Clock                  clock.Clock
+	RemoteIP               httputil.RemoteIP
+}
+
+func (h *IntrospectHandler) Handle(ctx context.Context, req *http.Request, r protocol.IntrospectRequest) httputil.Result {
+	logger := IntrospectHandlerLogger.GetLogger(ctx)
+	if err := applyClientAssertion(url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := applyClientSecretBasic(req, url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := checkRateLimit(ctx, h.RateLimiter, NewBucketSpecOAuthTokenPerIP(string(h.RemoteIP))); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := h.authenticateClient(ctx, req, r); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if r.Token() == "" {
+		return errorResult(ctx, logger, protocol.NewError("invalid_request", "token is required"))
+	}
+
+	resp, err := h.introspect(ctx, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	return tokenResultOK{Response: protocol.TokenResponse(resp)}
+}
+
+func (h *IntrospectHandler) authenticateClient(ctx context.Context, req *http.Request, r protocol.IntrospectRequest) error {
+	// The introspection endpoint reveals information about tokens issued
+	// to any client, so only confidential clients are allowed to call it.
+	_, client := resolveClient(ctx, h.ClientResolver, r.ClientID())
+	if client == nil {
+		return protocol.NewErrorStatusCode("invalid_client", "invalid client ID", http.StatusUnauthorized)
+	}
+	if !client.IsConfidential() {
+		return protocol.NewErrorStatusCode("invalid_client", "only confidential clients can introspect tokens", http.StatusUnauthorized)
+	}
+	ctx, err := authenticateClientWithKeys(ctx, h.ClientAuthenticator, req, client, url.Values(r))
+	if err != nil {
+		return err
+	}
+	if isClientAuthenticated(ctx) {
+		return nil
+	}
+	if r.ClientSecret() == "" {
+		return protocol.NewErrorStatusCode("invalid_client", "client secret is required", http.StatusUnauthorized)
+	}
+	if _, err := validateClientSecret(h.OAuthClientCredentials, client, r.ClientSecret()); err != nil {
+		return protocol.NewErrorStatusCode("invalid_client", "invalid client secret", http.StatusUnauthorized)
+	}
+	return nil
+}
+
+func (h *IntrospectHandler) introspect(ctx context.Context, r protocol.IntrospectRequest) (protocol.IntrospectResponse, error) {
+	// token_type_hint only decides which lookup goes first.
+	// A wrong hint must not make a valid token look inactive.
+	// See https://datatracker.ietf.org/doc/html/rfc7662#section-2.1
+	lookups := []func(ctx context.Context, token string) (protocol.IntrospectResponse, error){
+		h.introspectAccessToken,
+		h.introspectRefreshToken,
+	}
+	if r.TokenTypeHint() == TokenTypeHintRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		resp, err := lookup(ctx, r.Token())
+		if err != nil {
+			return nil, err
+		}
+		if resp != nil {
+			return resp, nil
+		}
+	}
+
+	resp := protocol.IntrospectResponse{}
+	resp.Active(false)
+	return resp, nil
+}
+
+// introspectAccessToken returns (nil, nil) if token is not an active access token.
+func (h *IntrospectHandler) introspectAccessToken(ctx context.Context, token string) (protocol.IntrospectResponse, error) {
+	tok, isHash, err := h.AccessTokenDecoder.DecodeAccessToken(token)
+	if err != nil {
+		// An invalid or expired JWT access token is simply inactive.
+		return nil, nil
+	}
+
+	tokenHash := tok
+	if !isHash {
+		tokenHash = oauth.HashToken(token)
+	}
+
+	grant, err := h.AccessGrants.GetAccessGrant(ctx, tokenHash)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !h.Clock.NowUTC().Before(grant.ExpireAt) {
+		return nil, nil
+	}
+
+	authz, err := h.Authorizations.GetByID(ctx, grant.AuthorizationID)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var sid string
+	var dpopJKT string
+	switch grant.SessionKind {
+	case oauth.GrantSessionKindSession:
+		s, err := h.IDPSessions.Get(ctx, grant.SessionID)
+		if errors.Is(err, idpsession.ErrSessionNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if h.IDPSessions.CheckSessionExpired(s) {
+			return nil, nil
+		}
+		sid = oauth.EncodeSID(s)
+	case oauth.GrantSessionKindOffline:
+		offlineGrant, err := h.OfflineGrantService.GetOfflineGrant(ctx, grant.SessionID)
+		if errors.Is(err, oauth.ErrGrantNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		offlineGrantSession, ok := offlineGrant.ToSession(grant.InitialRefreshTokenHash)
+		if !ok {
+			return nil, nil
+		}
+		sid = oauth.EncodeSID(offlineGrant)
+		dpopJKT = offlineGrantSession.DPoPJKT
+	default:
+		panic("oauth: introspecting unknown grant session kind")
+	}
+
+	resp := protocol.IntrospectResponse{}
+	resp.Active(true)
+	resp.TokenType("Bearer")
+	resp.Iss(h.Issuer.Iss())
+	resp.ClientID(authz.ClientID)
+	resp.Sub(authz.UserID)
+	resp.Scope(strings.Join(grant.Scopes, " "))
+	resp.Iat(grant.CreatedAt.Unix())
+	resp.Exp(grant.ExpireAt.Unix())
+	resp.SID(sid)
+	if dpopJKT != "" {
+		resp.DPoPJKT(dpopJKT)
+	}
+	return resp, nil
+}
+
+// introspectRefreshToken returns (nil, nil) if token is not an active refresh token.
+func (h *IntrospectHandler) introspectRefreshToken(ctx context.Context, token string) (protocol.IntrospectResponse, error) {
+	rawToken, grantID, err := oauth.DecodeRefreshToken(token)
+	if err != nil {
+		return nil, nil
+	}
+
+	offlineGrant, err := h.OfflineGrantService.GetOfflineGrant(ctx, grantID)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tokenHash := oauth.HashToken(rawToken)
+	if !offlineGrant.MatchCurrentHash(tokenHash) {
+		return nil, nil
+	}
+
+	offlineGrantSession, ok := offlineGrant.ToSession(tokenHash)
+	if !ok {
+		return nil, nil
+	}
+
+	resp := protocol.IntrospectResponse{}
+	resp.Active(true)
+	resp.Iss(h.Issuer.Iss())
+	resp.ClientID(offlineGrantSession.ClientID)
+	resp.Sub(offlineGrant.GetUserID())
+	resp.Scope(strings.Join(offlineGrantSession.Scopes, " "))
+	resp.Iat(offlineGrantSession.CreatedAt.Unix())
+	resp.Exp(offlineGrant.ExpireAtForResolvedSession.Unix())
+	resp.SID(oauth.EncodeSID(offlineGrant))
+	if offlineGrantSession.DPoPJKT != "" {
+		resp.DPoPJKT(offlineGrantSession.DPoPJKT)
+	}
+	return resp, nil
+}
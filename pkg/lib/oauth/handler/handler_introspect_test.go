@@ -0,0 +1,259 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type introspectAccessGrantStore struct {
+	grants map[string]*oauth.AccessGrant
+}
+
+func (s *introspectAccessGrantStore) GetAccessGrant(ctx context.Context, tokenHash string) (*oauth.AccessGrant, error) {
+	g, ok := s.grants[tokenHash]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	return g, nil
+}
+
+type introspectAuthorizationStore struct {
+	authzs map[string]*oauth.Authorization
+}
+
+func (s *introspectAuthorizationStore) GetByID(ctx context.Context, id string) (*oauth.Authorization, error) {
+	a, ok := s.authzs[id]
+	if !ok {
+		return nil, oauth.ErrAuthorizationNotFound
+	}
+	return a, nil
+}
+
+type introspectOfflineGrantService struct {
+	grants map[string]*oauth.OfflineGrant
+}
+
+func (s *introspectOfflineGrantService) GetOfflineGrant(ctx context.Context, id string) (*oauth.OfflineGrant, error) {
+	g, ok := s.grants[id]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	return g, nil
+}
+
+type introspectIDPSessionProvider struct {
+	sessions map[string]*idpsession.IDPSession
+}
+
+func (p *introspectIDPSessionProvider) Get(ctx context.Context, id string) (*idpsession.IDPSession, error) {
+	s, ok := p.sessions[id]
+	if !ok {
+		return nil, idpsession.ErrSessionNotFound
+	}
+	return s, nil
+}
+
+func (p *introspectIDPSessionProvider) CheckSessionExpired(s *idpsession.IDPSession) bool {
+	return false
+}
+
+type introspectAccessTokenDecoder struct{}
+
+func (introspectAccessTokenDecoder) DecodeAccessToken(encodedToken string) (string, bool, error) {
+	return encodedToken, false, nil
+}
+
+type introspectIssuer struct{}
+
+func (introspectIssuer) Iss() string { return "http://accounts.example.com" }
+
+type introspectRateLimiter struct{}
+
+func (introspectRateLimiter) Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error) {
+	return nil, nil
+}
+
+func TestIntrospectHandler(t *testing.T) {
+	Convey("Introspect handler", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		now := clk.NowUTC()
+
+		clientResolver := &multiClientResolver{
+			ClientConfigs: map[string]*config.OAuthClientConfig{
+				"resource-server": {
+					ClientID:        "resource-server",
+					ApplicationType: config.OAuthClientApplicationTypeConfidential,
+				},
+				"app": {
+					ClientID:             "app",
+					ApplicationType:      config.OAuthClientApplicationTypeNative,
+					RefreshTokenLifetime: config.DurationSeconds(86400),
+				},
+			},
+		}
+
+		key, err := jwk.FromRaw([]byte("supersecret"))
+		So(err, ShouldBeNil)
+		keySet := jwk.NewSet()
+		_ = keySet.AddKey(key)
+
+		accessGrants := &introspectAccessGrantStore{grants: map[string]*oauth.AccessGrant{}}
+		authorizations := &introspectAuthorizationStore{authzs: map[string]*oauth.Authorization{
+			"authz-id": {ID: "authz-id", ClientID: "app", UserID: "user-id"},
+		}}
+		offlineGrants := &introspectOfflineGrantService{grants: map[string]*oauth.OfflineGrant{}}
+		idpSessions := &introspectIDPSessionProvider{sessions: map[string]*idpsession.IDPSession{
+			"idp-session-id": {ID: "idp-session-id"},
+		}}
+
+		h := &handler.IntrospectHandler{
+			OAuthClientCredentials: &config.OAuthClientCredentials{
+				Items: []config.OAuthClientCredentialsItem{
+					{
+						ClientID:                     "resource-server",
+						OAuthClientCredentialsKeySet: config.OAuthClientCredentialsKeySet{Set: keySet},
+					},
+				},
+			},
+			ClientResolver:      clientResolver,
+			AccessGrants:        accessGrants,
+			Authorizations:      authorizations,
+			OfflineGrantService: offlineGrants,
+			IDPSessions:         idpSessions,
+			AccessTokenDecoder:  introspectAccessTokenDecoder{},
+			Issuer:              introspectIssuer{},
+			RateLimiter:         introspectRateLimiter{},
+			Clock:               clk,
+			RemoteIP:            "1.2.3.4",
+		}
+
+		introspect := func(form url.Values, basicAuth bool) (int, map[string]any) {
+			req, _ := http.NewRequest("POST", "/oauth2/introspect", strings.NewReader(form.Encode()))
+			if basicAuth {
+				req.SetBasicAuth("resource-server", "supersecret")
+			}
+			r := protocol.IntrospectRequest{}
+			for k, v := range form {
+				r[k] = v
+			}
+			result := h.Handle(context.Background(), req, r)
+			rw := httptest.NewRecorder()
+			result.WriteResponse(rw, req)
+
+			var body map[string]any
+			err := json.Unmarshal(rw.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return rw.Code, body
+		}
+
+		Convey("should reject unauthenticated client", func() {
+			code, body := introspect(url.Values{
+				"token":         {"some-token"},
+				"client_id":     {"resource-server"},
+				"client_secret": {"wrong"},
+			}, false)
+			So(code, ShouldEqual, 401)
+			So(body["error"], ShouldEqual, "invalid_client")
+		})
+
+		Convey("should reject public client", func() {
+			code, body := introspect(url.Values{
+				"token":     {"some-token"},
+				"client_id": {"app"},
+			}, false)
+			So(code, ShouldEqual, 401)
+			So(body["error"], ShouldEqual, "invalid_client")
+		})
+
+		Convey("should return inactive for unknown token", func() {
+			code, body := introspect(url.Values{
+				"token": {"some-token"},
+			}, true)
+			So(code, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]any{"active": false})
+		})
+
+		Convey("should introspect access token of IDP session", func() {
+			accessGrants.grants[oauth.HashToken("access-token")] = &oauth.AccessGrant{
+				AuthorizationID: "authz-id",
+				SessionID:       "idp-session-id",
+				SessionKind:     oauth.GrantSessionKindSession,
+				CreatedAt:       now,
+				ExpireAt:        now.Add(time.Hour),
+				Scopes:          []string{"openid", "offline_access"},
+				TokenHash:       oauth.HashToken("access-token"),
+			}
+
+			code, body := introspect(url.Values{
+				"token": {"access-token"},
+			}, true)
+			So(code, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]any{
+				"active":     true,
+				"token_type": "Bearer",
+				"iss":        "http://accounts.example.com",
+				"client_id":  "app",
+				"sub":        "user-id",
+				"scope":      "openid offline_access",
+				"iat":        float64(now.Unix()),
+				"exp":        float64(now.Add(time.Hour).Unix()),
+				"sid":        oauth.EncodeSIDByRawValues(session.TypeIdentityProvider, "idp-session-id"),
+			})
+		})
+
+		Convey("should introspect DPoP-bound refresh token", func() {
+			offlineGrants.grants["offline-grant-id"] = &oauth.OfflineGrant{
+				ID:              "offline-grant-id",
+				InitialClientID: "app",
+				CreatedAt:       now,
+				Attrs:           session.Attrs{UserID: "user-id"},
+				RefreshTokens: []oauth.OfflineGrantRefreshToken{
+					{
+						InitialTokenHash: oauth.HashToken("refresh-token"),
+						ClientID:         "app",
+						CreatedAt:        now,
+						Scopes:           []string{"openid", "offline_access"},
+						AuthorizationID:  "authz-id",
+						DPoPJKT:          "jkt",
+					},
+				},
+				ExpireAtForResolvedSession: now.Add(24 * time.Hour),
+			}
+
+			code, body := introspect(url.Values{
+				"token":           {oauth.EncodeRefreshToken("refresh-token", "offline-grant-id")},
+				"token_type_hint": {"refresh_token"},
+			}, true)
+			So(code, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]any{
+				"active":    true,
+				"iss":       "http://accounts.example.com",
+				"client_id": "app",
+				"sub":       "user-id",
+				"scope":     "openid offline_access",
+				"iat":       float64(now.Unix()),
+				"exp":       float64(now.Add(24 * time.Hour).Unix()),
+				"sid":       oauth.EncodeSIDByRawValues(session.TypeOfflineGrant, "offline-grant-id"),
+				"cnf":       map[string]any{"jkt": "jkt"},
+			})
+		})
+	})
+}
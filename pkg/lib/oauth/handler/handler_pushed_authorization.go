@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+var PushedAuthorizationHandlerLogger = slogutil.NewLogger("oauth-pushed-authorization")
+
+type PushedAuthorizationHandlerAuthorizationValidator interface {
+	ValidateRequestWithoutTx(ctx context.Context, r protocol.AuthorizationRequest) (context.Context, *AuthorizationParams, *AuthorizationResultError)
+}
+
+type PushedAuthorizationHandlerRequestResolver interface {
+	ResolveRequestObject(ctx context.Context, client *config.OAuthClientConfig, requestObject string) (protocol.AuthorizationRequest, error)
+}
+
+type PushedAuthorizationHandlerPushedAuthorizationRequestStore interface {
+	CreatePushedAuthorizationRequest(ctx context.Context, r *oauth.PushedAuthorizationRequest) error
+}
+
+type PushedAuthorizationHandlerRateLimiter interface {
+	Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error)
+}
+
+// PushedAuthorizationHandler implements the pushed authorization request endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc9126#section-2
+type PushedAuthorizationHandler struct {
+	AppID                       config.AppID
+	OAuthClientCredentials      *config.OAuthClientCredentials
+	ClientResolver              OAuthClientResolver
+	ClientAuthenticator         ClientAuthenticator
+	Authorizations              PushedAuthorizationHandlerAuthorizationValidator
+	RequestResolver             PushedAuthorizationHandlerRequestResolver
+	PushedAuthorizationRequests PushedAuthorizationHandlerPushedAuthorizationRequestStore
+	RateLimiter                 PushedAuthorizationHandlerRateLimiter
+	Clock                       clock.Clock
+	RemoteIP                    httputil.RemoteIP
+}
+
+func (h *PushedAuthorizationHandler) Handle(ctx context.Context, req *http.Request, r protocol.PushedAuthorizationRequest) httputil.Result {
+	logger := PushedAuthorizationHandlerLogger.GetLogger(ctx)
+	if err := applyClientAssertion(url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := applyClientSecretBasic(req, url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	if err := checkRateLimit(ctx, h.RateLimiter, NewBucketSpecOAuthPushedAuthorizationPerIP(string(h.RemoteIP))); err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	client, err := h.authenticateClient(ctx, req, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	authzReq, err := h.resolveAuthorizationRequest(ctx, client, r)
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	// Run the same checks as the authorization endpoint,
+	// so that the client learns about an invalid request before redirecting the user agent.
+	_, _, errResult := h.Authorizations.ValidateRequestWithoutTx(ctx, authzReq)
+	if errResult != nil {
+		if errResult.InternalError {
+			return tokenResultError{
+				InternalError: true,
+				Response:      errResult.Response,
+			}
+		}
+		// The state is meaningless in a back channel response.
+		delete(errResult.Response, "state")
+		return tokenResultError{Response: errResult.Response}
+	}
+
+	now := h.Clock.NowUTC()
+	requestURI := oauth.NewPushedAuthorizationRequestURI()
+	err = h.PushedAuthorizationRequests.CreatePushedAuthorizationRequest(ctx, &oauth.PushedAuthorizationRequest{
+		AppID:                string(h.AppID),
+		ClientID:             client.ClientID,
+		RequestURIHash:       oauth.HashToken(requestURI),
+		CreatedAt:            now,
+		ExpireAt:             now.Add(oauth.PushedAuthorizationRequestLifetime),
+		AuthorizationRequest: authzReq,
+	})
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
+	resp := protocol.PushedAuthorizationResponse{}
+	resp.RequestURI(requestURI)
+	resp.ExpiresIn(int(oauth.PushedAuthorizationRequestLifetime / time.Second))
+	return tokenResultOK{
+		StatusCode: http.StatusCreated,
+		Response:   protocol.TokenResponse(resp),
+	}
+}
+
+func (h *PushedAuthorizationHandler) authenticateClient(ctx context.Context, req *http.Request, r protocol.PushedAuthorizationRequest) (*config.OAuthClientConfig, error) {
+	_, client := resolveClient(ctx, h.ClientResolver, r.ClientID())
+	if client == nil {
+		return nil, protocol.NewErrorStatusCode("invalid_client", "invalid client ID", http.StatusUnauthorized)
+	}
+
+	ctx, err := authenticateClientWithKeys(ctx, h.ClientAuthenticator, req, client, url.Values(r))
+	if err != nil {
+		return nil, err
+	}
+
+	if client.IsConfidential() && !isClientAuthenticated(ctx) {
+		if r.ClientSecret() == "" {
+			return nil, protocol.NewErrorStatusCode("invalid_client", "client secret is required", http.StatusUnauthorized)
+		}
+		if _, err := validateClientSecret(h.OAuthClientCredentials, client, r.ClientSecret()); err != nil {
+			return nil, protocol.NewErrorStatusCode("invalid_client", "invalid client secret", http.StatusUnauthorized)
+		}
+	}
+
+	return client, nil
+}
+
+func (h *PushedAuthorizationHandler) resolveAuthorizationRequest(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	r protocol.PushedAuthorizationRequest,
+) (protocol.AuthorizationRequest, error) {
+	authzReq := r.AuthorizationRequest()
+
+	if authzReq.RequestURI() != "" {
+		return nil, protocol.NewError("invalid_request", "request_uri is not allowed in pushed authorization request")
+	}
+
+	if authzReq.Request() != "" {
+		return h.RequestResolver.ResolveRequestObject(ctx, client, authzReq.Request())
+	}
+
+	return authzReq, nil
+}
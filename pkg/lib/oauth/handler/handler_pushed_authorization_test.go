@@ -0,0 +1,336 @@
+package handler_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+type pushedAuthorizationRequestStore struct {
+	requests map[string]*oauth.PushedAuthorizationRequest
+}
+
+func (s *pushedAuthorizationRequestStore) CreatePushedAuthorizationRequest(ctx context.Context, r *oauth.PushedAuthorizationRequest) error {
+	rr := *r
+	s.requests[r.RequestURIHash] = &rr
+	return nil
+}
+
+func (s *pushedAuthorizationRequestStore) ConsumePushedAuthorizationRequest(ctx context.Context, requestURIHash string) (*oauth.PushedAuthorizationRequest, error) {
+	r, ok := s.requests[requestURIHash]
+	if !ok {
+		return nil, oauth.ErrGrantNotFound
+	}
+	delete(s.requests, requestURIHash)
+	return r, nil
+}
+
+type pushedAuthorizationIssuer struct{}
+
+func (pushedAuthorizationIssuer) Iss() string {
+	return "http://accounts.example.com"
+}
+
+func newRequestObjectKey() (jwk.Key, map[string]any) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	So(err, ShouldBeNil)
+	key, err := jwk.FromRaw(privKey)
+	So(err, ShouldBeNil)
+	_ = key.Set(jwk.KeyIDKey, "key-1")
+
+	pubKey, err := key.PublicKey()
+	So(err, ShouldBeNil)
+	b, err := json.Marshal(pubKey)
+	So(err, ShouldBeNil)
+	var m map[string]any
+	err = json.Unmarshal(b, &m)
+	So(err, ShouldBeNil)
+
+	return key, m
+}
+
+func TestPushedAuthorizationHandler(t *testing.T) {
+	Convey("Pushed authorization request", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		key, publicJWK := newRequestObjectKey()
+
+		clientResolver := &multiClientResolver{
+			ClientConfigs: map[string]*config.OAuthClientConfig{
+				"app": {
+					ClientID:        "app",
+					ApplicationType: config.OAuthClientApplicationTypeNative,
+					RedirectURIs:    []string{"https://example.com/callback"},
+					JWKS: &config.OAuthClientJWKS{
+						Keys: []map[string]any{publicJWK},
+					},
+				},
+				"fapi": {
+					ClientID:                           "fapi",
+					ApplicationType:                    config.OAuthClientApplicationTypeNative,
+					RedirectURIs:                       []string{"https://example.com/callback"},
+					RequirePushedAuthorizationRequests: true,
+				},
+				"confidential": {
+					ClientID:        "confidential",
+					ApplicationType: config.OAuthClientApplicationTypeConfidential,
+					RedirectURIs:    []string{"https://example.com/callback"},
+				},
+			},
+		}
+		store := &pushedAuthorizationRequestStore{requests: map[string]*oauth.PushedAuthorizationRequest{}}
+		resolver := &handler.AuthorizationRequestResolver{
+			Clock:                       clk,
+			Issuer:                      pushedAuthorizationIssuer{},
+			PushedAuthorizationRequests: store,
+			// The test server listens on the loopback address.
+			HTTPClient: handler.ClientJWKSHTTPClient{Client: &http.Client{}},
+		}
+		authzHandler := &handler.AuthorizationHandler{
+			AppID:           "app-id",
+			Config:          &config.OAuthConfig{},
+			HTTPOrigin:      "http://accounts.example.com",
+			Clock:           clk,
+			ClientResolver:  clientResolver,
+			RequestResolver: resolver,
+		}
+
+		h := &handler.PushedAuthorizationHandler{
+			AppID:                       "app-id",
+			OAuthClientCredentials:      &config.OAuthClientCredentials{},
+			ClientResolver:              clientResolver,
+			Authorizations:              authzHandler,
+			RequestResolver:             resolver,
+			PushedAuthorizationRequests: store,
+			RateLimiter:                 introspectRateLimiter{},
+			Clock:                       clk,
+			RemoteIP:                    "1.2.3.4",
+		}
+
+		push := func(form url.Values) (int, map[string]any) {
+			req, _ := http.NewRequest("POST", "/oauth2/par", nil)
+			result := h.Handle(context.Background(), req, protocol.PushedAuthorizationRequest(form))
+			rw := httptest.NewRecorder()
+			result.WriteResponse(rw, req)
+
+			var body map[string]any
+			err := json.Unmarshal(rw.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return rw.Code, body
+		}
+
+		signRequestObject := func(claims map[string]any) string {
+			token, err := jwtutil.BuildFromMap(claims)
+			So(err, ShouldBeNil)
+			b, err := jwtutil.Sign(token, jwa.ES256, key)
+			So(err, ShouldBeNil)
+			return string(b)
+		}
+
+		validForm := url.Values{
+			"client_id":             {"app"},
+			"response_type":         {"code"},
+			"redirect_uri":          {"https://example.com/callback"},
+			"scope":                 {"openid"},
+			"code_challenge":        {"challenge"},
+			"code_challenge_method": {"S256"},
+			"state":                 {"state"},
+		}
+
+		Convey("should push authorization request", func() {
+			code, body := push(validForm)
+			So(code, ShouldEqual, 201)
+			So(body["expires_in"], ShouldEqual, 90)
+
+			requestURI := body["request_uri"].(string)
+			So(oauth.IsPushedAuthorizationRequestURI(requestURI), ShouldBeTrue)
+
+			Convey("should resolve request_uri at the authorization endpoint once", func() {
+				r, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+					"client_id":   "app",
+					"request_uri": requestURI,
+				})
+				So(errResult, ShouldBeNil)
+				So(r.RedirectURI(), ShouldEqual, "https://example.com/callback")
+				So(r.State(), ShouldEqual, "state")
+
+				_, errResult = authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+					"client_id":   "app",
+					"request_uri": requestURI,
+				})
+				So(errResult.Response["error"], ShouldEqual, "invalid_request_uri")
+			})
+
+			Convey("should reject expired request_uri", func() {
+				clk.AdvanceSeconds(int(oauth.PushedAuthorizationRequestLifetime / time.Second))
+				_, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+					"client_id":   "app",
+					"request_uri": requestURI,
+				})
+				So(errResult.Response["error"], ShouldEqual, "invalid_request_uri")
+			})
+
+			Convey("should reject request_uri of another client", func() {
+				_, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+					"client_id":   "fapi",
+					"request_uri": requestURI,
+				})
+				So(errResult.Response["error"], ShouldEqual, "invalid_request_uri")
+			})
+		})
+
+		Convey("should validate the request as the authorization endpoint", func() {
+			form := url.Values{}
+			for k, v := range validForm {
+				form[k] = v
+			}
+			form.Del("code_challenge")
+
+			_, body := push(form)
+			So(body["error"], ShouldEqual, "invalid_request")
+			So(body["error_description"], ShouldEqual, "PKCE code challenge is required for public clients")
+			So(body["state"], ShouldBeNil)
+		})
+
+		Convey("should require client secret for confidential client", func() {
+			code, body := push(url.Values{"client_id": {"confidential"}})
+			So(code, ShouldEqual, 401)
+			So(body["error"], ShouldEqual, "invalid_client")
+		})
+
+		Convey("should reject request_uri in pushed authorization request", func() {
+			_, body := push(url.Values{
+				"client_id":   {"app"},
+				"request_uri": {"urn:ietf:params:oauth:request_uri:foo"},
+			})
+			So(body["error"], ShouldEqual, "invalid_request")
+		})
+
+		Convey("should require pushed authorization request if the client requires so", func() {
+			_, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+				"client_id":     "fapi",
+				"response_type": "code",
+			})
+			So(errResult.Response["error"], ShouldEqual, "invalid_request")
+			So(errResult.RedirectURI, ShouldBeNil)
+		})
+
+		Convey("should resolve signed request object", func() {
+			requestObject := signRequestObject(map[string]any{
+				jwt.IssuerKey:     "app",
+				jwt.AudienceKey:   "http://accounts.example.com",
+				jwt.ExpirationKey: clk.NowUTC().Add(time.Minute).Unix(),
+				"response_type":   "code",
+				"redirect_uri":    "https://example.com/callback",
+				"scope":           "openid",
+				"max_age":         0,
+			})
+
+			r, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+				"client_id": "app",
+				"request":   requestObject,
+				"scope":     "openid offline_access",
+			})
+			So(errResult, ShouldBeNil)
+			So(r, ShouldResemble, protocol.AuthorizationRequest{
+				"client_id":     "app",
+				"response_type": "code",
+				"redirect_uri":  "https://example.com/callback",
+				"scope":         "openid",
+				"max_age":       "0",
+			})
+
+			Convey("should also be accepted by the pushed authorization request endpoint", func() {
+				code, _ := push(url.Values{
+					"client_id": {"app"},
+					"request": {signRequestObject(map[string]any{
+						jwt.IssuerKey:           "app",
+						jwt.AudienceKey:         "http://accounts.example.com",
+						"response_type":         "code",
+						"redirect_uri":          "https://example.com/callback",
+						"scope":                 "openid",
+						"code_challenge":        "challenge",
+						"code_challenge_method": "S256",
+					})},
+				})
+				So(code, ShouldEqual, 201)
+			})
+		})
+
+		Convey("should reject request object with wrong audience", func() {
+			requestObject := signRequestObject(map[string]any{
+				jwt.IssuerKey:   "app",
+				jwt.AudienceKey: "http://other.example.com",
+			})
+			_, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+				"client_id": "app",
+				"request":   requestObject,
+			})
+			So(errResult.Response["error"], ShouldEqual, "invalid_request_object")
+		})
+
+		Convey("should reject request object signed by unknown key", func() {
+			otherKey, _ := newRequestObjectKey()
+			token, err := jwtutil.BuildFromMap(map[string]any{
+				jwt.IssuerKey:   "app",
+				jwt.AudienceKey: "http://accounts.example.com",
+			})
+			So(err, ShouldBeNil)
+			b, err := jwtutil.Sign(token, jwa.ES256, otherKey)
+			So(err, ShouldBeNil)
+
+			_, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+				"client_id": "app",
+				"request":   string(b),
+			})
+			So(errResult.Response["error"], ShouldEqual, "invalid_request_object")
+		})
+
+		Convey("should verify request object with the keys at jwks_uri", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]any{"keys": []any{publicJWK}})
+			}))
+			defer server.Close()
+
+			clientResolver.ClientConfigs["jwks-uri-app"] = &config.OAuthClientConfig{
+				ClientID:        "jwks-uri-app",
+				ApplicationType: config.OAuthClientApplicationTypeNative,
+				RedirectURIs:    []string{"https://example.com/callback"},
+				JWKSURI:         server.URL,
+			}
+
+			requestObject := signRequestObject(map[string]any{
+				jwt.IssuerKey:   "jwks-uri-app",
+				jwt.AudienceKey: "http://accounts.example.com",
+				"response_type": "code",
+				"redirect_uri":  "https://example.com/callback",
+				"scope":         "openid",
+			})
+
+			r, errResult := authzHandler.ResolveRequest(context.Background(), protocol.AuthorizationRequest{
+				"client_id": "jwks-uri-app",
+				"request":   requestObject,
+			})
+			So(errResult, ShouldBeNil)
+			So(r.RedirectURI(), ShouldEqual, "https://example.com/callback")
+		})
+	})
+}
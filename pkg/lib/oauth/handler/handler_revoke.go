@@ -3,7 +3,10 @@ package handler
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/url"
 
+	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
 	"github.com/authgear/authgear-server/pkg/lib/session"
@@ -23,21 +26,84 @@ type RevokeHandlerAccessGrantStore interface {
 	DeleteAccessGrant(ctx context.Context, g *oauth.AccessGrant) error
 }
 
+type RevokeHandlerAuthorizationStore interface {
+	GetByID(ctx context.Context, id string) (*oauth.Authorization, error)
+}
+
 type RevokeHandler struct {
-	SessionManager      SessionManager
-	OfflineGrantService RevokeHandlerOfflineGrantService
-	AccessGrants        RevokeHandlerAccessGrantStore
+	OAuthClientCredentials *config.OAuthClientCredentials
+	ClientResolver         OAuthClientResolver
+	ClientAuthenticator    ClientAuthenticator
+	SessionManager         SessionManager
+	OfflineGrantService    RevokeHandlerOfflineGrantService
+	AccessGrants           RevokeHandlerAccessGrantStore
+	Authorizations         RevokeHandlerAuthorizationStore
 }
 
-func (h *RevokeHandler) Handle(ctx context.Context, r protocol.RevokeRequest) error {
+func (h *RevokeHandler) Handle(ctx context.Context, req *http.Request, r protocol.RevokeRequest) error {
+	client, err := h.authenticateClient(ctx, req, r)
+	if err != nil {
+		return err
+	}
+
 	token, grantID, err := oauth.DecodeRefreshToken(r.Token())
 	if err == nil {
-		return h.revokeOfflineGrant(ctx, token, grantID)
+		return h.revokeOfflineGrant(ctx, client, token, grantID)
 	}
-	return h.revokeAccessGrant(ctx, r.Token())
+	return h.revokeAccessGrant(ctx, client, r.Token())
 }
 
-func (h *RevokeHandler) revokeOfflineGrant(ctx context.Context, token, grantID string) error {
+// authenticateClient returns the client if it is a confidential client,
+// or it has authenticated with private_key_jwt or self_signed_tls_client_auth.
+// A confidential client must authenticate.
+// Public clients are not authenticated, for backward compatibility.
+func (h *RevokeHandler) authenticateClient(ctx context.Context, req *http.Request, r protocol.RevokeRequest) (*config.OAuthClientConfig, error) {
+	form := url.Values{}
+	for name, value := range r {
+		form.Set(name, value)
+	}
+	if err := applyClientAssertion(form); err != nil {
+		return nil, err
+	}
+	if err := applyClientSecretBasic(req, form); err != nil {
+		return nil, err
+	}
+
+	_, client := resolveClient(ctx, h.ClientResolver, form.Get("client_id"))
+	if client == nil {
+		if form.Get("client_assertion") != "" || form.Get("client_secret") != "" {
+			return nil, newInvalidClientError("invalid client ID")
+		}
+		return nil, nil
+	}
+
+	ctx, err := authenticateClientWithKeys(ctx, h.ClientAuthenticator, req, client, form)
+	if err != nil {
+		return nil, err
+	}
+	if isClientAuthenticated(ctx) {
+		return client, nil
+	}
+
+	if !client.IsConfidential() {
+		return nil, nil
+	}
+
+	clientSecret := form.Get("client_secret")
+	if clientSecret == "" {
+		return nil, newInvalidClientError("client secret is required")
+	}
+	if _, err := validateClientSecret(h.OAuthClientCredentials, client, clientSecret); err != nil {
+		return nil, newInvalidClientError("invalid client secret")
+	}
+
+	return client, nil
+}
+
+// revokeOfflineGrant revokes the offline grant.
+// If client is not nil, only the offline grant issued to client is revoked.
+// See https://datatracker.ietf.org/doc/html/rfc7009#section-2.1
+func (h *RevokeHandler) revokeOfflineGrant(ctx context.Context, client *config.OAuthClientConfig, token, grantID string) error {
 	offlineGrant, err := h.OfflineGrantService.GetOfflineGrant(ctx, grantID)
 	if errors.Is(err, oauth.ErrGrantNotFound) {
 		return nil
@@ -50,6 +116,13 @@ func (h *RevokeHandler) revokeOfflineGrant(ctx context.Context, token, grantID s
 		return nil
 	}
 
+	if client != nil {
+		offlineGrantSession, ok := offlineGrant.ToSession(tokenHash)
+		if !ok || offlineGrantSession.ClientID != client.ClientID {
+			return nil
+		}
+	}
+
 	err = h.SessionManager.RevokeWithEvent(ctx, offlineGrant, false, false)
 	if err != nil {
 		return err
@@ -58,7 +131,7 @@ func (h *RevokeHandler) revokeOfflineGrant(ctx context.Context, token, grantID s
 	return nil
 }
 
-func (h *RevokeHandler) revokeAccessGrant(ctx context.Context, token string) error {
+func (h *RevokeHandler) revokeAccessGrant(ctx context.Context, client *config.OAuthClientConfig, token string) error {
 	tokenHash := oauth.HashToken(token)
 	accessGrant, err := h.AccessGrants.GetAccessGrant(ctx, tokenHash)
 	if errors.Is(err, oauth.ErrGrantNotFound) {
@@ -67,6 +140,18 @@ func (h *RevokeHandler) revokeAccessGrant(ctx context.Context, token string) err
 		return err
 	}
 
+	if client != nil {
+		authz, err := h.Authorizations.GetByID(ctx, accessGrant.AuthorizationID)
+		if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if authz.ClientID != client.ClientID {
+			return nil
+		}
+	}
+
 	err = h.AccessGrants.DeleteAccessGrant(ctx, accessGrant)
 	if err != nil {
 		return err
@@ -0,0 +1,142 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+)
+
+type revokeAccessGrantStore struct {
+	introspectAccessGrantStore
+}
+
+func (s *revokeAccessGrantStore) DeleteAccessGrant(ctx context.Context, g *oauth.AccessGrant) error {
+	delete(s.grants, g.TokenHash)
+	return nil
+}
+
+func TestRevokeHandler(t *testing.T) {
+	Convey("Revoke handler", t, func() {
+		clientResolver := &multiClientResolver{
+			ClientConfigs: map[string]*config.OAuthClientConfig{
+				"confidential": {
+					ClientID:        "confidential",
+					ApplicationType: config.OAuthClientApplicationTypeConfidential,
+				},
+				"app": {
+					ClientID:        "app",
+					ApplicationType: config.OAuthClientApplicationTypeNative,
+				},
+			},
+		}
+
+		key, err := jwk.FromRaw([]byte("supersecret"))
+		So(err, ShouldBeNil)
+		keySet := jwk.NewSet()
+		_ = keySet.AddKey(key)
+
+		accessGrants := &revokeAccessGrantStore{introspectAccessGrantStore{grants: map[string]*oauth.AccessGrant{}}}
+		authorizations := &introspectAuthorizationStore{authzs: map[string]*oauth.Authorization{
+			"confidential-authz-id": {ID: "confidential-authz-id", ClientID: "confidential", UserID: "user-id"},
+			"app-authz-id":          {ID: "app-authz-id", ClientID: "app", UserID: "user-id"},
+		}}
+
+		h := &handler.RevokeHandler{
+			OAuthClientCredentials: &config.OAuthClientCredentials{
+				Items: []config.OAuthClientCredentialsItem{
+					{
+						ClientID:                     "confidential",
+						OAuthClientCredentialsKeySet: config.OAuthClientCredentialsKeySet{Set: keySet},
+					},
+				},
+			},
+			ClientResolver: clientResolver,
+			SessionManager: &revokedSessionRecorder{},
+			OfflineGrantService: &introspectOfflineGrantService{
+				grants: map[string]*oauth.OfflineGrant{},
+			},
+			AccessGrants:   accessGrants,
+			Authorizations: authorizations,
+		}
+
+		addAccessGrant := func(token string, authzID string) {
+			accessGrants.grants[oauth.HashToken(token)] = &oauth.AccessGrant{
+				AuthorizationID: authzID,
+				TokenHash:       oauth.HashToken(token),
+			}
+		}
+
+		revoke := func(r protocol.RevokeRequest, basicAuth bool) error {
+			req, _ := http.NewRequest("POST", "/oauth2/revoke", nil)
+			if basicAuth {
+				req.SetBasicAuth("confidential", "supersecret")
+			}
+			return h.Handle(context.Background(), req, r)
+		}
+
+		invalidClient := func(err error) bool {
+			var oauthError *protocol.OAuthProtocolError
+			return errors.As(err, &oauthError) && oauthError.Type() == "invalid_client"
+		}
+
+		Convey("should reject confidential client without client secret", func() {
+			addAccessGrant("access-token", "confidential-authz-id")
+			err := revoke(protocol.RevokeRequest{
+				"token":     "access-token",
+				"client_id": "confidential",
+			}, false)
+			So(invalidClient(err), ShouldBeTrue)
+			So(accessGrants.grants, ShouldHaveLength, 1)
+		})
+
+		Convey("should reject confidential client with wrong client secret", func() {
+			addAccessGrant("access-token", "confidential-authz-id")
+			err := revoke(protocol.RevokeRequest{
+				"token":         "access-token",
+				"client_id":     "confidential",
+				"client_secret": "wrong",
+			}, false)
+			So(invalidClient(err), ShouldBeTrue)
+			So(accessGrants.grants, ShouldHaveLength, 1)
+		})
+
+		Convey("should revoke token of authenticated confidential client", func() {
+			addAccessGrant("access-token", "confidential-authz-id")
+			err := revoke(protocol.RevokeRequest{
+				"token": "access-token",
+			}, true)
+			So(err, ShouldBeNil)
+			So(accessGrants.grants, ShouldHaveLength, 0)
+		})
+
+		Convey("should not revoke token of another client", func() {
+			addAccessGrant("access-token", "app-authz-id")
+			err := revoke(protocol.RevokeRequest{
+				"token":         "access-token",
+				"client_id":     "confidential",
+				"client_secret": "supersecret",
+			}, false)
+			So(err, ShouldBeNil)
+			So(accessGrants.grants, ShouldHaveLength, 1)
+		})
+
+		Convey("should revoke token of public client without authentication", func() {
+			addAccessGrant("access-token", "app-authz-id")
+			err := revoke(protocol.RevokeRequest{
+				"token":     "access-token",
+				"client_id": "app",
+			}, false)
+			So(err, ShouldBeNil)
+			So(accessGrants.grants, ShouldHaveLength, 0)
+		})
+	})
+}
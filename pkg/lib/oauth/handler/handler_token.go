@@ -105,6 +105,18 @@ type TokenHandlerSettingsActionGrantStore interface {
 	DeleteSettingsActionGrant(ctx context.Context, g *oauth.SettingsActionGrant) error
 }
 
+type TokenHandlerDeviceGrantStore interface {
+	GetDeviceGrant(ctx context.Context, deviceCodeHash string) (*oauth.DeviceGrant, error)
+	UpdateDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error
+	DeleteDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error
+}
+
+type TokenHandlerCIBAGrantStore interface {
+	GetCIBAGrant(ctx context.Context, authReqIDHash string) (*oauth.CIBAGrant, error)
+	UpdateCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error
+	DeleteCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error
+}
+
 type TokenHandlerOfflineGrantStore interface {
 	DeleteOfflineGrant(ctx context.Context, g *oauth.OfflineGrant) error
 
@@ -166,6 +178,7 @@ var _ TokenHandlerTokenService = &TokenService{}
 
 type TokenHandlerIDPSessionProvider interface {
 	Get(ctx context.Context, id string) (*idpsession.IDPSession, error)
+	AddOIDCClientParticipant(ctx context.Context, session *idpsession.IDPSession, clientID string) (*idpsession.IDPSession, error)
 }
 
 type PreAuthenticatedURLTokenService interface {
@@ -222,6 +235,8 @@ type TokenHandler struct {
 	Authorizations                  AuthorizationService
 	CodeGrants                      TokenHandlerCodeGrantStore
 	SettingsActionGrantStore        TokenHandlerSettingsActionGrantStore
+	DeviceGrants                    TokenHandlerDeviceGrantStore
+	CIBAGrants                      TokenHandlerCIBAGrantStore
 	IDPSessions                     TokenHandlerIDPSessionProvider
 	OfflineGrants                   TokenHandlerOfflineGrantStore
 	AppSessionTokens                TokenHandlerAppSessionTokenStore
@@ -240,6 +255,7 @@ type TokenHandler struct {
 	Challenges                      ChallengeProvider
 	CodeGrantService                TokenHandlerCodeGrantService
 	ClientResolver                  OAuthClientResolver
+	ClientAuthenticator             ClientAuthenticator
 	UIInfoResolver                  UIInfoResolver
 	RateLimiter                     TokenHandlerRateLimiter
 
@@ -250,46 +266,17 @@ type TokenHandler struct {
 func (h *TokenHandler) Handle(ctx context.Context, rw http.ResponseWriter, req *http.Request, r protocol.TokenRequest) httputil.Result {
 
 	logger := TokenHandlerLogger.GetLogger(ctx)
-	errorResult := func(err error) httputil.Result {
-		var oauthError *protocol.OAuthProtocolError
-		resultErr := tokenResultError{}
-		if errors.As(err, &oauthError) {
-			resultErr.StatusCode = oauthError.StatusCode
-			resultErr.Response = oauthError.Response
-		} else {
-			logger.WithError(err).Error(ctx, "token handler failed")
-			resultErr.Response = protocol.NewErrorResponse("server_error", "internal server error")
-			resultErr.InternalError = true
-		}
-		return resultErr
-	}
-
-	// Prefer client authentication in the request body over the Authorization
-	// header: an Authorization header may come from an unrelated proxy (e.g.
-	// one protecting a staging environment with HTTP Basic auth) rather than
-	// the client itself, so it must not take precedence over, or conflict
-	// with, credentials the client actually placed in the body.
-	if r.ClientID() == "" && r.ClientSecret() == "" {
-		if username, password, ok := req.BasicAuth(); ok {
-			// RFC 6749 Appendix B: client_id and client_secret are encoded with
-			// application/x-www-form-urlencoded before being used in HTTP Basic auth.
-			clientID, err := url.QueryUnescape(username)
-			if err != nil {
-				return errorResult(protocol.NewError("invalid_request", "invalid client_id in Authorization header"))
-			}
-			clientSecret, err := url.QueryUnescape(password)
-			if err != nil {
-				return errorResult(protocol.NewError("invalid_request", "invalid client_secret in Authorization header"))
-			}
+	if err := applyClientAssertion(url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
+	}
 
-			r["client_id"] = []string{clientID}
-			r["client_secret"] = []string{clientSecret}
-		}
+	if err := applyClientSecretBasic(req, url.Values(r)); err != nil {
+		return errorResult(ctx, logger, err)
 	}
 
 	ipRateLimitBucket := NewBucketSpecOAuthTokenPerIP(string(h.RemoteIP))
 	if err := h.checkRateLimit(ctx, ipRateLimitBucket); err != nil {
-		return errorResult(err)
+		return errorResult(ctx, logger, err)
 	}
 	ctx, client := resolveClient(ctx, h.ClientResolver, r.ClientID())
 	if client == nil {
@@ -298,10 +285,14 @@ func (h *TokenHandler) Handle(ctx context.Context, rw http.ResponseWriter, req *
 		}
 	}
 
-	var err error
+	ctx, err := authenticateClientWithKeys(ctx, h.ClientAuthenticator, req, client, url.Values(r))
+	if err != nil {
+		return errorResult(ctx, logger, err)
+	}
+
 	var handleResult *HandleResult
-	if err := h.validateRequestWithoutTx(r, client); err != nil {
-		return errorResult(err)
+	if err := h.validateRequestWithoutTx(ctx, r, client); err != nil {
+		return errorResult(ctx, logger, err)
 	}
 
 	err = h.Database.WithTx(ctx, func(ctx context.Context) error {
@@ -310,7 +301,7 @@ func (h *TokenHandler) Handle(ctx context.Context, rw http.ResponseWriter, req *
 		return handleErr
 	})
 	if err != nil {
-		return errorResult(err)
+		return errorResult(ctx, logger, err)
 	}
 
 	if handleResult.PrepareIDTokenResult != nil {
@@ -318,7 +309,7 @@ func (h *TokenHandler) Handle(ctx context.Context, rw http.ResponseWriter, req *
 			PreparationResult: handleResult.PrepareIDTokenResult,
 		})
 		if err != nil {
-			return errorResult(err)
+			return errorResult(ctx, logger, err)
 		}
 
 		if handleResult.Response != nil {
@@ -334,7 +325,7 @@ func (h *TokenHandler) Handle(ctx context.Context, rw http.ResponseWriter, req *
 		})
 		if err != nil {
 			err = h.translateAccessTokenError(ctx, err)
-			return errorResult(err)
+			return errorResult(ctx, logger, err)
 		}
 
 		result2.WriteTo(handleResult.Response)
@@ -387,13 +378,17 @@ func (h *TokenHandler) doHandleWithTx(
 		return h.handleSettingsActionCode(ctx, client, r)
 	case oauth.ClientCredentialsGrantType:
 		return h.handleClientCredentials(ctx, client, r)
+	case oauth.DeviceCodeGrantType:
+		return h.handleDeviceCode(ctx, client, r)
+	case oauth.CIBAGrantType:
+		return h.handleCIBA(ctx, client, r)
 	default:
 		panic("oauth: unexpected grant type")
 	}
 }
 
 // nolint:gocognit
-func (h *TokenHandler) validateRequestWithoutTx(r protocol.TokenRequest, client *config.OAuthClientConfig) error {
+func (h *TokenHandler) validateRequestWithoutTx(ctx context.Context, r protocol.TokenRequest, client *config.OAuthClientConfig) error {
 	switch r.GrantType() {
 	case oauth.SettingsActionGrantType:
 		fallthrough
@@ -406,7 +401,7 @@ func (h *TokenHandler) validateRequestWithoutTx(r protocol.TokenRequest, client
 				return protocol.NewError("invalid_request", "PKCE code verifier is required")
 			}
 		}
-		if client.IsConfidential() {
+		if client.IsConfidential() && !isClientAuthenticated(ctx) {
 			if r.ClientSecret() == "" {
 				return protocol.NewError("invalid_client", "client secret is required")
 			}
@@ -449,7 +444,23 @@ func (h *TokenHandler) validateRequestWithoutTx(r protocol.TokenRequest, client
 		if r.Resource() == "" {
 			return protocol.NewError("invalid_target", "resource is required")
 		}
-		if r.ClientSecret() == "" {
+		if r.ClientSecret() == "" && !isClientAuthenticated(ctx) {
+			return protocol.NewError("invalid_client", "client secret is required")
+		}
+	case oauth.DeviceCodeGrantType:
+		if r.DeviceCode() == "" {
+			return protocol.NewError("invalid_request", "device code is required")
+		}
+		if client.IsConfidential() && !isClientAuthenticated(ctx) {
+			if r.ClientSecret() == "" {
+				return protocol.NewError("invalid_client", "client secret is required")
+			}
+		}
+	case oauth.CIBAGrantType:
+		if r.AuthReqID() == "" {
+			return protocol.NewError("invalid_request", "auth_req_id is required")
+		}
+		if r.ClientSecret() == "" && !isClientAuthenticated(ctx) {
 			return protocol.NewError("invalid_client", "client secret is required")
 		}
 	default:
@@ -653,7 +664,7 @@ func (h *TokenHandler) IssueTokensForAuthorizationCode(
 	}
 
 	// verify client secret
-	needClientSecret := client.IsConfidential()
+	needClientSecret := client.IsConfidential() && !isClientAuthenticated(ctx)
 	if needClientSecret {
 		if _, err := h.validateClientSecret(client, r.ClientSecret()); err != nil {
 			return nil, err
@@ -742,6 +753,11 @@ func (h *TokenHandler) handleRefreshToken(
 		return nil, ErrInvalidRefreshToken
 	}
 
+	offlineGrantSession, err = h.dispatchOAuthTokenPreRefreshEvent(ctx, client, offlineGrantSession, deviceInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	handleResult, err := h.issueTokensForRefreshToken(ctx, client, offlineGrantSession, authz)
 	if err != nil {
 		// NOTE(DEV-2982): This is for debugging the session lost problem
@@ -1672,6 +1688,15 @@ func (h *TokenHandler) handleIDToken(
 		return nil, err
 	}
 
+	// The sid of the ID token is the IDP session,
+	// so the IDP session has to remember the client for back-channel and front-channel logout.
+	if idpSession, ok := s.(*idpsession.IDPSession); ok && (client.BackchannelLogoutURI != "" || client.FrontchannelLogoutURI != "") {
+		_, err = h.IDPSessions.AddOIDCClientParticipant(ctx, idpSession, client.ClientID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &HandleResult{
 		PrepareIDTokenResult: prepareIDTokenResult,
 		Response:             resp,
@@ -2008,6 +2033,63 @@ func (h *TokenHandler) doIssueTokensForAuthorizationCode(
 	}, nil
 }
 
+// dispatchOAuthTokenPreRefreshEvent lets blocking hooks deny the refresh, or
+// narrow the scopes of the tokens issued by this refresh.
+// When the refresh is denied, the offline grant is revoked.
+func (h *TokenHandler) dispatchOAuthTokenPreRefreshEvent(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	offlineGrantSession *oauth.OfflineGrantSession,
+	deviceInfo map[string]any,
+) (*oauth.OfflineGrantSession, error) {
+	offlineGrant := offlineGrantSession.OfflineGrant
+	payload := &blocking.OAuthTokenPreRefreshBlockingEventPayload{
+		UserRef: model.UserRef{
+			Meta: model.Meta{
+				ID: offlineGrant.GetUserID(),
+			},
+		},
+		OfflineGrant: blocking.OAuthOfflineGrant{
+			ID:              offlineGrant.ID,
+			CreatedAt:       offlineGrantSession.CreatedAt,
+			AuthenticatedAt: offlineGrant.GetAuthenticatedAt(),
+			Scopes:          slices.Clone(offlineGrantSession.Scopes),
+		},
+		ClientID:   client.ClientID,
+		DeviceInfo: deviceInfo,
+		IPAddress:  string(h.RemoteIP),
+	}
+
+	err := h.Events.DispatchEventOnCommit(ctx, payload)
+	if apierrors.IsKind(err, hook.HookDisallowed) {
+		// The hook denying the refresh is a policy decision, not a failure.
+		logger := TokenHandlerLogger.GetLogger(ctx)
+		logger.Info(ctx, "refresh token exchange denied by hook",
+			slog.String("offline_grant_id", offlineGrant.ID),
+			slog.String("user_id", offlineGrant.GetUserID()),
+			slog.String("client_id", client.ClientID),
+		)
+
+		// Offline grants are not stored in the database,
+		// so the revocation persists even though the transaction is rolled back.
+		revokeErr := h.SessionManager.RevokeWithoutEvent(ctx, offlineGrant)
+		if revokeErr != nil {
+			return nil, revokeErr
+		}
+		return nil, protocol.NewError("invalid_grant", "refresh token is revoked by hook")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if slices.Equal(payload.OfflineGrant.Scopes, offlineGrantSession.Scopes) {
+		return offlineGrantSession, nil
+	}
+
+	narrowed := *offlineGrantSession
+	narrowed.Scopes = payload.OfflineGrant.Scopes
+	return &narrowed, nil
+}
+
 func (h *TokenHandler) issueTokensForRefreshToken(
 	ctx context.Context,
 	client *config.OAuthClientConfig,
@@ -2128,6 +2210,207 @@ func (h *TokenHandler) shouldIssueDeviceSecret(scopes []string) bool {
 	return issueDeviceToken
 }
 
+var errInvalidDeviceCode = protocol.NewError("invalid_grant", "invalid device code")
+
+// nolint:gocognit
+func (h *TokenHandler) handleDeviceCode(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	r protocol.TokenRequest,
+) (*HandleResult, error) {
+	deviceInfo, err := r.DeviceInfo()
+	if err != nil {
+		return nil, protocol.NewError("invalid_request", err.Error())
+	}
+
+	deviceGrant, err := h.DeviceGrants.GetDeviceGrant(ctx, oauth.HashToken(r.DeviceCode()))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	if deviceGrant.ClientID != client.ClientID {
+		return nil, errInvalidDeviceCode
+	}
+
+	if client.IsConfidential() && !isClientAuthenticated(ctx) {
+		if _, err := h.validateClientSecret(client, r.ClientSecret()); err != nil {
+			return nil, err
+		}
+	}
+
+	now := h.Clock.NowUTC()
+	if !now.Before(deviceGrant.ExpireAt) {
+		return nil, protocol.NewError("expired_token", "device code is expired")
+	}
+
+	switch deviceGrant.Status {
+	case oauth.DeviceGrantStatusPending:
+		// See https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+		tooFast := deviceGrant.LastPolledAt != nil &&
+			now.Before(deviceGrant.LastPolledAt.Add(time.Duration(deviceGrant.Interval)*time.Second))
+		if tooFast {
+			deviceGrant.Interval += DeviceGrantSlowDownIncrement
+		}
+		deviceGrant.LastPolledAt = &now
+		err := h.DeviceGrants.UpdateDeviceGrant(ctx, deviceGrant)
+		if errors.Is(err, oauth.ErrGrantConflict) {
+			// The user has just made a decision. The next poll will see it.
+			return nil, protocol.NewError("authorization_pending", "the user has not yet completed authorization")
+		} else if errors.Is(err, oauth.ErrGrantNotFound) {
+			return nil, errInvalidDeviceCode
+		} else if err != nil {
+			return nil, err
+		}
+		if tooFast {
+			return nil, protocol.NewError("slow_down", "polling too frequently")
+		}
+		return nil, protocol.NewError("authorization_pending", "the user has not yet completed authorization")
+	case oauth.DeviceGrantStatusDenied:
+		err := h.DeviceGrants.DeleteDeviceGrant(ctx, deviceGrant)
+		if err != nil && !errors.Is(err, oauth.ErrGrantNotFound) {
+			return nil, err
+		}
+		return nil, protocol.NewError("access_denied", "the user denied the authorization request")
+	case oauth.DeviceGrantStatusApproved:
+		break
+	default:
+		panic(fmt.Errorf("oauth: unexpected device grant status: %v", deviceGrant.Status))
+	}
+
+	authz, err := h.Authorizations.GetByID(ctx, deviceGrant.AuthorizationID)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := h.checkUserRateLimit(ctx, authz.UserID); err != nil {
+		return nil, err
+	}
+
+	// Delete the grant first so that a device code can only be exchanged once.
+	err = h.DeviceGrants.DeleteDeviceGrant(ctx, deviceGrant)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	codeGrant := &oauth.CodeGrant{
+		AppID:              deviceGrant.AppID,
+		AuthorizationID:    deviceGrant.AuthorizationID,
+		AuthenticationInfo: deviceGrant.AuthenticationInfo,
+		CreatedAt:          deviceGrant.CreatedAt,
+		ExpireAt:           deviceGrant.ExpireAt,
+		AuthorizationRequest: protocol.AuthorizationRequest{
+			"client_id": deviceGrant.ClientID,
+			"scope":     strings.Join(deviceGrant.Scopes, " "),
+		},
+		IdentitySpecs: deviceGrant.IdentitySpecs,
+	}
+
+	// The device grant is exchanged like an authorization code,
+	// except that there is no redirect URI and PKCE.
+	return h.doIssueTokensForAuthorizationCode(ctx, client, codeGrant, authz, deviceInfo, "")
+}
+
+var errInvalidAuthReqID = protocol.NewError("invalid_grant", "invalid auth_req_id")
+
+// handleCIBA exchanges auth_req_id for tokens.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.1
+// nolint:gocognit
+func (h *TokenHandler) handleCIBA(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	r protocol.TokenRequest,
+) (*HandleResult, error) {
+	deviceInfo, err := r.DeviceInfo()
+	if err != nil {
+		return nil, protocol.NewError("invalid_request", err.Error())
+	}
+
+	cibaGrant, err := h.CIBAGrants.GetCIBAGrant(ctx, oauth.HashToken(r.AuthReqID()))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		// The grant is removed from the store when it expires.
+		return nil, protocol.NewError("expired_token", "auth_req_id is expired")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if cibaGrant.ClientID != client.ClientID {
+		return nil, errInvalidAuthReqID
+	}
+
+	if !isClientAuthenticated(ctx) {
+		if _, err := h.validateClientSecret(client, r.ClientSecret()); err != nil {
+			return nil, err
+		}
+	}
+
+	now := h.Clock.NowUTC()
+	if !now.Before(cibaGrant.ExpireAt) {
+		return nil, protocol.NewError("expired_token", "auth_req_id is expired")
+	}
+
+	switch cibaGrant.Status {
+	case oauth.CIBAGrantStatusPending:
+		tooFast := cibaGrant.LastPolledAt != nil &&
+			now.Before(cibaGrant.LastPolledAt.Add(time.Duration(cibaGrant.Interval)*time.Second))
+		if tooFast {
+			cibaGrant.Interval += CIBAGrantSlowDownIncrement
+		}
+		cibaGrant.LastPolledAt = &now
+		if err := h.CIBAGrants.UpdateCIBAGrant(ctx, cibaGrant); err != nil {
+			return nil, err
+		}
+		if tooFast {
+			return nil, protocol.NewError("slow_down", "polling too frequently")
+		}
+		return nil, protocol.NewError("authorization_pending", "the user has not yet been authenticated")
+	case oauth.CIBAGrantStatusDenied:
+		if err := h.CIBAGrants.DeleteCIBAGrant(ctx, cibaGrant); err != nil {
+			return nil, err
+		}
+		return nil, protocol.NewError("access_denied", "the user denied the authentication request")
+	case oauth.CIBAGrantStatusApproved:
+		break
+	default:
+		panic(fmt.Errorf("oauth: unexpected CIBA grant status: %v", cibaGrant.Status))
+	}
+
+	authz, err := h.Authorizations.GetByID(ctx, cibaGrant.AuthorizationID)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return nil, errInvalidAuthReqID
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := h.checkUserRateLimit(ctx, authz.UserID); err != nil {
+		return nil, err
+	}
+
+	// Delete the grant first so that auth_req_id can only be exchanged once.
+	if err := h.CIBAGrants.DeleteCIBAGrant(ctx, cibaGrant); err != nil {
+		return nil, err
+	}
+
+	codeGrant := &oauth.CodeGrant{
+		AppID:              cibaGrant.AppID,
+		AuthorizationID:    cibaGrant.AuthorizationID,
+		AuthenticationInfo: cibaGrant.AuthenticationInfo,
+		CreatedAt:          cibaGrant.CreatedAt,
+		ExpireAt:           cibaGrant.ExpireAt,
+		AuthorizationRequest: protocol.AuthorizationRequest{
+			"client_id": cibaGrant.ClientID,
+			"scope":     strings.Join(cibaGrant.Scopes, " "),
+		},
+	}
+
+	return h.doIssueTokensForAuthorizationCode(ctx, client, codeGrant, authz, deviceInfo, "")
+}
+
 func (h *TokenHandler) handleSettingsActionCode(
 	ctx context.Context,
 	client *config.OAuthClientConfig,
@@ -2186,7 +2469,7 @@ func (h *TokenHandler) IssueTokensForSettingsActionCode(
 	}
 
 	// verify client secret
-	needClientSecret := client.IsConfidential()
+	needClientSecret := client.IsConfidential() && !isClientAuthenticated(ctx)
 	if needClientSecret {
 		if r.ClientSecret() == "" {
 			return nil, protocol.NewError("invalid_client", "invalid client secret")
@@ -2238,8 +2521,10 @@ func (h *TokenHandler) handleClientCredentials(
 
 	var maskedSecret string
 	var err error
-	if maskedSecret, err = h.validateClientSecret(client, r.ClientSecret()); err != nil {
-		return nil, err
+	if !isClientAuthenticated(ctx) {
+		if maskedSecret, err = h.validateClientSecret(client, r.ClientSecret()); err != nil {
+			return nil, err
+		}
 	}
 
 	if r.Resource() == "" {
@@ -2292,23 +2577,7 @@ func (h *TokenHandler) handleClientCredentials(
 }
 
 func (h *TokenHandler) validateClientSecret(client *config.OAuthClientConfig, clientSecret string) (maskedSecret string, err error) {
-	credentialsItem, ok := h.OAuthClientCredentials.Lookup(client.ClientID)
-	if !ok {
-		return "", protocol.NewError("invalid_request", "client secret is not supported for the client")
-	}
-
-	keys := credentialsItem.Keys()
-	for _, secret := range keys {
-		if subtle.ConstantTimeCompare([]byte(clientSecret), secret.Key) == 1 {
-			maskedSecret = secret.Mask()
-			break
-		}
-	}
-	if maskedSecret == "" {
-		return "", protocol.NewError("invalid_request", "invalid client secret")
-	}
-
-	return maskedSecret, nil
+	return validateClientSecret(h.OAuthClientCredentials, client, clientSecret)
 }
 
 func (h *TokenHandler) checkUserRateLimit(ctx context.Context, userID string) error {
@@ -2329,17 +2598,5 @@ func (h *TokenHandler) resolveUserBlockingEventContext(ctx context.Context, user
 }
 
 func (h *TokenHandler) checkRateLimit(ctx context.Context, spec ratelimit.BucketSpec) error {
-	var err error
-
-	failedReservation, allowErr := h.RateLimiter.Allow(ctx, spec)
-	if allowErr != nil {
-		err = allowErr
-	} else if resvErr := failedReservation.Error(); resvErr != nil {
-		err = resvErr
-	}
-
-	if err != nil && apierrors.IsKind(err, ratelimit.RateLimited) {
-		return protocol.NewErrorStatusCode("x_rate_limited", "rate limit exceeded, please try again later.", http.StatusTooManyRequests)
-	}
-	return err
+	return checkRateLimit(ctx, h.RateLimiter, spec)
 }
@@ -393,6 +393,138 @@ func (mr *MockTokenHandlerSettingsActionGrantStoreMockRecorder) GetSettingsActio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettingsActionGrant", reflect.TypeOf((*MockTokenHandlerSettingsActionGrantStore)(nil).GetSettingsActionGrant), ctx, codeHash)
 }
 
+// MockTokenHandlerDeviceGrantStore is a mock of TokenHandlerDeviceGrantStore interface.
+type MockTokenHandlerDeviceGrantStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenHandlerDeviceGrantStoreMockRecorder
+}
+
+// MockTokenHandlerDeviceGrantStoreMockRecorder is the mock recorder for MockTokenHandlerDeviceGrantStore.
+type MockTokenHandlerDeviceGrantStoreMockRecorder struct {
+	mock *MockTokenHandlerDeviceGrantStore
+}
+
+// NewMockTokenHandlerDeviceGrantStore creates a new mock instance.
+func NewMockTokenHandlerDeviceGrantStore(ctrl *gomock.Controller) *MockTokenHandlerDeviceGrantStore {
+	mock := &MockTokenHandlerDeviceGrantStore{ctrl: ctrl}
+	mock.recorder = &MockTokenHandlerDeviceGrantStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenHandlerDeviceGrantStore) EXPECT() *MockTokenHandlerDeviceGrantStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteDeviceGrant mocks base method.
+func (m *MockTokenHandlerDeviceGrantStore) DeleteDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeviceGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeviceGrant indicates an expected call of DeleteDeviceGrant.
+func (mr *MockTokenHandlerDeviceGrantStoreMockRecorder) DeleteDeviceGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeviceGrant", reflect.TypeOf((*MockTokenHandlerDeviceGrantStore)(nil).DeleteDeviceGrant), ctx, g)
+}
+
+// GetDeviceGrant mocks base method.
+func (m *MockTokenHandlerDeviceGrantStore) GetDeviceGrant(ctx context.Context, deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceGrant", ctx, deviceCodeHash)
+	ret0, _ := ret[0].(*oauth.DeviceGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceGrant indicates an expected call of GetDeviceGrant.
+func (mr *MockTokenHandlerDeviceGrantStoreMockRecorder) GetDeviceGrant(ctx, deviceCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceGrant", reflect.TypeOf((*MockTokenHandlerDeviceGrantStore)(nil).GetDeviceGrant), ctx, deviceCodeHash)
+}
+
+// UpdateDeviceGrant mocks base method.
+func (m *MockTokenHandlerDeviceGrantStore) UpdateDeviceGrant(ctx context.Context, g *oauth.DeviceGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeviceGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeviceGrant indicates an expected call of UpdateDeviceGrant.
+func (mr *MockTokenHandlerDeviceGrantStoreMockRecorder) UpdateDeviceGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeviceGrant", reflect.TypeOf((*MockTokenHandlerDeviceGrantStore)(nil).UpdateDeviceGrant), ctx, g)
+}
+
+// MockTokenHandlerCIBAGrantStore is a mock of TokenHandlerCIBAGrantStore interface.
+type MockTokenHandlerCIBAGrantStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenHandlerCIBAGrantStoreMockRecorder
+}
+
+// MockTokenHandlerCIBAGrantStoreMockRecorder is the mock recorder for MockTokenHandlerCIBAGrantStore.
+type MockTokenHandlerCIBAGrantStoreMockRecorder struct {
+	mock *MockTokenHandlerCIBAGrantStore
+}
+
+// NewMockTokenHandlerCIBAGrantStore creates a new mock instance.
+func NewMockTokenHandlerCIBAGrantStore(ctrl *gomock.Controller) *MockTokenHandlerCIBAGrantStore {
+	mock := &MockTokenHandlerCIBAGrantStore{ctrl: ctrl}
+	mock.recorder = &MockTokenHandlerCIBAGrantStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenHandlerCIBAGrantStore) EXPECT() *MockTokenHandlerCIBAGrantStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteCIBAGrant mocks base method.
+func (m *MockTokenHandlerCIBAGrantStore) DeleteCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCIBAGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCIBAGrant indicates an expected call of DeleteCIBAGrant.
+func (mr *MockTokenHandlerCIBAGrantStoreMockRecorder) DeleteCIBAGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCIBAGrant", reflect.TypeOf((*MockTokenHandlerCIBAGrantStore)(nil).DeleteCIBAGrant), ctx, g)
+}
+
+// GetCIBAGrant mocks base method.
+func (m *MockTokenHandlerCIBAGrantStore) GetCIBAGrant(ctx context.Context, authReqIDHash string) (*oauth.CIBAGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCIBAGrant", ctx, authReqIDHash)
+	ret0, _ := ret[0].(*oauth.CIBAGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCIBAGrant indicates an expected call of GetCIBAGrant.
+func (mr *MockTokenHandlerCIBAGrantStoreMockRecorder) GetCIBAGrant(ctx, authReqIDHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCIBAGrant", reflect.TypeOf((*MockTokenHandlerCIBAGrantStore)(nil).GetCIBAGrant), ctx, authReqIDHash)
+}
+
+// UpdateCIBAGrant mocks base method.
+func (m *MockTokenHandlerCIBAGrantStore) UpdateCIBAGrant(ctx context.Context, g *oauth.CIBAGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCIBAGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCIBAGrant indicates an expected call of UpdateCIBAGrant.
+func (mr *MockTokenHandlerCIBAGrantStoreMockRecorder) UpdateCIBAGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCIBAGrant", reflect.TypeOf((*MockTokenHandlerCIBAGrantStore)(nil).UpdateCIBAGrant), ctx, g)
+}
+
 // MockTokenHandlerOfflineGrantStore is a mock of TokenHandlerOfflineGrantStore interface.
 type MockTokenHandlerOfflineGrantStore struct {
 	ctrl     *gomock.Controller
@@ -795,6 +927,21 @@ func (m *MockTokenHandlerIDPSessionProvider) Get(ctx context.Context, id string)
 	return ret0, ret1
 }
 
+// AddOIDCClientParticipant mocks base method.
+func (m *MockTokenHandlerIDPSessionProvider) AddOIDCClientParticipant(ctx context.Context, session *idpsession.IDPSession, clientID string) (*idpsession.IDPSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOIDCClientParticipant", ctx, session, clientID)
+	ret0, _ := ret[0].(*idpsession.IDPSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOIDCClientParticipant indicates an expected call of AddOIDCClientParticipant.
+func (mr *MockTokenHandlerIDPSessionProviderMockRecorder) AddOIDCClientParticipant(ctx, session, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOIDCClientParticipant", reflect.TypeOf((*MockTokenHandlerIDPSessionProvider)(nil).AddOIDCClientParticipant), ctx, session, clientID)
+}
+
 // Get indicates an expected call of Get.
 func (mr *MockTokenHandlerIDPSessionProviderMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
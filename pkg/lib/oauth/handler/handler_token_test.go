@@ -14,8 +14,11 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/event/blocking"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
 	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc"
@@ -113,6 +116,9 @@ func TestTokenHandler(t *testing.T) {
 			return resp
 		}
 
+		sessionManager := &revokedSessionRecorder{}
+		h.SessionManager = sessionManager
+
 		Convey("handle refresh token", func() {
 			Convey("success", func() {
 				req, _ := http.NewRequest("POST", "/token", nil)
@@ -157,6 +163,7 @@ func TestTokenHandler(t *testing.T) {
 					Enabled:        true,
 				}).Return(nil, nil)
 				tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "asdf").Return(&oauth.Authorization{}, offlineGrant, refreshTokenHash, nil)
+				events.EXPECT().DispatchEventOnCommit(gomock.Any(), gomock.Any()).Return(nil)
 
 				idTokenIssuer.EXPECT().PrepareIDToken(gomock.Any(), gomock.Any()).Return(&oidc.PrepareIDTokenResult{}, nil)
 				idTokenIssuer.EXPECT().MakeIDTokenFromPreparationResult(gomock.Any(), gomock.Any()).Return("id-token", nil)
@@ -218,6 +225,7 @@ func TestTokenHandler(t *testing.T) {
 				}
 				rateLimiter.EXPECT().Allow(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
 				tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "asdf").Return(&oauth.Authorization{}, offlineGrant, refreshTokenHash, nil)
+				events.EXPECT().DispatchEventOnCommit(gomock.Any(), gomock.Any()).Return(nil)
 
 				idTokenIssuer.EXPECT().PrepareIDToken(gomock.Any(), gomock.Any()).Return(&oidc.PrepareIDTokenResult{}, nil)
 				idTokenIssuer.EXPECT().MakeIDTokenFromPreparationResult(gomock.Any(), gomock.Any()).Return("id-token", nil)
@@ -284,6 +292,7 @@ func TestTokenHandler(t *testing.T) {
 				}
 				rateLimiter.EXPECT().Allow(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
 				tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "asdf").Return(&oauth.Authorization{}, offlineGrant, refreshTokenHash, nil)
+				events.EXPECT().DispatchEventOnCommit(gomock.Any(), gomock.Any()).Return(nil)
 
 				idTokenIssuer.EXPECT().PrepareIDToken(gomock.Any(), gomock.Any()).Return(&oidc.PrepareIDTokenResult{}, nil)
 				idTokenIssuer.EXPECT().MakeIDTokenFromPreparationResult(gomock.Any(), gomock.Any()).Return("id-token", nil)
@@ -322,6 +331,104 @@ func TestTokenHandler(t *testing.T) {
 				So(body, ShouldNotContainKey, "refresh_token")
 			})
 
+			Convey("should narrow scopes by oauth.token.pre_refresh", func() {
+				req, _ := http.NewRequest("POST", "/token", nil)
+				clientResolver.ClientConfigs["app-id"] = &config.OAuthClientConfig{
+					ClientID: "app-id",
+				}
+				r := protocol.TokenRequest{}
+				r["grant_type"] = []string{"refresh_token"}
+				r["client_id"] = []string{"app-id"}
+				r["refresh_token"] = []string{"asdf"}
+				refreshTokenHash := "hash1"
+				offlineGrant := &oauth.OfflineGrant{
+					ID:              "offline-grant-id",
+					Attrs:           *session.NewAttrs("user-id"),
+					InitialClientID: "app-id",
+					RefreshTokens: []oauth.OfflineGrantRefreshToken{{
+						ClientID:         "app-id",
+						Scopes:           []string{"openid", "offline_access", "email"},
+						InitialTokenHash: refreshTokenHash,
+					}},
+					ExpireAtForResolvedSession: time.Date(2020, 02, 01, 1, 0, 0, 0, time.UTC),
+				}
+				rateLimiter.EXPECT().Allow(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+				tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "asdf").Return(&oauth.Authorization{}, offlineGrant, refreshTokenHash, nil)
+				events.EXPECT().DispatchEventOnCommit(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, payload event.Payload) error {
+						p := payload.(*blocking.OAuthTokenPreRefreshBlockingEventPayload)
+						So(p.OfflineGrant.ID, ShouldEqual, "offline-grant-id")
+						So(p.ClientID, ShouldEqual, "app-id")
+						So(p.IPAddress, ShouldEqual, "1.2.3.4")
+						p.ApplyHookResponse(ctx, event.HookResponse{
+							IsAllowed: true,
+							Mutations: event.Mutations{
+								OAuthToken: event.OAuthTokenMutations{
+									Scopes: []string{"offline_access", "email", "profile"},
+								},
+							},
+						})
+						return nil
+					})
+
+				tokenService.EXPECT().PrepareUserAccessGrantByRefreshToken(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, options handler.PrepareUserAccessGrantByRefreshTokenOptions) (*handler.PrepareUserAccessGrantByRefreshTokenResult, error) {
+						So(options.Scopes, ShouldResemble, []string{"offline_access", "email"})
+						return &handler.PrepareUserAccessGrantByRefreshTokenResult{}, nil
+					})
+				accessTokenEncoding.EXPECT().MakeUserAccessTokenFromPreparationResult(gomock.Any(), gomock.Any()).Return(&oauth.IssueAccessGrantResult{
+					Token:     "access-token",
+					TokenType: "Bearer",
+					ExpiresIn: 300,
+				}, nil)
+
+				offlineGrantService.EXPECT().AccessOfflineGrant(gomock.Any(), "offline-grant-id", refreshTokenHash, gomock.Any(), offlineGrant.ExpireAtForResolvedSession).Return(offlineGrant, nil)
+				offlineGrants.EXPECT().UpdateOfflineGrantDeviceInfo(gomock.Any(), "offline-grant-id", gomock.Any(), offlineGrant.ExpireAtForResolvedSession).Return(offlineGrant, nil)
+				ctx := context.Background()
+				res := handle(ctx, req, r)
+				So(res.Result().StatusCode, ShouldEqual, 200)
+				var body map[string]any
+				err := json.Unmarshal(res.Body.Bytes(), &body)
+				So(err, ShouldBeNil)
+				So(body, ShouldNotContainKey, "id_token")
+			})
+
+			Convey("should revoke offline grant if disallowed by oauth.token.pre_refresh", func() {
+				req, _ := http.NewRequest("POST", "/token", nil)
+				clientResolver.ClientConfigs["app-id"] = &config.OAuthClientConfig{
+					ClientID: "app-id",
+				}
+				r := protocol.TokenRequest{}
+				r["grant_type"] = []string{"refresh_token"}
+				r["client_id"] = []string{"app-id"}
+				r["refresh_token"] = []string{"asdf"}
+				refreshTokenHash := "hash1"
+				offlineGrant := &oauth.OfflineGrant{
+					ID:              "offline-grant-id",
+					Attrs:           *session.NewAttrs("user-id"),
+					InitialClientID: "app-id",
+					RefreshTokens: []oauth.OfflineGrantRefreshToken{{
+						ClientID:         "app-id",
+						Scopes:           []string{"openid"},
+						InitialTokenHash: refreshTokenHash,
+					}},
+					ExpireAtForResolvedSession: time.Date(2020, 02, 01, 1, 0, 0, 0, time.UTC),
+				}
+				rateLimiter.EXPECT().Allow(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+				tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "asdf").Return(&oauth.Authorization{}, offlineGrant, refreshTokenHash, nil)
+				events.EXPECT().DispatchEventOnCommit(gomock.Any(), gomock.Any()).Return(hook.HookDisallowed.New("disallowed"))
+
+				ctx := context.Background()
+				res := handle(ctx, req, r)
+				So(res.Result().StatusCode, ShouldEqual, 400)
+				var body map[string]any
+				err := json.Unmarshal(res.Body.Bytes(), &body)
+				So(err, ShouldBeNil)
+				So(body["error"], ShouldEqual, "invalid_grant")
+				So(sessionManager.Revoked, ShouldHaveLength, 1)
+				So(sessionManager.Revoked[0].SessionID(), ShouldEqual, "offline-grant-id")
+			})
+
 			Convey("rate limited", func() {
 				req, _ := http.NewRequest("POST", "/token", nil)
 				clientResolver.ClientConfigs["app-id"] = &config.OAuthClientConfig{
@@ -1091,3 +1198,17 @@ func TestTokenHandler(t *testing.T) {
 		})
 	})
 }
+
+type revokedSessionRecorder struct {
+	Revoked []session.SessionBase
+}
+
+func (r *revokedSessionRecorder) RevokeWithEvent(ctx context.Context, s session.SessionBase, isTermination bool, isAdminAPI bool) error {
+	r.Revoked = append(r.Revoked, s)
+	return nil
+}
+
+func (r *revokedSessionRecorder) RevokeWithoutEvent(ctx context.Context, s session.SessionBase) error {
+	r.Revoked = append(r.Revoked, s)
+	return nil
+}
@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
 	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 )
 
@@ -13,6 +18,42 @@ func NewBucketSpecOAuthTokenPerIP(ip string) ratelimit.BucketSpec {
 	}, ratelimit.OAuthTokenPerIP, ip)
 }
 
+func NewBucketSpecOAuthDeviceAuthorizationPerIP(ip string) ratelimit.BucketSpec {
+	return ratelimit.NewBucketSpec(ratelimit.RateLimitOAuthDeviceAuthorizationPerIP, ratelimit.RateLimitGroupOAuthDeviceAuthorization, &config.RateLimitConfig{
+		Enabled: func() *bool { var t = true; return &t }(),
+		Period:  "1m",
+		Burst:   20,
+	}, ratelimit.OAuthDeviceAuthorizationPerIP, ip)
+}
+
+// NewBucketSpecOAuthDeviceVerificationPerIP limits the attempts to enter a user code,
+// so that user codes cannot be brute-forced.
+func NewBucketSpecOAuthDeviceVerificationPerIP(ip string) ratelimit.BucketSpec {
+	return ratelimit.NewBucketSpec(ratelimit.RateLimitOAuthDeviceVerificationPerIP, ratelimit.RateLimitGroupOAuthDeviceVerification, &config.RateLimitConfig{
+		Enabled: func() *bool { var t = true; return &t }(),
+		Period:  "1m",
+		Burst:   10,
+	}, ratelimit.OAuthDeviceVerificationPerIP, ip)
+}
+
+func NewBucketSpecOAuthPushedAuthorizationPerIP(ip string) ratelimit.BucketSpec {
+	return ratelimit.NewBucketSpec(ratelimit.RateLimitOAuthPushedAuthorizationPerIP, ratelimit.RateLimitGroupOAuthPushedAuthorization, &config.RateLimitConfig{
+		Enabled: func() *bool { var t = true; return &t }(),
+		Period:  "1m",
+		Burst:   60,
+	}, ratelimit.OAuthPushedAuthorizationPerIP, ip)
+}
+
+// NewBucketSpecOAuthBackchannelAuthenticationPerIP limits the backchannel authentication requests,
+// each of which sends a message to the user.
+func NewBucketSpecOAuthBackchannelAuthenticationPerIP(ip string) ratelimit.BucketSpec {
+	return ratelimit.NewBucketSpec(ratelimit.RateLimitOAuthBackchannelAuthenticationPerIP, ratelimit.RateLimitGroupOAuthBackchannelAuthentication, &config.RateLimitConfig{
+		Enabled: func() *bool { var t = true; return &t }(),
+		Period:  "1m",
+		Burst:   20,
+	}, ratelimit.OAuthBackchannelAuthenticationPerIP, ip)
+}
+
 func NewBucketSpecOAuthTokenPerUser(userID string) ratelimit.BucketSpec {
 	return ratelimit.NewBucketSpec(ratelimit.RateLimitOAuthTokenGeneralPerUser, ratelimit.RateLimitGroupOAuthTokenGeneral, &config.RateLimitConfig{
 		Enabled: func() *bool { var t = true; return &t }(),
@@ -20,3 +61,23 @@ func NewBucketSpecOAuthTokenPerUser(userID string) ratelimit.BucketSpec {
 		Burst:   60,
 	}, ratelimit.OAuthTokenPerUser, userID)
 }
+
+type rateLimiter interface {
+	Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error)
+}
+
+func checkRateLimit(ctx context.Context, limiter rateLimiter, spec ratelimit.BucketSpec) error {
+	var err error
+
+	failedReservation, allowErr := limiter.Allow(ctx, spec)
+	if allowErr != nil {
+		err = allowErr
+	} else if resvErr := failedReservation.Error(); resvErr != nil {
+		err = resvErr
+	}
+
+	if err != nil && apierrors.IsKind(err, ratelimit.RateLimited) {
+		return protocol.NewErrorStatusCode("x_rate_limited", "rate limit exceeded, please try again later.", http.StatusTooManyRequests)
+	}
+	return err
+}
@@ -1,29 +1,40 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
 )
 
 type (
 	tokenResultOK struct {
-		Response protocol.TokenResponse
+		StatusCode int
+		Response   protocol.TokenResponse
 	}
 	tokenResultError struct {
 		StatusCode    int
 		InternalError bool
 		Response      protocol.ErrorResponse
 	}
-	tokenResultEmpty struct{}
+	tokenResultEmpty struct {
+		StatusCode int
+	}
 )
 
 func (t tokenResultOK) WriteResponse(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Set("Content-Type", "application/json")
 	rw.Header().Set("Cache-Control", "no-store")
 	rw.Header().Set("Pragma", "no-cache")
-	rw.WriteHeader(http.StatusOK)
+	if t.StatusCode == 0 {
+		rw.WriteHeader(http.StatusOK)
+	} else {
+		rw.WriteHeader(t.StatusCode)
+	}
 
 	encoder := json.NewEncoder(rw)
 	err := encoder.Encode(t.Response)
@@ -61,10 +72,30 @@ func (t tokenResultError) IsInternalError() bool {
 	return t.InternalError
 }
 
+// errorResult turns err into a JSON error response.
+// Errors other than OAuthProtocolError are logged and reported as server_error.
+func errorResult(ctx context.Context, logger slogutil.NamedLogger, err error) httputil.Result {
+	var oauthError *protocol.OAuthProtocolError
+	resultErr := tokenResultError{}
+	if errors.As(err, &oauthError) {
+		resultErr.StatusCode = oauthError.StatusCode
+		resultErr.Response = oauthError.Response
+	} else {
+		logger.WithError(err).Error(ctx, "handler failed")
+		resultErr.Response = protocol.NewErrorResponse("server_error", "internal server error")
+		resultErr.InternalError = true
+	}
+	return resultErr
+}
+
 func (t tokenResultEmpty) WriteResponse(rw http.ResponseWriter, r *http.Request) {
 	rw.Header().Set("Cache-Control", "no-store")
 	rw.Header().Set("Pragma", "no-cache")
-	rw.WriteHeader(http.StatusOK)
+	if t.StatusCode == 0 {
+		rw.WriteHeader(http.StatusOK)
+	} else {
+		rw.WriteHeader(t.StatusCode)
+	}
 }
 
 func (t tokenResultEmpty) IsInternalError() bool {
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/translation"
+	"github.com/authgear/authgear-server/pkg/lib/uiparam"
+)
+
+var errCIBANoMessageTarget = protocol.NewError("invalid_request", "the user has no email address or phone number to receive the request")
+
+type CIBAMessageSenderIdentityService interface {
+	ListByUser(ctx context.Context, userID string) ([]*identity.Info, error)
+}
+
+type CIBAMessageSenderTranslationService interface {
+	EmailMessageData(ctx context.Context, msg *translation.MessageSpec, variables *translation.PartialTemplateVariables) (*translation.EmailMessageData, error)
+	SMSMessageData(ctx context.Context, msg *translation.MessageSpec, variables *translation.PartialTemplateVariables) (*translation.SMSMessageData, error)
+}
+
+type CIBAMessageSenderSender interface {
+	SendEmailAsync(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error
+	SendSMSAsync(ctx context.Context, msgType translation.MessageType, opts *sms.SendOptions) error
+}
+
+type SendCIBAMessageOptions struct {
+	ClientID       string
+	UserID         string
+	LoginHint      *oauth.LoginHint
+	BindingMessage string
+	Link           string
+}
+
+// CIBAMessageSender sends the link to the approval page to the user
+// by email or SMS.
+type CIBAMessageSender struct {
+	AppID       config.AppID
+	Identities  CIBAMessageSenderIdentityService
+	Translation CIBAMessageSenderTranslationService
+	Sender      CIBAMessageSenderSender
+}
+
+func (s *CIBAMessageSender) Send(ctx context.Context, opts *SendCIBAMessageOptions) error {
+	email, phone, err := s.resolveTarget(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	// The message mentions the client that initiated the request.
+	ctx = uiparam.WithUIParam(ctx, &uiparam.T{ClientID: opts.ClientID})
+
+	spec := translation.MessageBackchannelAuthentication
+	variables := &translation.PartialTemplateVariables{
+		Email:          email,
+		Phone:          phone,
+		Link:           opts.Link,
+		BindingMessage: opts.BindingMessage,
+	}
+
+	if email != "" {
+		data, err := s.Translation.EmailMessageData(ctx, spec, variables)
+		if err != nil {
+			return err
+		}
+
+		return s.Sender.SendEmailAsync(ctx, spec.MessageType, &mail.SendOptions{
+			Sender:    data.Sender,
+			ReplyTo:   data.ReplyTo,
+			Subject:   data.Subject,
+			Recipient: email,
+			TextBody:  data.TextBody.String,
+			HTMLBody:  data.HTMLBody.String,
+		})
+	}
+
+	data, err := s.Translation.SMSMessageData(ctx, spec, variables)
+	if err != nil {
+		return err
+	}
+
+	return s.Sender.SendSMSAsync(ctx, spec.MessageType, &sms.SendOptions{
+		Sender:            data.Sender,
+		To:                phone,
+		Body:              data.Body.String,
+		AppID:             string(s.AppID),
+		TemplateName:      filepath.Base(spec.SMSTemplate.Name),
+		LanguageTag:       data.Body.LanguageTag,
+		TemplateVariables: sms.NewTemplateVariablesFromPreparedTemplateVariables(data.PreparedTemplateVariables),
+	})
+}
+
+// resolveTarget returns the login ID in login_hint if it is an email address or a phone number.
+// Otherwise, the first email address or phone number of the user is used.
+func (s *CIBAMessageSender) resolveTarget(ctx context.Context, opts *SendCIBAMessageOptions) (email string, phone string, err error) {
+	switch {
+	case opts.LoginHint.LoginIDEmail != "":
+		return opts.LoginHint.LoginIDEmail, "", nil
+	case opts.LoginHint.LoginIDPhone != "":
+		return "", opts.LoginHint.LoginIDPhone, nil
+	}
+
+	infos, err := s.Identities.ListByUser(ctx, opts.UserID)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, info := range infos {
+		if info.Type != model.IdentityTypeLoginID {
+			continue
+		}
+		claims := info.IdentityAwareStandardClaims()
+		if email == "" {
+			email = claims[model.ClaimEmail]
+		}
+		if phone == "" {
+			phone = claims[model.ClaimPhoneNumber]
+		}
+	}
+
+	switch {
+	case email != "":
+		return email, "", nil
+	case phone != "":
+		return "", phone, nil
+	default:
+		return "", "", errCIBANoMessageTarget
+	}
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/duration"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+// requestObjectRegisteredClaims are the JWT claims in a request object
+// that are not authorization request parameters.
+var requestObjectRegisteredClaims = map[string]struct{}{
+	jwt.IssuerKey:     {},
+	jwt.AudienceKey:   {},
+	jwt.ExpirationKey: {},
+	jwt.IssuedAtKey:   {},
+	jwt.NotBeforeKey:  {},
+	jwt.JwtIDKey:      {},
+}
+
+type AuthorizationRequestResolverIssuer interface {
+	Iss() string
+}
+
+type jwtClock struct {
+	Clock clock.Clock
+}
+
+func (c jwtClock) Now() time.Time {
+	return c.Clock.NowUTC()
+}
+
+// AuthorizationRequestResolver resolves the authorization request parameters
+// passed by reference (request_uri) or by value (request).
+type AuthorizationRequestResolver struct {
+	Clock                       clock.Clock
+	Issuer                      AuthorizationRequestResolverIssuer
+	PushedAuthorizationRequests oauth.PushedAuthorizationRequestStore
+	HTTPClient                  ClientJWKSHTTPClient
+}
+
+// ResolveRequestURI consumes the pushed authorization request identified by requestURI.
+// See https://datatracker.ietf.org/doc/html/rfc9126#section-4
+func (s *AuthorizationRequestResolver) ResolveRequestURI(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	requestURI string,
+) (protocol.AuthorizationRequest, error) {
+	if !oauth.IsPushedAuthorizationRequestURI(requestURI) {
+		return nil, protocol.NewError("invalid_request_uri", "only request_uri issued by the pushed authorization request endpoint is supported")
+	}
+
+	par, err := s.PushedAuthorizationRequests.ConsumePushedAuthorizationRequest(ctx, oauth.HashToken(requestURI))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, protocol.NewError("invalid_request_uri", "invalid or expired request_uri")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !s.Clock.NowUTC().Before(par.ExpireAt) {
+		return nil, protocol.NewError("invalid_request_uri", "invalid or expired request_uri")
+	}
+
+	if par.ClientID != client.ClientID {
+		return nil, protocol.NewError("invalid_request_uri", "request_uri was not issued to this client")
+	}
+
+	return par.AuthorizationRequest, nil
+}
+
+// ResolveRequestObject verifies the signed request object with the JWKS of the client,
+// and returns the authorization request parameters in it.
+// See https://datatracker.ietf.org/doc/html/rfc9101#section-6
+func (s *AuthorizationRequestResolver) ResolveRequestObject(
+	ctx context.Context,
+	client *config.OAuthClientConfig,
+	requestObject string,
+) (protocol.AuthorizationRequest, error) {
+	set, err := s.clientJWKS(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := jws.Verify([]byte(requestObject), jws.WithKeySet(set,
+		jws.WithInferAlgorithmFromKey(true),
+		jws.WithUseDefault(true),
+	))
+	if err != nil {
+		return nil, protocol.NewError("invalid_request_object", "invalid request object signature")
+	}
+
+	_, token, err := jwtutil.SplitWithoutVerify([]byte(requestObject))
+	if err != nil {
+		return nil, protocol.NewError("invalid_request_object", "invalid request object")
+	}
+
+	err = jwt.Validate(token,
+		jwt.WithClock(jwtClock{s.Clock}),
+		jwt.WithAcceptableSkew(duration.ClockSkew),
+		jwt.WithIssuer(client.ClientID),
+		jwt.WithAudience(s.Issuer.Iss()),
+	)
+	if err != nil {
+		return nil, protocol.NewError("invalid_request_object", err.Error())
+	}
+
+	var claims map[string]any
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, protocol.NewError("invalid_request_object", "invalid request object")
+	}
+
+	// Only the parameters inside the request object are used.
+	// See https://datatracker.ietf.org/doc/html/rfc9101#section-5
+	r := protocol.AuthorizationRequest{}
+	for name, value := range claims {
+		if _, ok := requestObjectRegisteredClaims[name]; ok {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			r[name] = v
+		case float64:
+			r[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			r[name] = strconv.FormatBool(v)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			r[name] = string(b)
+		}
+	}
+
+	if clientID, ok := r["client_id"]; ok && clientID != client.ClientID {
+		return nil, protocol.NewError("invalid_request_object", "client_id in request object does not match")
+	}
+	r["client_id"] = client.ClientID
+
+	if r.Request() != "" || r.RequestURI() != "" {
+		return nil, protocol.NewError("invalid_request_object", "request object cannot contain request or request_uri")
+	}
+
+	return r, nil
+}
+
+func (s *AuthorizationRequestResolver) clientJWKS(ctx context.Context, client *config.OAuthClientConfig) (jwk.Set, error) {
+	switch {
+	case client.JWKS != nil:
+		set, err := client.JWKS.Set()
+		if err != nil {
+			return nil, protocol.NewError("invalid_request_object", "invalid client jwks")
+		}
+		return set, nil
+	case client.JWKSURI != "":
+		set, err := s.HTTPClient.FetchJWKS(ctx, client.JWKSURI)
+		if err != nil {
+			return nil, protocol.NewError("invalid_request_object", "failed to fetch client jwks_uri")
+		}
+		return set, nil
+	default:
+		return nil, protocol.NewError("invalid_request_object", "client does not have jwks to verify request object")
+	}
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+var ErrCIBAApprovalCodeInvalid = apierrors.Invalid.WithReason("InvalidCIBAApprovalCode").New("invalid backchannel authentication request")
+
+type CIBANotificationHTTPClient struct {
+	*http.Client
+}
+
+func NewCIBANotificationHTTPClient() CIBANotificationHTTPClient {
+	return CIBANotificationHTTPClient{
+		httputil.NewExternalClient(5 * time.Second),
+	}
+}
+
+// CIBANotificationRequest is the input of a CIBA ping callback task.
+type CIBANotificationRequest struct {
+	ClientID                string `json:"client_id"`
+	AuthReqID               string `json:"auth_req_id"`
+	ClientNotificationToken string `json:"client_notification_token"`
+}
+
+type CIBANotificationProducer interface {
+	NewTask(appID string, input json.RawMessage, taskIDPrefix string) *redisqueue.Task
+	EnqueueTask(ctx context.Context, task *redisqueue.Task) error
+}
+
+type CIBAGrantDatabase interface {
+	UseHook(ctx context.Context, hook db.TransactionHook)
+}
+
+// CIBAGrantService is used by the approval page to approve or deny a CIBA grant.
+// In ping mode, the client is notified after the transaction commits,
+// so that the client does not redeem a grant whose authorization is rolled back.
+type CIBAGrantService struct {
+	AppID          config.AppID
+	Clock          clock.Clock
+	CIBAGrants     oauth.CIBAGrantStore
+	Authorizations AuthorizationService
+	Database       CIBAGrantDatabase
+	Producer       CIBANotificationProducer
+
+	Notifications  []*CIBANotificationRequest `wire:"-"`
+	DatabaseHooked bool                       `wire:"-"`
+}
+
+// GetPendingCIBAGrant returns the pending CIBA grant identified by approvalCode.
+// The grant must be issued to the user of the current session.
+func (s *CIBAGrantService) GetPendingCIBAGrant(ctx context.Context, approvalCode string, userID string) (*oauth.CIBAGrant, error) {
+	g, err := s.CIBAGrants.GetCIBAGrantByApprovalCode(ctx, oauth.HashToken(approvalCode))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, ErrCIBAApprovalCodeInvalid
+	} else if err != nil {
+		return nil, err
+	}
+
+	if g.Status != oauth.CIBAGrantStatusPending || !s.Clock.NowUTC().Before(g.ExpireAt) {
+		return nil, ErrCIBAApprovalCodeInvalid
+	}
+
+	if g.UserID != userID {
+		return nil, ErrCIBAApprovalCodeInvalid
+	}
+
+	return g, nil
+}
+
+func (s *CIBAGrantService) ApproveCIBAGrant(ctx context.Context, approvalCode string, info authenticationinfo.T) error {
+	g, err := s.GetPendingCIBAGrant(ctx, approvalCode, info.UserID)
+	if err != nil {
+		return err
+	}
+
+	authz, err := s.Authorizations.CheckAndGrant(ctx, g.ClientID, info.UserID, g.Scopes)
+	if err != nil {
+		return err
+	}
+
+	g.Status = oauth.CIBAGrantStatusApproved
+	g.AuthorizationID = authz.ID
+	g.AuthenticationInfo = info
+	err = s.CIBAGrants.UpdateCIBAGrant(ctx, g)
+	if err != nil {
+		return err
+	}
+
+	s.notify(ctx, g)
+	return nil
+}
+
+func (s *CIBAGrantService) DenyCIBAGrant(ctx context.Context, approvalCode string, userID string) error {
+	g, err := s.GetPendingCIBAGrant(ctx, approvalCode, userID)
+	if err != nil {
+		return err
+	}
+
+	g.Status = oauth.CIBAGrantStatusDenied
+	err = s.CIBAGrants.UpdateCIBAGrant(ctx, g)
+	if err != nil {
+		return err
+	}
+
+	s.notify(ctx, g)
+	return nil
+}
+
+// notify enqueues the ping callback to the client in ping mode.
+func (s *CIBAGrantService) notify(ctx context.Context, g *oauth.CIBAGrant) {
+	if g.DeliveryMode != config.OAuthClientBackchannelTokenDeliveryModePing {
+		return
+	}
+
+	s.Notifications = append(s.Notifications, &CIBANotificationRequest{
+		ClientID:                g.ClientID,
+		AuthReqID:               g.AuthReqID,
+		ClientNotificationToken: g.ClientNotificationToken,
+	})
+
+	if !s.DatabaseHooked {
+		s.Database.UseHook(ctx, s)
+		s.DatabaseHooked = true
+	}
+}
+
+func (s *CIBAGrantService) WillCommitTx(ctx context.Context) error {
+	return nil
+}
+
+func (s *CIBAGrantService) DidCommitTx(ctx context.Context) {
+	logger := BackchannelAuthenticationHandlerLogger.GetLogger(ctx)
+
+	// Reset s.Notifications so that the tasks are not enqueued twice.
+	notifications := s.Notifications
+	s.Notifications = nil
+
+	for _, request := range notifications {
+		rawMessage, err := json.Marshal(request)
+		if err == nil {
+			task := s.Producer.NewTask(string(s.AppID), rawMessage, "task")
+			err = s.Producer.EnqueueTask(ctx, task)
+		}
+		if err != nil {
+			logger.WithError(err).Error(ctx, "failed to enqueue backchannel authentication notification",
+				slog.String("client_id", request.ClientID),
+			)
+		}
+	}
+}
+
+// CIBANotificationDeliverer sends the ping callback to the client.
+// A failed notification is only logged, the client can still poll the token endpoint.
+// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.2
+type CIBANotificationDeliverer struct {
+	ClientResolver OAuthClientResolver
+	HTTPClient     CIBANotificationHTTPClient
+}
+
+func (d *CIBANotificationDeliverer) Deliver(ctx context.Context, request *CIBANotificationRequest) error {
+	// The client could have been removed, or switched to poll mode, after the task was enqueued.
+	client := d.ClientResolver.ResolveClient(request.ClientID)
+	if client == nil || client.BackchannelClientNotificationEndpoint == "" {
+		return nil
+	}
+
+	err := d.post(ctx, client.BackchannelClientNotificationEndpoint, request)
+	if err != nil {
+		logger := BackchannelAuthenticationHandlerLogger.GetLogger(ctx)
+		logger.WithError(err).Warn(ctx, "failed to notify client of backchannel authentication result",
+			slog.String("client_id", request.ClientID),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (d *CIBANotificationDeliverer) post(ctx context.Context, endpoint string, request *CIBANotificationRequest) error {
+	body, err := json.Marshal(map[string]any{
+		"auth_req_id": request.AuthReqID,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+request.ClientNotificationToken)
+
+	resp, err := d.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("ciba: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
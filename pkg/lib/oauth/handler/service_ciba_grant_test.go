@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+)
+
+type cibaNotificationProducer struct {
+	requests []CIBANotificationRequest
+}
+
+func (p *cibaNotificationProducer) NewTask(appID string, input json.RawMessage, taskIDPrefix string) *redisqueue.Task {
+	return &redisqueue.Task{AppID: appID, Input: input}
+}
+
+func (p *cibaNotificationProducer) EnqueueTask(ctx context.Context, task *redisqueue.Task) error {
+	var request CIBANotificationRequest
+	err := json.Unmarshal(task.Input, &request)
+	if err != nil {
+		return err
+	}
+	p.requests = append(p.requests, request)
+	return nil
+}
+
+type cibaGrantDatabase struct {
+	hooks []db.TransactionHook
+}
+
+func (d *cibaGrantDatabase) UseHook(ctx context.Context, hook db.TransactionHook) {
+	d.hooks = append(d.hooks, hook)
+}
+
+type cibaClientResolver map[string]*config.OAuthClientConfig
+
+func (r cibaClientResolver) ResolveClient(clientID string) *config.OAuthClientConfig {
+	return r[clientID]
+}
+
+func TestCIBAGrantServiceNotify(t *testing.T) {
+	Convey("CIBAGrantService.notify", t, func() {
+		ctx := context.Background()
+		producer := &cibaNotificationProducer{}
+		database := &cibaGrantDatabase{}
+		s := &CIBAGrantService{
+			AppID:    "app-id",
+			Database: database,
+			Producer: producer,
+		}
+
+		ping := &oauth.CIBAGrant{
+			ClientID:                "rp-ping",
+			AuthReqID:               "auth-req-id",
+			DeliveryMode:            config.OAuthClientBackchannelTokenDeliveryModePing,
+			ClientNotificationToken: "notification-token",
+		}
+		poll := &oauth.CIBAGrant{
+			ClientID:     "rp-poll",
+			AuthReqID:    "auth-req-id-2",
+			DeliveryMode: config.OAuthClientBackchannelTokenDeliveryModePoll,
+		}
+
+		Convey("should enqueue the ping callback after commit", func() {
+			s.notify(ctx, ping)
+			s.notify(ctx, poll)
+
+			So(database.hooks, ShouldHaveLength, 1)
+			So(producer.requests, ShouldBeEmpty)
+
+			database.hooks[0].DidCommitTx(ctx)
+			So(producer.requests, ShouldResemble, []CIBANotificationRequest{
+				{
+					ClientID:                "rp-ping",
+					AuthReqID:               "auth-req-id",
+					ClientNotificationToken: "notification-token",
+				},
+			})
+
+			// The notification is not enqueued twice.
+			database.hooks[0].DidCommitTx(ctx)
+			So(producer.requests, ShouldHaveLength, 1)
+		})
+
+		Convey("should not enqueue if the transaction does not commit", func() {
+			s.notify(ctx, ping)
+			So(database.hooks, ShouldHaveLength, 1)
+			So(producer.requests, ShouldBeEmpty)
+		})
+
+		Convey("should not use hook in poll mode", func() {
+			s.notify(ctx, poll)
+			So(database.hooks, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestCIBANotificationDeliverer(t *testing.T) {
+	Convey("CIBANotificationDeliverer", t, func() {
+		ctx := context.Background()
+
+		var header http.Header
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		d := &CIBANotificationDeliverer{
+			ClientResolver: cibaClientResolver{
+				"rp-ping": {
+					ClientID:                              "rp-ping",
+					BackchannelClientNotificationEndpoint: server.URL,
+				},
+			},
+			HTTPClient: CIBANotificationHTTPClient{server.Client()},
+		}
+
+		Convey("should post the auth_req_id with the client notification token", func() {
+			err := d.Deliver(ctx, &CIBANotificationRequest{
+				ClientID:                "rp-ping",
+				AuthReqID:               "auth-req-id",
+				ClientNotificationToken: "notification-token",
+			})
+			So(err, ShouldBeNil)
+			So(header.Get("Authorization"), ShouldEqual, "Bearer notification-token")
+			So(string(body), ShouldEqualJSON, `{"auth_req_id": "auth-req-id"}`)
+		})
+
+		Convey("should skip removed clients", func() {
+			err := d.Deliver(ctx, &CIBANotificationRequest{
+				ClientID:  "removed",
+				AuthReqID: "auth-req-id",
+			})
+			So(err, ShouldBeNil)
+			So(body, ShouldBeNil)
+		})
+	})
+}
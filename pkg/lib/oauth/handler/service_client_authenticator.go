@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/nonce"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/duration"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+type ClientAuthenticatorIssuer interface {
+	Iss() string
+}
+
+type ClientAuthenticatorNonceStore interface {
+	Consume(ctx context.Context, scope string, nonce string, expireAt time.Time) error
+}
+
+// ClientAuthenticatorImpl authenticates clients with their keys instead of client secrets.
+type ClientAuthenticatorImpl struct {
+	Clock                      clock.Clock
+	Issuer                     ClientAuthenticatorIssuer
+	Nonces                     ClientAuthenticatorNonceStore
+	HTTPClient                 ClientJWKSHTTPClient
+	TLSClientCertificateHeader config.TLSClientCertificateHeader
+}
+
+var _ ClientAuthenticator = &ClientAuthenticatorImpl{}
+
+// AuthenticatePrivateKeyJWT verifies the client assertion signed by the client.
+// See https://datatracker.ietf.org/doc/html/rfc7523#section-3
+func (s *ClientAuthenticatorImpl) AuthenticatePrivateKeyJWT(
+	ctx context.Context,
+	req *http.Request,
+	client *config.OAuthClientConfig,
+	clientAssertionType string,
+	clientAssertion string,
+) error {
+	if clientAssertionType != ClientAssertionTypeJWTBearer {
+		return newInvalidClientError("unsupported client_assertion_type")
+	}
+
+	hdr, token, err := jwtutil.SplitWithoutVerify([]byte(clientAssertion))
+	if err != nil {
+		return newInvalidClientError("invalid client_assertion")
+	}
+	if !slices.Contains(oauth.ClientAssertionSigningAlgorithms, hdr.Algorithm().String()) {
+		return newInvalidClientError("unsupported client_assertion signing algorithm")
+	}
+
+	set, err := s.clientJWKS(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	_, err = jws.Verify([]byte(clientAssertion), jws.WithKeySet(set,
+		jws.WithInferAlgorithmFromKey(true),
+		jws.WithUseDefault(true),
+	))
+	if err != nil {
+		return newInvalidClientError("invalid client_assertion signature")
+	}
+
+	err = jwt.Validate(token,
+		jwt.WithClock(jwtClock{s.Clock}),
+		jwt.WithAcceptableSkew(duration.ClockSkew),
+		jwt.WithIssuer(client.ClientID),
+		jwt.WithSubject(client.ClientID),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithRequiredClaim(jwt.JwtIDKey),
+	)
+	if err != nil {
+		return newInvalidClientError(err.Error())
+	}
+
+	// The audience is either the issuer or the URL of the endpoint receiving the assertion.
+	endpointURL := strings.TrimSuffix(s.Issuer.Iss(), "/") + req.URL.Path
+	if !slices.Contains(token.Audience(), s.Issuer.Iss()) && !slices.Contains(token.Audience(), endpointURL) {
+		return newInvalidClientError("invalid client_assertion audience")
+	}
+
+	err = s.Nonces.Consume(ctx, "client_assertion:"+client.ClientID, token.JwtID(), token.Expiration().Add(duration.ClockSkew))
+	if errors.Is(err, nonce.ErrNonceReused) {
+		return newInvalidClientError("client_assertion has been used")
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AuthenticateSelfSignedTLSClient checks that the public key of the client certificate
+// is one of the keys of the client.
+// See https://datatracker.ietf.org/doc/html/rfc8705#section-2.2
+func (s *ClientAuthenticatorImpl) AuthenticateSelfSignedTLSClient(
+	ctx context.Context,
+	req *http.Request,
+	client *config.OAuthClientConfig,
+) error {
+	if s.TLSClientCertificateHeader == "" {
+		return newInvalidClientError("self_signed_tls_client_auth is not enabled")
+	}
+
+	headerValue := req.Header.Get(string(s.TLSClientCertificateHeader))
+	if headerValue == "" {
+		return newInvalidClientError("client certificate is required")
+	}
+
+	cert, err := parseClientCertificate(headerValue)
+	if err != nil {
+		return newInvalidClientError("invalid client certificate")
+	}
+
+	now := s.Clock.NowUTC()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return newInvalidClientError("client certificate is expired")
+	}
+
+	certKey, err := jwk.FromRaw(cert.PublicKey)
+	if err != nil {
+		return newInvalidClientError("invalid client certificate")
+	}
+	certThumbprint, err := certKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return newInvalidClientError("invalid client certificate")
+	}
+
+	set, err := s.clientJWKS(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < set.Len(); i++ {
+		key, _ := set.Key(i)
+		thumbprint, err := key.Thumbprint(crypto.SHA256)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(thumbprint, certThumbprint) {
+			return nil
+		}
+	}
+
+	return newInvalidClientError("client certificate does not match the keys of the client")
+}
+
+func (s *ClientAuthenticatorImpl) clientJWKS(ctx context.Context, client *config.OAuthClientConfig) (jwk.Set, error) {
+	switch {
+	case client.JWKS != nil:
+		set, err := client.JWKS.Set()
+		if err != nil {
+			return nil, newInvalidClientError("invalid client jwks")
+		}
+		return set, nil
+	case client.JWKSURI != "":
+		set, err := s.HTTPClient.FetchJWKS(ctx, client.JWKSURI)
+		if err != nil {
+			return nil, newInvalidClientError("failed to fetch client jwks_uri")
+		}
+		return set, nil
+	default:
+		return nil, newInvalidClientError("client does not have jwks")
+	}
+}
+
+// parseClientCertificate parses the client certificate forwarded by the TLS terminating proxy.
+// The certificate is either a URL-escaped PEM, or a base64-encoded DER.
+func parseClientCertificate(headerValue string) (*x509.Certificate, error) {
+	unescaped, err := url.PathUnescape(headerValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if block, _ := pem.Decode([]byte(unescaped)); block != nil {
+		return x509.ParseCertificate(block.Bytes)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(headerValue)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,245 @@
+package handler_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/nonce"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+type clientAssertionNonceStore struct {
+	nonces map[string]time.Time
+}
+
+func (s *clientAssertionNonceStore) Consume(ctx context.Context, scope string, n string, expireAt time.Time) error {
+	key := scope + ":" + n
+	if _, ok := s.nonces[key]; ok {
+		return nonce.ErrNonceReused
+	}
+	s.nonces[key] = expireAt
+	return nil
+}
+
+func newClientCertificate(key jwk.Key, now time.Time) string {
+	var privKey ecdsa.PrivateKey
+	err := key.Raw(&privKey)
+	So(err, ShouldBeNil)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, &privKey)
+	So(err, ShouldBeNil)
+
+	return url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+}
+
+func TestClientAuthenticator(t *testing.T) {
+	Convey("ClientAuthenticator", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		key, publicJWK := newRequestObjectKey()
+
+		client := &config.OAuthClientConfig{
+			ClientID:                "m2m",
+			ApplicationType:         config.OAuthClientApplicationTypeM2M,
+			TokenEndpointAuthMethod: config.OAuthClientAuthMethodPrivateKeyJWT,
+			JWKS: &config.OAuthClientJWKS{
+				Keys: []map[string]any{publicJWK},
+			},
+		}
+		authenticator := &handler.ClientAuthenticatorImpl{
+			Clock:                      clk,
+			Issuer:                     pushedAuthorizationIssuer{},
+			Nonces:                     &clientAssertionNonceStore{nonces: map[string]time.Time{}},
+			TLSClientCertificateHeader: "X-Client-Cert",
+		}
+
+		req, _ := http.NewRequest("POST", "/oauth2/token", nil)
+
+		signClientAssertion := func(signingKey jwk.Key, claims map[string]any) string {
+			token, err := jwtutil.BuildFromMap(claims)
+			So(err, ShouldBeNil)
+			b, err := jwtutil.Sign(token, jwa.ES256, signingKey)
+			So(err, ShouldBeNil)
+			return string(b)
+		}
+
+		validClaims := func() map[string]any {
+			return map[string]any{
+				jwt.IssuerKey:     "m2m",
+				jwt.SubjectKey:    "m2m",
+				jwt.AudienceKey:   "http://accounts.example.com/oauth2/token",
+				jwt.ExpirationKey: clk.NowUTC().Add(time.Minute).Unix(),
+				jwt.JwtIDKey:      "jti-1",
+			}
+		}
+
+		authenticate := func(clientAssertion string) error {
+			return authenticator.AuthenticatePrivateKeyJWT(context.Background(), req, client, handler.ClientAssertionTypeJWTBearer, clientAssertion)
+		}
+
+		errorCode := func(err error) string {
+			oauthError, ok := err.(*protocol.OAuthProtocolError)
+			So(ok, ShouldBeTrue)
+			So(oauthError.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			return oauthError.Type()
+		}
+
+		Convey("should accept valid client assertion once", func() {
+			clientAssertion := signClientAssertion(key, validClaims())
+			So(authenticate(clientAssertion), ShouldBeNil)
+
+			err := authenticate(clientAssertion)
+			So(errorCode(err), ShouldEqual, "invalid_client")
+			So(err.Error(), ShouldEqual, "client_assertion has been used")
+		})
+
+		Convey("should accept issuer as audience", func() {
+			claims := validClaims()
+			claims[jwt.AudienceKey] = "http://accounts.example.com"
+			So(authenticate(signClientAssertion(key, claims)), ShouldBeNil)
+		})
+
+		Convey("should reject unknown client_assertion_type", func() {
+			err := authenticator.AuthenticatePrivateKeyJWT(context.Background(), req, client, "unknown", signClientAssertion(key, validClaims()))
+			So(errorCode(err), ShouldEqual, "invalid_client")
+		})
+
+		Convey("should reject wrong audience", func() {
+			claims := validClaims()
+			claims[jwt.AudienceKey] = "http://other.example.com"
+			err := authenticate(signClientAssertion(key, claims))
+			So(err.Error(), ShouldEqual, "invalid client_assertion audience")
+		})
+
+		Convey("should reject client assertion without jti", func() {
+			claims := validClaims()
+			delete(claims, jwt.JwtIDKey)
+			err := authenticate(signClientAssertion(key, claims))
+			So(errorCode(err), ShouldEqual, "invalid_client")
+		})
+
+		Convey("should reject expired client assertion", func() {
+			clientAssertion := signClientAssertion(key, validClaims())
+			clk.AdvanceSeconds(3600)
+			err := authenticate(clientAssertion)
+			So(errorCode(err), ShouldEqual, "invalid_client")
+		})
+
+		Convey("should reject client assertion signed by unknown key", func() {
+			otherKey, _ := newRequestObjectKey()
+			err := authenticate(signClientAssertion(otherKey, validClaims()))
+			So(err.Error(), ShouldEqual, "invalid client_assertion signature")
+		})
+
+		Convey("should accept self-signed client certificate of the client", func() {
+			req.Header.Set("X-Client-Cert", newClientCertificate(key, clk.NowUTC()))
+			err := authenticator.AuthenticateSelfSignedTLSClient(context.Background(), req, client)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should reject client certificate of another key", func() {
+			otherKey, _ := newRequestObjectKey()
+			req.Header.Set("X-Client-Cert", newClientCertificate(otherKey, clk.NowUTC()))
+			err := authenticator.AuthenticateSelfSignedTLSClient(context.Background(), req, client)
+			So(err.Error(), ShouldEqual, "client certificate does not match the keys of the client")
+		})
+
+		Convey("should reject missing client certificate", func() {
+			err := authenticator.AuthenticateSelfSignedTLSClient(context.Background(), req, client)
+			So(err.Error(), ShouldEqual, "client certificate is required")
+		})
+
+		Convey("should authenticate at the pushed authorization request endpoint", func() {
+			clientResolver := &multiClientResolver{
+				ClientConfigs: map[string]*config.OAuthClientConfig{
+					"web": {
+						ClientID:                "web",
+						ApplicationType:         config.OAuthClientApplicationTypeConfidential,
+						RedirectURIs:            []string{"https://example.com/callback"},
+						TokenEndpointAuthMethod: config.OAuthClientAuthMethodPrivateKeyJWT,
+						JWKS:                    client.JWKS,
+					},
+					"app": {
+						ClientID:        "app",
+						ApplicationType: config.OAuthClientApplicationTypeNative,
+						RedirectURIs:    []string{"https://example.com/callback"},
+					},
+				},
+			}
+			authzHandler := &handler.AuthorizationHandler{
+				AppID:          "app-id",
+				Config:         &config.OAuthConfig{},
+				HTTPOrigin:     "http://accounts.example.com",
+				Clock:          clk,
+				ClientResolver: clientResolver,
+			}
+			h := &handler.PushedAuthorizationHandler{
+				AppID:                       "app-id",
+				OAuthClientCredentials:      &config.OAuthClientCredentials{},
+				ClientResolver:              clientResolver,
+				ClientAuthenticator:         authenticator,
+				Authorizations:              authzHandler,
+				PushedAuthorizationRequests: &pushedAuthorizationRequestStore{requests: map[string]*oauth.PushedAuthorizationRequest{}},
+				RateLimiter:                 introspectRateLimiter{},
+				Clock:                       clk,
+			}
+
+			push := func(form url.Values) int {
+				req, _ := http.NewRequest("POST", "/oauth2/par", nil)
+				result := h.Handle(context.Background(), req, protocol.PushedAuthorizationRequest(form))
+				rw := httptest.NewRecorder()
+				result.WriteResponse(rw, req)
+				return rw.Code
+			}
+
+			claims := validClaims()
+			claims[jwt.IssuerKey] = "web"
+			claims[jwt.SubjectKey] = "web"
+			claims[jwt.AudienceKey] = "http://accounts.example.com"
+
+			So(push(url.Values{
+				"client_assertion_type": {handler.ClientAssertionTypeJWTBearer},
+				"client_assertion":      {signClientAssertion(key, claims)},
+				"response_type":         {"code"},
+				"redirect_uri":          {"https://example.com/callback"},
+				"scope":                 {"openid"},
+			}), ShouldEqual, 201)
+
+			So(push(url.Values{
+				"client_id":     {"web"},
+				"client_secret": {"secret"},
+			}), ShouldEqual, 401)
+
+			So(push(url.Values{
+				"client_id":             {"app"},
+				"client_assertion_type": {handler.ClientAssertionTypeJWTBearer},
+				"client_assertion":      {signClientAssertion(key, claims)},
+			}), ShouldEqual, 401)
+		})
+	})
+}
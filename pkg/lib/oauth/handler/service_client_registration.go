@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	// We need "sigs.k8s.io/yaml" package instead of other yaml serializer,
+	// because "gopkg.in/yaml.v3" add `null`s for null pointers which break some validations.
+	"sigs.k8s.io/yaml"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/globaldb"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/filepathutil"
+	"github.com/authgear/authgear-server/pkg/util/secrets"
+)
+
+var ErrClientRegistrationNotFound = errors.New("client registration not found")
+
+type ClientRegistrationConfigSourceStore interface {
+	GetDatabaseSourceByAppID(ctx context.Context, appID string) (*configsource.DatabaseSource, error)
+	UpdateDatabaseSource(ctx context.Context, dbs *configsource.DatabaseSource) error
+}
+
+// ClientRegistrationService writes the clients managed by the client registration endpoint
+// to authgear.yaml and authgear.secrets.yaml in the database config source.
+// The config source notifies the servers to reload the app config on commit.
+type ClientRegistrationService struct {
+	AppID              config.AppID
+	GlobalDatabase     *globaldb.Handle
+	ConfigSourceStore  ClientRegistrationConfigSourceStore
+	OAuthFeatureConfig *config.OAuthFeatureConfig
+	Clock              clock.Clock
+}
+
+// CreateClient adds the client to authgear.yaml.
+// If the client authenticates with a client secret, a client secret is generated and returned.
+func (s *ClientRegistrationService) CreateClient(ctx context.Context, clientID string, metadata map[string]any) (client *config.OAuthClientConfig, clientSecret string, err error) {
+	client, err = s.update(ctx, clientID, func(clients []any) ([]any, error) {
+		if findClient(clients, clientID) >= 0 {
+			return nil, fmt.Errorf("duplicated client ID: %v", clientID)
+		}
+		client, err := newClientMetadata(clientID, metadata)
+		if err != nil {
+			return nil, err
+		}
+		return append(clients, client), nil
+	}, func(client *config.OAuthClientConfig, secretConfig *config.SecretConfig) (*config.SecretConfig, error) {
+		if !clientSecretRequired(client) {
+			return secretConfig, nil
+		}
+		var err error
+		secretConfig, clientSecret, err = s.generateClientSecret(clientID, secretConfig)
+		return secretConfig, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, clientSecret, nil
+}
+
+// UpdateClient replaces the metadata of the client in authgear.yaml.
+// The client secret is generated if the client now requires one, or if rotateClientSecret is true.
+// When the client secret is rotated, the previous client secret remains valid
+// until the next rotation, so that the client can roll out the new one.
+func (s *ClientRegistrationService) UpdateClient(ctx context.Context, clientID string, metadata map[string]any, rotateClientSecret bool) (client *config.OAuthClientConfig, clientSecret string, err error) {
+	client, err = s.update(ctx, clientID, func(clients []any) ([]any, error) {
+		idx := findClient(clients, clientID)
+		if idx < 0 {
+			return nil, ErrClientRegistrationNotFound
+		}
+		client, err := newClientMetadata(clientID, metadata)
+		if err != nil {
+			return nil, err
+		}
+		clients[idx] = client
+		return clients, nil
+	}, func(client *config.OAuthClientConfig, secretConfig *config.SecretConfig) (*config.SecretConfig, error) {
+		if !clientSecretRequired(client) {
+			return secretConfig, nil
+		}
+
+		credentials, _ := secretConfig.LookupData(config.OAuthClientCredentialsKey).(*config.OAuthClientCredentials)
+		var item *config.OAuthClientCredentialsItem
+		if credentials != nil {
+			item, _ = credentials.Lookup(clientID)
+		}
+		if item != nil && !rotateClientSecret {
+			return secretConfig, nil
+		}
+
+		var err error
+		if item != nil && item.Len() >= 2 {
+			// Revoke the oldest client secret to make room for the new one.
+			oldest, _ := item.Set.Key(0)
+			secretConfig, err = (&config.OAuthClientSecretsUpdateInstruction{
+				Action: config.SecretUpdateInstructionActionDelete,
+				DeleteData: &config.OAuthClientSecretsUpdateInstructionDeleteData{
+					ClientID: clientID,
+					KeyID:    oldest.KeyID(),
+				},
+			}).ApplyTo(s.instructionContext(nil), secretConfig)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		secretConfig, clientSecret, err = s.generateClientSecret(clientID, secretConfig)
+		return secretConfig, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, clientSecret, nil
+}
+
+// DeleteClient removes the client from authgear.yaml, and its client secrets from authgear.secrets.yaml.
+func (s *ClientRegistrationService) DeleteClient(ctx context.Context, clientID string) error {
+	var keepClientIDs []string
+	_, err := s.update(ctx, clientID, func(clients []any) ([]any, error) {
+		idx := findClient(clients, clientID)
+		if idx < 0 {
+			return nil, ErrClientRegistrationNotFound
+		}
+		clients = append(clients[:idx], clients[idx+1:]...)
+
+		keepClientIDs = []string{}
+		for _, c := range clients {
+			if m, ok := c.(map[string]any); ok {
+				if id, ok := m["client_id"].(string); ok {
+					keepClientIDs = append(keepClientIDs, id)
+				}
+			}
+		}
+		return clients, nil
+	}, func(_ *config.OAuthClientConfig, secretConfig *config.SecretConfig) (*config.SecretConfig, error) {
+		return (&config.OAuthClientSecretsUpdateInstruction{
+			Action: config.SecretUpdateInstructionActionCleanup,
+			CleanupData: &config.OAuthClientSecretsUpdateInstructionCleanupData{
+				KeepClientIDs: keepClientIDs,
+			},
+		}).ApplyTo(s.instructionContext(nil), secretConfig)
+	})
+	return err
+}
+
+// update applies the changes to authgear.yaml and authgear.secrets.yaml in a transaction.
+// It returns the client after the changes, or nil if the client is deleted.
+func (s *ClientRegistrationService) update(
+	ctx context.Context,
+	clientID string,
+	updateClients func(clients []any) ([]any, error),
+	updateSecrets func(client *config.OAuthClientConfig, secretConfig *config.SecretConfig) (*config.SecretConfig, error),
+) (*config.OAuthClientConfig, error) {
+	var client *config.OAuthClientConfig
+	err := s.GlobalDatabase.WithTx(ctx, func(ctx context.Context) error {
+		dbs, err := s.ConfigSourceStore.GetDatabaseSourceByAppID(ctx, string(s.AppID))
+		if err != nil {
+			return err
+		}
+
+		appConfigKey := filepathutil.EscapePath(configsource.AuthgearYAML)
+		secretConfigKey := filepathutil.EscapePath(configsource.AuthgearSecretYAML)
+
+		var appConfigDoc map[string]any
+		err = yaml.Unmarshal(dbs.Data[appConfigKey], &appConfigDoc)
+		if err != nil {
+			return err
+		}
+
+		oauthDoc, _ := appConfigDoc["oauth"].(map[string]any)
+		if oauthDoc == nil {
+			oauthDoc = map[string]any{}
+		}
+		clients, _ := oauthDoc["clients"].([]any)
+		clients, err = updateClients(clients)
+		if err != nil {
+			return err
+		}
+		oauthDoc["clients"] = clients
+		appConfigDoc["oauth"] = oauthDoc
+
+		appConfigYAML, err := yaml.Marshal(appConfigDoc)
+		if err != nil {
+			return err
+		}
+
+		// Validate with the same schema as the portal.
+		appConfig, err := config.Parse(ctx, appConfigYAML)
+		if err != nil {
+			return protocol.NewError("invalid_client_metadata", err.Error())
+		}
+		if len(appConfig.OAuth.Clients) > *s.OAuthFeatureConfig.Client.Maximum {
+			return protocol.NewError("invalid_client_metadata", fmt.Sprintf(
+				"exceed the maximum number of oauth clients, actual: %d, expected: %d",
+				len(appConfig.OAuth.Clients),
+				*s.OAuthFeatureConfig.Client.Maximum,
+			))
+		}
+		client, _ = appConfig.OAuth.GetClient(clientID)
+
+		secretConfig, err := config.ParseSecret(ctx, dbs.Data[secretConfigKey])
+		if err != nil {
+			return err
+		}
+		secretConfig, err = updateSecrets(client, secretConfig)
+		if err != nil {
+			return err
+		}
+		secretConfigYAML, err := yaml.Marshal(secretConfig)
+		if err != nil {
+			return err
+		}
+
+		dbs.Data[appConfigKey] = appConfigYAML
+		dbs.Data[secretConfigKey] = secretConfigYAML
+		dbs.UpdatedAt = s.Clock.NowUTC()
+		return s.ConfigSourceStore.UpdateDatabaseSource(ctx, dbs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (s *ClientRegistrationService) instructionContext(generateKey func(createdAt time.Time, rng *mathrand.Rand) jwk.Key) *config.SecretConfigUpdateInstructionContext {
+	return &config.SecretConfigUpdateInstructionContext{
+		Clock:                            s.Clock,
+		GenerateClientSecretOctetKeyFunc: generateKey,
+	}
+}
+
+func (s *ClientRegistrationService) generateClientSecret(clientID string, secretConfig *config.SecretConfig) (*config.SecretConfig, string, error) {
+	var key jwk.Key
+	ctx := s.instructionContext(func(createdAt time.Time, rng *mathrand.Rand) jwk.Key {
+		// The key generated for client secret doesn't have use usage key
+		// Since the key neither use for sig nor enc
+		key = secrets.GenerateOctetKey(createdAt, rng)
+		return key
+	})
+
+	secretConfig, err := (&config.OAuthClientSecretsUpdateInstruction{
+		Action: config.SecretUpdateInstructionActionGenerate,
+		GenerateData: &config.OAuthClientSecretsUpdateInstructionGenerateData{
+			ClientID: clientID,
+		},
+	}).ApplyTo(ctx, secretConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var clientSecret []byte
+	err = key.Raw(&clientSecret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return secretConfig, string(clientSecret), nil
+}
+
+func findClient(clients []any, clientID string) int {
+	for idx, c := range clients {
+		if m, ok := c.(map[string]any); ok && m["client_id"] == clientID {
+			return idx
+		}
+	}
+	return -1
+}
+
+// registrableClientMetadata is the client metadata that a client can register by itself.
+// It is the metadata in RFC 7591, and the metadata registered by the extensions we support.
+// Other fields of OAuthClientConfig, like x_application_type, can only be configured by the developer.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-2
+var registrableClientMetadata = map[string]struct{}{
+	"redirect_uris":              {},
+	"token_endpoint_auth_method": {},
+	"grant_types":                {},
+	"response_types":             {},
+	"client_name":                {},
+	"client_uri":                 {},
+	"logo_uri":                   {},
+	"policy_uri":                 {},
+	"tos_uri":                    {},
+	"jwks":                       {},
+	"jwks_uri":                   {},
+	// OpenID Connect RP-Initiated Logout
+	"post_logout_redirect_uris": {},
+	// OpenID Connect Back-Channel Logout and Front-Channel Logout
+	"backchannel_logout_uri":  {},
+	"frontchannel_logout_uri": {},
+	// OpenID Connect CIBA
+	"backchannel_token_delivery_mode":          {},
+	"backchannel_client_notification_endpoint": {},
+	// OAuth 2.0 Pushed Authorization Requests
+	"require_pushed_authorization_requests": {},
+}
+
+// alwaysSatisfiedClientMetadata is the client metadata that is accepted but not stored,
+// because it asks for the behavior we always have.
+// sid is always included in logout tokens, and iss and sid are always added to frontchannel_logout_uri.
+var alwaysSatisfiedClientMetadata = map[string]struct{}{
+	"backchannel_logout_session_required":  {},
+	"frontchannel_logout_session_required": {},
+}
+
+// newClientMetadata turns the client registration request into OAuthClientConfig.
+// A registered client is always a third-party app, so that the user must give consent to it.
+func newClientMetadata(clientID string, metadata map[string]any) (map[string]any, error) {
+	out := map[string]any{}
+	for k, v := range metadata {
+		if _, ok := alwaysSatisfiedClientMetadata[k]; ok {
+			continue
+		}
+		if _, ok := registrableClientMetadata[k]; !ok {
+			return nil, protocol.NewError("invalid_client_metadata", fmt.Sprintf("unsupported client metadata: %v", k))
+		}
+		out[k] = v
+	}
+
+	// A third-party app must authenticate at the token endpoint.
+	if out["token_endpoint_auth_method"] == string(config.OAuthClientAuthMethodNone) {
+		return nil, protocol.NewError("invalid_client_metadata", "token_endpoint_auth_method none is not supported")
+	}
+
+	out["client_id"] = clientID
+	out["x_application_type"] = string(config.OAuthClientApplicationTypeThirdPartyApp)
+	if clientName, ok := out["client_name"].(string); ok && clientName != "" {
+		out["name"] = clientName
+	} else {
+		out["name"] = clientID
+	}
+
+	return out, nil
+}
+
+// clientSecretRequired reports whether the client must have a client secret in authgear.secrets.yaml.
+// Confidential clients always have one, even if they authenticate with their keys.
+func clientSecretRequired(client *config.OAuthClientConfig) bool {
+	return client.IsConfidential()
+}
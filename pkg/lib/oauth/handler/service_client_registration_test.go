@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"sigs.k8s.io/yaml"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+)
+
+func TestNewClientMetadata(t *testing.T) {
+	Convey("newClientMetadata", t, func() {
+		ctx := context.Background()
+
+		parse := func(client map[string]any) (*config.OAuthClientConfig, error) {
+			appConfigYAML, err := yaml.Marshal(map[string]any{
+				"id": "test",
+				"http": map[string]any{
+					"public_origin": "http://test",
+				},
+				"oauth": map[string]any{
+					"clients": []any{client},
+				},
+			})
+			So(err, ShouldBeNil)
+
+			appConfig, err := config.Parse(ctx, appConfigYAML)
+			if err != nil {
+				return nil, err
+			}
+			c, _ := appConfig.OAuth.GetClient(client["client_id"].(string))
+			return c, nil
+		}
+
+		shouldBeInvalidClientMetadata := func(err error) {
+			var oauthError *protocol.OAuthProtocolError
+			So(errors.As(err, &oauthError), ShouldBeTrue)
+			So(oauthError.Type(), ShouldEqual, "invalid_client_metadata")
+		}
+
+		Convey("should register a third-party app", func() {
+			client, err := newClientMetadata("client-id", map[string]any{
+				"client_name":   "Example",
+				"redirect_uris": []any{"https://example.com/callback"},
+			})
+			So(err, ShouldBeNil)
+			So(client, ShouldResemble, map[string]any{
+				"client_id":          "client-id",
+				"name":               "Example",
+				"client_name":        "Example",
+				"x_application_type": "third_party_app",
+				"redirect_uris":      []any{"https://example.com/callback"},
+			})
+
+			c, err := parse(client)
+			So(err, ShouldBeNil)
+			So(c.IsThirdParty(), ShouldBeTrue)
+			So(c.IsConfidential(), ShouldBeTrue)
+		})
+
+		Convey("should require client_name", func() {
+			client, err := newClientMetadata("client-id", map[string]any{
+				"redirect_uris": []any{"https://example.com/callback"},
+			})
+			So(err, ShouldBeNil)
+			So(client["name"], ShouldEqual, "client-id")
+
+			_, err = parse(client)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should accept the registrable client metadata", func() {
+			client, err := newClientMetadata("client-id", map[string]any{
+				"client_name":                     "Example",
+				"redirect_uris":                   []any{"https://example.com/callback"},
+				"token_endpoint_auth_method":      "client_secret_post",
+				"post_logout_redirect_uris":       []any{"https://example.com/logout"},
+				"backchannel_logout_uri":          "https://example.com/backchannel-logout",
+				"backchannel_token_delivery_mode": "poll",
+			})
+			So(err, ShouldBeNil)
+
+			_, err = parse(client)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should reject x_application_type", func() {
+			_, err := newClientMetadata("client-id", map[string]any{
+				"client_name":        "Example",
+				"redirect_uris":      []any{"https://example.com/callback"},
+				"x_application_type": "spa",
+			})
+			shouldBeInvalidClientMetadata(err)
+		})
+
+		Convey("should reject other fields of OAuthClientConfig", func() {
+			for _, field := range []string{"name", "x_custom_ui_uri", "x_max_concurrent_session", "access_token_lifetime_seconds", "issue_jwt_access_token"} {
+				_, err := newClientMetadata("client-id", map[string]any{
+					"client_name":   "Example",
+					"redirect_uris": []any{"https://example.com/callback"},
+					field:           "value",
+				})
+				shouldBeInvalidClientMetadata(err)
+			}
+		})
+
+		Convey("should reject token_endpoint_auth_method none", func() {
+			_, err := newClientMetadata("client-id", map[string]any{
+				"client_name":                "Example",
+				"redirect_uris":              []any{"https://example.com/callback"},
+				"token_endpoint_auth_method": "none",
+			})
+			shouldBeInvalidClientMetadata(err)
+		})
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticationinfo"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+var ErrDeviceUserCodeInvalid = apierrors.Invalid.WithReason("InvalidDeviceUserCode").New("invalid user code")
+
+type DeviceGrantServiceRateLimiter interface {
+	Allow(ctx context.Context, spec ratelimit.BucketSpec) (*ratelimit.FailedReservation, error)
+}
+
+// DeviceGrantService is used by the verification page to approve or deny a device grant.
+type DeviceGrantService struct {
+	Clock          clock.Clock
+	RemoteIP       httputil.RemoteIP
+	DeviceGrants   oauth.DeviceGrantStore
+	Authorizations AuthorizationService
+	RateLimiter    DeviceGrantServiceRateLimiter
+}
+
+// deviceGrantUpdateMaxAttempts is the number of times a decision is saved
+// when the grant is updated concurrently by the polling device.
+const deviceGrantUpdateMaxAttempts = 3
+
+// GetPendingDeviceGrant returns the pending device grant identified by userCode.
+func (s *DeviceGrantService) GetPendingDeviceGrant(ctx context.Context, userCode string) (*oauth.DeviceGrant, error) {
+	failedReservation, err := s.RateLimiter.Allow(ctx, NewBucketSpecOAuthDeviceVerificationPerIP(string(s.RemoteIP)))
+	if err != nil {
+		return nil, err
+	}
+	if err := failedReservation.Error(); err != nil {
+		return nil, err
+	}
+
+	return s.getPendingDeviceGrant(ctx, userCode)
+}
+
+func (s *DeviceGrantService) getPendingDeviceGrant(ctx context.Context, userCode string) (*oauth.DeviceGrant, error) {
+	g, err := s.DeviceGrants.GetDeviceGrantByUserCode(ctx, oauth.HashUserCode(userCode))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, ErrDeviceUserCodeInvalid
+	} else if err != nil {
+		return nil, err
+	}
+
+	if g.Status != oauth.DeviceGrantStatusPending || !s.Clock.NowUTC().Before(g.ExpireAt) {
+		return nil, ErrDeviceUserCodeInvalid
+	}
+
+	return g, nil
+}
+
+// updatePendingDeviceGrant applies update to the pending device grant.
+// The device updates the grant when it polls, so the update is retried on conflict.
+func (s *DeviceGrantService) updatePendingDeviceGrant(ctx context.Context, userCode string, update func(g *oauth.DeviceGrant)) error {
+	for attempt := 1; ; attempt++ {
+		g, err := s.getPendingDeviceGrant(ctx, userCode)
+		if err != nil {
+			return err
+		}
+
+		update(g)
+		err = s.DeviceGrants.UpdateDeviceGrant(ctx, g)
+		if errors.Is(err, oauth.ErrGrantConflict) && attempt < deviceGrantUpdateMaxAttempts {
+			continue
+		} else if errors.Is(err, oauth.ErrGrantNotFound) {
+			return ErrDeviceUserCodeInvalid
+		}
+		return err
+	}
+}
+
+func (s *DeviceGrantService) ApproveDeviceGrant(ctx context.Context, userCode string, info authenticationinfo.T) error {
+	g, err := s.GetPendingDeviceGrant(ctx, userCode)
+	if err != nil {
+		return err
+	}
+
+	authz, err := s.Authorizations.CheckAndGrant(ctx, g.ClientID, info.UserID, g.Scopes)
+	if err != nil {
+		return err
+	}
+
+	return s.updatePendingDeviceGrant(ctx, userCode, func(g *oauth.DeviceGrant) {
+		g.Status = oauth.DeviceGrantStatusApproved
+		g.AuthorizationID = authz.ID
+		g.AuthenticationInfo = info
+	})
+}
+
+func (s *DeviceGrantService) DenyDeviceGrant(ctx context.Context, userCode string) error {
+	_, err := s.GetPendingDeviceGrant(ctx, userCode)
+	if err != nil {
+		return err
+	}
+
+	return s.updatePendingDeviceGrant(ctx, userCode, func(g *oauth.DeviceGrant) {
+		g.Status = oauth.DeviceGrantStatusDenied
+	})
+}
@@ -1,12 +1,31 @@
 package oauth
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/dpop"
 	"github.com/authgear/authgear-server/pkg/util/pkce"
 )
 
+// ClientAssertionSigningAlgorithms are the algorithms accepted in private_key_jwt client assertions.
+var ClientAssertionSigningAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
 type MetadataProvider struct {
-	Endpoints EndpointsProvider
+	Endpoints                  EndpointsProvider
+	TLSClientCertificateHeader config.TLSClientCertificateHeader
+}
+
+func (p *MetadataProvider) clientAuthMethods() []string {
+	methods := []string{"client_secret_post", "client_secret_basic", "private_key_jwt"}
+	// self_signed_tls_client_auth requires the TLS terminating proxy to forward the client certificate.
+	if p.TLSClientCertificateHeader != "" {
+		methods = append(methods, "self_signed_tls_client_auth")
+	}
+	return methods
 }
 
 func (p *MetadataProvider) PopulateMetadata(meta map[string]any) {
@@ -14,11 +33,35 @@ func (p *MetadataProvider) PopulateMetadata(meta map[string]any) {
 	meta["token_endpoint"] = p.Endpoints.TokenEndpointURL().String()
 	meta["response_types_supported"] = []string{"code", "urn:authgear:params:oauth:response-type:settings-action", "none"}
 	meta["response_modes_supported"] = []string{"query", "fragment", "form_post"}
-	meta["grant_types_supported"] = []string{"authorization_code", "refresh_token", "client_credentials"}
+	meta["grant_types_supported"] = []string{"authorization_code", "refresh_token", "client_credentials", DeviceCodeGrantType, CIBAGrantType}
 	meta["code_challenge_methods_supported"] = []string{pkce.CodeChallengeMethodS256}
 	meta["revocation_endpoint"] = p.Endpoints.RevokeEndpointURL().String()
+	meta["introspection_endpoint"] = p.Endpoints.IntrospectEndpointURL().String()
+	meta["introspection_endpoint_auth_methods_supported"] = p.clientAuthMethods()
+	meta["introspection_endpoint_auth_signing_alg_values_supported"] = ClientAssertionSigningAlgorithms
+	meta["revocation_endpoint_auth_methods_supported"] = append([]string{"none"}, p.clientAuthMethods()...)
+	meta["revocation_endpoint_auth_signing_alg_values_supported"] = ClientAssertionSigningAlgorithms
+	meta["device_authorization_endpoint"] = p.Endpoints.DeviceAuthorizationEndpointURL().String()
+	// Pushed authorization requests are required per client with the client metadata of the same name.
+	// See https://datatracker.ietf.org/doc/html/rfc9126#section-5
+	meta["pushed_authorization_request_endpoint"] = p.Endpoints.PushedAuthorizationRequestEndpointURL().String()
+	meta["require_pushed_authorization_requests"] = false
+	meta["request_parameter_supported"] = true
+	meta["request_uri_parameter_supported"] = true
+	// Registration requires an initial access token minted from the Admin API.
+	// See https://datatracker.ietf.org/doc/html/rfc7591#section-3
+	meta["registration_endpoint"] = p.Endpoints.RegistrationEndpointURL().String()
+	// See https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.4
+	meta["backchannel_authentication_endpoint"] = p.Endpoints.BackchannelAuthenticationEndpointURL().String()
+	meta["backchannel_token_delivery_modes_supported"] = []string{
+		string(config.OAuthClientBackchannelTokenDeliveryModePoll),
+		string(config.OAuthClientBackchannelTokenDeliveryModePing),
+	}
+	meta["backchannel_user_code_parameter_supported"] = false
 	// See https://openid.net/specs/openid-connect-discovery-1_0.html#:~:text=passed%20by%20reference.-,token_endpoint_auth_methods_supported,-OPTIONAL.%20JSON%20array
 	// See https://openid.net/specs/openid-connect-core-1_0.html#ClientAuthentication:~:text=The%20Client%20does%20not%20authenticate%20itself%20at%20the%20Token%20Endpoint
-	meta["token_endpoint_auth_methods_supported"] = []string{"none", "client_secret_post", "client_secret_basic"}
+	meta["token_endpoint_auth_methods_supported"] = append([]string{"none"}, p.clientAuthMethods()...)
+	// See https://datatracker.ietf.org/doc/html/rfc8414#section-2
+	meta["token_endpoint_auth_signing_alg_values_supported"] = ClientAssertionSigningAlgorithms
 	meta["dpop_signing_alg_values_supported"] = dpop.SupportedAlgorithms
 }
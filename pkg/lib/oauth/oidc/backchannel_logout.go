@@ -0,0 +1,266 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+// BackchannelLogoutEvent is the only member of the events claim of logout tokens.
+// See https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+const BackchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// LogoutTokenValidDuration is the valid period of logout token.
+// The spec recommends two minutes or less.
+const LogoutTokenValidDuration = 2 * time.Minute
+
+// BackchannelLogoutMaxAttempts is the number of times a logout token is delivered
+// before the delivery is given up.
+const BackchannelLogoutMaxAttempts = 5
+
+// BackchannelLogoutRetryBackoff is the delay before the first retry.
+// The delay is doubled on every subsequent retry.
+const BackchannelLogoutRetryBackoff = 5 * time.Second
+
+var BackchannelLogoutLogger = slogutil.NewLogger("oidc-backchannel-logout")
+
+// BackchannelLogoutRequest is the input of a backchannel logout task.
+type BackchannelLogoutRequest struct {
+	ClientID string `json:"client_id"`
+	UserID   string `json:"user_id"`
+	SID      string `json:"sid"`
+	Attempt  int    `json:"attempt"`
+}
+
+type BackchannelLogoutProducer interface {
+	NewTask(appID string, input json.RawMessage, taskIDPrefix string) *redisqueue.Task
+	EnqueueTask(ctx context.Context, task *redisqueue.Task) error
+	EnqueueTaskAt(ctx context.Context, task *redisqueue.Task, notBefore time.Time) error
+}
+
+func enqueueBackchannelLogout(ctx context.Context, producer BackchannelLogoutProducer, appID config.AppID, request *BackchannelLogoutRequest) error {
+	rawMessage, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	task := producer.NewTask(string(appID), rawMessage, "task")
+	return producer.EnqueueTask(ctx, task)
+}
+
+func enqueueBackchannelLogoutRetry(ctx context.Context, producer BackchannelLogoutProducer, appID config.AppID, request *BackchannelLogoutRequest, notBefore time.Time) error {
+	rawMessage, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	task := producer.NewTask(string(appID), rawMessage, "task")
+	return producer.EnqueueTaskAt(ctx, task, notBefore)
+}
+
+type BackchannelLogoutDatabase interface {
+	UseHook(ctx context.Context, hook db.TransactionHook)
+}
+
+// BackchannelLogoutService enqueues logout tokens for the clients
+// participating in the terminated sessions.
+// The tasks are enqueued after the transaction commits,
+// so that the clients are not logged out if the sessions are not terminated in the end.
+type BackchannelLogoutService struct {
+	AppID    config.AppID
+	OAuth    *config.OAuthConfig
+	Database BackchannelLogoutDatabase
+	Producer BackchannelLogoutProducer
+
+	Requests       []*BackchannelLogoutRequest `wire:"-"`
+	DatabaseHooked bool                        `wire:"-"`
+}
+
+func (s *BackchannelLogoutService) SendBackchannelLogout(ctx context.Context, sessions []session.ListableSession) error {
+	for _, sess := range sessions {
+		for _, client := range s.OAuth.Clients {
+			if client.BackchannelLogoutURI == "" || !participatedInSession(sess, client.ClientID) {
+				continue
+			}
+
+			s.Requests = append(s.Requests, &BackchannelLogoutRequest{
+				ClientID: client.ClientID,
+				UserID:   sess.GetAuthenticationInfo().UserID,
+				SID:      oauth.EncodeSID(sess),
+			})
+		}
+	}
+
+	if len(s.Requests) > 0 && !s.DatabaseHooked {
+		s.Database.UseHook(ctx, s)
+		s.DatabaseHooked = true
+	}
+
+	return nil
+}
+
+func (s *BackchannelLogoutService) WillCommitTx(ctx context.Context) error {
+	return nil
+}
+
+func (s *BackchannelLogoutService) DidCommitTx(ctx context.Context) {
+	logger := BackchannelLogoutLogger.GetLogger(ctx)
+
+	// Reset s.Requests so that the tasks are not enqueued twice.
+	requests := s.Requests
+	s.Requests = nil
+
+	for _, request := range requests {
+		err := enqueueBackchannelLogout(ctx, s.Producer, s.AppID, request)
+		if err != nil {
+			logger.WithError(err).Error(ctx, "backchannel logout: failed to enqueue task",
+				slog.String("client_id", request.ClientID),
+			)
+		}
+	}
+}
+
+// LogoutTokenIssuer signs logout tokens with the same key as ID tokens.
+type LogoutTokenIssuer struct {
+	Secrets *config.OAuthKeyMaterials
+	BaseURL BaseURLProvider
+	Clock   clock.Clock
+}
+
+func (ti *LogoutTokenIssuer) IssueLogoutToken(clientID string, userID string, sid string) (string, error) {
+	claims := jwt.New()
+
+	now := ti.Clock.NowUTC()
+	_ = claims.Set(jwt.IssuerKey, ti.BaseURL.Origin().String())
+	_ = claims.Set(jwt.AudienceKey, clientID)
+	_ = claims.Set(jwt.IssuedAtKey, now.Unix())
+	_ = claims.Set(jwt.ExpirationKey, now.Add(LogoutTokenValidDuration).Unix())
+	_ = claims.Set(jwt.JwtIDKey, uuid.New())
+	_ = claims.Set(jwt.SubjectKey, userID)
+	_ = claims.Set(string(model.ClaimSID), sid)
+	_ = claims.Set("events", map[string]any{
+		BackchannelLogoutEvent: map[string]any{},
+	})
+
+	hdr := jws.NewHeaders()
+	_ = hdr.Set("typ", "logout+jwt")
+
+	jwk, _ := ti.Secrets.Set.Key(0)
+	signed, err := jwtutil.SignWithHeader(claims, hdr, jwa.RS256, jwk)
+	if err != nil {
+		return "", err
+	}
+	return string(signed), nil
+}
+
+type BackchannelLogoutTokenIssuer interface {
+	IssueLogoutToken(clientID string, userID string, sid string) (string, error)
+}
+
+type BackchannelLogoutHTTPClient struct {
+	*http.Client
+}
+
+func NewBackchannelLogoutHTTPClient() BackchannelLogoutHTTPClient {
+	return BackchannelLogoutHTTPClient{
+		httputil.NewExternalClient(5 * time.Second),
+	}
+}
+
+// BackchannelLogoutDeliverer delivers logout tokens to the backchannel_logout_uri of clients.
+// Failed deliveries are enqueued again as delayed tasks with exponential backoff.
+type BackchannelLogoutDeliverer struct {
+	AppID      config.AppID
+	OAuth      *config.OAuthConfig
+	Issuer     BackchannelLogoutTokenIssuer
+	HTTPClient BackchannelLogoutHTTPClient
+	Producer   BackchannelLogoutProducer
+	Clock      clock.Clock
+}
+
+func (d *BackchannelLogoutDeliverer) Deliver(ctx context.Context, request *BackchannelLogoutRequest) error {
+	// The client could have been removed, or opted out, after the task was enqueued.
+	client, ok := d.OAuth.GetClient(request.ClientID)
+	if !ok || client.BackchannelLogoutURI == "" {
+		return nil
+	}
+
+	// The logout token is signed at delivery so that retries do not send expired tokens.
+	logoutToken, err := d.Issuer.IssueLogoutToken(request.ClientID, request.UserID, request.SID)
+	if err != nil {
+		return err
+	}
+
+	err = d.post(ctx, client.BackchannelLogoutURI, logoutToken)
+	if err == nil {
+		return nil
+	}
+
+	logger := BackchannelLogoutLogger.GetLogger(ctx)
+	if request.Attempt+1 >= BackchannelLogoutMaxAttempts {
+		logger.WithError(err).Warn(ctx, "backchannel logout: giving up delivery",
+			slog.String("client_id", request.ClientID),
+			slog.Int("attempt", request.Attempt),
+		)
+		return err
+	}
+
+	retry := *request
+	retry.Attempt = request.Attempt + 1
+	notBefore := d.Clock.NowUTC().Add(BackchannelLogoutRetryBackoff << request.Attempt)
+
+	logger.WithError(err).Warn(ctx, "backchannel logout: retry delivery",
+		slog.String("client_id", request.ClientID),
+		slog.Int("attempt", retry.Attempt),
+		slog.Time("not_before", notBefore),
+	)
+
+	enqueueErr := enqueueBackchannelLogoutRetry(ctx, d.Producer, d.AppID, &retry, notBefore)
+	if enqueueErr != nil {
+		return enqueueErr
+	}
+
+	return err
+}
+
+func (d *BackchannelLogoutDeliverer) post(ctx context.Context, backchannelLogoutURI string, logoutToken string) error {
+	form := url.Values{}
+	form.Set("logout_token", logoutToken)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", backchannelLogoutURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := d.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("backchannel logout: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/endpoints"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type backchannelLogoutProducer struct {
+	requests   []BackchannelLogoutRequest
+	notBefores []time.Time
+}
+
+func (p *backchannelLogoutProducer) NewTask(appID string, input json.RawMessage, taskIDPrefix string) *redisqueue.Task {
+	return &redisqueue.Task{AppID: appID, Input: input}
+}
+
+func (p *backchannelLogoutProducer) EnqueueTask(ctx context.Context, task *redisqueue.Task) error {
+	var request BackchannelLogoutRequest
+	err := json.Unmarshal(task.Input, &request)
+	if err != nil {
+		return err
+	}
+	p.requests = append(p.requests, request)
+	return nil
+}
+
+func (p *backchannelLogoutProducer) EnqueueTaskAt(ctx context.Context, task *redisqueue.Task, notBefore time.Time) error {
+	p.notBefores = append(p.notBefores, notBefore)
+	return p.EnqueueTask(ctx, task)
+}
+
+type backchannelLogoutDatabase struct {
+	hooks []db.TransactionHook
+}
+
+func (d *backchannelLogoutDatabase) UseHook(ctx context.Context, hook db.TransactionHook) {
+	d.hooks = append(d.hooks, hook)
+}
+
+func TestBackchannelLogout(t *testing.T) {
+	Convey("BackchannelLogout", t, func() {
+		ctx := context.Background()
+		clk := clock.NewMockClockAt("2020-01-01T00:00:00Z")
+
+		jwkSet, err := jwk.Parse([]byte(PrivateKeyPEM), jwk.WithPEM(true))
+		So(err, ShouldBeNil)
+
+		oauthConfig := &config.OAuthConfig{
+			Clients: []config.OAuthClientConfig{
+				{ClientID: "web", BackchannelLogoutURI: "https://web.example.com/logout"},
+				{ClientID: "app"},
+				{ClientID: "other", BackchannelLogoutURI: "https://other.example.com/logout"},
+			},
+		}
+		producer := &backchannelLogoutProducer{}
+
+		offlineGrant := &oauth.OfflineGrant{
+			ID:              "offline-grant-id",
+			InitialClientID: "app",
+			RefreshTokens: []oauth.OfflineGrantRefreshToken{
+				{ClientID: "app"},
+				{ClientID: "web"},
+			},
+			Attrs: session.Attrs{UserID: "user-id"},
+		}
+
+		Convey("SendBackchannelLogout", func() {
+			database := &backchannelLogoutDatabase{}
+			service := &BackchannelLogoutService{
+				AppID:    "app-id",
+				OAuth:    oauthConfig,
+				Database: database,
+				Producer: producer,
+			}
+
+			idpSession := &idpsession.IDPSession{
+				ID:                        "idp-session-id",
+				Attrs:                     session.Attrs{UserID: "user-id"},
+				ParticipatedOIDCClientIDs: []string{"other"},
+			}
+
+			err := service.SendBackchannelLogout(ctx, []session.ListableSession{
+				idpSession,
+				offlineGrant,
+			})
+			So(err, ShouldBeNil)
+			So(producer.requests, ShouldBeEmpty)
+			So(database.hooks, ShouldHaveLength, 1)
+
+			database.hooks[0].DidCommitTx(ctx)
+			So(producer.requests, ShouldResemble, []BackchannelLogoutRequest{
+				{
+					ClientID: "other",
+					UserID:   "user-id",
+					SID:      oauth.EncodeSID(idpSession),
+				},
+				{
+					ClientID: "web",
+					UserID:   "user-id",
+					SID:      oauth.EncodeSID(offlineGrant),
+				},
+			})
+
+			database.hooks[0].DidCommitTx(ctx)
+			So(producer.requests, ShouldHaveLength, 2)
+		})
+
+		Convey("SendBackchannelLogout should not enqueue tasks if the transaction does not commit", func() {
+			database := &backchannelLogoutDatabase{}
+			service := &BackchannelLogoutService{
+				AppID:    "app-id",
+				OAuth:    oauthConfig,
+				Database: database,
+				Producer: producer,
+			}
+
+			err := service.SendBackchannelLogout(ctx, []session.ListableSession{offlineGrant})
+			So(err, ShouldBeNil)
+			So(producer.requests, ShouldBeEmpty)
+		})
+
+		Convey("IssueLogoutToken", func() {
+			issuer := &LogoutTokenIssuer{
+				Secrets: &config.OAuthKeyMaterials{Set: jwkSet},
+				BaseURL: &endpoints.Endpoints{
+					OAuthEndpoints: &endpoints.OAuthEndpoints{
+						HTTPHost:  "test.authgear.com",
+						HTTPProto: "http",
+					},
+				},
+				Clock: clk,
+			}
+
+			logoutToken, err := issuer.IssueLogoutToken("web", "user-id", "sid")
+			So(err, ShouldBeNil)
+
+			publicKeySet, err := jwk.PublicSetOf(jwkSet)
+			So(err, ShouldBeNil)
+			publicKey, _ := publicKeySet.Key(0)
+			_, err = jws.Verify([]byte(logoutToken), jws.WithKey(jwa.RS256, publicKey))
+			So(err, ShouldBeNil)
+			msg, err := jws.Parse([]byte(logoutToken))
+			So(err, ShouldBeNil)
+			So(msg.Signatures()[0].ProtectedHeaders().Type(), ShouldEqual, "logout+jwt")
+
+			token, err := jwt.Parse([]byte(logoutToken), jwt.WithVerify(false), jwt.WithValidate(false))
+			So(err, ShouldBeNil)
+			So(token.Issuer(), ShouldEqual, "http://test.authgear.com")
+			So(token.Audience(), ShouldResemble, []string{"web"})
+			So(token.Subject(), ShouldEqual, "user-id")
+			So(token.JwtID(), ShouldNotBeEmpty)
+			So(token.Expiration().Unix(), ShouldEqual, clk.NowUTC().Add(LogoutTokenValidDuration).Unix())
+			sid, _ := token.Get("sid")
+			So(sid, ShouldEqual, "sid")
+			_, hasNonce := token.Get("nonce")
+			So(hasNonce, ShouldBeFalse)
+			events, _ := token.Get("events")
+			So(events, ShouldResemble, map[string]any{
+				BackchannelLogoutEvent: map[string]any{},
+			})
+		})
+
+		Convey("Deliver", func() {
+			var received []string
+			statusCode := http.StatusOK
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				received = append(received, r.PostForm.Get("logout_token"))
+				w.WriteHeader(statusCode)
+			}))
+			defer server.Close()
+
+			oauthConfig.Clients[0].BackchannelLogoutURI = server.URL
+			deliverer := &BackchannelLogoutDeliverer{
+				AppID:      "app-id",
+				OAuth:      oauthConfig,
+				Issuer:     fakeLogoutTokenIssuer{},
+				HTTPClient: BackchannelLogoutHTTPClient{server.Client()},
+				Producer:   producer,
+				Clock:      clk,
+			}
+			request := &BackchannelLogoutRequest{
+				ClientID: "web",
+				UserID:   "user-id",
+				SID:      "sid",
+			}
+
+			Convey("should POST logout token", func() {
+				err := deliverer.Deliver(ctx, request)
+				So(err, ShouldBeNil)
+				So(received, ShouldResemble, []string{"web:user-id:sid"})
+				So(producer.requests, ShouldBeEmpty)
+			})
+
+			Convey("should retry with backoff", func() {
+				statusCode = http.StatusInternalServerError
+
+				err := deliverer.Deliver(ctx, request)
+				So(err, ShouldBeError, "backchannel logout: unexpected status code 500")
+
+				notBefore := clk.NowUTC().Add(5 * time.Second)
+				So(producer.requests, ShouldResemble, []BackchannelLogoutRequest{
+					{
+						ClientID: "web",
+						UserID:   "user-id",
+						SID:      "sid",
+						Attempt:  1,
+					},
+				})
+				So(producer.notBefores, ShouldResemble, []time.Time{notBefore})
+			})
+
+			Convey("should give up after max attempts", func() {
+				statusCode = http.StatusInternalServerError
+				request.Attempt = BackchannelLogoutMaxAttempts - 1
+
+				err := deliverer.Deliver(ctx, request)
+				So(err, ShouldNotBeNil)
+				So(producer.requests, ShouldBeEmpty)
+			})
+
+			Convey("should skip client without backchannel_logout_uri", func() {
+				request.ClientID = "app"
+
+				err := deliverer.Deliver(ctx, request)
+				So(err, ShouldBeNil)
+				So(received, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+type fakeLogoutTokenIssuer struct{}
+
+func (fakeLogoutTokenIssuer) IssueLogoutToken(clientID string, userID string, sid string) (string, error) {
+	return clientID + ":" + userID + ":" + sid, nil
+}
@@ -12,4 +12,10 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(UIInfoResolver), "*"),
 	wire.Bind(new(UIInfoResolverIDTokenHintResolver), new(*IDTokenHintResolver)),
 	wire.Struct(new(UIURLBuilder), "*"),
+	wire.Struct(new(BackchannelLogoutService), "*"),
+	wire.Struct(new(LogoutTokenIssuer), "*"),
+	wire.Bind(new(BackchannelLogoutTokenIssuer), new(*LogoutTokenIssuer)),
+	wire.Struct(new(BackchannelLogoutDeliverer), "*"),
+	NewBackchannelLogoutHTTPClient,
+	wire.Struct(new(FrontchannelLogoutService), "*"),
 )
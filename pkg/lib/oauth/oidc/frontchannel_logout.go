@@ -0,0 +1,87 @@
+package oidc
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/setutil"
+	"github.com/authgear/authgear-server/pkg/util/template"
+	"github.com/authgear/authgear-server/pkg/util/urlutil"
+)
+
+// FrontchannelLogoutTimeoutMilliseconds is how long the logout page waits for the iframes
+// before it proceeds to the post logout redirect URI.
+const FrontchannelLogoutTimeoutMilliseconds = 5000
+
+var TemplateWebFrontchannelLogoutHTML = template.RegisterHTML("web/frontchannel_logout.html")
+
+// FrontchannelLogoutService resolves the frontchannel_logout_uri of the clients
+// participating in the logged out sessions, and renders the page that loads them.
+type FrontchannelLogoutService struct {
+	OAuth          *config.OAuthConfig
+	Endpoints      EndpointsProvider
+	TemplateEngine *template.Engine
+}
+
+func (s *FrontchannelLogoutService) FrontchannelLogoutURIs(sessions []session.ListableSession) []string {
+	iss := s.Endpoints.Origin().String()
+
+	var uris []string
+	seen := setutil.Set[string]{}
+	for _, sess := range sessions {
+		for _, client := range s.OAuth.Clients {
+			if client.FrontchannelLogoutURI == "" || !participatedInSession(sess, client.ClientID) {
+				continue
+			}
+
+			u, err := url.Parse(client.FrontchannelLogoutURI)
+			if err != nil {
+				continue
+			}
+			uri := urlutil.WithQueryParamsAdded(u, map[string]string{
+				"iss": iss,
+				"sid": oauth.EncodeSID(sess),
+			}).String()
+
+			if seen.Has(uri) {
+				continue
+			}
+			seen.Add(uri)
+			uris = append(uris, uri)
+		}
+	}
+
+	return uris
+}
+
+// WriteResponse renders the frontchannel_logout_uri of the clients in hidden iframes,
+// and proceeds to redirectURI after the iframes have loaded.
+// See https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout
+func (s *FrontchannelLogoutService) WriteResponse(rw http.ResponseWriter, r *http.Request, frontchannelLogoutURIs []string, redirectURI string) {
+	data := map[string]any{
+		"CSPNonce":               httputil.GetCSPNonce(r.Context()),
+		"FrontchannelLogoutURIs": frontchannelLogoutURIs,
+		"RedirectURI":            redirectURI,
+		"TimeoutMilliseconds":    FrontchannelLogoutTimeoutMilliseconds,
+		"TimeoutSeconds":         FrontchannelLogoutTimeoutMilliseconds / 1000,
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	s.TemplateEngine.RenderStatus(rw, r, http.StatusOK, TemplateWebFrontchannelLogoutHTML, data)
+}
+
+func participatedInSession(sess session.ListableSession, clientID string) bool {
+	switch sess := sess.(type) {
+	case *oauth.OfflineGrant:
+		return sess.HasClientID(clientID)
+	case *idpsession.IDPSession:
+		return sess.GetParticipatedOIDCClientIDsSet().Has(clientID)
+	default:
+		return false
+	}
+}
@@ -61,16 +61,24 @@ type IDTokenHintOfflineGrantService interface {
 	GetOfflineGrant(ctx context.Context, id string) (*oauth.OfflineGrant, error)
 }
 
+// FrontchannelLogoutService resolves the frontchannel_logout_uri to be
+// rendered for the sessions logged out by this handler.
+type FrontchannelLogoutService interface {
+	FrontchannelLogoutURIs(sessions []session.ListableSession) []string
+	WriteResponse(rw http.ResponseWriter, r *http.Request, frontchannelLogoutURIs []string, redirectURI string)
+}
+
 type EndSessionHandler struct {
-	Config           *config.OAuthConfig
-	Endpoints        oidc.EndpointsProvider
-	URLs             WebAppURLsProvider
-	SessionManager   LogoutSessionManager
-	SessionCookieDef session.CookieDef
-	Cookies          CookieManager
-	IDTokenVerifier  IDTokenVerifier
-	Sessions         IDTokenHintSessionProvider
-	OfflineGrants    IDTokenHintOfflineGrantService
+	Config             *config.OAuthConfig
+	Endpoints          oidc.EndpointsProvider
+	URLs               WebAppURLsProvider
+	SessionManager     LogoutSessionManager
+	SessionCookieDef   session.CookieDef
+	Cookies            CookieManager
+	IDTokenVerifier    IDTokenVerifier
+	Sessions           IDTokenHintSessionProvider
+	OfflineGrants      IDTokenHintOfflineGrantService
+	FrontchannelLogout FrontchannelLogoutService
 }
 
 func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSession, req protocol.EndSessionRequest, r *http.Request, rw http.ResponseWriter) error {
@@ -99,6 +107,10 @@ func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSessio
 
 	idTokenHint := req.IDTokenHint()
 
+	// The sessions logged out directly by this handler.
+	// Their clients are notified with front-channel logout before redirecting.
+	var invalidatedSessions []session.ListableSession
+
 	if idTokenHint == "" {
 		// Step 3: existing SameSiteStrict fast path (unrelated CSRF
 		// safeguard, preserved as-is; covers same-site navigations, e.g. a
@@ -110,10 +122,11 @@ func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSessio
 		if s != nil && err == nil && sameSiteStrict.Value == "true" {
 			// Logout directly.
 			// TODO(SAML): Logout affected saml service providers
-			_, err := h.SessionManager.Logout(ctx, s, rw)
+			sessions, err := h.SessionManager.Logout(ctx, s, rw)
 			if err != nil {
 				return err
 			}
+			invalidatedSessions = sessions
 			// Set s to nil and fall through.
 			s = nil
 		}
@@ -134,10 +147,11 @@ func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSessio
 		// of a client that never sends that Authgear-specific extension.
 		if client, sidSession, ok := h.resolveIDTokenHintSession(ctx, idTokenHint); ok &&
 			client.IsFirstParty() && sidSession.IsSameSSOGroup(s) {
-			_, err := h.SessionManager.Logout(ctx, s, rw)
+			sessions, err := h.SessionManager.Logout(ctx, s, rw)
 			if err != nil {
 				return err
 			}
+			invalidatedSessions = sessions
 			s = nil
 		}
 	}
@@ -158,6 +172,8 @@ func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSessio
 		return nil
 	}
 
+	frontchannelLogoutURIs := h.FrontchannelLogout.FrontchannelLogoutURIs(invalidatedSessions)
+
 	redirectURI := req.PostLogoutRedirectURI()
 	valid, client := h.validateRedirectURI(redirectURI)
 	if !valid {
@@ -167,6 +183,10 @@ func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSessio
 		} else {
 			redirectURI = h.URLs.SettingsURL().String()
 		}
+		if len(frontchannelLogoutURIs) > 0 {
+			h.FrontchannelLogout.WriteResponse(rw, r, frontchannelLogoutURIs, redirectURI)
+			return nil
+		}
 		http.Redirect(rw, r, redirectURI, http.StatusFound)
 		return nil
 	}
@@ -184,6 +204,11 @@ func (h *EndSessionHandler) Handle(ctx context.Context, s session.ResolvedSessio
 		panic(err)
 	}
 
+	if len(frontchannelLogoutURIs) > 0 {
+		h.FrontchannelLogout.WriteResponse(rw, r, frontchannelLogoutURIs, redirectURIURL.String())
+		return nil
+	}
+
 	writeResponseOptions := oauth.WriteResponseOptions{
 		RedirectURI:  redirectURIURL,
 		ResponseMode: "query",
@@ -286,3 +286,52 @@ func (mr *MockIDTokenHintOfflineGrantServiceMockRecorder) GetOfflineGrant(ctx, i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOfflineGrant", reflect.TypeOf((*MockIDTokenHintOfflineGrantService)(nil).GetOfflineGrant), ctx, id)
 }
+
+// MockFrontchannelLogoutService is a mock of FrontchannelLogoutService interface.
+type MockFrontchannelLogoutService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFrontchannelLogoutServiceMockRecorder
+}
+
+// MockFrontchannelLogoutServiceMockRecorder is the mock recorder for MockFrontchannelLogoutService.
+type MockFrontchannelLogoutServiceMockRecorder struct {
+	mock *MockFrontchannelLogoutService
+}
+
+// NewMockFrontchannelLogoutService creates a new mock instance.
+func NewMockFrontchannelLogoutService(ctrl *gomock.Controller) *MockFrontchannelLogoutService {
+	mock := &MockFrontchannelLogoutService{ctrl: ctrl}
+	mock.recorder = &MockFrontchannelLogoutServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFrontchannelLogoutService) EXPECT() *MockFrontchannelLogoutServiceMockRecorder {
+	return m.recorder
+}
+
+// FrontchannelLogoutURIs mocks base method.
+func (m *MockFrontchannelLogoutService) FrontchannelLogoutURIs(sessions []session.ListableSession) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FrontchannelLogoutURIs", sessions)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// FrontchannelLogoutURIs indicates an expected call of FrontchannelLogoutURIs.
+func (mr *MockFrontchannelLogoutServiceMockRecorder) FrontchannelLogoutURIs(sessions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FrontchannelLogoutURIs", reflect.TypeOf((*MockFrontchannelLogoutService)(nil).FrontchannelLogoutURIs), sessions)
+}
+
+// WriteResponse mocks base method.
+func (m *MockFrontchannelLogoutService) WriteResponse(rw http.ResponseWriter, r *http.Request, frontchannelLogoutURIs []string, redirectURI string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WriteResponse", rw, r, frontchannelLogoutURIs, redirectURI)
+}
+
+// WriteResponse indicates an expected call of WriteResponse.
+func (mr *MockFrontchannelLogoutServiceMockRecorder) WriteResponse(rw, r, frontchannelLogoutURIs, redirectURI interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteResponse", reflect.TypeOf((*MockFrontchannelLogoutService)(nil).WriteResponse), rw, r, frontchannelLogoutURIs, redirectURI)
+}
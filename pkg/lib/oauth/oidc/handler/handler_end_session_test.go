@@ -13,14 +13,18 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	. "github.com/smartystreets/goconvey/convey"
 
+	runtimeresource "github.com/authgear/authgear-server"
 	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc/handler"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc/protocol"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/resource"
+	"github.com/authgear/authgear-server/pkg/util/template"
 )
 
 // endSessionRefQueryParam mirrors the unexported constant of the same name in
@@ -28,6 +32,21 @@ import (
 // directly.
 const endSessionRefQueryParam = "x_end_session_ref"
 
+func newTemplateEngine() *template.Engine {
+	manager := resource.NewManagerWithDir(resource.NewManagerWithDirOptions{
+		Registry:              resource.DefaultRegistry,
+		BuiltinResourceFS:     runtimeresource.EmbedFS_resources_authgear,
+		BuiltinResourceFSRoot: runtimeresource.RelativePath_resources_authgear,
+	})
+	return &template.Engine{
+		Resolver: &template.Resolver{
+			Resources:             manager,
+			DefaultLanguageTag:    template.DefaultLanguageTag("en"),
+			SupportedLanguageTags: template.SupportedLanguageTags{"en"},
+		},
+	}
+}
+
 type fakeEndpointsProvider struct{}
 
 func (fakeEndpointsProvider) Origin() *url.URL {
@@ -100,6 +119,7 @@ func TestEndSessionHandlerHandle(t *testing.T) {
 		ClientID:               "first-party-client",
 		ApplicationType:        config.OAuthClientApplicationTypeSPA,
 		PostLogoutRedirectURIs: []string{"https://rp.example.com/after-logout"},
+		FrontchannelLogoutURI:  "https://rp.example.com/frontchannel-logout",
 	}
 	thirdPartyClient := config.OAuthClientConfig{
 		ClientID:               "third-party-client",
@@ -135,6 +155,11 @@ func TestEndSessionHandlerHandle(t *testing.T) {
 			IDTokenVerifier:  idTokenVerifier,
 			Sessions:         sessions,
 			OfflineGrants:    offlineGrants,
+			FrontchannelLogout: &oidc.FrontchannelLogoutService{
+				OAuth:          oauthConfig,
+				Endpoints:      fakeEndpointsProvider{},
+				TemplateEngine: newTemplateEngine(),
+			},
 		}
 	}
 
@@ -186,6 +211,35 @@ func TestEndSessionHandlerHandle(t *testing.T) {
 			So(loc.Scheme+"://"+loc.Host+loc.Path, ShouldEqual, "https://rp.example.com/after-logout")
 		})
 
+		Convey("GET, silent logout of a session with a front-channel logout client: iframes then post_logout_redirect_uri", func() {
+			idTokenVerifier.EXPECT().VerifyIDToken("valid-hint").Return(newIDToken(sessOfflineGrantSID, firstPartyClient.ClientID), nil)
+			expectSameLoginOfflineGrant()
+			loggedOutGrant := &oauth.OfflineGrant{
+				ID:              "grant-same-login",
+				InitialClientID: firstPartyClient.ClientID,
+			}
+			sessionManager.EXPECT().Logout(gomock.Any(), sess, gomock.Any()).Return([]session.ListableSession{sess, loggedOutGrant}, nil)
+
+			req := protocol.EndSessionRequest{
+				"id_token_hint":            "valid-hint",
+				"post_logout_redirect_uri": "https://rp.example.com/after-logout",
+			}
+			r := httptest.NewRequest(http.MethodGet, "https://app.example.com/oauth2/end_session", nil)
+			rw := httptest.NewRecorder()
+
+			err := h.Handle(context.Background(), sess, req, r, rw)
+			So(err, ShouldBeNil)
+			So(rw.Code, ShouldEqual, http.StatusOK)
+			So(rw.Header().Get("Location"), ShouldBeEmpty)
+
+			body := rw.Body.String()
+			frontchannelLogoutURI := "https://rp.example.com/frontchannel-logout?iss=https%3A%2F%2Fapp.example.com&amp;sid=" + sessOfflineGrantSID
+			So(strings.Count(body, "<iframe"), ShouldEqual, 1)
+			So(body, ShouldContainSubstring, `<iframe src="`+frontchannelLogoutURI+`"`)
+			So(body, ShouldContainSubstring, `https:\/\/rp.example.com\/after-logout`)
+			So(body, ShouldContainSubstring, `<title>Logging out</title>`)
+		})
+
 		Convey("GET, valid id_token_hint whose sid is the IDP session directly, first-party client: silent logout", func() {
 			// Less common than the offline-grant case above, but the same
 			// decision must hold when id_token_hint's sid names the IDP
@@ -113,6 +113,10 @@ func (ti *IDTokenIssuer) PrepareIDToken(ctx context.Context, opts PrepareIDToken
 	if amr := info.AMR; len(amr) > 0 {
 		_ = claims.Set(string(model.ClaimAMR), amr)
 	}
+	// org_id
+	if orgID := info.OrganizationID; orgID != "" {
+		_ = claims.Set(string(model.ClaimOrganizationID), orgID)
+	}
 	// ds_hash
 	if dshash := opts.DeviceSecretHash; dshash != "" {
 		_ = claims.Set(string(model.ClaimDeviceSecretHash), dshash)
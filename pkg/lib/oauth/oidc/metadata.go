@@ -57,5 +57,9 @@ func (p *MetadataProvider) PopulateMetadata(meta map[string]any) {
 	meta["jwks_uri"] = p.Endpoints.JWKSEndpointURL().String()
 	meta["userinfo_endpoint"] = p.Endpoints.UserInfoEndpointURL().String()
 	meta["end_session_endpoint"] = p.Endpoints.EndSessionEndpointURL().String()
+	meta["backchannel_logout_supported"] = true
+	meta["backchannel_logout_session_supported"] = true
+	meta["frontchannel_logout_supported"] = true
+	meta["frontchannel_logout_session_supported"] = true
 	// TODO(mfa): Declare acr_values_supported and support acr_values in authorization request.
 }
@@ -69,6 +69,10 @@ func (r AuthorizationRequest) CodeChallengeMethod() string { return r["code_chal
 // DPoP
 func (r AuthorizationRequest) DPoPJKT() string { return r["dpop_jkt"] }
 
+// JAR and PAR
+func (r AuthorizationRequest) Request() string    { return r["request"] }
+func (r AuthorizationRequest) RequestURI() string { return r["request_uri"] }
+
 // Proprietary
 func (r AuthorizationRequest) Platform() string          { return r["x_platform"] }
 func (r AuthorizationRequest) WeChatRedirectURI() string { return r["x_wechat_redirect_uri"] }
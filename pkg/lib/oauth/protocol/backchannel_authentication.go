@@ -0,0 +1,45 @@
+package protocol
+
+import (
+	"net/url"
+	"strconv"
+)
+
+type BackchannelAuthenticationRequest url.Values
+type BackchannelAuthenticationResponse map[string]any
+
+func (r BackchannelAuthenticationRequest) ClientID() string {
+	return url.Values(r).Get("client_id")
+}
+func (r BackchannelAuthenticationRequest) ClientSecret() string {
+	return url.Values(r).Get("client_secret")
+}
+func (r BackchannelAuthenticationRequest) Scope() []string {
+	return parseSpaceDelimitedString(url.Values(r).Get("scope"))
+}
+func (r BackchannelAuthenticationRequest) LoginHint() string {
+	return url.Values(r).Get("login_hint")
+}
+func (r BackchannelAuthenticationRequest) BindingMessage() string {
+	return url.Values(r).Get("binding_message")
+}
+func (r BackchannelAuthenticationRequest) ClientNotificationToken() string {
+	return url.Values(r).Get("client_notification_token")
+}
+
+// RequestedExpiry returns requested_expiry in seconds, or 0 if absent.
+func (r BackchannelAuthenticationRequest) RequestedExpiry() (int, bool) {
+	s := url.Values(r).Get("requested_expiry")
+	if s == "" {
+		return 0, true
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return 0, false
+	}
+	return i, true
+}
+
+func (r BackchannelAuthenticationResponse) AuthReqID(v string) { r["auth_req_id"] = v }
+func (r BackchannelAuthenticationResponse) ExpiresIn(v int)    { r["expires_in"] = v }
+func (r BackchannelAuthenticationResponse) Interval(v int)     { r["interval"] = v }
@@ -0,0 +1,53 @@
+package protocol
+
+// ClientRegistrationRequest is the client metadata sent to the client registration endpoint,
+// or to the client configuration endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc7591#section-3.1
+// See https://datatracker.ietf.org/doc/html/rfc7592#section-2.2
+type ClientRegistrationRequest map[string]any
+type ClientRegistrationResponse map[string]any
+
+func (r ClientRegistrationRequest) ClientID() string {
+	v, _ := r["client_id"].(string)
+	return v
+}
+
+func (r ClientRegistrationRequest) ClientSecret() string {
+	v, _ := r["client_secret"].(string)
+	return v
+}
+
+// RotateClientSecret tells the client configuration endpoint to issue a new client secret.
+func (r ClientRegistrationRequest) RotateClientSecret() bool {
+	v, _ := r["x_rotate_client_secret"].(bool)
+	return v
+}
+
+// ClientMetadata returns the client metadata,
+// excluding the fields that are issued by the server.
+func (r ClientRegistrationRequest) ClientMetadata() map[string]any {
+	metadata := map[string]any{}
+	for name, value := range r {
+		switch name {
+		case "client_id",
+			"client_secret",
+			"client_id_issued_at",
+			"client_secret_expires_at",
+			"registration_access_token",
+			"registration_client_uri",
+			"x_rotate_client_secret":
+			continue
+		}
+		metadata[name] = value
+	}
+	return metadata
+}
+
+func (r ClientRegistrationResponse) ClientID(v string)             { r["client_id"] = v }
+func (r ClientRegistrationResponse) ClientSecret(v string)         { r["client_secret"] = v }
+func (r ClientRegistrationResponse) ClientIDIssuedAt(v int64)      { r["client_id_issued_at"] = v }
+func (r ClientRegistrationResponse) ClientSecretExpiresAt(v int64) { r["client_secret_expires_at"] = v }
+func (r ClientRegistrationResponse) RegistrationAccessToken(v string) {
+	r["registration_access_token"] = v
+}
+func (r ClientRegistrationResponse) RegistrationClientURI(v string) { r["registration_client_uri"] = v }
@@ -0,0 +1,23 @@
+package protocol
+
+import (
+	"net/url"
+)
+
+type DeviceAuthorizationRequest url.Values
+type DeviceAuthorizationResponse map[string]any
+
+func (r DeviceAuthorizationRequest) ClientID() string     { return url.Values(r).Get("client_id") }
+func (r DeviceAuthorizationRequest) ClientSecret() string { return url.Values(r).Get("client_secret") }
+func (r DeviceAuthorizationRequest) Scope() []string {
+	return parseSpaceDelimitedString(url.Values(r).Get("scope"))
+}
+
+func (r DeviceAuthorizationResponse) DeviceCode(v string)      { r["device_code"] = v }
+func (r DeviceAuthorizationResponse) UserCode(v string)        { r["user_code"] = v }
+func (r DeviceAuthorizationResponse) VerificationURI(v string) { r["verification_uri"] = v }
+func (r DeviceAuthorizationResponse) VerificationURIComplete(v string) {
+	r["verification_uri_complete"] = v
+}
+func (r DeviceAuthorizationResponse) ExpiresIn(v int) { r["expires_in"] = v }
+func (r DeviceAuthorizationResponse) Interval(v int)  { r["interval"] = v }
@@ -0,0 +1,24 @@
+package protocol
+
+import (
+	"net/url"
+)
+
+type IntrospectRequest url.Values
+type IntrospectResponse map[string]any
+
+func (r IntrospectRequest) Token() string         { return url.Values(r).Get("token") }
+func (r IntrospectRequest) TokenTypeHint() string { return url.Values(r).Get("token_type_hint") }
+func (r IntrospectRequest) ClientID() string      { return url.Values(r).Get("client_id") }
+func (r IntrospectRequest) ClientSecret() string  { return url.Values(r).Get("client_secret") }
+
+func (r IntrospectResponse) Active(v bool)      { r["active"] = v }
+func (r IntrospectResponse) Scope(v string)     { r["scope"] = v }
+func (r IntrospectResponse) ClientID(v string)  { r["client_id"] = v }
+func (r IntrospectResponse) TokenType(v string) { r["token_type"] = v }
+func (r IntrospectResponse) Exp(v int64)        { r["exp"] = v }
+func (r IntrospectResponse) Iat(v int64)        { r["iat"] = v }
+func (r IntrospectResponse) Sub(v string)       { r["sub"] = v }
+func (r IntrospectResponse) Iss(v string)       { r["iss"] = v }
+func (r IntrospectResponse) SID(v string)       { r["sid"] = v }
+func (r IntrospectResponse) DPoPJKT(v string)   { r["cnf"] = map[string]any{"jkt": v} }
@@ -0,0 +1,27 @@
+package protocol
+
+import (
+	"net/url"
+)
+
+type PushedAuthorizationRequest url.Values
+type PushedAuthorizationResponse map[string]any
+
+func (r PushedAuthorizationRequest) ClientID() string     { return url.Values(r).Get("client_id") }
+func (r PushedAuthorizationRequest) ClientSecret() string { return url.Values(r).Get("client_secret") }
+
+// AuthorizationRequest returns the authorization request parameters,
+// excluding the client authentication parameters.
+func (r PushedAuthorizationRequest) AuthorizationRequest() AuthorizationRequest {
+	req := AuthorizationRequest{}
+	for name, values := range r {
+		if name == "client_secret" || len(values) == 0 {
+			continue
+		}
+		req[name] = values[0]
+	}
+	return req
+}
+
+func (r PushedAuthorizationResponse) RequestURI(v string) { r["request_uri"] = v }
+func (r PushedAuthorizationResponse) ExpiresIn(v int)     { r["expires_in"] = v }
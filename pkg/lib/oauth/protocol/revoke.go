@@ -4,3 +4,4 @@ type RevokeRequest map[string]string
 
 func (r RevokeRequest) Token() string         { return r["token"] }
 func (r RevokeRequest) TokenTypeHint() string { return r["token_type_hint"] }
+func (r RevokeRequest) ClientID() string      { return r["client_id"] }
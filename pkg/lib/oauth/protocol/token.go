@@ -33,6 +33,8 @@ func (r TokenRequest) ActorTokenType() string     { return url.Values(r).Get("ac
 func (r TokenRequest) ActorToken() string         { return url.Values(r).Get("actor_token") }
 func (r TokenRequest) DeviceSecret() string       { return url.Values(r).Get("device_secret") }
 func (r TokenRequest) Resource() string           { return url.Values(r).Get("resource") }
+func (r TokenRequest) DeviceCode() string         { return url.Values(r).Get("device_code") }
+func (r TokenRequest) AuthReqID() string          { return url.Values(r).Get("auth_req_id") }
 
 func (r TokenResponse) AccessToken(v string)     { r["access_token"] = v }
 func (r TokenResponse) TokenType(v string)       { r["token_type"] = v }
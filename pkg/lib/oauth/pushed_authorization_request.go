@@ -0,0 +1,38 @@
+package oauth
+
+import (
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+)
+
+const (
+	// PushedAuthorizationRequestURIPrefix is the prefix of request_uri returned by the PAR endpoint.
+	// See https://datatracker.ietf.org/doc/html/rfc9126#section-2.2
+	PushedAuthorizationRequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+	// PushedAuthorizationRequestLifetime is short because
+	// the client is expected to redirect the user agent right after pushing the request.
+	PushedAuthorizationRequestLifetime = 90 * time.Second
+)
+
+// PushedAuthorizationRequest is an authorization request pushed via the PAR endpoint.
+// See https://datatracker.ietf.org/doc/html/rfc9126
+type PushedAuthorizationRequest struct {
+	AppID          string `json:"app_id"`
+	ClientID       string `json:"client_id"`
+	RequestURIHash string `json:"request_uri_hash"`
+
+	CreatedAt time.Time `json:"created_at"`
+	ExpireAt  time.Time `json:"expire_at"`
+
+	AuthorizationRequest protocol.AuthorizationRequest `json:"authorization_request"`
+}
+
+func NewPushedAuthorizationRequestURI() string {
+	return PushedAuthorizationRequestURIPrefix + GenerateToken()
+}
+
+func IsPushedAuthorizationRequestURI(requestURI string) bool {
+	return strings.HasPrefix(requestURI, PushedAuthorizationRequestURIPrefix)
+}
@@ -10,6 +10,22 @@ func settingsActionGrantKey(appID, codeHash string) string {
 	return fmt.Sprintf("app:%s:settings-action-grant:%s", appID, codeHash)
 }
 
+func deviceGrantKey(appID, deviceCodeHash string) string {
+	return fmt.Sprintf("app:%s:device-grant:%s", appID, deviceCodeHash)
+}
+
+func deviceGrantUserCodeKey(appID, userCodeHash string) string {
+	return fmt.Sprintf("app:%s:device-grant-user-code:%s", appID, userCodeHash)
+}
+
+func cibaGrantKey(appID, authReqIDHash string) string {
+	return fmt.Sprintf("app:%s:ciba-grant:%s", appID, authReqIDHash)
+}
+
+func cibaGrantApprovalCodeKey(appID, approvalCodeHash string) string {
+	return fmt.Sprintf("app:%s:ciba-grant-approval-code:%s", appID, approvalCodeHash)
+}
+
 func accessGrantKey(appID, tokenHash string) string {
 	return fmt.Sprintf("app:%s:access-grant:%s", appID, tokenHash)
 }
@@ -37,3 +53,15 @@ func appSessionKey(appID string, tokenHash string) string {
 func preAuthenticatedURLTokenKey(appID string, tokenHash string) string {
 	return fmt.Sprintf("app:%s:pre-authenticated-url-token:%s", appID, tokenHash)
 }
+
+func pushedAuthorizationRequestKey(appID string, requestURIHash string) string {
+	return fmt.Sprintf("app:%s:pushed-authorization-request:%s", appID, requestURIHash)
+}
+
+func initialAccessTokenKey(appID string, tokenHash string) string {
+	return fmt.Sprintf("app:%s:initial-access-token:%s", appID, tokenHash)
+}
+
+func clientRegistrationKey(appID string, clientID string) string {
+	return fmt.Sprintf("app:%s:client-registration:%s", appID, clientID)
+}
@@ -40,6 +40,47 @@ func (s *Store) loadData(ctx context.Context, conn redis.Redis_6_0_Cmdable, key
 	return data, nil
 }
 
+// getDelLuaScript is GETDEL, which is only available since Redis 6.2.
+var getDelLuaScript = goredis.NewScript(`
+local value = redis.call("GET", KEYS[1])
+if value then
+	redis.call("DEL", KEYS[1])
+end
+return value
+`)
+
+const (
+	compareAndSetRevisionNotFound = -1
+	compareAndSetRevisionConflict = 0
+)
+
+// compareAndSetRevisionLuaScript replaces the JSON value if its revision is ARGV[1].
+// The TTL of the key is kept.
+var compareAndSetRevisionLuaScript = goredis.NewScript(`
+local value = redis.call("GET", KEYS[1])
+if not value then
+	return -1
+end
+local revision = cjson.decode(value)["revision"] or 0
+if revision ~= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "KEEPTTL")
+return 1
+`)
+
+// consumeData loads the data and deletes the key atomically,
+// so that a single-use token cannot be used by concurrent requests twice.
+func (s *Store) consumeData(ctx context.Context, conn redis.Redis_6_0_Cmdable, key string) ([]byte, error) {
+	data, err := getDelLuaScript.Run(ctx, conn, []string{key}).Text()
+	if errors.Is(err, goredis.Nil) {
+		return nil, oauth.ErrGrantNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return []byte(data), nil
+}
+
 func (s *Store) unmarshalCodeGrant(data []byte) (*oauth.CodeGrant, error) {
 	var g oauth.CodeGrant
 	err := json.Unmarshal(data, &g)
@@ -58,6 +99,24 @@ func (s *Store) unmarshalSettingsActionGrant(data []byte) (*oauth.SettingsAction
 	return &g, nil
 }
 
+func (s *Store) unmarshalDeviceGrant(data []byte) (*oauth.DeviceGrant, error) {
+	var g oauth.DeviceGrant
+	err := json.Unmarshal(data, &g)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (s *Store) unmarshalCIBAGrant(data []byte) (*oauth.CIBAGrant, error) {
+	var g oauth.CIBAGrant
+	err := json.Unmarshal(data, &g)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
 func (s *Store) unmarshalAccessGrant(data []byte) (*oauth.AccessGrant, error) {
 	var g oauth.AccessGrant
 	err := json.Unmarshal(data, &g)
@@ -106,6 +165,15 @@ func (s *Store) unmarshalPreAuthenticatedURLToken(data []byte) (*oauth.PreAuthen
 	return &t, nil
 }
 
+func (s *Store) unmarshalPushedAuthorizationRequest(data []byte) (*oauth.PushedAuthorizationRequest, error) {
+	var r oauth.PushedAuthorizationRequest
+	err := json.Unmarshal(data, &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
 func (s *Store) save(ctx context.Context, conn redis.Redis_6_0_Cmdable, key string, value any, expireAt time.Time, ifNotExists bool) error {
 	data, err := json.Marshal(value)
 	if err != nil {
@@ -199,6 +267,194 @@ func (s *Store) DeleteSettingsActionGrant(ctx context.Context, grant *oauth.Sett
 	})
 }
 
+func (s *Store) GetDeviceGrant(ctx context.Context, deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	var g *oauth.DeviceGrant
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		data, err := s.loadData(ctx, conn, deviceGrantKey(string(s.AppID), deviceCodeHash))
+		if err != nil {
+			return err
+		}
+		g, err = s.unmarshalDeviceGrant(data)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (s *Store) GetDeviceGrantByUserCode(ctx context.Context, userCodeHash string) (*oauth.DeviceGrant, error) {
+	var g *oauth.DeviceGrant
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		deviceCodeHash, err := s.loadData(ctx, conn, deviceGrantUserCodeKey(string(s.AppID), userCodeHash))
+		if err != nil {
+			return err
+		}
+		data, err := s.loadData(ctx, conn, deviceGrantKey(string(s.AppID), string(deviceCodeHash)))
+		if err != nil {
+			return err
+		}
+		g, err = s.unmarshalDeviceGrant(data)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// deviceGrantExpiredRetention is how long a device grant is kept after it expires,
+// so that the token endpoint can tell an expired device code from an unknown one.
+const deviceGrantExpiredRetention = 10 * time.Minute
+
+func (s *Store) CreateDeviceGrant(ctx context.Context, grant *oauth.DeviceGrant) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		ttl := grant.ExpireAt.Sub(s.Clock.NowUTC())
+		userCodeKey := deviceGrantUserCodeKey(grant.AppID, grant.UserCodeHash)
+		// User codes are short, so a collision is possible though unlikely.
+		ok, err := conn.SetNX(ctx, userCodeKey, grant.DeviceCodeHash, ttl).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return oauth.ErrUserCodeCollision
+		}
+		return s.save(ctx, conn, deviceGrantKey(grant.AppID, grant.DeviceCodeHash), grant, grant.ExpireAt.Add(deviceGrantExpiredRetention), true)
+	})
+}
+
+// UpdateDeviceGrant saves grant if it has not been updated since it was read.
+// Otherwise, it returns oauth.ErrGrantConflict.
+func (s *Store) UpdateDeviceGrant(ctx context.Context, grant *oauth.DeviceGrant) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		updated := *grant
+		updated.Revision = grant.Revision + 1
+		data, err := json.Marshal(updated)
+		if err != nil {
+			return err
+		}
+
+		result, err := compareAndSetRevisionLuaScript.Run(ctx, conn,
+			[]string{deviceGrantKey(grant.AppID, grant.DeviceCodeHash)},
+			grant.Revision,
+			data,
+		).Int()
+		if err != nil {
+			return err
+		}
+
+		switch result {
+		case compareAndSetRevisionNotFound:
+			return oauth.ErrGrantNotFound
+		case compareAndSetRevisionConflict:
+			return oauth.ErrGrantConflict
+		}
+
+		grant.Revision = updated.Revision
+		return nil
+	})
+}
+
+// DeleteDeviceGrant deletes grant.
+// It returns oauth.ErrGrantNotFound if the grant has been deleted,
+// so that only one of the concurrent requests can exchange the device code.
+func (s *Store) DeleteDeviceGrant(ctx context.Context, grant *oauth.DeviceGrant) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		err := s.del(ctx, conn, deviceGrantUserCodeKey(grant.AppID, grant.UserCodeHash))
+		if err != nil {
+			return err
+		}
+
+		n, err := conn.Del(ctx, deviceGrantKey(grant.AppID, grant.DeviceCodeHash)).Result()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return oauth.ErrGrantNotFound
+		}
+		return nil
+	})
+}
+
+func (s *Store) GetCIBAGrant(ctx context.Context, authReqIDHash string) (*oauth.CIBAGrant, error) {
+	var g *oauth.CIBAGrant
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		data, err := s.loadData(ctx, conn, cibaGrantKey(string(s.AppID), authReqIDHash))
+		if err != nil {
+			return err
+		}
+		g, err = s.unmarshalCIBAGrant(data)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (s *Store) GetCIBAGrantByApprovalCode(ctx context.Context, approvalCodeHash string) (*oauth.CIBAGrant, error) {
+	var g *oauth.CIBAGrant
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		authReqIDHash, err := s.loadData(ctx, conn, cibaGrantApprovalCodeKey(string(s.AppID), approvalCodeHash))
+		if err != nil {
+			return err
+		}
+		data, err := s.loadData(ctx, conn, cibaGrantKey(string(s.AppID), string(authReqIDHash)))
+		if err != nil {
+			return err
+		}
+		g, err = s.unmarshalCIBAGrant(data)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (s *Store) CreateCIBAGrant(ctx context.Context, grant *oauth.CIBAGrant) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		ttl := grant.ExpireAt.Sub(s.Clock.NowUTC())
+		err := conn.Set(ctx, cibaGrantApprovalCodeKey(grant.AppID, grant.ApprovalCodeHash), grant.AuthReqIDHash, ttl).Err()
+		if err != nil {
+			return err
+		}
+		return s.save(ctx, conn, cibaGrantKey(grant.AppID, grant.AuthReqIDHash), grant, grant.ExpireAt, true)
+	})
+}
+
+func (s *Store) UpdateCIBAGrant(ctx context.Context, grant *oauth.CIBAGrant) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		return s.save(ctx, conn, cibaGrantKey(grant.AppID, grant.AuthReqIDHash), grant, grant.ExpireAt, false)
+	})
+}
+
+func (s *Store) DeleteCIBAGrant(ctx context.Context, grant *oauth.CIBAGrant) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		err := s.del(ctx, conn, cibaGrantApprovalCodeKey(grant.AppID, grant.ApprovalCodeHash))
+		if err != nil {
+			return err
+		}
+		return s.del(ctx, conn, cibaGrantKey(grant.AppID, grant.AuthReqIDHash))
+	})
+}
+
 func (s *Store) GetAccessGrant(ctx context.Context, tokenHash string) (*oauth.AccessGrant, error) {
 	var g *oauth.AccessGrant
 	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
@@ -745,6 +1001,92 @@ func (s *Store) ConsumePreAuthenticatedURLToken(ctx context.Context, tokenHash s
 	return t, nil
 }
 
+func (s *Store) CreatePushedAuthorizationRequest(ctx context.Context, r *oauth.PushedAuthorizationRequest) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		return s.save(ctx, conn, pushedAuthorizationRequestKey(r.AppID, r.RequestURIHash), r, r.ExpireAt, true)
+	})
+}
+
+func (s *Store) ConsumePushedAuthorizationRequest(ctx context.Context, requestURIHash string) (*oauth.PushedAuthorizationRequest, error) {
+	r := &oauth.PushedAuthorizationRequest{}
+
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		data, err := s.consumeData(ctx, conn, pushedAuthorizationRequestKey(string(s.AppID), requestURIHash))
+		if err != nil {
+			return err
+		}
+
+		r, err = s.unmarshalPushedAuthorizationRequest(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *Store) CreateInitialAccessToken(ctx context.Context, t *oauth.InitialAccessToken) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		return s.save(ctx, conn, initialAccessTokenKey(t.AppID, t.TokenHash), t, t.ExpireAt, true)
+	})
+}
+
+func (s *Store) ConsumeInitialAccessToken(ctx context.Context, tokenHash string) (*oauth.InitialAccessToken, error) {
+	t := &oauth.InitialAccessToken{}
+
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		data, err := s.consumeData(ctx, conn, initialAccessTokenKey(string(s.AppID), tokenHash))
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(data, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *Store) GetClientRegistration(ctx context.Context, clientID string) (*oauth.ClientRegistration, error) {
+	r := &oauth.ClientRegistration{}
+
+	err := s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		data, err := s.loadData(ctx, conn, clientRegistrationKey(string(s.AppID), clientID))
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(data, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *Store) CreateClientRegistration(ctx context.Context, r *oauth.ClientRegistration) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		// The registration lives as long as the client, so it does not expire.
+		_, err := conn.Set(ctx, clientRegistrationKey(r.AppID, r.ClientID), data, 0).Result()
+		return err
+	})
+}
+
+func (s *Store) DeleteClientRegistration(ctx context.Context, r *oauth.ClientRegistration) error {
+	return s.Redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		return s.del(ctx, conn, clientRegistrationKey(r.AppID, r.ClientID))
+	})
+}
+
 func (s *Store) CleanUpForDeletingUserID(ctx context.Context, userID string) (err error) {
 	listKey := offlineGrantListKey(string(s.AppID), userID)
 
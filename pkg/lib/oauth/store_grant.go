@@ -21,6 +21,22 @@ type SettingsActionGrantStore interface {
 	DeleteSettingsActionGrant(ctx context.Context, g *SettingsActionGrant) error
 }
 
+type DeviceGrantStore interface {
+	GetDeviceGrant(ctx context.Context, deviceCodeHash string) (*DeviceGrant, error)
+	GetDeviceGrantByUserCode(ctx context.Context, userCodeHash string) (*DeviceGrant, error)
+	CreateDeviceGrant(ctx context.Context, g *DeviceGrant) error
+	UpdateDeviceGrant(ctx context.Context, g *DeviceGrant) error
+	DeleteDeviceGrant(ctx context.Context, g *DeviceGrant) error
+}
+
+type CIBAGrantStore interface {
+	GetCIBAGrant(ctx context.Context, authReqIDHash string) (*CIBAGrant, error)
+	GetCIBAGrantByApprovalCode(ctx context.Context, approvalCodeHash string) (*CIBAGrant, error)
+	CreateCIBAGrant(ctx context.Context, g *CIBAGrant) error
+	UpdateCIBAGrant(ctx context.Context, g *CIBAGrant) error
+	DeleteCIBAGrant(ctx context.Context, g *CIBAGrant) error
+}
+
 type AddOfflineGrantRefreshTokenOptions struct {
 	OfflineGrantID                 string
 	AccessInfo                     access.Info
@@ -99,3 +115,19 @@ type PreAuthenticatedURLTokenStore interface {
 	CreatePreAuthenticatedURLToken(ctx context.Context, t *PreAuthenticatedURLToken) error
 	ConsumePreAuthenticatedURLToken(ctx context.Context, tokenHash string) (*PreAuthenticatedURLToken, error)
 }
+
+type PushedAuthorizationRequestStore interface {
+	CreatePushedAuthorizationRequest(ctx context.Context, r *PushedAuthorizationRequest) error
+	ConsumePushedAuthorizationRequest(ctx context.Context, requestURIHash string) (*PushedAuthorizationRequest, error)
+}
+
+type InitialAccessTokenStore interface {
+	CreateInitialAccessToken(ctx context.Context, t *InitialAccessToken) error
+	ConsumeInitialAccessToken(ctx context.Context, tokenHash string) (*InitialAccessToken, error)
+}
+
+type ClientRegistrationStore interface {
+	GetClientRegistration(ctx context.Context, clientID string) (*ClientRegistration, error)
+	CreateClientRegistration(ctx context.Context, r *ClientRegistration) error
+	DeleteClientRegistration(ctx context.Context, r *ClientRegistration) error
+}
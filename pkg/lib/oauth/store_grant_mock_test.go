@@ -144,6 +144,196 @@ func (mr *MockSettingsActionGrantStoreMockRecorder) GetSettingsActionGrant(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettingsActionGrant", reflect.TypeOf((*MockSettingsActionGrantStore)(nil).GetSettingsActionGrant), ctx, codeHash)
 }
 
+// MockDeviceGrantStore is a mock of DeviceGrantStore interface.
+type MockDeviceGrantStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceGrantStoreMockRecorder
+}
+
+// MockDeviceGrantStoreMockRecorder is the mock recorder for MockDeviceGrantStore.
+type MockDeviceGrantStoreMockRecorder struct {
+	mock *MockDeviceGrantStore
+}
+
+// NewMockDeviceGrantStore creates a new mock instance.
+func NewMockDeviceGrantStore(ctrl *gomock.Controller) *MockDeviceGrantStore {
+	mock := &MockDeviceGrantStore{ctrl: ctrl}
+	mock.recorder = &MockDeviceGrantStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceGrantStore) EXPECT() *MockDeviceGrantStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateDeviceGrant mocks base method.
+func (m *MockDeviceGrantStore) CreateDeviceGrant(ctx context.Context, g *DeviceGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeviceGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeviceGrant indicates an expected call of CreateDeviceGrant.
+func (mr *MockDeviceGrantStoreMockRecorder) CreateDeviceGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeviceGrant", reflect.TypeOf((*MockDeviceGrantStore)(nil).CreateDeviceGrant), ctx, g)
+}
+
+// DeleteDeviceGrant mocks base method.
+func (m *MockDeviceGrantStore) DeleteDeviceGrant(ctx context.Context, g *DeviceGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeviceGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeviceGrant indicates an expected call of DeleteDeviceGrant.
+func (mr *MockDeviceGrantStoreMockRecorder) DeleteDeviceGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeviceGrant", reflect.TypeOf((*MockDeviceGrantStore)(nil).DeleteDeviceGrant), ctx, g)
+}
+
+// GetDeviceGrant mocks base method.
+func (m *MockDeviceGrantStore) GetDeviceGrant(ctx context.Context, deviceCodeHash string) (*DeviceGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceGrant", ctx, deviceCodeHash)
+	ret0, _ := ret[0].(*DeviceGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceGrant indicates an expected call of GetDeviceGrant.
+func (mr *MockDeviceGrantStoreMockRecorder) GetDeviceGrant(ctx, deviceCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceGrant", reflect.TypeOf((*MockDeviceGrantStore)(nil).GetDeviceGrant), ctx, deviceCodeHash)
+}
+
+// GetDeviceGrantByUserCode mocks base method.
+func (m *MockDeviceGrantStore) GetDeviceGrantByUserCode(ctx context.Context, userCodeHash string) (*DeviceGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceGrantByUserCode", ctx, userCodeHash)
+	ret0, _ := ret[0].(*DeviceGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceGrantByUserCode indicates an expected call of GetDeviceGrantByUserCode.
+func (mr *MockDeviceGrantStoreMockRecorder) GetDeviceGrantByUserCode(ctx, userCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceGrantByUserCode", reflect.TypeOf((*MockDeviceGrantStore)(nil).GetDeviceGrantByUserCode), ctx, userCodeHash)
+}
+
+// UpdateDeviceGrant mocks base method.
+func (m *MockDeviceGrantStore) UpdateDeviceGrant(ctx context.Context, g *DeviceGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeviceGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeviceGrant indicates an expected call of UpdateDeviceGrant.
+func (mr *MockDeviceGrantStoreMockRecorder) UpdateDeviceGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeviceGrant", reflect.TypeOf((*MockDeviceGrantStore)(nil).UpdateDeviceGrant), ctx, g)
+}
+
+// MockCIBAGrantStore is a mock of CIBAGrantStore interface.
+type MockCIBAGrantStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCIBAGrantStoreMockRecorder
+}
+
+// MockCIBAGrantStoreMockRecorder is the mock recorder for MockCIBAGrantStore.
+type MockCIBAGrantStoreMockRecorder struct {
+	mock *MockCIBAGrantStore
+}
+
+// NewMockCIBAGrantStore creates a new mock instance.
+func NewMockCIBAGrantStore(ctrl *gomock.Controller) *MockCIBAGrantStore {
+	mock := &MockCIBAGrantStore{ctrl: ctrl}
+	mock.recorder = &MockCIBAGrantStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCIBAGrantStore) EXPECT() *MockCIBAGrantStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateCIBAGrant mocks base method.
+func (m *MockCIBAGrantStore) CreateCIBAGrant(ctx context.Context, g *CIBAGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCIBAGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCIBAGrant indicates an expected call of CreateCIBAGrant.
+func (mr *MockCIBAGrantStoreMockRecorder) CreateCIBAGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCIBAGrant", reflect.TypeOf((*MockCIBAGrantStore)(nil).CreateCIBAGrant), ctx, g)
+}
+
+// DeleteCIBAGrant mocks base method.
+func (m *MockCIBAGrantStore) DeleteCIBAGrant(ctx context.Context, g *CIBAGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCIBAGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCIBAGrant indicates an expected call of DeleteCIBAGrant.
+func (mr *MockCIBAGrantStoreMockRecorder) DeleteCIBAGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCIBAGrant", reflect.TypeOf((*MockCIBAGrantStore)(nil).DeleteCIBAGrant), ctx, g)
+}
+
+// GetCIBAGrant mocks base method.
+func (m *MockCIBAGrantStore) GetCIBAGrant(ctx context.Context, authReqIDHash string) (*CIBAGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCIBAGrant", ctx, authReqIDHash)
+	ret0, _ := ret[0].(*CIBAGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCIBAGrant indicates an expected call of GetCIBAGrant.
+func (mr *MockCIBAGrantStoreMockRecorder) GetCIBAGrant(ctx, authReqIDHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCIBAGrant", reflect.TypeOf((*MockCIBAGrantStore)(nil).GetCIBAGrant), ctx, authReqIDHash)
+}
+
+// GetCIBAGrantByApprovalCode mocks base method.
+func (m *MockCIBAGrantStore) GetCIBAGrantByApprovalCode(ctx context.Context, approvalCodeHash string) (*CIBAGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCIBAGrantByApprovalCode", ctx, approvalCodeHash)
+	ret0, _ := ret[0].(*CIBAGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCIBAGrantByApprovalCode indicates an expected call of GetCIBAGrantByApprovalCode.
+func (mr *MockCIBAGrantStoreMockRecorder) GetCIBAGrantByApprovalCode(ctx, approvalCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCIBAGrantByApprovalCode", reflect.TypeOf((*MockCIBAGrantStore)(nil).GetCIBAGrantByApprovalCode), ctx, approvalCodeHash)
+}
+
+// UpdateCIBAGrant mocks base method.
+func (m *MockCIBAGrantStore) UpdateCIBAGrant(ctx context.Context, g *CIBAGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCIBAGrant", ctx, g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCIBAGrant indicates an expected call of UpdateCIBAGrant.
+func (mr *MockCIBAGrantStoreMockRecorder) UpdateCIBAGrant(ctx, g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCIBAGrant", reflect.TypeOf((*MockCIBAGrantStore)(nil).UpdateCIBAGrant), ctx, g)
+}
+
 // MockOfflineGrantStore is a mock of OfflineGrantStore interface.
 type MockOfflineGrantStore struct {
 	ctrl     *gomock.Controller
@@ -638,3 +828,173 @@ func (mr *MockPreAuthenticatedURLTokenStoreMockRecorder) CreatePreAuthenticatedU
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreAuthenticatedURLToken", reflect.TypeOf((*MockPreAuthenticatedURLTokenStore)(nil).CreatePreAuthenticatedURLToken), ctx, t)
 }
+
+// MockPushedAuthorizationRequestStore is a mock of PushedAuthorizationRequestStore interface.
+type MockPushedAuthorizationRequestStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushedAuthorizationRequestStoreMockRecorder
+}
+
+// MockPushedAuthorizationRequestStoreMockRecorder is the mock recorder for MockPushedAuthorizationRequestStore.
+type MockPushedAuthorizationRequestStoreMockRecorder struct {
+	mock *MockPushedAuthorizationRequestStore
+}
+
+// NewMockPushedAuthorizationRequestStore creates a new mock instance.
+func NewMockPushedAuthorizationRequestStore(ctrl *gomock.Controller) *MockPushedAuthorizationRequestStore {
+	mock := &MockPushedAuthorizationRequestStore{ctrl: ctrl}
+	mock.recorder = &MockPushedAuthorizationRequestStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushedAuthorizationRequestStore) EXPECT() *MockPushedAuthorizationRequestStoreMockRecorder {
+	return m.recorder
+}
+
+// ConsumePushedAuthorizationRequest mocks base method.
+func (m *MockPushedAuthorizationRequestStore) ConsumePushedAuthorizationRequest(ctx context.Context, requestURIHash string) (*PushedAuthorizationRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePushedAuthorizationRequest", ctx, requestURIHash)
+	ret0, _ := ret[0].(*PushedAuthorizationRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumePushedAuthorizationRequest indicates an expected call of ConsumePushedAuthorizationRequest.
+func (mr *MockPushedAuthorizationRequestStoreMockRecorder) ConsumePushedAuthorizationRequest(ctx, requestURIHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePushedAuthorizationRequest", reflect.TypeOf((*MockPushedAuthorizationRequestStore)(nil).ConsumePushedAuthorizationRequest), ctx, requestURIHash)
+}
+
+// CreatePushedAuthorizationRequest mocks base method.
+func (m *MockPushedAuthorizationRequestStore) CreatePushedAuthorizationRequest(ctx context.Context, r *PushedAuthorizationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePushedAuthorizationRequest", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePushedAuthorizationRequest indicates an expected call of CreatePushedAuthorizationRequest.
+func (mr *MockPushedAuthorizationRequestStoreMockRecorder) CreatePushedAuthorizationRequest(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePushedAuthorizationRequest", reflect.TypeOf((*MockPushedAuthorizationRequestStore)(nil).CreatePushedAuthorizationRequest), ctx, r)
+}
+
+// MockInitialAccessTokenStore is a mock of InitialAccessTokenStore interface.
+type MockInitialAccessTokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockInitialAccessTokenStoreMockRecorder
+}
+
+// MockInitialAccessTokenStoreMockRecorder is the mock recorder for MockInitialAccessTokenStore.
+type MockInitialAccessTokenStoreMockRecorder struct {
+	mock *MockInitialAccessTokenStore
+}
+
+// NewMockInitialAccessTokenStore creates a new mock instance.
+func NewMockInitialAccessTokenStore(ctrl *gomock.Controller) *MockInitialAccessTokenStore {
+	mock := &MockInitialAccessTokenStore{ctrl: ctrl}
+	mock.recorder = &MockInitialAccessTokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInitialAccessTokenStore) EXPECT() *MockInitialAccessTokenStoreMockRecorder {
+	return m.recorder
+}
+
+// ConsumeInitialAccessToken mocks base method.
+func (m *MockInitialAccessTokenStore) ConsumeInitialAccessToken(ctx context.Context, tokenHash string) (*InitialAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeInitialAccessToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*InitialAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeInitialAccessToken indicates an expected call of ConsumeInitialAccessToken.
+func (mr *MockInitialAccessTokenStoreMockRecorder) ConsumeInitialAccessToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeInitialAccessToken", reflect.TypeOf((*MockInitialAccessTokenStore)(nil).ConsumeInitialAccessToken), ctx, tokenHash)
+}
+
+// CreateInitialAccessToken mocks base method.
+func (m *MockInitialAccessTokenStore) CreateInitialAccessToken(ctx context.Context, t *InitialAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInitialAccessToken", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInitialAccessToken indicates an expected call of CreateInitialAccessToken.
+func (mr *MockInitialAccessTokenStoreMockRecorder) CreateInitialAccessToken(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInitialAccessToken", reflect.TypeOf((*MockInitialAccessTokenStore)(nil).CreateInitialAccessToken), ctx, t)
+}
+
+// MockClientRegistrationStore is a mock of ClientRegistrationStore interface.
+type MockClientRegistrationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientRegistrationStoreMockRecorder
+}
+
+// MockClientRegistrationStoreMockRecorder is the mock recorder for MockClientRegistrationStore.
+type MockClientRegistrationStoreMockRecorder struct {
+	mock *MockClientRegistrationStore
+}
+
+// NewMockClientRegistrationStore creates a new mock instance.
+func NewMockClientRegistrationStore(ctrl *gomock.Controller) *MockClientRegistrationStore {
+	mock := &MockClientRegistrationStore{ctrl: ctrl}
+	mock.recorder = &MockClientRegistrationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClientRegistrationStore) EXPECT() *MockClientRegistrationStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateClientRegistration mocks base method.
+func (m *MockClientRegistrationStore) CreateClientRegistration(ctx context.Context, r *ClientRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClientRegistration", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateClientRegistration indicates an expected call of CreateClientRegistration.
+func (mr *MockClientRegistrationStoreMockRecorder) CreateClientRegistration(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClientRegistration", reflect.TypeOf((*MockClientRegistrationStore)(nil).CreateClientRegistration), ctx, r)
+}
+
+// DeleteClientRegistration mocks base method.
+func (m *MockClientRegistrationStore) DeleteClientRegistration(ctx context.Context, r *ClientRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClientRegistration", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClientRegistration indicates an expected call of DeleteClientRegistration.
+func (mr *MockClientRegistrationStoreMockRecorder) DeleteClientRegistration(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClientRegistration", reflect.TypeOf((*MockClientRegistrationStore)(nil).DeleteClientRegistration), ctx, r)
+}
+
+// GetClientRegistration mocks base method.
+func (m *MockClientRegistrationStore) GetClientRegistration(ctx context.Context, clientID string) (*ClientRegistration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientRegistration", ctx, clientID)
+	ret0, _ := ret[0].(*ClientRegistration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientRegistration indicates an expected call of GetClientRegistration.
+func (mr *MockClientRegistrationStoreMockRecorder) GetClientRegistration(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientRegistration", reflect.TypeOf((*MockClientRegistrationStore)(nil).GetClientRegistration), ctx, clientID)
+}
@@ -117,6 +117,11 @@ func (e *AccessTokenEncoding) PrepareUserAccessToken(ctx context.Context, option
 		_ = claims.Set(string(model.ClaimAMR), amr)
 	}
 
+	// org_id
+	if orgID := options.AuthenticationInfo.OrganizationID; orgID != "" {
+		_ = claims.Set(string(model.ClaimOrganizationID), orgID)
+	}
+
 	// jti
 	// Do not put raw token in JWT access token; JWT payload is not specified
 	// to be confidential. Put token hash to allow looking up access grant from
@@ -10,6 +10,8 @@ const (
 	TypeADFS       = "adfs"
 	TypeApple      = "apple"
 	TypeWechat     = "wechat"
+	TypeOIDC       = "oidc"
+	TypeOAuth2     = "oauth2"
 )
 
 var BuiltinProviderTypes = []string{
@@ -22,4 +24,19 @@ var BuiltinProviderTypes = []string{
 	TypeADFS,
 	TypeApple,
 	TypeWechat,
+	TypeOIDC,
+	TypeOAuth2,
+}
+
+// TrustsEmailVerifiedClaim reports whether the email_verified claim from
+// the provider is used to mark the email as verified.
+// The generic providers do not assume the email is verified,
+// because nothing is known about how the provider verifies email.
+func TrustsEmailVerifiedClaim(providerType string) bool {
+	switch providerType {
+	case TypeOIDC, TypeOAuth2:
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,241 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	"github.com/authgear/oauthrelyingparty/pkg/api/oauthrelyingparty"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
+	liboauthrelyingparty "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty"
+	"github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/oauthrelyingpartyutil"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+func init() {
+	oauthrelyingparty.RegisterProvider(Type, OAuth2{})
+}
+
+const Type = liboauthrelyingparty.TypeOAuth2
+
+type ProviderConfig oauthrelyingparty.ProviderConfig
+
+func (c ProviderConfig) AuthorizationEndpoint() string {
+	authorization_endpoint, _ := c["authorization_endpoint"].(string)
+	return authorization_endpoint
+}
+
+func (c ProviderConfig) TokenEndpoint() string {
+	token_endpoint, _ := c["token_endpoint"].(string)
+	return token_endpoint
+}
+
+func (c ProviderConfig) UserInfoEndpoint() string {
+	userinfo_endpoint, _ := c["userinfo_endpoint"].(string)
+	return userinfo_endpoint
+}
+
+func (c ProviderConfig) UserIDPointer() string {
+	user_id_pointer, ok := c["user_id_pointer"].(string)
+	if !ok {
+		return "/sub"
+	}
+	return user_id_pointer
+}
+
+var _ oauthrelyingparty.Provider = OAuth2{}
+
+// OAuth2 is a generic OAuth 2.0 provider.
+// The user is identified by the response of the userinfo endpoint.
+type OAuth2 struct{}
+
+func (OAuth2) GetJSONSchema() map[string]any {
+	builder := validation.SchemaBuilder{}
+	builder.Type(validation.TypeObject)
+	builder.Properties().
+		Property("type", validation.SchemaBuilder{}.Type(validation.TypeString)).
+		Property("client_id", validation.SchemaBuilder{}.Type(validation.TypeString).MinLength(1)).
+		Property("claims", validation.SchemaBuilder{}.Type(validation.TypeObject).
+			AdditionalPropertiesFalse().
+			Properties().
+			Property("email", validation.SchemaBuilder{}.Type(validation.TypeObject).
+				AdditionalPropertiesFalse().Properties().
+				Property("assume_verified", validation.SchemaBuilder{}.Type(validation.TypeBoolean)).
+				Property("required", validation.SchemaBuilder{}.Type(validation.TypeBoolean)),
+			),
+		).
+		Property("authorization_endpoint", validation.SchemaBuilder{}.Type(validation.TypeString).Format("uri")).
+		Property("token_endpoint", validation.SchemaBuilder{}.Type(validation.TypeString).Format("uri")).
+		Property("userinfo_endpoint", validation.SchemaBuilder{}.Type(validation.TypeString).Format("uri")).
+		Property("scopes", validation.SchemaBuilder{}.Type(validation.TypeArray).
+			Items(validation.SchemaBuilder{}.Type(validation.TypeString).MinLength(1)),
+		).
+		Property("user_id_pointer", validation.SchemaBuilder{}.Type(validation.TypeString).Format("json-pointer")).
+		Property("claim_mapping", oauthrelyingpartyutil.ClaimMappingSchemaBuilder()).
+		Property("pkce", validation.SchemaBuilder{}.Type(validation.TypeBoolean))
+	builder.Required("type", "client_id", "authorization_endpoint", "token_endpoint", "userinfo_endpoint")
+	return builder
+}
+
+func (OAuth2) SetDefaults(cfg oauthrelyingparty.ProviderConfig) {
+	// The email is marked as verified only if the provider says so with email_verified.
+	cfg.SetDefaultsEmailClaimConfig(oauthrelyingpartyutil.Email_NOT_AssumeVerified_Required())
+}
+
+func (OAuth2) ProviderID(cfg oauthrelyingparty.ProviderConfig) oauthrelyingparty.ProviderID {
+	// There is no issuer in plain OAuth 2.0.
+	// The authorization endpoint is the closest thing to identify the provider.
+	// Therefore, ProviderID is Type + authorization_endpoint.
+	authorizationEndpoint := ProviderConfig(cfg).AuthorizationEndpoint()
+	keys := map[string]any{
+		"authorization_endpoint": authorizationEndpoint,
+	}
+	return oauthrelyingparty.NewProviderID(cfg.Type(), keys)
+}
+
+func (OAuth2) scope(cfg oauthrelyingparty.ProviderConfig) []string {
+	return oauthrelyingpartyutil.Scopes(cfg, nil)
+}
+
+func (p OAuth2) GetAuthorizationURL(ctx context.Context, deps oauthrelyingparty.Dependencies, param oauthrelyingparty.GetAuthorizationURLOptions) (string, error) {
+	params := oauthrelyingpartyutil.AuthorizationURLParams{
+		ClientID:     deps.ProviderConfig.ClientID(),
+		RedirectURI:  param.RedirectURI,
+		Scope:        p.scope(deps.ProviderConfig),
+		ResponseType: oauthrelyingparty.ResponseTypeCode,
+		ResponseMode: param.ResponseMode,
+		State:        param.State,
+		// Prompt is unset because it is defined by OIDC.
+		// Nonce is unset because it is defined by OIDC.
+	}
+	if oauthrelyingpartyutil.PKCEEnabled(deps.ProviderConfig) {
+		verifier, err := oauthrelyingpartyutil.PreparePKCEVerifier(ctx, deps.SimpleStore, param.Nonce)
+		if err != nil {
+			return "", err
+		}
+		params.ExtraQuery = oauthrelyingpartyutil.PKCEExtraQuery(verifier)
+	}
+
+	endpoint := ProviderConfig(deps.ProviderConfig).AuthorizationEndpoint()
+	return oauthrelyingpartyutil.MakeAuthorizationURL(endpoint, params.Query()), nil
+}
+
+func (p OAuth2) GetUserProfile(ctx context.Context, deps oauthrelyingparty.Dependencies, param oauthrelyingparty.GetUserProfileOptions) (authInfo oauthrelyingparty.UserProfile, err error) {
+	code, err := oauthrelyingpartyutil.GetCode(param.Query)
+	if err != nil {
+		return
+	}
+
+	var codeVerifier string
+	if oauthrelyingpartyutil.PKCEEnabled(deps.ProviderConfig) {
+		codeVerifier, err = oauthrelyingpartyutil.ConsumePKCEVerifier(ctx, deps.SimpleStore, param.Nonce)
+		if err != nil {
+			return
+		}
+	}
+
+	accessTokenResp, err := oauthrelyingpartyutil.FetchAccessTokenRespWithCodeVerifier(
+		ctx,
+		deps.HTTPClient,
+		code,
+		ProviderConfig(deps.ProviderConfig).TokenEndpoint(),
+		param.RedirectURI,
+		deps.ProviderConfig.ClientID(),
+		deps.ClientSecret,
+		codeVerifier,
+	)
+	if err != nil {
+		return
+	}
+
+	userProfile, err := p.fetchUserInfo(ctx, deps, accessTokenResp)
+	if err != nil {
+		return
+	}
+	authInfo.ProviderRawProfile = userProfile
+
+	id, err := p.userID(deps, userProfile)
+	if err != nil {
+		return
+	}
+	authInfo.ProviderUserID = id
+
+	mapped, err := oauthrelyingpartyutil.ClaimMappingOf(deps.ProviderConfig).Apply(userProfile)
+	if err != nil {
+		return
+	}
+
+	emailRequired := deps.ProviderConfig.EmailClaimConfig().Required()
+	stdAttrs, err := stdattrs.Extract(mapped, stdattrs.ExtractOptions{
+		EmailRequired: emailRequired,
+	})
+	if err != nil {
+		return
+	}
+	authInfo.StandardAttributes = stdAttrs
+
+	return
+}
+
+func (OAuth2) userID(deps oauthrelyingparty.Dependencies, userProfile map[string]any) (string, error) {
+	pointer := ProviderConfig(deps.ProviderConfig).UserIDPointer()
+	ptr, err := jsonpointer.Parse(pointer)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := ptr.Traverse(userProfile)
+	if err != nil {
+		return "", oauthrelyingpartyutil.OAuthProtocolError.New(fmt.Sprintf("user ID not found in user profile: %v", pointer))
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case json.Number:
+		return string(v), nil
+	}
+	return "", oauthrelyingpartyutil.OAuthProtocolError.New(fmt.Sprintf("user ID in user profile is of invalid type: %T", value))
+}
+
+func (OAuth2) fetchUserInfo(ctx context.Context, deps oauthrelyingparty.Dependencies, accessTokenResp oauthrelyingpartyutil.AccessTokenResp) (userProfile map[string]any, err error) {
+	tokenType := accessTokenResp.TokenType()
+	accessTokenValue := accessTokenResp.AccessToken()
+	authorizationHeader := fmt.Sprintf("%s %s", tokenType, accessTokenValue)
+
+	endpoint := ProviderConfig(deps.ProviderConfig).UserInfoEndpoint()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Add("Authorization", authorizationHeader)
+
+	resp, err := deps.HTTPClient.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return
+	}
+
+	if resp.StatusCode != 200 {
+		err = fmt.Errorf("failed to fetch user profile: unexpected status code: %d", resp.StatusCode)
+		return
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	// Deserialize numeric user ID as json.Number.
+	decoder.UseNumber()
+	err = decoder.Decode(&userProfile)
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,102 @@
+package oauth2
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/oauthrelyingparty/pkg/api/oauthrelyingparty"
+)
+
+type memorySimpleStore map[string]string
+
+func (s memorySimpleStore) GetDel(ctx context.Context, key string) (string, error) {
+	v := s[key]
+	delete(s, key)
+	return v, nil
+}
+
+func (s memorySimpleStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	s[key] = value
+	return nil
+}
+
+func TestOAuth2(t *testing.T) {
+	Convey("OAuth2", t, func() {
+		client := &http.Client{}
+		gock.InterceptClient(client)
+		store := memorySimpleStore{
+			"pkce:nonce": "nSLS8P8EEL1Ntk494lctasg-kTJRfdS_3P3y6eHXGd8",
+		}
+		defer gock.Off()
+
+		deps := oauthrelyingparty.Dependencies{
+			ProviderConfig: oauthrelyingparty.ProviderConfig{
+				"client_id":              "client_id",
+				"type":                   Type,
+				"authorization_endpoint": "https://localhost/authorize",
+				"token_endpoint":         "https://localhost/token",
+				"userinfo_endpoint":      "https://localhost/userinfo",
+				"scopes":                 []any{"read:user"},
+				"user_id_pointer":        "/data/id",
+				"claim_mapping": map[string]any{
+					"email": "/data/mail",
+				},
+			},
+			ClientSecret: "client_secret",
+			HTTPClient:   client,
+			SimpleStore:  store,
+		}
+
+		g := OAuth2{}
+		ctx := context.Background()
+
+		Convey("GetAuthorizationURL", func() {
+			u, err := g.GetAuthorizationURL(ctx, deps, oauthrelyingparty.GetAuthorizationURLOptions{
+				RedirectURI:  "https://localhost/",
+				ResponseMode: oauthrelyingparty.ResponseModeFormPost,
+				Nonce:        "nonce",
+				State:        "state",
+				Prompt:       []string{"login"},
+			})
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, "https://localhost/authorize?client_id=client_id&code_challenge=qcvkAiOTTM0ZMtIxh1oB1QnC1rlEyNQn3NZ7SaBnCos&code_challenge_method=S256&redirect_uri=https%3A%2F%2Flocalhost%2F&response_mode=form_post&response_type=code&scope=read%3Auser&state=state")
+		})
+
+		Convey("GetUserProfile", func() {
+			gock.New("https://localhost/token").
+				MatchType("url").
+				BodyString("code_verifier=nSLS8P8EEL1Ntk494lctasg-kTJRfdS_3P3y6eHXGd8").
+				Reply(200).
+				BodyString(`{"access_token": "access_token", "token_type": "bearer"}`)
+			gock.New("https://localhost/userinfo").
+				MatchHeader("Authorization", "Bearer access_token").
+				Reply(200).
+				BodyString(`{"data": {"id": 12345678901234567890, "mail": "user@example.com"}}`)
+			defer func() { gock.Flush() }()
+
+			profile, err := g.GetUserProfile(ctx, deps, oauthrelyingparty.GetUserProfileOptions{
+				Query:       "code=code&state=state",
+				RedirectURI: "https://localhost/",
+				Nonce:       "nonce",
+			})
+			So(err, ShouldBeNil)
+			So(profile.ProviderUserID, ShouldEqual, "12345678901234567890")
+			So(profile.StandardAttributes["email"], ShouldEqual, "user@example.com")
+			So(store, ShouldBeEmpty)
+		})
+
+		Convey("GetUserProfile without the stored code verifier", func() {
+			_, err := g.GetUserProfile(ctx, deps, oauthrelyingparty.GetUserProfileOptions{
+				Query:       "code=code&state=state",
+				RedirectURI: "https://localhost/",
+				Nonce:       "another-nonce",
+			})
+			So(err, ShouldBeError, "code verifier not found or expired")
+		})
+	})
+}
@@ -90,6 +90,21 @@ func FetchAccessTokenResp(
 	redirectURL string,
 	clientID string,
 	clientSecret string,
+) (r AccessTokenResp, err error) {
+	return FetchAccessTokenRespWithCodeVerifier(ctx, client, code, accessTokenURL, redirectURL, clientID, clientSecret, "")
+}
+
+// FetchAccessTokenRespWithCodeVerifier is FetchAccessTokenResp with PKCE.
+// codeVerifier is sent only when it is non-empty.
+func FetchAccessTokenRespWithCodeVerifier(
+	ctx context.Context,
+	client *http.Client,
+	code string,
+	accessTokenURL string,
+	redirectURL string,
+	clientID string,
+	clientSecret string,
+	codeVerifier string,
 ) (r AccessTokenResp, err error) {
 	v := url.Values{}
 	v.Set("grant_type", "authorization_code")
@@ -97,6 +112,9 @@ func FetchAccessTokenResp(
 	v.Add("redirect_uri", redirectURL)
 	v.Add("client_id", clientID)
 	v.Add("client_secret", clientSecret)
+	if codeVerifier != "" {
+		v.Add("code_verifier", codeVerifier)
+	}
 
 	// nolint: gosec
 	resp, err := httputil.PostFormWithContext(ctx, client, accessTokenURL, v)
@@ -30,3 +30,15 @@ func GetCode(query string) (code string, err error) {
 	code = form.Get("code")
 	return
 }
+
+func GetState(query string) (state string, err error) {
+	// query may start with a ?, remove it.
+	query = strings.TrimPrefix(query, "?")
+	form, err := url.ParseQuery(query)
+	if err != nil {
+		return
+	}
+
+	state = form.Get("state")
+	return
+}
@@ -15,3 +15,10 @@ func Email_AssumeVerified_NOT_Required() oauthrelyingparty.ProviderClaimConfig {
 		"required":        false,
 	}
 }
+
+func Email_NOT_AssumeVerified_Required() oauthrelyingparty.ProviderClaimConfig {
+	return oauthrelyingparty.ProviderClaimConfig{
+		"assume_verified": false,
+		"required":        true,
+	}
+}
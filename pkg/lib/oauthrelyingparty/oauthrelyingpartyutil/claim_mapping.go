@@ -0,0 +1,78 @@
+package oauthrelyingpartyutil
+
+import (
+	"maps"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+// ClaimMappingKeys are the standard attributes that can be mapped from the claims of a provider.
+var ClaimMappingKeys = []string{
+	stdattrs.Email,
+	stdattrs.EmailVerified,
+	stdattrs.PhoneNumber,
+	stdattrs.PhoneNumberVerified,
+	stdattrs.PreferredUsername,
+	stdattrs.Name,
+	stdattrs.GivenName,
+	stdattrs.FamilyName,
+	stdattrs.MiddleName,
+	stdattrs.Nickname,
+	stdattrs.Picture,
+	stdattrs.Profile,
+	stdattrs.Website,
+	stdattrs.Gender,
+	stdattrs.Birthdate,
+	stdattrs.Zoneinfo,
+	stdattrs.Locale,
+}
+
+func ClaimMappingSchemaBuilder() validation.SchemaBuilder {
+	builder := validation.SchemaBuilder{}.Type(validation.TypeObject).AdditionalPropertiesFalse()
+	properties := builder.Properties()
+	for _, key := range ClaimMappingKeys {
+		properties.Property(key, validation.SchemaBuilder{}.Type(validation.TypeString).Format("json-pointer"))
+	}
+	return builder
+}
+
+// ClaimMapping maps a standard attribute to a JSON pointer into the claims of a provider.
+type ClaimMapping map[string]string
+
+func NewClaimMapping(v any) ClaimMapping {
+	m, _ := v.(map[string]any)
+	mapping := ClaimMapping{}
+	for key, value := range m {
+		if pointer, ok := value.(string); ok {
+			mapping[key] = pointer
+		}
+	}
+	return mapping
+}
+
+// Apply returns a copy of claims, with the mapped standard attributes added.
+// A pointer that does not point to a value is ignored.
+func (m ClaimMapping) Apply(claims map[string]any) (map[string]any, error) {
+	out := maps.Clone(claims)
+	if out == nil {
+		out = map[string]any{}
+	}
+
+	for key, pointer := range m {
+		ptr, err := jsonpointer.Parse(pointer)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := ptr.Traverse(claims)
+		if err != nil {
+			continue
+		}
+		out[key] = value
+	}
+
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package oauthrelyingpartyutil
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClaimMapping(t *testing.T) {
+	Convey("ClaimMapping", t, func() {
+		claims := map[string]any{
+			"sub":   "user",
+			"email": "user@example.com",
+			"profile": map[string]any{
+				"display_name": "User",
+				"mail":         "user@example.org",
+			},
+		}
+
+		Convey("map nested claims", func() {
+			m := NewClaimMapping(map[string]any{
+				"name":  "/profile/display_name",
+				"email": "/profile/mail",
+			})
+			out, err := m.Apply(claims)
+			So(err, ShouldBeNil)
+			So(out["name"], ShouldEqual, "User")
+			So(out["email"], ShouldEqual, "user@example.org")
+			So(out["sub"], ShouldEqual, "user")
+			So(claims["email"], ShouldEqual, "user@example.com")
+		})
+
+		Convey("ignore pointer to nothing", func() {
+			m := NewClaimMapping(map[string]any{
+				"name": "/profile/nickname",
+			})
+			out, err := m.Apply(claims)
+			So(err, ShouldBeNil)
+			So(out, ShouldNotContainKey, "name")
+		})
+	})
+}
@@ -92,6 +92,24 @@ func (d *OIDCDiscoveryDocument) ExchangeCode(
 	redirectURI string,
 	nonce string,
 	tokenResp *AccessTokenResp,
+) (jwt.Token, error) {
+	return d.ExchangeCodeWithCodeVerifier(ctx, client, clock, code, jwks, clientID, clientSecret, redirectURI, nonce, "", tokenResp)
+}
+
+// ExchangeCodeWithCodeVerifier is ExchangeCode with PKCE.
+// codeVerifier is sent only when it is non-empty.
+func (d *OIDCDiscoveryDocument) ExchangeCodeWithCodeVerifier(
+	ctx context.Context,
+	client *http.Client,
+	clock oauthrelyingparty.Clock,
+	code string,
+	jwks jwk.Set,
+	clientID string,
+	clientSecret string,
+	redirectURI string,
+	nonce string,
+	codeVerifier string,
+	tokenResp *AccessTokenResp,
 ) (jwt.Token, error) {
 	body := url.Values{}
 	body.Set("grant_type", "authorization_code")
@@ -99,6 +117,9 @@ func (d *OIDCDiscoveryDocument) ExchangeCode(
 	body.Set("code", code)
 	body.Set("redirect_uri", redirectURI)
 	body.Set("client_secret", clientSecret)
+	if codeVerifier != "" {
+		body.Set("code_verifier", codeVerifier)
+	}
 
 	resp, err := httputil.PostFormWithContext(ctx, client, d.TokenEndpoint, body)
 	if err != nil {
@@ -0,0 +1,81 @@
+package oauthrelyingpartyutil
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/util/pkce"
+)
+
+// PKCEVerifierLifetime bounds the time between the authorization request and the code exchange.
+const PKCEVerifierLifetime = 1 * time.Hour
+
+// PKCEVerifierStore is the SimpleStore in the dependencies of the provider.
+type PKCEVerifierStore interface {
+	GetDel(ctx context.Context, key string) (string, error)
+	SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error
+}
+
+func pkceVerifierKey(nonce string) string {
+	return "pkce:" + nonce
+}
+
+// PreparePKCEVerifier returns the code verifier for the authorization request.
+// The code verifier is random, and is stored server-side under nonce,
+// which the caller keeps with the OAuth state until the code exchange.
+// The same code verifier is returned if the authorization URL is generated again for nonce.
+func PreparePKCEVerifier(ctx context.Context, store PKCEVerifierStore, nonce string) (*pkce.Verifier, error) {
+	if nonce == "" {
+		return nil, OAuthProtocolError.New("nonce is required for PKCE")
+	}
+
+	key := pkceVerifierKey(nonce)
+	codeVerifier, err := store.GetDel(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var verifier *pkce.Verifier
+	if codeVerifier == "" {
+		verifier = pkce.GenerateS256Verifier()
+	} else {
+		verifier, err = pkce.NewS256Verifier(codeVerifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = store.SetWithTTL(ctx, key, verifier.CodeVerifier, PKCEVerifierLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifier, nil
+}
+
+// ConsumePKCEVerifier returns the code verifier stored by PreparePKCEVerifier.
+// The code verifier can only be used once.
+func ConsumePKCEVerifier(ctx context.Context, store PKCEVerifierStore, nonce string) (string, error) {
+	if nonce == "" {
+		return "", OAuthProtocolError.New("nonce is required for PKCE")
+	}
+
+	codeVerifier, err := store.GetDel(ctx, pkceVerifierKey(nonce))
+	if err != nil {
+		return "", err
+	}
+	if codeVerifier == "" {
+		return "", OAuthProtocolError.New("code verifier not found or expired")
+	}
+
+	return codeVerifier, nil
+}
+
+// PKCEExtraQuery is the authorization request parameters of verifier.
+func PKCEExtraQuery(verifier *pkce.Verifier) url.Values {
+	return url.Values{
+		"code_challenge":        []string{verifier.Challenge()},
+		"code_challenge_method": []string{verifier.CodeChallengeMethod},
+	}
+}
@@ -0,0 +1,66 @@
+package oauthrelyingpartyutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type memoryPKCEVerifierStore map[string]string
+
+func (s memoryPKCEVerifierStore) GetDel(ctx context.Context, key string) (string, error) {
+	v := s[key]
+	delete(s, key)
+	return v, nil
+}
+
+func (s memoryPKCEVerifierStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	s[key] = value
+	return nil
+}
+
+func TestPKCEVerifier(t *testing.T) {
+	Convey("PKCE verifier", t, func() {
+		ctx := context.Background()
+		store := memoryPKCEVerifierStore{}
+
+		Convey("should generate a random verifier per nonce", func() {
+			v1, err := PreparePKCEVerifier(ctx, store, "nonce-1")
+			So(err, ShouldBeNil)
+			So(v1.CodeVerifier, ShouldHaveLength, 43)
+
+			v2, err := PreparePKCEVerifier(ctx, store, "nonce-2")
+			So(err, ShouldBeNil)
+			So(v2.CodeVerifier, ShouldNotEqual, v1.CodeVerifier)
+		})
+
+		Convey("should reuse the verifier of the same nonce", func() {
+			v1, err := PreparePKCEVerifier(ctx, store, "nonce")
+			So(err, ShouldBeNil)
+			v2, err := PreparePKCEVerifier(ctx, store, "nonce")
+			So(err, ShouldBeNil)
+			So(v2.CodeVerifier, ShouldEqual, v1.CodeVerifier)
+		})
+
+		Convey("should consume the verifier once", func() {
+			v, err := PreparePKCEVerifier(ctx, store, "nonce")
+			So(err, ShouldBeNil)
+
+			codeVerifier, err := ConsumePKCEVerifier(ctx, store, "nonce")
+			So(err, ShouldBeNil)
+			So(codeVerifier, ShouldEqual, v.CodeVerifier)
+
+			_, err = ConsumePKCEVerifier(ctx, store, "nonce")
+			So(err, ShouldBeError, "code verifier not found or expired")
+		})
+
+		Convey("should require nonce", func() {
+			_, err := PreparePKCEVerifier(ctx, store, "")
+			So(err, ShouldBeError, "nonce is required for PKCE")
+			_, err = ConsumePKCEVerifier(ctx, store, "")
+			So(err, ShouldBeError, "nonce is required for PKCE")
+		})
+	})
+}
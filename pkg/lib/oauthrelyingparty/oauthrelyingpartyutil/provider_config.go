@@ -0,0 +1,37 @@
+package oauthrelyingpartyutil
+
+import (
+	"github.com/authgear/oauthrelyingparty/pkg/api/oauthrelyingparty"
+)
+
+// Scopes returns cfg["scopes"], or defaultScopes if it is absent.
+func Scopes(cfg oauthrelyingparty.ProviderConfig, defaultScopes []string) []string {
+	switch v := cfg["scopes"].(type) {
+	case []string:
+		return v
+	case []any:
+		var scopes []string
+		for _, scope := range v {
+			if s, ok := scope.(string); ok {
+				scopes = append(scopes, s)
+			}
+		}
+		return scopes
+	default:
+		return defaultScopes
+	}
+}
+
+// PKCEEnabled returns cfg["pkce"], which is true by default.
+func PKCEEnabled(cfg oauthrelyingparty.ProviderConfig) bool {
+	pkce, ok := cfg["pkce"].(bool)
+	if !ok {
+		return true
+	}
+	return pkce
+}
+
+// ClaimMappingOf returns cfg["claim_mapping"].
+func ClaimMappingOf(cfg oauthrelyingparty.ProviderConfig) ClaimMapping {
+	return NewClaimMapping(cfg["claim_mapping"])
+}
@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/authgear/oauthrelyingparty/pkg/api/oauthrelyingparty"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/stdattrs"
+	liboauthrelyingparty "github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty"
+	"github.com/authgear/authgear-server/pkg/lib/oauthrelyingparty/oauthrelyingpartyutil"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+func init() {
+	oauthrelyingparty.RegisterProvider(Type, OIDC{})
+}
+
+const Type = liboauthrelyingparty.TypeOIDC
+
+type ProviderConfig oauthrelyingparty.ProviderConfig
+
+func (c ProviderConfig) Issuer() string {
+	issuer, _ := c["issuer"].(string)
+	return issuer
+}
+
+func (c ProviderConfig) DiscoveryDocumentEndpoint() string {
+	return strings.TrimSuffix(c.Issuer(), "/") + "/.well-known/openid-configuration"
+}
+
+var _ oauthrelyingparty.Provider = OIDC{}
+
+// OIDC is a generic OpenID Connect provider.
+// The endpoints are discovered from the issuer.
+type OIDC struct{}
+
+func (OIDC) GetJSONSchema() map[string]any {
+	builder := validation.SchemaBuilder{}
+	builder.Type(validation.TypeObject)
+	builder.Properties().
+		Property("type", validation.SchemaBuilder{}.Type(validation.TypeString)).
+		Property("client_id", validation.SchemaBuilder{}.Type(validation.TypeString).MinLength(1)).
+		Property("claims", validation.SchemaBuilder{}.Type(validation.TypeObject).
+			AdditionalPropertiesFalse().
+			Properties().
+			Property("email", validation.SchemaBuilder{}.Type(validation.TypeObject).
+				AdditionalPropertiesFalse().Properties().
+				Property("assume_verified", validation.SchemaBuilder{}.Type(validation.TypeBoolean)).
+				Property("required", validation.SchemaBuilder{}.Type(validation.TypeBoolean)),
+			),
+		).
+		Property("issuer", validation.SchemaBuilder{}.Type(validation.TypeString).Format("uri")).
+		Property("scopes", validation.SchemaBuilder{}.Type(validation.TypeArray).
+			Items(validation.SchemaBuilder{}.Type(validation.TypeString).MinLength(1)),
+		).
+		Property("claim_mapping", oauthrelyingpartyutil.ClaimMappingSchemaBuilder()).
+		Property("pkce", validation.SchemaBuilder{}.Type(validation.TypeBoolean))
+	builder.Required("type", "client_id", "issuer")
+	return builder
+}
+
+func (OIDC) SetDefaults(cfg oauthrelyingparty.ProviderConfig) {
+	// The email is marked as verified only if the provider says so with email_verified.
+	cfg.SetDefaultsEmailClaimConfig(oauthrelyingpartyutil.Email_NOT_AssumeVerified_Required())
+}
+
+func (OIDC) ProviderID(cfg oauthrelyingparty.ProviderConfig) oauthrelyingparty.ProviderID {
+	// sub is only unique within an issuer.
+	// Therefore, ProviderID is Type + issuer.
+	//
+	// Rotating the OAuth application is OK,
+	// unless the provider issues pairwise sub.
+	issuer := ProviderConfig(cfg).Issuer()
+	keys := map[string]any{
+		"issuer": issuer,
+	}
+	return oauthrelyingparty.NewProviderID(cfg.Type(), keys)
+}
+
+func (OIDC) scope(cfg oauthrelyingparty.ProviderConfig) []string {
+	return oauthrelyingpartyutil.Scopes(cfg, []string{"openid", "profile", "email"})
+}
+
+func (OIDC) getOpenIDConfiguration(ctx context.Context, deps oauthrelyingparty.Dependencies) (*oauthrelyingpartyutil.OIDCDiscoveryDocument, error) {
+	endpoint := ProviderConfig(deps.ProviderConfig).DiscoveryDocumentEndpoint()
+	c, err := oauthrelyingpartyutil.FetchOIDCDiscoveryDocument(ctx, deps.HTTPClient, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderConfigurationValidation
+	if c.Issuer != ProviderConfig(deps.ProviderConfig).Issuer() {
+		return nil, oauthrelyingpartyutil.OAuthProtocolError.New("issuer in discovery document does not match the issuer")
+	}
+
+	return c, nil
+}
+
+func (p OIDC) GetAuthorizationURL(ctx context.Context, deps oauthrelyingparty.Dependencies, param oauthrelyingparty.GetAuthorizationURLOptions) (string, error) {
+	c, err := p.getOpenIDConfiguration(ctx, deps)
+	if err != nil {
+		return "", err
+	}
+
+	params := oauthrelyingpartyutil.AuthorizationURLParams{
+		ClientID:     deps.ProviderConfig.ClientID(),
+		RedirectURI:  param.RedirectURI,
+		Scope:        p.scope(deps.ProviderConfig),
+		ResponseType: oauthrelyingparty.ResponseTypeCode,
+		ResponseMode: param.ResponseMode,
+		State:        param.State,
+		Prompt:       param.Prompt,
+		Nonce:        param.Nonce,
+	}
+	if oauthrelyingpartyutil.PKCEEnabled(deps.ProviderConfig) {
+		verifier, err := oauthrelyingpartyutil.PreparePKCEVerifier(ctx, deps.SimpleStore, param.Nonce)
+		if err != nil {
+			return "", err
+		}
+		params.ExtraQuery = oauthrelyingpartyutil.PKCEExtraQuery(verifier)
+	}
+
+	return c.MakeOAuthURL(params), nil
+}
+
+func (p OIDC) GetUserProfile(ctx context.Context, deps oauthrelyingparty.Dependencies, param oauthrelyingparty.GetUserProfileOptions) (authInfo oauthrelyingparty.UserProfile, err error) {
+	c, err := p.getOpenIDConfiguration(ctx, deps)
+	if err != nil {
+		return
+	}
+
+	// OPTIMIZE(sso): Cache JWKs
+	keySet, err := c.FetchJWKs(ctx, deps.HTTPClient)
+	if err != nil {
+		return
+	}
+
+	code, err := oauthrelyingpartyutil.GetCode(param.Query)
+	if err != nil {
+		return
+	}
+
+	var codeVerifier string
+	if oauthrelyingpartyutil.PKCEEnabled(deps.ProviderConfig) {
+		codeVerifier, err = oauthrelyingpartyutil.ConsumePKCEVerifier(ctx, deps.SimpleStore, param.Nonce)
+		if err != nil {
+			return
+		}
+	}
+
+	var tokenResp oauthrelyingpartyutil.AccessTokenResp
+	jwtToken, err := c.ExchangeCodeWithCodeVerifier(
+		ctx,
+		deps.HTTPClient,
+		deps.Clock,
+		code,
+		keySet,
+		deps.ProviderConfig.ClientID(),
+		deps.ClientSecret,
+		param.RedirectURI,
+		param.Nonce,
+		codeVerifier,
+		&tokenResp,
+	)
+	if err != nil {
+		return
+	}
+
+	claims, err := jwtToken.AsMap(oauthrelyingpartyutil.ContextForTheUnusedContextArgumentInJWXV2API)
+	if err != nil {
+		return
+	}
+
+	// Verify the issuer against the configured one.
+	// https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+	iss, ok := claims["iss"].(string)
+	if !ok {
+		err = oauthrelyingpartyutil.OAuthProtocolError.New("iss not found in ID token")
+		return
+	}
+	if iss != ProviderConfig(deps.ProviderConfig).Issuer() {
+		err = oauthrelyingpartyutil.OAuthProtocolError.New("iss does not match the issuer")
+		return
+	}
+
+	sub, ok := claims["sub"].(string)
+	if !ok {
+		err = oauthrelyingpartyutil.OAuthProtocolError.New("sub not found in ID token")
+		return
+	}
+
+	// Merge the claims from the userinfo endpoint, if there is one.
+	// The claims in the ID token take precedence.
+	if c.UserInfoEndpoint != "" {
+		var userInfo map[string]any
+		userInfo, err = c.FetchUserInfo(ctx, deps.HTTPClient, tokenResp)
+		if err != nil {
+			return
+		}
+		// The sub in the userinfo response MUST match the sub in the ID token.
+		// https://openid.net/specs/openid-connect-core-1_0.html#UserInfoResponse
+		if userInfoSub, _ := userInfo["sub"].(string); userInfoSub != sub {
+			err = oauthrelyingpartyutil.OAuthProtocolError.New("sub in userinfo does not match sub in ID token")
+			return
+		}
+		for key, value := range claims {
+			userInfo[key] = value
+		}
+		claims = userInfo
+	}
+
+	mapped, err := oauthrelyingpartyutil.ClaimMappingOf(deps.ProviderConfig).Apply(claims)
+	if err != nil {
+		return
+	}
+
+	emailRequired := deps.ProviderConfig.EmailClaimConfig().Required()
+	stdAttrs, err := stdattrs.Extract(mapped, stdattrs.ExtractOptions{
+		EmailRequired: emailRequired,
+	})
+	if err != nil {
+		return
+	}
+
+	authInfo.ProviderRawProfile = claims
+	authInfo.ProviderUserID = sub
+	authInfo.StandardAttributes = stdAttrs
+
+	return
+}
@@ -0,0 +1,106 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/oauthrelyingparty/pkg/api/oauthrelyingparty"
+)
+
+type memorySimpleStore map[string]string
+
+func (s memorySimpleStore) GetDel(ctx context.Context, key string) (string, error) {
+	v := s[key]
+	delete(s, key)
+	return v, nil
+}
+
+func (s memorySimpleStore) SetWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	s[key] = value
+	return nil
+}
+
+func TestOIDC(t *testing.T) {
+	Convey("OIDC", t, func() {
+		client := &http.Client{}
+		gock.InterceptClient(client)
+		store := memorySimpleStore{
+			"pkce:nonce": "nSLS8P8EEL1Ntk494lctasg-kTJRfdS_3P3y6eHXGd8",
+		}
+		defer gock.Off()
+
+		deps := oauthrelyingparty.Dependencies{
+			ProviderConfig: oauthrelyingparty.ProviderConfig{
+				"client_id": "client_id",
+				"type":      Type,
+				"issuer":    "https://localhost",
+			},
+			ClientSecret: "client_secret",
+			HTTPClient:   client,
+			SimpleStore:  store,
+		}
+
+		g := OIDC{}
+
+		gock.New("https://localhost/.well-known/openid-configuration").
+			Reply(200).
+			BodyString(`
+{
+  "issuer": "https://localhost",
+  "authorization_endpoint": "https://localhost/authorize"
+}
+			`)
+		defer func() { gock.Flush() }()
+
+		ctx := context.Background()
+
+		Convey("should use PKCE by default", func() {
+			u, err := g.GetAuthorizationURL(ctx, deps, oauthrelyingparty.GetAuthorizationURLOptions{
+				RedirectURI:  "https://localhost/",
+				ResponseMode: oauthrelyingparty.ResponseModeFormPost,
+				Nonce:        "nonce",
+				State:        "state",
+				Prompt:       []string{"login"},
+			})
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, "https://localhost/authorize?client_id=client_id&code_challenge=qcvkAiOTTM0ZMtIxh1oB1QnC1rlEyNQn3NZ7SaBnCos&code_challenge_method=S256&nonce=nonce&prompt=login&redirect_uri=https%3A%2F%2Flocalhost%2F&response_mode=form_post&response_type=code&scope=openid+profile+email&state=state")
+		})
+
+		Convey("should generate a random code verifier for another nonce", func() {
+			u, err := g.GetAuthorizationURL(ctx, deps, oauthrelyingparty.GetAuthorizationURLOptions{
+				RedirectURI:  "https://localhost/",
+				ResponseMode: oauthrelyingparty.ResponseModeFormPost,
+				Nonce:        "another-nonce",
+				State:        "state",
+			})
+			So(err, ShouldBeNil)
+			So(u, ShouldNotContainSubstring, "code_challenge=qcvkAiOTTM0ZMtIxh1oB1QnC1rlEyNQn3NZ7SaBnCos")
+			So(store["pkce:another-nonce"], ShouldHaveLength, 43)
+		})
+
+		Convey("should default to not assume the email is verified", func() {
+			cfg := oauthrelyingparty.ProviderConfig{}
+			g.SetDefaults(cfg)
+			So(cfg.EmailClaimConfig().AssumeVerified(), ShouldBeFalse)
+			So(cfg.EmailClaimConfig().Required(), ShouldBeTrue)
+		})
+
+		Convey("should use configured scopes without PKCE", func() {
+			deps.ProviderConfig["scopes"] = []any{"openid", "email"}
+			deps.ProviderConfig["pkce"] = false
+			u, err := g.GetAuthorizationURL(ctx, deps, oauthrelyingparty.GetAuthorizationURLOptions{
+				RedirectURI:  "https://localhost/",
+				ResponseMode: oauthrelyingparty.ResponseModeFormPost,
+				Nonce:        "nonce",
+				State:        "state",
+			})
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, "https://localhost/authorize?client_id=client_id&nonce=nonce&redirect_uri=https%3A%2F%2Flocalhost%2F&response_mode=form_post&response_type=code&scope=openid+email&state=state")
+		})
+	})
+}
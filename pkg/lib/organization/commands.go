@@ -0,0 +1,281 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
+	"github.com/authgear/authgear-server/pkg/util/slice"
+)
+
+// CommandsStore is implemented by *Store.
+type CommandsStore interface {
+	NewOrganization(options *NewOrganizationOptions) *Organization
+	CreateOrganization(ctx context.Context, o *Organization) error
+	UpdateOrganization(ctx context.Context, options *UpdateOrganizationOptions) error
+	DeleteOrganization(ctx context.Context, id string) error
+	GetOrganizationByID(ctx context.Context, id string) (*Organization, error)
+
+	ListAllMemberUserIDsByOrganizationID(ctx context.Context, organizationID string) ([]string, error)
+	CreateMember(ctx context.Context, organizationID string, userID string) (*Member, error)
+	DeleteMember(ctx context.Context, memberID string) error
+	DeleteUserMembership(ctx context.Context, userID string) error
+	GetMember(ctx context.Context, organizationID string, userID string) (*Member, error)
+	ResetMemberRoles(ctx context.Context, m *Member, roleIDs []string) error
+
+	NewInvitation(options *NewInvitationOptions) *Invitation
+	CreateInvitation(ctx context.Context, i *Invitation) error
+	DeleteInvitation(ctx context.Context, id string) error
+	ListPendingInvitationsByEmails(ctx context.Context, emails []string) ([]*Invitation, error)
+	MarkInvitationAccepted(ctx context.Context, i *Invitation, userID string) error
+
+	ListRolesByKeys(ctx context.Context, roleKeys []string) (roleIDs []string, seenKeys []string, err error)
+}
+
+var _ CommandsStore = &Store{}
+
+type Commands struct {
+	Store CommandsStore
+}
+
+func (c *Commands) CreateOrganization(ctx context.Context, options *NewOrganizationOptions) (*model.Organization, error) {
+	err := rolesgroups.ValidateKey(ctx, options.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	o := c.Store.NewOrganization(options)
+	err = c.Store.CreateOrganization(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.ToModel(), nil
+}
+
+func (c *Commands) UpdateOrganization(ctx context.Context, options *UpdateOrganizationOptions) (*model.Organization, error) {
+	if options.RequireUpdate() {
+		if options.NewKey != nil {
+			err := rolesgroups.ValidateKey(ctx, *options.NewKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err := c.Store.UpdateOrganization(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	o, err := c.Store.GetOrganizationByID(ctx, options.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.ToModel(), nil
+}
+
+// DeleteOrganization returns the user IDs of the members of the deleted organization.
+func (c *Commands) DeleteOrganization(ctx context.Context, id string) (memberUserIDs []string, err error) {
+	memberUserIDs, err = c.Store.ListAllMemberUserIDsByOrganizationID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Store.DeleteOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return memberUserIDs, nil
+}
+
+func (c *Commands) AddMember(ctx context.Context, organizationID string, userID string, roleKeys []string) (*model.OrganizationMember, error) {
+	_, err := c.Store.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs, err := c.getRoleIDsByKeys(ctx, roleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := c.Store.CreateMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Store.ResetMemberRoles(ctx, m, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err = c.Store.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ToModel(), nil
+}
+
+func (c *Commands) RemoveMember(ctx context.Context, organizationID string, userID string) error {
+	m, err := c.Store.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Store.DeleteMember(ctx, m.ID)
+}
+
+func (c *Commands) UpdateMemberRoles(ctx context.Context, organizationID string, userID string, roleKeys []string) (*model.OrganizationMember, error) {
+	m, err := c.Store.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs, err := c.getRoleIDsByKeys(ctx, roleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.Store.ResetMemberRoles(ctx, m, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err = c.Store.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ToModel(), nil
+}
+
+func (c *Commands) DeleteUserMembership(ctx context.Context, userID string) error {
+	return c.Store.DeleteUserMembership(ctx, userID)
+}
+
+func (c *Commands) CreateInvitation(ctx context.Context, options *NewInvitationOptions) (*model.OrganizationInvitation, error) {
+	_, err := c.Store.GetOrganizationByID(ctx, options.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := normalizeInvitationEmail(ctx, options.Email)
+	if err != nil {
+		return nil, err
+	}
+	options.Email = email
+
+	// Fail early if any of the roles does not exist.
+	_, err = c.getRoleIDsByKeys(ctx, options.RoleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	i := c.Store.NewInvitation(options)
+	err = c.Store.CreateInvitation(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.ToModel(), nil
+}
+
+// getRoleIDsByKeys fails if any of the roles does not exist.
+func (c *Commands) getRoleIDsByKeys(ctx context.Context, roleKeys []string) ([]string, error) {
+	roleIDs, seenKeys, err := c.Store.ListRolesByKeys(ctx, roleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	missingKeys := slice.ExceptStrings(roleKeys, seenKeys)
+	if len(missingKeys) > 0 {
+		return nil, RoleUnknownKeys.NewWithInfo("unknown role keys", apierrors.Details{"keys": missingKeys})
+	}
+
+	return roleIDs, nil
+}
+
+func (c *Commands) DeleteInvitation(ctx context.Context, id string) error {
+	return c.Store.DeleteInvitation(ctx, id)
+}
+
+type AcceptedInvitation struct {
+	Organization *model.Organization
+	Invitation   *model.OrganizationInvitation
+	Member       *model.OrganizationMember
+}
+
+// AcceptPendingInvitations makes the user a member of the organizations
+// that have invited any of the given emails.
+// The caller is responsible for ensuring the emails belong to the user.
+func (c *Commands) AcceptPendingInvitations(ctx context.Context, userID string, emails []string) ([]*AcceptedInvitation, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	// Invitation emails are stored in lowercase.
+	lowercased := make([]string, len(emails))
+	for i, email := range emails {
+		lowercased[i] = strings.ToLower(strings.TrimSpace(email))
+	}
+
+	invitations, err := c.Store.ListPendingInvitationsByEmails(ctx, lowercased)
+	if err != nil {
+		return nil, err
+	}
+
+	var accepted []*AcceptedInvitation
+	for _, i := range invitations {
+		o, err := c.Store.GetOrganizationByID(ctx, i.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := c.Store.GetMember(ctx, i.OrganizationID, userID)
+		if errors.Is(err, ErrMemberNotFound) {
+			m, err = c.Store.CreateMember(ctx, i.OrganizationID, userID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Roles deleted after the invitation was created are ignored.
+			roleIDs, _, err := c.Store.ListRolesByKeys(ctx, i.RoleKeys)
+			if err != nil {
+				return nil, err
+			}
+
+			err = c.Store.ResetMemberRoles(ctx, m, roleIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			m, err = c.Store.GetMember(ctx, i.OrganizationID, userID)
+			if err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		// Otherwise the user is already a member, and the existing roles are kept.
+
+		err = c.Store.MarkInvitationAccepted(ctx, i, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		accepted = append(accepted, &AcceptedInvitation{
+			Organization: o.ToModel(),
+			Invitation:   i.ToModel(),
+			Member:       m.ToModel(),
+		})
+	}
+
+	return accepted, nil
+}
@@ -0,0 +1,383 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+type fakeCommandsStore struct {
+	now           time.Time
+	organizations map[string]*Organization
+	members       map[string]*Member
+	roleIDs       map[string]string
+	invitations   []*Invitation
+}
+
+func newFakeCommandsStore() *fakeCommandsStore {
+	return &fakeCommandsStore{
+		now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		organizations: map[string]*Organization{
+			"org-1": {ID: "org-1", Key: "acme"},
+		},
+		members: map[string]*Member{},
+		roleIDs: map[string]string{
+			"admin":  "role-admin",
+			"viewer": "role-viewer",
+		},
+	}
+}
+
+func (s *fakeCommandsStore) memberKey(organizationID string, userID string) string {
+	return organizationID + "/" + userID
+}
+
+func (s *fakeCommandsStore) NewOrganization(options *NewOrganizationOptions) *Organization {
+	return &Organization{ID: "org-" + options.Key, Key: options.Key, Name: options.Name}
+}
+
+func (s *fakeCommandsStore) CreateOrganization(ctx context.Context, o *Organization) error {
+	s.organizations[o.ID] = o
+	return nil
+}
+
+func (s *fakeCommandsStore) UpdateOrganization(ctx context.Context, options *UpdateOrganizationOptions) error {
+	return nil
+}
+
+func (s *fakeCommandsStore) DeleteOrganization(ctx context.Context, id string) error {
+	if _, ok := s.organizations[id]; !ok {
+		return ErrOrganizationNotFound
+	}
+	delete(s.organizations, id)
+	for key, m := range s.members {
+		if m.OrganizationID == id {
+			delete(s.members, key)
+		}
+	}
+	return nil
+}
+
+func (s *fakeCommandsStore) GetOrganizationByID(ctx context.Context, id string) (*Organization, error) {
+	o, ok := s.organizations[id]
+	if !ok {
+		return nil, ErrOrganizationNotFound
+	}
+	return o, nil
+}
+
+func (s *fakeCommandsStore) CreateMember(ctx context.Context, organizationID string, userID string) (*Member, error) {
+	key := s.memberKey(organizationID, userID)
+	if _, ok := s.members[key]; ok {
+		return nil, ErrMemberDuplicate
+	}
+	m := &Member{
+		ID:             fmt.Sprintf("member-%d", len(s.members)+1),
+		OrganizationID: organizationID,
+		UserID:         userID,
+	}
+	s.members[key] = m
+	return m, nil
+}
+
+func (s *fakeCommandsStore) ListAllMemberUserIDsByOrganizationID(ctx context.Context, organizationID string) ([]string, error) {
+	userIDs := []string{}
+	for _, m := range s.members {
+		if m.OrganizationID == organizationID {
+			userIDs = append(userIDs, m.UserID)
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+func (s *fakeCommandsStore) DeleteMember(ctx context.Context, memberID string) error {
+	for key, m := range s.members {
+		if m.ID == memberID {
+			delete(s.members, key)
+			return nil
+		}
+	}
+	return ErrMemberNotFound
+}
+
+func (s *fakeCommandsStore) DeleteUserMembership(ctx context.Context, userID string) error {
+	for key, m := range s.members {
+		if m.UserID == userID {
+			delete(s.members, key)
+		}
+	}
+	return nil
+}
+
+func (s *fakeCommandsStore) GetMember(ctx context.Context, organizationID string, userID string) (*Member, error) {
+	m, ok := s.members[s.memberKey(organizationID, userID)]
+	if !ok {
+		return nil, ErrMemberNotFound
+	}
+	copied := *m
+	return &copied, nil
+}
+
+func (s *fakeCommandsStore) ResetMemberRoles(ctx context.Context, m *Member, roleIDs []string) error {
+	var roleKeys []string
+	for _, roleID := range roleIDs {
+		for key, id := range s.roleIDs {
+			if id == roleID {
+				roleKeys = append(roleKeys, key)
+			}
+		}
+	}
+	sort.Strings(roleKeys)
+	s.members[s.memberKey(m.OrganizationID, m.UserID)].RoleKeys = roleKeys
+	return nil
+}
+
+func (s *fakeCommandsStore) NewInvitation(options *NewInvitationOptions) *Invitation {
+	return &Invitation{
+		ID:             fmt.Sprintf("invitation-%d", len(s.invitations)+1),
+		OrganizationID: options.OrganizationID,
+		Email:          options.Email,
+		RoleKeys:       options.RoleKeys,
+		ExpireAt:       s.now.Add(DefaultInvitationLifetime),
+	}
+}
+
+func (s *fakeCommandsStore) CreateInvitation(ctx context.Context, i *Invitation) error {
+	s.invitations = append(s.invitations, i)
+	return nil
+}
+
+func (s *fakeCommandsStore) DeleteInvitation(ctx context.Context, id string) error {
+	return nil
+}
+
+func (s *fakeCommandsStore) ListPendingInvitationsByEmails(ctx context.Context, emails []string) ([]*Invitation, error) {
+	var invitations []*Invitation
+	for _, i := range s.invitations {
+		if i.AcceptedAt != nil || !i.ExpireAt.After(s.now) {
+			continue
+		}
+		for _, email := range emails {
+			if i.Email == email {
+				invitations = append(invitations, i)
+			}
+		}
+	}
+	return invitations, nil
+}
+
+func (s *fakeCommandsStore) MarkInvitationAccepted(ctx context.Context, i *Invitation, userID string) error {
+	now := s.now
+	i.AcceptedAt = &now
+	i.AcceptedByUserID = &userID
+	return nil
+}
+
+func (s *fakeCommandsStore) ListRolesByKeys(ctx context.Context, roleKeys []string) (roleIDs []string, seenKeys []string, err error) {
+	for _, key := range roleKeys {
+		if id, ok := s.roleIDs[key]; ok {
+			roleIDs = append(roleIDs, id)
+			seenKeys = append(seenKeys, key)
+		}
+	}
+	return
+}
+
+func TestCommandsMembership(t *testing.T) {
+	Convey("Commands membership", t, func() {
+		ctx := context.Background()
+		store := newFakeCommandsStore()
+		c := &Commands{Store: store}
+
+		Convey("should add member with organization-scoped roles", func() {
+			m, err := c.AddMember(ctx, "org-1", "user-1", []string{"viewer", "admin"})
+			So(err, ShouldBeNil)
+			So(m.OrganizationID, ShouldEqual, "org-1")
+			So(m.UserID, ShouldEqual, "user-1")
+			So(m.RoleKeys, ShouldResemble, []string{"admin", "viewer"})
+		})
+
+		Convey("should add member without roles", func() {
+			m, err := c.AddMember(ctx, "org-1", "user-1", nil)
+			So(err, ShouldBeNil)
+			So(m.RoleKeys, ShouldResemble, []string{})
+		})
+
+		Convey("should not add member to unknown organization", func() {
+			_, err := c.AddMember(ctx, "org-unknown", "user-1", nil)
+			So(err, ShouldBeError, ErrOrganizationNotFound)
+			So(store.members, ShouldBeEmpty)
+		})
+
+		Convey("should not add member with unknown roles", func() {
+			_, err := c.AddMember(ctx, "org-1", "user-1", []string{"admin", "owner"})
+			So(apierrors.IsKind(err, RoleUnknownKeys), ShouldBeTrue)
+			So(apierrors.AsAPIErrorWithContext(ctx, err).Info_ReadOnly["keys"], ShouldResemble, []string{"owner"})
+			So(store.members, ShouldBeEmpty)
+		})
+
+		Convey("should not add member twice", func() {
+			_, err := c.AddMember(ctx, "org-1", "user-1", nil)
+			So(err, ShouldBeNil)
+			_, err = c.AddMember(ctx, "org-1", "user-1", nil)
+			So(err, ShouldBeError, ErrMemberDuplicate)
+		})
+
+		Convey("should update roles of member", func() {
+			_, err := c.AddMember(ctx, "org-1", "user-1", []string{"admin"})
+			So(err, ShouldBeNil)
+
+			m, err := c.UpdateMemberRoles(ctx, "org-1", "user-1", []string{"viewer"})
+			So(err, ShouldBeNil)
+			So(m.RoleKeys, ShouldResemble, []string{"viewer"})
+
+			_, err = c.UpdateMemberRoles(ctx, "org-1", "user-1", []string{"owner"})
+			So(apierrors.IsKind(err, RoleUnknownKeys), ShouldBeTrue)
+			m2, err := c.Store.GetMember(ctx, "org-1", "user-1")
+			So(err, ShouldBeNil)
+			So(m2.RoleKeys, ShouldResemble, []string{"viewer"})
+		})
+
+		Convey("should not update roles of non-member", func() {
+			_, err := c.UpdateMemberRoles(ctx, "org-1", "user-1", []string{"viewer"})
+			So(err, ShouldBeError, ErrMemberNotFound)
+		})
+
+		Convey("should remove member", func() {
+			_, err := c.AddMember(ctx, "org-1", "user-1", nil)
+			So(err, ShouldBeNil)
+
+			err = c.RemoveMember(ctx, "org-1", "user-1")
+			So(err, ShouldBeNil)
+			So(store.members, ShouldBeEmpty)
+
+			err = c.RemoveMember(ctx, "org-1", "user-1")
+			So(err, ShouldBeError, ErrMemberNotFound)
+		})
+
+		Convey("should return the members of the deleted organization", func() {
+			_, err := c.AddMember(ctx, "org-1", "user-2", nil)
+			So(err, ShouldBeNil)
+			_, err = c.AddMember(ctx, "org-1", "user-1", nil)
+			So(err, ShouldBeNil)
+
+			userIDs, err := c.DeleteOrganization(ctx, "org-1")
+			So(err, ShouldBeNil)
+			So(userIDs, ShouldResemble, []string{"user-1", "user-2"})
+			So(store.members, ShouldBeEmpty)
+
+			_, err = c.DeleteOrganization(ctx, "org-1")
+			So(err, ShouldBeError, ErrOrganizationNotFound)
+		})
+	})
+}
+
+func TestCommandsInvitation(t *testing.T) {
+	Convey("Commands invitation", t, func() {
+		ctx := context.Background()
+		store := newFakeCommandsStore()
+		c := &Commands{Store: store}
+
+		Convey("should create invitation with normalized email", func() {
+			i, err := c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          " User@Example.com ",
+				RoleKeys:       []string{"viewer"},
+			})
+			So(err, ShouldBeNil)
+			So(i.Email, ShouldEqual, "user@example.com")
+			So(i.RoleKeys, ShouldResemble, []string{"viewer"})
+		})
+
+		Convey("should not create invitation with unknown roles", func() {
+			_, err := c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          "user@example.com",
+				RoleKeys:       []string{"owner"},
+			})
+			So(apierrors.IsKind(err, RoleUnknownKeys), ShouldBeTrue)
+			So(store.invitations, ShouldBeEmpty)
+		})
+
+		Convey("should not create invitation to unknown organization", func() {
+			_, err := c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-unknown",
+				Email:          "user@example.com",
+			})
+			So(err, ShouldBeError, ErrOrganizationNotFound)
+		})
+
+		Convey("should do nothing without emails", func() {
+			accepted, err := c.AcceptPendingInvitations(ctx, "user-1", nil)
+			So(err, ShouldBeNil)
+			So(accepted, ShouldBeEmpty)
+		})
+
+		Convey("should make the user a member with the roles of the invitation", func() {
+			_, err := c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          "user@example.com",
+				RoleKeys:       []string{"admin", "viewer"},
+			})
+			So(err, ShouldBeNil)
+
+			// The role is deleted after the invitation was created.
+			delete(store.roleIDs, "viewer")
+
+			accepted, err := c.AcceptPendingInvitations(ctx, "user-1", []string{"User@Example.com"})
+			So(err, ShouldBeNil)
+			So(accepted, ShouldHaveLength, 1)
+			So(accepted[0].Organization.ID, ShouldEqual, "org-1")
+			So(accepted[0].Member.UserID, ShouldEqual, "user-1")
+			So(accepted[0].Member.RoleKeys, ShouldResemble, []string{"admin"})
+			So(*accepted[0].Invitation.AcceptedByUserID, ShouldEqual, "user-1")
+
+			accepted, err = c.AcceptPendingInvitations(ctx, "user-1", []string{"user@example.com"})
+			So(err, ShouldBeNil)
+			So(accepted, ShouldBeEmpty)
+		})
+
+		Convey("should keep the roles of existing member", func() {
+			_, err := c.AddMember(ctx, "org-1", "user-1", []string{"viewer"})
+			So(err, ShouldBeNil)
+			_, err = c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          "user@example.com",
+				RoleKeys:       []string{"admin"},
+			})
+			So(err, ShouldBeNil)
+
+			accepted, err := c.AcceptPendingInvitations(ctx, "user-1", []string{"user@example.com"})
+			So(err, ShouldBeNil)
+			So(accepted, ShouldHaveLength, 1)
+			So(accepted[0].Member.RoleKeys, ShouldResemble, []string{"viewer"})
+			So(accepted[0].Invitation.AcceptedAt, ShouldNotBeNil)
+		})
+
+		Convey("should ignore invitations to other emails and expired invitations", func() {
+			_, err := c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          "other@example.com",
+			})
+			So(err, ShouldBeNil)
+			_, err = c.CreateInvitation(ctx, &NewInvitationOptions{
+				OrganizationID: "org-1",
+				Email:          "user@example.com",
+			})
+			So(err, ShouldBeNil)
+			store.now = store.now.Add(DefaultInvitationLifetime)
+
+			accepted, err := c.AcceptPendingInvitations(ctx, "user-1", []string{"user@example.com"})
+			So(err, ShouldBeNil)
+			So(accepted, ShouldBeEmpty)
+			So(store.members, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,13 @@
+package organization
+
+import (
+	"github.com/google/wire"
+)
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(Store), "*"),
+	wire.Struct(new(Queries), "*"),
+	wire.Struct(new(Commands), "*"),
+	wire.Struct(new(InvitationMessageSender), "*"),
+	wire.Bind(new(CommandsStore), new(*Store)),
+)
@@ -0,0 +1,26 @@
+package organization
+
+import (
+	"context"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+var InvitationEmailSchema = validation.NewSimpleSchema(`
+	{
+		"type": "string",
+		"format": "email"
+	}
+`)
+
+// normalizeInvitationEmail validates the email and lowercases it,
+// so that invitations can be matched against the emails of a user.
+func normalizeInvitationEmail(ctx context.Context, email string) (string, error) {
+	email = strings.TrimSpace(email)
+	err := InvitationEmailSchema.Validator().ValidateValue(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(email), nil
+}
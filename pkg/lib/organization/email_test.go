@@ -0,0 +1,27 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNormalizeInvitationEmail(t *testing.T) {
+	ctx := context.Background()
+	Convey("normalizeInvitationEmail", t, func() {
+		email, err := normalizeInvitationEmail(ctx, "user@example.com")
+		So(err, ShouldBeNil)
+		So(email, ShouldEqual, "user@example.com")
+
+		email, err = normalizeInvitationEmail(ctx, "  User@Example.COM ")
+		So(err, ShouldBeNil)
+		So(email, ShouldEqual, "user@example.com")
+
+		_, err = normalizeInvitationEmail(ctx, "")
+		So(err, ShouldNotBeNil)
+
+		_, err = normalizeInvitationEmail(ctx, "not an email")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,19 @@
+package organization
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+var ErrOrganizationNotFound = apierrors.NotFound.WithReason("OrganizationNotFound").New("organization not found")
+
+var ErrOrganizationDuplicateKey = apierrors.BadRequest.WithReason("OrganizationDuplicateKey").New("duplicate organization key")
+
+var ErrMemberNotFound = apierrors.NotFound.WithReason("OrganizationMemberNotFound").New("organization member not found")
+
+var ErrMemberDuplicate = apierrors.BadRequest.WithReason("OrganizationMemberDuplicate").New("user is already a member of the organization")
+
+var ErrInvitationNotFound = apierrors.NotFound.WithReason("OrganizationInvitationNotFound").New("organization invitation not found")
+
+var ErrSelectOrganizationNotMember = apierrors.Forbidden.WithReason("OrganizationNotMember").New("user is not a member of the organization")
+
+var RoleUnknownKeys = apierrors.NotFound.WithReason("RoleUnknownKeys")
@@ -0,0 +1,114 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+type NewOrganizationOptions struct {
+	Key         string
+	Name        *string
+	Description *string
+}
+
+type UpdateOrganizationOptions struct {
+	ID             string
+	NewKey         *string
+	NewName        *string
+	NewDescription *string
+}
+
+func (o *UpdateOrganizationOptions) RequireUpdate() bool {
+	return o.NewKey != nil || o.NewName != nil || o.NewDescription != nil
+}
+
+type Organization struct {
+	ID          string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Key         string
+	Name        *string
+	Description *string
+}
+
+func (o *Organization) ToModel() *model.Organization {
+	return &model.Organization{
+		Meta: model.Meta{
+			ID:        o.ID,
+			CreatedAt: o.CreatedAt,
+			UpdatedAt: o.UpdatedAt,
+		},
+		Key:         o.Key,
+		Name:        o.Name,
+		Description: o.Description,
+	}
+}
+
+type Member struct {
+	ID             string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID string
+	UserID         string
+	RoleKeys       []string
+}
+
+func (m *Member) ToModel() *model.OrganizationMember {
+	roleKeys := m.RoleKeys
+	if roleKeys == nil {
+		roleKeys = []string{}
+	}
+	return &model.OrganizationMember{
+		Meta: model.Meta{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		RoleKeys:       roleKeys,
+	}
+}
+
+// DefaultInvitationLifetime is used when NewInvitationOptions.ExpireAt is zero.
+const DefaultInvitationLifetime = 7 * 24 * time.Hour
+
+type NewInvitationOptions struct {
+	OrganizationID string
+	Email          string
+	RoleKeys       []string
+	ExpireAt       time.Time
+}
+
+type Invitation struct {
+	ID               string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	OrganizationID   string
+	Email            string
+	RoleKeys         []string
+	ExpireAt         time.Time
+	AcceptedAt       *time.Time
+	AcceptedByUserID *string
+}
+
+func (i *Invitation) ToModel() *model.OrganizationInvitation {
+	roleKeys := i.RoleKeys
+	if roleKeys == nil {
+		roleKeys = []string{}
+	}
+	return &model.OrganizationInvitation{
+		Meta: model.Meta{
+			ID:        i.ID,
+			CreatedAt: i.CreatedAt,
+			UpdatedAt: i.UpdatedAt,
+		},
+		OrganizationID:   i.OrganizationID,
+		Email:            i.Email,
+		RoleKeys:         roleKeys,
+		ExpireAt:         i.ExpireAt,
+		AcceptedAt:       i.AcceptedAt,
+		AcceptedByUserID: i.AcceptedByUserID,
+	}
+}
@@ -0,0 +1,149 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type Queries struct {
+	Store *Store
+}
+
+func (q *Queries) GetOrganization(ctx context.Context, id string) (*model.Organization, error) {
+	o, err := q.Store.GetOrganizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return o.ToModel(), nil
+}
+
+func (q *Queries) GetManyOrganizations(ctx context.Context, ids []string) ([]*model.Organization, error) {
+	organizations, err := q.Store.GetManyOrganizations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*model.Organization, len(organizations))
+	for i, o := range organizations {
+		models[i] = o.ToModel()
+	}
+
+	return models, nil
+}
+
+type ListOrganizationsOptions struct {
+	SearchKeyword string
+}
+
+func (q *Queries) ListOrganizations(ctx context.Context, options *ListOrganizationsOptions, pageArgs graphqlutil.PageArgs) ([]model.PageItemRef, error) {
+	organizations, offset, err := q.Store.ListOrganizations(ctx, options, pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]model.PageItemRef, len(organizations))
+	for i, o := range organizations {
+		//nolint:gosec // G115
+		i_uint64 := uint64(i)
+		pageKey := db.PageKey{Offset: offset + i_uint64}
+		cursor, err := pageKey.ToPageCursor()
+		if err != nil {
+			return nil, err
+		}
+
+		models[i] = model.PageItemRef{ID: o.ID, Cursor: cursor}
+	}
+	return models, nil
+}
+
+func (q *Queries) CountOrganizations(ctx context.Context) (uint64, error) {
+	return q.Store.CountOrganizations(ctx)
+}
+
+func (q *Queries) ListOrganizationsByUserID(ctx context.Context, userID string) ([]*model.Organization, error) {
+	organizations, err := q.Store.ListOrganizationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*model.Organization, len(organizations))
+	for i, o := range organizations {
+		models[i] = o.ToModel()
+	}
+
+	return models, nil
+}
+
+func (q *Queries) GetMember(ctx context.Context, organizationID string, userID string) (*model.OrganizationMember, error) {
+	m, err := q.Store.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return m.ToModel(), nil
+}
+
+func (q *Queries) ListMembers(ctx context.Context, organizationID string, pageArgs graphqlutil.PageArgs) ([]*model.OrganizationMember, []model.PageCursor, error) {
+	members, offset, err := q.Store.ListMembersByOrganizationID(ctx, organizationID, pageArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	models := make([]*model.OrganizationMember, len(members))
+	cursors := make([]model.PageCursor, len(members))
+	for i, m := range members {
+		//nolint:gosec // G115
+		i_uint64 := uint64(i)
+		pageKey := db.PageKey{Offset: offset + i_uint64}
+		cursor, err := pageKey.ToPageCursor()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		models[i] = m.ToModel()
+		cursors[i] = cursor
+	}
+	return models, cursors, nil
+}
+
+func (q *Queries) CountMembers(ctx context.Context, organizationID string) (uint64, error) {
+	return q.Store.CountMembersByOrganizationID(ctx, organizationID)
+}
+
+func (q *Queries) ListMembersByUserID(ctx context.Context, userID string) ([]*model.OrganizationMember, error) {
+	members, err := q.Store.ListMembersByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*model.OrganizationMember, len(members))
+	for i, m := range members {
+		models[i] = m.ToModel()
+	}
+
+	return models, nil
+}
+
+func (q *Queries) GetInvitation(ctx context.Context, id string) (*model.OrganizationInvitation, error) {
+	i, err := q.Store.GetInvitationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return i.ToModel(), nil
+}
+
+func (q *Queries) ListInvitations(ctx context.Context, organizationID string) ([]*model.OrganizationInvitation, error) {
+	invitations, err := q.Store.ListInvitationsByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]*model.OrganizationInvitation, len(invitations))
+	for i, inv := range invitations {
+		models[i] = inv.ToModel()
+	}
+
+	return models, nil
+}
@@ -0,0 +1,57 @@
+package organization
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/translation"
+)
+
+type InvitationMessageTranslationService interface {
+	EmailMessageData(ctx context.Context, msg *translation.MessageSpec, variables *translation.PartialTemplateVariables) (*translation.EmailMessageData, error)
+}
+
+type InvitationMessageSenderService interface {
+	SendEmailAsync(ctx context.Context, msgType translation.MessageType, opts *mail.SendOptions) error
+}
+
+type InvitationMessageEndpoints interface {
+	SignupEndpointURL() *url.URL
+}
+
+// InvitationMessageSender sends the invitation email to the invited email.
+// The email links to the signup page,
+// where the invitee can sign up or log in with the invited email.
+type InvitationMessageSender struct {
+	Translation InvitationMessageTranslationService
+	Sender      InvitationMessageSenderService
+	Endpoints   InvitationMessageEndpoints
+}
+
+func (s *InvitationMessageSender) Send(ctx context.Context, o *model.Organization, i *model.OrganizationInvitation) error {
+	organizationName := o.Key
+	if o.Name != nil && *o.Name != "" {
+		organizationName = *o.Name
+	}
+
+	spec := translation.MessageOrganizationInvitation
+	data, err := s.Translation.EmailMessageData(ctx, spec, &translation.PartialTemplateVariables{
+		Email:            i.Email,
+		Link:             s.Endpoints.SignupEndpointURL().String(),
+		OrganizationName: organizationName,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.Sender.SendEmailAsync(ctx, spec.MessageType, &mail.SendOptions{
+		Sender:    data.Sender,
+		ReplyTo:   data.ReplyTo,
+		Subject:   data.Subject,
+		Recipient: i.Email,
+		TextBody:  data.TextBody.String,
+		HTMLBody:  data.HTMLBody.String,
+	})
+}
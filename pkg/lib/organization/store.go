@@ -0,0 +1,12 @@
+package organization
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/infra/db/appdb"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type Store struct {
+	SQLBuilder  *appdb.SQLBuilderApp
+	SQLExecutor *appdb.SQLExecutor
+	Clock       clock.Clock
+}
@@ -0,0 +1,218 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+func (s *Store) NewInvitation(options *NewInvitationOptions) *Invitation {
+	now := s.Clock.NowUTC()
+	expireAt := options.ExpireAt
+	if expireAt.IsZero() {
+		expireAt = now.Add(DefaultInvitationLifetime)
+	}
+	return &Invitation{
+		ID:             uuid.New(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		OrganizationID: options.OrganizationID,
+		Email:          options.Email,
+		RoleKeys:       options.RoleKeys,
+		ExpireAt:       expireAt,
+	}
+}
+
+func (s *Store) CreateInvitation(ctx context.Context, i *Invitation) error {
+	roleKeys := i.RoleKeys
+	if roleKeys == nil {
+		roleKeys = []string{}
+	}
+	roleKeysBytes, err := json.Marshal(roleKeys)
+	if err != nil {
+		return err
+	}
+
+	q := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName("_auth_organization_invitation")).
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"organization_id",
+			"email",
+			"role_keys",
+			"expire_at",
+		).
+		Values(
+			i.ID,
+			i.CreatedAt,
+			i.UpdatedAt,
+			i.OrganizationID,
+			i.Email,
+			roleKeysBytes,
+			i.ExpireAt,
+		)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) DeleteInvitation(ctx context.Context, id string) error {
+	q := s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_invitation")).
+		Where("id = ?", id)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) MarkInvitationAccepted(ctx context.Context, i *Invitation, userID string) error {
+	now := s.Clock.NowUTC()
+
+	q := s.SQLBuilder.Update(s.SQLBuilder.TableName("_auth_organization_invitation")).
+		Set("updated_at", now).
+		Set("accepted_at", now).
+		Set("accepted_by_user_id", userID).
+		Where("id = ? AND accepted_at IS NULL", i.ID)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return ErrInvitationNotFound
+	}
+
+	i.UpdatedAt = now
+	i.AcceptedAt = &now
+	i.AcceptedByUserID = &userID
+	return nil
+}
+
+func (s *Store) GetInvitationByID(ctx context.Context, id string) (*Invitation, error) {
+	q := s.selectInvitationQuery().Where("id = ?", id)
+
+	row, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	i, err := s.scanInvitation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func (s *Store) ListInvitationsByOrganizationID(ctx context.Context, organizationID string) ([]*Invitation, error) {
+	q := s.selectInvitationQuery().
+		Where("organization_id = ?", organizationID).
+		OrderBy("created_at DESC")
+
+	return s.queryInvitations(ctx, q)
+}
+
+// ListPendingInvitationsByEmails lists the invitations to the emails that are neither accepted nor expired.
+func (s *Store) ListPendingInvitationsByEmails(ctx context.Context, emails []string) ([]*Invitation, error) {
+	now := s.Clock.NowUTC()
+	q := s.selectInvitationQuery().
+		Where("email = ANY (?) AND accepted_at IS NULL AND expire_at > ?", pq.Array(emails), now).
+		OrderBy("created_at ASC")
+
+	return s.queryInvitations(ctx, q)
+}
+
+func (s *Store) selectInvitationQuery() db.SelectBuilder {
+	return s.SQLBuilder.
+		Select(
+			"id",
+			"created_at",
+			"updated_at",
+			"organization_id",
+			"email",
+			"role_keys",
+			"expire_at",
+			"accepted_at",
+			"accepted_by_user_id",
+		).
+		From(s.SQLBuilder.TableName("_auth_organization_invitation"))
+}
+
+func (s *Store) scanInvitation(scanner db.Scanner) (*Invitation, error) {
+	i := &Invitation{}
+	var roleKeysBytes []byte
+
+	err := scanner.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+		&i.Email,
+		&roleKeysBytes,
+		&i.ExpireAt,
+		&i.AcceptedAt,
+		&i.AcceptedByUserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(roleKeysBytes, &i.RoleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func (s *Store) queryInvitations(ctx context.Context, q db.SelectBuilder) ([]*Invitation, error) {
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*Invitation
+	for rows.Next() {
+		i, err := s.scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, i)
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,397 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	databaseutil "github.com/authgear/authgear-server/pkg/util/databaseutil"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+	"github.com/authgear/authgear-server/pkg/util/slice"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+func (s *Store) CreateMember(ctx context.Context, organizationID string, userID string) (*Member, error) {
+	err := s.checkUserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Clock.NowUTC()
+	m := &Member{
+		ID:             uuid.New(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		OrganizationID: organizationID,
+		UserID:         userID,
+	}
+
+	q := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName("_auth_organization_member")).
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"organization_id",
+			"user_id",
+		).
+		Values(
+			m.ID,
+			m.CreatedAt,
+			m.UpdatedAt,
+			m.OrganizationID,
+			m.UserID,
+		)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		if databaseutil.IsDuplicateKeyError(err) {
+			return nil, ErrMemberDuplicate
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *Store) DeleteMember(ctx context.Context, memberID string) error {
+	q := s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member_role")).
+		Where("member_id = ?", memberID)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member")).
+		Where("id = ?", memberID)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) DeleteUserMembership(ctx context.Context, userID string) error {
+	q := s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member_role")).
+		Where("member_id IN (SELECT id FROM "+s.SQLBuilder.TableName("_auth_organization_member")+" WHERE user_id = ?)", userID)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member")).
+		Where("user_id = ?", userID)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) GetMember(ctx context.Context, organizationID string, userID string) (*Member, error) {
+	q := s.selectMemberQuery().
+		Where("organization_id = ? AND user_id = ?", organizationID, userID)
+
+	row, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.scanMember(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+
+	err = s.populateRoleKeys(ctx, []*Member{m})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *Store) ListMembersByOrganizationID(ctx context.Context, organizationID string, pageArgs graphqlutil.PageArgs) ([]*Member, uint64, error) {
+	q := s.selectMemberQuery().
+		Where("organization_id = ?", organizationID).
+		// Sort by created_at and id to ensure we have a stable order.
+		OrderBy("created_at ASC", "id ASC")
+
+	q, offset, err := db.ApplyPageArgs(q, pageArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	members, err := s.queryMembers(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = s.populateRoleKeys(ctx, members)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return members, offset, nil
+}
+
+func (s *Store) CountMembersByOrganizationID(ctx context.Context, organizationID string) (uint64, error) {
+	builder := s.SQLBuilder.
+		Select("count(*)").
+		From(s.SQLBuilder.TableName("_auth_organization_member")).
+		Where("organization_id = ?", organizationID)
+	scanner, err := s.SQLExecutor.QueryRowWith(ctx, builder)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err = scanner.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *Store) ListAllMemberUserIDsByOrganizationID(ctx context.Context, organizationID string) ([]string, error) {
+	q := s.SQLBuilder.Select("user_id").
+		From(s.SQLBuilder.TableName("_auth_organization_member")).
+		Where("organization_id = ?", organizationID)
+
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := []string{}
+	for rows.Next() {
+		var userID string
+		err := rows.Scan(&userID)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+func (s *Store) ListMembersByUserID(ctx context.Context, userID string) ([]*Member, error) {
+	q := s.selectMemberQuery().
+		Where("user_id = ?", userID).
+		OrderBy("created_at ASC", "id ASC")
+
+	members, err := s.queryMembers(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.populateRoleKeys(ctx, members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *Store) ResetMemberRoles(ctx context.Context, m *Member, roleIDs []string) error {
+	q := s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member_role")).
+		Where("member_id = ?", m.ID)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	now := s.Clock.NowUTC()
+	for _, roleID := range roleIDs {
+		q := s.SQLBuilder.
+			Insert(s.SQLBuilder.TableName("_auth_organization_member_role")).
+			Columns(
+				"id",
+				"created_at",
+				"updated_at",
+				"member_id",
+				"role_id",
+			).
+			Values(
+				uuid.New(),
+				now,
+				now,
+				m.ID,
+				roleID,
+			).Suffix("ON CONFLICT DO NOTHING")
+
+		_, err := s.SQLExecutor.ExecWith(ctx, q)
+		if err != nil {
+			return err
+		}
+	}
+
+	updateQ := s.SQLBuilder.Update(s.SQLBuilder.TableName("_auth_organization_member")).
+		Set("updated_at", now).
+		Where("id = ?", m.ID)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, updateQ)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListRolesByKeys returns the IDs and the keys of the existing roles among roleKeys.
+func (s *Store) ListRolesByKeys(ctx context.Context, roleKeys []string) (roleIDs []string, seenKeys []string, err error) {
+	if len(roleKeys) == 0 {
+		return nil, nil, nil
+	}
+
+	q := s.SQLBuilder.Select("id", "key").
+		From(s.SQLBuilder.TableName("_auth_role")).
+		Where("key = ANY (?)", pq.Array(roleKeys))
+
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, key string
+		err := rows.Scan(&id, &key)
+		if err != nil {
+			return nil, nil, err
+		}
+		roleIDs = append(roleIDs, id)
+		seenKeys = append(seenKeys, key)
+	}
+
+	return roleIDs, seenKeys, nil
+}
+
+func (s *Store) populateRoleKeys(ctx context.Context, members []*Member) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	memberIDs := slice.Map(members, func(m *Member) string { return m.ID })
+	q := s.SQLBuilder.Select("mr.member_id", "r.key").
+		From(s.SQLBuilder.TableName("_auth_organization_member_role"), "mr").
+		Join(s.SQLBuilder.TableName("_auth_role"), "r", "mr.role_id = r.id").
+		Where("mr.member_id = ANY (?)", pq.Array(memberIDs)).
+		OrderBy("r.key ASC")
+
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	roleKeysByMemberID := make(map[string][]string)
+	for rows.Next() {
+		var memberID, key string
+		err := rows.Scan(&memberID, &key)
+		if err != nil {
+			return err
+		}
+		roleKeysByMemberID[memberID] = append(roleKeysByMemberID[memberID], key)
+	}
+
+	for _, m := range members {
+		m.RoleKeys = roleKeysByMemberID[m.ID]
+	}
+
+	return nil
+}
+
+func (s *Store) checkUserExists(ctx context.Context, userID string) error {
+	q := s.SQLBuilder.Select("id").
+		From(s.SQLBuilder.TableName("_auth_user")).
+		Where("id = ?", userID)
+
+	row, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	err = row.Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return api.ErrUserNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) selectMemberQuery() db.SelectBuilder {
+	return s.SQLBuilder.
+		Select(
+			"id",
+			"created_at",
+			"updated_at",
+			"organization_id",
+			"user_id",
+		).
+		From(s.SQLBuilder.TableName("_auth_organization_member"))
+}
+
+func (s *Store) scanMember(scanner db.Scanner) (*Member, error) {
+	m := &Member{}
+
+	err := scanner.Scan(
+		&m.ID,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.OrganizationID,
+		&m.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (s *Store) queryMembers(ctx context.Context, q db.SelectBuilder) ([]*Member, error) {
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*Member
+	for rows.Next() {
+		m, err := s.scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,279 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	databaseutil "github.com/authgear/authgear-server/pkg/util/databaseutil"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+func (s *Store) NewOrganization(options *NewOrganizationOptions) *Organization {
+	now := s.Clock.NowUTC()
+	return &Organization{
+		ID:          uuid.New(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Key:         options.Key,
+		Name:        options.Name,
+		Description: options.Description,
+	}
+}
+
+func (s *Store) CreateOrganization(ctx context.Context, o *Organization) error {
+	q := s.SQLBuilder.
+		Insert(s.SQLBuilder.TableName("_auth_organization")).
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"key",
+			"name",
+			"description",
+		).
+		Values(
+			o.ID,
+			o.CreatedAt,
+			o.UpdatedAt,
+			o.Key,
+			o.Name,
+			o.Description,
+		)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		if databaseutil.IsDuplicateKeyError(err) {
+			return ErrOrganizationDuplicateKey
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) UpdateOrganization(ctx context.Context, options *UpdateOrganizationOptions) error {
+	now := s.Clock.NowUTC()
+
+	q := s.SQLBuilder.Update(s.SQLBuilder.TableName("_auth_organization")).
+		Set("updated_at", now).
+		Where("id = ?", options.ID)
+
+	if options.NewKey != nil {
+		q = q.Set("key", *options.NewKey)
+	}
+
+	if options.NewName != nil {
+		if *options.NewName == "" {
+			q = q.Set("name", nil)
+		} else {
+			q = q.Set("name", *options.NewName)
+		}
+	}
+
+	if options.NewDescription != nil {
+		if *options.NewDescription == "" {
+			q = q.Set("description", nil)
+		} else {
+			q = q.Set("description", *options.NewDescription)
+		}
+	}
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		if databaseutil.IsDuplicateKeyError(err) {
+			return ErrOrganizationDuplicateKey
+		}
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) DeleteOrganization(ctx context.Context, id string) error {
+	q := s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member_role")).
+		Where("member_id IN (SELECT id FROM "+s.SQLBuilder.TableName("_auth_organization_member")+" WHERE organization_id = ?)", id)
+
+	_, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member")).
+		Where("organization_id = ?", id)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_invitation")).
+		Where("organization_id = ?", id)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization")).
+		Where("id = ?", id)
+
+	result, err := s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+func (s *Store) GetOrganizationByID(ctx context.Context, id string) (*Organization, error) {
+	q := s.selectOrganizationQuery().Where("id = ?", id)
+
+	row, err := s.SQLExecutor.QueryRowWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := s.scanOrganization(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (s *Store) GetManyOrganizations(ctx context.Context, ids []string) ([]*Organization, error) {
+	q := s.selectOrganizationQuery().Where("id = ANY (?)", pq.Array(ids))
+	return s.queryOrganizations(ctx, q)
+}
+
+func (s *Store) CountOrganizations(ctx context.Context) (uint64, error) {
+	builder := s.SQLBuilder.
+		Select("count(*)").
+		From(s.SQLBuilder.TableName("_auth_organization"))
+	scanner, err := s.SQLExecutor.QueryRowWith(ctx, builder)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err = scanner.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *Store) ListOrganizations(ctx context.Context, options *ListOrganizationsOptions, pageArgs graphqlutil.PageArgs) ([]*Organization, uint64, error) {
+	q := s.selectOrganizationQuery().
+		// Sort by key to ensure we have a stable order.
+		OrderBy("key ASC")
+
+	if options.SearchKeyword != "" {
+		q = q.Where("(key ILIKE ('%' || ? || '%') OR name ILIKE ('%' || ? || '%'))", options.SearchKeyword, options.SearchKeyword)
+	}
+
+	q, offset, err := db.ApplyPageArgs(q, pageArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	organizations, err := s.queryOrganizations(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return organizations, offset, nil
+}
+
+func (s *Store) ListOrganizationsByUserID(ctx context.Context, userID string) ([]*Organization, error) {
+	q := s.SQLBuilder.Select(
+		"o.id",
+		"o.created_at",
+		"o.updated_at",
+		"o.key",
+		"o.name",
+		"o.description",
+	).
+		From(s.SQLBuilder.TableName("_auth_organization_member"), "m").
+		Join(s.SQLBuilder.TableName("_auth_organization"), "o", "m.organization_id = o.id").
+		Where("m.user_id = ?", userID).
+		OrderBy("o.key ASC")
+
+	return s.queryOrganizations(ctx, q)
+}
+
+func (s *Store) selectOrganizationQuery() db.SelectBuilder {
+	return s.SQLBuilder.
+		Select(
+			"id",
+			"created_at",
+			"updated_at",
+			"key",
+			"name",
+			"description",
+		).
+		From(s.SQLBuilder.TableName("_auth_organization"))
+}
+
+func (s *Store) scanOrganization(scanner db.Scanner) (*Organization, error) {
+	o := &Organization{}
+
+	err := scanner.Scan(
+		&o.ID,
+		&o.CreatedAt,
+		&o.UpdatedAt,
+		&o.Key,
+		&o.Name,
+		&o.Description,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (s *Store) queryOrganizations(ctx context.Context, q db.SelectBuilder) ([]*Organization, error) {
+	rows, err := s.SQLExecutor.QueryWith(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var organizations []*Organization
+	for rows.Next() {
+		o, err := s.scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, o)
+	}
+
+	return organizations, nil
+}
@@ -45,6 +45,16 @@ const (
 	// Token endpoint rate limits
 	RateLimitGroupOAuthTokenGeneral           RateLimitGroup = "oauth.token.general"            // #nosec G101
 	RateLimitGroupOAuthTokenClientCredentials RateLimitGroup = "oauth.token.client_credentials" // #nosec G101
+
+	// Device authorization grant rate limits
+	RateLimitGroupOAuthDeviceAuthorization RateLimitGroup = "oauth.device_authorization"
+	RateLimitGroupOAuthDeviceVerification  RateLimitGroup = "oauth.device_verification"
+
+	// Backchannel authentication rate limits
+	RateLimitGroupOAuthBackchannelAuthentication RateLimitGroup = "oauth.backchannel_authentication"
+
+	// Pushed authorization request rate limits
+	RateLimitGroupOAuthPushedAuthorization RateLimitGroup = "oauth.pushed_authorization"
 )
 
 const (
@@ -108,6 +118,16 @@ const (
 	// OAuth Token
 	RateLimitOAuthTokenGeneralPerIP   RateLimitName = "oauth.token.general.per_ip"   // #nosec G101
 	RateLimitOAuthTokenGeneralPerUser RateLimitName = "oauth.token.general.per_user" // #nosec G101
+
+	// OAuth Device Authorization
+	RateLimitOAuthDeviceAuthorizationPerIP RateLimitName = "oauth.device_authorization.per_ip"
+	RateLimitOAuthDeviceVerificationPerIP  RateLimitName = "oauth.device_verification.per_ip"
+
+	// OAuth Backchannel Authentication
+	RateLimitOAuthBackchannelAuthenticationPerIP RateLimitName = "oauth.backchannel_authentication.per_ip"
+
+	// OAuth Pushed Authorization Request
+	RateLimitOAuthPushedAuthorizationPerIP RateLimitName = "oauth.pushed_authorization.per_ip"
 )
 
 const (
@@ -190,6 +210,13 @@ const (
 	OAuthTokenPerUser                     BucketName = "OAuthTokenPerUser" // #nosec G101
 	OAuthTokenClientCredentialsPerClient  BucketName = "OAuthTokenClientCredentialsPerClient"
 	OAuthTokenClientCredentialsPerProject BucketName = "OAuthTokenClientCredentialsPerProject"
+
+	OAuthDeviceAuthorizationPerIP BucketName = "OAuthDeviceAuthorizationPerIP"
+	OAuthDeviceVerificationPerIP  BucketName = "OAuthDeviceVerificationPerIP"
+
+	OAuthBackchannelAuthenticationPerIP BucketName = "OAuthBackchannelAuthenticationPerIP"
+
+	OAuthPushedAuthorizationPerIP BucketName = "OAuthPushedAuthorizationPerIP"
 )
 
 func (n RateLimitGroup) resolvePerIP(cfg *config.AppConfig, featureCfg *config.FeatureConfig) *config.RateLimitConfig {
@@ -607,6 +634,14 @@ func (r RateLimitGroup) ResolveBucketSpecs(
 
 	case RateLimitGroupOAuthTokenGeneral:
 		panic(fmt.Errorf("ResolveBucketSpecs not supported for %s", RateLimitGroupOAuthTokenGeneral))
+	case RateLimitGroupOAuthDeviceAuthorization:
+		panic(fmt.Errorf("ResolveBucketSpecs not supported for %s", RateLimitGroupOAuthDeviceAuthorization))
+	case RateLimitGroupOAuthDeviceVerification:
+		panic(fmt.Errorf("ResolveBucketSpecs not supported for %s", RateLimitGroupOAuthDeviceVerification))
+	case RateLimitGroupOAuthBackchannelAuthentication:
+		panic(fmt.Errorf("ResolveBucketSpecs not supported for %s", RateLimitGroupOAuthBackchannelAuthentication))
+	case RateLimitGroupOAuthPushedAuthorization:
+		panic(fmt.Errorf("ResolveBucketSpecs not supported for %s", RateLimitGroupOAuthPushedAuthorization))
 	}
 
 	return specs
@@ -738,6 +773,14 @@ func (n RateLimitGroup) perIPName() RateLimitName {
 		return RateLimitMessagingEmailPerIP
 	case RateLimitGroupOAuthTokenGeneral:
 		return RateLimitOAuthTokenGeneralPerIP
+	case RateLimitGroupOAuthDeviceAuthorization:
+		return RateLimitOAuthDeviceAuthorizationPerIP
+	case RateLimitGroupOAuthDeviceVerification:
+		return RateLimitOAuthDeviceVerificationPerIP
+	case RateLimitGroupOAuthBackchannelAuthentication:
+		return RateLimitOAuthBackchannelAuthenticationPerIP
+	case RateLimitGroupOAuthPushedAuthorization:
+		return RateLimitOAuthPushedAuthorizationPerIP
 	}
 	return ""
 }
@@ -0,0 +1,7 @@
+package risk
+
+import "github.com/google/wire"
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(Service), "*"),
+)
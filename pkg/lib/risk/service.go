@@ -0,0 +1,132 @@
+package risk
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/slogutil"
+)
+
+type EventService interface {
+	DispatchEventImmediately(ctx context.Context, payload event.NonBlockingPayload) error
+}
+
+type DatabaseHandle interface {
+	IsInTx(ctx context.Context) bool
+	ReadOnly(ctx context.Context, do func(ctx context.Context) error) error
+}
+
+type SessionLister interface {
+	List(ctx context.Context, userID string) ([]session.ListableSession, error)
+}
+
+var ServiceLogger = slogutil.NewLogger("risk")
+
+var ErrBlockedByRiskAssessment = apierrors.Forbidden.WithReason("BlockedByRiskAssessment").New("login blocked by risk assessment")
+
+type Service struct {
+	AppID           config.AppID
+	Config          *config.RiskConfig
+	Sessions        SessionLister
+	RemoteIP        httputil.RemoteIP
+	UserAgentString httputil.UserAgentString
+	HTTPRequestURL  httputil.HTTPRequestURL
+	HTTPReferer     httputil.HTTPReferer
+	Clock           clock.Clock
+	Database        DatabaseHandle
+	EventService    EventService
+}
+
+// Assess scores the current login attempt of userID against the access
+// history of the existing sessions of the user, and records the decision.
+// It returns ErrBlockedByRiskAssessment if the block threshold is reached.
+func (s *Service) Assess(ctx context.Context, userID string) (model.RiskDecision, error) {
+	if !*s.Config.Enabled {
+		return model.RiskDecisionAllowed, nil
+	}
+
+	sessions, err := s.Sessions.List(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var history []accessRecord
+	for _, sess := range sessions {
+		info := sess.GetAccessInfo()
+		if info == nil {
+			continue
+		}
+		history = append(history, newAccessRecord(info.InitialAccess), newAccessRecord(info.LastAccess))
+	}
+
+	now := s.Clock.NowUTC()
+	current := newAccessRecord(access.NewEvent(now, s.RemoteIP, s.UserAgentString))
+
+	result := evaluateSignals(s.Config.Signals, now, current, history)
+	decision := decide(s.Config.Decision, result.Score)
+
+	if len(result.Signals) > 0 {
+		signalTypes := make([]string, len(result.Signals))
+		for i, signal := range result.Signals {
+			signalTypes[i] = string(signal.Type)
+		}
+		ServiceLogger.GetLogger(ctx).Warn(ctx, "risk signals triggered",
+			slog.String("app_id", string(s.AppID)),
+			slog.String("user_id", userID),
+			slog.String("signals", strings.Join(signalTypes, ",")),
+			slog.Int("score", result.Score),
+			slog.String("decision", string(decision)),
+		)
+	}
+
+	triggeredSignals := result.Signals
+	if triggeredSignals == nil {
+		triggeredSignals = []model.RiskSignal{}
+	}
+
+	payload := &nonblocking.RiskDecisionRecordedEventPayload{
+		Record: model.RiskDecisionRecord{
+			Timestamp:               now,
+			Decision:                decision,
+			Score:                   result.Score,
+			TriggeredSignals:        triggeredSignals,
+			UserAgent:               string(s.UserAgentString),
+			IPAddress:               string(s.RemoteIP),
+			HTTPUrl:                 string(s.HTTPRequestURL),
+			HTTPReferer:             string(s.HTTPReferer),
+			UserID:                  userID,
+			GeoLocationCode:         current.CountryCode,
+			PreviousGeoLocationCode: result.PreviousCountryCode,
+		},
+	}
+	if err := s.dispatchEventImmediately(ctx, payload); err != nil {
+		ServiceLogger.GetLogger(ctx).WithError(err).Error(ctx, "failed to dispatch risk decision_recorded event")
+	}
+
+	if decision == model.RiskDecisionBlocked {
+		return decision, ErrBlockedByRiskAssessment
+	}
+
+	return decision, nil
+}
+
+// dispatchEventImmediately dispatches an event, opening a read-only transaction
+// if the caller is not already inside one (same pattern as fraudprotection.Service).
+func (s *Service) dispatchEventImmediately(ctx context.Context, payload event.NonBlockingPayload) error {
+	if s.Database.IsInTx(ctx) {
+		return s.EventService.DispatchEventImmediately(ctx, payload)
+	}
+	return s.Database.ReadOnly(ctx, func(ctx context.Context) error {
+		return s.EventService.DispatchEventImmediately(ctx, payload)
+	})
+}
@@ -0,0 +1,158 @@
+package risk
+
+import (
+	"net"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+	"github.com/authgear/authgear-server/pkg/util/geoip"
+)
+
+// accessRecord is an access event with its country and device resolved.
+type accessRecord struct {
+	Timestamp   time.Time
+	IPAddress   string
+	CountryCode string
+	Coordinates *geoip.Coordinates
+	Device      string
+}
+
+func newAccessRecord(e access.Event) accessRecord {
+	r := accessRecord{
+		Timestamp: e.Timestamp,
+		IPAddress: e.RemoteIP,
+		Device:    deviceFingerprint(e.UserAgent),
+	}
+	if info, ok := geoip.IPString(e.RemoteIP); ok {
+		r.CountryCode = info.CountryCode
+		r.Coordinates = info.Coordinates
+	}
+	return r
+}
+
+// deviceFingerprint identifies a device by its browser, OS and device model.
+// Versions are excluded so that software updates are not considered new devices.
+func deviceFingerprint(userAgent string) string {
+	ua := model.ParseUserAgent(userAgent)
+	if ua.Name == "" && ua.OS == "" && ua.DeviceModel == "" {
+		return userAgent
+	}
+	return ua.Name + "|" + ua.OS + "|" + ua.DeviceModel
+}
+
+type evaluateResult struct {
+	Signals             []model.RiskSignal
+	Score               int
+	PreviousCountryCode string
+}
+
+// evaluateSignals scores current against history.
+// The new device, new country and impossible travel signals compare against
+// history, so they never trigger for a user without any access history.
+func evaluateSignals(cfg *config.RiskSignalsConfig, now time.Time, current accessRecord, history []accessRecord) evaluateResult {
+	var result evaluateResult
+	add := func(typ model.RiskSignalType, score int) {
+		result.Signals = append(result.Signals, model.RiskSignal{Type: typ, Score: score})
+		result.Score += score
+	}
+
+	var knownDevice bool
+	var knownCountry bool
+	var hasDevice bool
+	var hasCountry bool
+	var latest *accessRecord
+	for i := range history {
+		h := history[i]
+		if h.Timestamp.IsZero() {
+			continue
+		}
+		if h.Device != "" {
+			hasDevice = true
+			if h.Device == current.Device {
+				knownDevice = true
+			}
+		}
+		if h.CountryCode != "" {
+			hasCountry = true
+			if h.CountryCode == current.CountryCode {
+				knownCountry = true
+			}
+			if latest == nil || h.Timestamp.After(latest.Timestamp) {
+				latest = &history[i]
+			}
+		}
+	}
+
+	if latest != nil {
+		result.PreviousCountryCode = latest.CountryCode
+	}
+
+	if c := cfg.NewDevice; *c.Score > 0 && current.Device != "" && hasDevice && !knownDevice {
+		add(model.RiskSignalTypeNewDevice, *c.Score)
+	}
+
+	if c := cfg.NewCountry; *c.Score > 0 && current.CountryCode != "" && hasCountry && !knownCountry {
+		add(model.RiskSignalTypeNewCountry, *c.Score)
+	}
+
+	if c := cfg.ImpossibleTravel; *c.Score > 0 && latest != nil {
+		if isImpossibleTravel(*latest, current, now, *c.MaxSpeedKMH) {
+			add(model.RiskSignalTypeImpossibleTravel, *c.Score)
+		}
+	}
+
+	if c := cfg.DenyIP; *c.Score > 0 && isIPInCIDRs(current.IPAddress, c.CIDRs) {
+		add(model.RiskSignalTypeDenyIP, *c.Score)
+	}
+
+	return result
+}
+
+// isImpossibleTravel reports whether travelling from the location of previous
+// to the location of current requires a speed above maxSpeedKMH.
+func isImpossibleTravel(previous accessRecord, current accessRecord, now time.Time, maxSpeedKMH int) bool {
+	if previous.Coordinates == nil || current.Coordinates == nil {
+		return false
+	}
+
+	distanceKm := previous.Coordinates.DistanceKm(*current.Coordinates)
+	if distanceKm == 0 {
+		return false
+	}
+
+	elapsed := now.Sub(previous.Timestamp)
+	if elapsed <= 0 {
+		return true
+	}
+	return distanceKm/elapsed.Hours() > float64(maxSpeedKMH)
+}
+
+func decide(cfg *config.RiskDecisionConfig, score int) model.RiskDecision {
+	switch {
+	case score >= *cfg.BlockThreshold:
+		return model.RiskDecisionBlocked
+	case score >= *cfg.StepUpThreshold:
+		return model.RiskDecisionStepUp
+	default:
+		return model.RiskDecisionAllowed
+	}
+}
+
+func isIPInCIDRs(ip string, cidrs []string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
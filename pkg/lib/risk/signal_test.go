@@ -0,0 +1,183 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/geoip"
+)
+
+func TestEvaluateSignals(t *testing.T) {
+	Convey("evaluateSignals", t, func() {
+		cfg := &config.RiskSignalsConfig{
+			NewDevice:  &config.RiskNewDeviceSignalConfig{Score: new(30)},
+			NewCountry: &config.RiskNewCountrySignalConfig{Score: new(30)},
+			ImpossibleTravel: &config.RiskImpossibleTravelSignalConfig{
+				Score:       new(60),
+				MaxSpeedKMH: new(1000),
+			},
+			DenyIP: &config.RiskDenyIPSignalConfig{
+				Score: new(100),
+				CIDRs: []string{"192.0.2.0/24"},
+			},
+		}
+		hk := &geoip.Coordinates{Latitude: 22.396428, Longitude: 114.109497}
+		jp := &geoip.Coordinates{Latitude: 36.204824, Longitude: 138.252924}
+		tw := &geoip.Coordinates{Latitude: 23.69781, Longitude: 120.960515}
+		now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		current := accessRecord{
+			Timestamp:   now,
+			IPAddress:   "203.0.113.1",
+			CountryCode: "HK",
+			Coordinates: hk,
+			Device:      "Chrome|Mac OS X|",
+		}
+
+		Convey("no signals without history", func() {
+			result := evaluateSignals(cfg, now, current, nil)
+			So(result.Signals, ShouldBeNil)
+			So(result.Score, ShouldEqual, 0)
+		})
+
+		Convey("no signals for a known device in a known country", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-24 * time.Hour), CountryCode: "HK", Coordinates: hk, Device: "Chrome|Mac OS X|"},
+			})
+			So(result.Signals, ShouldBeNil)
+			So(result.PreviousCountryCode, ShouldEqual, "HK")
+		})
+
+		Convey("new device", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-24 * time.Hour), CountryCode: "HK", Coordinates: hk, Device: "Safari|iOS|Apple iPhone"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeNewDevice, Score: 30},
+			})
+			So(result.Score, ShouldEqual, 30)
+		})
+
+		Convey("new country reachable since the latest access", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-24 * time.Hour), CountryCode: "JP", Coordinates: jp, Device: "Chrome|Mac OS X|"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeNewCountry, Score: 30},
+			})
+			So(result.PreviousCountryCode, ShouldEqual, "JP")
+		})
+
+		Convey("impossible travel compares with the latest access", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-48 * time.Hour), CountryCode: "HK", Coordinates: hk, Device: "Chrome|Mac OS X|"},
+				{Timestamp: now.Add(-10 * time.Minute), CountryCode: "JP", Coordinates: jp, Device: "Chrome|Mac OS X|"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeImpossibleTravel, Score: 60},
+			})
+			So(result.PreviousCountryCode, ShouldEqual, "JP")
+		})
+
+		Convey("impossible travel is computed from the distance", func() {
+			// Hong Kong and Taiwan are about 700km apart.
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-1 * time.Hour), CountryCode: "TW", Coordinates: tw, Device: "Chrome|Mac OS X|"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeNewCountry, Score: 30},
+			})
+
+			result = evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-30 * time.Minute), CountryCode: "TW", Coordinates: tw, Device: "Chrome|Mac OS X|"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeNewCountry, Score: 30},
+				{Type: model.RiskSignalTypeImpossibleTravel, Score: 60},
+			})
+		})
+
+		Convey("no impossible travel without location", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-10 * time.Minute), CountryCode: "JP", Device: "Chrome|Mac OS X|"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeNewCountry, Score: 30},
+			})
+		})
+
+		Convey("deny IP applies without history", func() {
+			current.IPAddress = "192.0.2.10"
+			result := evaluateSignals(cfg, now, current, nil)
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeDenyIP, Score: 100},
+			})
+		})
+
+		Convey("signals with score 0 are disabled", func() {
+			cfg.NewDevice.Score = new(0)
+			cfg.NewCountry.Score = new(0)
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-24 * time.Hour), CountryCode: "JP", Coordinates: jp, Device: "Safari|iOS|Apple iPhone"},
+			})
+			So(result.Signals, ShouldBeNil)
+		})
+
+		Convey("ignore history without timestamp", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{CountryCode: "JP", Coordinates: jp, Device: "Safari|iOS|Apple iPhone"},
+			})
+			So(result.Signals, ShouldBeNil)
+		})
+
+		Convey("scores are summed", func() {
+			result := evaluateSignals(cfg, now, current, []accessRecord{
+				{Timestamp: now.Add(-5 * time.Minute), CountryCode: "JP", Coordinates: jp, Device: "Safari|iOS|Apple iPhone"},
+			})
+			So(result.Signals, ShouldResemble, []model.RiskSignal{
+				{Type: model.RiskSignalTypeNewDevice, Score: 30},
+				{Type: model.RiskSignalTypeNewCountry, Score: 30},
+				{Type: model.RiskSignalTypeImpossibleTravel, Score: 60},
+			})
+			So(result.Score, ShouldEqual, 120)
+		})
+	})
+}
+
+func TestDecide(t *testing.T) {
+	Convey("decide", t, func() {
+		cfg := &config.RiskDecisionConfig{
+			StepUpThreshold: new(50),
+			BlockThreshold:  new(100),
+		}
+
+		So(decide(cfg, 0), ShouldEqual, model.RiskDecisionAllowed)
+		So(decide(cfg, 49), ShouldEqual, model.RiskDecisionAllowed)
+		So(decide(cfg, 50), ShouldEqual, model.RiskDecisionStepUp)
+		So(decide(cfg, 99), ShouldEqual, model.RiskDecisionStepUp)
+		So(decide(cfg, 100), ShouldEqual, model.RiskDecisionBlocked)
+	})
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	Convey("deviceFingerprint", t, func() {
+		Convey("ignore versions", func() {
+			a := deviceFingerprint("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+			b := deviceFingerprint("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36")
+			So(a, ShouldEqual, b)
+		})
+
+		Convey("distinguish browsers", func() {
+			a := deviceFingerprint("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+			b := deviceFingerprint("Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:121.0) Gecko/20100101 Firefox/121.0")
+			So(a, ShouldNotEqual, b)
+		})
+
+		Convey("fall back to the raw user agent", func() {
+			So(deviceFingerprint("my-client"), ShouldEqual, "my-client")
+		})
+	})
+}
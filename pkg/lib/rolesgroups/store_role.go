@@ -120,6 +120,14 @@ func (s *Store) DeleteRole(ctx context.Context, id string) error {
 		return err
 	}
 
+	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_organization_member_role")).
+		Where("role_id = ?", id)
+
+	_, err = s.SQLExecutor.ExecWith(ctx, q)
+	if err != nil {
+		return err
+	}
+
 	q = s.SQLBuilder.Delete(s.SQLBuilder.TableName("_auth_role")).
 		Where("id = ?", id)
 
@@ -133,38 +133,11 @@ func (s *SAMLBindingHTTPRedirectWriter) write(
 	} else {
 		panic("no SAMLRequest or SAMLResponse given")
 	}
-	// https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
-	// 3.4.4.1 DEFLATE Encoding
-	// Any signature on the SAML protocol message, including the <ds:Signature> XML element itself,
-	// MUST be removed.
-	if sigEl := el.FindElement("./Signature"); sigEl != nil {
-		el.RemoveChild(sigEl)
-	}
-
-	doc := etree.NewDocument()
-	doc.SetRoot(el)
-	elBuf, err := doc.WriteToBytes()
+	encodedEl, err := deflateAndEncode(el)
 	if err != nil {
 		return err
 	}
 
-	compressedElBuffer := &bytes.Buffer{}
-	writer, err := flate.NewWriter(compressedElBuffer, 9)
-	if err != nil {
-		return err
-	}
-	_, err = writer.Write(elBuf)
-	if err != nil {
-		return err
-	}
-
-	err = writer.Close()
-	if err != nil {
-		return err
-	}
-
-	encodedEl := base64.StdEncoding.EncodeToString(compressedElBuffer.Bytes())
-
 	redirectURL, err := url.Parse(callbackURL)
 	if err != nil {
 		return err
@@ -232,3 +205,64 @@ func (s *SAMLBindingHTTPRedirectWriter) WriteRequest(
 		},
 	)
 }
+
+// SAMLBindingHTTPRedirectMakeRequestURL returns the URL that sends an unsigned SAMLRequest
+// to destination with the HTTP-Redirect binding.
+// RelayState is omitted if it is empty, so that the caller can add it to the URL later.
+func SAMLBindingHTTPRedirectMakeRequestURL(
+	destination string,
+	requestEl *etree.Element,
+	relayState string) (*url.URL, error) {
+	encodedEl, err := deflateAndEncode(requestEl)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURL, err := url.Parse(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	q := redirectURL.Query()
+	q.Set("SAMLRequest", encodedEl)
+	if relayState != "" {
+		q.Set("RelayState", relayState)
+	}
+	redirectURL.RawQuery = q.Encode()
+
+	return redirectURL, nil
+}
+
+func deflateAndEncode(el *etree.Element) (string, error) {
+	// https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+	// 3.4.4.1 DEFLATE Encoding
+	// Any signature on the SAML protocol message, including the <ds:Signature> XML element itself,
+	// MUST be removed.
+	if sigEl := el.FindElement("./Signature"); sigEl != nil {
+		el.RemoveChild(sigEl)
+	}
+
+	doc := etree.NewDocument()
+	doc.SetRoot(el)
+	elBuf, err := doc.WriteToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	compressedElBuffer := &bytes.Buffer{}
+	writer, err := flate.NewWriter(compressedElBuffer, 9)
+	if err != nil {
+		return "", err
+	}
+	_, err = writer.Write(elBuf)
+	if err != nil {
+		return "", err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(compressedElBuffer.Bytes()), nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 
@@ -109,4 +110,40 @@ func TestSAMLBindingHTTPRedirect(t *testing.T) {
 			So(logoutResponse.Status.StatusCode.Value, ShouldEqual, "urn:oasis:names:tc:SAML:2.0:status:Success")
 		})
 	})
+
+	Convey("SAMLBindingHTTPRedirectMakeRequestURL", t, func() {
+		Convey("success", func() {
+			authnRequest := &samlprotocol.AuthnRequest{
+				ID:                          "samlauthnrequest_test",
+				Version:                     samlprotocol.SAMLVersion2,
+				IssueInstant:                time.Date(2024, 8, 16, 8, 25, 59, 0, time.UTC),
+				Destination:                 "https://idp.example.com/sso",
+				AssertionConsumerServiceURL: "http://localhost:3000/sso/saml2/acs/okta",
+				ProtocolBinding:             string(samlprotocol.SAMLBindingHTTPPost),
+				Issuer: &samlprotocol.Issuer{
+					Value: "http://localhost:3000/saml2/sp/metadata/okta",
+				},
+			}
+
+			u, err := samlbinding.SAMLBindingHTTPRedirectMakeRequestURL(
+				"https://idp.example.com/sso?tenant=1",
+				authnRequest.Element(),
+				"",
+			)
+			So(err, ShouldBeNil)
+			So(u.Host, ShouldEqual, "idp.example.com")
+			So(u.Query().Get("tenant"), ShouldEqual, "1")
+			So(u.Query().Has("RelayState"), ShouldBeFalse)
+
+			req := &http.Request{}
+			req.URL = u
+			result, err := samlbinding.SAMLBindingHTTPRedirectParseRequest(req)
+			So(err, ShouldBeNil)
+			parsed, err := samlprotocol.ParseAuthnRequest([]byte(result.SAMLRequestXML))
+			So(err, ShouldBeNil)
+			So(parsed.ID, ShouldEqual, "samlauthnrequest_test")
+			So(parsed.AssertionConsumerServiceURL, ShouldEqual, "http://localhost:3000/sso/saml2/acs/okta")
+			So(parsed.Issuer.Value, ShouldEqual, "http://localhost:3000/saml2/sp/metadata/okta")
+		})
+	})
 }
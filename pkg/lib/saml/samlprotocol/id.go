@@ -30,3 +30,7 @@ func GenerateAssertionID() string {
 func GenerateLogoutRequestID() string {
 	return generateID("samllogoutrequest")
 }
+
+func GenerateAuthnRequestID() string {
+	return generateID("samlauthnrequest")
+}
@@ -1,9 +1,12 @@
 package samlprotocol
 
 import (
+	"bytes"
+	"encoding/xml"
 	"time"
 
 	"github.com/beevik/etree"
+	xrv "github.com/mattermost/xml-roundtrip-validator"
 )
 
 func newResponse(issueInstant time.Time, status Status, issuer string) *Response {
@@ -104,3 +107,16 @@ func NewSuccessResponse(
 	response.InResponseTo = inResponseTo
 	return response
 }
+
+func ParseResponse(input []byte) (*Response, error) {
+	var resp Response
+	if err := xrv.Validate(bytes.NewReader(input)); err != nil {
+		return nil, err
+	}
+
+	if err := xml.Unmarshal(input, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -22,6 +22,7 @@ type SAMLSLOSessionEntry struct {
 	UserID                          string                   `json:"user_id,omitempty"`
 	IsPartialLogout                 bool                     `json:"is_partial_logout,omitempty"`
 	PostLogoutRedirectURI           string                   `json:"post_logout_redirect_uri,omitempty"`
+	FrontchannelLogoutURIs          []string                 `json:"frontchannel_logout_uris,omitempty"`
 }
 
 func NewSAMLSLOSession(entry *SAMLSLOSessionEntry) *SAMLSLOSession {
@@ -0,0 +1,356 @@
+package samlsp
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/beevik/etree"
+
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlbinding"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/duration"
+	"github.com/authgear/authgear-server/pkg/util/slice"
+)
+
+const subjectConfirmationMethodBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+type SAMLSPEndpoints interface {
+	SAMLSPMetadataURL(identityProviderName string) *url.URL
+	SAMLSPACSURL(identityProviderName string) *url.URL
+}
+
+// Service implements the service provider side of SAML,
+// that is, authenticating users with an upstream SAML identity provider.
+type Service struct {
+	Clock                            clock.Clock
+	SAMLIdentityConfig               *config.SAMLIdentityConfig
+	SAMLIdentityProviderCertificates *config.SAMLIdentityProviderCertificates
+	Endpoints                        SAMLSPEndpoints
+}
+
+// Assertion is the verified content of a SAML response.
+type Assertion struct {
+	IdentityProviderName     string
+	IdentityProviderEntityID string
+	NameID                   string
+	NameIDFormat             string
+	Attributes               map[string][]string
+}
+
+// EntityID is the entity ID of Authgear as a service provider of the identity provider.
+// Each identity provider sees a different entity ID so that they can be registered independently.
+func (s *Service) EntityID(identityProviderName string) string {
+	return s.Endpoints.SAMLSPMetadataURL(identityProviderName).String()
+}
+
+func (s *Service) resolveIdentityProvider(identityProviderName string) (*config.SAMLIdentityProviderConfig, error) {
+	if s.SAMLIdentityConfig == nil {
+		return nil, api.ErrSAMLIdentityProviderNotFound
+	}
+	idp, ok := s.SAMLIdentityConfig.GetIdentityProviderConfig(identityProviderName)
+	if !ok {
+		return nil, api.ErrSAMLIdentityProviderNotFound
+	}
+	return idp, nil
+}
+
+func (s *Service) Metadata(identityProviderName string) (*samlprotocol.Metadata, error) {
+	idp, err := s.resolveIdentityProvider(identityProviderName)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameIDFormats []samlprotocol.SAMLNameIDFormat
+	if idp.NameIDFormat != "" {
+		nameIDFormats = append(nameIDFormats, idp.NameIDFormat)
+	}
+
+	authnRequestsSigned := false
+	wantAssertionsSigned := true
+	isDefault := true
+
+	descriptor := samlprotocol.EntityDescriptor{
+		EntityID: s.EntityID(idp.Name),
+		SPSSODescriptors: []samlprotocol.SPSSODescriptor{
+			{
+				SSODescriptor: samlprotocol.SSODescriptor{
+					RoleDescriptor: samlprotocol.RoleDescriptor{
+						ProtocolSupportEnumeration: "urn:oasis:names:tc:SAML:2.0:protocol",
+					},
+					NameIDFormats: nameIDFormats,
+				},
+				AuthnRequestsSigned:  &authnRequestsSigned,
+				WantAssertionsSigned: &wantAssertionsSigned,
+				AssertionConsumerServices: []samlprotocol.IndexedEndpoint{
+					{
+						Binding:   string(samlprotocol.SAMLBindingHTTPPost),
+						Location:  s.Endpoints.SAMLSPACSURL(idp.Name).String(),
+						Index:     0,
+						IsDefault: &isDefault,
+					},
+				},
+			},
+		},
+	}
+
+	return &samlprotocol.Metadata{
+		EntityDescriptor: descriptor,
+	}, nil
+}
+
+// MakeAuthnRequestURL returns the URL that sends an AuthnRequest to the identity provider
+// with the HTTP-Redirect binding. The caller is expected to append RelayState to the URL.
+func (s *Service) MakeAuthnRequestURL(identityProviderName string, authnRequestID string) (*url.URL, error) {
+	idp, err := s.resolveIdentityProvider(identityProviderName)
+	if err != nil {
+		return nil, err
+	}
+
+	authnRequest := &samlprotocol.AuthnRequest{
+		ID:           authnRequestID,
+		Version:      samlprotocol.SAMLVersion2,
+		IssueInstant: s.Clock.NowUTC(),
+		Destination:  idp.SSOURL,
+		Issuer: &samlprotocol.Issuer{
+			Format: samlprotocol.SAMLIssertFormatEntity,
+			Value:  s.EntityID(idp.Name),
+		},
+		AssertionConsumerServiceURL: s.Endpoints.SAMLSPACSURL(idp.Name).String(),
+		ProtocolBinding:             string(samlprotocol.SAMLBindingHTTPPost),
+	}
+
+	if idp.NameIDFormat != "" {
+		format := string(idp.NameIDFormat)
+		allowCreate := true
+		authnRequest.NameIDPolicy = &samlprotocol.NameIDPolicy{
+			Format:      &format,
+			AllowCreate: &allowCreate,
+		}
+	}
+
+	return samlbinding.SAMLBindingHTTPRedirectMakeRequestURL(idp.SSOURL, authnRequest.Element(), "")
+}
+
+// ParseResponse verifies the base64-encoded SAMLResponse received at the assertion consumer service,
+// and returns the assertion in it.
+func (s *Service) ParseResponse(identityProviderName string, authnRequestID string, samlResponse string) (*Assertion, error) {
+	idp, err := s.resolveIdentityProvider(identityProviderName)
+	if err != nil {
+		return nil, err
+	}
+
+	if samlResponse == "" {
+		return nil, api.InvalidSAMLResponse.New("missing SAMLResponse")
+	}
+
+	responseXML, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, api.InvalidSAMLResponse.New("SAMLResponse is not base64 encoded")
+	}
+
+	response, err := samlprotocol.ParseResponse(responseXML)
+	if err != nil {
+		return nil, api.InvalidSAMLResponse.New("failed to parse SAMLResponse")
+	}
+
+	if response.Status.StatusCode.Value != samlprotocol.StatusSuccess {
+		return nil, api.InvalidSAMLResponse.New(fmt.Sprintf("unexpected status: %v", response.Status.StatusCode.Value))
+	}
+
+	acsURL := s.Endpoints.SAMLSPACSURL(idp.Name).String()
+	if response.Destination != "" && response.Destination != acsURL {
+		return nil, api.InvalidSAMLResponse.New("unexpected destination")
+	}
+	if response.InResponseTo != authnRequestID {
+		return nil, api.InvalidSAMLResponse.New("unexpected InResponseTo")
+	}
+	if response.Issuer != nil && response.Issuer.Value != idp.EntityID {
+		return nil, api.InvalidSAMLResponse.New("unexpected issuer")
+	}
+	if response.EncryptedAssertion != nil {
+		return nil, api.InvalidSAMLResponse.New("encrypted assertion is not supported")
+	}
+
+	doc := etree.NewDocument()
+	err = doc.ReadFromBytes(responseXML)
+	if err != nil {
+		return nil, api.InvalidSAMLResponse.New("failed to parse SAMLResponse")
+	}
+
+	// Only the content covered by a valid signature is trusted from now on.
+	assertionEl, err := s.verifyAssertion(idp, doc.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, err := parseAssertion(assertionEl)
+	if err != nil {
+		return nil, api.InvalidSAMLResponse.New("failed to parse assertion")
+	}
+
+	err = s.validateAssertion(idp, authnRequestID, assertion)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := map[string][]string{}
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			for _, value := range attr.Values {
+				attributes[attr.Name] = append(attributes[attr.Name], value.Value)
+			}
+		}
+	}
+
+	return &Assertion{
+		IdentityProviderName:     idp.Name,
+		IdentityProviderEntityID: idp.EntityID,
+		NameID:                   assertion.Subject.NameID.Value,
+		NameIDFormat:             assertion.Subject.NameID.Format,
+		Attributes:               attributes,
+	}, nil
+}
+
+// verifyAssertion returns the assertion element covered by a valid signature.
+// Either the response or the assertion can be signed.
+func (s *Service) verifyAssertion(idp *config.SAMLIdentityProviderConfig, responseEl *etree.Element) (*etree.Element, error) {
+	certs, _, ok := s.SAMLIdentityProviderCertificates.Resolve(idp.Name)
+	if !ok || len(certs.Certificates) == 0 {
+		// This should be prevented by config validation. Therefore it is a programming error.
+		panic(fmt.Errorf("identity provider certificates not configured"))
+	}
+	certificateStore := &dsig.MemoryX509CertificateStore{
+		Roots: slice.Map(certs.Certificates, func(c config.X509Certificate) *x509.Certificate {
+			return c.X509Certificate()
+		}),
+	}
+	validationCtx := dsig.NewDefaultValidationContext(certificateStore)
+	validationCtx.Clock = dsig.NewFakeClockAt(s.Clock.NowUTC())
+
+	var verifiedAssertionEl *etree.Element
+	if responseEl.FindElement("./Signature") != nil {
+		verifiedResponseEl, err := validationCtx.Validate(responseEl)
+		if err != nil {
+			return nil, api.InvalidSAMLResponse.NewWithCause("invalid signature", apierrors.StringCause("InvalidSignature"))
+		}
+		verifiedAssertionEl = verifiedResponseEl.FindElement("./Assertion")
+		if verifiedAssertionEl == nil {
+			return nil, api.InvalidSAMLResponse.New("missing assertion")
+		}
+	} else {
+		assertionEl := responseEl.FindElement("./Assertion")
+		if assertionEl == nil {
+			return nil, api.InvalidSAMLResponse.New("missing assertion")
+		}
+		if assertionEl.FindElement("./Signature") == nil {
+			return nil, api.InvalidSAMLResponse.New("assertion is not signed")
+		}
+		// Validate the assertion in place.
+		// Detaching it first rewrites the namespace declarations, which breaks the digest.
+		var err error
+		verifiedAssertionEl, err = validationCtx.Validate(assertionEl)
+		if err != nil {
+			return nil, api.InvalidSAMLResponse.NewWithCause("invalid signature", apierrors.StringCause("InvalidSignature"))
+		}
+	}
+
+	return detach(verifiedAssertionEl)
+}
+
+func (s *Service) validateAssertion(idp *config.SAMLIdentityProviderConfig, authnRequestID string, assertion *samlprotocol.Assertion) error {
+	now := s.Clock.NowUTC()
+	entityID := s.EntityID(idp.Name)
+	acsURL := s.Endpoints.SAMLSPACSURL(idp.Name).String()
+
+	if assertion.Issuer.Value != idp.EntityID {
+		return api.InvalidSAMLResponse.New("unexpected assertion issuer")
+	}
+
+	if conditions := assertion.Conditions; conditions != nil {
+		if !conditions.NotBefore.IsZero() && now.Add(duration.ClockSkew).Before(conditions.NotBefore) {
+			return api.InvalidSAMLResponse.New("assertion is not yet valid")
+		}
+		if !conditions.NotOnOrAfter.IsZero() && !now.Add(-duration.ClockSkew).Before(conditions.NotOnOrAfter) {
+			return api.InvalidSAMLResponse.New("assertion expired")
+		}
+		for _, restriction := range conditions.AudienceRestrictions {
+			audiences := slice.Map(restriction.Audience, func(a samlprotocol.Audience) string {
+				return a.Value
+			})
+			if !slices.Contains(audiences, entityID) {
+				return api.InvalidSAMLResponse.New("unexpected audience")
+			}
+		}
+	}
+
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || assertion.Subject.NameID.Value == "" {
+		return api.InvalidSAMLResponse.New("missing NameID")
+	}
+
+	// https://docs.oasis-open.org/security/saml/v2.0/saml-profiles-2.0-os.pdf
+	// 4.1.4.2 <Response> Usage
+	// At least one bearer <SubjectConfirmation> element MUST be present.
+	confirmed := false
+	for _, confirmation := range assertion.Subject.SubjectConfirmations {
+		if confirmation.Method != subjectConfirmationMethodBearer {
+			continue
+		}
+		data := confirmation.SubjectConfirmationData
+		if data == nil {
+			continue
+		}
+		if data.Recipient != acsURL {
+			continue
+		}
+		if data.InResponseTo != authnRequestID {
+			continue
+		}
+		if data.NotOnOrAfter.IsZero() || !now.Add(-duration.ClockSkew).Before(data.NotOnOrAfter) {
+			continue
+		}
+		confirmed = true
+		break
+	}
+	if !confirmed {
+		return api.InvalidSAMLResponse.New("no valid bearer subject confirmation")
+	}
+
+	return nil
+}
+
+// detach returns a copy of el that declares all the namespaces it uses,
+// so that it can be processed without its ancestors.
+func detach(el *etree.Element) (*etree.Element, error) {
+	nsCtx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	return etreeutils.NSDetatch(nsCtx, el)
+}
+
+func parseAssertion(el *etree.Element) (*samlprotocol.Assertion, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(el)
+	buf, err := doc.WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var assertion samlprotocol.Assertion
+	err = xml.Unmarshal(buf, &assertion)
+	if err != nil {
+		return nil, err
+	}
+	return &assertion, nil
+}
@@ -0,0 +1,191 @@
+package samlsp_test
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlprotocol"
+	"github.com/authgear/authgear-server/pkg/lib/saml/samlsp"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type endpoints struct{}
+
+func (endpoints) SAMLSPMetadataURL(identityProviderName string) *url.URL {
+	u, _ := url.Parse("https://app.localhost/saml2/sp/metadata/" + identityProviderName)
+	return u
+}
+
+func (endpoints) SAMLSPACSURL(identityProviderName string) *url.URL {
+	u, _ := url.Parse("https://app.localhost/sso/saml2/acs/" + identityProviderName)
+	return u
+}
+
+func TestService(t *testing.T) {
+	Convey("Service", t, func() {
+		now := time.Now().UTC().Truncate(time.Second)
+		clk := clock.NewMockClockAtTime(now)
+
+		keyStore := dsig.RandomKeyStoreForTest()
+		_, certDER, err := keyStore.GetKeyPair()
+		So(err, ShouldBeNil)
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+
+		s := &samlsp.Service{
+			Clock: clk,
+			SAMLIdentityConfig: &config.SAMLIdentityConfig{
+				IdentityProviders: []*config.SAMLIdentityProviderConfig{
+					{
+						Name:         "okta",
+						EntityID:     "http://www.okta.com/test",
+						SSOURL:       "https://okta.localhost/sso/saml",
+						NameIDFormat: samlprotocol.SAMLNameIDFormatEmailAddress,
+					},
+				},
+			},
+			SAMLIdentityProviderCertificates: &config.SAMLIdentityProviderCertificates{
+				{
+					IdentityProviderName: "okta",
+					Certificates: []config.X509Certificate{
+						{Pem: config.X509CertificatePem(certPEM)},
+					},
+				},
+			},
+			Endpoints: endpoints{},
+		}
+
+		makeResponse := func(inResponseTo string, audience string) *samlprotocol.Response {
+			return &samlprotocol.Response{
+				ID:           "id_response",
+				InResponseTo: inResponseTo,
+				Version:      samlprotocol.SAMLVersion2,
+				IssueInstant: now,
+				Destination:  "https://app.localhost/sso/saml2/acs/okta",
+				Issuer: &samlprotocol.Issuer{
+					Format: samlprotocol.SAMLIssertFormatEntity,
+					Value:  "http://www.okta.com/test",
+				},
+				Status: samlprotocol.Status{
+					StatusCode: samlprotocol.StatusCode{
+						Value: samlprotocol.StatusSuccess,
+					},
+				},
+				Assertion: &samlprotocol.Assertion{
+					ID:           "id_assertion",
+					IssueInstant: now,
+					Version:      samlprotocol.SAMLVersion2,
+					Issuer: samlprotocol.Issuer{
+						Format: samlprotocol.SAMLIssertFormatEntity,
+						Value:  "http://www.okta.com/test",
+					},
+					Subject: &samlprotocol.Subject{
+						NameID: &samlprotocol.NameID{
+							Format: string(samlprotocol.SAMLNameIDFormatEmailAddress),
+							Value:  "user@example.com",
+						},
+						SubjectConfirmations: []samlprotocol.SubjectConfirmation{
+							{
+								Method: "urn:oasis:names:tc:SAML:2.0:cm:bearer",
+								SubjectConfirmationData: &samlprotocol.SubjectConfirmationData{
+									NotOnOrAfter: now.Add(5 * time.Minute),
+									Recipient:    "https://app.localhost/sso/saml2/acs/okta",
+									InResponseTo: inResponseTo,
+								},
+							},
+						},
+					},
+					Conditions: &samlprotocol.Conditions{
+						NotBefore:    now.Add(-1 * time.Minute),
+						NotOnOrAfter: now.Add(5 * time.Minute),
+						AudienceRestrictions: []samlprotocol.AudienceRestriction{
+							{
+								Audience: []samlprotocol.Audience{
+									{Value: audience},
+								},
+							},
+						},
+					},
+					AttributeStatements: []samlprotocol.AttributeStatement{
+						{
+							Attributes: []samlprotocol.Attribute{
+								{
+									Name: "firstName",
+									Values: []samlprotocol.AttributeValue{
+										{Value: "John"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		encode := func(response *samlprotocol.Response, sign bool) string {
+			if sign {
+				signingContext := dsig.NewDefaultSigningContext(keyStore)
+				sigEl, err := signingContext.ConstructSignature(response.Assertion.Element(), true)
+				So(err, ShouldBeNil)
+				response.Assertion.Signature = sigEl
+			}
+			doc := etree.NewDocument()
+			doc.SetRoot(response.Element())
+			buf, err := doc.WriteToBytes()
+			So(err, ShouldBeNil)
+			return base64.StdEncoding.EncodeToString(buf)
+		}
+
+		Convey("MakeAuthnRequestURL", func() {
+			u, err := s.MakeAuthnRequestURL("okta", "samlauthnrequest_test")
+			So(err, ShouldBeNil)
+			So(u.Host, ShouldEqual, "okta.localhost")
+			So(u.Path, ShouldEqual, "/sso/saml")
+			So(u.Query().Get("SAMLRequest"), ShouldNotBeEmpty)
+			So(u.Query().Has("RelayState"), ShouldBeFalse)
+
+			_, err = s.MakeAuthnRequestURL("unknown", "samlauthnrequest_test")
+			So(err, ShouldBeError, api.ErrSAMLIdentityProviderNotFound)
+		})
+
+		Convey("ParseResponse", func() {
+			Convey("should accept a signed assertion", func() {
+				response := makeResponse("samlauthnrequest_test", "https://app.localhost/saml2/sp/metadata/okta")
+				assertion, err := s.ParseResponse("okta", "samlauthnrequest_test", encode(response, true))
+				So(err, ShouldBeNil)
+				So(assertion.NameID, ShouldEqual, "user@example.com")
+				So(assertion.NameIDFormat, ShouldEqual, string(samlprotocol.SAMLNameIDFormatEmailAddress))
+				So(assertion.Attributes, ShouldResemble, map[string][]string{
+					"firstName": {"John"},
+				})
+			})
+
+			Convey("should reject an unsigned assertion", func() {
+				response := makeResponse("samlauthnrequest_test", "https://app.localhost/saml2/sp/metadata/okta")
+				_, err := s.ParseResponse("okta", "samlauthnrequest_test", encode(response, false))
+				So(apierrors.IsKind(err, api.InvalidSAMLResponse), ShouldBeTrue)
+			})
+
+			Convey("should reject a response to another request", func() {
+				response := makeResponse("samlauthnrequest_other", "https://app.localhost/saml2/sp/metadata/okta")
+				_, err := s.ParseResponse("okta", "samlauthnrequest_test", encode(response, true))
+				So(apierrors.IsKind(err, api.InvalidSAMLResponse), ShouldBeTrue)
+			})
+
+			Convey("should reject an assertion for another audience", func() {
+				response := makeResponse("samlauthnrequest_test", "https://other.localhost")
+				_, err := s.ParseResponse("okta", "samlauthnrequest_test", encode(response, true))
+				So(apierrors.IsKind(err, api.InvalidSAMLResponse), ShouldBeTrue)
+			})
+		})
+	})
+}
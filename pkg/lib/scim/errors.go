@@ -0,0 +1,38 @@
+package scim
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+var InvalidFilter = apierrors.BadRequest.WithReason("SCIMInvalidFilter")
+var InvalidPath = apierrors.BadRequest.WithReason("SCIMInvalidPath")
+var InvalidValue = apierrors.BadRequest.WithReason("SCIMInvalidValue")
+var InvalidSyntax = apierrors.BadRequest.WithReason("SCIMInvalidSyntax")
+var Mutability = apierrors.BadRequest.WithReason("SCIMMutability")
+var NoTarget = apierrors.BadRequest.WithReason("SCIMNoTarget")
+
+// ErrUnsupportedContentType is returned when the request body is neither
+// application/scim+json nor application/json.
+var ErrUnsupportedContentType = apierrors.BadRequest.WithReason("SCIMInvalidSyntax").New("request content type is invalid")
+
+// scimTypes maps the reason of an API error to the scimType defined in RFC7644 Section 3.12.
+var scimTypes = map[string]string{
+	"SCIMInvalidFilter": "invalidFilter",
+	"SCIMInvalidPath":   "invalidPath",
+	"SCIMInvalidValue":  "invalidValue",
+	"SCIMInvalidSyntax": "invalidSyntax",
+	"SCIMMutability":    "mutability",
+	"SCIMNoTarget":      "noTarget",
+	"ValidationFailed":  "invalidValue",
+	// The following reasons indicate that the resource conflicts with an existing one.
+	"InvariantViolated": "uniqueness",
+	"GroupDuplicateKey": "uniqueness",
+}
+
+// Error is the error response defined in RFC7644 Section 3.12.
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
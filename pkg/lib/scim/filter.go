@@ -0,0 +1,142 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OperatorEqual is the only comparison operator supported at the moment.
+const OperatorEqual = "eq"
+
+// Filter is a single attribute expression of RFC7644 Section 3.4.2.2.
+// Logical operators and grouping are not supported.
+type Filter struct {
+	// AttrPath is the attribute path with the schema URN prefix removed.
+	AttrPath string
+	// Operator is lower-cased.
+	Operator string
+	Value    any
+}
+
+// IsAttr reports whether the filter targets attr. Attribute names are case-insensitive.
+func (f *Filter) IsAttr(attr string) bool {
+	return strings.EqualFold(f.AttrPath, attr)
+}
+
+// StringValue returns the value of the filter if it is a string.
+func (f *Filter) StringValue() (string, bool) {
+	s, ok := f.Value.(string)
+	return s, ok
+}
+
+// ParseFilter parses filters of the form `attrPath eq "value"`.
+func ParseFilter(s string) (*Filter, error) {
+	s = strings.TrimSpace(s)
+
+	attrPath, rest, ok := strings.Cut(s, " ")
+	if !ok || attrPath == "" {
+		return nil, InvalidFilter.New("filter must be in the form of `attrPath eq value`")
+	}
+
+	rest = strings.TrimSpace(rest)
+	op, value, ok := strings.Cut(rest, " ")
+	if !ok {
+		return nil, InvalidFilter.New("filter must be in the form of `attrPath eq value`")
+	}
+
+	op = strings.ToLower(op)
+	if op != OperatorEqual {
+		return nil, InvalidFilter.New(fmt.Sprintf("unsupported filter operator: %v", op))
+	}
+
+	var v any
+	err := json.Unmarshal([]byte(strings.TrimSpace(value)), &v)
+	if err != nil {
+		return nil, InvalidFilter.New(fmt.Sprintf("invalid filter value: %v", value))
+	}
+
+	switch v.(type) {
+	case string, bool:
+		break
+	default:
+		return nil, InvalidFilter.New(fmt.Sprintf("unsupported filter value: %v", value))
+	}
+
+	return &Filter{
+		AttrPath: trimSchemaURN(attrPath),
+		Operator: op,
+		Value:    v,
+	}, nil
+}
+
+// Path is the target of a PATCH operation defined in RFC7644 Section 3.5.2.
+type Path struct {
+	Attr        string
+	ValueFilter *Filter
+	SubAttr     string
+}
+
+// IsAttr reports whether the path targets attr. Attribute names are case-insensitive.
+func (p *Path) IsAttr(attr string) bool {
+	return strings.EqualFold(p.Attr, attr)
+}
+
+// ParsePath parses paths of the form `attr`, `attr.subAttr`, and `attr[valueFilter]`
+// optionally followed by `.subAttr`.
+func ParsePath(s string) (*Path, error) {
+	s = trimSchemaURN(strings.TrimSpace(s))
+	if s == "" {
+		return nil, InvalidPath.New("path is empty")
+	}
+
+	p := &Path{}
+
+	if i := strings.Index(s, "["); i >= 0 {
+		j := strings.LastIndex(s, "]")
+		if j < i {
+			return nil, InvalidPath.New(fmt.Sprintf("invalid path: %v", s))
+		}
+
+		filter, err := ParseFilter(s[i+1 : j])
+		if err != nil {
+			return nil, InvalidPath.New(fmt.Sprintf("invalid path: %v", s))
+		}
+
+		p.Attr = s[:i]
+		p.ValueFilter = filter
+
+		rest := s[j+1:]
+		if rest != "" {
+			subAttr, ok := strings.CutPrefix(rest, ".")
+			if !ok || subAttr == "" {
+				return nil, InvalidPath.New(fmt.Sprintf("invalid path: %v", s))
+			}
+			p.SubAttr = subAttr
+		}
+	} else {
+		attr, subAttr, _ := strings.Cut(s, ".")
+		p.Attr = attr
+		p.SubAttr = subAttr
+	}
+
+	if p.Attr == "" || strings.ContainsAny(p.Attr, " ]") || strings.ContainsAny(p.SubAttr, " .[]") {
+		return nil, InvalidPath.New(fmt.Sprintf("invalid path: %v", s))
+	}
+
+	return p, nil
+}
+
+// trimSchemaURN turns `urn:ietf:params:scim:schemas:core:2.0:User:userName` into `userName`.
+func trimSchemaURN(attrPath string) string {
+	if !strings.HasPrefix(strings.ToLower(attrPath), "urn:") {
+		return attrPath
+	}
+	// The filter part may contain colons, so only look at the part before it.
+	head := attrPath
+	if i := strings.Index(attrPath, "["); i >= 0 {
+		head = attrPath[:i]
+	}
+	i := strings.LastIndex(head, ":")
+	return attrPath[i+1:]
+}
@@ -0,0 +1,144 @@
+package scim
+
+import (
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseFilter(t *testing.T) {
+	Convey("ParseFilter", t, func() {
+		Convey("should parse eq filter", func() {
+			f, err := ParseFilter(`userName eq "john@example.com"`)
+			So(err, ShouldBeNil)
+			So(f, ShouldResemble, &Filter{
+				AttrPath: "userName",
+				Operator: OperatorEqual,
+				Value:    "john@example.com",
+			})
+		})
+
+		Convey("should accept operator in any case and value with spaces", func() {
+			f, err := ParseFilter(`displayName EQ "Sales Team"`)
+			So(err, ShouldBeNil)
+			So(f.IsAttr("displayname"), ShouldBeTrue)
+			v, ok := f.StringValue()
+			So(ok, ShouldBeTrue)
+			So(v, ShouldEqual, "Sales Team")
+		})
+
+		Convey("should strip schema URN", func() {
+			f, err := ParseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "john"`)
+			So(err, ShouldBeNil)
+			So(f.AttrPath, ShouldEqual, "userName")
+		})
+
+		Convey("should reject unsupported filters", func() {
+			var err error
+			_, err = ParseFilter(`userName`)
+			So(err, ShouldBeError, "filter must be in the form of `attrPath eq value`")
+			_, err = ParseFilter(`userName sw "john"`)
+			So(err, ShouldBeError, "unsupported filter operator: sw")
+			_, err = ParseFilter(`userName eq "a" or userName eq "b"`)
+			So(err, ShouldBeError, `invalid filter value: "a" or userName eq "b"`)
+			_, err = ParseFilter(`userName eq 1`)
+			So(err, ShouldBeError, "unsupported filter value: 1")
+		})
+	})
+}
+
+func TestParsePath(t *testing.T) {
+	Convey("ParsePath", t, func() {
+		Convey("should parse simple path", func() {
+			p, err := ParsePath("active")
+			So(err, ShouldBeNil)
+			So(p, ShouldResemble, &Path{Attr: "active"})
+		})
+
+		Convey("should parse sub-attribute", func() {
+			p, err := ParsePath("urn:ietf:params:scim:schemas:core:2.0:User:name.givenName")
+			So(err, ShouldBeNil)
+			So(p, ShouldResemble, &Path{Attr: "name", SubAttr: "givenName"})
+		})
+
+		Convey("should parse value filter", func() {
+			p, err := ParsePath(`members[value eq "2819c223"]`)
+			So(err, ShouldBeNil)
+			So(p, ShouldResemble, &Path{
+				Attr: "members",
+				ValueFilter: &Filter{
+					AttrPath: "value",
+					Operator: OperatorEqual,
+					Value:    "2819c223",
+				},
+			})
+
+			p, err = ParsePath(`emails[type eq "work"].value`)
+			So(err, ShouldBeNil)
+			So(p.Attr, ShouldEqual, "emails")
+			So(p.SubAttr, ShouldEqual, "value")
+		})
+
+		Convey("should reject invalid path", func() {
+			var err error
+			_, err = ParsePath("")
+			So(err, ShouldBeError, "path is empty")
+			_, err = ParsePath(`members[value eq "a"`)
+			So(err, ShouldBeError, `invalid path: members[value eq "a"`)
+			_, err = ParsePath(`members[value eq "a"]value`)
+			So(err, ShouldBeError, `invalid path: members[value eq "a"]value`)
+		})
+	})
+}
+
+func TestPatchOperation(t *testing.T) {
+	Convey("PatchOperation", t, func() {
+		Convey("should normalize op", func() {
+			op := &PatchOperation{Op: "Replace", Path: "active", Value: false}
+			p, err := op.Normalize()
+			So(err, ShouldBeNil)
+			So(op.Op, ShouldEqual, PatchOpReplace)
+			So(p.IsAttr("active"), ShouldBeTrue)
+		})
+
+		Convey("should require path for remove", func() {
+			op := &PatchOperation{Op: "remove"}
+			_, err := op.Normalize()
+			So(err, ShouldBeError, "path is required for remove")
+		})
+
+		Convey("should flatten value without path", func() {
+			op := &PatchOperation{Op: "replace", Value: map[string]any{
+				"name": map[string]any{"givenName": "John"},
+			}}
+			ops, err := op.FlattenValue()
+			So(err, ShouldBeNil)
+			So(ops, ShouldResemble, []*PatchOperation{
+				{Op: "replace", Path: "name.givenName", Value: "John"},
+			})
+		})
+	})
+}
+
+func TestParseListOptions(t *testing.T) {
+	Convey("ParseListOptions", t, func() {
+		Convey("should use defaults", func() {
+			o, err := ParseListOptions(url.Values{})
+			So(err, ShouldBeNil)
+			So(o, ShouldResemble, &ListOptions{StartIndex: 1, Count: DefaultCount})
+		})
+
+		Convey("should clamp startIndex and count", func() {
+			o, err := ParseListOptions(url.Values{
+				"startIndex":         []string{"0"},
+				"count":              []string{"5000"},
+				"excludedAttributes": []string{"members"},
+			})
+			So(err, ShouldBeNil)
+			So(o.StartIndex, ShouldEqual, 1)
+			So(o.Count, ShouldEqual, MaxCount)
+			So(o.IsExcluded("Members"), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,168 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+// BodyMaxSize is the maximum size of a request body.
+const BodyMaxSize = 1024 * 1024
+
+var UserSchema = validation.NewSimpleSchema(`
+{
+	"type": "object",
+	"required": ["schemas", "userName"],
+	"properties": {
+		"schemas": {
+			"type": "array",
+			"contains": { "const": "urn:ietf:params:scim:schemas:core:2.0:User" }
+		},
+		"userName": { "type": "string", "minLength": 1 },
+		"active": { "type": "boolean" },
+		"name": { "type": "object" }
+	}
+}
+`)
+
+var GroupSchema = validation.NewSimpleSchema(`
+{
+	"type": "object",
+	"required": ["schemas", "displayName"],
+	"properties": {
+		"schemas": {
+			"type": "array",
+			"contains": { "const": "urn:ietf:params:scim:schemas:core:2.0:Group" }
+		},
+		"displayName": { "type": "string", "minLength": 1 },
+		"members": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"required": ["value"],
+				"properties": {
+					"value": { "type": "string" }
+				}
+			}
+		}
+	}
+}
+`)
+
+// ParseBody is like httputil.BindJSONBody, except that application/scim+json is accepted.
+func ParseBody(r *http.Request, w http.ResponseWriter, v *validation.SchemaValidator, payload any) error {
+	const errorMessage = "invalid request body"
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != ContentType && mediaType != "application/json") {
+		return ErrUnsupportedContentType
+	}
+
+	body := http.MaxBytesReader(w, r.Body, BodyMaxSize)
+	defer body.Close()
+
+	err = v.ParseWithMessage(r.Context(), body, errorMessage, payload)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return httputil.JSONTooLarge.NewWithInfo("request body too large", apierrors.Details{
+				"limit": maxBytesError.Limit,
+			})
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ParseListOptions parses the query parameters of RFC7644 Section 3.4.2.
+func ParseListOptions(query url.Values) (*ListOptions, error) {
+	options := &ListOptions{
+		StartIndex: 1,
+		Count:      DefaultCount,
+	}
+
+	if s := query.Get("filter"); s != "" {
+		filter, err := ParseFilter(s)
+		if err != nil {
+			return nil, err
+		}
+		options.Filter = filter
+	}
+
+	if s := query.Get("startIndex"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, InvalidValue.New("startIndex must be an integer")
+		}
+		// RFC7644 Section 3.4.2.4: A value less than 1 SHALL be interpreted as 1.
+		if i > 1 {
+			options.StartIndex = i
+		}
+	}
+
+	if s := query.Get("count"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, InvalidValue.New("count must be an integer")
+		}
+		// RFC7644 Section 3.4.2.4: A negative value SHALL be interpreted as 0.
+		options.Count = max(0, min(i, MaxCount))
+	}
+
+	if s := query.Get("excludedAttributes"); s != "" {
+		for _, attr := range strings.Split(s, ",") {
+			options.ExcludedAttributes = append(options.ExcludedAttributes, strings.ToLower(trimSchemaURN(strings.TrimSpace(attr))))
+		}
+	}
+
+	return options, nil
+}
+
+func WriteResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	if body == nil {
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		panic(err)
+	}
+}
+
+// WriteError writes err in the format of RFC7644 Section 3.12.
+func WriteError(ctx context.Context, w http.ResponseWriter, err error) {
+	apiError := apierrors.AsAPIErrorWithContext(ctx, err)
+
+	status := apiError.Code
+	scimType := ""
+	if status == http.StatusBadRequest {
+		scimType = scimTypes[apiError.Reason]
+		// RFC7644 Section 3.3: uniqueness is reported with 409 Conflict.
+		if scimType == "uniqueness" {
+			status = http.StatusConflict
+		}
+	}
+
+	WriteResponse(w, status, &Error{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   apiError.Message,
+	})
+
+	if apiError.Code >= 500 && apiError.Code < 600 {
+		logger := httputil.JSONResponseWriterLogger.GetLogger(ctx)
+		logger.WithError(err).Error(ctx, "unexpected error occurred")
+	}
+}
@@ -0,0 +1,95 @@
+package scim
+
+import (
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+const (
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+	PatchOpReplace = "replace"
+)
+
+var PatchRequestSchema = validation.NewSimpleSchema(`
+{
+	"type": "object",
+	"required": ["schemas", "Operations"],
+	"properties": {
+		"schemas": {
+			"type": "array",
+			"contains": { "const": "urn:ietf:params:scim:api:messages:2.0:PatchOp" }
+		},
+		"Operations": {
+			"type": "array",
+			"minItems": 1,
+			"items": {
+				"type": "object",
+				"required": ["op"],
+				"properties": {
+					"op": { "type": "string" },
+					"path": { "type": "string" }
+				}
+			}
+		}
+	}
+}
+`)
+
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Normalize lower-cases the op and parses the path.
+// Some clients send capitalized op like "Replace", which is accepted.
+func (o *PatchOperation) Normalize() (*Path, error) {
+	o.Op = strings.ToLower(o.Op)
+	switch o.Op {
+	case PatchOpAdd, PatchOpReplace:
+		if o.Value == nil {
+			return nil, InvalidValue.New("value is required for " + o.Op)
+		}
+	case PatchOpRemove:
+		if o.Path == "" {
+			return nil, NoTarget.New("path is required for remove")
+		}
+	default:
+		return nil, InvalidSyntax.New("unsupported patch op: " + o.Op)
+	}
+
+	if o.Path == "" {
+		return nil, nil
+	}
+	return ParsePath(o.Path)
+}
+
+// FlattenValue turns a patch operation without path into operations with path.
+// RFC7644 Section 3.5.2.1 allows the value to be an object of attributes to add or replace.
+func (o *PatchOperation) FlattenValue() ([]*PatchOperation, error) {
+	m, ok := o.Value.(map[string]any)
+	if !ok {
+		return nil, InvalidValue.New("value must be an object when path is absent")
+	}
+
+	var ops []*PatchOperation
+	for key, value := range m {
+		key = trimSchemaURN(key)
+		// Some clients send `{"name.givenName": "John"}` instead of a nested object.
+		if nested, ok := value.(map[string]any); ok && !strings.Contains(key, ".") {
+			for subKey, subValue := range nested {
+				ops = append(ops, &PatchOperation{Op: o.Op, Path: key + "." + subKey, Value: subValue})
+			}
+			continue
+		}
+		ops = append(ops, &PatchOperation{Op: o.Op, Path: key, Value: value})
+	}
+	return ops, nil
+}
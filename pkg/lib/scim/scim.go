@@ -0,0 +1,114 @@
+package scim
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type defined in RFC7644 Section 8.1.
+const ContentType = "application/scim+json"
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+const (
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// DefaultCount is the page size used when the client does not specify count.
+const DefaultCount = 100
+
+// MaxCount is the largest page size a client can request.
+const MaxCount = 1000
+
+type Meta struct {
+	ResourceType string     `json:"resourceType,omitempty"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	MiddleName string `json:"middleName,omitempty"`
+}
+
+type MultiValuedAttribute struct {
+	Value   string `json:"value,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type User struct {
+	Schemas      []string               `json:"schemas"`
+	ID           string                 `json:"id,omitempty"`
+	ExternalID   string                 `json:"externalId,omitempty"`
+	UserName     string                 `json:"userName,omitempty"`
+	Name         *Name                  `json:"name,omitempty"`
+	DisplayName  string                 `json:"displayName,omitempty"`
+	NickName     string                 `json:"nickName,omitempty"`
+	ProfileURL   string                 `json:"profileUrl,omitempty"`
+	Locale       string                 `json:"locale,omitempty"`
+	Timezone     string                 `json:"timezone,omitempty"`
+	Active       *bool                  `json:"active,omitempty"`
+	Emails       []MultiValuedAttribute `json:"emails,omitempty"`
+	PhoneNumbers []MultiValuedAttribute `json:"phoneNumbers,omitempty"`
+	Groups       []MultiValuedAttribute `json:"groups,omitempty"`
+	Meta         *Meta                  `json:"meta,omitempty"`
+}
+
+type Group struct {
+	Schemas     []string               `json:"schemas"`
+	ID          string                 `json:"id,omitempty"`
+	ExternalID  string                 `json:"externalId,omitempty"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	Members     []MultiValuedAttribute `json:"members,omitempty"`
+	Meta        *Meta                  `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+func NewListResponse(resources []any, totalResults int, startIndex int) *ListResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// ListOptions is the parsed form of the query parameters of a list request.
+// See RFC7644 Section 3.4.2.
+type ListOptions struct {
+	Filter *Filter
+	// StartIndex is 1-based.
+	StartIndex int
+	Count      int
+	// ExcludedAttributes is lower-cased.
+	ExcludedAttributes []string
+}
+
+func (o *ListOptions) IsExcluded(attr string) bool {
+	return slices.Contains(o.ExcludedAttributes, strings.ToLower(attr))
+}
@@ -120,6 +120,8 @@ func (s *SourceProvider) getSource(ctx context.Context, user *user.User) (*model
 			// No additional fields
 		case model.IdentityTypeLDAP:
 			// No additional fields
+		case model.IdentityTypeSAML:
+			// No additional fields
 		default:
 			panic(fmt.Errorf("search: unknown identity type %s", identityInfo.Type))
 		}
@@ -20,6 +20,7 @@ func NewAttrs(userID string) *Attrs {
 func NewAttrsFromAuthenticationInfo(info authenticationinfo.T) *Attrs {
 	attrs := NewAttrs(info.UserID)
 	attrs.SetAMR(info.AMR)
+	attrs.SetOrganizationID(info.OrganizationID)
 	return attrs
 }
 
@@ -35,3 +36,16 @@ func (a *Attrs) SetAMR(value []string) {
 		delete(a.Claims, model.ClaimAMR)
 	}
 }
+
+func (a *Attrs) GetOrganizationID() (string, bool) {
+	orgID, ok := a.Claims[model.ClaimOrganizationID].(string)
+	return orgID, ok
+}
+
+func (a *Attrs) SetOrganizationID(value string) {
+	if value != "" {
+		a.Claims[model.ClaimOrganizationID] = value
+	} else {
+		delete(a.Claims, model.ClaimOrganizationID)
+	}
+}
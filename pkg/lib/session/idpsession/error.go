@@ -1,5 +1,14 @@
 package idpsession
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
 
 var ErrSessionNotFound = errors.New("session is not found")
+
+var ErrConcurrentSessionLimitExceeded = apierrors.Forbidden.
+	WithReason("ConcurrentSessionLimitExceeded").
+	SkipLoggingToExternalService().
+	New("concurrent session limit exceeded")
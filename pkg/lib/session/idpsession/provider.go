@@ -217,6 +217,46 @@ func (p *Provider) AddSAMLServiceProviderParticipant(ctx context.Context, sessio
 	return result, err
 }
 
+func (p *Provider) AddOIDCClientParticipant(ctx context.Context, session *IDPSession, clientID string) (*IDPSession, error) {
+	mutexName := sessionMutexName(p.AppID, session.ID)
+	var result *IDPSession
+	err := p.Redis.WithMutex(ctx, mutexName, func() error {
+		s, err := p.Get(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		newParticipatedOIDCClientIDs := s.GetParticipatedOIDCClientIDsSet()
+		newParticipatedOIDCClientIDs.Add(clientID)
+		s.ParticipatedOIDCClientIDs = newParticipatedOIDCClientIDs.Keys()
+		if err = p.Store.Update(ctx, s, s.ExpireAtForResolvedSession); err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+		result = s
+		return nil
+	})
+
+	return result, err
+}
+
+func (p *Provider) UpdateDeviceName(ctx context.Context, session *IDPSession, deviceName string) (*IDPSession, error) {
+	mutexName := sessionMutexName(p.AppID, session.ID)
+	var result *IDPSession
+	err := p.Redis.WithMutex(ctx, mutexName, func() error {
+		s, err := p.Get(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		s.DeviceName = deviceName
+		if err = p.Store.Update(ctx, s, s.ExpireAtForResolvedSession); err != nil {
+			return fmt.Errorf("failed to update session: %w", err)
+		}
+		result = s
+		return nil
+	})
+
+	return result, err
+}
+
 func (p *Provider) CheckSessionExpired(session *IDPSession) (expired bool) {
 	now := p.Clock.NowUTC()
 	cloned := *session
@@ -228,6 +268,87 @@ func (p *Provider) CheckSessionExpired(session *IDPSession) (expired bool) {
 	return
 }
 
+// ListActive lists the sessions of the user that are not expired, in ascending creation time order.
+func (p *Provider) ListActive(ctx context.Context, userID string) ([]*IDPSession, error) {
+	sessions, err := p.Store.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []*IDPSession
+	for _, s := range sessions {
+		if p.CheckSessionExpired(s) {
+			continue
+		}
+		active = append(active, s)
+	}
+	return active, nil
+}
+
+// ListExcessSessions returns the oldest active sessions of the user that have to end,
+// so that the user does not exceed the concurrent session limit after a new session is created.
+// The session identified by excludeSessionID is not counted, as it is about to be replaced.
+func (p *Provider) ListExcessSessions(ctx context.Context, userID string, excludeSessionID string) ([]*IDPSession, error) {
+	if !p.Config.IsConcurrentSessionLimitEnabled() {
+		return nil, nil
+	}
+
+	active, err := p.ListActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var counted []*IDPSession
+	for _, s := range active {
+		if s.ID == excludeSessionID {
+			continue
+		}
+		counted = append(counted, s)
+	}
+
+	// Leave room for the new session.
+	n := len(counted) - (p.Config.MaxConcurrentSessions - 1)
+	if n <= 0 {
+		return nil, nil
+	}
+	return counted[:n], nil
+}
+
+// ListSessionsToEnd returns the active sessions of the user that have to end before a new session is created,
+// so that the user does not exceed the concurrent session limit.
+// If endOthers is true, the user has confirmed to end all the other sessions, so they are all returned.
+// It returns ErrConcurrentSessionLimitExceeded if the limit is exceeded and the policy is reject.
+//
+// Every path creating a session must end the returned sessions before calling Create.
+func (p *Provider) ListSessionsToEnd(ctx context.Context, userID string, excludeSessionID string, endOthers bool) ([]*IDPSession, error) {
+	excessSessions, err := p.ListExcessSessions(ctx, userID, excludeSessionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(excessSessions) == 0 {
+		return nil, nil
+	}
+
+	switch {
+	case endOthers:
+		active, err := p.ListActive(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		var others []*IDPSession
+		for _, s := range active {
+			if s.ID != excludeSessionID {
+				others = append(others, s)
+			}
+		}
+		return others, nil
+	case p.Config.ConcurrentSessionLimitPolicy == config.ConcurrentSessionLimitPolicyReject:
+		return nil, ErrConcurrentSessionLimitExceeded
+	default:
+		return excessSessions, nil
+	}
+}
+
 func (p *Provider) generateToken(s *IDPSession) string {
 	token := encodeToken(s.ID, corerand.StringWithAlphabet(tokenLength, tokenAlphabet, p.Random))
 	s.TokenHash = hashToken(token)
@@ -125,6 +125,135 @@ func TestProvider(t *testing.T) {
 		})
 	})
 
+	Convey("ListExcessSessions", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := NewMockStore(ctrl)
+		clock := clock.NewMockClockAt("2020-01-01T01:00:00Z")
+
+		disabled := false
+		cfg := &config.SessionConfig{
+			Lifetime:           7200,
+			IdleTimeoutEnabled: &disabled,
+		}
+		provider := &Provider{
+			Store:  store,
+			Config: cfg,
+			Clock:  clock,
+		}
+
+		makeSession := func(id string, createdAt string) *IDPSession {
+			t, _ := time.Parse(time.RFC3339, createdAt)
+			return &IDPSession{
+				ID:        id,
+				Attrs:     session.Attrs{UserID: "user-id"},
+				CreatedAt: t,
+			}
+		}
+		expired := makeSession("expired", "2019-12-31T00:00:00Z")
+		s1 := makeSession("s1", "2020-01-01T00:00:00Z")
+		s2 := makeSession("s2", "2020-01-01T00:10:00Z")
+		s3 := makeSession("s3", "2020-01-01T00:20:00Z")
+		ctx := context.Background()
+
+		Convey("should return nothing if the limit is disabled", func() {
+			sessions, err := provider.ListExcessSessions(ctx, "user-id", "")
+			So(err, ShouldBeNil)
+			So(sessions, ShouldBeEmpty)
+		})
+
+		Convey("should return the oldest active sessions", func() {
+			cfg.MaxConcurrentSessions = 2
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{expired, s1, s2, s3}, nil)
+
+			sessions, err := provider.ListExcessSessions(ctx, "user-id", "")
+			So(err, ShouldBeNil)
+			So(sessions, ShouldResemble, []*IDPSession{s1, s2})
+		})
+
+		Convey("should not count the excluded session", func() {
+			cfg.MaxConcurrentSessions = 3
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{expired, s1, s2, s3}, nil)
+
+			sessions, err := provider.ListExcessSessions(ctx, "user-id", "s3")
+			So(err, ShouldBeNil)
+			So(sessions, ShouldBeEmpty)
+
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{expired, s1, s2, s3}, nil)
+			sessions, err = provider.ListExcessSessions(ctx, "user-id", "")
+			So(err, ShouldBeNil)
+			So(sessions, ShouldResemble, []*IDPSession{s1})
+		})
+	})
+
+	Convey("ListSessionsToEnd", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := NewMockStore(ctrl)
+		clock := clock.NewMockClockAt("2020-01-01T01:00:00Z")
+
+		disabled := false
+		cfg := &config.SessionConfig{
+			Lifetime:                     7200,
+			IdleTimeoutEnabled:           &disabled,
+			MaxConcurrentSessions:        2,
+			ConcurrentSessionLimitPolicy: config.ConcurrentSessionLimitPolicyEvictOldest,
+		}
+		provider := &Provider{
+			Store:  store,
+			Config: cfg,
+			Clock:  clock,
+		}
+
+		makeSession := func(id string, createdAt string) *IDPSession {
+			t, _ := time.Parse(time.RFC3339, createdAt)
+			return &IDPSession{
+				ID:        id,
+				Attrs:     session.Attrs{UserID: "user-id"},
+				CreatedAt: t,
+			}
+		}
+		s1 := makeSession("s1", "2020-01-01T00:00:00Z")
+		s2 := makeSession("s2", "2020-01-01T00:10:00Z")
+		s3 := makeSession("s3", "2020-01-01T00:20:00Z")
+		ctx := context.Background()
+
+		Convey("should return nothing if the limit is not exceeded", func() {
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{s1}, nil)
+
+			sessions, err := provider.ListSessionsToEnd(ctx, "user-id", "", false)
+			So(err, ShouldBeNil)
+			So(sessions, ShouldBeEmpty)
+		})
+
+		Convey("should return the oldest sessions if the policy is evict_oldest", func() {
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{s1, s2, s3}, nil)
+
+			sessions, err := provider.ListSessionsToEnd(ctx, "user-id", "", false)
+			So(err, ShouldBeNil)
+			So(sessions, ShouldResemble, []*IDPSession{s1, s2})
+		})
+
+		Convey("should reject if the policy is reject", func() {
+			cfg.ConcurrentSessionLimitPolicy = config.ConcurrentSessionLimitPolicyReject
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{s1, s2, s3}, nil)
+
+			_, err := provider.ListSessionsToEnd(ctx, "user-id", "", false)
+			So(err, ShouldBeError, ErrConcurrentSessionLimitExceeded)
+		})
+
+		Convey("should return all the other sessions if the user confirmed to end them", func() {
+			cfg.ConcurrentSessionLimitPolicy = config.ConcurrentSessionLimitPolicyReject
+			store.EXPECT().List(gomock.Any(), "user-id").Return([]*IDPSession{s1, s2, s3}, nil).Times(2)
+
+			sessions, err := provider.ListSessionsToEnd(ctx, "user-id", "s3", true)
+			So(err, ShouldBeNil)
+			So(sessions, ShouldResemble, []*IDPSession{s1, s2})
+		})
+	})
+
 	enabled := true
 	disabled := false
 
@@ -26,7 +26,12 @@ type IDPSession struct {
 
 	TokenHash string `json:"token_hash"`
 
+	// DeviceName is the name given to the device by the user.
+	// It takes precedence over the name derived from the user agent.
+	DeviceName string `json:"device_name,omitempty"`
+
 	ParticipatedSAMLServiceProviderIDs []string `json:"participated_saml_service_provider_ids,omitempty"`
+	ParticipatedOIDCClientIDs          []string `json:"participated_oidc_client_ids,omitempty"`
 
 	// ExpireAtForResolvedSession is a transient field that tells when the session will exire at, computed now.
 	// Note that ExpireAtForResolvedSession will keep changing if idle timeout is enabled.
@@ -74,6 +79,10 @@ func (s *IDPSession) ToAPIModel() *model.Session {
 		UserAgent:   ua.Raw,
 	}
 
+	if s.DeviceName != "" {
+		apiModel.DisplayName = s.DeviceName
+	}
+
 	ipInfo, ok := geoip.IPString(s.AccessInfo.LastAccess.RemoteIP)
 	if ok {
 		apiModel.LastAccessedByIPCountryCode = ipInfo.CountryCode
@@ -85,19 +94,23 @@ func (s *IDPSession) ToAPIModel() *model.Session {
 
 func (s *IDPSession) GetAuthenticationInfo() authenticationinfo.T {
 	amr, _ := s.GetOIDCAMR()
+	orgID, _ := s.Attrs.GetOrganizationID()
 	return authenticationinfo.T{
 		UserID:          s.GetUserID(),
 		AuthenticatedAt: s.GetAuthenticatedAt(),
 		AMR:             amr,
+		OrganizationID:  orgID,
 	}
 }
 
 func (s *IDPSession) CreateNewAuthenticationInfoByThisSession() authenticationinfo.T {
 	amr, _ := s.GetOIDCAMR()
+	orgID, _ := s.Attrs.GetOrganizationID()
 	return authenticationinfo.T{
 		UserID:                     s.GetUserID(),
 		AuthenticatedAt:            s.GetAuthenticatedAt(),
 		AMR:                        amr,
+		OrganizationID:             orgID,
 		AuthenticatedBySessionType: string(s.SessionType()),
 		AuthenticatedBySessionID:   s.SessionID(),
 	}
@@ -127,3 +140,7 @@ func (s *IDPSession) EqualSession(ss session.SessionBase) bool {
 func (s *IDPSession) GetParticipatedSAMLServiceProviderIDsSet() setutil.Set[string] {
 	return setutil.NewSetFromSlice(s.ParticipatedSAMLServiceProviderIDs, setutil.Identity)
 }
+
+func (s *IDPSession) GetParticipatedOIDCClientIDsSet() setutil.Set[string] {
+	return setutil.NewSetFromSlice(s.ParticipatedOIDCClientIDs, setutil.Identity)
+}
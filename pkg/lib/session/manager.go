@@ -41,10 +41,15 @@ type EventService interface {
 	DispatchEventOnCommit(ctx context.Context, payload event.Payload) error
 }
 
+type BackchannelLogoutService interface {
+	SendBackchannelLogout(ctx context.Context, sessions []ListableSession) error
+}
+
 type Manager struct {
 	IDPSessions         IDPSessionManager
 	AccessTokenSessions AccessTokenSessionManager
 	Events              EventService
+	BackchannelLogout   BackchannelLogoutService
 }
 
 func (m *Manager) resolveManagementProvider(session ListableSession) ManagementService {
@@ -129,6 +134,11 @@ func (m *Manager) invalidate(ctx context.Context, session SessionBase, option *r
 		if err != nil {
 			return nil, nil, err
 		}
+
+		err = m.BackchannelLogout.SendBackchannelLogout(ctx, invalidatedSessions)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	return invalidatedSessions, provider, nil
@@ -221,6 +231,11 @@ func (m *Manager) TerminateAllExcept(ctx context.Context, userID string, current
 		if err != nil {
 			return err
 		}
+
+		err = m.BackchannelLogout.SendBackchannelLogout(ctx, append(idpSessions, accessGrantSessions...))
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -15,6 +15,8 @@ const (
 	MessageTypeSendPasswordToNewUser      MessageType = "send-password-to-new-user"
 	MessageTypeUsageAlert                 MessageType = "usage-alert"
 	MessageTypeWhatsappCode               MessageType = "whatsapp-code"
+	MessageTypeBackchannelAuthentication  MessageType = "backchannel-authentication"
+	MessageTypeOrganizationInvitation     MessageType = "organization-invitation"
 )
 
 var (
@@ -68,6 +70,13 @@ var (
 
 	TemplateMessageUsageAlertEmailTXT  = template.RegisterMessagePlainText("messages/usage_alert_email.txt")
 	TemplateMessageUsageAlertEmailHTML = template.RegisterMessageHTML("messages/usage_alert_email.html")
+
+	TemplateMessageBackchannelAuthenticationSMSTXT    = template.RegisterMessagePlainText("messages/backchannel_authentication_sms.txt")
+	TemplateMessageBackchannelAuthenticationEmailTXT  = template.RegisterMessagePlainText("messages/backchannel_authentication_email.txt")
+	TemplateMessageBackchannelAuthenticationEmailHTML = template.RegisterMessageHTML("messages/backchannel_authentication_email.html")
+
+	TemplateMessageOrganizationInvitationEmailTXT  = template.RegisterMessagePlainText("messages/organization_invitation_email.txt")
+	TemplateMessageOrganizationInvitationEmailHTML = template.RegisterMessageHTML("messages/organization_invitation_email.html")
 )
 
 type SpecName string
@@ -89,6 +98,8 @@ const (
 	SpecNameSendPasswordToExistingUser     SpecName = "send-password-to-existing-user"
 	SpecNameSendPasswordToNewUser          SpecName = "send-password-to-new-user"
 	SpecNameUsageAlert                     SpecName = "usage-alert"
+	SpecNameBackchannelAuthentication      SpecName = "backchannel-authentication"
+	SpecNameOrganizationInvitation         SpecName = "organization-invitation"
 )
 
 var (
@@ -188,4 +199,17 @@ var (
 		TXTEmailTemplate:  TemplateMessageUsageAlertEmailTXT,
 		HTMLEmailTemplate: TemplateMessageUsageAlertEmailHTML,
 	}
+	MessageBackchannelAuthentication = &MessageSpec{
+		MessageType:       MessageTypeBackchannelAuthentication,
+		Name:              SpecNameBackchannelAuthentication,
+		TXTEmailTemplate:  TemplateMessageBackchannelAuthenticationEmailTXT,
+		HTMLEmailTemplate: TemplateMessageBackchannelAuthenticationEmailHTML,
+		SMSTemplate:       TemplateMessageBackchannelAuthenticationSMSTXT,
+	}
+	MessageOrganizationInvitation = &MessageSpec{
+		MessageType:       MessageTypeOrganizationInvitation,
+		Name:              SpecNameOrganizationInvitation,
+		TXTEmailTemplate:  TemplateMessageOrganizationInvitationEmailTXT,
+		HTMLEmailTemplate: TemplateMessageOrganizationInvitationEmailHTML,
+	}
 )
@@ -316,18 +316,20 @@ func (s *Service) prepareTemplateVariables(ctx context.Context, v *PartialTempla
 	}
 
 	return &PreparedTemplateVariables{
-		AppID:       v.AppID,
-		AppName:     appName,
-		ClientID:    uiParams.ClientID,
-		ClientName:  clientName,
-		Code:        v.Code,
-		Email:       v.Email,
-		HasPassword: v.HasPassword,
-		Host:        v.Host,
-		Link:        v.Link,
-		Password:    v.Password,
-		Phone:       v.Phone,
-		State:       uiParams.State,
+		AppID:            v.AppID,
+		AppName:          appName,
+		BindingMessage:   v.BindingMessage,
+		ClientID:         uiParams.ClientID,
+		ClientName:       clientName,
+		Code:             v.Code,
+		Email:            v.Email,
+		HasPassword:      v.HasPassword,
+		Host:             v.Host,
+		Link:             v.Link,
+		OrganizationName: v.OrganizationName,
+		Password:         v.Password,
+		Phone:            v.Phone,
+		State:            uiParams.State,
 		StaticAssetURL: func(id string) (url string, err error) {
 			return s.StaticAssets.StaticAssetURL(ctx, id)
 		},
@@ -22,11 +22,18 @@ type PartialTemplateVariables struct {
 	UsagePeriod       string
 	UsageQuota        int
 	UsageCurrentValue int
+
+	// Backchannel authentication
+	BindingMessage string
+
+	// Organization invitation
+	OrganizationName string
 }
 
 type PreparedTemplateVariables struct {
 	AppID             string
 	AppName           string
+	BindingMessage    string
 	ClientID          string
 	ClientName        string
 	Code              string
@@ -34,6 +41,7 @@ type PreparedTemplateVariables struct {
 	HasPassword       bool
 	Host              string
 	Link              string
+	OrganizationName  string
 	Password          string
 	Phone             string
 	State             string
@@ -5,11 +5,10 @@ import (
 	"errors"
 	"log/slog"
 
-	"gopkg.in/gomail.v2"
-
 	"github.com/authgear/authgear-server/pkg/api/event/nonblocking"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
 )
 
@@ -18,8 +17,8 @@ type TranslationService interface {
 }
 
 type MailSender interface {
-	PrepareMessage(opts mail.SendOptions) (*gomail.Message, error)
-	Send(*gomail.Message) error
+	Send(ctx context.Context, client mailapi.Client, opts mail.SendOptions) error
+	ResolveClient() (mailapi.Client, error)
 }
 
 type UsageAlertEmailService interface {
@@ -70,21 +69,26 @@ func (s *UsageAlertEmailServiceImpl) Send(ctx context.Context, recipients []stri
 		htmlBody = data.HTMLBody.String
 	}
 
+	client, err := s.MailSender.ResolveClient()
+	if err != nil {
+		return err
+	}
+
 	var errs []error
 	for _, recipient := range recipients {
-		message, err := s.MailSender.PrepareMessage(mail.SendOptions{
+		opts := mail.SendOptions{
 			Sender:    data.Sender,
 			ReplyTo:   data.ReplyTo,
 			Subject:   data.Subject,
 			Recipient: recipient,
 			TextBody:  data.TextBody.String,
 			HTMLBody:  htmlBody,
-		})
-		if err != nil {
+		}
+		if err := opts.Validate(); err != nil {
 			errs = append(errs, err)
 			continue
 		}
-		if err := s.MailSender.Send(message); err != nil {
+		if err := s.MailSender.Send(ctx, client, opts); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -111,6 +111,7 @@ type Identity struct {
 	LoginID *IdentityLoginID   `json:"login_id,omitempty"`
 	OAuth   *IdentityOAuth     `json:"oauth,omitempty"`
 	LDAP    *IdentityLDAP      `json:"ldap,omitempty"`
+	SAML    *IdentitySAML      `json:"saml,omitempty"`
 	Claims  map[string]any     `json:"claims,omitempty"`
 }
 
@@ -129,6 +130,14 @@ type IdentityLDAP struct {
 	Attributes           map[string]any `json:"attributes,omitempty"`
 }
 
+type IdentitySAML struct {
+	IdentityProviderName     string         `json:"identity_provider_name,omitempty"`
+	IdentityProviderEntityID string         `json:"identity_provider_entity_id,omitempty"`
+	NameID                   string         `json:"name_id,omitempty"`
+	NameIDFormat             string         `json:"name_id_format,omitempty"`
+	Attributes               map[string]any `json:"attributes,omitempty"`
+}
+
 type IdentityOAuth struct {
 	ProviderAlias     string         `json:"provider_alias,omitempty"`
 	ProviderType      string         `json:"provider_type,omitempty"`
@@ -142,6 +142,18 @@ func (s *UserExportService) convertDBUserToRecord(user *user.UserForExport) (rec
 				},
 				Claims: identity.LDAP.Claims,
 			})
+		case model.IdentityTypeSAML:
+			record.Identities = append(record.Identities, &Identity{
+				Type: model.IdentityTypeSAML,
+				SAML: &IdentitySAML{
+					IdentityProviderName:     identity.SAML.IdentityProviderName,
+					IdentityProviderEntityID: identity.SAML.IdentityProviderEntityID,
+					NameID:                   identity.SAML.NameID,
+					NameIDFormat:             identity.SAML.NameIDFormat,
+					Attributes:               identity.SAML.Attributes,
+				},
+				Claims: identity.SAML.Claims,
+			})
 		case model.IdentityTypeOAuth:
 			record.Identities = append(record.Identities, &Identity{
 				Type: model.IdentityTypeOAuth,
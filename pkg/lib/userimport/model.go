@@ -50,7 +50,14 @@ func init() {
 			AdditionalPropertiesFalse().
 			Required("type", "password_hash")
 		password.Properties().
-			Property("type", validation.SchemaBuilder{}.Type(validation.TypeString).Enum("bcrypt")).
+			Property("type", validation.SchemaBuilder{}.Type(validation.TypeString).Enum(
+				PasswordTypeBcrypt,
+				PasswordTypeArgon2id,
+				PasswordTypeScrypt,
+				PasswordTypeFirebaseScrypt,
+				PasswordTypePBKDF2,
+				PasswordTypeDjango,
+			)).
 			Property("password_hash", minLenStr).
 			Property("expire_after", rfc3339)
 
@@ -262,8 +269,16 @@ const (
 	IdentifierPhoneNumber       = "phone_number"
 )
 
+// The password type describes the format of password_hash.
+// The format is detected from password_hash itself,
+// so password_hash is accepted as long as it is in any of the supported formats.
 const (
-	PasswordTypeBcrypt = "bcrypt"
+	PasswordTypeBcrypt         = "bcrypt"
+	PasswordTypeArgon2id       = "argon2id"
+	PasswordTypeScrypt         = "scrypt"
+	PasswordTypeFirebaseScrypt = "firebase_scrypt"
+	PasswordTypePBKDF2         = "pbkdf2"
+	PasswordTypeDjango         = "django"
 )
 
 type Password map[string]any
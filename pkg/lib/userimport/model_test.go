@@ -928,6 +928,24 @@ func TestRecordSchema(t *testing.T) {
 				"expire_after": "2006-01-02T03:04:05Z"
 			}
 		}`, ``)
+
+		test(`{
+			"email": "user@example.com",
+			"password": {
+				"type": "argon2id",
+				"password_hash": "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+			}
+		}`, ``)
+
+		test(`{
+			"email": "user@example.com",
+			"password": {
+				"type": "md5",
+				"password_hash": "5f4dcc3b5aa765d61d8327deb882cf99"
+			}
+		}`, `invalid request body:
+/password/type: enum
+  map[actual:md5 expected:[bcrypt argon2id scrypt firebase_scrypt pbkdf2 django]]`)
 	})
 
 	Convey("Record JSON schema for mfa", t, func() {
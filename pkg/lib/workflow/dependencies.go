@@ -111,10 +111,12 @@ type IDPSessionService interface {
 	MakeSession(*session.Attrs) (*idpsession.IDPSession, string)
 	Create(ctx context.Context, s *idpsession.IDPSession) error
 	Reauthenticate(ctx context.Context, idpSessionID string, amr []string) error
+	ListSessionsToEnd(ctx context.Context, userID string, excludeSessionID string, endOthers bool) ([]*idpsession.IDPSession, error)
 }
 
 type SessionService interface {
 	RevokeWithoutEvent(ctx context.Context, s session.SessionBase) error
+	RevokeWithEvent(ctx context.Context, s session.SessionBase, isTermination bool, isAdminAPI bool) error
 }
 
 type StdAttrsService interface {
@@ -20,5 +20,5 @@ func ProvideSMTPServerCredentials(c *portalconfig.SMTPConfig) *config.SMTPServer
 
 var MailDependencySet = wire.NewSet(
 	ProvideSMTPServerCredentials,
-	mail.DependencySet,
+	mail.SMTPDependencySet,
 )
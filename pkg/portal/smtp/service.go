@@ -10,6 +10,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail/mailapi"
 	"github.com/authgear/authgear-server/pkg/portal/model"
 	"github.com/authgear/authgear-server/pkg/util/slogutil"
 )
@@ -24,8 +25,8 @@ type SendTestEmailOptions struct {
 }
 
 type MailSender interface {
-	PrepareMessage(opts mail.SendOptions) (*gomail.Message, error)
-	Send(*gomail.Message) error
+	Send(ctx context.Context, client mailapi.Client, opts mail.SendOptions) error
+	ResolveClient() (mailapi.Client, error)
 }
 
 var ServiceLogger = slogutil.NewLogger("smtp")
@@ -47,12 +48,17 @@ func (s *Service) SendRealEmail(ctx context.Context, opts mail.SendOptions) (err
 		return
 	}
 
-	message, err := s.MailSender.PrepareMessage(opts)
+	err = opts.Validate()
 	if err != nil {
 		return
 	}
 
-	err = s.MailSender.Send(message)
+	client, err := s.MailSender.ResolveClient()
+	if err != nil {
+		return
+	}
+
+	err = s.MailSender.Send(ctx, client, opts)
 	if err != nil {
 		return
 	}
@@ -174,9 +174,11 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 	devMode := environmentConfig.DevMode
 	smtpConfig := rootProvider.SMTPConfig
 	smtpServerCredentials := deps.ProvideSMTPServerCredentials(smtpConfig)
-	dialer := mail.NewGomailDialer(smtpServerCredentials)
+	clientResolver := &mail.ClientResolver{
+		SMTPServerCredentials: smtpServerCredentials,
+	}
 	sender := &mail.Sender{
-		GomailDialer: dialer,
+		ClientResolver: clientResolver,
 	}
 	smtpService := &smtp.Service{
 		DevMode:    devMode,
@@ -470,9 +472,11 @@ func newAdminAPIHandler(p *deps.RequestProvider) http.Handler {
 	devMode := environmentConfig.DevMode
 	smtpConfig := rootProvider.SMTPConfig
 	smtpServerCredentials := deps.ProvideSMTPServerCredentials(smtpConfig)
-	dialer := mail.NewGomailDialer(smtpServerCredentials)
+	clientResolver := &mail.ClientResolver{
+		SMTPServerCredentials: smtpServerCredentials,
+	}
 	sender := &mail.Sender{
-		GomailDialer: dialer,
+		ClientResolver: clientResolver,
 	}
 	smtpService := &smtp.Service{
 		DevMode:    devMode,
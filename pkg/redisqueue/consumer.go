@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	goredis "github.com/redis/go-redis/v9"
@@ -19,8 +20,10 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/util/backoff"
+	"github.com/authgear/authgear-server/pkg/util/base32"
 	"github.com/authgear/authgear-server/pkg/util/clock"
 	"github.com/authgear/authgear-server/pkg/util/panicutil"
+	"github.com/authgear/authgear-server/pkg/util/rand"
 	"github.com/authgear/authgear-server/pkg/util/signalutil"
 	"github.com/authgear/authgear-server/pkg/util/slogutil"
 )
@@ -31,6 +34,17 @@ var logger = slogutil.NewLogger("redisqueue")
 // and does not poll redis too frequently.
 var timeout = 10 * time.Second
 
+// minDequeueTimeout is the shortest timeout of BRPOPLPUSH.
+// A zero timeout would block indefinitely.
+var minDequeueTimeout = 100 * time.Millisecond
+
+// heartbeatInterval is the interval of the heartbeat of the consumer.
+var heartbeatInterval = 10 * time.Second
+
+// heartbeatTimeout is the duration without heartbeat after which
+// the tasks being processed by a consumer are considered abandoned.
+var heartbeatTimeout = 1 * time.Minute
+
 var errNoTask = errors.New("no task in queue")
 
 const taskQueueBucket ratelimit.BucketName = "TaskQueue"
@@ -40,6 +54,8 @@ type taskExecutor func() (output json.RawMessage, err error)
 
 type Consumer struct {
 	QueueName              redisqueue.QueueName
+	consumerID             string
+	concurrency            int
 	clock                  clock.Clock
 	rootProvider           *deps.RootProvider
 	configSourceController *configsource.Controller
@@ -52,7 +68,7 @@ type Consumer struct {
 
 	// shutdown is for breaking the loop.
 	shutdown chan struct{}
-	// shutdown blocks Stop until the loop has ended.
+	// shutdown blocks Stop until the loops have ended.
 	shutdownDone chan struct{}
 	// shutdownCtx is for shutdown timeout.
 	shutdownCtx context.Context
@@ -60,7 +76,8 @@ type Consumer struct {
 
 var _ signalutil.Daemon = &Consumer{}
 
-func NewConsumer(ctx context.Context, queueName redisqueue.QueueName, rateLimitConfig config.RateLimitsEnvironmentConfigEntry, rootProvider *deps.RootProvider, configSourceController *configsource.Controller, taskProcessor TaskProcessor) *Consumer {
+// NewConsumer creates a consumer that processes at most concurrency tasks at the same time.
+func NewConsumer(ctx context.Context, queueName redisqueue.QueueName, concurrency int, rateLimitConfig config.RateLimitsEnvironmentConfigEntry, rootProvider *deps.RootProvider, configSourceController *configsource.Controller, taskProcessor TaskProcessor) *Consumer {
 	redis := globalredis.NewHandle(
 		rootProvider.RedisPool,
 		&rootProvider.EnvironmentConfig.RedisConfig,
@@ -69,6 +86,8 @@ func NewConsumer(ctx context.Context, queueName redisqueue.QueueName, rateLimitC
 
 	return &Consumer{
 		QueueName:              queueName,
+		consumerID:             rand.StringWithAlphabet(32, base32.Alphabet, rand.SecureRand),
+		concurrency:            max(concurrency, 1),
 		clock:                  clock.NewSystemClock(),
 		rootProvider:           rootProvider,
 		configSourceController: configSourceController,
@@ -95,21 +114,105 @@ func (c *Consumer) DisplayName() string {
 // Start starts draining the queue and blocks indefinitely.
 // It should be called with go.
 func (c *Consumer) Start0(ctx context.Context) {
+	// Register the consumer before dequeuing,
+	// so that its tasks can be requeued if it is gone.
+	c.heartbeat(ctx)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.heartbeatLoop(ctx)
+	}()
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.workLoop(ctx)
+		}()
+	}
+	wg.Wait()
+
+	c.remove(ctx)
+	close(c.shutdownDone)
+}
+
+func (c *Consumer) workLoop(ctx context.Context) {
 	logger := logger.GetLogger(ctx)
-loop:
 	for {
 		select {
 		case <-c.shutdown:
 			logger.Info(ctx, "shutdown gracefully")
-			break loop
+			return
 		case <-c.shutdownCtx.Done():
 			logger.Info(ctx, "shutdown context timeout")
-			break loop
+			return
 		default:
 			c.work(ctx)
 		}
 	}
-	close(c.shutdownDone)
+}
+
+func (c *Consumer) heartbeatLoop(ctx context.Context) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.shutdown:
+			return
+		case <-ticker.C:
+			c.heartbeat(ctx)
+		}
+	}
+}
+
+// heartbeat tells other consumers that this consumer is alive,
+// and requeues the tasks of the consumers that are gone.
+func (c *Consumer) heartbeat(ctx context.Context) {
+	logger := logger.GetLogger(ctx)
+	err := c.redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		now := c.clock.NowUTC()
+		err := redisqueue.ConsumerHeartbeat(ctx, conn, c.QueueName, c.consumerID, now)
+		if err != nil {
+			return fmt.Errorf("heartbeat: %w", err)
+		}
+
+		n, err := redisqueue.RequeueAbandonedTasks(ctx, conn, c.QueueName, now.Add(-heartbeatTimeout))
+		if err != nil {
+			return fmt.Errorf("requeue abandoned tasks: %w", err)
+		}
+		if n > 0 {
+			logger.Warn(ctx, "requeued abandoned tasks",
+				slog.String("queue_name", string(c.QueueName)),
+				slog.Int64("count", n),
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error(ctx, "failed to send heartbeat")
+	}
+}
+
+// remove requeues the tasks that have not been completed when the consumer stops.
+func (c *Consumer) remove(ctx context.Context) {
+	logger := logger.GetLogger(ctx)
+	err := c.redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		n, err := redisqueue.RemoveConsumer(ctx, conn, c.QueueName, c.consumerID)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			logger.Info(ctx, "requeued uncompleted tasks",
+				slog.String("queue_name", string(c.QueueName)),
+				slog.Int64("count", n),
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error(ctx, "failed to remove consumer")
+	}
 }
 
 func (c *Consumer) Start(ctx context.Context) {
@@ -127,37 +230,54 @@ func (c *Consumer) Stop(ctx context.Context) error {
 	return nil
 }
 
-func (c *Consumer) dequeue(ctx context.Context) (taskExecutor, *redisqueue.Task, error) {
+// dequeue moves a task to the processing queue of the consumer.
+// The returned queue item must be acknowledged after the task is completed.
+func (c *Consumer) dequeue(ctx context.Context) (taskExecutor, *redisqueue.Task, string, error) {
 	var task redisqueue.Task
 	var appProvider *deps.AppProvider
 	var appID string
+	var queueItemStr string
 
 	err := c.redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
-		queueKey := redisqueue.RedisKeyForQueue(c.QueueName)
+		// Delayed tasks become available once they are due.
+		// Do not block longer than the next one becomes due.
+		now := c.clock.NowUTC()
+		dequeueTimeout := timeout
+		nextDueAt, err := redisqueue.PromoteDelayedTasks(ctx, conn, c.QueueName, now)
+		if err != nil {
+			return fmt.Errorf("promote delayed tasks: %w", err)
+		}
+		if nextDueAt != nil {
+			if wait := nextDueAt.Sub(now); wait < dequeueTimeout {
+				dequeueTimeout = max(wait, minDequeueTimeout)
+			}
+		}
 
-		strs, err := conn.BRPop(ctx, timeout, queueKey).Result()
+		queueItemStr, err = redisqueue.DequeueTask(ctx, conn, c.QueueName, c.consumerID, dequeueTimeout)
 		if errors.Is(err, goredis.Nil) {
 			// timeout.
 			return errNoTask
 		}
 		if err != nil {
 			// other errors.
-			return fmt.Errorf("BRPOP queue: %w", err)
+			return fmt.Errorf("BRPOPLPUSH queue: %w", err)
+		}
+
+		// The queue item cannot be processed, so it is dropped.
+		drop := func(err error) error {
+			ackErr := redisqueue.AckTask(ctx, conn, c.QueueName, c.consumerID, queueItemStr)
+			return errors.Join(err, ackErr)
 		}
 
-		// The first item in the array is the queue name.
-		// The second item in the array is the value.
-		// See https://redis.io/commands/blpop/
-		queueItemBytes := []byte(strs[1])
 		var queueItem redisqueue.QueueItem
-		err = json.Unmarshal(queueItemBytes, &queueItem)
+		err = json.Unmarshal([]byte(queueItemStr), &queueItem)
 		if err != nil {
-			return fmt.Errorf("unmarshal queue item: %w", err)
+			return drop(fmt.Errorf("unmarshal queue item: %w", err))
 		}
 
 		taskBytes, err := conn.Get(ctx, queueItem.RedisKey()).Bytes()
 		if errors.Is(err, goredis.Nil) {
-			return errors.New("task item not found")
+			return drop(errors.New("task item not found"))
 		}
 		if err != nil {
 			return fmt.Errorf("get task: %w", err)
@@ -165,14 +285,14 @@ func (c *Consumer) dequeue(ctx context.Context) (taskExecutor, *redisqueue.Task,
 
 		err = json.Unmarshal(taskBytes, &task)
 		if err != nil {
-			return fmt.Errorf("unmarshal task: %w", err)
+			return drop(fmt.Errorf("unmarshal task: %w", err))
 		}
 		appID = queueItem.AppID
 		return nil
 	})
 
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	var executor taskExecutor = func() (output json.RawMessage, err error) {
@@ -190,7 +310,18 @@ func (c *Consumer) dequeue(ctx context.Context) (taskExecutor, *redisqueue.Task,
 		return
 	}
 
-	return executor, &task, err
+	return executor, &task, queueItemStr, err
+}
+
+// ack removes the task from the processing queue of the consumer.
+func (c *Consumer) ack(ctx context.Context, queueItem string) {
+	logger := logger.GetLogger(ctx)
+	err := c.redis.WithConnContext(ctx, func(ctx context.Context, conn redis.Redis_6_0_Cmdable) error {
+		return redisqueue.AckTask(ctx, conn, c.QueueName, c.consumerID, queueItem)
+	})
+	if err != nil {
+		logger.WithError(err).Error(ctx, "failed to acknowledge task")
+	}
 }
 
 func (c *Consumer) process(
@@ -243,8 +374,8 @@ func (c *Consumer) work(ctx context.Context) {
 	// Since the burst (10) is larger than the number of workers (3),
 	// All workers can reserve().
 	// And then they all dequeue().
-	// Since the queue is empty, they are blocked by BRPOP.
-	// When the are unblocked by BRPOP after timeout,
+	// Since the queue is empty, they are blocked by BRPOPLPUSH.
+	// When the are unblocked by BRPOPLPUSH after timeout,
 	// they cancel the reservation.
 	// And the progress repeats.
 	// Once the queue becomes non-empty,
@@ -285,7 +416,7 @@ func (c *Consumer) work(ctx context.Context) {
 		}
 	}
 
-	execute, task, err := c.dequeue(ctx)
+	execute, task, queueItem, err := c.dequeue(ctx)
 	if errors.Is(err, errNoTask) {
 		// There is actually no task.
 		// Cancel the reservation
@@ -301,6 +432,11 @@ func (c *Consumer) work(ctx context.Context) {
 
 	logger.Info(ctx, "consume reservation")
 
+	// The task is acknowledged once it has been processed,
+	// even if the output cannot be saved.
+	// Otherwise it would be processed again.
+	defer c.ack(ctx, queueItem)
+
 	// Reset backoff when we can dequeue.
 	c.dequeueBackoff.Reset()
 
@@ -0,0 +1,21 @@
+package redisqueue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/authgear/authgear-server/pkg/lib/deps"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc"
+)
+
+func BackchannelLogout(ctx context.Context, appProvider *deps.AppProvider, task *redisqueue.Task) (output json.RawMessage, err error) {
+	deliverer := newBackchannelLogoutDeliverer(ctx, appProvider)
+	var request oidc.BackchannelLogoutRequest
+	err = json.Unmarshal(task.Input, &request)
+	if err != nil {
+		return
+	}
+	err = deliverer.Deliver(ctx, &request)
+	return
+}
@@ -0,0 +1,21 @@
+package redisqueue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/authgear/authgear-server/pkg/lib/deps"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+)
+
+func CIBANotification(ctx context.Context, appProvider *deps.AppProvider, task *redisqueue.Task) (output json.RawMessage, err error) {
+	deliverer := newCIBANotificationDeliverer(ctx, appProvider)
+	var request handler.CIBANotificationRequest
+	err = json.Unmarshal(task.Input, &request)
+	if err != nil {
+		return
+	}
+	err = deliverer.Deliver(ctx, &request)
+	return
+}
@@ -0,0 +1,21 @@
+package redisqueue
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/authgear/authgear-server/pkg/lib/deps"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redisqueue"
+	"github.com/authgear/authgear-server/pkg/lib/messaging"
+)
+
+func MessageDelivery(ctx context.Context, appProvider *deps.AppProvider, task *redisqueue.Task) (output json.RawMessage, err error) {
+	sender := newMessagingSender(ctx, appProvider)
+	var request messaging.MessageDeliveryRequest
+	err = json.Unmarshal(task.Input, &request)
+	if err != nil {
+		return
+	}
+	err = sender.Deliver(ctx, &request)
+	return
+}
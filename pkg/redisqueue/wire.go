@@ -8,6 +8,9 @@ import (
 	"github.com/google/wire"
 
 	"github.com/authgear/authgear-server/pkg/lib/deps"
+	"github.com/authgear/authgear-server/pkg/lib/messaging"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc"
 	"github.com/authgear/authgear-server/pkg/lib/search/reindex"
 	"github.com/authgear/authgear-server/pkg/lib/userexport"
 	"github.com/authgear/authgear-server/pkg/lib/userimport"
@@ -33,3 +36,24 @@ func newSearchReindexer(ctx context.Context, p *deps.AppProvider) *reindex.Reind
 		deps.CommonDependencySet,
 	))
 }
+
+func newBackchannelLogoutDeliverer(ctx context.Context, p *deps.AppProvider) *oidc.BackchannelLogoutDeliverer {
+	panic(wire.Build(
+		deps.RedisQueueDependencySet,
+		deps.CommonDependencySet,
+	))
+}
+
+func newCIBANotificationDeliverer(ctx context.Context, p *deps.AppProvider) *handler.CIBANotificationDeliverer {
+	panic(wire.Build(
+		deps.RedisQueueDependencySet,
+		deps.CommonDependencySet,
+	))
+}
+
+func newMessagingSender(ctx context.Context, p *deps.AppProvider) *messaging.Sender {
+	panic(wire.Build(
+		deps.RedisQueueDependencySet,
+		deps.CommonDependencySet,
+	))
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/passkey"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity/saml"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/siwe"
 	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
@@ -59,6 +60,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/oauth/pq"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/redis"
 	"github.com/authgear/authgear-server/pkg/lib/oauthclient"
+	"github.com/authgear/authgear-server/pkg/lib/organization"
 	"github.com/authgear/authgear-server/pkg/lib/otelauthgear"
 	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/rolesgroups"
@@ -370,6 +372,15 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Clock:                        clock,
 		StandardAttributesNormalizer: normalizer,
 	}
+	samlStore := &saml.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	samlProvider := &saml.Provider{
+		Store:                        samlStore,
+		Clock:                        clock,
+		StandardAttributesNormalizer: normalizer,
+	}
 	serviceService := &service.Service{
 		Authentication:          authenticationConfig,
 		Identity:                identityConfig,
@@ -383,6 +394,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Passkey:                 passkeyProvider,
 		SIWE:                    siweProvider,
 		LDAP:                    ldapProvider,
+		SAML:                    samlProvider,
 	}
 	store3 := &service2.Store{
 		SQLBuilder:  sqlBuilderApp,
@@ -400,7 +412,13 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		SQLExecutor: sqlExecutor,
 	}
 	authenticatorFeatureConfig := featureConfig.Authenticator
-	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore)
+	pwnedPasswordsEnvironmentConfig := environmentConfig.PwnedPasswords
+	breachedPasswordHTTPClient := password.NewBreachedPasswordHTTPClient()
+	breachedPasswordService := &password.BreachedPasswordService{
+		EnvConfig:  pwnedPasswordsEnvironmentConfig,
+		HTTPClient: breachedPasswordHTTPClient,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore, breachedPasswordService)
 	expiry := password.ProvideExpiry(authenticatorPasswordConfig, clock)
 	housekeeper := &password.Housekeeper{
 		Store:  historyStore,
@@ -551,6 +569,14 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 	commands := &rolesgroups.Commands{
 		Store: rolesgroupsStore,
 	}
+	organizationStore := &organization.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+		Clock:       clock,
+	}
+	organizationCommands := &organization.Commands{
+		Store: organizationStore,
+	}
 	sink := &hook.Sink{
 		Config:             hookConfig,
 		Clock:              clock,
@@ -636,7 +662,18 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		AnalyticRedis: analyticredisHandle,
 		Posthog:       posthogService,
 	}
-	eventService := event.NewService(appID, remoteIP, userAgentString, httpRequestURL, appdbHandle, clock, localizationConfig, storeImpl, resolverImpl, sink, auditSink, reindexSink, userinfoSink, firstAuthSink)
+	webhookDeliveryStore := &hook.WebhookDeliveryStore{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	webhookDeliveryService := &hook.WebhookDeliveryService{
+		Clock:        clock,
+		Config:       hookConfig,
+		Database:     appdbHandle,
+		Store:        webhookDeliveryStore,
+		EventWebHook: eventWebHookImpl,
+	}
+	eventService := event.NewService(appID, remoteIP, userAgentString, httpRequestURL, appdbHandle, clock, localizationConfig, storeImpl, resolverImpl, sink, auditSink, reindexSink, userinfoSink, firstAuthSink, webhookDeliveryService)
 	serviceReadOnlyService := service2.ReadOnlyService{
 		Store:    store3,
 		Password: passwordProvider,
@@ -805,9 +842,19 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Lockout:         mfaLockout,
 	}
 	smtpServerCredentials := deps.ProvideSMTPServerCredentials(secretConfig)
-	dialer := mail.NewGomailDialer(smtpServerCredentials)
+	sesCredentials := deps.ProvideSESCredentials(secretConfig)
+	sendGridCredentials := deps.ProvideSendGridCredentials(secretConfig)
+	mailgunCredentials := deps.ProvideMailgunCredentials(secretConfig)
+	postmarkCredentials := deps.ProvidePostmarkCredentials(secretConfig)
+	mailClientResolver := &mail.ClientResolver{
+		SMTPServerCredentials: smtpServerCredentials,
+		SESCredentials:        sesCredentials,
+		SendGridCredentials:   sendGridCredentials,
+		MailgunCredentials:    mailgunCredentials,
+		PostmarkCredentials:   postmarkCredentials,
+	}
 	sender := &mail.Sender{
-		GomailDialer: dialer,
+		ClientResolver: mailClientResolver,
 	}
 	devMode := environmentConfig.DevMode
 	usageAlertEmailServiceImpl := &usage.UsageAlertEmailServiceImpl{
@@ -886,6 +933,10 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 	testModeSMSConfig := testModeConfig.SMS
 	featureTestModeWhatsappSuppressed := deps.ProvideTestModeWhatsappSuppressed(testModeFeatureConfig)
 	testModeWhatsappConfig := testModeConfig.Whatsapp
+	messageDeliveryProducer := redisqueue.NewMessageDeliveryProducer(handle, clock)
+	whatsappDeliveryCallback := &otp.WhatsappDeliveryCallback{
+		CodeStore: codeStoreRedis,
+	}
 	messagingSender := &messaging.Sender{
 		Limits:                            limits,
 		Events:                            eventService,
@@ -893,7 +944,11 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		MailSender:                        sender,
 		SMSSender:                         smsSender,
 		WhatsappSender:                    whatsappService,
+		Producer:                          messageDeliveryProducer,
+		WhatsappCallback:                  whatsappDeliveryCallback,
 		Database:                          appdbHandle,
+		Clock:                             clock,
+		AppID:                             appID,
 		DevMode:                           devMode,
 		MessagingFeatureConfig:            messagingFeatureConfig,
 		FeatureTestModeEmailSuppressed:    featureTestModeEmailSuppressed,
@@ -977,6 +1032,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		UserCommands:               userCommands,
 		UserQueries:                userQueries,
 		RolesGroupsCommands:        commands,
+		OrganizationCommands:       organizationCommands,
 		StdAttrsService:            stdattrsService,
 		PasswordHistory:            historyStore,
 		OAuth:                      authorizationStore,
@@ -1216,6 +1272,15 @@ func newSessionResolveHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                        clockClock,
 		StandardAttributesNormalizer: normalizer,
 	}
+	samlStore := &saml.Store{
+		SQLBuilder:  sqlBuilderApp,
+		SQLExecutor: sqlExecutor,
+	}
+	samlProvider := &saml.Provider{
+		Store:                        samlStore,
+		Clock:                        clockClock,
+		StandardAttributesNormalizer: normalizer,
+	}
 	serviceService := &service.Service{
 		Authentication:          authenticationConfig,
 		Identity:                identityConfig,
@@ -1229,6 +1294,7 @@ func newSessionResolveHandler(p *deps.RequestProvider) http.Handler {
 		Passkey:                 passkeyProvider,
 		SIWE:                    siweProvider,
 		LDAP:                    ldapProvider,
+		SAML:                    samlProvider,
 	}
 	store3 := &service2.Store{
 		SQLBuilder:  sqlBuilderApp,
@@ -1246,7 +1312,13 @@ func newSessionResolveHandler(p *deps.RequestProvider) http.Handler {
 		SQLExecutor: sqlExecutor,
 	}
 	authenticatorFeatureConfig := featureConfig.Authenticator
-	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore)
+	pwnedPasswordsEnvironmentConfig := environmentConfig.PwnedPasswords
+	breachedPasswordHTTPClient := password.NewBreachedPasswordHTTPClient()
+	breachedPasswordService := &password.BreachedPasswordService{
+		EnvConfig:  pwnedPasswordsEnvironmentConfig,
+		HTTPClient: breachedPasswordHTTPClient,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, authenticatorFeatureConfig, historyStore, breachedPasswordService)
 	expiry := password.ProvideExpiry(authenticatorPasswordConfig, clockClock)
 	housekeeper := &password.Housekeeper{
 		Store:  historyStore,
@@ -0,0 +1,258 @@
+package geoip
+
+// countryCoordinates are the approximate geographical centers of countries,
+// keyed by ISO 3166-1 alpha-2 code.
+// The embedded database resolves an IP address to a country only,
+// so the center of the country is the best estimate of the location.
+var countryCoordinates = map[string]Coordinates{
+	"AD": {Latitude: 42.546245, Longitude: 1.601554},
+	"AE": {Latitude: 23.424076, Longitude: 53.847818},
+	"AF": {Latitude: 33.93911, Longitude: 67.709953},
+	"AG": {Latitude: 17.060816, Longitude: -61.796428},
+	"AI": {Latitude: 18.220554, Longitude: -63.068615},
+	"AL": {Latitude: 41.153332, Longitude: 20.168331},
+	"AM": {Latitude: 40.069099, Longitude: 45.038189},
+	"AO": {Latitude: -11.202692, Longitude: 17.873887},
+	"AQ": {Latitude: -75.250973, Longitude: -0.071389},
+	"AR": {Latitude: -38.416097, Longitude: -63.616672},
+	"AS": {Latitude: -14.270972, Longitude: -170.132217},
+	"AT": {Latitude: 47.516231, Longitude: 14.550072},
+	"AU": {Latitude: -25.274398, Longitude: 133.775136},
+	"AW": {Latitude: 12.52111, Longitude: -69.968338},
+	"AX": {Latitude: 60.1785, Longitude: 19.9156},
+	"AZ": {Latitude: 40.143105, Longitude: 47.576927},
+	"BA": {Latitude: 43.915886, Longitude: 17.679076},
+	"BB": {Latitude: 13.193887, Longitude: -59.543198},
+	"BD": {Latitude: 23.684994, Longitude: 90.356331},
+	"BE": {Latitude: 50.503887, Longitude: 4.469936},
+	"BF": {Latitude: 12.238333, Longitude: -1.561593},
+	"BG": {Latitude: 42.733883, Longitude: 25.48583},
+	"BH": {Latitude: 25.930414, Longitude: 50.637772},
+	"BI": {Latitude: -3.373056, Longitude: 29.918886},
+	"BJ": {Latitude: 9.30769, Longitude: 2.315834},
+	"BL": {Latitude: 17.9, Longitude: -62.8333},
+	"BM": {Latitude: 32.321384, Longitude: -64.75737},
+	"BN": {Latitude: 4.535277, Longitude: 114.727669},
+	"BO": {Latitude: -16.290154, Longitude: -63.588653},
+	"BQ": {Latitude: 12.1784, Longitude: -68.2385},
+	"BR": {Latitude: -14.235004, Longitude: -51.92528},
+	"BS": {Latitude: 25.03428, Longitude: -77.39628},
+	"BT": {Latitude: 27.514162, Longitude: 90.433601},
+	"BV": {Latitude: -54.423199, Longitude: 3.413194},
+	"BW": {Latitude: -22.328474, Longitude: 24.684866},
+	"BY": {Latitude: 53.709807, Longitude: 27.953389},
+	"BZ": {Latitude: 17.189877, Longitude: -88.49765},
+	"CA": {Latitude: 56.130366, Longitude: -106.346771},
+	"CC": {Latitude: -12.164165, Longitude: 96.870956},
+	"CD": {Latitude: -4.038333, Longitude: 21.758664},
+	"CF": {Latitude: 6.611111, Longitude: 20.939444},
+	"CG": {Latitude: -0.228021, Longitude: 15.827659},
+	"CH": {Latitude: 46.818188, Longitude: 8.227512},
+	"CI": {Latitude: 7.539989, Longitude: -5.54708},
+	"CK": {Latitude: -21.236736, Longitude: -159.777671},
+	"CL": {Latitude: -35.675147, Longitude: -71.542969},
+	"CM": {Latitude: 7.369722, Longitude: 12.354722},
+	"CN": {Latitude: 35.86166, Longitude: 104.195397},
+	"CO": {Latitude: 4.570868, Longitude: -74.297333},
+	"CR": {Latitude: 9.748917, Longitude: -83.753428},
+	"CU": {Latitude: 21.521757, Longitude: -77.781167},
+	"CV": {Latitude: 16.002082, Longitude: -24.013197},
+	"CW": {Latitude: 12.1696, Longitude: -68.99},
+	"CX": {Latitude: -10.447525, Longitude: 105.690449},
+	"CY": {Latitude: 35.126413, Longitude: 33.429859},
+	"CZ": {Latitude: 49.817492, Longitude: 15.472962},
+	"DE": {Latitude: 51.165691, Longitude: 10.451526},
+	"DJ": {Latitude: 11.825138, Longitude: 42.590275},
+	"DK": {Latitude: 56.26392, Longitude: 9.501785},
+	"DM": {Latitude: 15.414999, Longitude: -61.370976},
+	"DO": {Latitude: 18.735693, Longitude: -70.162651},
+	"DZ": {Latitude: 28.033886, Longitude: 1.659626},
+	"EC": {Latitude: -1.831239, Longitude: -78.183406},
+	"EE": {Latitude: 58.595272, Longitude: 25.013607},
+	"EG": {Latitude: 26.820553, Longitude: 30.802498},
+	"EH": {Latitude: 24.215527, Longitude: -12.885834},
+	"ER": {Latitude: 15.179384, Longitude: 39.782334},
+	"ES": {Latitude: 40.463667, Longitude: -3.74922},
+	"ET": {Latitude: 9.145, Longitude: 40.489673},
+	"FI": {Latitude: 61.92411, Longitude: 25.748151},
+	"FJ": {Latitude: -16.578193, Longitude: 179.414413},
+	"FK": {Latitude: -51.796253, Longitude: -59.523613},
+	"FM": {Latitude: 7.425554, Longitude: 150.550812},
+	"FO": {Latitude: 61.892635, Longitude: -6.911806},
+	"FR": {Latitude: 46.227638, Longitude: 2.213749},
+	"GA": {Latitude: -0.803689, Longitude: 11.609444},
+	"GB": {Latitude: 55.378051, Longitude: -3.435973},
+	"GD": {Latitude: 12.262776, Longitude: -61.604171},
+	"GE": {Latitude: 42.315407, Longitude: 43.356892},
+	"GF": {Latitude: 3.933889, Longitude: -53.125782},
+	"GG": {Latitude: 49.465691, Longitude: -2.585278},
+	"GH": {Latitude: 7.946527, Longitude: -1.023194},
+	"GI": {Latitude: 36.137741, Longitude: -5.345374},
+	"GL": {Latitude: 71.706936, Longitude: -42.604303},
+	"GM": {Latitude: 13.443182, Longitude: -15.310139},
+	"GN": {Latitude: 9.945587, Longitude: -9.696645},
+	"GP": {Latitude: 16.995971, Longitude: -62.067641},
+	"GQ": {Latitude: 1.650801, Longitude: 10.267895},
+	"GR": {Latitude: 39.074208, Longitude: 21.824312},
+	"GS": {Latitude: -54.429579, Longitude: -36.587909},
+	"GT": {Latitude: 15.783471, Longitude: -90.230759},
+	"GU": {Latitude: 13.444304, Longitude: 144.793731},
+	"GW": {Latitude: 11.803749, Longitude: -15.180413},
+	"GY": {Latitude: 4.860416, Longitude: -58.93018},
+	"HK": {Latitude: 22.396428, Longitude: 114.109497},
+	"HM": {Latitude: -53.08181, Longitude: 73.504158},
+	"HN": {Latitude: 15.199999, Longitude: -86.241905},
+	"HR": {Latitude: 45.1, Longitude: 15.2},
+	"HT": {Latitude: 18.971187, Longitude: -72.285215},
+	"HU": {Latitude: 47.162494, Longitude: 19.503304},
+	"ID": {Latitude: -0.789275, Longitude: 113.921327},
+	"IE": {Latitude: 53.41291, Longitude: -8.24389},
+	"IL": {Latitude: 31.046051, Longitude: 34.851612},
+	"IM": {Latitude: 54.236107, Longitude: -4.548056},
+	"IN": {Latitude: 20.593684, Longitude: 78.96288},
+	"IO": {Latitude: -6.343194, Longitude: 71.876519},
+	"IQ": {Latitude: 33.223191, Longitude: 43.679291},
+	"IR": {Latitude: 32.427908, Longitude: 53.688046},
+	"IS": {Latitude: 64.963051, Longitude: -19.020835},
+	"IT": {Latitude: 41.87194, Longitude: 12.56738},
+	"JE": {Latitude: 49.214439, Longitude: -2.13125},
+	"JM": {Latitude: 18.109581, Longitude: -77.297508},
+	"JO": {Latitude: 30.585164, Longitude: 36.238414},
+	"JP": {Latitude: 36.204824, Longitude: 138.252924},
+	"KE": {Latitude: -0.023559, Longitude: 37.906193},
+	"KG": {Latitude: 41.20438, Longitude: 74.766098},
+	"KH": {Latitude: 12.565679, Longitude: 104.990963},
+	"KI": {Latitude: -3.370417, Longitude: -168.734039},
+	"KM": {Latitude: -11.875001, Longitude: 43.872219},
+	"KN": {Latitude: 17.357822, Longitude: -62.782998},
+	"KP": {Latitude: 40.339852, Longitude: 127.510093},
+	"KR": {Latitude: 35.907757, Longitude: 127.766922},
+	"KW": {Latitude: 29.31166, Longitude: 47.481766},
+	"KY": {Latitude: 19.513469, Longitude: -80.566956},
+	"KZ": {Latitude: 48.019573, Longitude: 66.923684},
+	"LA": {Latitude: 19.85627, Longitude: 102.495496},
+	"LB": {Latitude: 33.854721, Longitude: 35.862285},
+	"LC": {Latitude: 13.909444, Longitude: -60.978893},
+	"LI": {Latitude: 47.166, Longitude: 9.555373},
+	"LK": {Latitude: 7.873054, Longitude: 80.771797},
+	"LR": {Latitude: 6.428055, Longitude: -9.429499},
+	"LS": {Latitude: -29.609988, Longitude: 28.233608},
+	"LT": {Latitude: 55.169438, Longitude: 23.881275},
+	"LU": {Latitude: 49.815273, Longitude: 6.129583},
+	"LV": {Latitude: 56.879635, Longitude: 24.603189},
+	"LY": {Latitude: 26.3351, Longitude: 17.228331},
+	"MA": {Latitude: 31.791702, Longitude: -7.09262},
+	"MC": {Latitude: 43.750298, Longitude: 7.412841},
+	"MD": {Latitude: 47.411631, Longitude: 28.369885},
+	"ME": {Latitude: 42.708678, Longitude: 19.37439},
+	"MF": {Latitude: 18.0708, Longitude: -63.0501},
+	"MG": {Latitude: -18.766947, Longitude: 46.869107},
+	"MH": {Latitude: 7.131474, Longitude: 171.184478},
+	"MK": {Latitude: 41.608635, Longitude: 21.745275},
+	"ML": {Latitude: 17.570692, Longitude: -3.996166},
+	"MM": {Latitude: 21.913965, Longitude: 95.956223},
+	"MN": {Latitude: 46.862496, Longitude: 103.846656},
+	"MO": {Latitude: 22.198745, Longitude: 113.543873},
+	"MP": {Latitude: 17.33083, Longitude: 145.38469},
+	"MQ": {Latitude: 14.641528, Longitude: -61.024174},
+	"MR": {Latitude: 21.00789, Longitude: -10.940835},
+	"MS": {Latitude: 16.742498, Longitude: -62.187366},
+	"MT": {Latitude: 35.937496, Longitude: 14.375416},
+	"MU": {Latitude: -20.348404, Longitude: 57.552152},
+	"MV": {Latitude: 3.202778, Longitude: 73.22068},
+	"MW": {Latitude: -13.254308, Longitude: 34.301525},
+	"MX": {Latitude: 23.634501, Longitude: -102.552784},
+	"MY": {Latitude: 4.210484, Longitude: 101.975766},
+	"MZ": {Latitude: -18.665695, Longitude: 35.529562},
+	"NA": {Latitude: -22.95764, Longitude: 18.49041},
+	"NC": {Latitude: -20.904305, Longitude: 165.618042},
+	"NE": {Latitude: 17.607789, Longitude: 8.081666},
+	"NF": {Latitude: -29.040835, Longitude: 167.954712},
+	"NG": {Latitude: 9.081999, Longitude: 8.675277},
+	"NI": {Latitude: 12.865416, Longitude: -85.207229},
+	"NL": {Latitude: 52.132633, Longitude: 5.291266},
+	"NO": {Latitude: 60.472024, Longitude: 8.468946},
+	"NP": {Latitude: 28.394857, Longitude: 84.124008},
+	"NR": {Latitude: -0.522778, Longitude: 166.931503},
+	"NU": {Latitude: -19.054445, Longitude: -169.867233},
+	"NZ": {Latitude: -40.900557, Longitude: 174.885971},
+	"OM": {Latitude: 21.512583, Longitude: 55.923255},
+	"PA": {Latitude: 8.537981, Longitude: -80.782127},
+	"PE": {Latitude: -9.189967, Longitude: -75.015152},
+	"PF": {Latitude: -17.679742, Longitude: -149.406843},
+	"PG": {Latitude: -6.314993, Longitude: 143.95555},
+	"PH": {Latitude: 12.879721, Longitude: 121.774017},
+	"PK": {Latitude: 30.375321, Longitude: 69.345116},
+	"PL": {Latitude: 51.919438, Longitude: 19.145136},
+	"PM": {Latitude: 46.941936, Longitude: -56.27111},
+	"PN": {Latitude: -24.703615, Longitude: -127.439308},
+	"PR": {Latitude: 18.220833, Longitude: -66.590149},
+	"PS": {Latitude: 31.952162, Longitude: 35.233154},
+	"PT": {Latitude: 39.399872, Longitude: -8.224454},
+	"PW": {Latitude: 7.51498, Longitude: 134.58252},
+	"PY": {Latitude: -23.442503, Longitude: -58.443832},
+	"QA": {Latitude: 25.354826, Longitude: 51.183884},
+	"RE": {Latitude: -21.115141, Longitude: 55.536384},
+	"RO": {Latitude: 45.943161, Longitude: 24.96676},
+	"RS": {Latitude: 44.016521, Longitude: 21.005859},
+	"RU": {Latitude: 61.52401, Longitude: 105.318756},
+	"RW": {Latitude: -1.940278, Longitude: 29.873888},
+	"SA": {Latitude: 23.885942, Longitude: 45.079162},
+	"SB": {Latitude: -9.64571, Longitude: 160.156194},
+	"SC": {Latitude: -4.679574, Longitude: 55.491977},
+	"SD": {Latitude: 12.862807, Longitude: 30.217636},
+	"SE": {Latitude: 60.128161, Longitude: 18.643501},
+	"SG": {Latitude: 1.352083, Longitude: 103.819836},
+	"SH": {Latitude: -24.143474, Longitude: -10.030696},
+	"SI": {Latitude: 46.151241, Longitude: 14.995463},
+	"SJ": {Latitude: 77.553604, Longitude: 23.670272},
+	"SK": {Latitude: 48.669026, Longitude: 19.699024},
+	"SL": {Latitude: 8.460555, Longitude: -11.779889},
+	"SM": {Latitude: 43.94236, Longitude: 12.457777},
+	"SN": {Latitude: 14.497401, Longitude: -14.452362},
+	"SO": {Latitude: 5.152149, Longitude: 46.199616},
+	"SR": {Latitude: 3.919305, Longitude: -56.027783},
+	"SS": {Latitude: 6.877, Longitude: 31.307},
+	"ST": {Latitude: 0.18636, Longitude: 6.613081},
+	"SV": {Latitude: 13.794185, Longitude: -88.89653},
+	"SX": {Latitude: 18.0425, Longitude: -63.0548},
+	"SY": {Latitude: 34.802075, Longitude: 38.996815},
+	"SZ": {Latitude: -26.522503, Longitude: 31.465866},
+	"TC": {Latitude: 21.694025, Longitude: -71.797928},
+	"TD": {Latitude: 15.454166, Longitude: 18.732207},
+	"TF": {Latitude: -49.280366, Longitude: 69.348557},
+	"TG": {Latitude: 8.619543, Longitude: 0.824782},
+	"TH": {Latitude: 15.870032, Longitude: 100.992541},
+	"TJ": {Latitude: 38.861034, Longitude: 71.276093},
+	"TK": {Latitude: -8.967363, Longitude: -171.855881},
+	"TL": {Latitude: -8.874217, Longitude: 125.727539},
+	"TM": {Latitude: 38.969719, Longitude: 59.556278},
+	"TN": {Latitude: 33.886917, Longitude: 9.537499},
+	"TO": {Latitude: -21.178986, Longitude: -175.198242},
+	"TR": {Latitude: 38.963745, Longitude: 35.243322},
+	"TT": {Latitude: 10.691803, Longitude: -61.222503},
+	"TV": {Latitude: -7.109535, Longitude: 177.64933},
+	"TW": {Latitude: 23.69781, Longitude: 120.960515},
+	"TZ": {Latitude: -6.369028, Longitude: 34.888822},
+	"UA": {Latitude: 48.379433, Longitude: 31.16558},
+	"UG": {Latitude: 1.373333, Longitude: 32.290275},
+	"UM": {Latitude: 19.2823, Longitude: 166.647},
+	"US": {Latitude: 37.09024, Longitude: -95.712891},
+	"UY": {Latitude: -32.522779, Longitude: -55.765835},
+	"UZ": {Latitude: 41.377491, Longitude: 64.585262},
+	"VA": {Latitude: 41.902916, Longitude: 12.453389},
+	"VC": {Latitude: 12.984305, Longitude: -61.287228},
+	"VE": {Latitude: 6.42375, Longitude: -66.58973},
+	"VG": {Latitude: 18.420695, Longitude: -64.639968},
+	"VI": {Latitude: 18.335765, Longitude: -64.896335},
+	"VN": {Latitude: 14.058324, Longitude: 108.277199},
+	"VU": {Latitude: -15.376706, Longitude: 166.959158},
+	"WF": {Latitude: -13.768752, Longitude: -177.156097},
+	"WS": {Latitude: -13.759029, Longitude: -172.104629},
+	"XK": {Latitude: 42.602636, Longitude: 20.902977},
+	"YE": {Latitude: 15.552727, Longitude: 48.516388},
+	"YT": {Latitude: -12.8275, Longitude: 45.166244},
+	"ZA": {Latitude: -30.559482, Longitude: 22.937506},
+	"ZM": {Latitude: -13.133897, Longitude: 27.849332},
+	"ZW": {Latitude: -19.015438, Longitude: 29.154857},
+}
@@ -20,6 +20,9 @@ func init() {
 type Info struct {
 	CountryCode        string
 	EnglishCountryName string
+	// Coordinates is the approximate center of the country.
+	// It is nil if the country is unknown.
+	Coordinates *Coordinates
 }
 
 func open() *geoip2.Reader {
@@ -44,6 +47,9 @@ func IPString(ipString string) (info *Info, ok bool) {
 		CountryCode:        country.Country.IsoCode,
 		EnglishCountryName: country.Country.Names["en"],
 	}
+	if coordinates, ok := countryCoordinates[info.CountryCode]; ok {
+		info.Coordinates = &coordinates
+	}
 	ok = true
 	return
 }
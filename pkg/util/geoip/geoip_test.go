@@ -22,5 +22,16 @@ func TestIPString(t *testing.T) {
 		So(ok, ShouldBeTrue)
 		So(info.CountryCode, ShouldEqual, "HK")
 		So(info.EnglishCountryName, ShouldEqual, "Hong Kong")
+		So(info.Coordinates, ShouldResemble, &Coordinates{Latitude: 22.396428, Longitude: 114.109497})
+	})
+}
+
+func TestCoordinatesDistanceKm(t *testing.T) {
+	Convey("Coordinates.DistanceKm", t, func() {
+		hk := countryCoordinates["HK"]
+		gb := countryCoordinates["GB"]
+		So(hk.DistanceKm(hk), ShouldEqual, 0)
+		So(hk.DistanceKm(gb), ShouldAlmostEqual, 9557, 1)
+		So(gb.DistanceKm(hk), ShouldAlmostEqual, hk.DistanceKm(gb), 0.001)
 	})
 }
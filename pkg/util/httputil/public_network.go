@@ -0,0 +1,88 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var ErrNonPublicAddress = errors.New("httputil: refuse to connect to non-public address")
+
+// NewPublicNetworkTransport returns a transport that only connects to public addresses.
+// It is used to fetch URLs supplied by developers or clients, such as jwks_uri,
+// so that they cannot make the server reach the internal network.
+//
+// The check is done on the resolved address right before connecting,
+// so a hostname resolving to an internal address is refused as well.
+// Proxy is not used because the proxy would connect on our behalf.
+func NewPublicNetworkTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicNetworkDialControl,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+func publicNetworkDialControl(network string, address string, c syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNonPublicAddress, address)
+	}
+
+	if !IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %v", ErrNonPublicAddress, address)
+	}
+
+	return nil
+}
+
+// IsPublicAddr reports whether addr is a globally routable unicast address.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	switch {
+	case !addr.IsValid():
+		return false
+	case addr.IsUnspecified(),
+		addr.IsLoopback(),
+		addr.IsPrivate(),
+		addr.IsLinkLocalUnicast(),
+		addr.IsLinkLocalMulticast(),
+		addr.IsInterfaceLocalMulticast(),
+		addr.IsMulticast():
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nonPublicPrefixes are the special-purpose ranges not covered by the methods of netip.Addr.
+var nonPublicPrefixes = []netip.Prefix{
+	// "This network"
+	netip.MustParsePrefix("0.0.0.0/8"),
+	// Shared address space for carrier-grade NAT
+	netip.MustParsePrefix("100.64.0.0/10"),
+	// IETF protocol assignments
+	netip.MustParsePrefix("192.0.0.0/24"),
+	// Benchmarking
+	netip.MustParsePrefix("198.18.0.0/15"),
+	// Reserved, including the limited broadcast address
+	netip.MustParsePrefix("240.0.0.0/4"),
+	// NAT64
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
@@ -0,0 +1,55 @@
+package httputil_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	Convey("IsPublicAddr", t, func() {
+		test := func(addr string, expected bool) {
+			So(httputil.IsPublicAddr(netip.MustParseAddr(addr)), ShouldEqual, expected)
+		}
+
+		test("1.1.1.1", true)
+		test("8.8.8.8", true)
+		test("2606:4700:4700::1111", true)
+
+		test("0.0.0.0", false)
+		test("127.0.0.1", false)
+		test("10.0.0.1", false)
+		test("172.16.0.1", false)
+		test("192.168.1.1", false)
+		test("169.254.169.254", false)
+		test("100.64.0.1", false)
+		test("255.255.255.255", false)
+		test("224.0.0.1", false)
+		test("::", false)
+		test("::1", false)
+		test("fc00::1", false)
+		test("fe80::1", false)
+		test("::ffff:127.0.0.1", false)
+		test("64:ff9b::7f00:1", false)
+	})
+}
+
+func TestNewPublicNetworkTransport(t *testing.T) {
+	Convey("NewPublicNetworkTransport", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: httputil.NewPublicNetworkTransport()}
+
+		_, err := client.Get(server.URL)
+		So(errors.Is(err, httputil.ErrNonPublicAddress), ShouldBeTrue)
+	})
+}
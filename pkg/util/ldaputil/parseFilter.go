@@ -33,3 +33,26 @@ func ParseFilter(filter string, username string) (string, error) {
 
 	return result, nil
 }
+
+// ParseGroupSearchFilter renders the group search filter template with the DN of the user.
+func ParseGroupSearchFilter(filter string, userDN string) (string, error) {
+	trimedFilter := strings.TrimSpace(filter)
+
+	tmpl, err := template.New("group_search_filter").Parse(trimedFilter)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]string{"DN": ldap.EscapeFilter(userDN)})
+	if err != nil {
+		return "", err
+	}
+
+	result := strings.TrimSpace(buf.String())
+	_, err = ldap.CompileFilter(result)
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
@@ -15,3 +15,12 @@ func TestParseFilter(t *testing.T) {
 		So(filter, ShouldEqual, "(&(objectclass=persion)(uid=hi\\29\\28email=\\2a))")
 	})
 }
+
+func TestParseGroupSearchFilter(t *testing.T) {
+	Convey("Parse Group Search Filter", t, func() {
+		filterTemplate := "(&(objectClass=groupOfNames)(member={{.DN}}))"
+		filter, err := ParseGroupSearchFilter(filterTemplate, "cn=John (Admin),ou=people,dc=example,dc=com")
+		So(err, ShouldBeNil)
+		So(filter, ShouldEqual, "(&(objectClass=groupOfNames)(member=cn=John \\28Admin\\29,ou=people,dc=example,dc=com))")
+	})
+}
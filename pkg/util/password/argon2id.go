@@ -0,0 +1,97 @@
+package password
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// The parameters follow the recommendation of OWASP.
+// See https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#argon2id
+const (
+	argon2idMemory      = 19 * 1024
+	argon2idIterations  = 2
+	argon2idParallelism = 1
+	argon2idSaltLength  = 16
+	argon2idKeyLength   = 32
+)
+
+// The maximum parameters accepted in a hash.
+// They are well above the parameters used by common libraries.
+const (
+	argon2idMaxMemory      = 256 * 1024
+	argon2idMaxIterations  = 16
+	argon2idMaxParallelism = 16
+)
+
+// argon2idPassword is Argon2id in the PHC string format,
+// for example, $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+type argon2idPassword struct{}
+
+var _ passwordFormat = argon2idPassword{}
+
+func (argon2idPassword) ID() string {
+	return "argon2id"
+}
+
+func (p argon2idPassword) Hash(password []byte) ([]byte, error) {
+	salt := generateSalt(argon2idSaltLength)
+	key := argon2.IDKey(password, salt, argon2idIterations, argon2idMemory, argon2idParallelism, argon2idKeyLength)
+	params := fmt.Sprintf("m=%d,t=%d,p=%d", argon2idMemory, argon2idIterations, argon2idParallelism)
+	return constructPHCFormat(p.ID(), strconv.Itoa(argon2.Version), params, salt, key), nil
+}
+
+func (p argon2idPassword) Compare(password, hash []byte) error {
+	h, m, t, par, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+
+	key := argon2.IDKey(password, h.Salt, uint32(t), uint32(m), uint8(par), uint32(len(h.Hash)))
+	if subtle.ConstantTimeCompare(key, h.Hash) != 1 {
+		return errMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (p argon2idPassword) CheckHash(hash []byte) error {
+	_, _, _, _, err := p.parse(hash)
+	return err
+}
+
+func (p argon2idPassword) parse(hash []byte) (h *phcHash, m int, t int, par int, err error) {
+	h, err = parsePHCFormat(hash)
+	if err != nil {
+		return
+	}
+	if h.ID != p.ID() {
+		err = fmt.Errorf("%w: expected argon2id", ErrInvalidPasswordFormat)
+		return
+	}
+	if h.Version != strconv.Itoa(argon2.Version) {
+		err = fmt.Errorf("%w: unsupported argon2 version %q", ErrInvalidPasswordFormat, h.Version)
+		return
+	}
+	if m, err = h.IntParam("m"); err != nil {
+		return
+	}
+	if err = checkMaxParam("m", m, argon2idMaxMemory); err != nil {
+		return
+	}
+	if t, err = h.IntParam("t"); err != nil {
+		return
+	}
+	if err = checkMaxParam("t", t, argon2idMaxIterations); err != nil {
+		return
+	}
+	if par, err = h.IntParam("p"); err != nil {
+		return
+	}
+	if err = checkMaxParam("p", par, argon2idMaxParallelism); err != nil {
+		return
+	}
+	err = checkKeyLength(h.Hash)
+	return
+}
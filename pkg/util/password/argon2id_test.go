@@ -0,0 +1,47 @@
+package password
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestArgon2id(t *testing.T) {
+	Convey("Argon2id", t, func() {
+		argon2id := argon2idPassword{}
+		Convey("should hash as expected", func() {
+			h, err := argon2id.Hash([]byte("password"))
+			So(err, ShouldBeNil)
+			So(string(h), ShouldStartWith, "$argon2id$v=19$m=19456,t=2,p=1$")
+			So(argon2id.Compare([]byte("password"), h), ShouldBeNil)
+			So(argon2id.Compare([]byte("Password"), h), ShouldBeError)
+		})
+		Convey("should compare as expected", func() {
+			h := []byte("$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")
+			So(argon2id.Compare([]byte("password"), h), ShouldBeNil)
+			So(argon2id.Compare([]byte("Password"), h), ShouldBeError)
+
+			h = []byte("$argon2id$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo/8GaUw434IimaPqxwCo")
+			So(argon2id.Compare([]byte("password"), h), ShouldBeNil)
+		})
+		Convey("should check existing hash", func() {
+			So(argon2id.CheckHash([]byte("$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeNil)
+
+			So(argon2id.CheckHash(nil), ShouldBeError, "invalid password format")
+			So(argon2id.CheckHash([]byte("$argon2id$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeError, `invalid password format: unsupported argon2 version "16"`)
+			So(argon2id.CheckHash([]byte("$argon2id$v=19$m=65536,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeError, `invalid password format: missing parameter "t"`)
+		})
+		Convey("should reject excessive parameters", func() {
+			So(argon2id.CheckHash([]byte("$argon2id$v=19$m=4194304,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeError, `invalid password format: parameter "m" exceeds 262144`)
+			So(argon2id.CheckHash([]byte("$argon2id$v=19$m=65536,t=100,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeError, `invalid password format: parameter "t" exceeds 16`)
+			So(argon2id.CheckHash([]byte("$argon2id$v=19$m=65536,t=2,p=255$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeError, `invalid password format: parameter "p" exceeds 16`)
+			So(argon2id.Compare([]byte("password"), []byte("$argon2id$v=19$m=4194304,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")), ShouldBeError, `invalid password format: parameter "m" exceeds 262144`)
+		})
+		Convey("should compare Django hash", func() {
+			h := []byte("argon2$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")
+			So(Compare([]byte("password"), h), ShouldBeNil)
+			So(Compare([]byte("Password"), h), ShouldBeError)
+			So(CheckHash(h), ShouldBeNil)
+		})
+	})
+}
@@ -1,11 +1,25 @@
 package password
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// Algorithm is an algorithm that can be used to hash new passwords.
+type Algorithm string
+
+const (
+	AlgorithmBcryptSHA512 Algorithm = "bcrypt_sha512"
+	AlgorithmArgon2id     Algorithm = "argon2id"
+	AlgorithmScrypt       Algorithm = "scrypt"
+	AlgorithmPBKDF2SHA256 Algorithm = "pbkdf2_sha256"
+)
 
 var latestFormat passwordFormat
 
 var defaultFormat passwordFormat
 var supportedFormats map[string]passwordFormat
+var algorithmFormats map[Algorithm]passwordFormat
 
 var ErrTooLong = errors.New("password is too long")
 
@@ -16,14 +30,41 @@ func init() {
 	supportedFormats = map[string]passwordFormat{}
 	for _, fmt := range []passwordFormat{
 		bcryptSHA512Password{},
+		argon2idPassword{},
+		scryptPassword{},
+		firebaseScryptPassword{},
+		pbkdf2SHA1Password,
+		pbkdf2SHA256Password,
+		pbkdf2SHA512Password,
+		djangoPBKDF2SHA1Password,
+		djangoPBKDF2SHA256Password,
+		djangoArgon2Password{},
 	} {
 		supportedFormats[fmt.ID()] = fmt
 	}
+
+	algorithmFormats = map[Algorithm]passwordFormat{
+		AlgorithmBcryptSHA512: bcryptSHA512Password{},
+		AlgorithmArgon2id:     argon2idPassword{},
+		AlgorithmScrypt:       scryptPassword{},
+		AlgorithmPBKDF2SHA256: pbkdf2SHA256Password,
+	}
 }
 
 func resolveFormat(hash []byte) (passwordFormat, error) {
 	id, _, err := parsePasswordFormat(hash)
-	if err != nil {
+	if errors.Is(err, ErrInvalidPasswordFormat) {
+		// Django hashes do not start with $.
+		id, _, err = parseDjangoPasswordFormat(hash)
+		if err != nil {
+			return nil, err
+		}
+		fmt, ok := supportedFormats[string(id)]
+		if !ok {
+			return nil, ErrInvalidPasswordFormat
+		}
+		return fmt, nil
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -34,13 +75,30 @@ func resolveFormat(hash []byte) (passwordFormat, error) {
 	return defaultFormat, nil
 }
 
+func resolveAlgorithm(algorithm Algorithm) passwordFormat {
+	if algorithm == "" {
+		return latestFormat
+	}
+	f, ok := algorithmFormats[algorithm]
+	if !ok {
+		panic(fmt.Errorf("password: unknown algorithm: %v", algorithm))
+	}
+	return f
+}
+
 func Hash(password []byte) ([]byte, error) {
+	return HashWithAlgorithm(password, "")
+}
+
+// HashWithAlgorithm hashes password with algorithm.
+// The latest format is used if algorithm is empty.
+func HashWithAlgorithm(password []byte, algorithm Algorithm) ([]byte, error) {
 	// Reject if new password is too long
 	if len(password) > MaxLength {
 		return nil, ErrTooLong
 	}
 
-	return latestFormat.Hash(password)
+	return resolveAlgorithm(algorithm).Hash(password)
 }
 
 func Compare(password, hash []byte) error {
@@ -63,6 +121,12 @@ func CheckHash(hash []byte) error {
 }
 
 func TryMigrate(password []byte, hash *[]byte) (migrated bool, err error) {
+	return TryMigrateToAlgorithm(password, hash, "")
+}
+
+// TryMigrateToAlgorithm rehashes password with algorithm if hash is in another format.
+// The latest format is used if algorithm is empty.
+func TryMigrateToAlgorithm(password []byte, hash *[]byte, algorithm Algorithm) (migrated bool, err error) {
 	// Do not enforce password length limit: migration of old password should
 	// not fail due to length limit
 
@@ -70,10 +134,11 @@ func TryMigrate(password []byte, hash *[]byte) (migrated bool, err error) {
 	if err != nil {
 		return
 	}
-	if fmt.ID() == latestFormat.ID() {
+	targetFormat := resolveAlgorithm(algorithm)
+	if fmt.ID() == targetFormat.ID() {
 		return
 	}
-	newHash, err := latestFormat.Hash(password)
+	newHash, err := targetFormat.Hash(password)
 	if err != nil {
 		return
 	}
@@ -34,18 +34,22 @@ func TestDispatch(t *testing.T) {
 	Convey("Dispatching functions", t, func() {
 		hash1 := testHash{id: "test1"}
 		hash2 := testHash{id: "test2"}
+		hash3 := testHash{id: "test3"}
 
 		oldLatestFormat := latestFormat
 		oldDefaultFormat := defaultFormat
 		oldSupportedFormats := supportedFormats
+		oldAlgorithmFormats := algorithmFormats
 		defer func() {
 			latestFormat = oldLatestFormat
 			defaultFormat = oldDefaultFormat
 			supportedFormats = oldSupportedFormats
+			algorithmFormats = oldAlgorithmFormats
 		}()
 		latestFormat = &hash2
 		defaultFormat = &hash1
-		supportedFormats = map[string]passwordFormat{hash2.ID(): &hash2}
+		supportedFormats = map[string]passwordFormat{hash2.ID(): &hash2, hash3.ID(): &hash3}
+		algorithmFormats = map[Algorithm]passwordFormat{"test3": &hash3}
 
 		Convey("should hash using correct format", func() {
 			h, err := Hash([]byte("password"))
@@ -79,6 +83,24 @@ func TestDispatch(t *testing.T) {
 			So(string(h), ShouldEqual, "$test2$password")
 		})
 
+		Convey("should hash using the preferred algorithm", func() {
+			h, err := HashWithAlgorithm([]byte("password"), "test3")
+			So(err, ShouldBeNil)
+			So(string(h), ShouldEqual, "$test3$password")
+		})
+
+		Convey("should perform migration to the preferred algorithm", func() {
+			h := []byte("$test2$password")
+			migrated, err := TryMigrateToAlgorithm([]byte("password"), &h, "test3")
+			So(err, ShouldBeNil)
+			So(migrated, ShouldBeTrue)
+			So(string(h), ShouldEqual, "$test3$password")
+
+			migrated, err = TryMigrateToAlgorithm([]byte("password"), &h, "test3")
+			So(err, ShouldBeNil)
+			So(migrated, ShouldBeFalse)
+		})
+
 		Convey("should not perform migration if not needed", func() {
 			h := []byte("$test2$password")
 			migrated, err := TryMigrate([]byte("password"), &h)
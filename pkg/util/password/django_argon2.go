@@ -0,0 +1,46 @@
+package password
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// djangoArgon2Password is Argon2 as stored by Django,
+// that is, the PHC string format prefixed with argon2,
+// for example, argon2$argon2id$v=19$m=102400,t=2,p=8$<salt>$<hash>
+// Only argon2id is supported.
+type djangoArgon2Password struct{}
+
+var _ passwordFormat = djangoArgon2Password{}
+
+func (djangoArgon2Password) ID() string {
+	return "argon2"
+}
+
+func (djangoArgon2Password) Hash(password []byte) ([]byte, error) {
+	return nil, fmt.Errorf("argon2: hashing is unsupported")
+}
+
+func (p djangoArgon2Password) Compare(password, hash []byte) error {
+	phc, err := p.toPHC(hash)
+	if err != nil {
+		return err
+	}
+	return argon2idPassword{}.Compare(password, phc)
+}
+
+func (p djangoArgon2Password) CheckHash(hash []byte) error {
+	phc, err := p.toPHC(hash)
+	if err != nil {
+		return err
+	}
+	return argon2idPassword{}.CheckHash(phc)
+}
+
+func (p djangoArgon2Password) toPHC(hash []byte) ([]byte, error) {
+	phc, ok := bytes.CutPrefix(hash, []byte(p.ID()))
+	if !ok {
+		return nil, ErrInvalidPasswordFormat
+	}
+	return phc, nil
+}
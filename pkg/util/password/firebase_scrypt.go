@@ -0,0 +1,93 @@
+package password
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// firebaseScryptPassword is the modified scrypt of Firebase Authentication.
+// The project-wide hash parameters are embedded in the hash, for example,
+// $firebase-scrypt$m=14,r=8,k=<base64_signer_key>,s=<base64_salt_separator>$<salt>$<password_hash>
+//
+// See https://github.com/firebase/scrypt
+type firebaseScryptPassword struct{}
+
+var _ passwordFormat = firebaseScryptPassword{}
+
+func (firebaseScryptPassword) ID() string {
+	return "firebase-scrypt"
+}
+
+// Hash is unsupported because the signer key is owned by the Firebase project.
+func (firebaseScryptPassword) Hash(password []byte) ([]byte, error) {
+	return nil, errors.New("firebase-scrypt: hashing is unsupported")
+}
+
+func (p firebaseScryptPassword) Compare(password, hash []byte) error {
+	h, memCost, rounds, signerKey, saltSeparator, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+
+	salt := append(append([]byte{}, h.Salt...), saltSeparator...)
+	derivedKey, err := scrypt.Key(password, salt, 1<<memCost, rounds, 1, 32)
+	if err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return err
+	}
+	iv := make([]byte, aes.BlockSize)
+	out := make([]byte, len(signerKey))
+	cipher.NewCTR(block, iv).XORKeyStream(out, signerKey)
+
+	if subtle.ConstantTimeCompare(out, h.Hash) != 1 {
+		return errMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (p firebaseScryptPassword) CheckHash(hash []byte) error {
+	_, _, _, _, _, err := p.parse(hash)
+	return err
+}
+
+func (p firebaseScryptPassword) parse(hash []byte) (h *phcHash, memCost int, rounds int, signerKey []byte, saltSeparator []byte, err error) {
+	h, err = parsePHCFormat(hash)
+	if err != nil {
+		return
+	}
+	if memCost, err = h.IntParam("m"); err != nil {
+		return
+	}
+	if err = checkMaxParam("m", memCost, scryptMaxLogN); err != nil {
+		return
+	}
+	if rounds, err = h.IntParam("r"); err != nil {
+		return
+	}
+	if err = checkMaxParam("r", rounds, scryptMaxBlockSize); err != nil {
+		return
+	}
+	if err = checkScryptMemory(memCost, rounds); err != nil {
+		return
+	}
+	if signerKey, err = decodePHCBase64(h.Params["k"]); err != nil {
+		return
+	}
+	if len(signerKey) == 0 {
+		err = fmt.Errorf("%w: missing parameter \"k\"", ErrInvalidPasswordFormat)
+		return
+	}
+	if saltSeparator, err = decodePHCBase64(h.Params["s"]); err != nil {
+		return
+	}
+	return
+}
@@ -2,7 +2,12 @@ package password
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 type passwordFormat interface {
@@ -16,19 +21,23 @@ type passwordFormat interface {
 	CheckHash(hash []byte) error
 }
 
-var errInvalidPasswordFormat = errors.New("invalid password format")
+// ErrInvalidPasswordFormat is returned when a hash cannot be parsed.
+// Errors about malformed hashes wrap it.
+var ErrInvalidPasswordFormat = errors.New("invalid password format")
+
+var errMismatchedHashAndPassword = errors.New("hash and password mismatch")
 
 func parsePasswordFormat(h []byte) (id []byte, data []byte, err error) {
 	i := bytes.IndexByte(h, '$')
 	if i != 0 {
-		err = errInvalidPasswordFormat
+		err = ErrInvalidPasswordFormat
 		return
 	}
 	h = h[i+1:]
 
 	i = bytes.IndexByte(h, '$')
 	if i == -1 {
-		err = errInvalidPasswordFormat
+		err = ErrInvalidPasswordFormat
 		return
 	}
 
@@ -45,3 +54,136 @@ func constructPasswordFormat(id []byte, data []byte) []byte {
 	copy(h[len(id)+2:], data)
 	return h
 }
+
+// parseDjangoPasswordFormat parses a hash produced by Django, which does not start with $.
+// See https://docs.djangoproject.com/en/5.0/topics/auth/passwords/#how-django-stores-passwords
+func parseDjangoPasswordFormat(h []byte) (id []byte, data []byte, err error) {
+	i := bytes.IndexByte(h, '$')
+	if i <= 0 {
+		err = ErrInvalidPasswordFormat
+		return
+	}
+
+	id = h[:i]
+	data = h[i+1:]
+	return
+}
+
+// phcHash is a hash in the PHC string format.
+// See https://github.com/P-H-C/phc-string-format/blob/master/phc-sf-spec.md
+type phcHash struct {
+	ID      string
+	Version string
+	Params  map[string]string
+	Salt    []byte
+	Hash    []byte
+}
+
+func parsePHCFormat(h []byte) (*phcHash, error) {
+	id, data, err := parsePasswordFormat(h)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &phcHash{
+		ID:     string(id),
+		Params: map[string]string{},
+	}
+
+	fields := strings.Split(string(data), "$")
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "v=") {
+		out.Version = strings.TrimPrefix(fields[0], "v=")
+		fields = fields[1:]
+	}
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("%w: expected parameters, salt and hash", ErrInvalidPasswordFormat)
+	}
+
+	for _, kv := range strings.Split(fields[0], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid parameter %q", ErrInvalidPasswordFormat, kv)
+		}
+		out.Params[k] = v
+	}
+
+	out.Salt, err = decodePHCBase64(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	out.Hash, err = decodePHCBase64(fields[2])
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Hash) == 0 {
+		return nil, fmt.Errorf("%w: empty hash", ErrInvalidPasswordFormat)
+	}
+
+	return out, nil
+}
+
+func (h *phcHash) IntParam(key string) (int, error) {
+	s, ok := h.Params[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: missing parameter %q", ErrInvalidPasswordFormat, key)
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return 0, fmt.Errorf("%w: invalid parameter %q", ErrInvalidPasswordFormat, key)
+	}
+	return i, nil
+}
+
+// The cost parameters of a hash are bounded when the hash is checked and compared,
+// so that an imported hash cannot make a single comparison exhaust the CPU or the memory.
+const maxKeyLength = 64
+
+// checkMaxParam returns an error if the parameter key is greater than max.
+func checkMaxParam(key string, value int, max int) error {
+	if value > max {
+		return fmt.Errorf("%w: parameter %q exceeds %d", ErrInvalidPasswordFormat, key, max)
+	}
+	return nil
+}
+
+// checkKeyLength returns an error if the length of the derived key is greater than maxKeyLength.
+func checkKeyLength(key []byte) error {
+	if len(key) > maxKeyLength {
+		return fmt.Errorf("%w: hash exceeds %d bytes", ErrInvalidPasswordFormat, maxKeyLength)
+	}
+	return nil
+}
+
+func constructPHCFormat(id string, version string, params string, salt []byte, hash []byte) []byte {
+	var fields []string
+	if version != "" {
+		fields = append(fields, "v="+version)
+	}
+	fields = append(fields,
+		params,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+	return constructPasswordFormat([]byte(id), []byte(strings.Join(fields, "$")))
+}
+
+// decodePHCBase64 decodes base64 with or without padding.
+// The adapted base64 of passlib, which uses . instead of +, is also accepted.
+func decodePHCBase64(s string) ([]byte, error) {
+	s = strings.ReplaceAll(s, ".", "+")
+	s = strings.TrimRight(s, "=")
+	b, err := base64.RawStdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPasswordFormat, err)
+	}
+	return b, nil
+}
+
+func generateSalt(size int) []byte {
+	salt := make([]byte, size)
+	_, err := rand.Read(salt)
+	if err != nil {
+		panic(err)
+	}
+	return salt
+}
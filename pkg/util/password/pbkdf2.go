@@ -0,0 +1,162 @@
+package password
+
+import (
+	"crypto/pbkdf2"
+	"crypto/sha1" // nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// The number of iterations follows the recommendation of OWASP for PBKDF2-HMAC-SHA256.
+// See https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#pbkdf2
+const (
+	pbkdf2Iterations = 600000
+	pbkdf2SaltLength = 16
+)
+
+// pbkdf2MaxIterations is the maximum iterations accepted in a hash.
+// It is above the iterations used by recent versions of Django.
+const pbkdf2MaxIterations = 2000000
+
+// pbkdf2Password is PBKDF2 in the PHC string format,
+// for example, $pbkdf2-sha256$i=600000$<salt>$<hash>
+// This is also how Keycloak credentials are imported,
+// with the salt, the iterations and the value of the credential.
+type pbkdf2Password struct {
+	id      string
+	newHash func() hash.Hash
+	keySize int
+}
+
+var _ passwordFormat = pbkdf2Password{}
+
+var (
+	pbkdf2SHA1Password   = pbkdf2Password{id: "pbkdf2-sha1", newHash: sha1.New, keySize: sha1.Size}
+	pbkdf2SHA256Password = pbkdf2Password{id: "pbkdf2-sha256", newHash: sha256.New, keySize: sha256.Size}
+	pbkdf2SHA512Password = pbkdf2Password{id: "pbkdf2-sha512", newHash: sha512.New, keySize: sha512.Size}
+)
+
+func (p pbkdf2Password) ID() string {
+	return p.id
+}
+
+func (p pbkdf2Password) Hash(password []byte) ([]byte, error) {
+	salt := generateSalt(pbkdf2SaltLength)
+	key, err := pbkdf2.Key(p.newHash, string(password), salt, pbkdf2Iterations, p.keySize)
+	if err != nil {
+		return nil, err
+	}
+	params := fmt.Sprintf("i=%d", pbkdf2Iterations)
+	return constructPHCFormat(p.ID(), "", params, salt, key), nil
+}
+
+func (p pbkdf2Password) Compare(password, hash []byte) error {
+	h, iterations, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+	return comparePBKDF2(p.newHash, password, h.Salt, iterations, h.Hash)
+}
+
+func (p pbkdf2Password) CheckHash(hash []byte) error {
+	_, _, err := p.parse(hash)
+	return err
+}
+
+func (p pbkdf2Password) parse(hash []byte) (h *phcHash, iterations int, err error) {
+	h, err = parsePHCFormat(hash)
+	if err != nil {
+		return
+	}
+	if iterations, err = h.IntParam("i"); err != nil {
+		return
+	}
+	if err = checkMaxParam("i", iterations, pbkdf2MaxIterations); err != nil {
+		return
+	}
+	err = checkKeyLength(h.Hash)
+	return
+}
+
+// djangoPBKDF2Password is PBKDF2 as stored by Django,
+// for example, pbkdf2_sha256$<iterations>$<salt>$<hash>
+// Unlike the PHC string format, the salt is not encoded.
+type djangoPBKDF2Password struct {
+	id      string
+	newHash func() hash.Hash
+}
+
+var _ passwordFormat = djangoPBKDF2Password{}
+
+var (
+	djangoPBKDF2SHA1Password   = djangoPBKDF2Password{id: "pbkdf2_sha1", newHash: sha1.New}
+	djangoPBKDF2SHA256Password = djangoPBKDF2Password{id: "pbkdf2_sha256", newHash: sha256.New}
+)
+
+func (p djangoPBKDF2Password) ID() string {
+	return p.id
+}
+
+func (p djangoPBKDF2Password) Hash(password []byte) ([]byte, error) {
+	return nil, fmt.Errorf("%v: hashing is unsupported", p.id)
+}
+
+func (p djangoPBKDF2Password) Compare(password, hash []byte) error {
+	iterations, salt, key, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+	return comparePBKDF2(p.newHash, password, salt, iterations, key)
+}
+
+func (p djangoPBKDF2Password) CheckHash(hash []byte) error {
+	_, _, _, err := p.parse(hash)
+	return err
+}
+
+func (p djangoPBKDF2Password) parse(hash []byte) (iterations int, salt []byte, key []byte, err error) {
+	_, data, err := parseDjangoPasswordFormat(hash)
+	if err != nil {
+		return
+	}
+
+	fields := strings.Split(string(data), "$")
+	if len(fields) != 3 {
+		err = fmt.Errorf("%w: expected iterations, salt and hash", ErrInvalidPasswordFormat)
+		return
+	}
+
+	iterations, err = strconv.Atoi(fields[0])
+	if err != nil || iterations <= 0 {
+		err = fmt.Errorf("%w: invalid iterations", ErrInvalidPasswordFormat)
+		return
+	}
+	if err = checkMaxParam("iterations", iterations, pbkdf2MaxIterations); err != nil {
+		return
+	}
+	salt = []byte(fields[1])
+	key, err = base64.StdEncoding.DecodeString(fields[2])
+	if err != nil || len(key) == 0 {
+		err = fmt.Errorf("%w: invalid hash", ErrInvalidPasswordFormat)
+		return
+	}
+	err = checkKeyLength(key)
+	return
+}
+
+func comparePBKDF2(newHash func() hash.Hash, password []byte, salt []byte, iterations int, expected []byte) error {
+	key, err := pbkdf2.Key(newHash, string(password), salt, iterations, len(expected))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(key, expected) != 1 {
+		return errMismatchedHashAndPassword
+	}
+	return nil
+}
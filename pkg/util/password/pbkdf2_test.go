@@ -0,0 +1,45 @@
+package password
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPBKDF2(t *testing.T) {
+	Convey("PBKDF2", t, func() {
+		Convey("should hash as expected", func() {
+			h, err := pbkdf2SHA256Password.Hash([]byte("password"))
+			So(err, ShouldBeNil)
+			So(string(h), ShouldStartWith, "$pbkdf2-sha256$i=600000$")
+			So(pbkdf2SHA256Password.Compare([]byte("password"), h), ShouldBeNil)
+		})
+		Convey("should compare as expected", func() {
+			So(Compare([]byte("password"), []byte("$pbkdf2-sha256$i=1000$c29tZXNhbHQ$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY")), ShouldBeNil)
+			So(Compare([]byte("Password"), []byte("$pbkdf2-sha256$i=1000$c29tZXNhbHQ$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY")), ShouldBeError)
+			So(Compare([]byte("password"), []byte("$pbkdf2-sha1$i=1000$c29tZXNhbHQ$nhpKdz3UCE/OUeC0aLwb8Rne5X8")), ShouldBeNil)
+			// Keycloak encodes the salt and the hash with padding.
+			So(Compare([]byte("password"), []byte("$pbkdf2-sha512$i=1000$c29tZXNhbHQ=$pArTsT8AahzxmI5OZcxKNw2o4l9qiKwc5zbWR8bo8900Q7MYRcodIEijxiztL4hDlWTfVLTSRiLheMi39WU5Yw==")), ShouldBeNil)
+		})
+		Convey("should check existing hash", func() {
+			So(CheckHash([]byte("$pbkdf2-sha256$i=1000$c29tZXNhbHQ$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY")), ShouldBeNil)
+			So(CheckHash([]byte("$pbkdf2-sha256$i=0$c29tZXNhbHQ$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY")), ShouldBeError, `invalid password format: invalid parameter "i"`)
+			So(CheckHash([]byte("$pbkdf2-sha256$i=1000000000$c29tZXNhbHQ$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY")), ShouldBeError, `invalid password format: parameter "i" exceeds 2000000`)
+			So(Compare([]byte("password"), []byte("$pbkdf2-sha256$i=1000000000$c29tZXNhbHQ$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY")), ShouldBeError, `invalid password format: parameter "i" exceeds 2000000`)
+		})
+	})
+
+	Convey("Django PBKDF2", t, func() {
+		Convey("should compare as expected", func() {
+			So(Compare([]byte("password"), []byte("pbkdf2_sha256$1000$somesalt$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY=")), ShouldBeNil)
+			So(Compare([]byte("Password"), []byte("pbkdf2_sha256$1000$somesalt$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY=")), ShouldBeError)
+			So(Compare([]byte("password"), []byte("pbkdf2_sha1$1000$somesalt$nhpKdz3UCE/OUeC0aLwb8Rne5X8=")), ShouldBeNil)
+		})
+		Convey("should check existing hash", func() {
+			So(CheckHash([]byte("pbkdf2_sha256$1000$somesalt$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY=")), ShouldBeNil)
+			So(CheckHash([]byte("pbkdf2_sha256$somesalt$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY=")), ShouldBeError, "invalid password format: expected iterations, salt and hash")
+			So(CheckHash([]byte("pbkdf2_sha256$1000000000$somesalt$j4Aa14inUtOh7Sg/D7hH54ohymuHNQD4+ccfhepGWAY=")), ShouldBeError, `invalid password format: parameter "iterations" exceeds 2000000`)
+			So(CheckHash([]byte("md5$somesalt$hash")), ShouldBeError, "invalid password format")
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package password
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// The parameters follow the recommendation of OWASP.
+// See https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#scrypt
+const (
+	scryptLogN        = 16
+	scryptBlockSize   = 8
+	scryptParallelism = 2
+	scryptSaltLength  = 16
+	scryptKeyLength   = 32
+)
+
+// The maximum parameters accepted in a hash.
+// scryptMaxMemory bounds the memory used by scrypt, which is 128 * r * N bytes.
+const (
+	scryptMaxLogN        = 20
+	scryptMaxBlockSize   = 32
+	scryptMaxParallelism = 16
+	scryptMaxMemory      = 256 * 1024 * 1024
+)
+
+// scryptPassword is scrypt in the PHC string format used by passlib,
+// for example, $scrypt$ln=16,r=8,p=2$<salt>$<hash>
+type scryptPassword struct{}
+
+var _ passwordFormat = scryptPassword{}
+
+func (scryptPassword) ID() string {
+	return "scrypt"
+}
+
+func (p scryptPassword) Hash(password []byte) ([]byte, error) {
+	salt := generateSalt(scryptSaltLength)
+	key, err := scrypt.Key(password, salt, 1<<scryptLogN, scryptBlockSize, scryptParallelism, scryptKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	params := fmt.Sprintf("ln=%d,r=%d,p=%d", scryptLogN, scryptBlockSize, scryptParallelism)
+	return constructPHCFormat(p.ID(), "", params, salt, key), nil
+}
+
+func (p scryptPassword) Compare(password, hash []byte) error {
+	h, ln, r, par, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+
+	key, err := scrypt.Key(password, h.Salt, 1<<ln, r, par, len(h.Hash))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(key, h.Hash) != 1 {
+		return errMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (p scryptPassword) CheckHash(hash []byte) error {
+	_, _, _, _, err := p.parse(hash)
+	return err
+}
+
+func (p scryptPassword) parse(hash []byte) (h *phcHash, ln int, r int, par int, err error) {
+	h, err = parsePHCFormat(hash)
+	if err != nil {
+		return
+	}
+	if ln, err = h.IntParam("ln"); err != nil {
+		return
+	}
+	if err = checkMaxParam("ln", ln, scryptMaxLogN); err != nil {
+		return
+	}
+	if r, err = h.IntParam("r"); err != nil {
+		return
+	}
+	if err = checkMaxParam("r", r, scryptMaxBlockSize); err != nil {
+		return
+	}
+	if err = checkScryptMemory(ln, r); err != nil {
+		return
+	}
+	if par, err = h.IntParam("p"); err != nil {
+		return
+	}
+	if err = checkMaxParam("p", par, scryptMaxParallelism); err != nil {
+		return
+	}
+	err = checkKeyLength(h.Hash)
+	return
+}
+
+// checkScryptMemory returns an error if scrypt would use more than scryptMaxMemory.
+// ln and r must have been bounded.
+func checkScryptMemory(ln int, r int) error {
+	if 128*r*(1<<ln) > scryptMaxMemory {
+		return fmt.Errorf("%w: memory cost exceeds %d bytes", ErrInvalidPasswordFormat, scryptMaxMemory)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package password
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScrypt(t *testing.T) {
+	Convey("scrypt", t, func() {
+		scrypt := scryptPassword{}
+		Convey("should hash as expected", func() {
+			h, err := scrypt.Hash([]byte("password"))
+			So(err, ShouldBeNil)
+			So(string(h), ShouldStartWith, "$scrypt$ln=16,r=8,p=2$")
+			So(scrypt.Compare([]byte("password"), h), ShouldBeNil)
+		})
+		Convey("should compare as expected", func() {
+			h := []byte("$scrypt$ln=10,r=8,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")
+			So(scrypt.Compare([]byte("password"), h), ShouldBeNil)
+			So(scrypt.Compare([]byte("Password"), h), ShouldBeError)
+		})
+		Convey("should check existing hash", func() {
+			So(scrypt.CheckHash([]byte("$scrypt$ln=10,r=8,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeNil)
+			So(scrypt.CheckHash([]byte("$scrypt$ln=10,r=8$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeError, `invalid password format: missing parameter "p"`)
+		})
+		Convey("should reject excessive parameters", func() {
+			So(scrypt.CheckHash([]byte("$scrypt$ln=31,r=8,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeError, `invalid password format: parameter "ln" exceeds 20`)
+			So(scrypt.CheckHash([]byte("$scrypt$ln=10,r=1000,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeError, `invalid password format: parameter "r" exceeds 32`)
+			So(scrypt.CheckHash([]byte("$scrypt$ln=20,r=32,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeError, "invalid password format: memory cost exceeds 268435456 bytes")
+			So(scrypt.CheckHash([]byte("$scrypt$ln=10,r=8,p=1000$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeError, `invalid password format: parameter "p" exceeds 16`)
+			So(scrypt.Compare([]byte("password"), []byte("$scrypt$ln=31,r=8,p=1$c29tZXNhbHQ$wdXoWEig5T693O7BJbufEPRk+qarG40BYOh1xe9tMAc")), ShouldBeError, `invalid password format: parameter "ln" exceeds 20`)
+		})
+	})
+
+	Convey("Firebase scrypt", t, func() {
+		firebase := firebaseScryptPassword{}
+		// The example in https://github.com/firebase/scrypt
+		h := []byte("$firebase-scrypt$m=14,r=8,k=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==")
+		Convey("should compare as expected", func() {
+			So(firebase.Compare([]byte("user1password"), h), ShouldBeNil)
+			So(firebase.Compare([]byte("user2password"), h), ShouldBeError)
+		})
+		Convey("should check existing hash", func() {
+			So(firebase.CheckHash(h), ShouldBeNil)
+			So(firebase.CheckHash([]byte("$firebase-scrypt$m=14,r=8,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==")), ShouldBeError, `invalid password format: missing parameter "k"`)
+			So(firebase.CheckHash([]byte("$firebase-scrypt$m=30,r=8,k=jxspr8Ki0RYycVU8zykbdLGjFQ3McFUH0uiiTvC8pVMXAn210wjLNmdZJzxUECKbm0QsEmYUSDzZvpjeJ9WmXA==,s=Bw==$42xEC+ixf3L2lw==$lSrfV15cpx95/sZS2W9c9Kp6i/LVgQNDNC/qzrCnh1SAyZvqmZqAjTdn3aoItz+VHjoZilo78198JAdRuid5lQ==")), ShouldBeError, `invalid password format: parameter "m" exceeds 20`)
+		})
+		Convey("should not hash", func() {
+			_, err := firebase.Hash([]byte("password"))
+			So(err, ShouldBeError)
+		})
+	})
+}
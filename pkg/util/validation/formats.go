@@ -41,6 +41,8 @@ func init() {
 	jsonschemaformat.DefaultChecker["ldap_url"] = FormatLDAPURL{}
 	jsonschemaformat.DefaultChecker["ldap_dn"] = FormatLDAPDN{}
 	jsonschemaformat.DefaultChecker["ldap_search_filter_template"] = FormatLDAPSearchFilterTemplate{}
+	jsonschemaformat.DefaultChecker["ldap_group_search_filter_template"] = FormatLDAPGroupSearchFilterTemplate{}
+	jsonschemaformat.DefaultChecker["ldap_filter"] = FormatLDAPFilter{}
 	jsonschemaformat.DefaultChecker["ldap_attribute_name"] = FormatLDAPAttribute{}
 	jsonschemaformat.DefaultChecker["wechat_account_id"] = FormatWeChatAccountID{}
 	jsonschemaformat.DefaultChecker["bcp47"] = FormatBCP47{}
@@ -297,6 +299,49 @@ func (FormatLDAPSearchFilterTemplate) CheckFormat(ctx context.Context, value any
 	return nil
 }
 
+type FormatLDAPGroupSearchFilterTemplate struct{}
+
+func (FormatLDAPGroupSearchFilterTemplate) CheckFormat(ctx context.Context, value any) error {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	tmpl, err := texttemplate.New("group_search_filter").Parse(str)
+	if err != nil {
+		return errors.New("invalid template")
+	}
+	// check if the template can be execute with a valid DN
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, map[string]string{"DN": "cn=user,ou=people,dc=example,dc=com"})
+	if err != nil {
+		return err
+	}
+	filterString := strings.TrimSpace(buf.String())
+	_, err = ldap.CompileFilter(filterString)
+	if err != nil {
+		return errors.New("invalid search filter")
+	}
+
+	return nil
+}
+
+type FormatLDAPFilter struct{}
+
+func (FormatLDAPFilter) CheckFormat(ctx context.Context, value any) error {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	_, err := ldap.CompileFilter(strings.TrimSpace(str))
+	if err != nil {
+		return errors.New("invalid search filter")
+	}
+
+	return nil
+}
+
 type FormatLDAPAttribute struct{}
 
 func (FormatLDAPAttribute) CheckFormat(ctx context.Context, value any) error {
@@ -179,6 +179,30 @@ func TestFormatLDAPSearchFilterTemplate(t *testing.T) {
 	})
 }
 
+func TestFormatLDAPGroupSearchFilterTemplate(t *testing.T) {
+	Convey("FormatLDAPGroupSearchFilterTemplate", t, func() {
+		f := FormatLDAPGroupSearchFilterTemplate{}.CheckFormat
+
+		So(f(backgroundCtx(), 1), ShouldBeNil)
+		So(f(backgroundCtx(), "(&(objectClass=groupOfNames)(member={{.DN}}))"), ShouldBeNil)
+		So(f(backgroundCtx(), "(&(objectClass=groupOfNames)(member={{.DN}})"), ShouldBeError, "invalid search filter")
+		So(f(backgroundCtx(), "(member={{.DN}})"), ShouldBeNil)
+		So(f(backgroundCtx(), "(member={{if .DN}})"), ShouldBeError, "invalid template")
+	})
+}
+
+func TestFormatLDAPFilter(t *testing.T) {
+	Convey("FormatLDAPFilter", t, func() {
+		f := FormatLDAPFilter{}.CheckFormat
+
+		So(f(backgroundCtx(), 1), ShouldBeNil)
+		So(f(backgroundCtx(), "(objectClass=person)"), ShouldBeNil)
+		So(f(backgroundCtx(), "(&(objectClass=person)(!(userAccountControl:1.2.840.113556.1.4.803:=2)))"), ShouldBeNil)
+		So(f(backgroundCtx(), "(objectClass=person"), ShouldBeError, "invalid search filter")
+		So(f(backgroundCtx(), ""), ShouldBeError, "invalid search filter")
+	})
+}
+
 func TestFormatLDAPAttribute(t *testing.T) {
 	Convey("FormatLDAPAttribute", t, func() {
 		f := FormatLDAPAttribute{}.CheckFormat
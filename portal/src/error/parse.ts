@@ -180,6 +180,11 @@ function parsePasswordPolicyViolatedError(
           messageID: "errors.password-policy.containing-excluded-keywords",
         });
         break;
+      case "PasswordBreached":
+        errors.push({
+          messageID: "errors.password-policy.breached",
+        });
+        break;
       default:
         hasUnmatched = true;
         break;
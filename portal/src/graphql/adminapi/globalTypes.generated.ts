@@ -192,6 +192,7 @@ export enum AuditLogActivityType {
   AdminApiMutationDeleteScopeExecuted = 'ADMIN_API_MUTATION_DELETE_SCOPE_EXECUTED',
   AdminApiMutationDeleteUserExecuted = 'ADMIN_API_MUTATION_DELETE_USER_EXECUTED',
   AdminApiMutationGenerateOobOtpCodeExecuted = 'ADMIN_API_MUTATION_GENERATE_OOB_OTP_CODE_EXECUTED',
+  AdminApiMutationRedeliverWebhookDeliveryExecuted = 'ADMIN_API_MUTATION_REDELIVER_WEBHOOK_DELIVERY_EXECUTED',
   AdminApiMutationRemoveGroupFromRolesExecuted = 'ADMIN_API_MUTATION_REMOVE_GROUP_FROM_ROLES_EXECUTED',
   AdminApiMutationRemoveGroupFromUsersExecuted = 'ADMIN_API_MUTATION_REMOVE_GROUP_FROM_USERS_EXECUTED',
   AdminApiMutationRemoveResourceFromClientidExecuted = 'ADMIN_API_MUTATION_REMOVE_RESOURCE_FROM_CLIENTID_EXECUTED',
@@ -886,6 +887,7 @@ export enum IdentityType {
   LoginId = 'LOGIN_ID',
   Oauth = 'OAUTH',
   Passkey = 'PASSKEY',
+  Saml = 'SAML',
   Siwe = 'SIWE'
 }
 
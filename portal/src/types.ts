@@ -216,8 +216,16 @@ export interface PasswordPolicyConfig {
   excluded_keywords?: string[];
   history_size?: number;
   history_days?: number;
+  breached_password_check?: PasswordBreachedPasswordCheckConfig;
 }
 
+export interface PasswordBreachedPasswordCheckConfig {
+  enabled?: boolean;
+  fail_mode?: BreachedPasswordCheckFailMode;
+}
+
+export type BreachedPasswordCheckFailMode = "open" | "closed";
+
 export interface PasswordExpiryConfig {
   force_change?: PasswordExpiryForceChangeConfig;
 }
@@ -622,8 +630,12 @@ export interface SessionConfig {
   idle_timeout_seconds?: DurationSeconds;
   lifetime_seconds?: DurationSeconds;
   use_session_cookie?: boolean;
+  max_concurrent_sessions?: number;
+  concurrent_session_limit_policy?: ConcurrentSessionLimitPolicy;
 }
 
+export type ConcurrentSessionLimitPolicy = "evict_oldest" | "reject";
+
 export interface HookConfig {
   sync_hook_timeout_seconds?: number;
   sync_hook_total_timeout_seconds?: number;
@@ -996,6 +1008,7 @@ export interface PasswordPolicyFeatureConfig {
   minimum_guessable_level?: PasswordPolicyItemFeatureConfig;
   excluded_keywords?: PasswordPolicyItemFeatureConfig;
   history?: PasswordPolicyItemFeatureConfig;
+  breached_password_check?: PasswordPolicyItemFeatureConfig;
 }
 
 export interface PasswordPolicyItemFeatureConfig {